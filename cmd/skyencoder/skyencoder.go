@@ -0,0 +1,105 @@
+/*
+skyencoder generates the encoders of a package from its skyencoder.json schema file
+
+See src/cipher/encoder/skyencoder for the schema format.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
+)
+
+var help = fmt.Sprintf(`skyencoder generates the binary encoders of a package from its %s schema file.
+
+The schema describes the encoded types of the package, and lists the types to generate encoders for.
+By default, the Go encoders, their tests and a file of test vectors (%s)
+are written to the package directory. Generated encoder files that are no longer described
+by the schema are removed.
+
+With -check, the generated files and the Go type declarations of the package are verified
+against the schema, and nothing is written.
+
+Run it from a package directory with go generate:
+
+	//go:generate go run github.com/skycoin/skycoin/cmd/skyencoder`, skyencoder.SchemaFilename, skyencoder.VectorsFilename)
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "%s\n\nUsage of %s:\n", help, os.Args[0])
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	dir := flag.String("dir", ".", "package directory")
+	check := flag.Bool("check", false, "verify the generated files instead of writing them")
+	generators := flag.String("generators", "", "comma separated list of generators to run, default all")
+
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("skyencoder: ")
+
+	gens, err := selectGenerators(*generators)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	loader, err := skyencoder.NewLoader(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p, err := loader.Load(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := p.CheckSource(); err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := skyencoder.Generate(p, gens)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *check {
+		err = skyencoder.CheckFiles(p, files)
+	} else {
+		err = skyencoder.WriteFiles(p, files)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func selectGenerators(names string) ([]skyencoder.Generator, error) {
+	all := skyencoder.DefaultGenerators()
+	if names == "" {
+		return all, nil
+	}
+
+	var gens []skyencoder.Generator
+	for _, name := range strings.Split(names, ",") {
+		found := false
+		for _, g := range all {
+			if g.Name() == strings.TrimSpace(name) {
+				gens = append(gens, g)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown generator %q", name)
+		}
+	}
+
+	return gens, nil
+}
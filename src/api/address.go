@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/cipher/bech32"
+	"github.com/skycoin/skycoin/src/params"
 )
 
 // VerifyAddressRequest is the request data for POST /api/v2/address/verify
@@ -14,10 +16,19 @@ type VerifyAddressRequest struct {
 
 // VerifyAddressResponse is returned by POST /api/v2/address/verify
 type VerifyAddressResponse struct {
-	Version byte `json:"version"`
+	Version byte   `json:"version"`
+	Base58  string `json:"base58"`
+	Bech32  string `json:"bech32"`
 }
 
-// addressVerifyHandler verifies a Skycoin address
+// VerifyAddressErrorResponse is returned by POST /api/v2/address/verify with the error
+// of an invalid bech32 address, if the wrong characters can be located
+type VerifyAddressErrorResponse struct {
+	// ErrorPositions are the 0-based positions of the characters of the address that are likely wrong
+	ErrorPositions []int `json:"error_positions"`
+}
+
+// addressVerifyHandler verifies a Skycoin address, in base58 or bech32 format
 // Method: POST
 // URI: /api/v2/address/verify
 func addressVerifyHandler(w http.ResponseWriter, r *http.Request) {
@@ -40,10 +51,24 @@ func addressVerifyHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	addr, err := cipher.DecodeBase58Address(req.Address)
+	addr, err := cipher.DecodeAddress(params.AddressHRP, req.Address)
 
 	if err != nil {
 		resp := NewHTTPErrorResponse(http.StatusUnprocessableEntity, err.Error())
+		if cipher.HasBech32Prefix(params.AddressHRP, req.Address) {
+			if positions := bech32.LocateErrors(req.Address); len(positions) != 0 {
+				resp.Data = VerifyAddressErrorResponse{
+					ErrorPositions: positions,
+				}
+			}
+		}
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	bech32Addr, err := addr.Bech32(params.AddressHRP)
+	if err != nil {
+		resp := NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
 		writeHTTPResponse(w, resp)
 		return
 	}
@@ -51,6 +76,8 @@ func addressVerifyHandler(w http.ResponseWriter, r *http.Request) {
 	writeHTTPResponse(w, HTTPResponse{
 		Data: VerifyAddressResponse{
 			Version: addr.Version,
+			Base58:  addr.String(),
+			Bech32:  bech32Addr,
 		},
 	})
 }
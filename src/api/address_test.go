@@ -67,6 +67,64 @@ func TestVerifyAddress(t *testing.T) {
 			}),
 			httpResponse: NewHTTPErrorResponse(http.StatusUnprocessableEntity, "Invalid checksum"),
 		},
+		{
+			name:   "422 - Invalid bech32 checksum",
+			method: http.MethodPost,
+			status: http.StatusUnprocessableEntity,
+			httpBody: toJSON(t, VerifyAddressRequest{
+				Address: "sky1qzpez7qs65f3rs8wzx5vn6slvavgjxu7rdx22vk",
+			}),
+			httpResponse: HTTPResponse{
+				Error: &HTTPError{
+					Code:    http.StatusUnprocessableEntity,
+					Message: "Invalid bech32 checksum",
+				},
+				Data: VerifyAddressErrorResponse{
+					ErrorPositions: []int{10, 20},
+				},
+			},
+		},
+		{
+			name:   "422 - Invalid bech32 character",
+			method: http.MethodPost,
+			status: http.StatusUnprocessableEntity,
+			httpBody: toJSON(t, VerifyAddressRequest{
+				Address: "sky1qzpez7ps65f3rs8wwx5vn6slvavgjxu7rdx2bvk",
+			}),
+			httpResponse: HTTPResponse{
+				Error: &HTTPError{
+					Code:    http.StatusUnprocessableEntity,
+					Message: "Invalid bech32 character",
+				},
+				Data: VerifyAddressErrorResponse{
+					ErrorPositions: []int{40},
+				},
+			},
+		},
+		{
+			name:   "422 - Invalid bech32 human-readable part",
+			method: http.MethodPost,
+			status: http.StatusUnprocessableEntity,
+			httpBody: toJSON(t, VerifyAddressRequest{
+				Address: "tst1qzpez7ps65f3rs8wwx5vn6slvavgjxu7rdx22vk",
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusUnprocessableEntity, "Invalid base58 character"),
+		},
+		{
+			name:   "200 - bech32",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			httpBody: toJSON(t, VerifyAddressRequest{
+				Address: "SKY1QZPEZ7PS65F3RS8WWX5VN6SLVAVGJXU7RDX22VK",
+			}),
+			httpResponse: HTTPResponse{
+				Data: VerifyAddressResponse{
+					Version: 0,
+					Base58:  "7cpQ7t3PZZXvjTst8G7Uvs7XH4LeM8fBPD",
+					Bech32:  "sky1qzpez7ps65f3rs8wwx5vn6slvavgjxu7rdx22vk",
+				},
+			},
+		},
 		{
 			name:   "200",
 			method: http.MethodPost,
@@ -77,6 +135,8 @@ func TestVerifyAddress(t *testing.T) {
 			httpResponse: HTTPResponse{
 				Data: VerifyAddressResponse{
 					Version: 0,
+					Base58:  "7cpQ7t3PZZXvjTst8G7Uvs7XH4LeM8fBPD",
+					Bech32:  "sky1qzpez7ps65f3rs8wwx5vn6slvavgjxu7rdx22vk",
 				},
 			},
 		},
@@ -90,6 +150,8 @@ func TestVerifyAddress(t *testing.T) {
 			httpResponse: HTTPResponse{
 				Data: VerifyAddressResponse{
 					Version: 0,
+					Base58:  "7cpQ7t3PZZXvjTst8G7Uvs7XH4LeM8fBPD",
+					Bech32:  "sky1qzpez7ps65f3rs8wwx5vn6slvavgjxu7rdx22vk",
 				},
 			},
 			csrfDisabled: true,
@@ -137,11 +199,20 @@ func TestVerifyAddress(t *testing.T) {
 			} else {
 				require.NotNil(t, tc.httpResponse.Data)
 
-				var addrRsp VerifyAddressResponse
-				err := json.Unmarshal(rsp.Data, &addrRsp)
-				require.NoError(t, err)
-
-				require.Equal(t, tc.httpResponse.Data.(VerifyAddressResponse), addrRsp)
+				switch data := tc.httpResponse.Data.(type) {
+				case VerifyAddressResponse:
+					var addrRsp VerifyAddressResponse
+					err := json.Unmarshal(rsp.Data, &addrRsp)
+					require.NoError(t, err)
+					require.Equal(t, data, addrRsp)
+				case VerifyAddressErrorResponse:
+					var errRsp VerifyAddressErrorResponse
+					err := json.Unmarshal(rsp.Data, &errRsp)
+					require.NoError(t, err)
+					require.Equal(t, data, errRsp)
+				default:
+					t.Fatalf("unexpected response data type %T", data)
+				}
 			}
 		})
 	}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/skycoin/skycoin/src/readable"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+// BalanceAtResponse is returned by GET /api/v2/balance
+type BalanceAtResponse struct {
+	BlockSeq  uint64                      `json:"block_seq"`
+	BlockHash string                      `json:"block_hash"`
+	BlockTime uint64                      `json:"block_time"`
+	Balance   readable.Balance            `json:"balance"`
+	Addresses map[string]readable.Balance `json:"addresses"`
+}
+
+// RichlistAtResponse is returned by GET /api/v2/richlist
+type RichlistAtResponse struct {
+	BlockSeq  uint64                     `json:"block_seq"`
+	BlockHash string                     `json:"block_hash"`
+	BlockTime uint64                     `json:"block_time"`
+	Richlist  []readable.RichlistBalance `json:"richlist"`
+}
+
+// balanceHandlerV2 returns the confirmed balance of addresses at a block, rebuilt from the blockchain history.
+// The coin hours are the coin hours of the unspent outputs at the time of the block.
+// Method: GET
+// URI: /api/v2/balance
+// Args:
+//	addrs [string] comma separated list of addresses [required]
+//	seq [int] block seq [optional, default to the head block]
+func balanceHandlerV2(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError405Response(w)
+			return
+		}
+
+		addrs, err := parseAddressesFromStr(r.FormValue("addrs"))
+		if err != nil {
+			writeError400Response(w, err.Error())
+			return
+		}
+
+		if len(addrs) == 0 {
+			writeError400Response(w, "addrs is required")
+			return
+		}
+
+		seq, ok := parseBlockSeqParam(w, r, gateway)
+		if !ok {
+			return
+		}
+
+		bals, b, err := gateway.GetBalancesAt(addrs, seq)
+		if err != nil {
+			writeBlockSeqError(w, err)
+			return
+		}
+
+		var total wallet.Balance
+		addressBalances := make(map[string]readable.Balance, len(addrs))
+		for i, addr := range addrs {
+			addressBalances[addr.String()] = readable.NewBalance(bals[i])
+
+			total, err = total.Add(bals[i])
+			if err != nil {
+				writeError500Response(w, err.Error())
+				return
+			}
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: BalanceAtResponse{
+				BlockSeq:  b.Seq(),
+				BlockHash: b.HashHeader().Hex(),
+				BlockTime: b.Time(),
+				Balance:   readable.NewBalance(total),
+				Addresses: addressBalances,
+			},
+		})
+	}
+}
+
+// richlistHandlerV2 returns the top holders at a block, rebuilt from the blockchain history.
+// Method: GET
+// URI: /api/v2/richlist
+// Args:
+//	seq [int] block seq [optional, default to the head block]
+//	n [int] number of addresses [optional, default to 20, 0 returns all addresses]
+//	include-distribution [bool] include the distribution addresses [optional]
+func richlistHandlerV2(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError405Response(w)
+			return
+		}
+
+		topn := 20
+		if nStr := r.FormValue("n"); nStr != "" {
+			var err error
+			topn, err = strconv.Atoi(nStr)
+			if err != nil || topn < 0 {
+				writeError400Response(w, "invalid n")
+				return
+			}
+		}
+
+		includeDistribution, err := parseBoolFlag(r.FormValue("include-distribution"))
+		if err != nil {
+			writeError400Response(w, "invalid include-distribution")
+			return
+		}
+
+		seq, ok := parseBlockSeqParam(w, r, gateway)
+		if !ok {
+			return
+		}
+
+		richlist, b, err := gateway.GetRichlistAt(seq, includeDistribution)
+		if err != nil {
+			writeBlockSeqError(w, err)
+			return
+		}
+
+		if topn > 0 && topn < len(richlist) {
+			richlist = richlist[:topn]
+		}
+
+		readableRichlist, err := readable.NewRichlistBalances(richlist)
+		if err != nil {
+			writeError500Response(w, err.Error())
+			return
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: RichlistAtResponse{
+				BlockSeq:  b.Seq(),
+				BlockHash: b.HashHeader().Hex(),
+				BlockTime: b.Time(),
+				Richlist:  readableRichlist,
+			},
+		})
+	}
+}
+
+// parseBlockSeqParam parses the seq param, which defaults to the head block seq.
+// Writes an error response and returns false if it fails.
+func parseBlockSeqParam(w http.ResponseWriter, r *http.Request, gateway Gatewayer) (uint64, bool) {
+	if seqStr := r.FormValue("seq"); seqStr != "" {
+		seq, err := strconv.ParseUint(seqStr, 10, 64)
+		if err != nil {
+			writeError400Response(w, fmt.Sprintf("invalid 'seq' value: %v", err))
+			return 0, false
+		}
+		return seq, true
+	}
+
+	seq, ok, err := gateway.HeadBkSeq()
+	if err != nil {
+		writeError500Response(w, err.Error())
+		return 0, false
+	}
+
+	if !ok {
+		writeHTTPResponse(w, NewHTTPErrorResponse(http.StatusNotFound, "blockchain is empty"))
+		return 0, false
+	}
+
+	return seq, true
+}
+
+func writeBlockSeqError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case visor.ErrBlockNotExist:
+		writeHTTPResponse(w, NewHTTPErrorResponse(http.StatusNotFound, err.Error()))
+	default:
+		writeError500Response(w, err.Error())
+	}
+}
@@ -0,0 +1,373 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/readable"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+func makeHistoryTestBlock(seq uint64) *coin.SignedBlock {
+	return &coin.SignedBlock{
+		Block: coin.Block{
+			Head: coin.BlockHeader{
+				BkSeq: seq,
+				Time:  1500000000 + seq,
+			},
+		},
+	}
+}
+
+func TestBalanceV2(t *testing.T) {
+	addr1 := testutil.MakeAddress()
+	addr2 := testutil.MakeAddress()
+	addrs := addr1.String() + "," + addr2.String()
+
+	b := makeHistoryTestBlock(7)
+	head := makeHistoryTestBlock(12)
+
+	balances := []wallet.Balance{
+		{
+			Coins: 1e6,
+			Hours: 10,
+		},
+		{
+			Coins: 2e6,
+			Hours: 5,
+		},
+	}
+
+	cases := []struct {
+		name            string
+		method          string
+		status          int
+		addrs           string
+		seq             string
+		gatewaySeq      uint64
+		gatewayBalances []wallet.Balance
+		gatewayBlock    *coin.SignedBlock
+		gatewayErr      error
+		headSeq         uint64
+		headSeqOk       bool
+		httpResponse    HTTPResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodPost,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "addrs missing",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "addrs is required"),
+		},
+		{
+			name:         "invalid address",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			addrs:        "foo",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "address \"foo\" is invalid: Invalid address length"),
+		},
+		{
+			name:         "invalid seq",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			addrs:        addrs,
+			seq:          "-1",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid 'seq' value: strconv.ParseUint: parsing \"-1\": invalid syntax"),
+		},
+		{
+			name:         "block not found",
+			method:       http.MethodGet,
+			status:       http.StatusNotFound,
+			addrs:        addrs,
+			seq:          "100",
+			gatewaySeq:   100,
+			gatewayErr:   visor.NewErrBlockNotExist(100),
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, "block does not exist seq=100"),
+		},
+		{
+			name:         "gateway error",
+			method:       http.MethodGet,
+			status:       http.StatusInternalServerError,
+			addrs:        addrs,
+			seq:          "7",
+			gatewaySeq:   7,
+			gatewayErr:   errors.New("db error"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "db error"),
+		},
+		{
+			name:         "empty blockchain",
+			method:       http.MethodGet,
+			status:       http.StatusNotFound,
+			addrs:        addrs,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, "blockchain is empty"),
+		},
+		{
+			name:            "ok",
+			method:          http.MethodGet,
+			status:          http.StatusOK,
+			addrs:           addrs,
+			seq:             "7",
+			gatewaySeq:      7,
+			gatewayBalances: balances,
+			gatewayBlock:    b,
+			httpResponse: HTTPResponse{
+				Data: BalanceAtResponse{
+					BlockSeq:  7,
+					BlockHash: b.HashHeader().Hex(),
+					BlockTime: b.Time(),
+					Balance: readable.Balance{
+						Coins: 3e6,
+						Hours: 15,
+					},
+					Addresses: map[string]readable.Balance{
+						addr1.String(): {
+							Coins: 1e6,
+							Hours: 10,
+						},
+						addr2.String(): {
+							Coins: 2e6,
+							Hours: 5,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:            "ok head block",
+			method:          http.MethodGet,
+			status:          http.StatusOK,
+			addrs:           addr1.String(),
+			headSeq:         12,
+			headSeqOk:       true,
+			gatewaySeq:      12,
+			gatewayBalances: balances[:1],
+			gatewayBlock:    head,
+			httpResponse: HTTPResponse{
+				Data: BalanceAtResponse{
+					BlockSeq:  12,
+					BlockHash: head.HashHeader().Hex(),
+					BlockTime: head.Time(),
+					Balance: readable.Balance{
+						Coins: 1e6,
+						Hours: 10,
+					},
+					Addresses: map[string]readable.Balance{
+						addr1.String(): {
+							Coins: 1e6,
+							Hours: 10,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("HeadBkSeq").Return(tc.headSeq, tc.headSeqOk, nil)
+
+			var gatewayAddrs []cipher.Address
+			if tc.addrs != "" {
+				var err error
+				gatewayAddrs, err = parseAddressesFromStr(tc.addrs)
+				if err == nil {
+					gateway.On("GetBalancesAt", gatewayAddrs, tc.gatewaySeq).Return(tc.gatewayBalances, tc.gatewayBlock, tc.gatewayErr)
+				}
+			}
+
+			v := url.Values{}
+			if tc.addrs != "" {
+				v.Add("addrs", tc.addrs)
+			}
+			if tc.seq != "" {
+				v.Add("seq", tc.seq)
+			}
+
+			req, err := http.NewRequest(tc.method, "/api/v2/balance?"+v.Encode(), nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+				return
+			}
+
+			var balanceRsp BalanceAtResponse
+			err = json.Unmarshal(rsp.Data, &balanceRsp)
+			require.NoError(t, err)
+			require.Equal(t, tc.httpResponse.Data, balanceRsp)
+		})
+	}
+}
+
+func TestRichlistV2(t *testing.T) {
+	b := makeHistoryTestBlock(7)
+
+	richlist := visor.Richlist{
+		{
+			Address: testutil.MakeAddress(),
+			Coins:   3e6,
+			Locked:  true,
+		},
+		{
+			Address: testutil.MakeAddress(),
+			Coins:   2e6,
+		},
+		{
+			Address: testutil.MakeAddress(),
+			Coins:   1e6,
+		},
+	}
+
+	readableRichlist, err := readable.NewRichlistBalances(richlist)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name                string
+		method              string
+		status              int
+		query               string
+		gatewaySeq          uint64
+		includeDistribution bool
+		gatewayRichlist     visor.Richlist
+		gatewayBlock        *coin.SignedBlock
+		gatewayErr          error
+		httpResponse        HTTPResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodPost,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "invalid n",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			query:        "?n=-1",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid n"),
+		},
+		{
+			name:         "invalid include-distribution",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			query:        "?include-distribution=foo",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid include-distribution"),
+		},
+		{
+			name:         "invalid seq",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			query:        "?seq=foo",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid 'seq' value: strconv.ParseUint: parsing \"foo\": invalid syntax"),
+		},
+		{
+			name:         "block not found",
+			method:       http.MethodGet,
+			status:       http.StatusNotFound,
+			query:        "?seq=100",
+			gatewaySeq:   100,
+			gatewayErr:   visor.NewErrBlockNotExist(100),
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, "block does not exist seq=100"),
+		},
+		{
+			name:                "ok",
+			method:              http.MethodGet,
+			status:              http.StatusOK,
+			query:               "?seq=7&include-distribution=true",
+			gatewaySeq:          7,
+			includeDistribution: true,
+			gatewayRichlist:     richlist,
+			gatewayBlock:        b,
+			httpResponse: HTTPResponse{
+				Data: RichlistAtResponse{
+					BlockSeq:  7,
+					BlockHash: b.HashHeader().Hex(),
+					BlockTime: b.Time(),
+					Richlist:  readableRichlist,
+				},
+			},
+		},
+		{
+			name:            "ok top n, head block",
+			method:          http.MethodGet,
+			status:          http.StatusOK,
+			query:           "?n=2",
+			gatewaySeq:      7,
+			gatewayRichlist: richlist,
+			gatewayBlock:    b,
+			httpResponse: HTTPResponse{
+				Data: RichlistAtResponse{
+					BlockSeq:  7,
+					BlockHash: b.HashHeader().Hex(),
+					BlockTime: b.Time(),
+					Richlist:  readableRichlist[:2],
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("HeadBkSeq").Return(uint64(7), true, nil)
+			gateway.On("GetRichlistAt", tc.gatewaySeq, tc.includeDistribution).Return(tc.gatewayRichlist, tc.gatewayBlock, tc.gatewayErr)
+
+			req, err := http.NewRequest(tc.method, "/api/v2/richlist"+tc.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+				return
+			}
+
+			var richlistRsp RichlistAtResponse
+			err = json.Unmarshal(rsp.Data, &richlistRsp)
+			require.NoError(t, err)
+			require.Equal(t, tc.httpResponse.Data, richlistRsp)
+		})
+	}
+}
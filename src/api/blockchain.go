@@ -393,3 +393,84 @@ func lastBlocksHandler(gateway Gatewayer) http.HandlerFunc {
 		wh.SendJSONOr500(logger, w, rb)
 	}
 }
+
+// BlocksPageResponse is returned by GET /api/v2/blocks
+type BlocksPageResponse struct {
+	CursorInfo readable.CursorInfo `json:"cursor_info"`
+	Blocks     []readable.Block    `json:"blocks"`
+}
+
+// BlocksPageVerboseResponse is returned by GET /api/v2/blocks?verbose=1
+type BlocksPageVerboseResponse struct {
+	CursorInfo readable.CursorInfo     `json:"cursor_info"`
+	Blocks     []readable.BlockVerbose `json:"blocks"`
+}
+
+// blocksHandlerV2 returns a page of blocks. Pages are requested with the cursor of the previous page,
+// and are not affected by the blocks added since then.
+// Method: GET
+// URI: /api/v2/blocks
+// Args:
+//	cursor [string] cursor returned with the previous page, empty for the first page
+//	limit [int] number of blocks per page [optional, default to 10, must be <= 100]
+//	sort [string] "asc" or "desc" [optional, default to "asc"]
+//	verbose [bool]
+func blocksHandlerV2(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError405Response(w)
+			return
+		}
+
+		verbose, err := parseBoolFlag(r.FormValue("verbose"))
+		if err != nil {
+			writeError400Response(w, "Invalid value for verbose")
+			return
+		}
+
+		cursor, limit, order, err := parseCursorPageParams(r)
+		if err != nil {
+			writeError400Response(w, err.Error())
+			return
+		}
+
+		var resp HTTPResponse
+		if verbose {
+			blocks, inputs, next, err := gateway.GetBlocksPageVerbose(cursor, order, limit)
+			if err != nil {
+				writeError500Response(w, err.Error())
+				return
+			}
+
+			rb, err := readable.NewBlocksVerbose(blocks, inputs)
+			if err != nil {
+				writeError500Response(w, err.Error())
+				return
+			}
+
+			resp.Data = BlocksPageVerboseResponse{
+				CursorInfo: readable.NewCursorInfo(limit, next),
+				Blocks:     rb.Blocks,
+			}
+		} else {
+			blocks, next, err := gateway.GetBlocksPage(cursor, order, limit)
+			if err != nil {
+				writeError500Response(w, err.Error())
+				return
+			}
+
+			rb, err := readable.NewBlocks(blocks)
+			if err != nil {
+				writeError500Response(w, err.Error())
+				return
+			}
+
+			resp.Data = BlocksPageResponse{
+				CursorInfo: readable.NewCursorInfo(limit, next),
+				Blocks:     rb.Blocks,
+			}
+		}
+
+		writeHTTPResponse(w, resp)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/skycoin/skycoin/src/readable"
 	"github.com/skycoin/skycoin/src/testutil"
 	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/historydb"
 )
 
 func TestGetBlockchainMetadata(t *testing.T) {
@@ -1220,3 +1221,154 @@ func TestGetLastBlocks(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlocksV2(t *testing.T) {
+	blocks := []coin.SignedBlock{
+		{Block: coin.Block{Head: coin.BlockHeader{BkSeq: 5}}},
+		{Block: coin.Block{Head: coin.BlockHeader{BkSeq: 6}}},
+	}
+	inputs := [][][]visor.TransactionInput{{}, {}}
+
+	rBlocks, err := readable.NewBlocks(blocks)
+	require.NoError(t, err)
+	rBlocksVerbose, err := readable.NewBlocksVerbose(blocks, inputs)
+	require.NoError(t, err)
+
+	cursor := &visor.Cursor{
+		Position: historydb.Position{
+			BlockSeq: 4,
+		},
+	}
+	next := &visor.Cursor{
+		Position: historydb.Position{
+			BlockSeq: 6,
+		},
+	}
+
+	tt := []struct {
+		name          string
+		method        string
+		args          url.Values
+		status        int
+		err           string
+		verbose       bool
+		gatewayCursor *visor.Cursor
+		gatewayOrder  visor.SortOrder
+		gatewayLimit  uint64
+		gatewayNext   *visor.Cursor
+		gatewayErr    error
+		response      interface{}
+	}{
+		{
+			name:   "405",
+			method: http.MethodPost,
+			status: http.StatusMethodNotAllowed,
+			err:    "Method Not Allowed",
+		},
+		{
+			name:   "400 - bad verbose",
+			method: http.MethodGet,
+			args:   url.Values{"verbose": {"foo"}},
+			status: http.StatusBadRequest,
+			err:    "Invalid value for verbose",
+		},
+		{
+			name:   "400 - invalid cursor",
+			method: http.MethodGet,
+			args:   url.Values{"cursor": {"foo"}},
+			status: http.StatusBadRequest,
+			err:    "invalid 'cursor' value: invalid cursor",
+		},
+		{
+			name:   "400 - limit too large",
+			method: http.MethodGet,
+			args:   url.Values{"limit": {"1000"}},
+			status: http.StatusBadRequest,
+			err:    "transaction page size must be not greater than 100",
+		},
+		{
+			name:         "500 - gateway error",
+			method:       http.MethodGet,
+			status:       http.StatusInternalServerError,
+			err:          "gateway error",
+			gatewayOrder: visor.AscOrder,
+			gatewayLimit: visor.DefaultTxnPageSize,
+			gatewayErr:   errors.New("gateway error"),
+		},
+		{
+			name:          "200",
+			method:        http.MethodGet,
+			args:          url.Values{"cursor": {cursor.String()}, "limit": {"2"}},
+			status:        http.StatusOK,
+			gatewayCursor: cursor,
+			gatewayOrder:  visor.AscOrder,
+			gatewayLimit:  2,
+			gatewayNext:   next,
+			response: &BlocksPageResponse{
+				CursorInfo: readable.CursorInfo{Limit: 2, NextCursor: next.String()},
+				Blocks:     rBlocks.Blocks,
+			},
+		},
+		{
+			name:         "200 verbose desc",
+			method:       http.MethodGet,
+			args:         url.Values{"verbose": {"1"}, "sort": {"desc"}},
+			status:       http.StatusOK,
+			verbose:      true,
+			gatewayOrder: visor.DescOrder,
+			gatewayLimit: visor.DefaultTxnPageSize,
+			response: &BlocksPageVerboseResponse{
+				CursorInfo: readable.CursorInfo{Limit: 10},
+				Blocks:     rBlocksVerbose.Blocks,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			if tc.gatewayErr != nil {
+				gateway.On("GetBlocksPage", tc.gatewayCursor, tc.gatewayOrder, tc.gatewayLimit).Return(nil, nil, tc.gatewayErr)
+			} else {
+				gateway.On("GetBlocksPage", tc.gatewayCursor, tc.gatewayOrder, tc.gatewayLimit).Return(blocks, tc.gatewayNext, nil)
+				gateway.On("GetBlocksPageVerbose", tc.gatewayCursor, tc.gatewayOrder, tc.gatewayLimit).Return(blocks, inputs, tc.gatewayNext, nil)
+			}
+
+			endpoint := "/api/v2/blocks"
+			if len(tc.args) > 0 {
+				endpoint += "?" + tc.args.Encode()
+			}
+
+			req, err := http.NewRequest(tc.method, endpoint, nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var rsp ReceivedHTTPResponse
+			err = json.NewDecoder(rr.Body).Decode(&rsp)
+			require.NoError(t, err)
+
+			if rr.Code != http.StatusOK {
+				require.Equal(t, tc.err, rsp.Error.Message)
+				return
+			}
+
+			if tc.verbose {
+				var page BlocksPageVerboseResponse
+				err = json.Unmarshal(rsp.Data, &page)
+				require.NoError(t, err)
+				require.Equal(t, tc.response, &page)
+			} else {
+				var page BlocksPageResponse
+				err = json.Unmarshal(rsp.Data, &page)
+				require.NoError(t, err)
+				require.Equal(t, tc.response, &page)
+			}
+		})
+	}
+}
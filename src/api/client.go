@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -63,6 +64,7 @@ type Client struct {
 	Addr       string
 	Username   string
 	Password   string
+	APIToken   string
 }
 
 // NewClient creates a Client
@@ -92,7 +94,17 @@ func (c *Client) SetAuth(username, password string) {
 	c.Password = password
 }
 
+// SetAPIToken configures the Client to authenticate requests with an API token, instead of basic auth
+func (c *Client) SetAPIToken(token string) {
+	c.APIToken = token
+}
+
 func (c *Client) applyAuth(req *http.Request) {
+	if c.APIToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIToken)
+		return
+	}
+
 	if c.Username == "" && c.Password == "" {
 		return
 	}
@@ -588,6 +600,20 @@ func (c *Client) Balance(addrs []string) (*BalanceResponse, error) {
 	return &b, nil
 }
 
+// BalanceAt makes a request to GET /api/v2/balance?addrs=xxx&seq=xxx
+func (c *Client) BalanceAt(addrs []string, seq uint64) (*BalanceAtResponse, error) {
+	v := url.Values{}
+	v.Add("addrs", strings.Join(addrs, ","))
+	v.Add("seq", fmt.Sprint(seq))
+	endpoint := "/api/v2/balance?" + v.Encode()
+
+	var b BalanceAtResponse
+	if _, err := c.GetV2(endpoint, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
 // UxOut makes a request to GET /api/v1/uxout?uxid=xxx
 func (c *Client) UxOut(uxID string) (*readable.SpentOutput, error) {
 	v := url.Values{}
@@ -647,6 +673,7 @@ type CreateWalletOptions struct {
 	Password       string
 	ScanN          uint64
 	XPub           string
+	Descriptor     string
 	Encrypt        bool
 	Bip44Coin      *bip44.CoinType
 }
@@ -680,6 +707,10 @@ func (c *Client) CreateWallet(o CreateWalletOptions) (*WalletResponse, error) {
 		v.Add("xpub", o.XPub)
 	}
 
+	if o.Descriptor != "" {
+		v.Add("descriptor", o.Descriptor)
+	}
+
 	var w WalletResponse
 	if err := c.PostForm("/api/v1/wallet/create", strings.NewReader(v.Encode()), &w); err != nil {
 		return nil, err
@@ -744,6 +775,7 @@ type CreateTransactionRequest struct {
 	IgnoreUnconfirmed bool           `json:"ignore_unconfirmed"`
 	HoursSelection    HoursSelection `json:"hours_selection"`
 	ChangeAddress     *string        `json:"change_address,omitempty"`
+	ChooseStrategy    string         `json:"choose_strategy,omitempty"`
 	To                []Receiver     `json:"to"`
 	UxOuts            []string       `json:"unspents,omitempty"`
 	Addresses         []string       `json:"addresses,omitempty"`
@@ -1202,6 +1234,28 @@ func (c *Client) VerifyAddress(addr string) (*VerifyAddressResponse, error) {
 	return nil, err
 }
 
+// SignMessage makes a request to POST /api/v2/message/sign
+func (c *Client) SignMessage(req MessageSignRequest) (*MessageSignResponse, error) {
+	var rsp MessageSignResponse
+	ok, err := c.PostJSONV2("/api/v2/message/sign", req, &rsp)
+	if ok {
+		return &rsp, err
+	}
+
+	return nil, err
+}
+
+// VerifyMessage makes a request to POST /api/v2/message/verify
+func (c *Client) VerifyMessage(req MessageVerifyRequest) (*MessageVerifyResponse, error) {
+	var rsp MessageVerifyResponse
+	ok, err := c.PostJSONV2("/api/v2/message/verify", req, &rsp)
+	if ok {
+		return &rsp, err
+	}
+
+	return nil, err
+}
+
 // RichlistParams are arguments to the /richlist endpoint
 type RichlistParams struct {
 	N                   int
@@ -1226,6 +1280,23 @@ func (c *Client) Richlist(params *RichlistParams) (*Richlist, error) {
 	return &r, nil
 }
 
+// RichlistAt makes a request to GET /api/v2/richlist?seq=xxx
+func (c *Client) RichlistAt(seq uint64, params *RichlistParams) (*RichlistAtResponse, error) {
+	v := url.Values{}
+	v.Add("seq", fmt.Sprint(seq))
+	if params != nil {
+		v.Add("n", fmt.Sprint(params.N))
+		v.Add("include-distribution", fmt.Sprint(params.IncludeDistribution))
+	}
+	endpoint := "/api/v2/richlist?" + v.Encode()
+
+	var r RichlistAtResponse
+	if _, err := c.GetV2(endpoint, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
 // AddressCount makes a request to GET /api/v1/addresscount
 func (c *Client) AddressCount() (uint64, error) {
 	var r struct {
@@ -1238,6 +1309,22 @@ func (c *Client) AddressCount() (uint64, error) {
 
 }
 
+// Search makes a request to GET /api/v2/search
+func (c *Client) Search(query string, limit int) (*SearchResponse, error) {
+	v := url.Values{}
+	v.Add("q", query)
+	v.Add("limit", strconv.Itoa(limit))
+	endpoint := "/api/v2/search?" + v.Encode()
+
+	var rsp SearchResponse
+	ok, err := c.GetV2(endpoint, &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return &rsp, err
+}
+
 // UnloadWallet makes a request to POST /api/v1/wallet/unload
 func (c *Client) UnloadWallet(id string) error {
 	v := url.Values{}
@@ -1294,6 +1381,165 @@ func (c *Client) RecoverWallet(req WalletRecoverRequest) (*WalletResponse, error
 	return nil, err
 }
 
+// WalletDescriptor makes a request to POST /api/v2/wallet/descriptor.
+// The password is only required for encrypted bip44 wallets that were not created from a descriptor.
+func (c *Client) WalletDescriptor(id, password string) (*WalletDescriptorResponse, error) {
+	var rsp WalletDescriptorResponse
+	ok, err := c.PostJSONV2("/api/v2/wallet/descriptor", WalletDescriptorRequest{
+		ID:       id,
+		Password: password,
+	}, &rsp)
+	if ok {
+		return &rsp, err
+	}
+
+	return nil, err
+}
+
+// WalletOutputsMeta makes a request to GET /api/v2/wallet/outputs/meta
+func (c *Client) WalletOutputsMeta(id string) (readable.UxOutsMeta, error) {
+	v := url.Values{}
+	v.Add("id", id)
+	endpoint := "/api/v2/wallet/outputs/meta?" + v.Encode()
+
+	var rsp readable.UxOutsMeta
+	ok, err := c.GetV2(endpoint, &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return rsp, err
+}
+
+// UpdateWalletOutputsMeta makes a request to POST /api/v2/wallet/outputs/meta
+func (c *Client) UpdateWalletOutputsMeta(req WalletOutputsMetaRequest) (readable.UxOutsMeta, error) {
+	var rsp readable.UxOutsMeta
+	ok, err := c.PostJSONV2("/api/v2/wallet/outputs/meta", req, &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return rsp, err
+}
+
+// PayoutRequest is sent to POST /api/v2/payout
+type PayoutRequest struct {
+	IdempotencyKey string         `json:"idempotency_key"`
+	WalletID       string         `json:"wallet_id"`
+	Password       string         `json:"password"`
+	HoursSelection HoursSelection `json:"hours_selection"`
+	To             []Receiver     `json:"to"`
+}
+
+// CreatePayout makes a request to POST /api/v2/payout.
+// If the payout job was created but could not be completed, the job is returned along with the error.
+func (c *Client) CreatePayout(req PayoutRequest) (*PayoutJob, error) {
+	var rsp PayoutJob
+	ok, err := c.PostJSONV2("/api/v2/payout", req, &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return &rsp, err
+}
+
+// Payout makes a request to GET /api/v2/payout
+func (c *Client) Payout(idempotencyKey string) (*PayoutJob, error) {
+	v := url.Values{}
+	v.Add("idempotency_key", idempotencyKey)
+	endpoint := "/api/v2/payout?" + v.Encode()
+
+	var rsp PayoutJob
+	ok, err := c.GetV2(endpoint, &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return &rsp, err
+}
+
+// Payouts makes a request to GET /api/v2/payouts
+func (c *Client) Payouts() ([]PayoutJob, error) {
+	var rsp []PayoutJob
+	ok, err := c.GetV2("/api/v2/payouts", &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return rsp, err
+}
+
+// WebhookRequest is sent to POST /api/v2/webhook
+type WebhookRequest struct {
+	URL           string   `json:"url"`
+	Event         string   `json:"event"`
+	Secret        string   `json:"secret,omitempty"`
+	Addresses     []string `json:"addresses,omitempty"`
+	TxID          string   `json:"txid,omitempty"`
+	Confirmations uint64   `json:"confirmations,omitempty"`
+}
+
+// CreateWebhook makes a request to POST /api/v2/webhook.
+// The returned webhook includes the secret that signs its deliveries.
+func (c *Client) CreateWebhook(req WebhookRequest) (*Webhook, error) {
+	var rsp Webhook
+	ok, err := c.PostJSONV2("/api/v2/webhook", req, &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return &rsp, err
+}
+
+// Webhook makes a request to GET /api/v2/webhook
+func (c *Client) Webhook(id string) (*Webhook, error) {
+	v := url.Values{}
+	v.Add("id", id)
+	endpoint := "/api/v2/webhook?" + v.Encode()
+
+	var rsp Webhook
+	ok, err := c.GetV2(endpoint, &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return &rsp, err
+}
+
+// DeleteWebhook makes a request to DELETE /api/v2/webhook
+func (c *Client) DeleteWebhook(id string) error {
+	v := url.Values{}
+	v.Add("id", id)
+	_, err := c.DeleteV2("/api/v2/webhook?"+v.Encode(), nil)
+	return err
+}
+
+// Webhooks makes a request to GET /api/v2/webhooks
+func (c *Client) Webhooks() ([]Webhook, error) {
+	var rsp []Webhook
+	ok, err := c.GetV2("/api/v2/webhooks", &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return rsp, err
+}
+
+// WebhookDeliveries makes a request to GET /api/v2/webhook/deliveries
+func (c *Client) WebhookDeliveries(id string) ([]WebhookDelivery, error) {
+	v := url.Values{}
+	v.Add("id", id)
+	endpoint := "/api/v2/webhook/deliveries?" + v.Encode()
+
+	var rsp []WebhookDelivery
+	ok, err := c.GetV2(endpoint, &rsp)
+	if !ok {
+		return nil, err
+	}
+
+	return rsp, err
+}
+
 // Disconnect disconnect a connections by ID
 func (c *Client) Disconnect(id uint64) error {
 	v := url.Values{}
@@ -1401,3 +1647,89 @@ func (c *Client) TransactionsVerboseV2(args ...RequestArg) (*TransactionsWithSta
 	}
 	return &obj, nil
 }
+
+// cursorPageValues returns the query parameters of a cursor pagination request.
+// The cursor parameter is always set, as an empty cursor requests the first page.
+func cursorPageValues(cursor string, args []RequestArg) url.Values {
+	v := url.Values{}
+	v.Set("cursor", cursor)
+	for _, arg := range args {
+		v.Add(arg.Key, arg.Value)
+	}
+	return v
+}
+
+// TransactionsPage makes a request to GET /api/v2/transactions with a cursor, to get a page of confirmed transactions.
+// Use an empty cursor for the first page, and the next_cursor of the response for the next pages.
+func (c *Client) TransactionsPage(cursor string, args ...RequestArg) (*TransactionsPageResponse, error) {
+	endpoint := "/api/v2/transactions?" + cursorPageValues(cursor, args).Encode()
+
+	var obj TransactionsPageResponse
+	if _, err := c.GetV2(endpoint, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// TransactionsPageVerbose makes a request to GET /api/v2/transactions?verbose=1 with a cursor
+func (c *Client) TransactionsPageVerbose(cursor string, args ...RequestArg) (*TransactionsPageVerboseResponse, error) {
+	v := cursorPageValues(cursor, args)
+	v.Set("verbose", "1")
+	endpoint := "/api/v2/transactions?" + v.Encode()
+
+	var obj TransactionsPageVerboseResponse
+	if _, err := c.GetV2(endpoint, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// BlocksPage makes a request to GET /api/v2/blocks
+func (c *Client) BlocksPage(cursor string, args ...RequestArg) (*BlocksPageResponse, error) {
+	endpoint := "/api/v2/blocks?" + cursorPageValues(cursor, args).Encode()
+
+	var obj BlocksPageResponse
+	if _, err := c.GetV2(endpoint, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// BlocksPageVerbose makes a request to GET /api/v2/blocks?verbose=1
+func (c *Client) BlocksPageVerbose(cursor string, args ...RequestArg) (*BlocksPageVerboseResponse, error) {
+	v := cursorPageValues(cursor, args)
+	v.Set("verbose", "1")
+	endpoint := "/api/v2/blocks?" + v.Encode()
+
+	var obj BlocksPageVerboseResponse
+	if _, err := c.GetV2(endpoint, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// AddressUxOutsPage makes a request to GET /api/v2/address_uxouts
+func (c *Client) AddressUxOutsPage(addr, cursor string, args ...RequestArg) (*AddressUxOutsPageResponse, error) {
+	v := cursorPageValues(cursor, args)
+	v.Set("address", addr)
+	endpoint := "/api/v2/address_uxouts?" + v.Encode()
+
+	var obj AddressUxOutsPageResponse
+	if _, err := c.GetV2(endpoint, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// OutputsPage makes a request to GET /api/v2/outputs
+func (c *Client) OutputsPage(addrs []string, cursor string, args ...RequestArg) (*OutputsPageResponse, error) {
+	v := cursorPageValues(cursor, args)
+	v.Set("addrs", strings.Join(addrs, ","))
+	endpoint := "/api/v2/outputs?" + v.Encode()
+
+	var obj OutputsPageResponse
+	if _, err := c.GetV2(endpoint, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
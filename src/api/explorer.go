@@ -7,9 +7,10 @@ import (
 
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/readable"
-	"github.com/skycoin/skycoin/src/util/droplet"
+	"github.com/skycoin/skycoin/src/util/amount"
 	wh "github.com/skycoin/skycoin/src/util/http"
-	"github.com/skycoin/skycoin/src/util/mathutil"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/historydb"
 )
 
 // CoinSupply records the coin supply info
@@ -61,13 +62,13 @@ func coinSupplyHandler(gateway Gatewayer) http.HandlerFunc {
 		// Search map of unlocked addresses, used to filter unspents
 		unlockedAddrSet := newAddrSet(unlockedAddrs)
 
-		var unlockedSupply uint64
+		var unlockedSupply amount.Amount
 		// check confirmed unspents only
 		for _, u := range allUnspents.Confirmed {
 			// check if address is an unlocked distribution address
 			if _, ok := unlockedAddrSet[u.Body.Address]; ok {
 				var err error
-				unlockedSupply, err = mathutil.AddUint64(unlockedSupply, u.Body.Coins)
+				unlockedSupply, err = unlockedSupply.Add(amount.Amount(u.Body.Coins))
 				if err != nil {
 					err = fmt.Errorf("uint64 overflow while adding up unlocked supply coins: %v", err)
 					wh.Error500(w, err.Error())
@@ -78,27 +79,43 @@ func coinSupplyHandler(gateway Gatewayer) http.HandlerFunc {
 
 		// "total supply" is the number of coins unlocked.
 		// Each distribution address was allocated distribution.AddressInitialBalance coins.
-		totalSupply := uint64(len(unlockedAddrs)) * dist.AddressInitialBalance()
-		totalSupply *= droplet.Multiplier
+		totalSupply, err := amount.FromCoins(uint64(len(unlockedAddrs)) * dist.AddressInitialBalance())
+		if err != nil {
+			err = fmt.Errorf("uint64 overflow while computing total supply: %v", err)
+			wh.Error500(w, err.Error())
+			return
+		}
 
 		// "current supply" is the number of coins distributed from the unlocked pool
-		currentSupply := totalSupply - unlockedSupply
+		currentSupply, err := totalSupply.Sub(unlockedSupply)
+		if err != nil {
+			err = fmt.Errorf("unlocked supply exceeds total supply: %v", err)
+			wh.Error500(w, err.Error())
+			return
+		}
 
-		currentSupplyStr, err := droplet.ToString(currentSupply)
+		currentSupplyStr, err := currentSupply.ToString()
 		if err != nil {
 			err = fmt.Errorf("Failed to convert coins to string: %v", err)
 			wh.Error500(w, err.Error())
 			return
 		}
 
-		totalSupplyStr, err := droplet.ToString(totalSupply)
+		totalSupplyStr, err := totalSupply.ToString()
 		if err != nil {
 			err = fmt.Errorf("Failed to convert coins to string: %v", err)
 			wh.Error500(w, err.Error())
 			return
 		}
 
-		maxSupplyStr, err := droplet.ToString(dist.MaxCoinSupply * droplet.Multiplier)
+		maxSupply, err := amount.FromCoins(dist.MaxCoinSupply)
+		if err != nil {
+			err = fmt.Errorf("uint64 overflow while computing max supply: %v", err)
+			wh.Error500(w, err.Error())
+			return
+		}
+
+		maxSupplyStr, err := maxSupply.ToString()
 		if err != nil {
 			err = fmt.Errorf("Failed to convert coins to string: %v", err)
 			wh.Error500(w, err.Error())
@@ -110,11 +127,11 @@ func coinSupplyHandler(gateway Gatewayer) http.HandlerFunc {
 		lockedAddrSet := newAddrSet(lockedAddrs)
 
 		// get total coins hours which excludes locked distribution addresses
-		var totalCoinHours uint64
+		var totalCoinHours amount.Hours
 		for _, out := range allUnspents.Confirmed {
 			if _, ok := lockedAddrSet[out.Body.Address]; !ok {
 				var err error
-				totalCoinHours, err = mathutil.AddUint64(totalCoinHours, out.CalculatedHours)
+				totalCoinHours, err = totalCoinHours.Add(amount.Hours(out.CalculatedHours))
 				if err != nil {
 					err = fmt.Errorf("uint64 overflow while adding up total coin hours: %v", err)
 					wh.Error500(w, err.Error())
@@ -146,7 +163,7 @@ func coinSupplyHandler(gateway Gatewayer) http.HandlerFunc {
 			TotalSupply:           totalSupplyStr,
 			MaxSupply:             maxSupplyStr,
 			CurrentCoinHourSupply: strconv.FormatUint(currentCoinHours, 10),
-			TotalCoinHourSupply:   strconv.FormatUint(totalCoinHours, 10),
+			TotalCoinHourSupply:   totalCoinHours.String(),
 			UnlockedAddresses:     dist.UnlockedAddresses(),
 			LockedAddresses:       dist.LockedAddresses(),
 		}
@@ -240,3 +257,90 @@ func addressCountHandler(gateway Gatewayer) http.HandlerFunc {
 		wh.SendJSONOr500(logger, w, &map[string]uint64{"count": addrCount})
 	}
 }
+
+// SearchHash is a block or transaction hash matched by a search, with the seq of its block
+type SearchHash struct {
+	Hash     string `json:"hash"`
+	BlockSeq uint64 `json:"block_seq"`
+}
+
+// SearchResponse is returned by GET /api/v2/search
+type SearchResponse struct {
+	Blocks       []SearchHash `json:"blocks"`
+	Transactions []SearchHash `json:"transactions"`
+	Addresses    []string     `json:"addresses"`
+}
+
+// NewSearchResponse creates a SearchResponse from a visor.SearchResult
+func NewSearchResponse(r visor.SearchResult) SearchResponse {
+	newSearchHashes := func(matches []historydb.HashMatch) []SearchHash {
+		hashes := make([]SearchHash, len(matches))
+		for i, m := range matches {
+			hashes[i] = SearchHash{
+				Hash:     m.Hash.Hex(),
+				BlockSeq: m.BlockSeq,
+			}
+		}
+		return hashes
+	}
+
+	addrs := make([]string, len(r.Addresses))
+	for i, a := range r.Addresses {
+		addrs[i] = a.String()
+	}
+
+	return SearchResponse{
+		Blocks:       newSearchHashes(r.Blocks),
+		Transactions: newSearchHashes(r.Transactions),
+		Addresses:    addrs,
+	}
+}
+
+// searchHandler finds the blocks, transactions and addresses matching a query, for autocompletion.
+// A block seq matches the block, a hex prefix matches block hashes and transaction hashes,
+// and a base58 prefix matches the addresses seen in the blockchain.
+// Only confirmed transactions are searched.
+// Method: GET
+// URI: /api/v2/search
+// Args:
+//	q [string] block seq, or prefix of a block hash, transaction hash or address
+//	limit [int] maximum number of results of each kind [optional, default to 10, must be <= 100]
+func searchHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError405Response(w)
+			return
+		}
+
+		query := r.FormValue("q")
+		if query == "" {
+			writeError400Response(w, "q is required")
+			return
+		}
+
+		limit := 10
+		if limitStr := r.FormValue("limit"); limitStr != "" {
+			var err error
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil {
+				writeError400Response(w, fmt.Sprintf("invalid 'limit' value: %v", err))
+				return
+			}
+		}
+
+		result, err := gateway.Search(query, limit)
+		if err != nil {
+			switch err {
+			case visor.ErrInvalidSearchLimit:
+				writeError400Response(w, err.Error())
+			default:
+				writeError500Response(w, err.Error())
+			}
+			return
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: NewSearchResponse(*result),
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/skycoin/skycoin/src/testutil"
 	"github.com/skycoin/skycoin/src/util/droplet"
 	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/historydb"
 )
 
 func makeSuccessCoinSupplyResult(t *testing.T, allUnspents readable.UnspentOutputsSummary) *CoinSupply {
@@ -149,7 +150,7 @@ func TestCoinSupply(t *testing.T) {
 			name:                        "500 - too large HeadOutputs item",
 			method:                      http.MethodGet,
 			status:                      http.StatusInternalServerError,
-			err:                         "500 Internal Server Error - Failed to convert coins to string: Droplet string conversion failed: Value is too large",
+			err:                         "500 Internal Server Error - unlocked supply exceeds total supply: uint64 subtraction underflow",
 			gatewayGetUnspentOutputsArg: filterInUnlocked,
 			gatewayGetUnspentOutputsResult: &visor.UnspentOutputsSummary{
 				Confirmed: []visor.UnspentOutput{
@@ -551,3 +552,143 @@ func TestGetAddressCount(t *testing.T) {
 		})
 	}
 }
+
+func TestSearch(t *testing.T) {
+	addr := testutil.MakeAddress()
+	result := &visor.SearchResult{
+		Blocks: []historydb.HashMatch{
+			{
+				Hash:     testutil.RandSHA256(t),
+				BlockSeq: 12,
+			},
+		},
+		Transactions: []historydb.HashMatch{
+			{
+				Hash:     testutil.RandSHA256(t),
+				BlockSeq: 3,
+			},
+		},
+		Addresses: []cipher.Address{addr},
+	}
+
+	cases := []struct {
+		name           string
+		method         string
+		status         int
+		query          string
+		limit          int
+		gatewayResult  *visor.SearchResult
+		gatewayErr     error
+		httpResponse   HTTPResponse
+		searchResponse *SearchResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodPost,
+			status:       http.StatusMethodNotAllowed,
+			query:        "?q=12",
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "missing q",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "q is required"),
+		},
+		{
+			name:         "invalid limit",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			query:        "?q=12&limit=foo",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, `invalid 'limit' value: strconv.Atoi: parsing "foo": invalid syntax`),
+		},
+		{
+			name:         "limit too large",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			query:        "?q=12&limit=101",
+			limit:        101,
+			gatewayErr:   visor.ErrInvalidSearchLimit,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, visor.ErrInvalidSearchLimit.Error()),
+		},
+		{
+			name:         "gateway error",
+			method:       http.MethodGet,
+			status:       http.StatusInternalServerError,
+			query:        "?q=12",
+			limit:        10,
+			gatewayErr:   errors.New("db error"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "db error"),
+		},
+		{
+			name:          "no results",
+			method:        http.MethodGet,
+			status:        http.StatusOK,
+			query:         "?q=12",
+			limit:         10,
+			gatewayResult: &visor.SearchResult{},
+			searchResponse: &SearchResponse{
+				Blocks:       []SearchHash{},
+				Transactions: []SearchHash{},
+				Addresses:    []string{},
+			},
+		},
+		{
+			name:          "ok",
+			method:        http.MethodGet,
+			status:        http.StatusOK,
+			query:         "?q=12&limit=5",
+			limit:         5,
+			gatewayResult: result,
+			searchResponse: &SearchResponse{
+				Blocks: []SearchHash{
+					{
+						Hash:     result.Blocks[0].Hash.Hex(),
+						BlockSeq: 12,
+					},
+				},
+				Transactions: []SearchHash{
+					{
+						Hash:     result.Transactions[0].Hash.Hex(),
+						BlockSeq: 3,
+					},
+				},
+				Addresses: []string{addr.String()},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("Search", "12", tc.limit).Return(tc.gatewayResult, tc.gatewayErr)
+
+			req, err := http.NewRequest(tc.method, "/api/v2/search"+tc.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if tc.searchResponse == nil {
+				require.Nil(t, rsp.Data)
+				return
+			}
+
+			var searchRsp SearchResponse
+			err = json.Unmarshal(rsp.Data, &searchRsp)
+			require.NoError(t, err)
+			require.Equal(t, *tc.searchResponse, searchRsp)
+		})
+	}
+}
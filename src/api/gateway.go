@@ -7,29 +7,36 @@ import (
 	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/daemon"
 	"github.com/skycoin/skycoin/src/kvstorage"
+	"github.com/skycoin/skycoin/src/payout"
 	"github.com/skycoin/skycoin/src/transaction"
 	"github.com/skycoin/skycoin/src/visor"
 	"github.com/skycoin/skycoin/src/visor/historydb"
 	"github.com/skycoin/skycoin/src/wallet"
+	"github.com/skycoin/skycoin/src/webhook"
 )
 
 //go:generate mockery -name Gatewayer -case underscore -inpkg -testonly
 
-// Gateway bundles daemon.Daemon, Visor, wallet.Service and kvstorage.Manager into a single object
+// Gateway bundles daemon.Daemon, Visor, wallet.Service, kvstorage.Manager, payout.Processor
+// and webhook.Dispatcher into a single object
 type Gateway struct {
 	*daemon.Daemon
 	*visor.Visor
 	*wallet.Service
 	*kvstorage.Manager
+	*payout.Processor
+	*webhook.Dispatcher
 }
 
 // NewGateway creates a Gateway
-func NewGateway(d *daemon.Daemon, v *visor.Visor, w *wallet.Service, m *kvstorage.Manager) *Gateway {
+func NewGateway(d *daemon.Daemon, v *visor.Visor, w *wallet.Service, m *kvstorage.Manager, p *payout.Processor, wd *webhook.Dispatcher) *Gateway {
 	return &Gateway{
-		Daemon:  d,
-		Visor:   v,
-		Service: w,
-		Manager: m,
+		Daemon:     d,
+		Visor:      v,
+		Service:    w,
+		Manager:    m,
+		Processor:  p,
+		Dispatcher: wd,
 	}
 }
 
@@ -39,6 +46,8 @@ type Gatewayer interface {
 	Visorer
 	Walleter
 	Storer
+	Payouter
+	Webhooker
 }
 
 // Daemoner interface for daemon.Daemon methods used by the API
@@ -72,20 +81,29 @@ type Visorer interface {
 	GetBlocksInRangeVerbose(start, end uint64) ([]coin.SignedBlock, [][][]visor.TransactionInput, error)
 	GetLastBlocks(num uint64) ([]coin.SignedBlock, error)
 	GetLastBlocksVerbose(num uint64) ([]coin.SignedBlock, [][][]visor.TransactionInput, error)
+	GetBlocksPage(after *visor.Cursor, order visor.SortOrder, limit uint64) ([]coin.SignedBlock, *visor.Cursor, error)
+	GetBlocksPageVerbose(after *visor.Cursor, order visor.SortOrder, limit uint64) ([]coin.SignedBlock, [][][]visor.TransactionInput, *visor.Cursor, error)
 	GetUnspentOutputsSummary(filters []visor.OutputsFilter) (*visor.UnspentOutputsSummary, error)
 	GetBalanceOfAddresses(addrs []cipher.Address) ([]wallet.BalancePair, error)
+	GetBalancesAt(addrs []cipher.Address, seq uint64) ([]wallet.Balance, *coin.SignedBlock, error)
 	VerifyTxnVerbose(txn *coin.Transaction, signed visor.TxnSignedFlag) ([]visor.TransactionInput, bool, error)
 	AddressCount() (uint64, error)
 	GetUxOutByID(id cipher.SHA256) (*historydb.UxOut, uint64, error)
 	GetSpentOutputsForAddresses(addr []cipher.Address) ([][]historydb.UxOut, uint64, error)
+	GetAddressUxOutsPage(addr cipher.Address, after *visor.Cursor, order visor.SortOrder, limit uint64) ([]historydb.UxOut, *visor.Cursor, uint64, error)
+	GetUnspentOutputsPage(addrs []cipher.Address, after *visor.Cursor, order visor.SortOrder, limit uint64) ([]visor.UnspentOutput, *visor.Cursor, *coin.SignedBlock, error)
 	// GetVerboseTransactionsForAddress(a cipher.Address) ([]visor.Transaction, [][]visor.TransactionInput, error)
 	GetRichlist(includeDistribution bool) (visor.Richlist, error)
+	GetRichlistAt(seq uint64, includeDistribution bool) (visor.Richlist, *coin.SignedBlock, error)
 	GetAllUnconfirmedTransactions() ([]visor.UnconfirmedTransaction, error)
 	GetAllUnconfirmedTransactionsVerbose() ([]visor.UnconfirmedTransaction, [][]visor.TransactionInput, error)
 	GetTransaction(txid cipher.SHA256) (*visor.Transaction, error)
 	GetTransactionWithInputs(txid cipher.SHA256) (*visor.Transaction, []visor.TransactionInput, error)
 	GetTransactions(flts []visor.TxFilter, order visor.SortOrder, page *visor.PageIndex) ([]visor.Transaction, uint64, error)
 	GetTransactionsWithInputs(flts []visor.TxFilter, order visor.SortOrder, page *visor.PageIndex) ([]visor.Transaction, [][]visor.TransactionInput, uint64, error)
+	GetTransactionsPage(addrs []cipher.Address, after *visor.Cursor, order visor.SortOrder, limit uint64) ([]visor.Transaction, *visor.Cursor, error)
+	GetTransactionsPageWithInputs(addrs []cipher.Address, after *visor.Cursor, order visor.SortOrder, limit uint64) ([]visor.Transaction, [][]visor.TransactionInput, *visor.Cursor, error)
+	Search(query string, limit int) (*visor.SearchResult, error)
 	GetWalletUnconfirmedTransactions(wltID string) ([]visor.UnconfirmedTransaction, error)
 	GetWalletUnconfirmedTransactionsVerbose(wltID string) ([]visor.UnconfirmedTransaction, [][]visor.TransactionInput, error)
 	GetWalletBalance(wltID string) (wallet.BalancePair, wallet.AddressBalances, error)
@@ -103,6 +121,8 @@ type Walleter interface {
 	EncryptWallet(wltID string, password []byte) (wallet.Wallet, error)
 	DecryptWallet(wltID string, password []byte) (wallet.Wallet, error)
 	GetWalletSeed(wltID string, password []byte) (string, string, error)
+	GetWalletDescriptor(wltID string, password []byte) (string, error)
+	SignMessage(wltID string, password []byte, addr cipher.Address, msg []byte) (cipher.Sig, error)
 	CreateWallet(wltName string, options wallet.Options) (wallet.Wallet, error)
 	RecoverWallet(wltID, seed, seedPassphrase string, password []byte) (wallet.Wallet, error)
 	NewAddresses(wltID string, password []byte, n uint64, options ...wallet.Option) ([]cipher.Address, error)
@@ -111,6 +131,8 @@ type Walleter interface {
 	GetWallets() (wallet.Wallets, error)
 	UpdateWalletLabel(wltID, label string) error
 	WalletDir() (string, error)
+	GetUxOutsMeta(wltID string) (wallet.UxOutsMeta, error)
+	UpdateWalletUxOutsMeta(wltID string, hashes []cipher.SHA256, f func(*wallet.UxOutMeta)) (wallet.UxOutsMeta, error)
 }
 
 // Storer interface for kvstorage.Manager methods used by the API
@@ -120,3 +142,19 @@ type Storer interface {
 	AddStorageValue(storageType kvstorage.Type, key, val string) error
 	RemoveStorageValue(storageType kvstorage.Type, key string) error
 }
+
+// Payouter interface for payout.Processor methods used by the API
+type Payouter interface {
+	CreatePayoutJob(r payout.JobRequest, password []byte) (*payout.Job, error)
+	GetPayoutJob(key string) (*payout.Job, error)
+	GetPayoutJobs() ([]payout.Job, error)
+}
+
+// Webhooker interface for webhook.Dispatcher methods used by the API
+type Webhooker interface {
+	CreateWebhook(r webhook.Request) (*webhook.Webhook, error)
+	GetWebhook(id string) (*webhook.Webhook, error)
+	GetWebhooks() ([]webhook.Webhook, error)
+	DeleteWebhook(id string) error
+	GetWebhookDeliveries(id string) ([]webhook.Delivery, error)
+}
@@ -0,0 +1,1088 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/skycoin/skycoin/src/api/grpcpb"
+	"github.com/skycoin/skycoin/src/apitoken"
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/daemon"
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/util/metrics"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+const (
+	defaultGRPCPollInterval = time.Second
+	// defaultGRPCMaxClientStreams is the default maximum number of subscriptions open at once by a client
+	defaultGRPCMaxClientStreams = 8
+	// maxGRPCBlocks is the maximum number of blocks returned by GetBlocks, or sent at once by SubscribeBlocks
+	maxGRPCBlocks = 100
+)
+
+var (
+	grpcCallDuration = metrics.NewHistogramVec("skycoin_grpc_call_duration_seconds", "Duration of the gRPC calls, by method and code", nil, "method", "code")
+)
+
+// grpcMethod is a gRPC method
+type grpcMethod struct {
+	// apiSets are the API sets of the method, at least one of which must be enabled.
+	// They match the API sets of the equivalent REST endpoint.
+	apiSets []string
+	// endpoint is the equivalent REST endpoint. Each call is charged the cost of the endpoint by the rate limiter.
+	endpoint string
+}
+
+// grpcMethods are the gRPC methods, by name
+var grpcMethods = map[string]grpcMethod{
+	"GetBlockchainMetadata": {apiSets: []string{EndpointsRead, EndpointsStatus}, endpoint: "/api/v1/blockchain/metadata"},
+	"GetBlock":              {apiSets: []string{EndpointsRead}, endpoint: "/api/v1/block"},
+	"GetBlocks":             {apiSets: []string{EndpointsRead}, endpoint: "/api/v1/blocks"},
+	"GetTransaction":        {apiSets: []string{EndpointsRead}, endpoint: "/api/v1/transaction"},
+	"GetUnspentOutputs":     {apiSets: []string{EndpointsRead}, endpoint: "/api/v1/outputs"},
+	"GetBalance":            {apiSets: []string{EndpointsRead}, endpoint: "/api/v1/balance"},
+	"InjectTransaction":     {apiSets: []string{EndpointsTransaction, EndpointsWallet}, endpoint: "/api/v1/injectTransaction"},
+	"VerifyTransaction":     {apiSets: []string{EndpointsRead}, endpoint: "/api/v2/transaction/verify"},
+	"SubscribeBlocks":       {apiSets: []string{EndpointsRead}, endpoint: "/api/v1/blocks"},
+	"SubscribeMempool":      {apiSets: []string{EndpointsRead}, endpoint: "/api/v1/pendingTxs"},
+}
+
+// GRPCServer exposes the node over gRPC
+type GRPCServer struct {
+	server   *grpc.Server
+	listener net.Listener
+	poller   *grpcPoller
+	done     chan struct{}
+}
+
+// GRPCConfig configures GRPCServer
+type GRPCConfig struct {
+	EnabledAPISets map[string]struct{}
+	// Username and Password are the credentials of the "authorization: Basic" metadata, like the web interface
+	Username string
+	Password string
+	// PlaintextAuth allows the "authorization: Basic" credentials and the API tokens to be sent to a server without TLS
+	PlaintextAuth bool
+	// APITokens authenticates calls made with API tokens, in the "authorization: Bearer" metadata.
+	// If nil, API tokens are not accepted
+	APITokens *apitoken.Store
+	// PollInterval is the interval at which the subscriptions check for new blocks and mempool changes
+	PollInterval time.Duration
+	// MaxClientStreams is the maximum number of subscriptions open at once by a client
+	MaxClientStreams int
+	// RateLimiter rate limits the calls of each client, and should be the RateLimiter of the web interface.
+	// Each call is charged the cost of the equivalent REST endpoint. If nil, calls are not rate limited
+	RateLimiter *RateLimiter
+}
+
+// CreateGRPC creates a new GRPCServer instance that listens on host without TLS
+func CreateGRPC(host string, c GRPCConfig, gateway Gatewayer) (*GRPCServer, error) {
+	return createGRPC(host, c, gateway, false)
+}
+
+// CreateGRPCTLS creates a new GRPCServer instance that listens on host with TLS
+func CreateGRPCTLS(host string, c GRPCConfig, gateway Gatewayer, certFile, keyFile string) (*GRPCServer, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Using %s for the gRPC certificate", certFile)
+	logger.Infof("Using %s for the gRPC key", keyFile)
+
+	return createGRPC(host, c, gateway, true, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+	})))
+}
+
+func createGRPC(host string, c GRPCConfig, gateway Gatewayer, useTLS bool, opts ...grpc.ServerOption) (*GRPCServer, error) {
+	if c.PollInterval == 0 {
+		c.PollInterval = defaultGRPCPollInterval
+	}
+	if c.MaxClientStreams == 0 {
+		c.MaxClientStreams = defaultGRPCMaxClientStreams
+	}
+
+	listener, err := net.Listen("tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := newGRPCAuth(c, useTLS)
+	opts = append(opts,
+		grpc.UnaryInterceptor(auth.unaryInterceptor),
+		grpc.StreamInterceptor(auth.streamInterceptor),
+	)
+
+	poller := newGRPCPoller(gateway, c.PollInterval)
+
+	server := grpc.NewServer(opts...)
+	grpcpb.RegisterNodeServer(server, &grpcNodeServer{
+		gateway: gateway,
+		poller:  poller,
+	})
+
+	return &GRPCServer{
+		server:   server,
+		listener: listener,
+		poller:   poller,
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Addr returns the listening address of the GRPCServer
+func (s *GRPCServer) Addr() string {
+	if s == nil || s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Serve serves the gRPC API on the configured host
+func (s *GRPCServer) Serve() error {
+	logger.Infof("Starting gRPC server on %s", s.listener.Addr())
+	defer logger.Info("gRPC server closed")
+	defer close(s.done)
+
+	if err := s.server.Serve(s.listener); err != nil && err != grpc.ErrServerStopped {
+		return err
+	}
+	return nil
+}
+
+// Shutdown closes the gRPC server and its open subscriptions. This can only be called after Serve has been called.
+func (s *GRPCServer) Shutdown() {
+	if s == nil {
+		return
+	}
+
+	logger.Info("Shutting down gRPC server")
+	defer logger.Info("gRPC server shut down")
+
+	// Stop instead of GracefulStop, which would wait for the subscriptions to end
+	s.server.Stop()
+	s.poller.stop()
+	<-s.done
+}
+
+// grpcAuth authenticates gRPC calls, checks the API sets of their methods and rate limits them,
+// in the same way as the web interface does for endpoints. The calls are recorded in the metrics.
+type grpcAuth struct {
+	enabledAPISets       map[string]struct{}
+	tokens               *apitoken.Store
+	needsAuth            bool
+	usernamePasswordHash cipher.SHA256
+	// refusePlaintextAuth is true if the server does not use TLS and plaintext auth is not allowed
+	refusePlaintextAuth bool
+	rateLimiter         *RateLimiter
+	maxClientStreams    int
+
+	streamsLock sync.Mutex
+	// streams is the number of open streams of each client
+	streams map[string]int
+}
+
+func newGRPCAuth(c GRPCConfig, useTLS bool) *grpcAuth {
+	return &grpcAuth{
+		enabledAPISets:       c.EnabledAPISets,
+		tokens:               c.APITokens,
+		needsAuth:            c.Username != "" || c.Password != "",
+		usernamePasswordHash: cipher.SumSHA256(append([]byte(c.Username), []byte(c.Password)...)),
+		refusePlaintextAuth:  !useTLS && !c.PlaintextAuth,
+		rateLimiter:          c.RateLimiter,
+		maxClientStreams:     c.MaxClientStreams,
+		streams:              make(map[string]int),
+	}
+}
+
+func (a *grpcAuth) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	t := time.Now()
+
+	var resp interface{}
+	_, err := a.authorize(ctx, info.FullMethod)
+	if err == nil {
+		resp, err = handler(ctx, req)
+	}
+
+	observeGRPCCall(info.FullMethod, err, time.Since(t))
+	return resp, err
+}
+
+func (a *grpcAuth) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	t := time.Now()
+
+	token, err := a.authorize(ss.Context(), info.FullMethod)
+	if err == nil {
+		key := grpcClientKey(ss.Context(), token)
+		if a.openStream(key) {
+			err = handler(srv, ss)
+			a.closeStream(key)
+		} else {
+			err = status.Error(codes.ResourceExhausted, "too many open streams")
+		}
+	}
+
+	observeGRPCCall(info.FullMethod, err, time.Since(t))
+	return err
+}
+
+// openStream counts a new stream of a client. Returns false if the client has too many open streams.
+func (a *grpcAuth) openStream(key string) bool {
+	a.streamsLock.Lock()
+	defer a.streamsLock.Unlock()
+
+	if a.streams[key] >= a.maxClientStreams {
+		return false
+	}
+
+	a.streams[key]++
+	return true
+}
+
+// closeStream counts a closed stream of a client
+func (a *grpcAuth) closeStream(key string) {
+	a.streamsLock.Lock()
+	defer a.streamsLock.Unlock()
+
+	a.streams[key]--
+	if a.streams[key] <= 0 {
+		delete(a.streams, key)
+	}
+}
+
+// observeGRPCCall records the duration of a call, limiting the method label values to the known methods
+func observeGRPCCall(fullMethod string, err error, d time.Duration) {
+	method := path.Base(fullMethod)
+	if _, ok := grpcMethods[method]; !ok {
+		method = "OTHER"
+	}
+
+	grpcCallDuration.ObserveDuration(d, method, status.Code(err).String())
+}
+
+// authorize authenticates a call, checks that it can use the method and charges it to the client in the rate limiter.
+// Returns the API token which authenticated the call, if any.
+func (a *grpcAuth) authorize(ctx context.Context, fullMethod string) (*apitoken.Token, error) {
+	token, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := grpcMethods[path.Base(fullMethod)]
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "unknown method")
+	}
+
+	if err := checkMethodAccess(a.enabledAPISets, token, m.apiSets); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if a.rateLimiter == nil {
+		return token, nil
+	}
+
+	if err := a.rateLimit(ctx, token, a.rateLimiter.endpointCost(m.endpoint, false, m.apiSets)); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// rateLimit charges cost to the client of a call, identified by its API token if it has one, otherwise by its IP address.
+// Returns a ResourceExhausted error if the client exceeded its rate limit.
+func (a *grpcAuth) rateLimit(ctx context.Context, token *apitoken.Token, cost int) error {
+	if a.rateLimiter == nil {
+		return nil
+	}
+
+	if ok, _ := a.rateLimiter.take(grpcClientKey(ctx, token), cost); !ok {
+		return status.Error(codes.ResourceExhausted, http.StatusText(http.StatusTooManyRequests))
+	}
+
+	return nil
+}
+
+// grpcClientKey identifies the client of a call by its API token if it has one, otherwise by its IP address
+func grpcClientKey(ctx context.Context, token *apitoken.Token) string {
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	return rateLimitClientKey(token, addr)
+}
+
+// authenticate authenticates a call with the "authorization" metadata. Returns the API token which authenticated the call, if any.
+func (a *grpcAuth) authenticate(ctx context.Context) (*apitoken.Token, error) {
+	var auth string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) != 0 {
+			auth = v[0]
+		}
+	}
+
+	if secret, ok := authorizationValue(auth, "Bearer "); ok {
+		if a.tokens == nil {
+			return nil, status.Error(codes.Unauthenticated, "API tokens are not enabled")
+		}
+
+		if a.refusePlaintextAuth {
+			return nil, status.Error(codes.Unauthenticated, "API tokens require TLS")
+		}
+
+		t, err := a.tokens.Authenticate(secret, time.Now())
+		if err != nil {
+			switch err {
+			case apitoken.ErrInvalidToken, apitoken.ErrTokenExpired:
+				if err := a.rateLimit(ctx, nil, rateLimitAuthFailureCost); err != nil {
+					return nil, err
+				}
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			default:
+				logger.WithError(err).Error("API token authentication failed")
+				return nil, status.Error(codes.Internal, "")
+			}
+		}
+
+		return t, nil
+	}
+
+	var user, pass string
+	if encoded, ok := authorizationValue(auth, "Basic "); ok {
+		if a.needsAuth && a.refusePlaintextAuth {
+			return nil, status.Error(codes.Unauthenticated, "basic auth requires TLS")
+		}
+
+		b, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid basic auth")
+		}
+
+		i := strings.IndexByte(string(b), ':')
+		if i < 0 {
+			return nil, status.Error(codes.Unauthenticated, "invalid basic auth")
+		}
+		user, pass = string(b[:i]), string(b[i+1:])
+	} else if auth != "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization")
+	}
+
+	if !a.needsAuth {
+		// As with the web interface, reject credentials if auth is not configured
+		if user != "" || pass != "" {
+			return nil, status.Error(codes.Unauthenticated, "auth is not enabled")
+		}
+		return nil, nil
+	}
+
+	userPassHash := cipher.SumSHA256(append([]byte(user), []byte(pass)...))
+	if subtle.ConstantTimeCompare(userPassHash[:], a.usernamePasswordHash[:]) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "")
+	}
+
+	return nil, nil
+}
+
+// authorizationValue returns the value of an authorization with a scheme prefix, e.g. "Bearer "
+func authorizationValue(auth, prefix string) (string, bool) {
+	if len(auth) < len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(auth[len(prefix):]), true
+}
+
+// grpcNodeServer implements grpcpb.NodeServer with a Gatewayer
+type grpcNodeServer struct {
+	gateway Gatewayer
+	poller  *grpcPoller
+}
+
+func (s *grpcNodeServer) GetBlockchainMetadata(ctx context.Context, req *grpcpb.GetBlockchainMetadataRequest) (*grpcpb.BlockchainMetadata, error) {
+	metadata, err := s.gateway.GetBlockchainMetadata()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "gateway.GetBlockchainMetadata failed: %v", err)
+	}
+
+	if metadata == nil {
+		return nil, status.Error(codes.Internal, "gateway.GetBlockchainMetadata metadata is nil")
+	}
+
+	headHash := metadata.HeadBlock.HashHeader()
+	return &grpcpb.BlockchainMetadata{
+		Head:        newPBBlockHeader(metadata.HeadBlock.Head),
+		HeadHash:    headHash[:],
+		Unspents:    metadata.Unspents,
+		Unconfirmed: metadata.Unconfirmed,
+	}, nil
+}
+
+func (s *grpcNodeServer) GetBlock(ctx context.Context, req *grpcpb.GetBlockRequest) (*grpcpb.Block, error) {
+	var b *coin.SignedBlock
+	var err error
+	switch v := req.Block.(type) {
+	case *grpcpb.GetBlockRequest_Hash:
+		h, hErr := cipher.SHA256FromBytes(v.Hash)
+		if hErr != nil {
+			return nil, status.Error(codes.InvalidArgument, hErr.Error())
+		}
+		b, err = s.gateway.GetSignedBlockByHash(h)
+	case *grpcpb.GetBlockRequest_Seq:
+		b, err = s.gateway.GetSignedBlockBySeq(v.Seq)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "should specify one filter, hash or seq")
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if b == nil {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+
+	return newPBBlock(*b), nil
+}
+
+func (s *grpcNodeServer) GetBlocks(ctx context.Context, req *grpcpb.GetBlocksRequest) (*grpcpb.GetBlocksResponse, error) {
+	if req.Start > req.End {
+		return nil, status.Error(codes.InvalidArgument, "start must be <= end")
+	}
+
+	if req.End-req.Start >= maxGRPCBlocks {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d blocks can be requested", maxGRPCBlocks)
+	}
+
+	blocks, err := s.gateway.GetBlocksInRange(req.Start, req.End)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &grpcpb.GetBlocksResponse{
+		Blocks: make([]*grpcpb.Block, len(blocks)),
+	}
+	for i, b := range blocks {
+		resp.Blocks[i] = newPBBlock(b)
+	}
+
+	return resp, nil
+}
+
+func (s *grpcNodeServer) GetTransaction(ctx context.Context, req *grpcpb.GetTransactionRequest) (*grpcpb.TransactionWithStatus, error) {
+	txid, err := cipher.SHA256FromBytes(req.Txid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	txn, err := s.gateway.GetTransaction(txid)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if txn == nil {
+		return nil, status.Error(codes.NotFound, "transaction not found")
+	}
+
+	isGenesis := txn.Status.Confirmed && txn.Status.BlockSeq == 0
+	return &grpcpb.TransactionWithStatus{
+		Transaction: newPBTransaction(txn.Transaction, isGenesis),
+		Status: &grpcpb.TransactionStatus{
+			Confirmed: txn.Status.Confirmed,
+			Height:    txn.Status.Height,
+			BlockSeq:  txn.Status.BlockSeq,
+		},
+		Time: txn.Time,
+	}, nil
+}
+
+func (s *grpcNodeServer) GetUnspentOutputs(ctx context.Context, req *grpcpb.GetUnspentOutputsRequest) (*grpcpb.UnspentOutputs, error) {
+	if len(req.Addresses) != 0 && len(req.Hashes) != 0 {
+		return nil, status.Error(codes.InvalidArgument, "addresses and hashes cannot be specified together")
+	}
+
+	var filters []visor.OutputsFilter
+
+	if len(req.Addresses) != 0 {
+		addrs, err := parsePBAddresses(req.Addresses)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, visor.FbyAddresses(addrs))
+	}
+
+	if len(req.Hashes) != 0 {
+		hashes := make([]cipher.SHA256, len(req.Hashes))
+		for i, b := range req.Hashes {
+			h, err := cipher.SHA256FromBytes(b)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			hashes[i] = h
+		}
+		filters = append(filters, visor.FbyHashes(hashes))
+	}
+
+	summary, err := s.gateway.GetUnspentOutputsSummary(filters)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "gateway.GetUnspentOutputsSummary failed: %v", err)
+	}
+
+	resp := &grpcpb.UnspentOutputs{
+		Confirmed: newPBUnspentOutputs(summary.Confirmed),
+		Outgoing:  newPBUnspentOutputs(summary.Outgoing),
+		Incoming:  newPBUnspentOutputs(summary.Incoming),
+	}
+	if summary.HeadBlock != nil {
+		resp.Head = newPBBlockHeader(summary.HeadBlock.Head)
+	}
+
+	return resp, nil
+}
+
+func (s *grpcNodeServer) GetBalance(ctx context.Context, req *grpcpb.GetBalanceRequest) (*grpcpb.GetBalanceResponse, error) {
+	if len(req.Addresses) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "addresses is required")
+	}
+
+	addrs, err := parsePBAddresses(req.Addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	bals, err := s.gateway.GetBalanceOfAddresses(addrs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "gateway.GetBalanceOfAddresses failed: %v", err)
+	}
+
+	resp := &grpcpb.GetBalanceResponse{
+		Addresses: make(map[string]*grpcpb.BalancePair, len(addrs)),
+	}
+
+	var balance wallet.BalancePair
+	for i, bal := range bals {
+		resp.Addresses[addrs[i].String()] = newPBBalancePair(bal)
+
+		balance.Confirmed, err = balance.Confirmed.Add(bal.Confirmed)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		balance.Predicted, err = balance.Predicted.Add(bal.Predicted)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	resp.Balance = newPBBalancePair(balance)
+
+	return resp, nil
+}
+
+func (s *grpcNodeServer) InjectTransaction(ctx context.Context, req *grpcpb.InjectTransactionRequest) (*grpcpb.InjectTransactionResponse, error) {
+	if len(req.Transaction) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction is required")
+	}
+
+	txn, err := coin.DeserializeTransaction(req.Transaction)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.NoBroadcast {
+		err = s.gateway.InjectTransaction(txn)
+	} else {
+		err = s.gateway.InjectBroadcastTransaction(txn)
+	}
+
+	if err != nil {
+		switch err.(type) {
+		case visor.ErrTxnViolatesUserConstraint,
+			visor.ErrTxnViolatesHardConstraint,
+			visor.ErrTxnViolatesSoftConstraint:
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		if daemon.IsBroadcastFailure(err) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	txid := txn.Hash()
+	return &grpcpb.InjectTransactionResponse{
+		Txid: txid[:],
+	}, nil
+}
+
+func (s *grpcNodeServer) VerifyTransaction(ctx context.Context, req *grpcpb.VerifyTransactionRequest) (*grpcpb.VerifyTransactionResponse, error) {
+	if len(req.Transaction) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction is required")
+	}
+
+	txn, err := coin.DeserializeTransaction(req.Transaction)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "decode transaction failed: %v", err)
+	}
+
+	signed := visor.TxnSigned
+	if req.Unsigned {
+		signed = visor.TxnUnsigned
+	}
+
+	resp := &grpcpb.VerifyTransactionResponse{
+		Unsigned:    !txn.IsFullySigned(),
+		Transaction: newPBTransaction(txn, false),
+	}
+
+	inputs, isTxnConfirmed, err := s.gateway.VerifyTxnVerbose(&txn, signed)
+	if err != nil {
+		switch err.(type) {
+		case visor.ErrTxnViolatesSoftConstraint,
+			visor.ErrTxnViolatesHardConstraint,
+			visor.ErrTxnViolatesUserConstraint:
+			resp.Error = err.Error()
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	resp.Confirmed = isTxnConfirmed
+	if isTxnConfirmed && resp.Error == "" {
+		resp.Error = "transaction has been spent"
+	}
+
+	if len(inputs) == len(txn.In) {
+		resp.Inputs = make([]*grpcpb.UnspentOutput, len(inputs))
+		for i, in := range inputs {
+			resp.Inputs[i] = newPBUnspentOutput(in.UxOut, in.CalculatedHours)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *grpcNodeServer) SubscribeBlocks(req *grpcpb.SubscribeBlocksRequest, stream grpcpb.Node_SubscribeBlocksServer) error {
+	// Subscribe before reading the head block, so that no poll is missed
+	poll := s.poller.subscribe(grpcPollBlocks)
+	defer s.poller.unsubscribe(grpcPollBlocks)
+
+	headSeq, hasHead, err := s.gateway.HeadBkSeq()
+	if err != nil {
+		return status.Errorf(codes.Internal, "gateway.HeadBkSeq failed: %v", err)
+	}
+
+	var next uint64
+	if start, ok := req.Start.(*grpcpb.SubscribeBlocksRequest_StartSeq); ok {
+		next = start.StartSeq
+	} else if hasHead {
+		next = headSeq + 1
+	}
+
+	for {
+		// Keep sending without waiting for a poll while the subscriber is catching up
+		for hasHead && headSeq >= next {
+			end := headSeq
+			if end-next >= maxGRPCBlocks {
+				end = next + maxGRPCBlocks - 1
+			}
+
+			blocks, err := s.gateway.GetBlocksInRange(next, end)
+			if err != nil {
+				return status.Errorf(codes.Internal, "gateway.GetBlocksInRange failed: %v", err)
+			}
+			if len(blocks) == 0 {
+				break
+			}
+
+			for _, b := range blocks {
+				if err := stream.Send(newPBBlock(b)); err != nil {
+					return err
+				}
+				next = b.Head.BkSeq + 1
+			}
+		}
+
+		poll = s.poller.wait(stream.Context(), poll, grpcPollBlocks)
+		if poll == nil {
+			return nil
+		}
+
+		if poll.headErr != nil {
+			return status.Errorf(codes.Internal, "gateway.HeadBkSeq failed: %v", poll.headErr)
+		}
+
+		headSeq, hasHead = poll.headSeq, poll.hasHead
+	}
+}
+
+func (s *grpcNodeServer) SubscribeMempool(req *grpcpb.SubscribeMempoolRequest, stream grpcpb.Node_SubscribeMempoolServer) error {
+	// Subscribe before reading the mempool, so that no poll is missed
+	poll := s.poller.subscribe(grpcPollMempool)
+	defer s.poller.unsubscribe(grpcPollMempool)
+
+	txns, err := s.gateway.GetAllUnconfirmedTransactions()
+	if err != nil {
+		return status.Errorf(codes.Internal, "gateway.GetAllUnconfirmedTransactions failed: %v", err)
+	}
+
+	known := make(map[cipher.SHA256]visor.UnconfirmedTransaction, len(txns))
+	for _, txn := range txns {
+		known[txn.Transaction.Hash()] = txn
+	}
+
+	if req.IncludeExisting {
+		if err := sendMempoolEvents(stream, grpcpb.MempoolEvent_ADDED, txns); err != nil {
+			return err
+		}
+	}
+
+	for {
+		poll = s.poller.wait(stream.Context(), poll, grpcPollMempool)
+		if poll == nil {
+			return nil
+		}
+
+		if poll.mempoolErr != nil {
+			return status.Errorf(codes.Internal, "gateway.GetAllUnconfirmedTransactions failed: %v", poll.mempoolErr)
+		}
+
+		// The transactions of the poll are shared by all the subscriptions and must not be modified
+		var added []visor.UnconfirmedTransaction
+		for h, txn := range poll.mempool {
+			if _, ok := known[h]; !ok {
+				added = append(added, txn)
+			}
+		}
+
+		var removed []visor.UnconfirmedTransaction
+		for h, txn := range known {
+			if _, ok := poll.mempool[h]; !ok {
+				removed = append(removed, txn)
+			}
+		}
+
+		known = poll.mempool
+
+		if err := sendMempoolEvents(stream, grpcpb.MempoolEvent_REMOVED, removed); err != nil {
+			return err
+		}
+
+		if err := sendMempoolEvents(stream, grpcpb.MempoolEvent_ADDED, added); err != nil {
+			return err
+		}
+	}
+}
+
+// grpcPollTarget is what a subscription needs to be polled
+type grpcPollTarget int
+
+const (
+	// grpcPollBlocks polls the head block
+	grpcPollBlocks grpcPollTarget = iota
+	// grpcPollMempool polls the unconfirmed transactions
+	grpcPollMempool
+)
+
+// grpcPoll is the result of a poll of the gateway
+type grpcPoll struct {
+	// hasBlocks is true if the head block was polled
+	hasBlocks bool
+	headSeq   uint64
+	hasHead   bool
+	headErr   error
+
+	// hasMempool is true if the unconfirmed transactions were polled
+	hasMempool bool
+	mempool    map[cipher.SHA256]visor.UnconfirmedTransaction
+	mempoolErr error
+
+	// done is closed when the next poll is available
+	done chan struct{}
+}
+
+func (p *grpcPoll) polled(target grpcPollTarget) bool {
+	switch target {
+	case grpcPollBlocks:
+		return p.hasBlocks
+	case grpcPollMempool:
+		return p.hasMempool
+	default:
+		return false
+	}
+}
+
+// grpcPoller polls the gateway every interval for all the subscriptions, so that the cost of polling
+// does not grow with the number of subscriptions. It only runs while there are subscriptions.
+type grpcPoller struct {
+	gateway  Gatewayer
+	interval time.Duration
+	quit     chan struct{}
+
+	sync.Mutex
+	subscriptions map[grpcPollTarget]int
+	running       bool
+	latest        *grpcPoll
+}
+
+func newGRPCPoller(gateway Gatewayer, interval time.Duration) *grpcPoller {
+	return &grpcPoller{
+		gateway:       gateway,
+		interval:      interval,
+		quit:          make(chan struct{}),
+		subscriptions: make(map[grpcPollTarget]int),
+		latest: &grpcPoll{
+			done: make(chan struct{}),
+		},
+	}
+}
+
+// subscribe adds a subscription to target, starting the poller if needed. Returns the latest poll, to pass to wait.
+func (p *grpcPoller) subscribe(target grpcPollTarget) *grpcPoll {
+	p.Lock()
+	defer p.Unlock()
+
+	p.subscriptions[target]++
+	if !p.running {
+		p.running = true
+		go p.run()
+	}
+
+	return p.latest
+}
+
+// unsubscribe removes a subscription to target. The poller stops at its next interval if there are no subscriptions left.
+func (p *grpcPoller) unsubscribe(target grpcPollTarget) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.subscriptions[target]--
+}
+
+// wait waits for the first poll of target after prev.
+// Returns nil if ctx is done or the poller is stopped.
+func (p *grpcPoller) wait(ctx context.Context, prev *grpcPoll, target grpcPollTarget) *grpcPoll {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.quit:
+			return nil
+		case <-prev.done:
+		}
+
+		p.Lock()
+		prev = p.latest
+		p.Unlock()
+
+		if prev.polled(target) {
+			return prev
+		}
+	}
+}
+
+// stop stops the poller. The subscriptions waiting for a poll return.
+func (p *grpcPoller) stop() {
+	close(p.quit)
+}
+
+func (p *grpcPoller) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.quit:
+			return
+		case <-ticker.C:
+		}
+
+		p.Lock()
+		pollBlocks := p.subscriptions[grpcPollBlocks] > 0
+		pollMempool := p.subscriptions[grpcPollMempool] > 0
+		if !pollBlocks && !pollMempool {
+			p.running = false
+			p.Unlock()
+			return
+		}
+		p.Unlock()
+
+		poll := &grpcPoll{
+			done: make(chan struct{}),
+		}
+
+		if pollBlocks {
+			poll.hasBlocks = true
+			poll.headSeq, poll.hasHead, poll.headErr = p.gateway.HeadBkSeq()
+		}
+
+		if pollMempool {
+			poll.hasMempool = true
+			var txns []visor.UnconfirmedTransaction
+			txns, poll.mempoolErr = p.gateway.GetAllUnconfirmedTransactions()
+			poll.mempool = make(map[cipher.SHA256]visor.UnconfirmedTransaction, len(txns))
+			for _, txn := range txns {
+				poll.mempool[txn.Transaction.Hash()] = txn
+			}
+		}
+
+		p.Lock()
+		prev := p.latest
+		p.latest = poll
+		p.Unlock()
+
+		close(prev.done)
+	}
+}
+
+// sendMempoolEvents sends an event for each transaction, ordered by the time they were received
+func sendMempoolEvents(stream grpcpb.Node_SubscribeMempoolServer, typ grpcpb.MempoolEvent_Type, txns []visor.UnconfirmedTransaction) error {
+	sort.SliceStable(txns, func(i, j int) bool {
+		return txns[i].Received < txns[j].Received
+	})
+
+	for _, txn := range txns {
+		if err := stream.Send(&grpcpb.MempoolEvent{
+			Type:        typ,
+			Transaction: newPBTransaction(txn.Transaction, false),
+			Received:    txn.Received,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parsePBAddresses(addrs []string) ([]cipher.Address, error) {
+	cipherAddrs := make([]cipher.Address, len(addrs))
+	for i, a := range addrs {
+		addr, err := cipher.DecodeAddress(params.AddressHRP, a)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "address %q is invalid: %v", a, err)
+		}
+		cipherAddrs[i] = addr
+	}
+	return cipherAddrs, nil
+}
+
+func newPBBlockHeader(h coin.BlockHeader) *grpcpb.BlockHeader {
+	return &grpcpb.BlockHeader{
+		Version:  h.Version,
+		Time:     h.Time,
+		Seq:      h.BkSeq,
+		Fee:      h.Fee,
+		PrevHash: append([]byte{}, h.PrevHash[:]...),
+		BodyHash: append([]byte{}, h.BodyHash[:]...),
+		UxHash:   append([]byte{}, h.UxHash[:]...),
+	}
+}
+
+func newPBBlock(b coin.SignedBlock) *grpcpb.Block {
+	hash := b.HashHeader()
+	isGenesis := b.Head.BkSeq == 0
+
+	txns := make([]*grpcpb.Transaction, len(b.Body.Transactions))
+	for i, txn := range b.Body.Transactions {
+		txns[i] = newPBTransaction(txn, isGenesis)
+	}
+
+	return &grpcpb.Block{
+		Header:       newPBBlockHeader(b.Head),
+		Hash:         hash[:],
+		Transactions: txns,
+		Sig:          append([]byte{}, b.Sig[:]...),
+	}
+}
+
+// newPBTransaction converts a transaction. The outputs of the genesis transaction use an empty transaction ID for their hashes.
+func newPBTransaction(txn coin.Transaction, isGenesis bool) *grpcpb.Transaction {
+	txid := txn.Hash()
+	txnOutputTxID := cipher.SHA256{}
+	if !isGenesis {
+		txnOutputTxID = txid
+	}
+
+	sigs := make([][]byte, len(txn.Sigs))
+	for i, sig := range txn.Sigs {
+		sigs[i] = append([]byte{}, sig[:]...)
+	}
+
+	in := make([][]byte, len(txn.In))
+	for i, h := range txn.In {
+		in[i] = append([]byte{}, h[:]...)
+	}
+
+	out := make([]*grpcpb.TransactionOutput, len(txn.Out))
+	for i, o := range txn.Out {
+		uxID := o.UxID(txnOutputTxID)
+		out[i] = &grpcpb.TransactionOutput{
+			Hash:    uxID[:],
+			Address: o.Address.String(),
+			Coins:   o.Coins,
+			Hours:   o.Hours,
+		}
+	}
+
+	return &grpcpb.Transaction{
+		Hash:      txid[:],
+		InnerHash: append([]byte{}, txn.InnerHash[:]...),
+		Type:      uint32(txn.Type),
+		Length:    txn.Length,
+		Sigs:      sigs,
+		Inputs:    in,
+		Outputs:   out,
+	}
+}
+
+func newPBUnspentOutput(ux coin.UxOut, calculatedHours uint64) *grpcpb.UnspentOutput {
+	hash := ux.Hash()
+	return &grpcpb.UnspentOutput{
+		Hash:            hash[:],
+		Time:            ux.Head.Time,
+		BlockSeq:        ux.Head.BkSeq,
+		SrcTransaction:  append([]byte{}, ux.Body.SrcTransaction[:]...),
+		Address:         ux.Body.Address.String(),
+		Coins:           ux.Body.Coins,
+		Hours:           ux.Body.Hours,
+		CalculatedHours: calculatedHours,
+	}
+}
+
+func newPBUnspentOutputs(uxOuts []visor.UnspentOutput) []*grpcpb.UnspentOutput {
+	outs := make([]*grpcpb.UnspentOutput, len(uxOuts))
+	for i, ux := range uxOuts {
+		outs[i] = newPBUnspentOutput(ux.UxOut, ux.CalculatedHours)
+	}
+	return outs
+}
+
+func newPBBalancePair(bp wallet.BalancePair) *grpcpb.BalancePair {
+	return &grpcpb.BalancePair{
+		Confirmed: &grpcpb.Balance{
+			Coins: bp.Confirmed.Coins.Value(),
+			Hours: bp.Confirmed.Hours.Value(),
+		},
+		Predicted: &grpcpb.Balance{
+			Coins: bp.Predicted.Coins.Value(),
+			Hours: bp.Predicted.Hours.Value(),
+		},
+	}
+}
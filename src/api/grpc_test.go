@@ -0,0 +1,525 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/skycoin/skycoin/src/api/grpcpb"
+	"github.com/skycoin/skycoin/src/apitoken"
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+func startTestGRPCServer(t *testing.T, c GRPCConfig, gateway Gatewayer) (grpcpb.NodeClient, func()) {
+	if c.PollInterval == 0 {
+		c.PollInterval = time.Millisecond * 10
+	}
+
+	s, err := CreateGRPC("127.0.0.1:0", c, gateway)
+	require.NoError(t, err)
+
+	go func() {
+		err := s.Serve()
+		require.NoError(t, err)
+	}()
+
+	conn, err := grpc.Dial(s.Addr(), grpc.WithInsecure())
+	require.NoError(t, err)
+
+	return grpcpb.NewNodeClient(conn), func() {
+		conn.Close()
+		s.Shutdown()
+	}
+}
+
+func requireProtoEqual(t *testing.T, expected, actual proto.Message) {
+	require.True(t, proto.Equal(expected, actual), "expected %v, got %v", expected, actual)
+}
+
+func requireGRPCStatus(t *testing.T, code codes.Code, msg string, err error) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code(), st.Message())
+	require.Equal(t, msg, st.Message())
+}
+
+func TestGRPCGetBlock(t *testing.T) {
+	b := coin.SignedBlock{
+		Block: coin.Block{
+			Head: coin.BlockHeader{
+				BkSeq: 1,
+			},
+			Body: coin.BlockBody{
+				Transactions: coin.Transactions{makeTransaction(t)},
+			},
+		},
+	}
+	hash := b.HashHeader()
+
+	gateway := &MockGatewayer{}
+	gateway.On("GetSignedBlockBySeq", uint64(1)).Return(&b, nil)
+	gateway.On("GetSignedBlockBySeq", uint64(2)).Return(nil, nil)
+	gateway.On("GetSignedBlockBySeq", uint64(3)).Return(nil, errors.New("GetSignedBlockBySeq failed"))
+	gateway.On("GetSignedBlockByHash", hash).Return(&b, nil)
+
+	c, stop := startTestGRPCServer(t, GRPCConfig{
+		EnabledAPISets: allAPISetsEnabled,
+	}, gateway)
+	defer stop()
+
+	ctx := context.Background()
+
+	block, err := c.GetBlock(ctx, &grpcpb.GetBlockRequest{
+		Block: &grpcpb.GetBlockRequest_Seq{Seq: 1},
+	})
+	require.NoError(t, err)
+	requireProtoEqual(t, newPBBlock(b), block)
+	require.Equal(t, hash[:], block.Hash)
+	require.Len(t, block.Transactions, 1)
+	txid := b.Body.Transactions[0].Hash()
+	require.Equal(t, txid[:], block.Transactions[0].Hash)
+
+	block, err = c.GetBlock(ctx, &grpcpb.GetBlockRequest{
+		Block: &grpcpb.GetBlockRequest_Hash{Hash: hash[:]},
+	})
+	require.NoError(t, err)
+	require.Equal(t, hash[:], block.Hash)
+
+	_, err = c.GetBlock(ctx, &grpcpb.GetBlockRequest{
+		Block: &grpcpb.GetBlockRequest_Seq{Seq: 2},
+	})
+	requireGRPCStatus(t, codes.NotFound, "block not found", err)
+
+	_, err = c.GetBlock(ctx, &grpcpb.GetBlockRequest{
+		Block: &grpcpb.GetBlockRequest_Seq{Seq: 3},
+	})
+	requireGRPCStatus(t, codes.Internal, "GetSignedBlockBySeq failed", err)
+
+	_, err = c.GetBlock(ctx, &grpcpb.GetBlockRequest{
+		Block: &grpcpb.GetBlockRequest_Hash{Hash: []byte{1, 2, 3}},
+	})
+	requireGRPCStatus(t, codes.InvalidArgument, "Invalid sha256 length", err)
+
+	_, err = c.GetBlock(ctx, &grpcpb.GetBlockRequest{})
+	requireGRPCStatus(t, codes.InvalidArgument, "should specify one filter, hash or seq", err)
+}
+
+func TestGRPCGetBalance(t *testing.T) {
+	addrs := []cipher.Address{makeAddress(), makeAddress()}
+
+	gateway := &MockGatewayer{}
+	gateway.On("GetBalanceOfAddresses", addrs).Return([]wallet.BalancePair{
+		{
+			Confirmed: wallet.Balance{Coins: 1e6, Hours: 10},
+			Predicted: wallet.Balance{Coins: 2e6, Hours: 20},
+		},
+		{
+			Confirmed: wallet.Balance{Coins: 3e6, Hours: 30},
+			Predicted: wallet.Balance{Coins: 3e6, Hours: 30},
+		},
+	}, nil)
+
+	c, stop := startTestGRPCServer(t, GRPCConfig{
+		EnabledAPISets: allAPISetsEnabled,
+	}, gateway)
+	defer stop()
+
+	ctx := context.Background()
+
+	resp, err := c.GetBalance(ctx, &grpcpb.GetBalanceRequest{
+		Addresses: []string{addrs[0].String(), addrs[1].String()},
+	})
+	require.NoError(t, err)
+	requireProtoEqual(t, &grpcpb.BalancePair{
+		Confirmed: &grpcpb.Balance{Coins: 4e6, Hours: 40},
+		Predicted: &grpcpb.Balance{Coins: 5e6, Hours: 50},
+	}, resp.Balance)
+	require.Len(t, resp.Addresses, 2)
+	require.Equal(t, uint64(1e6), resp.Addresses[addrs[0].String()].Confirmed.Coins)
+
+	_, err = c.GetBalance(ctx, &grpcpb.GetBalanceRequest{})
+	requireGRPCStatus(t, codes.InvalidArgument, "addresses is required", err)
+
+	_, err = c.GetBalance(ctx, &grpcpb.GetBalanceRequest{
+		Addresses: []string{"foo"},
+	})
+	requireGRPCStatus(t, codes.InvalidArgument, `address "foo" is invalid: Invalid address length`, err)
+}
+
+func TestGRPCInjectTransaction(t *testing.T) {
+	txn := makeTransaction(t)
+	txid := txn.Hash()
+	b, err := txn.Serialize()
+	require.NoError(t, err)
+
+	gateway := &MockGatewayer{}
+	gateway.On("InjectBroadcastTransaction", txn).Return(nil).Once()
+	gateway.On("InjectTransaction", txn).Return(visor.NewErrTxnViolatesSoftConstraint(errors.New("soft constraint"))).Once()
+
+	c, stop := startTestGRPCServer(t, GRPCConfig{
+		EnabledAPISets: allAPISetsEnabled,
+	}, gateway)
+	defer stop()
+
+	ctx := context.Background()
+
+	resp, err := c.InjectTransaction(ctx, &grpcpb.InjectTransactionRequest{
+		Transaction: b,
+	})
+	require.NoError(t, err)
+	require.Equal(t, txid[:], resp.Txid)
+
+	_, err = c.InjectTransaction(ctx, &grpcpb.InjectTransactionRequest{
+		Transaction: b,
+		NoBroadcast: true,
+	})
+	requireGRPCStatus(t, codes.FailedPrecondition, "Transaction violates soft constraint: soft constraint", err)
+
+	_, err = c.InjectTransaction(ctx, &grpcpb.InjectTransactionRequest{})
+	requireGRPCStatus(t, codes.InvalidArgument, "transaction is required", err)
+}
+
+func TestGRPCAuth(t *testing.T) {
+	gateway := &MockGatewayer{}
+	gateway.On("GetSignedBlockBySeq", uint64(1)).Return(nil, nil)
+
+	readOnly := map[string]struct{}{
+		EndpointsRead: struct{}{},
+	}
+
+	basicAuth := func(user, pass string) context.Context {
+		v := base64.StdEncoding.EncodeToString([]byte(user + ":" + pass))
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic "+v)
+	}
+
+	req := &grpcpb.GetBlockRequest{
+		Block: &grpcpb.GetBlockRequest_Seq{Seq: 1},
+	}
+
+	t.Run("api sets", func(t *testing.T) {
+		c, stop := startTestGRPCServer(t, GRPCConfig{
+			EnabledAPISets: readOnly,
+		}, gateway)
+		defer stop()
+
+		_, err := c.GetBlock(context.Background(), req)
+		requireGRPCStatus(t, codes.NotFound, "block not found", err)
+
+		_, err = c.InjectTransaction(context.Background(), &grpcpb.InjectTransactionRequest{})
+		requireGRPCStatus(t, codes.PermissionDenied, "Method is disabled", err)
+
+		// Credentials are rejected when auth is not configured
+		_, err = c.GetBlock(basicAuth("foo", "bar"), req)
+		requireGRPCStatus(t, codes.Unauthenticated, "auth is not enabled", err)
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer foo")
+		_, err = c.GetBlock(ctx, req)
+		requireGRPCStatus(t, codes.Unauthenticated, "API tokens are not enabled", err)
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		c, stop := startTestGRPCServer(t, GRPCConfig{
+			EnabledAPISets: readOnly,
+			Username:       "foo",
+			Password:       "bar",
+			PlaintextAuth:  true,
+		}, gateway)
+		defer stop()
+
+		_, err := c.GetBlock(context.Background(), req)
+		requireGRPCStatus(t, codes.Unauthenticated, "", err)
+
+		_, err = c.GetBlock(basicAuth("foo", "baz"), req)
+		requireGRPCStatus(t, codes.Unauthenticated, "", err)
+
+		_, err = c.GetBlock(basicAuth("foo", "bar"), req)
+		requireGRPCStatus(t, codes.NotFound, "block not found", err)
+	})
+
+	t.Run("basic auth without tls", func(t *testing.T) {
+		c, stop := startTestGRPCServer(t, GRPCConfig{
+			EnabledAPISets: readOnly,
+			Username:       "foo",
+			Password:       "bar",
+		}, gateway)
+		defer stop()
+
+		_, err := c.GetBlock(basicAuth("foo", "bar"), req)
+		requireGRPCStatus(t, codes.Unauthenticated, "basic auth requires TLS", err)
+	})
+
+	t.Run("api token without tls", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "apitokens")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "api_tokens.json")
+		_, secret, err := apitoken.Create(path, apitoken.CreateOptions{
+			Name:    "dashboard",
+			APISets: []string{EndpointsRead},
+		})
+		require.NoError(t, err)
+
+		store, err := apitoken.NewStore(path)
+		require.NoError(t, err)
+
+		c, stop := startTestGRPCServer(t, GRPCConfig{
+			EnabledAPISets: readOnly,
+			APITokens:      store,
+		}, gateway)
+		defer stop()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+secret)
+		_, err = c.GetBlock(ctx, req)
+		requireGRPCStatus(t, codes.Unauthenticated, "API tokens require TLS", err)
+	})
+}
+
+func TestGRPCRateLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apitokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := apitoken.NewStore(filepath.Join(dir, "api_tokens.json"))
+	require.NoError(t, err)
+
+	gateway := &MockGatewayer{}
+	gateway.On("GetSignedBlockBySeq", uint64(1)).Return(nil, nil)
+
+	c, stop := startTestGRPCServer(t, GRPCConfig{
+		EnabledAPISets: allAPISetsEnabled,
+		APITokens:      store,
+		PlaintextAuth:  true,
+		RateLimiter: NewRateLimiter(RateLimitConfig{
+			Rate:  0.001,
+			Burst: 3,
+			EndpointCosts: map[string]int{
+				"/api/v1/block": 2,
+			},
+		}),
+	}, gateway)
+	defer stop()
+
+	req := &grpcpb.GetBlockRequest{
+		Block: &grpcpb.GetBlockRequest_Seq{Seq: 1},
+	}
+
+	notFound := grpcCallDuration.Count("GetBlock", codes.NotFound.String())
+	exhausted := grpcCallDuration.Count("GetBlock", codes.ResourceExhausted.String())
+
+	// Each call is charged the cost of /api/v1/block
+	_, err = c.GetBlock(context.Background(), req)
+	requireGRPCStatus(t, codes.NotFound, "block not found", err)
+
+	// Failed authentications are charged to the IP address of the client
+	invalidToken := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer foo")
+	_, err = c.GetBlock(invalidToken, req)
+	requireGRPCStatus(t, codes.Unauthenticated, "invalid API token", err)
+
+	_, err = c.GetBlock(invalidToken, req)
+	requireGRPCStatus(t, codes.ResourceExhausted, "Too Many Requests", err)
+
+	_, err = c.GetBlock(context.Background(), req)
+	requireGRPCStatus(t, codes.ResourceExhausted, "Too Many Requests", err)
+
+	// The calls are recorded in the metrics
+	require.Equal(t, notFound+1, grpcCallDuration.Count("GetBlock", codes.NotFound.String()))
+	require.Equal(t, exhausted+2, grpcCallDuration.Count("GetBlock", codes.ResourceExhausted.String()))
+}
+
+func TestGRPCMethods(t *testing.T) {
+	// The equivalent endpoint of each method must exist, since its cost is charged for the method calls
+	endpoints := make(map[string]struct{})
+	for _, rt := range apiRoutes(defaultMuxConfig(), nil) {
+		endpoints[rt.endpoint] = struct{}{}
+	}
+
+	for name, m := range grpcMethods {
+		_, ok := endpoints[m.endpoint]
+		require.True(t, ok, "%s endpoint %q does not exist", name, m.endpoint)
+	}
+}
+
+func TestGRPCSubscribeBlocks(t *testing.T) {
+	blocks := []coin.SignedBlock{
+		{Block: coin.Block{Head: coin.BlockHeader{BkSeq: 4}}},
+		{Block: coin.Block{Head: coin.BlockHeader{BkSeq: 5}}},
+		{Block: coin.Block{Head: coin.BlockHeader{BkSeq: 6}}},
+	}
+
+	gateway := &MockGatewayer{}
+	gateway.On("HeadBkSeq").Return(uint64(5), true, nil).Times(2)
+	gateway.On("GetBlocksInRange", uint64(4), uint64(5)).Return(blocks[:2], nil).Once()
+	gateway.On("HeadBkSeq").Return(uint64(6), true, nil)
+	gateway.On("GetBlocksInRange", uint64(6), uint64(6)).Return(blocks[2:], nil).Once()
+
+	c, stop := startTestGRPCServer(t, GRPCConfig{
+		EnabledAPISets: allAPISetsEnabled,
+	}, gateway)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := c.SubscribeBlocks(ctx, &grpcpb.SubscribeBlocksRequest{
+		Start: &grpcpb.SubscribeBlocksRequest_StartSeq{StartSeq: 4},
+	})
+	require.NoError(t, err)
+
+	for _, b := range blocks {
+		pb, err := stream.Recv()
+		require.NoError(t, err)
+		requireProtoEqual(t, newPBBlock(b), pb)
+	}
+}
+
+func TestGRPCMaxClientStreams(t *testing.T) {
+	gateway := &MockGatewayer{}
+	gateway.On("GetAllUnconfirmedTransactions").Return(nil, nil)
+
+	c, stop := startTestGRPCServer(t, GRPCConfig{
+		EnabledAPISets:   allAPISetsEnabled,
+		MaxClientStreams: 2,
+	}, gateway)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscribe := func(ctx context.Context) error {
+		stream, err := c.SubscribeMempool(ctx, &grpcpb.SubscribeMempoolRequest{})
+		require.NoError(t, err)
+
+		// A refused stream ends with its status, an accepted stream waits for mempool changes
+		recvErr := make(chan error, 1)
+		go func() {
+			_, err := stream.Recv()
+			recvErr <- err
+		}()
+
+		select {
+		case err := <-recvErr:
+			return err
+		case <-time.After(time.Millisecond * 100):
+			return nil
+		}
+	}
+
+	require.NoError(t, subscribe(ctx))
+	require.NoError(t, subscribe(ctx))
+
+	err := subscribe(ctx)
+	requireGRPCStatus(t, codes.ResourceExhausted, "too many open streams", err)
+
+	// Closing a stream frees a slot
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	cancel()
+	time.Sleep(time.Millisecond * 100)
+
+	defer cancel2()
+	require.NoError(t, subscribe(ctx2))
+}
+
+func TestGRPCSubscribeSharedPoll(t *testing.T) {
+	var calls int32
+	gateway := &MockGatewayer{}
+	gateway.On("GetAllUnconfirmedTransactions").Return(nil, nil).Run(func(mock.Arguments) {
+		atomic.AddInt32(&calls, 1)
+	})
+
+	c, stop := startTestGRPCServer(t, GRPCConfig{
+		EnabledAPISets: allAPISetsEnabled,
+		PollInterval:   time.Millisecond * 50,
+	}, gateway)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const n = 5
+	for i := 0; i < n; i++ {
+		_, err := c.SubscribeMempool(ctx, &grpcpb.SubscribeMempoolRequest{})
+		require.NoError(t, err)
+	}
+
+	time.Sleep(time.Millisecond * 100)
+	start := atomic.LoadInt32(&calls)
+	require.True(t, start >= n)
+
+	time.Sleep(time.Millisecond * 500)
+
+	// The subscriptions share one poll of the mempool every interval, instead of polling it each
+	polls := atomic.LoadInt32(&calls) - start
+	require.True(t, polls > 0)
+	require.True(t, polls <= 11, "%d polls", polls)
+}
+
+func TestGRPCSubscribeMempool(t *testing.T) {
+	txnA := visor.UnconfirmedTransaction{
+		Transaction: makeTransaction(t),
+		Received:    1,
+	}
+	txnB := visor.UnconfirmedTransaction{
+		Transaction: makeTransaction(t),
+		Received:    2,
+	}
+
+	gateway := &MockGatewayer{}
+	gateway.On("GetAllUnconfirmedTransactions").Return([]visor.UnconfirmedTransaction{txnA}, nil).Once()
+	gateway.On("GetAllUnconfirmedTransactions").Return([]visor.UnconfirmedTransaction{txnB}, nil)
+
+	c, stop := startTestGRPCServer(t, GRPCConfig{
+		EnabledAPISets: allAPISetsEnabled,
+	}, gateway)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := c.SubscribeMempool(ctx, &grpcpb.SubscribeMempoolRequest{
+		IncludeExisting: true,
+	})
+	require.NoError(t, err)
+
+	expected := []*grpcpb.MempoolEvent{
+		{
+			Type:        grpcpb.MempoolEvent_ADDED,
+			Transaction: newPBTransaction(txnA.Transaction, false),
+			Received:    1,
+		},
+		{
+			Type:        grpcpb.MempoolEvent_REMOVED,
+			Transaction: newPBTransaction(txnA.Transaction, false),
+			Received:    1,
+		},
+		{
+			Type:        grpcpb.MempoolEvent_ADDED,
+			Transaction: newPBTransaction(txnB.Transaction, false),
+			Received:    2,
+		},
+	}
+
+	for _, e := range expected {
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		requireProtoEqual(t, e, event)
+	}
+}
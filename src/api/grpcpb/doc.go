@@ -0,0 +1,11 @@
+/*
+Package grpcpb contains the protocol buffer messages and the gRPC client and server stubs of the node's gRPC API.
+
+skycoin.pb.go is generated from skycoin.proto with protoc and protoc-gen-go v1.3.3:
+
+	go get github.com/golang/protobuf/protoc-gen-go@v1.3.3
+	go generate ./src/api/grpcpb
+*/
+package grpcpb
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. skycoin.proto
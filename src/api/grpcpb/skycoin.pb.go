@@ -0,0 +1,2090 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: skycoin.proto
+
+package grpcpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type MempoolEvent_Type int32
+
+const (
+	MempoolEvent_ADDED   MempoolEvent_Type = 0
+	MempoolEvent_REMOVED MempoolEvent_Type = 1
+)
+
+var MempoolEvent_Type_name = map[int32]string{
+	0: "ADDED",
+	1: "REMOVED",
+}
+
+var MempoolEvent_Type_value = map[string]int32{
+	"ADDED":   0,
+	"REMOVED": 1,
+}
+
+func (x MempoolEvent_Type) String() string {
+	return proto.EnumName(MempoolEvent_Type_name, int32(x))
+}
+
+func (MempoolEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{25, 0}
+}
+
+type BlockHeader struct {
+	Version              uint32   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Time                 uint64   `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Seq                  uint64   `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	Fee                  uint64   `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	PrevHash             []byte   `protobuf:"bytes,5,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	BodyHash             []byte   `protobuf:"bytes,6,opt,name=body_hash,json=bodyHash,proto3" json:"body_hash,omitempty"`
+	UxHash               []byte   `protobuf:"bytes,7,opt,name=ux_hash,json=uxHash,proto3" json:"ux_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
+func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
+func (*BlockHeader) ProtoMessage()    {}
+func (*BlockHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{0}
+}
+
+func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
+}
+func (m *BlockHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeader.Marshal(b, m, deterministic)
+}
+func (m *BlockHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeader.Merge(m, src)
+}
+func (m *BlockHeader) XXX_Size() int {
+	return xxx_messageInfo_BlockHeader.Size(m)
+}
+func (m *BlockHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeader proto.InternalMessageInfo
+
+func (m *BlockHeader) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetFee() uint64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetPrevHash() []byte {
+	if m != nil {
+		return m.PrevHash
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetBodyHash() []byte {
+	if m != nil {
+		return m.BodyHash
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetUxHash() []byte {
+	if m != nil {
+		return m.UxHash
+	}
+	return nil
+}
+
+type Block struct {
+	Header               *BlockHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Hash                 []byte         `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Transactions         []*Transaction `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Sig                  []byte         `protobuf:"bytes,4,opt,name=sig,proto3" json:"sig,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Block) Reset()         { *m = Block{} }
+func (m *Block) String() string { return proto.CompactTextString(m) }
+func (*Block) ProtoMessage()    {}
+func (*Block) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{1}
+}
+
+func (m *Block) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Block.Unmarshal(m, b)
+}
+func (m *Block) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Block.Marshal(b, m, deterministic)
+}
+func (m *Block) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Block.Merge(m, src)
+}
+func (m *Block) XXX_Size() int {
+	return xxx_messageInfo_Block.Size(m)
+}
+func (m *Block) XXX_DiscardUnknown() {
+	xxx_messageInfo_Block.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Block proto.InternalMessageInfo
+
+func (m *Block) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *Block) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Block) GetTransactions() []*Transaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *Block) GetSig() []byte {
+	if m != nil {
+		return m.Sig
+	}
+	return nil
+}
+
+type Transaction struct {
+	Hash                 []byte               `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	InnerHash            []byte               `protobuf:"bytes,2,opt,name=inner_hash,json=innerHash,proto3" json:"inner_hash,omitempty"`
+	Type                 uint32               `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	Length               uint32               `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	Sigs                 [][]byte             `protobuf:"bytes,5,rep,name=sigs,proto3" json:"sigs,omitempty"`
+	Inputs               [][]byte             `protobuf:"bytes,6,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs              []*TransactionOutput `protobuf:"bytes,7,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{2}
+}
+
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transaction.Unmarshal(m, b)
+}
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+}
+func (m *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(m, src)
+}
+func (m *Transaction) XXX_Size() int {
+	return xxx_messageInfo_Transaction.Size(m)
+}
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
+
+func (m *Transaction) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Transaction) GetInnerHash() []byte {
+	if m != nil {
+		return m.InnerHash
+	}
+	return nil
+}
+
+func (m *Transaction) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *Transaction) GetLength() uint32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *Transaction) GetSigs() [][]byte {
+	if m != nil {
+		return m.Sigs
+	}
+	return nil
+}
+
+func (m *Transaction) GetInputs() [][]byte {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *Transaction) GetOutputs() []*TransactionOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type TransactionOutput struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Coins                uint64   `protobuf:"varint,3,opt,name=coins,proto3" json:"coins,omitempty"`
+	Hours                uint64   `protobuf:"varint,4,opt,name=hours,proto3" json:"hours,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionOutput) Reset()         { *m = TransactionOutput{} }
+func (m *TransactionOutput) String() string { return proto.CompactTextString(m) }
+func (*TransactionOutput) ProtoMessage()    {}
+func (*TransactionOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{3}
+}
+
+func (m *TransactionOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionOutput.Unmarshal(m, b)
+}
+func (m *TransactionOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionOutput.Marshal(b, m, deterministic)
+}
+func (m *TransactionOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionOutput.Merge(m, src)
+}
+func (m *TransactionOutput) XXX_Size() int {
+	return xxx_messageInfo_TransactionOutput.Size(m)
+}
+func (m *TransactionOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionOutput proto.InternalMessageInfo
+
+func (m *TransactionOutput) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *TransactionOutput) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *TransactionOutput) GetCoins() uint64 {
+	if m != nil {
+		return m.Coins
+	}
+	return 0
+}
+
+func (m *TransactionOutput) GetHours() uint64 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+type TransactionStatus struct {
+	Confirmed            bool     `protobuf:"varint,1,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	BlockSeq             uint64   `protobuf:"varint,3,opt,name=block_seq,json=blockSeq,proto3" json:"block_seq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionStatus) Reset()         { *m = TransactionStatus{} }
+func (m *TransactionStatus) String() string { return proto.CompactTextString(m) }
+func (*TransactionStatus) ProtoMessage()    {}
+func (*TransactionStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{4}
+}
+
+func (m *TransactionStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionStatus.Unmarshal(m, b)
+}
+func (m *TransactionStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionStatus.Marshal(b, m, deterministic)
+}
+func (m *TransactionStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionStatus.Merge(m, src)
+}
+func (m *TransactionStatus) XXX_Size() int {
+	return xxx_messageInfo_TransactionStatus.Size(m)
+}
+func (m *TransactionStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionStatus proto.InternalMessageInfo
+
+func (m *TransactionStatus) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *TransactionStatus) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *TransactionStatus) GetBlockSeq() uint64 {
+	if m != nil {
+		return m.BlockSeq
+	}
+	return 0
+}
+
+type TransactionWithStatus struct {
+	Transaction          *Transaction       `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Status               *TransactionStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Time                 uint64             `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *TransactionWithStatus) Reset()         { *m = TransactionWithStatus{} }
+func (m *TransactionWithStatus) String() string { return proto.CompactTextString(m) }
+func (*TransactionWithStatus) ProtoMessage()    {}
+func (*TransactionWithStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{5}
+}
+
+func (m *TransactionWithStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionWithStatus.Unmarshal(m, b)
+}
+func (m *TransactionWithStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionWithStatus.Marshal(b, m, deterministic)
+}
+func (m *TransactionWithStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionWithStatus.Merge(m, src)
+}
+func (m *TransactionWithStatus) XXX_Size() int {
+	return xxx_messageInfo_TransactionWithStatus.Size(m)
+}
+func (m *TransactionWithStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionWithStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionWithStatus proto.InternalMessageInfo
+
+func (m *TransactionWithStatus) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *TransactionWithStatus) GetStatus() *TransactionStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *TransactionWithStatus) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type UnspentOutput struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Time                 uint64   `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	BlockSeq             uint64   `protobuf:"varint,3,opt,name=block_seq,json=blockSeq,proto3" json:"block_seq,omitempty"`
+	SrcTransaction       []byte   `protobuf:"bytes,4,opt,name=src_transaction,json=srcTransaction,proto3" json:"src_transaction,omitempty"`
+	Address              string   `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	Coins                uint64   `protobuf:"varint,6,opt,name=coins,proto3" json:"coins,omitempty"`
+	Hours                uint64   `protobuf:"varint,7,opt,name=hours,proto3" json:"hours,omitempty"`
+	CalculatedHours      uint64   `protobuf:"varint,8,opt,name=calculated_hours,json=calculatedHours,proto3" json:"calculated_hours,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnspentOutput) Reset()         { *m = UnspentOutput{} }
+func (m *UnspentOutput) String() string { return proto.CompactTextString(m) }
+func (*UnspentOutput) ProtoMessage()    {}
+func (*UnspentOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{6}
+}
+
+func (m *UnspentOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnspentOutput.Unmarshal(m, b)
+}
+func (m *UnspentOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnspentOutput.Marshal(b, m, deterministic)
+}
+func (m *UnspentOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnspentOutput.Merge(m, src)
+}
+func (m *UnspentOutput) XXX_Size() int {
+	return xxx_messageInfo_UnspentOutput.Size(m)
+}
+func (m *UnspentOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnspentOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnspentOutput proto.InternalMessageInfo
+
+func (m *UnspentOutput) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *UnspentOutput) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *UnspentOutput) GetBlockSeq() uint64 {
+	if m != nil {
+		return m.BlockSeq
+	}
+	return 0
+}
+
+func (m *UnspentOutput) GetSrcTransaction() []byte {
+	if m != nil {
+		return m.SrcTransaction
+	}
+	return nil
+}
+
+func (m *UnspentOutput) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *UnspentOutput) GetCoins() uint64 {
+	if m != nil {
+		return m.Coins
+	}
+	return 0
+}
+
+func (m *UnspentOutput) GetHours() uint64 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+func (m *UnspentOutput) GetCalculatedHours() uint64 {
+	if m != nil {
+		return m.CalculatedHours
+	}
+	return 0
+}
+
+type Balance struct {
+	Coins                uint64   `protobuf:"varint,1,opt,name=coins,proto3" json:"coins,omitempty"`
+	Hours                uint64   `protobuf:"varint,2,opt,name=hours,proto3" json:"hours,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Balance) Reset()         { *m = Balance{} }
+func (m *Balance) String() string { return proto.CompactTextString(m) }
+func (*Balance) ProtoMessage()    {}
+func (*Balance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{7}
+}
+
+func (m *Balance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Balance.Unmarshal(m, b)
+}
+func (m *Balance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Balance.Marshal(b, m, deterministic)
+}
+func (m *Balance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Balance.Merge(m, src)
+}
+func (m *Balance) XXX_Size() int {
+	return xxx_messageInfo_Balance.Size(m)
+}
+func (m *Balance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Balance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Balance proto.InternalMessageInfo
+
+func (m *Balance) GetCoins() uint64 {
+	if m != nil {
+		return m.Coins
+	}
+	return 0
+}
+
+func (m *Balance) GetHours() uint64 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+type BalancePair struct {
+	Confirmed            *Balance `protobuf:"bytes,1,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Predicted            *Balance `protobuf:"bytes,2,opt,name=predicted,proto3" json:"predicted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalancePair) Reset()         { *m = BalancePair{} }
+func (m *BalancePair) String() string { return proto.CompactTextString(m) }
+func (*BalancePair) ProtoMessage()    {}
+func (*BalancePair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{8}
+}
+
+func (m *BalancePair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalancePair.Unmarshal(m, b)
+}
+func (m *BalancePair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalancePair.Marshal(b, m, deterministic)
+}
+func (m *BalancePair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalancePair.Merge(m, src)
+}
+func (m *BalancePair) XXX_Size() int {
+	return xxx_messageInfo_BalancePair.Size(m)
+}
+func (m *BalancePair) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalancePair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalancePair proto.InternalMessageInfo
+
+func (m *BalancePair) GetConfirmed() *Balance {
+	if m != nil {
+		return m.Confirmed
+	}
+	return nil
+}
+
+func (m *BalancePair) GetPredicted() *Balance {
+	if m != nil {
+		return m.Predicted
+	}
+	return nil
+}
+
+type GetBlockchainMetadataRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockchainMetadataRequest) Reset()         { *m = GetBlockchainMetadataRequest{} }
+func (m *GetBlockchainMetadataRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockchainMetadataRequest) ProtoMessage()    {}
+func (*GetBlockchainMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{9}
+}
+
+func (m *GetBlockchainMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockchainMetadataRequest.Unmarshal(m, b)
+}
+func (m *GetBlockchainMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockchainMetadataRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockchainMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockchainMetadataRequest.Merge(m, src)
+}
+func (m *GetBlockchainMetadataRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockchainMetadataRequest.Size(m)
+}
+func (m *GetBlockchainMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockchainMetadataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockchainMetadataRequest proto.InternalMessageInfo
+
+type BlockchainMetadata struct {
+	Head                 *BlockHeader `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	HeadHash             []byte       `protobuf:"bytes,2,opt,name=head_hash,json=headHash,proto3" json:"head_hash,omitempty"`
+	Unspents             uint64       `protobuf:"varint,3,opt,name=unspents,proto3" json:"unspents,omitempty"`
+	Unconfirmed          uint64       `protobuf:"varint,4,opt,name=unconfirmed,proto3" json:"unconfirmed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *BlockchainMetadata) Reset()         { *m = BlockchainMetadata{} }
+func (m *BlockchainMetadata) String() string { return proto.CompactTextString(m) }
+func (*BlockchainMetadata) ProtoMessage()    {}
+func (*BlockchainMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{10}
+}
+
+func (m *BlockchainMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockchainMetadata.Unmarshal(m, b)
+}
+func (m *BlockchainMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockchainMetadata.Marshal(b, m, deterministic)
+}
+func (m *BlockchainMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockchainMetadata.Merge(m, src)
+}
+func (m *BlockchainMetadata) XXX_Size() int {
+	return xxx_messageInfo_BlockchainMetadata.Size(m)
+}
+func (m *BlockchainMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockchainMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockchainMetadata proto.InternalMessageInfo
+
+func (m *BlockchainMetadata) GetHead() *BlockHeader {
+	if m != nil {
+		return m.Head
+	}
+	return nil
+}
+
+func (m *BlockchainMetadata) GetHeadHash() []byte {
+	if m != nil {
+		return m.HeadHash
+	}
+	return nil
+}
+
+func (m *BlockchainMetadata) GetUnspents() uint64 {
+	if m != nil {
+		return m.Unspents
+	}
+	return 0
+}
+
+func (m *BlockchainMetadata) GetUnconfirmed() uint64 {
+	if m != nil {
+		return m.Unconfirmed
+	}
+	return 0
+}
+
+type GetBlockRequest struct {
+	// Types that are valid to be assigned to Block:
+	//	*GetBlockRequest_Hash
+	//	*GetBlockRequest_Seq
+	Block                isGetBlockRequest_Block `protobuf_oneof:"block"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetBlockRequest) Reset()         { *m = GetBlockRequest{} }
+func (m *GetBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockRequest) ProtoMessage()    {}
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{11}
+}
+
+func (m *GetBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockRequest.Unmarshal(m, b)
+}
+func (m *GetBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockRequest.Merge(m, src)
+}
+func (m *GetBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockRequest.Size(m)
+}
+func (m *GetBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockRequest proto.InternalMessageInfo
+
+type isGetBlockRequest_Block interface {
+	isGetBlockRequest_Block()
+}
+
+type GetBlockRequest_Hash struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3,oneof"`
+}
+
+type GetBlockRequest_Seq struct {
+	Seq uint64 `protobuf:"varint,2,opt,name=seq,proto3,oneof"`
+}
+
+func (*GetBlockRequest_Hash) isGetBlockRequest_Block() {}
+
+func (*GetBlockRequest_Seq) isGetBlockRequest_Block() {}
+
+func (m *GetBlockRequest) GetBlock() isGetBlockRequest_Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *GetBlockRequest) GetHash() []byte {
+	if x, ok := m.GetBlock().(*GetBlockRequest_Hash); ok {
+		return x.Hash
+	}
+	return nil
+}
+
+func (m *GetBlockRequest) GetSeq() uint64 {
+	if x, ok := m.GetBlock().(*GetBlockRequest_Seq); ok {
+		return x.Seq
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*GetBlockRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*GetBlockRequest_Hash)(nil),
+		(*GetBlockRequest_Seq)(nil),
+	}
+}
+
+type GetBlocksRequest struct {
+	Start                uint64   `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  uint64   `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlocksRequest) Reset()         { *m = GetBlocksRequest{} }
+func (m *GetBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlocksRequest) ProtoMessage()    {}
+func (*GetBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{12}
+}
+
+func (m *GetBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlocksRequest.Unmarshal(m, b)
+}
+func (m *GetBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlocksRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlocksRequest.Merge(m, src)
+}
+func (m *GetBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlocksRequest.Size(m)
+}
+func (m *GetBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlocksRequest proto.InternalMessageInfo
+
+func (m *GetBlocksRequest) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *GetBlocksRequest) GetEnd() uint64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type GetBlocksResponse struct {
+	Blocks               []*Block `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlocksResponse) Reset()         { *m = GetBlocksResponse{} }
+func (m *GetBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlocksResponse) ProtoMessage()    {}
+func (*GetBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{13}
+}
+
+func (m *GetBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlocksResponse.Unmarshal(m, b)
+}
+func (m *GetBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlocksResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlocksResponse.Merge(m, src)
+}
+func (m *GetBlocksResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlocksResponse.Size(m)
+}
+func (m *GetBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlocksResponse proto.InternalMessageInfo
+
+func (m *GetBlocksResponse) GetBlocks() []*Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+type GetTransactionRequest struct {
+	Txid                 []byte   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
+func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionRequest) ProtoMessage()    {}
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{14}
+}
+
+func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionRequest.Merge(m, src)
+}
+func (m *GetTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionRequest.Size(m)
+}
+func (m *GetTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionRequest proto.InternalMessageInfo
+
+func (m *GetTransactionRequest) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+type GetUnspentOutputsRequest struct {
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Hashes               [][]byte `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetUnspentOutputsRequest) Reset()         { *m = GetUnspentOutputsRequest{} }
+func (m *GetUnspentOutputsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUnspentOutputsRequest) ProtoMessage()    {}
+func (*GetUnspentOutputsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{15}
+}
+
+func (m *GetUnspentOutputsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUnspentOutputsRequest.Unmarshal(m, b)
+}
+func (m *GetUnspentOutputsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUnspentOutputsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetUnspentOutputsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUnspentOutputsRequest.Merge(m, src)
+}
+func (m *GetUnspentOutputsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetUnspentOutputsRequest.Size(m)
+}
+func (m *GetUnspentOutputsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUnspentOutputsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUnspentOutputsRequest proto.InternalMessageInfo
+
+func (m *GetUnspentOutputsRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *GetUnspentOutputsRequest) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+type UnspentOutputs struct {
+	Head                 *BlockHeader     `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	Confirmed            []*UnspentOutput `protobuf:"bytes,2,rep,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Outgoing             []*UnspentOutput `protobuf:"bytes,3,rep,name=outgoing,proto3" json:"outgoing,omitempty"`
+	Incoming             []*UnspentOutput `protobuf:"bytes,4,rep,name=incoming,proto3" json:"incoming,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *UnspentOutputs) Reset()         { *m = UnspentOutputs{} }
+func (m *UnspentOutputs) String() string { return proto.CompactTextString(m) }
+func (*UnspentOutputs) ProtoMessage()    {}
+func (*UnspentOutputs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{16}
+}
+
+func (m *UnspentOutputs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnspentOutputs.Unmarshal(m, b)
+}
+func (m *UnspentOutputs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnspentOutputs.Marshal(b, m, deterministic)
+}
+func (m *UnspentOutputs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnspentOutputs.Merge(m, src)
+}
+func (m *UnspentOutputs) XXX_Size() int {
+	return xxx_messageInfo_UnspentOutputs.Size(m)
+}
+func (m *UnspentOutputs) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnspentOutputs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnspentOutputs proto.InternalMessageInfo
+
+func (m *UnspentOutputs) GetHead() *BlockHeader {
+	if m != nil {
+		return m.Head
+	}
+	return nil
+}
+
+func (m *UnspentOutputs) GetConfirmed() []*UnspentOutput {
+	if m != nil {
+		return m.Confirmed
+	}
+	return nil
+}
+
+func (m *UnspentOutputs) GetOutgoing() []*UnspentOutput {
+	if m != nil {
+		return m.Outgoing
+	}
+	return nil
+}
+
+func (m *UnspentOutputs) GetIncoming() []*UnspentOutput {
+	if m != nil {
+		return m.Incoming
+	}
+	return nil
+}
+
+type GetBalanceRequest struct {
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBalanceRequest) Reset()         { *m = GetBalanceRequest{} }
+func (m *GetBalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBalanceRequest) ProtoMessage()    {}
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{17}
+}
+
+func (m *GetBalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBalanceRequest.Unmarshal(m, b)
+}
+func (m *GetBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBalanceRequest.Merge(m, src)
+}
+func (m *GetBalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBalanceRequest.Size(m)
+}
+func (m *GetBalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBalanceRequest proto.InternalMessageInfo
+
+func (m *GetBalanceRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type GetBalanceResponse struct {
+	Balance              *BalancePair            `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Addresses            map[string]*BalancePair `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetBalanceResponse) Reset()         { *m = GetBalanceResponse{} }
+func (m *GetBalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBalanceResponse) ProtoMessage()    {}
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{18}
+}
+
+func (m *GetBalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBalanceResponse.Unmarshal(m, b)
+}
+func (m *GetBalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBalanceResponse.Merge(m, src)
+}
+func (m *GetBalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBalanceResponse.Size(m)
+}
+func (m *GetBalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBalanceResponse proto.InternalMessageInfo
+
+func (m *GetBalanceResponse) GetBalance() *BalancePair {
+	if m != nil {
+		return m.Balance
+	}
+	return nil
+}
+
+func (m *GetBalanceResponse) GetAddresses() map[string]*BalancePair {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type InjectTransactionRequest struct {
+	Transaction          []byte   `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	NoBroadcast          bool     `protobuf:"varint,2,opt,name=no_broadcast,json=noBroadcast,proto3" json:"no_broadcast,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InjectTransactionRequest) Reset()         { *m = InjectTransactionRequest{} }
+func (m *InjectTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*InjectTransactionRequest) ProtoMessage()    {}
+func (*InjectTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{19}
+}
+
+func (m *InjectTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InjectTransactionRequest.Unmarshal(m, b)
+}
+func (m *InjectTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InjectTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *InjectTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InjectTransactionRequest.Merge(m, src)
+}
+func (m *InjectTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_InjectTransactionRequest.Size(m)
+}
+func (m *InjectTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InjectTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InjectTransactionRequest proto.InternalMessageInfo
+
+func (m *InjectTransactionRequest) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *InjectTransactionRequest) GetNoBroadcast() bool {
+	if m != nil {
+		return m.NoBroadcast
+	}
+	return false
+}
+
+type InjectTransactionResponse struct {
+	Txid                 []byte   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InjectTransactionResponse) Reset()         { *m = InjectTransactionResponse{} }
+func (m *InjectTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*InjectTransactionResponse) ProtoMessage()    {}
+func (*InjectTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{20}
+}
+
+func (m *InjectTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InjectTransactionResponse.Unmarshal(m, b)
+}
+func (m *InjectTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InjectTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *InjectTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InjectTransactionResponse.Merge(m, src)
+}
+func (m *InjectTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_InjectTransactionResponse.Size(m)
+}
+func (m *InjectTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InjectTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InjectTransactionResponse proto.InternalMessageInfo
+
+func (m *InjectTransactionResponse) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+type VerifyTransactionRequest struct {
+	Transaction          []byte   `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Unsigned             bool     `protobuf:"varint,2,opt,name=unsigned,proto3" json:"unsigned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyTransactionRequest) Reset()         { *m = VerifyTransactionRequest{} }
+func (m *VerifyTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyTransactionRequest) ProtoMessage()    {}
+func (*VerifyTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{21}
+}
+
+func (m *VerifyTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyTransactionRequest.Unmarshal(m, b)
+}
+func (m *VerifyTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *VerifyTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyTransactionRequest.Merge(m, src)
+}
+func (m *VerifyTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyTransactionRequest.Size(m)
+}
+func (m *VerifyTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyTransactionRequest proto.InternalMessageInfo
+
+func (m *VerifyTransactionRequest) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *VerifyTransactionRequest) GetUnsigned() bool {
+	if m != nil {
+		return m.Unsigned
+	}
+	return false
+}
+
+type VerifyTransactionResponse struct {
+	Error                string           `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Confirmed            bool             `protobuf:"varint,2,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Unsigned             bool             `protobuf:"varint,3,opt,name=unsigned,proto3" json:"unsigned,omitempty"`
+	Transaction          *Transaction     `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Inputs               []*UnspentOutput `protobuf:"bytes,5,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *VerifyTransactionResponse) Reset()         { *m = VerifyTransactionResponse{} }
+func (m *VerifyTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyTransactionResponse) ProtoMessage()    {}
+func (*VerifyTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{22}
+}
+
+func (m *VerifyTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyTransactionResponse.Unmarshal(m, b)
+}
+func (m *VerifyTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *VerifyTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyTransactionResponse.Merge(m, src)
+}
+func (m *VerifyTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_VerifyTransactionResponse.Size(m)
+}
+func (m *VerifyTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyTransactionResponse proto.InternalMessageInfo
+
+func (m *VerifyTransactionResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *VerifyTransactionResponse) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *VerifyTransactionResponse) GetUnsigned() bool {
+	if m != nil {
+		return m.Unsigned
+	}
+	return false
+}
+
+func (m *VerifyTransactionResponse) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *VerifyTransactionResponse) GetInputs() []*UnspentOutput {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+type SubscribeBlocksRequest struct {
+	// Types that are valid to be assigned to Start:
+	//	*SubscribeBlocksRequest_StartSeq
+	Start                isSubscribeBlocksRequest_Start `protobuf_oneof:"start"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *SubscribeBlocksRequest) Reset()         { *m = SubscribeBlocksRequest{} }
+func (m *SubscribeBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeBlocksRequest) ProtoMessage()    {}
+func (*SubscribeBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{23}
+}
+
+func (m *SubscribeBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeBlocksRequest.Unmarshal(m, b)
+}
+func (m *SubscribeBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeBlocksRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeBlocksRequest.Merge(m, src)
+}
+func (m *SubscribeBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeBlocksRequest.Size(m)
+}
+func (m *SubscribeBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeBlocksRequest proto.InternalMessageInfo
+
+type isSubscribeBlocksRequest_Start interface {
+	isSubscribeBlocksRequest_Start()
+}
+
+type SubscribeBlocksRequest_StartSeq struct {
+	StartSeq uint64 `protobuf:"varint,1,opt,name=start_seq,json=startSeq,proto3,oneof"`
+}
+
+func (*SubscribeBlocksRequest_StartSeq) isSubscribeBlocksRequest_Start() {}
+
+func (m *SubscribeBlocksRequest) GetStart() isSubscribeBlocksRequest_Start {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *SubscribeBlocksRequest) GetStartSeq() uint64 {
+	if x, ok := m.GetStart().(*SubscribeBlocksRequest_StartSeq); ok {
+		return x.StartSeq
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SubscribeBlocksRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SubscribeBlocksRequest_StartSeq)(nil),
+	}
+}
+
+type SubscribeMempoolRequest struct {
+	IncludeExisting      bool     `protobuf:"varint,1,opt,name=include_existing,json=includeExisting,proto3" json:"include_existing,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeMempoolRequest) Reset()         { *m = SubscribeMempoolRequest{} }
+func (m *SubscribeMempoolRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeMempoolRequest) ProtoMessage()    {}
+func (*SubscribeMempoolRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{24}
+}
+
+func (m *SubscribeMempoolRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeMempoolRequest.Unmarshal(m, b)
+}
+func (m *SubscribeMempoolRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeMempoolRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeMempoolRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeMempoolRequest.Merge(m, src)
+}
+func (m *SubscribeMempoolRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeMempoolRequest.Size(m)
+}
+func (m *SubscribeMempoolRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeMempoolRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeMempoolRequest proto.InternalMessageInfo
+
+func (m *SubscribeMempoolRequest) GetIncludeExisting() bool {
+	if m != nil {
+		return m.IncludeExisting
+	}
+	return false
+}
+
+type MempoolEvent struct {
+	Type                 MempoolEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=skycoin.api.MempoolEvent_Type" json:"type,omitempty"`
+	Transaction          *Transaction      `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Received             int64             `protobuf:"varint,3,opt,name=received,proto3" json:"received,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MempoolEvent) Reset()         { *m = MempoolEvent{} }
+func (m *MempoolEvent) String() string { return proto.CompactTextString(m) }
+func (*MempoolEvent) ProtoMessage()    {}
+func (*MempoolEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0db98435da4d5786, []int{25}
+}
+
+func (m *MempoolEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MempoolEvent.Unmarshal(m, b)
+}
+func (m *MempoolEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MempoolEvent.Marshal(b, m, deterministic)
+}
+func (m *MempoolEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolEvent.Merge(m, src)
+}
+func (m *MempoolEvent) XXX_Size() int {
+	return xxx_messageInfo_MempoolEvent.Size(m)
+}
+func (m *MempoolEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolEvent proto.InternalMessageInfo
+
+func (m *MempoolEvent) GetType() MempoolEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return MempoolEvent_ADDED
+}
+
+func (m *MempoolEvent) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *MempoolEvent) GetReceived() int64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("skycoin.api.MempoolEvent_Type", MempoolEvent_Type_name, MempoolEvent_Type_value)
+	proto.RegisterType((*BlockHeader)(nil), "skycoin.api.BlockHeader")
+	proto.RegisterType((*Block)(nil), "skycoin.api.Block")
+	proto.RegisterType((*Transaction)(nil), "skycoin.api.Transaction")
+	proto.RegisterType((*TransactionOutput)(nil), "skycoin.api.TransactionOutput")
+	proto.RegisterType((*TransactionStatus)(nil), "skycoin.api.TransactionStatus")
+	proto.RegisterType((*TransactionWithStatus)(nil), "skycoin.api.TransactionWithStatus")
+	proto.RegisterType((*UnspentOutput)(nil), "skycoin.api.UnspentOutput")
+	proto.RegisterType((*Balance)(nil), "skycoin.api.Balance")
+	proto.RegisterType((*BalancePair)(nil), "skycoin.api.BalancePair")
+	proto.RegisterType((*GetBlockchainMetadataRequest)(nil), "skycoin.api.GetBlockchainMetadataRequest")
+	proto.RegisterType((*BlockchainMetadata)(nil), "skycoin.api.BlockchainMetadata")
+	proto.RegisterType((*GetBlockRequest)(nil), "skycoin.api.GetBlockRequest")
+	proto.RegisterType((*GetBlocksRequest)(nil), "skycoin.api.GetBlocksRequest")
+	proto.RegisterType((*GetBlocksResponse)(nil), "skycoin.api.GetBlocksResponse")
+	proto.RegisterType((*GetTransactionRequest)(nil), "skycoin.api.GetTransactionRequest")
+	proto.RegisterType((*GetUnspentOutputsRequest)(nil), "skycoin.api.GetUnspentOutputsRequest")
+	proto.RegisterType((*UnspentOutputs)(nil), "skycoin.api.UnspentOutputs")
+	proto.RegisterType((*GetBalanceRequest)(nil), "skycoin.api.GetBalanceRequest")
+	proto.RegisterType((*GetBalanceResponse)(nil), "skycoin.api.GetBalanceResponse")
+	proto.RegisterMapType((map[string]*BalancePair)(nil), "skycoin.api.GetBalanceResponse.AddressesEntry")
+	proto.RegisterType((*InjectTransactionRequest)(nil), "skycoin.api.InjectTransactionRequest")
+	proto.RegisterType((*InjectTransactionResponse)(nil), "skycoin.api.InjectTransactionResponse")
+	proto.RegisterType((*VerifyTransactionRequest)(nil), "skycoin.api.VerifyTransactionRequest")
+	proto.RegisterType((*VerifyTransactionResponse)(nil), "skycoin.api.VerifyTransactionResponse")
+	proto.RegisterType((*SubscribeBlocksRequest)(nil), "skycoin.api.SubscribeBlocksRequest")
+	proto.RegisterType((*SubscribeMempoolRequest)(nil), "skycoin.api.SubscribeMempoolRequest")
+	proto.RegisterType((*MempoolEvent)(nil), "skycoin.api.MempoolEvent")
+}
+
+func init() { proto.RegisterFile("skycoin.proto", fileDescriptor_0db98435da4d5786) }
+
+var fileDescriptor_0db98435da4d5786 = []byte{
+	// 1386 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdd, 0x6e, 0x1b, 0x45,
+	0x14, 0xee, 0xfa, 0xdf, 0xc7, 0xf9, 0x71, 0x47, 0x69, 0xbb, 0x75, 0xd3, 0x60, 0x16, 0x28, 0x2d,
+	0x20, 0x27, 0x18, 0x51, 0x55, 0xa5, 0x12, 0x34, 0x4a, 0xd4, 0x80, 0x1a, 0x5a, 0x6d, 0xfa, 0x27,
+	0x6e, 0xac, 0xf5, 0xee, 0xc4, 0x1e, 0xea, 0xcc, 0xba, 0x3b, 0xb3, 0x51, 0xfc, 0x30, 0x88, 0x77,
+	0xe0, 0x92, 0xc7, 0xe0, 0x96, 0x5b, 0x2e, 0xb8, 0xe2, 0x19, 0xd0, 0x9c, 0x9d, 0xb5, 0x67, 0xed,
+	0x75, 0x4c, 0xb9, 0xf2, 0xcc, 0x99, 0xf3, 0x37, 0xe7, 0x7c, 0xe7, 0x9b, 0x95, 0x61, 0x5d, 0xbc,
+	0x9d, 0xf8, 0x21, 0xe3, 0x9d, 0x71, 0x14, 0xca, 0x90, 0x34, 0xd2, 0xad, 0x37, 0x66, 0xce, 0x6f,
+	0x16, 0x34, 0xf6, 0x47, 0xa1, 0xff, 0xf6, 0x88, 0x7a, 0x01, 0x8d, 0x88, 0x0d, 0xd5, 0x73, 0x1a,
+	0x09, 0x16, 0x72, 0xdb, 0x6a, 0x5b, 0x77, 0xd7, 0xdd, 0x74, 0x4b, 0x08, 0x94, 0x24, 0x3b, 0xa3,
+	0x76, 0xa1, 0x6d, 0xdd, 0x2d, 0xb9, 0xb8, 0x26, 0x4d, 0x28, 0x0a, 0xfa, 0xce, 0x2e, 0xa2, 0x48,
+	0x2d, 0x95, 0xe4, 0x94, 0x52, 0xbb, 0x94, 0x48, 0x4e, 0x29, 0x25, 0xb7, 0xa0, 0x3e, 0x8e, 0xe8,
+	0x79, 0x6f, 0xe8, 0x89, 0xa1, 0x5d, 0x6e, 0x5b, 0x77, 0xd7, 0xdc, 0x9a, 0x12, 0x1c, 0x79, 0x62,
+	0xa8, 0x0e, 0xfb, 0x61, 0x30, 0x49, 0x0e, 0x2b, 0xc9, 0xa1, 0x12, 0xe0, 0xe1, 0x0d, 0xa8, 0xc6,
+	0x17, 0xc9, 0x51, 0x15, 0x8f, 0x2a, 0xf1, 0x85, 0x3a, 0x70, 0x7e, 0xb1, 0xa0, 0x8c, 0x49, 0x93,
+	0x3d, 0xa8, 0x0c, 0x31, 0x71, 0xcc, 0xb6, 0xd1, 0xb5, 0x3b, 0xc6, 0xe5, 0x3a, 0xc6, 0xc5, 0x5c,
+	0xad, 0xa7, 0xae, 0x81, 0x1e, 0x0b, 0xe8, 0x11, 0xd7, 0xe4, 0x11, 0xac, 0xc9, 0xc8, 0xe3, 0xc2,
+	0xf3, 0x25, 0x0b, 0xb9, 0xb0, 0x8b, 0xed, 0xe2, 0x82, 0xaf, 0x17, 0x33, 0x05, 0x37, 0xa3, 0x8d,
+	0x45, 0x60, 0x03, 0xbc, 0xf2, 0x9a, 0xab, 0x96, 0xce, 0x1f, 0x16, 0x34, 0x0c, 0xfd, 0x69, 0x4c,
+	0xcb, 0x88, 0x79, 0x1b, 0x80, 0x71, 0x4e, 0xa3, 0x9e, 0x91, 0x4d, 0x1d, 0x25, 0x78, 0x77, 0x55,
+	0xed, 0xc9, 0x98, 0x62, 0x69, 0xd7, 0x5d, 0x5c, 0x93, 0xeb, 0x50, 0x19, 0x51, 0x3e, 0x90, 0x43,
+	0x8c, 0xb5, 0xee, 0xea, 0x9d, 0xd2, 0x15, 0x6c, 0x20, 0xec, 0x72, 0xbb, 0xa8, 0xdc, 0xab, 0xb5,
+	0xd2, 0x65, 0x7c, 0x1c, 0x4b, 0x61, 0x57, 0x50, 0xaa, 0x77, 0xe4, 0x01, 0x54, 0xc3, 0x58, 0xe2,
+	0x41, 0x15, 0x6f, 0xb9, 0xb3, 0xec, 0x96, 0xcf, 0x50, 0xcd, 0x4d, 0xd5, 0x9d, 0x33, 0xb8, 0xba,
+	0x70, 0x9a, 0x7b, 0x33, 0x1b, 0xaa, 0x5e, 0x10, 0x44, 0x54, 0x08, 0xbc, 0x56, 0xdd, 0x4d, 0xb7,
+	0x64, 0x0b, 0xca, 0x2a, 0x92, 0xd0, 0x80, 0x49, 0x36, 0x4a, 0x3a, 0x0c, 0xe3, 0x48, 0x68, 0xd0,
+	0x24, 0x1b, 0xe7, 0x34, 0x13, 0xee, 0x44, 0x7a, 0x32, 0x16, 0x64, 0x1b, 0xea, 0x7e, 0xc8, 0x4f,
+	0x59, 0x74, 0x46, 0x03, 0x8c, 0x59, 0x73, 0x67, 0x02, 0x75, 0xe7, 0x21, 0x65, 0x83, 0xa1, 0xd4,
+	0x18, 0xd5, 0x3b, 0x04, 0x99, 0x42, 0x42, 0x6f, 0x86, 0xd5, 0x1a, 0x0a, 0x4e, 0xe8, 0x3b, 0xe7,
+	0x57, 0x0b, 0xae, 0x19, 0x81, 0x5e, 0x33, 0x39, 0xd4, 0xc1, 0x1e, 0x42, 0xc3, 0xe8, 0x73, 0x2e,
+	0xc0, 0x4c, 0x50, 0x98, 0xca, 0xe4, 0x3e, 0x54, 0x04, 0x7a, 0xc1, 0x54, 0x2e, 0xa9, 0x72, 0x12,
+	0xcb, 0xd5, 0xda, 0xd3, 0x21, 0x2b, 0xce, 0x86, 0xcc, 0xf9, 0xc7, 0x82, 0xf5, 0x97, 0x5c, 0x8c,
+	0x29, 0x97, 0x97, 0x54, 0x3d, 0x6f, 0x3c, 0x2f, 0xbb, 0x38, 0xf9, 0x14, 0x36, 0x45, 0xe4, 0xf7,
+	0xcc, 0x2b, 0x26, 0x10, 0xde, 0x10, 0x91, 0x6f, 0xa2, 0xd7, 0xe8, 0x67, 0x79, 0x49, 0x3f, 0x2b,
+	0xb9, 0xfd, 0xac, 0x1a, 0xfd, 0x24, 0xf7, 0xa0, 0xe9, 0x7b, 0x23, 0x3f, 0x1e, 0x79, 0x92, 0x06,
+	0xbd, 0x44, 0xa1, 0x86, 0x0a, 0x9b, 0x33, 0xf9, 0x11, 0xb6, 0xfe, 0x6b, 0xa8, 0xee, 0x7b, 0x23,
+	0x8f, 0xfb, 0x74, 0x16, 0xc1, 0xca, 0x8d, 0x50, 0x30, 0x11, 0x13, 0x43, 0x43, 0x9b, 0x3d, 0xf7,
+	0x58, 0x44, 0xba, 0xf3, 0x58, 0x69, 0x74, 0xb7, 0xb2, 0xec, 0x90, 0x28, 0x9b, 0x08, 0xea, 0x22,
+	0x57, 0x05, 0xcc, 0x97, 0x34, 0xb0, 0x0b, 0x97, 0xd9, 0x4c, 0xd5, 0x9c, 0x1d, 0xd8, 0x7e, 0x42,
+	0x25, 0x52, 0x8d, 0x3f, 0xf4, 0x18, 0x3f, 0xa6, 0xd2, 0x0b, 0x3c, 0xe9, 0xb9, 0xf4, 0x5d, 0x4c,
+	0x85, 0x54, 0x64, 0x45, 0x16, 0x4f, 0xc9, 0x17, 0x50, 0x52, 0x8c, 0xb4, 0x92, 0xb7, 0x50, 0x4b,
+	0x75, 0x52, 0xfd, 0x9a, 0x64, 0x51, 0x53, 0x02, 0xe4, 0x8a, 0x16, 0xd4, 0xe2, 0x04, 0x1f, 0xe9,
+	0x64, 0x4d, 0xf7, 0xa4, 0x0d, 0x8d, 0x98, 0xcf, 0xea, 0x90, 0x8c, 0x98, 0x29, 0x72, 0x0e, 0x60,
+	0x33, 0xcd, 0x5f, 0xa7, 0x4c, 0xb6, 0x4c, 0x7c, 0x1d, 0x5d, 0x99, 0x22, 0x0c, 0xc9, 0x1e, 0x6b,
+	0x7e, 0x74, 0x05, 0xe9, 0x7e, 0xbf, 0x0a, 0x65, 0x04, 0x94, 0xf3, 0x10, 0x9a, 0xa9, 0x17, 0x31,
+	0x73, 0x53, 0x16, 0xd2, 0x8b, 0x64, 0xda, 0x3c, 0xdc, 0x28, 0xba, 0xa4, 0x3c, 0xd0, 0xad, 0x53,
+	0x4b, 0xe7, 0x5b, 0xb8, 0x6a, 0xd8, 0x8a, 0x71, 0xc8, 0x05, 0x25, 0x9f, 0x41, 0x05, 0x3d, 0xab,
+	0xd6, 0x2b, 0x9e, 0x22, 0x8b, 0x15, 0x72, 0xb5, 0x86, 0xf3, 0x39, 0x5c, 0x7b, 0x42, 0xa5, 0x39,
+	0x8c, 0x3a, 0x03, 0x35, 0x14, 0x17, 0x2c, 0x48, 0x07, 0x45, 0xad, 0x9d, 0xe7, 0x60, 0x3f, 0xa1,
+	0x32, 0x33, 0x50, 0xd3, 0x8c, 0xb7, 0xa1, 0xae, 0xb1, 0x4d, 0x93, 0xb8, 0x75, 0x77, 0x26, 0x40,
+	0x7e, 0xf1, 0xc4, 0x90, 0x2a, 0xdc, 0x21, 0xa7, 0x26, 0x3b, 0xe7, 0x6f, 0x0b, 0x36, 0xb2, 0xfe,
+	0xde, 0xb3, 0xbb, 0x0f, 0x4c, 0xa8, 0x16, 0xf0, 0xba, 0xad, 0x8c, 0x49, 0xc6, 0xbb, 0x09, 0xd8,
+	0xfb, 0x50, 0x0b, 0x63, 0x39, 0x08, 0x19, 0x1f, 0xd8, 0xc5, 0x95, 0x86, 0x53, 0x5d, 0x65, 0xc7,
+	0xb8, 0x1f, 0x9e, 0x29, 0xbb, 0xd2, 0x6a, 0xbb, 0x54, 0xd7, 0xf9, 0x32, 0x69, 0x95, 0x9e, 0x82,
+	0xff, 0x52, 0x35, 0x45, 0x5f, 0xc4, 0xb4, 0xd1, 0xfd, 0xed, 0x42, 0xb5, 0x9f, 0x88, 0xf2, 0x8b,
+	0x34, 0x9b, 0x64, 0x37, 0x55, 0x24, 0x4f, 0xcd, 0x40, 0x49, 0x9d, 0x3a, 0x19, 0xab, 0xc5, 0x38,
+	0x9d, 0xc7, 0xa9, 0xc1, 0x21, 0x97, 0xd1, 0xc4, 0x48, 0xac, 0xf5, 0x0a, 0x36, 0xb2, 0x87, 0x0a,
+	0x9a, 0x6f, 0xe9, 0x04, 0xf3, 0xa9, 0xbb, 0x6a, 0x49, 0x3a, 0x50, 0x3e, 0xf7, 0x46, 0x31, 0xb5,
+	0x0b, 0x2b, 0x72, 0x4c, 0xd4, 0x1e, 0x16, 0x1e, 0x58, 0x4e, 0x0f, 0xec, 0xef, 0xf9, 0xcf, 0xd4,
+	0xcf, 0x03, 0x64, 0x7b, 0xf1, 0x4d, 0x59, 0xcb, 0xbe, 0x1c, 0x1f, 0xc2, 0x1a, 0x0f, 0x7b, 0xfd,
+	0x28, 0xf4, 0x02, 0xdf, 0x13, 0xc9, 0x53, 0x56, 0x73, 0x1b, 0x3c, 0xdc, 0x4f, 0x45, 0xce, 0x2e,
+	0xdc, 0xcc, 0x09, 0xa0, 0xeb, 0x9a, 0x07, 0xf9, 0x37, 0x60, 0xbf, 0xa2, 0x11, 0x3b, 0x9d, 0xfc,
+	0xaf, 0x8c, 0x12, 0x7a, 0x61, 0x03, 0xae, 0x39, 0xb1, 0xe6, 0x4e, 0xf7, 0xce, 0x9f, 0x16, 0xdc,
+	0xcc, 0x71, 0xad, 0x73, 0xd9, 0x82, 0x32, 0x8d, 0xa2, 0x30, 0xd2, 0x15, 0x4d, 0x36, 0xd9, 0x47,
+	0xbc, 0x30, 0xff, 0x88, 0x9b, 0xd1, 0x8a, 0xd9, 0x68, 0xf3, 0x2f, 0x72, 0xe9, 0x7d, 0x5e, 0xe4,
+	0xee, 0xf4, 0x83, 0xa8, 0xbc, 0x12, 0xef, 0x5a, 0xd3, 0xf9, 0x0e, 0xae, 0x9f, 0xc4, 0x7d, 0xe1,
+	0x47, 0xac, 0x4f, 0xb3, 0xd4, 0x76, 0x1b, 0xea, 0xc8, 0x66, 0xf8, 0xb2, 0x5a, 0x9a, 0x11, 0x6b,
+	0x28, 0x3a, 0x49, 0x68, 0x11, 0xd7, 0xce, 0x01, 0xdc, 0x98, 0x7a, 0x38, 0xa6, 0x67, 0xe3, 0x30,
+	0x1c, 0xa5, 0x2e, 0xee, 0x41, 0x93, 0x71, 0x7f, 0x14, 0x07, 0xb4, 0x47, 0x2f, 0x98, 0x90, 0x6a,
+	0x14, 0x93, 0x4f, 0x9a, 0x4d, 0x2d, 0x3f, 0xd4, 0x62, 0xe7, 0x77, 0x0b, 0xd6, 0xb4, 0xf5, 0xe1,
+	0x39, 0xe5, 0x92, 0x74, 0xf5, 0xd7, 0xa1, 0xd2, 0xdf, 0x98, 0xfb, 0xb8, 0x30, 0x15, 0x3b, 0x2f,
+	0x26, 0x63, 0xaa, 0xbf, 0x1e, 0xe7, 0x8a, 0x57, 0x78, 0x9f, 0xe2, 0xb5, 0xa0, 0x16, 0x51, 0x9f,
+	0xb2, 0x73, 0xdd, 0x94, 0xa2, 0x3b, 0xdd, 0x3b, 0x3b, 0x50, 0x52, 0x51, 0x48, 0x1d, 0xca, 0x8f,
+	0x0f, 0x0e, 0x0e, 0x0f, 0x9a, 0x57, 0x48, 0x03, 0xaa, 0xee, 0xe1, 0xf1, 0xb3, 0x57, 0x87, 0x07,
+	0x4d, 0xab, 0xfb, 0x57, 0x05, 0x4a, 0x3f, 0x86, 0x01, 0x25, 0x3e, 0xb2, 0x74, 0xce, 0x53, 0x78,
+	0x6f, 0x61, 0x86, 0x97, 0x3d, 0xa6, 0xad, 0x0f, 0x16, 0x99, 0x34, 0xeb, 0xeb, 0x11, 0xd4, 0x52,
+	0x07, 0x64, 0x3b, 0xd7, 0x6f, 0xea, 0x2a, 0xe7, 0x41, 0x21, 0x3f, 0x40, 0x3d, 0x55, 0x13, 0xe4,
+	0x76, 0xae, 0x79, 0x0a, 0x81, 0xd6, 0xce, 0xb2, 0x63, 0x0d, 0xfe, 0x37, 0xb0, 0x91, 0x7d, 0x94,
+	0x88, 0x33, 0x6f, 0xb1, 0x38, 0x8e, 0x2d, 0x67, 0x59, 0x43, 0x8c, 0x0f, 0xd3, 0xd7, 0x48, 0xc2,
+	0x73, 0x2f, 0xce, 0x27, 0xf3, 0xce, 0x73, 0x5f, 0xb8, 0xd6, 0xad, 0xe5, 0xb0, 0x17, 0xe4, 0x18,
+	0x60, 0xc6, 0xa0, 0x64, 0x67, 0x29, 0xb5, 0xe6, 0xf5, 0x22, 0x87, 0xe2, 0xfb, 0x70, 0x75, 0x81,
+	0xa7, 0xe6, 0xf2, 0x5c, 0x46, 0x94, 0xad, 0x3b, 0xab, 0xd4, 0x66, 0x31, 0x16, 0xf8, 0x67, 0x2e,
+	0xc6, 0x32, 0xea, 0x6b, 0xdd, 0x59, 0xa5, 0xa6, 0x63, 0x3c, 0x85, 0xcd, 0x39, 0x1a, 0x20, 0x1f,
+	0x65, 0x4c, 0xf3, 0x49, 0x22, 0x0f, 0x61, 0x7b, 0x16, 0x79, 0x09, 0xcd, 0x79, 0x4a, 0x20, 0x1f,
+	0xe7, 0xbb, 0xcb, 0x32, 0x46, 0xeb, 0xe6, 0xd2, 0x39, 0xdf, 0xb3, 0xf6, 0xbb, 0x3f, 0xed, 0x0d,
+	0x98, 0x1c, 0xc6, 0xfd, 0x8e, 0x1f, 0x9e, 0xed, 0x6a, 0xc5, 0xd9, 0x6f, 0xe4, 0xef, 0x7a, 0x63,
+	0xb6, 0x3b, 0x88, 0xc6, 0xfe, 0xb8, 0xff, 0x4d, 0xf2, 0xd3, 0xaf, 0xe0, 0x1f, 0x02, 0x5f, 0xfd,
+	0x3b, 0x00, 0xc2, 0x93, 0xba, 0xa4, 0x21, 0x10, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// NodeClient is the client API for Node service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NodeClient interface {
+	GetBlockchainMetadata(ctx context.Context, in *GetBlockchainMetadataRequest, opts ...grpc.CallOption) (*BlockchainMetadata, error)
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (*GetBlocksResponse, error)
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*TransactionWithStatus, error)
+	GetUnspentOutputs(ctx context.Context, in *GetUnspentOutputsRequest, opts ...grpc.CallOption) (*UnspentOutputs, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	InjectTransaction(ctx context.Context, in *InjectTransactionRequest, opts ...grpc.CallOption) (*InjectTransactionResponse, error)
+	VerifyTransaction(ctx context.Context, in *VerifyTransactionRequest, opts ...grpc.CallOption) (*VerifyTransactionResponse, error)
+	SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (Node_SubscribeBlocksClient, error)
+	SubscribeMempool(ctx context.Context, in *SubscribeMempoolRequest, opts ...grpc.CallOption) (Node_SubscribeMempoolClient, error)
+}
+
+type nodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNodeClient(cc grpc.ClientConnInterface) NodeClient {
+	return &nodeClient{cc}
+}
+
+func (c *nodeClient) GetBlockchainMetadata(ctx context.Context, in *GetBlockchainMetadataRequest, opts ...grpc.CallOption) (*BlockchainMetadata, error) {
+	out := new(BlockchainMetadata)
+	err := c.cc.Invoke(ctx, "/skycoin.api.Node/GetBlockchainMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/skycoin.api.Node/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (*GetBlocksResponse, error) {
+	out := new(GetBlocksResponse)
+	err := c.cc.Invoke(ctx, "/skycoin.api.Node/GetBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*TransactionWithStatus, error) {
+	out := new(TransactionWithStatus)
+	err := c.cc.Invoke(ctx, "/skycoin.api.Node/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetUnspentOutputs(ctx context.Context, in *GetUnspentOutputsRequest, opts ...grpc.CallOption) (*UnspentOutputs, error) {
+	out := new(UnspentOutputs)
+	err := c.cc.Invoke(ctx, "/skycoin.api.Node/GetUnspentOutputs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, "/skycoin.api.Node/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) InjectTransaction(ctx context.Context, in *InjectTransactionRequest, opts ...grpc.CallOption) (*InjectTransactionResponse, error) {
+	out := new(InjectTransactionResponse)
+	err := c.cc.Invoke(ctx, "/skycoin.api.Node/InjectTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) VerifyTransaction(ctx context.Context, in *VerifyTransactionRequest, opts ...grpc.CallOption) (*VerifyTransactionResponse, error) {
+	out := new(VerifyTransactionResponse)
+	err := c.cc.Invoke(ctx, "/skycoin.api.Node/VerifyTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (Node_SubscribeBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[0], "/skycoin.api.Node/SubscribeBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeSubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_SubscribeBlocksClient interface {
+	Recv() (*Block, error)
+	grpc.ClientStream
+}
+
+type nodeSubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeSubscribeBlocksClient) Recv() (*Block, error) {
+	m := new(Block)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeClient) SubscribeMempool(ctx context.Context, in *SubscribeMempoolRequest, opts ...grpc.CallOption) (Node_SubscribeMempoolClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[1], "/skycoin.api.Node/SubscribeMempool", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeSubscribeMempoolClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_SubscribeMempoolClient interface {
+	Recv() (*MempoolEvent, error)
+	grpc.ClientStream
+}
+
+type nodeSubscribeMempoolClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeSubscribeMempoolClient) Recv() (*MempoolEvent, error) {
+	m := new(MempoolEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NodeServer is the server API for Node service.
+type NodeServer interface {
+	GetBlockchainMetadata(context.Context, *GetBlockchainMetadataRequest) (*BlockchainMetadata, error)
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	GetBlocks(context.Context, *GetBlocksRequest) (*GetBlocksResponse, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*TransactionWithStatus, error)
+	GetUnspentOutputs(context.Context, *GetUnspentOutputsRequest) (*UnspentOutputs, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	InjectTransaction(context.Context, *InjectTransactionRequest) (*InjectTransactionResponse, error)
+	VerifyTransaction(context.Context, *VerifyTransactionRequest) (*VerifyTransactionResponse, error)
+	SubscribeBlocks(*SubscribeBlocksRequest, Node_SubscribeBlocksServer) error
+	SubscribeMempool(*SubscribeMempoolRequest, Node_SubscribeMempoolServer) error
+}
+
+// UnimplementedNodeServer can be embedded to have forward compatible implementations.
+type UnimplementedNodeServer struct {
+}
+
+func (*UnimplementedNodeServer) GetBlockchainMetadata(ctx context.Context, req *GetBlockchainMetadataRequest) (*BlockchainMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockchainMetadata not implemented")
+}
+func (*UnimplementedNodeServer) GetBlock(ctx context.Context, req *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (*UnimplementedNodeServer) GetBlocks(ctx context.Context, req *GetBlocksRequest) (*GetBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlocks not implemented")
+}
+func (*UnimplementedNodeServer) GetTransaction(ctx context.Context, req *GetTransactionRequest) (*TransactionWithStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (*UnimplementedNodeServer) GetUnspentOutputs(ctx context.Context, req *GetUnspentOutputsRequest) (*UnspentOutputs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnspentOutputs not implemented")
+}
+func (*UnimplementedNodeServer) GetBalance(ctx context.Context, req *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (*UnimplementedNodeServer) InjectTransaction(ctx context.Context, req *InjectTransactionRequest) (*InjectTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectTransaction not implemented")
+}
+func (*UnimplementedNodeServer) VerifyTransaction(ctx context.Context, req *VerifyTransactionRequest) (*VerifyTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTransaction not implemented")
+}
+func (*UnimplementedNodeServer) SubscribeBlocks(req *SubscribeBlocksRequest, srv Node_SubscribeBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
+func (*UnimplementedNodeServer) SubscribeMempool(req *SubscribeMempoolRequest, srv Node_SubscribeMempoolServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMempool not implemented")
+}
+
+func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
+	s.RegisterService(&_Node_serviceDesc, srv)
+}
+
+func _Node_GetBlockchainMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockchainMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetBlockchainMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/skycoin.api.Node/GetBlockchainMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetBlockchainMetadata(ctx, req.(*GetBlockchainMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/skycoin.api.Node/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/skycoin.api.Node/GetBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetBlocks(ctx, req.(*GetBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/skycoin.api.Node/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetUnspentOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnspentOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetUnspentOutputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/skycoin.api.Node/GetUnspentOutputs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetUnspentOutputs(ctx, req.(*GetUnspentOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/skycoin.api.Node/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_InjectTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).InjectTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/skycoin.api.Node/InjectTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).InjectTransaction(ctx, req.(*InjectTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_VerifyTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).VerifyTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/skycoin.api.Node/VerifyTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).VerifyTransaction(ctx, req.(*VerifyTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).SubscribeBlocks(m, &nodeSubscribeBlocksServer{stream})
+}
+
+type Node_SubscribeBlocksServer interface {
+	Send(*Block) error
+	grpc.ServerStream
+}
+
+type nodeSubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeSubscribeBlocksServer) Send(m *Block) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Node_SubscribeMempool_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMempoolRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).SubscribeMempool(m, &nodeSubscribeMempoolServer{stream})
+}
+
+type Node_SubscribeMempoolServer interface {
+	Send(*MempoolEvent) error
+	grpc.ServerStream
+}
+
+type nodeSubscribeMempoolServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeSubscribeMempoolServer) Send(m *MempoolEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Node_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "skycoin.api.Node",
+	HandlerType: (*NodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlockchainMetadata",
+			Handler:    _Node_GetBlockchainMetadata_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _Node_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBlocks",
+			Handler:    _Node_GetBlocks_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _Node_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetUnspentOutputs",
+			Handler:    _Node_GetUnspentOutputs_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _Node_GetBalance_Handler,
+		},
+		{
+			MethodName: "InjectTransaction",
+			Handler:    _Node_InjectTransaction_Handler,
+		},
+		{
+			MethodName: "VerifyTransaction",
+			Handler:    _Node_VerifyTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _Node_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMempool",
+			Handler:       _Node_SubscribeMempool_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "skycoin.proto",
+}
@@ -37,6 +37,7 @@ type HealthResponse struct {
 	UnconfirmedVerifyTxn readable.VerifyTxn   `json:"unconfirmed_verify_transaction"`
 	StartedAt            int64                `json:"started_at"`
 	Fiber                readable.FiberConfig `json:"fiber"`
+	RateLimit            RateLimitStats       `json:"rate_limit"`
 }
 
 func getHealthData(c muxConfig, gateway Gatewayer) (*HealthResponse, error) {
@@ -94,6 +95,7 @@ func getHealthData(c muxConfig, gateway Gatewayer) (*HealthResponse, error) {
 		UnconfirmedVerifyTxn: readable.NewVerifyTxn(gateway.DaemonConfig().UnconfirmedVerifyTxn),
 		Uptime:               wh.FromDuration(time.Since(gateway.StartedAt())),
 		StartedAt:            gateway.StartedAt().Unix(),
+		RateLimit:            c.rateLimiter.stats(),
 	}, nil
 }
 
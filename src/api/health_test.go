@@ -84,6 +84,10 @@ func TestHealthHandler(t *testing.T) {
 					EndpointsStatus: struct{}{},
 					EndpointsRead:   struct{}{},
 				},
+				rateLimiter: NewRateLimiter(RateLimitConfig{
+					Rate:  10,
+					Burst: 20,
+				}),
 			},
 			walletAPIEnabled: false,
 		},
@@ -255,6 +259,19 @@ func TestHealthHandler(t *testing.T) {
 			require.Equal(t, dc.UnconfirmedVerifyTxn.MaxDropletPrecision, r.UnconfirmedVerifyTxn.MaxDropletPrecision)
 			require.True(t, time.Now().Unix() > r.StartedAt)
 
+			if tc.cfg.rateLimiter == nil {
+				require.Equal(t, RateLimitStats{}, r.RateLimit)
+			} else {
+				// The health request itself is counted
+				require.Equal(t, RateLimitStats{
+					Enabled:         true,
+					Rate:            10,
+					Burst:           20,
+					Clients:         1,
+					AllowedRequests: 1,
+				}, r.RateLimit)
+			}
+
 		})
 	}
 }
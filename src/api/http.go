@@ -12,6 +12,7 @@ import (
 	"net"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -19,7 +20,9 @@ import (
 	"github.com/rs/cors"
 	"github.com/skycoin/skycoin/src/util/gziphandler"
 
+	"github.com/skycoin/skycoin/src/apitoken"
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/readable"
 	"github.com/skycoin/skycoin/src/util/file"
 	wh "github.com/skycoin/skycoin/src/util/http"
@@ -58,6 +61,10 @@ const (
 	EndpointsNetCtrl = "NET_CTRL"
 	// EndpointsStorage endpoints implement interface for key-value storage for arbitrary data
 	EndpointsStorage = "STORAGE"
+	// EndpointsMetrics endpoints expose node metrics to monitoring systems
+	EndpointsMetrics = "METRICS"
+	// EndpointsWebhook endpoints manage HTTP callbacks for blockchain events
+	EndpointsWebhook = "WEBHOOK"
 )
 
 // Server exposes an HTTP API
@@ -82,6 +89,11 @@ type Config struct {
 	EnabledAPISets     map[string]struct{}
 	Username           string
 	Password           string
+	// APITokens authenticates requests made with API tokens. If nil, API tokens are not accepted
+	APITokens *apitoken.Store
+	// RateLimiter rate limits the requests of each client. It can be shared with the GRPCServer,
+	// so that a client has one quota for both interfaces. If nil, requests are not rate limited
+	RateLimiter *RateLimiter
 }
 
 // HealthConfig configuration data exposed in /health
@@ -103,6 +115,8 @@ type muxConfig struct {
 	hostWhitelist      []string
 	username           string
 	password           string
+	apiTokens          *apitoken.Store
+	rateLimiter        *RateLimiter
 	health             HealthConfig
 }
 
@@ -210,6 +224,8 @@ func create(host string, c Config, gateway Gatewayer) (*Server, error) {
 		hostWhitelist:      c.HostWhitelist,
 		username:           c.Username,
 		password:           c.Password,
+		apiTokens:          c.APITokens,
+		rateLimiter:        c.RateLimiter,
 	}
 
 	srvMux := newServerMux(mc, gateway)
@@ -351,7 +367,7 @@ func newServerMux(c muxConfig, gateway Gatewayer) *http.ServeMux {
 		return handler
 	}
 
-	forMethodAPISets := func(apiVersion string, f http.Handler, methodsAPISets map[string][]string) http.Handler {
+	forMethodAPISets := func(apiVersion string, f http.Handler, methodsAPISets map[string][]string, methodsWalletIDs map[string]walletIDSource) http.Handler {
 		if len(methodsAPISets) == 0 {
 			logger.Panic("methodsAPISets should not be empty")
 		}
@@ -377,20 +393,36 @@ func newServerMux(c muxConfig, gateway Gatewayer) *http.ServeMux {
 				return
 			}
 
-			for _, k := range apiSets {
-				if _, ok := c.enabledAPISets[k]; ok {
-					f.ServeHTTP(w, r)
+			// If the request was authenticated with an API token, the token must also grant one of the API sets
+			token := apiTokenFromContext(r.Context())
+
+			walletOnly, err := authorizeAPISets(c.enabledAPISets, token, apiSets)
+			switch err {
+			case nil:
+			case errMethodDisabled:
+				writeError(w, apiVersion, http.StatusForbidden, "Endpoint is disabled")
+				return
+			default:
+				writeError(w, apiVersion, http.StatusForbidden, "API token does not grant access to this endpoint")
+				return
+			}
+
+			// Tokens restricted to specific wallets can only use wallet endpoints that refer to one of these wallets
+			src := methodsWalletIDs[r.Method]
+			if restrictsWallet(token, walletOnly, apiSets, src) {
+				id, err := requestWalletID(r, src)
+				if err != nil {
+					writeError(w, apiVersion, http.StatusBadRequest, err.Error())
 					return
 				}
-			}
 
-			switch apiVersion {
-			case apiVersion1:
-				wh.Error403(w, "Endpoint is disabled")
-			case apiVersion2:
-				resp := NewHTTPErrorResponse(http.StatusForbidden, "Endpoint is disabled")
-				writeHTTPResponse(w, resp)
+				if id == "" || !token.HasWallet(id) {
+					writeError(w, apiVersion, http.StatusForbidden, "API token does not grant access to this wallet")
+					return
+				}
 			}
+
+			f.ServeHTTP(w, r)
 		})
 	}
 
@@ -411,34 +443,31 @@ func newServerMux(c muxConfig, gateway Gatewayer) *http.ServeMux {
 			handler = ContentTypeJSONRequired(handler)
 		}
 
-		handler = basicAuth(apiVersion, c.username, c.password, "skycoin daemon", handler)
+		handler = tokenAuth(apiVersion, c.apiTokens, c.rateLimiter, handler, basicAuth(apiVersion, c.username, c.password, "skycoin daemon", handler))
 		handler = gziphandler.New(handler)
+		handler = requestMetrics(endpoint, handler)
 		mux.Handle(endpoint, handler)
 	}
 
-	webHandler := func(apiVersion, endpoint string, handler http.Handler, methodAPISets map[string][]string) {
+	webHandler := func(apiVersion, endpoint string, handler http.Handler, methodAPISets map[string][]string, methodWalletIDs map[string]walletIDSource) {
 		// methodAPISets can be nil to ignore the concept of API sets for an endpoint. It will always be enabled.
 		// Explicitly check nil, caller should not pass empty initialized map
 		if methodAPISets != nil {
-			handler = forMethodAPISets(apiVersion, handler, methodAPISets)
+			handler = forMethodAPISets(apiVersion, handler, methodAPISets, methodWalletIDs)
+
+			if c.rateLimiter != nil {
+				handler = rateLimit(apiVersion, endpoint, c.rateLimiter, methodAPISets, handler)
+			}
 		}
 
 		webHandlerWithOptionals(apiVersion, endpoint, handler, true, !c.disableHeaderCheck)
 	}
 
-	webHandlerV1 := func(endpoint string, handler http.Handler, methodAPISets map[string][]string) {
-		webHandler(apiVersion1, "/api/v1"+endpoint, handler, methodAPISets)
-	}
-
-	webHandlerV2 := func(endpoint string, handler http.Handler, methodAPISets map[string][]string) {
-		webHandler(apiVersion2, "/api/v2"+endpoint, handler, methodAPISets)
-	}
-
 	indexHandler := newIndexHandler(c.appLoc, c.enableGUI)
 	if !c.disableCSP {
 		indexHandler = CSPHandler(indexHandler, ContentSecurityPolicy)
 	}
-	webHandler(apiVersion1, "/", indexHandler, nil)
+	webHandler(apiVersion1, "/", indexHandler, nil, nil)
 
 	if c.enableGUI {
 		fileInfos, err := ioutil.ReadDir(c.appLoc)
@@ -457,188 +486,18 @@ func newServerMux(c muxConfig, gateway Gatewayer) *http.ServeMux {
 				route = route + "/"
 			}
 
-			webHandler(apiVersion1, route, fs, nil)
+			webHandler(apiVersion1, route, fs, nil, nil)
 		}
 	}
 
-	// get the current CSRF token
-	csrfHandlerV1 := func(endpoint string, handler http.Handler) {
-		webHandlerWithOptionals(apiVersion1, "/api/v1"+endpoint, handler, false, !c.disableHeaderCheck)
-	}
-	csrfHandlerV1("/csrf", getCSRFToken(c.disableCSRF)) // csrf is always available, regardless of the API set
-
-	// Status endpoints
-	webHandlerV1("/version", versionHandler(c.health.BuildInfo), nil) // version is always available, regardless of the API set
-	webHandlerV1("/health", healthHandler(c, gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead, EndpointsStatus},
-	})
-
-	// Wallet endpoints
-	webHandlerV1("/wallet", walletHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/create", walletCreateHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/newAddress", walletNewAddressesHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/scan", walletScanAddressesHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/balance", walletBalanceHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/transaction", walletCreateTransactionHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV2("/wallet/transaction/sign", walletSignTransactionHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/transactions", walletTransactionsHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/update", walletUpdateHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV1("/wallets", walletsHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsWallet},
-	})
-	webHandlerV1("/wallets/folderName", walletFolderHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/newSeed", newSeedHandler(), map[string][]string{
-		http.MethodGet: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/seed", walletSeedHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsInsecureWalletSeed},
-	})
-	webHandlerV2("/wallet/seed/verify", http.HandlerFunc(walletVerifySeedHandler), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-
-	webHandlerV1("/wallet/unload", walletUnloadHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/encrypt", walletEncryptHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV1("/wallet/decrypt", walletDecryptHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-	webHandlerV2("/wallet/recover", walletRecoverHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsWallet},
-	})
-
-	// Blockchain interface
-	webHandlerV1("/blockchain/metadata", blockchainMetadataHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead, EndpointsStatus},
-	})
-	webHandlerV1("/blockchain/progress", blockchainProgressHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead, EndpointsStatus},
-	})
-	webHandlerV1("/block", blockHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-	webHandlerV1("/blocks", blocksHandler(gateway), map[string][]string{
-		http.MethodGet:  {EndpointsRead},
-		http.MethodPost: {EndpointsRead},
-	})
-	webHandlerV1("/last_blocks", lastBlocksHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-
-	// Network stats endpoints
-	webHandlerV1("/network/connection", connectionHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead, EndpointsStatus},
-	})
-	webHandlerV1("/network/connections", connectionsHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead, EndpointsStatus},
-	})
-	webHandlerV1("/network/defaultConnections", defaultConnectionsHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead, EndpointsStatus},
-	})
-	webHandlerV1("/network/connections/trust", trustConnectionsHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead, EndpointsStatus},
-	})
-	webHandlerV1("/network/connections/exchange", exchgConnectionsHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead, EndpointsStatus},
-	})
-
-	// Network admin endpoints
-	webHandlerV1("/network/connection/disconnect", disconnectHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsNetCtrl},
-	})
-
-	// Transaction related endpoints
-	webHandlerV1("/pendingTxs", pendingTxnsHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-	webHandlerV1("/transaction", transactionHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-	webHandlerV2("/transaction", transactionHandlerV2(gateway), map[string][]string{
-		// http.MethodGet:  []string{EndpointsRead},
-		http.MethodPost: {EndpointsTransaction},
-	})
-	webHandlerV2("/transaction/verify", verifyTxnHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsRead},
-	})
-	webHandlerV1("/transactions", transactionsHandler(gateway), map[string][]string{
-		http.MethodGet:  {EndpointsRead},
-		http.MethodPost: {EndpointsRead},
-	})
-	webHandlerV2("/transactions", transactionsHandlerV2(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-	webHandlerV1("/injectTransaction", injectTransactionHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsTransaction, EndpointsWallet},
-	})
-	webHandlerV1("/resendUnconfirmedTxns", resendUnconfirmedTxnsHandler(gateway), map[string][]string{
-		http.MethodPost: {EndpointsTransaction, EndpointsWallet},
-	})
-	webHandlerV1("/rawtx", rawTxnHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-
-	// Unspent output related endpoints
-	webHandlerV1("/outputs", outputsHandler(gateway), map[string][]string{
-		http.MethodGet:  {EndpointsRead},
-		http.MethodPost: {EndpointsRead},
-	})
-	webHandlerV1("/balance", balanceHandler(gateway), map[string][]string{
-		http.MethodGet:  {EndpointsRead},
-		http.MethodPost: {EndpointsRead},
-	})
-	webHandlerV1("/uxout", uxOutHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-	webHandlerV1("/address_uxouts", addrUxOutsHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-
-	// Address related endpoints
-	webHandlerV2("/address/verify", http.HandlerFunc(addressVerifyHandler), map[string][]string{
-		http.MethodPost: {EndpointsRead},
-	})
-
-	// Explorer endpoints
-	webHandlerV1("/coinSupply", coinSupplyHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-	webHandlerV1("/richlist", richlistHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
-	webHandlerV1("/addresscount", addressCountHandler(gateway), map[string][]string{
-		http.MethodGet: {EndpointsRead},
-	})
+	for _, rt := range apiRoutes(c, gateway) {
+		if rt.skipCSRFCheck {
+			webHandlerWithOptionals(rt.apiVersion, rt.endpoint, rt.handler, false, !c.disableHeaderCheck)
+			continue
+		}
 
-	// Storage endpoint
-	webHandlerV2("/data", storageHandler(gateway), map[string][]string{
-		http.MethodGet:    {EndpointsStorage},
-		http.MethodPost:   {EndpointsStorage},
-		http.MethodDelete: {EndpointsStorage},
-	})
+		webHandler(rt.apiVersion, rt.endpoint, rt.handler, rt.methodAPISets(), rt.walletIDs())
+	}
 
 	return mux
 }
@@ -691,7 +550,7 @@ func parseAddressesFromStr(s string) ([]cipher.Address, error) {
 
 	addrs := make([]cipher.Address, len(addrsStr))
 	for i, s := range addrsStr {
-		a, err := cipher.DecodeBase58Address(s)
+		a, err := cipher.DecodeAddress(params.AddressHRP, s)
 		if err != nil {
 			return nil, fmt.Errorf("address %q is invalid: %v", s, err)
 		}
@@ -718,6 +577,39 @@ func parseSortOrderFromStr(s string) (visor.SortOrder, error) {
 	}
 }
 
+// parseCursorPageParams parses the cursor, limit and sort parameters of cursor pagination.
+// The first page is requested with an empty cursor.
+func parseCursorPageParams(r *http.Request) (*visor.Cursor, uint64, visor.SortOrder, error) {
+	var cursor *visor.Cursor
+	if cursorStr := r.FormValue("cursor"); cursorStr != "" {
+		var err error
+		cursor, err = visor.ParseCursor(cursorStr)
+		if err != nil {
+			return nil, 0, visor.UnknownOrder, fmt.Errorf("invalid 'cursor' value: %v", err)
+		}
+	}
+
+	limit := visor.DefaultTxnPageSize
+	if limitStr := r.FormValue("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.ParseUint(limitStr, 10, 64)
+		if err != nil {
+			return nil, 0, visor.UnknownOrder, fmt.Errorf("invalid 'limit' value: %v", err)
+		}
+	}
+
+	if err := visor.CheckPageLimit(limit); err != nil {
+		return nil, 0, visor.UnknownOrder, err
+	}
+
+	order, err := parseSortOrderFromStr(r.FormValue("sort"))
+	if err != nil {
+		return nil, 0, visor.UnknownOrder, fmt.Errorf("invalid 'sort' value: %v", err)
+	}
+
+	return cursor, limit, order, nil
+}
+
 // parseAddressesFromStr parses comma-separated hashes string into []cipher.SHA256
 func parseHashesFromStr(s string) ([]cipher.SHA256, error) {
 	hashesStr := splitCommaString(s)
@@ -1,15 +1,21 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/apitoken"
 )
 
 const configuredHost = "127.0.0.1:6420"
@@ -22,6 +28,8 @@ var allAPISetsEnabled = map[string]struct{}{
 	EndpointsInsecureWalletSeed: struct{}{},
 	EndpointsNetCtrl:            struct{}{},
 	EndpointsStorage:            struct{}{},
+	EndpointsMetrics:            struct{}{},
+	EndpointsWebhook:            struct{}{},
 }
 
 func defaultMuxConfig() muxConfig {
@@ -35,6 +43,9 @@ func defaultMuxConfig() muxConfig {
 }
 
 var endpointsMethods = map[string][]string{
+	"/metrics": []string{
+		http.MethodGet,
+	},
 	"/api/v1/address_uxouts": []string{
 		http.MethodGet,
 	},
@@ -141,6 +152,15 @@ var endpointsMethods = map[string][]string{
 	"/api/v1/wallet/transactions": []string{
 		http.MethodGet,
 	},
+	"/api/v1/wallet/scan": []string{
+		http.MethodPost,
+	},
+	"/api/v1/wallet/encrypt": []string{
+		http.MethodPost,
+	},
+	"/api/v1/wallet/decrypt": []string{
+		http.MethodPost,
+	},
 	"/api/v1/wallet/unload": []string{
 		http.MethodPost,
 	},
@@ -160,9 +180,18 @@ var endpointsMethods = map[string][]string{
 	"/api/v2/address/verify": []string{
 		http.MethodPost,
 	},
+	"/api/v2/message/sign": []string{
+		http.MethodPost,
+	},
+	"/api/v2/message/verify": []string{
+		http.MethodPost,
+	},
 	"/api/v2/wallet/recover": []string{
 		http.MethodPost,
 	},
+	"/api/v2/wallet/descriptor": []string{
+		http.MethodPost,
+	},
 	"/api/v2/wallet/seed/verify": []string{
 		http.MethodPost,
 	},
@@ -173,11 +202,60 @@ var endpointsMethods = map[string][]string{
 		http.MethodPost,
 	},
 
+	"/api/v2/address_uxouts": []string{
+		http.MethodGet,
+	},
+	"/api/v2/balance": []string{
+		http.MethodGet,
+	},
+	"/api/v2/blocks": []string{
+		http.MethodGet,
+	},
 	"/api/v2/data": []string{
 		http.MethodGet,
 		http.MethodPost,
 		http.MethodDelete,
 	},
+	"/api/v2/jsonrpc": []string{
+		http.MethodPost,
+	},
+	"/api/v2/openapi.json": []string{
+		http.MethodGet,
+	},
+	"/api/v2/outputs": []string{
+		http.MethodGet,
+	},
+	"/api/v2/payout": []string{
+		http.MethodGet,
+		http.MethodPost,
+	},
+	"/api/v2/payouts": []string{
+		http.MethodGet,
+	},
+	"/api/v2/richlist": []string{
+		http.MethodGet,
+	},
+	"/api/v2/search": []string{
+		http.MethodGet,
+	},
+	"/api/v2/transactions": []string{
+		http.MethodGet,
+	},
+	"/api/v2/wallet/outputs/meta": []string{
+		http.MethodGet,
+		http.MethodPost,
+	},
+	"/api/v2/webhook": []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodDelete,
+	},
+	"/api/v2/webhook/deliveries": []string{
+		http.MethodGet,
+	},
+	"/api/v2/webhooks": []string{
+		http.MethodGet,
+	},
 }
 
 func allEndpoints() []string {
@@ -310,7 +388,7 @@ func TestAPISetDisabled(t *testing.T) {
 		handler.ServeHTTP(rr, req)
 
 		switch endpoint {
-		case "/api/v1/csrf", "/api/v1/version": // always enabled
+		case "/api/v1/csrf", "/api/v1/version", "/api/v2/openapi.json": // always enabled
 			require.Equal(t, http.StatusOK, rr.Code)
 		default:
 			require.Equal(t, http.StatusForbidden, rr.Code)
@@ -529,6 +607,274 @@ func TestHTTPBasicAuthInvalid(t *testing.T) {
 	}
 }
 
+func TestAPITokens(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apitokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "api_tokens.json")
+
+	createToken := func(name string, apiSets, walletIDs []string, expires time.Time) string {
+		_, secret, err := apitoken.Create(path, apitoken.CreateOptions{
+			Name:      name,
+			APISets:   apiSets,
+			WalletIDs: walletIDs,
+			Expires:   expires,
+		})
+		require.NoError(t, err)
+		return secret
+	}
+
+	readToken := createToken("dashboard", []string{EndpointsRead}, nil, time.Time{})
+	walletToken := createToken("bot", []string{EndpointsWallet}, []string{"foo.wlt"}, time.Time{})
+	txnWalletToken := createToken("txnbot", []string{EndpointsTransaction, EndpointsWallet}, []string{"foo.wlt"}, time.Time{})
+	netCtrlToken := createToken("netctrl", []string{EndpointsNetCtrl}, nil, time.Time{})
+	expiredToken := createToken("expired", []string{EndpointsRead}, nil, time.Now().Add(-time.Hour))
+
+	store, err := apitoken.NewStore(path)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name      string
+		method    string
+		endpoint  string
+		body      string
+		token     string
+		noStore   bool
+		basicAuth bool
+		status    int
+		errMsg    string
+	}{
+		{
+			name:     "invalid token",
+			method:   http.MethodGet,
+			endpoint: "/api/v1/addresscount",
+			token:    "foo",
+			status:   http.StatusUnauthorized,
+			errMsg:   "401 Unauthorized - invalid API token",
+		},
+		{
+			name:     "expired token",
+			method:   http.MethodGet,
+			endpoint: "/api/v1/addresscount",
+			token:    expiredToken,
+			status:   http.StatusUnauthorized,
+			errMsg:   "401 Unauthorized - API token has expired",
+		},
+		{
+			name:     "tokens not enabled",
+			method:   http.MethodGet,
+			endpoint: "/api/v1/addresscount",
+			token:    readToken,
+			noStore:  true,
+			status:   http.StatusUnauthorized,
+			errMsg:   "401 Unauthorized - API tokens are not enabled",
+		},
+		{
+			name:     "api set granted",
+			method:   http.MethodGet,
+			endpoint: "/api/v1/addresscount",
+			token:    readToken,
+			status:   http.StatusOK,
+		},
+		{
+			name:      "token replaces basic auth",
+			method:    http.MethodGet,
+			endpoint:  "/api/v1/addresscount",
+			token:     readToken,
+			basicAuth: true,
+			status:    http.StatusOK,
+		},
+		{
+			name:     "api set not granted",
+			method:   http.MethodGet,
+			endpoint: "/api/v2/payouts",
+			token:    readToken,
+			status:   http.StatusForbidden,
+			errMsg:   "API token does not grant access to this endpoint",
+		},
+		{
+			name:     "api set disabled",
+			method:   http.MethodPost,
+			endpoint: "/api/v1/network/connection/disconnect",
+			token:    netCtrlToken,
+			status:   http.StatusForbidden,
+			errMsg:   "403 Forbidden - Endpoint is disabled",
+		},
+		{
+			name:     "wallet granted",
+			method:   http.MethodGet,
+			endpoint: "/api/v2/payouts?wallet_id=foo.wlt",
+			token:    walletToken,
+			status:   http.StatusOK,
+		},
+		{
+			name:     "wallet not granted",
+			method:   http.MethodGet,
+			endpoint: "/api/v2/payouts?wallet_id=bar.wlt",
+			token:    walletToken,
+			status:   http.StatusForbidden,
+			errMsg:   "API token does not grant access to this wallet",
+		},
+		{
+			name:     "wallet missing",
+			method:   http.MethodGet,
+			endpoint: "/api/v2/payouts",
+			token:    walletToken,
+			status:   http.StatusForbidden,
+			errMsg:   "API token does not grant access to this wallet",
+		},
+		{
+			name:     "wallet not granted in json body",
+			method:   http.MethodPost,
+			endpoint: "/api/v2/payout",
+			body:     `{"idempotency_key":"foo","wallet_id":"bar.wlt"}`,
+			token:    walletToken,
+			status:   http.StatusForbidden,
+			errMsg:   "API token does not grant access to this wallet",
+		},
+		{
+			name:     "wallet granted in json body",
+			method:   http.MethodPost,
+			endpoint: "/api/v2/payout",
+			body:     `{"idempotency_key":"foo","wallet_id":"foo.wlt"}`,
+			token:    walletToken,
+			status:   http.StatusBadRequest,
+			errMsg:   "missing hours_selection.type",
+		},
+		{
+			name:     "wallet granted in query but not in json body",
+			method:   http.MethodPost,
+			endpoint: "/api/v2/payout?wallet_id=foo.wlt",
+			body:     `{"idempotency_key":"foo","wallet_id":"bar.wlt"}`,
+			token:    walletToken,
+			status:   http.StatusBadRequest,
+			errMsg:   "wallet ID of the query does not match the wallet ID of the request body",
+		},
+		{
+			name:     "wallet granted in query without json body wallet",
+			method:   http.MethodPost,
+			endpoint: "/api/v2/payout?id=foo.wlt",
+			body:     `{"idempotency_key":"foo"}`,
+			token:    walletToken,
+			status:   http.StatusBadRequest,
+			errMsg:   "wallet ID of the query does not match the wallet ID of the request body",
+		},
+		{
+			name:     "both id and wallet_id in json body",
+			method:   http.MethodPost,
+			endpoint: "/api/v2/payout",
+			body:     `{"idempotency_key":"foo","id":"foo.wlt","wallet_id":"bar.wlt"}`,
+			token:    walletToken,
+			status:   http.StatusBadRequest,
+			errMsg:   "id and wallet_id can't both be specified",
+		},
+		{
+			name:     "both id and wallet_id in query",
+			method:   http.MethodGet,
+			endpoint: "/api/v2/payouts?id=foo.wlt&wallet_id=bar.wlt",
+			token:    walletToken,
+			status:   http.StatusBadRequest,
+			errMsg:   "id and wallet_id can't both be specified",
+		},
+		{
+			name:     "wallet granted in json body field not read by the handler",
+			method:   http.MethodPost,
+			endpoint: "/api/v2/payout",
+			body:     `{"idempotency_key":"foo","id":"foo.wlt"}`,
+			token:    walletToken,
+			status:   http.StatusForbidden,
+			errMsg:   "API token does not grant access to this wallet",
+		},
+		{
+			name:     "wallet granted in query field not read by the handler",
+			method:   http.MethodGet,
+			endpoint: "/api/v2/payouts?id=foo.wlt",
+			token:    walletToken,
+			status:   http.StatusForbidden,
+			errMsg:   "API token does not grant access to this wallet",
+		},
+		{
+			name:     "wallet endpoint without wallet id",
+			method:   http.MethodGet,
+			endpoint: "/api/v1/wallets",
+			token:    walletToken,
+			status:   http.StatusForbidden,
+			errMsg:   "403 Forbidden - API token does not grant access to this wallet",
+		},
+		{
+			name:     "restricted wallet token injects transaction",
+			method:   http.MethodPost,
+			endpoint: "/api/v1/injectTransaction",
+			body:     `{"rawtx":""}`,
+			token:    walletToken,
+			status:   http.StatusBadRequest,
+			errMsg:   "400 Bad Request - rawtx is required",
+		},
+		{
+			name:     "restricted transaction and wallet token injects transaction",
+			method:   http.MethodPost,
+			endpoint: "/api/v1/injectTransaction",
+			body:     `{"rawtx":""}`,
+			token:    txnWalletToken,
+			status:   http.StatusBadRequest,
+			errMsg:   "400 Bad Request - rawtx is required",
+		},
+		{
+			name:     "restricted transaction and wallet token wallet not granted",
+			method:   http.MethodGet,
+			endpoint: "/api/v2/payouts?wallet_id=bar.wlt",
+			token:    txnWalletToken,
+			status:   http.StatusForbidden,
+			errMsg:   "API token does not grant access to this wallet",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("AddressCount").Return(uint64(1), nil)
+			gateway.On("GetPayoutJobs").Return(nil, nil)
+
+			req, err := http.NewRequest(tc.method, tc.endpoint, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+
+			cfg := defaultMuxConfig()
+			cfg.enabledAPISets = map[string]struct{}{
+				EndpointsRead:        struct{}{},
+				EndpointsTransaction: struct{}{},
+				EndpointsWallet:      struct{}{},
+			}
+			if !tc.noStore {
+				cfg.apiTokens = store
+			}
+			if tc.basicAuth {
+				cfg.username = "foo"
+				cfg.password = "bar"
+			}
+
+			handler := newServerMux(cfg, gateway)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code, rr.Body.String())
+
+			if tc.errMsg != "" {
+				if strings.HasPrefix(tc.endpoint, "/api/v2") {
+					var rsp ReceivedHTTPResponse
+					require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rsp))
+					require.NotNil(t, rsp.Error)
+					require.Equal(t, tc.errMsg, rsp.Error.Message)
+				} else {
+					require.Equal(t, tc.errMsg, strings.TrimSpace(rr.Body.String()))
+				}
+			}
+		})
+	}
+}
+
 ////////////////////////////////////////////////////////////////
 // Test helper tools
 ////////////////////////////////////////////////////////////////
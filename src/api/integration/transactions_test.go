@@ -394,7 +394,7 @@ func TestLiveInjectTransactionEnableNetworking(t *testing.T) {
 			name: "transaction type invalid",
 			createTxn: func(t *testing.T) *coin.Transaction {
 				txn, _ := prepareTxnFunc(t, defaultChangeAddress, totalCoins, "1")
-				txn.Type = 1
+				txn.Type = 2
 				return &txn
 			},
 			code: http.StatusBadRequest,
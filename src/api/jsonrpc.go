@@ -0,0 +1,860 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/skycoin/skycoin/src/apitoken"
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/daemon"
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/readable"
+	"github.com/skycoin/skycoin/src/transaction"
+	"github.com/skycoin/skycoin/src/util/fee"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/blockdb"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+// JSONRPCVersion is the version of the JSON-RPC protocol implemented by the JSON-RPC endpoint
+const JSONRPCVersion = "2.0"
+
+const (
+	// jsonRPCMaxBodySize is the maximum size of the body of a JSON-RPC request
+	jsonRPCMaxBodySize = 1024 * 1024
+	// jsonRPCMaxBatchLength is the maximum number of requests in a JSON-RPC batch request
+	jsonRPCMaxBatchLength = 100
+)
+
+// JSON-RPC error codes
+const (
+	// RPCErrorParse the request is not valid JSON
+	RPCErrorParse = -32700
+	// RPCErrorInvalidRequest the request is not a valid JSON-RPC request
+	RPCErrorInvalidRequest = -32600
+	// RPCErrorMethodNotFound the method does not exist
+	RPCErrorMethodNotFound = -32601
+	// RPCErrorInvalidParams the method parameters are invalid
+	RPCErrorInvalidParams = -32602
+	// RPCErrorInternal internal error
+	RPCErrorInternal = -32603
+
+	// The following codes are in the range reserved for implementation-defined server errors
+
+	// RPCErrorForbidden the method is disabled, or the API token does not grant access to it
+	RPCErrorForbidden = -32001
+	// RPCErrorNotFound the requested object does not exist
+	RPCErrorNotFound = -32002
+	// RPCErrorUnavailable the service is temporarily unavailable, e.g. a transaction could not be broadcast
+	RPCErrorUnavailable = -32003
+	// RPCErrorRateLimited the client exceeded its rate limit
+	RPCErrorRateLimited = -32004
+)
+
+// RPCRequest is a JSON-RPC request.
+// A request without an ID is a notification, which has no response.
+type RPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// RPCResponse is a JSON-RPC response
+type RPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is a JSON-RPC error
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+func newRPCError(code int, msg string) *RPCError {
+	return &RPCError{
+		Code:    code,
+		Message: msg,
+	}
+}
+
+// rpcMethod is a JSON-RPC method
+type rpcMethod struct {
+	// apiSets are the API sets of the method, at least one of which must be enabled.
+	// They match the API sets of the equivalent REST endpoint.
+	apiSets []string
+	// endpoint is the equivalent REST endpoint. Each call is charged the cost of the endpoint by the rate limiter.
+	endpoint string
+	handler  func(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError)
+}
+
+// rpcMethods returns the JSON-RPC methods, by name
+func rpcMethods() map[string]rpcMethod {
+	return map[string]rpcMethod{
+		"getBlockchainMetadata": {
+			apiSets:  []string{EndpointsRead, EndpointsStatus},
+			endpoint: "/api/v1/blockchain/metadata",
+			handler:  rpcGetBlockchainMetadata,
+		},
+		"getBlockchainProgress": {
+			apiSets:  []string{EndpointsRead, EndpointsStatus},
+			endpoint: "/api/v1/blockchain/progress",
+			handler:  rpcGetBlockchainProgress,
+		},
+		"getBlock": {
+			apiSets:  []string{EndpointsRead},
+			endpoint: "/api/v1/block",
+			handler:  rpcGetBlock,
+		},
+		"getLastBlocks": {
+			apiSets:  []string{EndpointsRead},
+			endpoint: "/api/v1/last_blocks",
+			handler:  rpcGetLastBlocks,
+		},
+		"getTransaction": {
+			apiSets:  []string{EndpointsRead},
+			endpoint: "/api/v1/transaction",
+			handler:  rpcGetTransaction,
+		},
+		"getRawTransaction": {
+			apiSets:  []string{EndpointsRead},
+			endpoint: "/api/v1/rawtx",
+			handler:  rpcGetRawTransaction,
+		},
+		"getPendingTransactions": {
+			apiSets:  []string{EndpointsRead},
+			endpoint: "/api/v1/pendingTxs",
+			handler:  rpcGetPendingTransactions,
+		},
+		"getBalance": {
+			apiSets:  []string{EndpointsRead},
+			endpoint: "/api/v1/balance",
+			handler:  rpcGetBalance,
+		},
+		"getOutputs": {
+			apiSets:  []string{EndpointsRead},
+			endpoint: "/api/v1/outputs",
+			handler:  rpcGetOutputs,
+		},
+		"getUxOut": {
+			apiSets:  []string{EndpointsRead},
+			endpoint: "/api/v1/uxout",
+			handler:  rpcGetUxOut,
+		},
+		"injectRawTransaction": {
+			apiSets:  []string{EndpointsTransaction, EndpointsWallet},
+			endpoint: "/api/v1/injectTransaction",
+			handler:  rpcInjectRawTransaction,
+		},
+		"createTransaction": {
+			apiSets:  []string{EndpointsTransaction},
+			endpoint: "/api/v2/transaction",
+			handler:  rpcCreateTransaction,
+		},
+	}
+}
+
+// jsonRPCAPISets returns the API sets of the JSON-RPC endpoint, which is enabled if any of its methods is enabled.
+// Methods check their own API sets. The endpoint reads no wallet ID, and also belongs to other API sets than
+// the wallet API sets, so forMethodAPISets doesn't restrict it to the wallets of an API token.
+func jsonRPCAPISets() []string {
+	names := make([]string, 0)
+	methods := rpcMethods()
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var apiSets []string
+	seen := make(map[string]struct{})
+	for _, name := range names {
+		for _, k := range methods[name].apiSets {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+
+			seen[k] = struct{}{}
+			apiSets = append(apiSets, k)
+		}
+	}
+
+	return apiSets
+}
+
+// jsonRPCHandler handles JSON-RPC 2.0 requests, including batch requests
+// URI: /api/v2/jsonrpc
+// Method: POST
+// Args: JSON-RPC request or array of requests
+func jsonRPCHandler(c muxConfig, gateway Gatewayer) http.HandlerFunc {
+	methods := rpcMethods()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError405Response(w)
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, jsonRPCMaxBodySize))
+		if err != nil {
+			writeHTTPResponse(w, NewHTTPErrorResponse(http.StatusRequestEntityTooLarge, ""))
+			return
+		}
+
+		token := apiTokenFromContext(r.Context())
+		limitKey := rateLimitKey(r)
+		call := func(req json.RawMessage) *RPCResponse {
+			return callRPCMethod(c, token, limitKey, methods, gateway, req)
+		}
+
+		body = bytes.TrimSpace(body)
+		if !json.Valid(body) {
+			writeRPCResponse(w, &RPCResponse{
+				JSONRPC: JSONRPCVersion,
+				Error:   newRPCError(RPCErrorParse, "Parse error"),
+			})
+			return
+		}
+
+		if body[0] != '[' {
+			resp := call(body)
+			if resp == nil {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			writeRPCResponse(w, resp)
+			return
+		}
+
+		var reqs []json.RawMessage
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			writeRPCResponse(w, &RPCResponse{
+				JSONRPC: JSONRPCVersion,
+				Error:   newRPCError(RPCErrorParse, "Parse error"),
+			})
+			return
+		}
+
+		if len(reqs) == 0 {
+			writeRPCResponse(w, &RPCResponse{
+				JSONRPC: JSONRPCVersion,
+				Error:   newRPCError(RPCErrorInvalidRequest, "Invalid Request: empty batch"),
+			})
+			return
+		}
+
+		if len(reqs) > jsonRPCMaxBatchLength {
+			writeRPCResponse(w, &RPCResponse{
+				JSONRPC: JSONRPCVersion,
+				Error:   newRPCError(RPCErrorInvalidRequest, fmt.Sprintf("Invalid Request: batch is longer than %d requests", jsonRPCMaxBatchLength)),
+			})
+			return
+		}
+
+		resps := make([]*RPCResponse, 0, len(reqs))
+		for _, req := range reqs {
+			if resp := call(req); resp != nil {
+				resps = append(resps, resp)
+			}
+		}
+
+		// A batch of notifications has no response
+		if len(resps) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		writeRPCResponse(w, resps)
+	}
+}
+
+// callRPCMethod calls the method of a JSON-RPC request. Returns nil if the request is a notification.
+// If rate limiting is enabled, the call is charged to the client identified by limitKey.
+func callRPCMethod(c muxConfig, token *apitoken.Token, limitKey string, methods map[string]rpcMethod, gateway Gatewayer, rawReq json.RawMessage) *RPCResponse {
+	resp := &RPCResponse{
+		JSONRPC: JSONRPCVersion,
+	}
+
+	var req RPCRequest
+	if err := json.Unmarshal(rawReq, &req); err != nil {
+		resp.Error = newRPCError(RPCErrorInvalidRequest, "Invalid Request")
+		return resp
+	}
+
+	resp.ID = req.ID
+
+	if req.JSONRPC != JSONRPCVersion {
+		resp.Error = newRPCError(RPCErrorInvalidRequest, `Invalid Request: jsonrpc must be "2.0"`)
+		return resp
+	}
+
+	if req.Method == "" {
+		resp.Error = newRPCError(RPCErrorInvalidRequest, "Invalid Request: method is required")
+		return resp
+	}
+
+	m, ok := methods[req.Method]
+	if !ok {
+		resp.Error = newRPCError(RPCErrorMethodNotFound, "Method not found")
+	} else if err := checkMethodAccess(c.enabledAPISets, token, m.apiSets); err != nil {
+		resp.Error = newRPCError(RPCErrorForbidden, err.Error())
+	} else if ok := c.rateLimiter.takeRPC(limitKey, m, req.Params); !ok {
+		resp.Error = newRPCError(RPCErrorRateLimited, http.StatusText(http.StatusTooManyRequests))
+	} else {
+		result, rpcErr := m.handler(gateway, req.Params)
+		if rpcErr != nil {
+			resp.Error = rpcErr
+		} else if b, err := json.Marshal(result); err != nil {
+			resp.Error = newRPCError(RPCErrorInternal, err.Error())
+		} else {
+			resp.Result = b
+		}
+	}
+
+	// Notifications have no response, even if an error occurred
+	if len(req.ID) == 0 {
+		return nil
+	}
+
+	return resp
+}
+
+func writeRPCResponse(w http.ResponseWriter, resp interface{}) {
+	out, err := json.Marshal(resp)
+	if err != nil {
+		writeError500Response(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(out); err != nil {
+		logger.WithError(err).Error("http Write failed")
+	}
+}
+
+// decodeRPCParams decodes the params of a method, which must be passed by name.
+// The params may be omitted if none are required.
+func decodeRPCParams(params json.RawMessage, v interface{}) *RPCError {
+	params = bytes.TrimSpace(params)
+	if len(params) == 0 || bytes.Equal(params, []byte("null")) {
+		return nil
+	}
+
+	if params[0] != '{' {
+		return newRPCError(RPCErrorInvalidParams, "params must be an object")
+	}
+
+	d := json.NewDecoder(bytes.NewReader(params))
+	d.DisallowUnknownFields()
+	if err := d.Decode(v); err != nil {
+		return newRPCError(RPCErrorInvalidParams, err.Error())
+	}
+
+	return nil
+}
+
+// rpcBlockParams are the params of the getBlock method. One of hash or seq is required.
+type rpcBlockParams struct {
+	Hash    string  `json:"hash,omitempty"`
+	Seq     *uint64 `json:"seq,omitempty"`
+	Verbose bool    `json:"verbose,omitempty"`
+}
+
+// rpcLastBlocksParams are the params of the getLastBlocks method
+type rpcLastBlocksParams struct {
+	Num     uint64 `json:"num"`
+	Verbose bool   `json:"verbose,omitempty"`
+}
+
+// rpcTransactionParams are the params of the getTransaction method
+type rpcTransactionParams struct {
+	TxID    string `json:"txid"`
+	Verbose bool   `json:"verbose,omitempty"`
+}
+
+// rpcVerboseParams are the params of the getPendingTransactions method
+type rpcVerboseParams struct {
+	Verbose bool `json:"verbose,omitempty"`
+}
+
+// rpcAddressesParams are the params of the getOutputs method
+type rpcAddressesParams struct {
+	Addrs  []string `json:"addrs,omitempty"`
+	Hashes []string `json:"hashes,omitempty"`
+}
+
+// rpcUxOutParams are the params of the getUxOut method
+type rpcUxOutParams struct {
+	UxID string `json:"uxid"`
+}
+
+func rpcGetBlockchainMetadata(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	if err := decodeRPCParams(params, &struct{}{}); err != nil {
+		return nil, err
+	}
+
+	metadata, err := gateway.GetBlockchainMetadata()
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, fmt.Sprintf("gateway.GetBlockchainMetadata failed: %v", err))
+	}
+
+	if metadata == nil {
+		return nil, newRPCError(RPCErrorInternal, "gateway.GetBlockchainMetadata metadata is nil")
+	}
+
+	return readable.NewBlockchainMetadata(*metadata), nil
+}
+
+func rpcGetBlockchainProgress(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	if err := decodeRPCParams(params, &struct{}{}); err != nil {
+		return nil, err
+	}
+
+	headSeq, _, err := gateway.HeadBkSeq()
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, fmt.Sprintf("gateway.HeadBkSeq failed: %v", err))
+	}
+
+	progress := gateway.GetBlockchainProgress(headSeq)
+	if progress == nil {
+		return nil, newRPCError(RPCErrorInternal, "gateway.GetBlockchainProgress progress is nil")
+	}
+
+	return readable.NewBlockchainProgress(progress), nil
+}
+
+func rpcGetBlock(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p rpcBlockParams
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.Hash == "" && p.Seq == nil:
+		return nil, newRPCError(RPCErrorInvalidParams, "should specify one filter, hash or seq")
+	case p.Hash != "" && p.Seq != nil:
+		return nil, newRPCError(RPCErrorInvalidParams, "should only specify one filter, hash or seq")
+	}
+
+	var h cipher.SHA256
+	if p.Hash != "" {
+		var err error
+		h, err = cipher.SHA256FromHex(p.Hash)
+		if err != nil {
+			return nil, newRPCError(RPCErrorInvalidParams, err.Error())
+		}
+	}
+
+	if p.Verbose {
+		var b *coin.SignedBlock
+		var inputs [][]visor.TransactionInput
+		var err error
+		if p.Hash != "" {
+			b, inputs, err = gateway.GetSignedBlockByHashVerbose(h)
+		} else {
+			b, inputs, err = gateway.GetSignedBlockBySeqVerbose(*p.Seq)
+		}
+
+		if err != nil {
+			return nil, newRPCError(RPCErrorInternal, err.Error())
+		}
+
+		if b == nil {
+			return nil, newRPCError(RPCErrorNotFound, "block not found")
+		}
+
+		rb, err := readable.NewBlockVerbose(b.Block, inputs)
+		if err != nil {
+			return nil, newRPCError(RPCErrorInternal, err.Error())
+		}
+
+		return rb, nil
+	}
+
+	var b *coin.SignedBlock
+	var err error
+	if p.Hash != "" {
+		b, err = gateway.GetSignedBlockByHash(h)
+	} else {
+		b, err = gateway.GetSignedBlockBySeq(*p.Seq)
+	}
+
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	if b == nil {
+		return nil, newRPCError(RPCErrorNotFound, "block not found")
+	}
+
+	rb, err := readable.NewBlock(b.Block)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return rb, nil
+}
+
+func rpcGetLastBlocks(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p rpcLastBlocksParams
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Verbose {
+		blocks, inputs, err := gateway.GetLastBlocksVerbose(p.Num)
+		if err != nil {
+			return nil, newRPCError(RPCErrorInternal, err.Error())
+		}
+
+		rb, err := readable.NewBlocksVerbose(blocks, inputs)
+		if err != nil {
+			return nil, newRPCError(RPCErrorInternal, err.Error())
+		}
+
+		return rb, nil
+	}
+
+	blocks, err := gateway.GetLastBlocks(p.Num)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	rb, err := readable.NewBlocks(blocks)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return rb, nil
+}
+
+func parseRPCTxID(txid string) (cipher.SHA256, *RPCError) {
+	if txid == "" {
+		return cipher.SHA256{}, newRPCError(RPCErrorInvalidParams, "txid is required")
+	}
+
+	h, err := cipher.SHA256FromHex(txid)
+	if err != nil {
+		return cipher.SHA256{}, newRPCError(RPCErrorInvalidParams, err.Error())
+	}
+
+	return h, nil
+}
+
+func rpcGetTransaction(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p rpcTransactionParams
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	h, rpcErr := parseRPCTxID(p.TxID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if p.Verbose {
+		txn, inputs, err := gateway.GetTransactionWithInputs(h)
+		if err != nil {
+			return nil, newRPCError(RPCErrorInternal, err.Error())
+		}
+
+		if txn == nil {
+			return nil, newRPCError(RPCErrorNotFound, "transaction not found")
+		}
+
+		rTxn, err := readable.NewTransactionWithStatusVerbose(txn, inputs)
+		if err != nil {
+			return nil, newRPCError(RPCErrorInternal, err.Error())
+		}
+
+		return rTxn, nil
+	}
+
+	txn, err := gateway.GetTransaction(h)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	if txn == nil {
+		return nil, newRPCError(RPCErrorNotFound, "transaction not found")
+	}
+
+	rTxn, err := readable.NewTransactionWithStatus(txn)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return rTxn, nil
+}
+
+func rpcGetRawTransaction(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p struct {
+		TxID string `json:"txid"`
+	}
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	h, rpcErr := parseRPCTxID(p.TxID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	txn, err := gateway.GetTransaction(h)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	if txn == nil {
+		return nil, newRPCError(RPCErrorNotFound, "transaction not found")
+	}
+
+	txnHex, err := txn.Transaction.SerializeHex()
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return txnHex, nil
+}
+
+func rpcGetPendingTransactions(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p rpcVerboseParams
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Verbose {
+		txns, inputs, err := gateway.GetAllUnconfirmedTransactionsVerbose()
+		if err != nil {
+			return nil, newRPCError(RPCErrorInternal, err.Error())
+		}
+
+		rTxns, err := readable.NewUnconfirmedTransactionsVerbose(txns, inputs)
+		if err != nil {
+			return nil, newRPCError(RPCErrorInternal, err.Error())
+		}
+
+		return rTxns, nil
+	}
+
+	txns, err := gateway.GetAllUnconfirmedTransactions()
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	rTxns, err := readable.NewUnconfirmedTransactions(txns)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return rTxns, nil
+}
+
+func parseRPCAddresses(addrs []string) ([]cipher.Address, *RPCError) {
+	cipherAddrs := make([]cipher.Address, len(addrs))
+	for i, a := range addrs {
+		addr, err := cipher.DecodeAddress(params.AddressHRP, a)
+		if err != nil {
+			return nil, newRPCError(RPCErrorInvalidParams, fmt.Sprintf("address %q is invalid: %v", a, err))
+		}
+		cipherAddrs[i] = addr
+	}
+
+	return cipherAddrs, nil
+}
+
+func rpcGetBalance(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p struct {
+		Addrs []string `json:"addrs"`
+	}
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if len(p.Addrs) == 0 {
+		return nil, newRPCError(RPCErrorInvalidParams, "addrs is required")
+	}
+
+	addrs, rpcErr := parseRPCAddresses(p.Addrs)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	bals, err := gateway.GetBalanceOfAddresses(addrs)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, fmt.Sprintf("gateway.GetBalanceOfAddresses failed: %v", err))
+	}
+
+	resp, err := newBalanceResponse(addrs, bals)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return resp, nil
+}
+
+func rpcGetOutputs(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p rpcAddressesParams
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if len(p.Addrs) != 0 && len(p.Hashes) != 0 {
+		return nil, newRPCError(RPCErrorInvalidParams, "addrs and hashes cannot be specified together")
+	}
+
+	var filters []visor.OutputsFilter
+
+	if len(p.Addrs) != 0 {
+		addrs, rpcErr := parseRPCAddresses(p.Addrs)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		filters = append(filters, visor.FbyAddresses(addrs))
+	}
+
+	if len(p.Hashes) != 0 {
+		hashes := make([]cipher.SHA256, len(p.Hashes))
+		for i, s := range p.Hashes {
+			h, err := cipher.SHA256FromHex(s)
+			if err != nil {
+				return nil, newRPCError(RPCErrorInvalidParams, fmt.Sprintf("hash %q is invalid: %v", s, err))
+			}
+			hashes[i] = h
+		}
+		filters = append(filters, visor.FbyHashes(hashes))
+	}
+
+	summary, err := gateway.GetUnspentOutputsSummary(filters)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, fmt.Sprintf("gateway.GetUnspentOutputsSummary failed: %v", err))
+	}
+
+	rSummary, err := readable.NewUnspentOutputsSummary(summary)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return rSummary, nil
+}
+
+func rpcGetUxOut(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p rpcUxOutParams
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.UxID == "" {
+		return nil, newRPCError(RPCErrorInvalidParams, "uxid is required")
+	}
+
+	id, err := cipher.SHA256FromHex(p.UxID)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInvalidParams, err.Error())
+	}
+
+	uxout, headTime, err := gateway.GetUxOutByID(id)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	if uxout == nil {
+		return nil, newRPCError(RPCErrorNotFound, "uxout not found")
+	}
+
+	out, err := readable.NewSpentOutput(uxout, headTime)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return out, nil
+}
+
+func rpcInjectRawTransaction(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var p InjectTransactionRequest
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.RawTxn == "" {
+		return nil, newRPCError(RPCErrorInvalidParams, "rawtx is required")
+	}
+
+	txn, err := coin.DeserializeTransactionHex(p.RawTxn)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInvalidParams, err.Error())
+	}
+
+	if p.NoBroadcast {
+		err = gateway.InjectTransaction(txn)
+	} else {
+		err = gateway.InjectBroadcastTransaction(txn)
+	}
+
+	if err != nil {
+		switch err.(type) {
+		case visor.ErrTxnViolatesUserConstraint,
+			visor.ErrTxnViolatesHardConstraint,
+			visor.ErrTxnViolatesSoftConstraint:
+			return nil, newRPCError(RPCErrorInvalidParams, err.Error())
+		}
+
+		if daemon.IsBroadcastFailure(err) {
+			return nil, newRPCError(RPCErrorUnavailable, err.Error())
+		}
+
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	return txn.Hash().Hex(), nil
+}
+
+func rpcCreateTransaction(gateway Gatewayer, params json.RawMessage) (interface{}, *RPCError) {
+	var req createTransactionRequest
+	if err := decodeRPCParams(params, &req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, newRPCError(RPCErrorInvalidParams, err.Error())
+	}
+
+	if len(req.Addresses) == 0 && len(req.UxOuts) == 0 {
+		return nil, newRPCError(RPCErrorInvalidParams, "one of addresses or unspents must not be empty")
+	}
+
+	txn, inputs, err := gateway.CreateTransaction(req.TransactionParams(), req.VisorParams())
+	if err != nil {
+		switch err.(type) {
+		case blockdb.ErrUnspentNotExist, transaction.Error, visor.UserError, wallet.Error:
+			return nil, newRPCError(RPCErrorInvalidParams, err.Error())
+		}
+
+		if err == fee.ErrTxnNoFee || err == fee.ErrTxnInsufficientCoinHours {
+			return nil, newRPCError(RPCErrorInvalidParams, err.Error())
+		}
+
+		return nil, newRPCError(RPCErrorInternal, err.Error())
+	}
+
+	txnResp, err := NewCreateTransactionResponse(txn, inputs)
+	if err != nil {
+		return nil, newRPCError(RPCErrorInternal, fmt.Sprintf("NewCreateTransactionResponse failed: %v", err))
+	}
+
+	return txnResp, nil
+}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/skycoin/skycoin/src/readable"
+)
+
+// RPCClient provides an interface to a remote node's JSON-RPC API.
+// It uses the authentication of the embedded Client.
+type RPCClient struct {
+	*Client
+	lastID uint64
+}
+
+// NewRPCClient creates a RPCClient
+func NewRPCClient(addr string) *RPCClient {
+	return &RPCClient{
+		Client: NewClient(addr),
+	}
+}
+
+// NewRPCRequest creates a JSON-RPC request for a method. params are passed by name and can be nil.
+// id can be empty, to create a notification.
+func NewRPCRequest(id, method string, params interface{}) (RPCRequest, error) {
+	req := RPCRequest{
+		JSONRPC: JSONRPCVersion,
+		Method:  method,
+	}
+
+	if id != "" {
+		rawID, err := json.Marshal(id)
+		if err != nil {
+			return RPCRequest{}, err
+		}
+		req.ID = rawID
+	}
+
+	if params != nil {
+		rawParams, err := json.Marshal(params)
+		if err != nil {
+			return RPCRequest{}, err
+		}
+		req.Params = rawParams
+	}
+
+	return req, nil
+}
+
+// Call makes a JSON-RPC request to POST /api/v2/jsonrpc and unmarshals the result of the method to result.
+// If the method fails, returns a *RPCError.
+func (c *RPCClient) Call(method string, params, result interface{}) error {
+	req, err := NewRPCRequest(strconv.FormatUint(atomic.AddUint64(&c.lastID, 1), 10), method, params)
+	if err != nil {
+		return err
+	}
+
+	var resp RPCResponse
+	if err := c.PostJSON("/api/v2/jsonrpc", req, &resp); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(resp.ID, req.ID) {
+		return errors.New("JSON-RPC response ID does not match the request ID")
+	}
+
+	return resp.Decode(result)
+}
+
+// Batch makes a JSON-RPC batch request to POST /api/v2/jsonrpc.
+// The responses are not necessarily in the order of the requests, use their IDs to match them.
+// The batch must include at least one request which is not a notification.
+func (c *RPCClient) Batch(reqs []RPCRequest) ([]RPCResponse, error) {
+	var resps []RPCResponse
+	if err := c.PostJSON("/api/v2/jsonrpc", reqs, &resps); err != nil {
+		return nil, err
+	}
+	return resps, nil
+}
+
+// Decode unmarshals the result of a response to result. If the response is an error, returns a *RPCError.
+func (r RPCResponse) Decode(result interface{}) error {
+	if r.Error != nil {
+		return r.Error
+	}
+
+	if r.Result == nil {
+		return errors.New("JSON-RPC response has no result")
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(r.Result))
+	d.DisallowUnknownFields()
+	return d.Decode(result)
+}
+
+// BlockchainMetadata calls the getBlockchainMetadata JSON-RPC method
+func (c *RPCClient) BlockchainMetadata() (*readable.BlockchainMetadata, error) {
+	var v readable.BlockchainMetadata
+	if err := c.Call("getBlockchainMetadata", nil, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// BlockchainProgress calls the getBlockchainProgress JSON-RPC method
+func (c *RPCClient) BlockchainProgress() (*readable.BlockchainProgress, error) {
+	var v readable.BlockchainProgress
+	if err := c.Call("getBlockchainProgress", nil, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// BlockByHash calls the getBlock JSON-RPC method with the hash param
+func (c *RPCClient) BlockByHash(hash string) (*readable.Block, error) {
+	var b readable.Block
+	if err := c.Call("getBlock", rpcBlockParams{Hash: hash}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// BlockByHashVerbose calls the getBlock JSON-RPC method with the hash and verbose params
+func (c *RPCClient) BlockByHashVerbose(hash string) (*readable.BlockVerbose, error) {
+	var b readable.BlockVerbose
+	if err := c.Call("getBlock", rpcBlockParams{Hash: hash, Verbose: true}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// BlockBySeq calls the getBlock JSON-RPC method with the seq param
+func (c *RPCClient) BlockBySeq(seq uint64) (*readable.Block, error) {
+	var b readable.Block
+	if err := c.Call("getBlock", rpcBlockParams{Seq: &seq}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// BlockBySeqVerbose calls the getBlock JSON-RPC method with the seq and verbose params
+func (c *RPCClient) BlockBySeqVerbose(seq uint64) (*readable.BlockVerbose, error) {
+	var b readable.BlockVerbose
+	if err := c.Call("getBlock", rpcBlockParams{Seq: &seq, Verbose: true}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// LastBlocks calls the getLastBlocks JSON-RPC method
+func (c *RPCClient) LastBlocks(n uint64) (*readable.Blocks, error) {
+	var b readable.Blocks
+	if err := c.Call("getLastBlocks", rpcLastBlocksParams{Num: n}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// LastBlocksVerbose calls the getLastBlocks JSON-RPC method with the verbose param
+func (c *RPCClient) LastBlocksVerbose(n uint64) (*readable.BlocksVerbose, error) {
+	var b readable.BlocksVerbose
+	if err := c.Call("getLastBlocks", rpcLastBlocksParams{Num: n, Verbose: true}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Transaction calls the getTransaction JSON-RPC method
+func (c *RPCClient) Transaction(txid string) (*readable.TransactionWithStatus, error) {
+	var r readable.TransactionWithStatus
+	if err := c.Call("getTransaction", rpcTransactionParams{TxID: txid}, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// TransactionVerbose calls the getTransaction JSON-RPC method with the verbose param
+func (c *RPCClient) TransactionVerbose(txid string) (*readable.TransactionWithStatusVerbose, error) {
+	var r readable.TransactionWithStatusVerbose
+	if err := c.Call("getTransaction", rpcTransactionParams{TxID: txid, Verbose: true}, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// RawTransaction calls the getRawTransaction JSON-RPC method
+func (c *RPCClient) RawTransaction(txid string) (string, error) {
+	var rawTxn string
+	if err := c.Call("getRawTransaction", rpcTransactionParams{TxID: txid}, &rawTxn); err != nil {
+		return "", err
+	}
+	return rawTxn, nil
+}
+
+// PendingTransactions calls the getPendingTransactions JSON-RPC method
+func (c *RPCClient) PendingTransactions() ([]readable.UnconfirmedTransactions, error) {
+	var v []readable.UnconfirmedTransactions
+	if err := c.Call("getPendingTransactions", nil, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// PendingTransactionsVerbose calls the getPendingTransactions JSON-RPC method with the verbose param
+func (c *RPCClient) PendingTransactionsVerbose() ([]readable.UnconfirmedTransactionVerbose, error) {
+	var v []readable.UnconfirmedTransactionVerbose
+	if err := c.Call("getPendingTransactions", rpcVerboseParams{Verbose: true}, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Balance calls the getBalance JSON-RPC method
+func (c *RPCClient) Balance(addrs []string) (*BalanceResponse, error) {
+	var b BalanceResponse
+	if err := c.Call("getBalance", rpcAddressesParams{Addrs: addrs}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// OutputsForAddresses calls the getOutputs JSON-RPC method with the addrs param
+func (c *RPCClient) OutputsForAddresses(addrs []string) (*readable.UnspentOutputsSummary, error) {
+	var o readable.UnspentOutputsSummary
+	if err := c.Call("getOutputs", rpcAddressesParams{Addrs: addrs}, &o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// OutputsForHashes calls the getOutputs JSON-RPC method with the hashes param
+func (c *RPCClient) OutputsForHashes(hashes []string) (*readable.UnspentOutputsSummary, error) {
+	var o readable.UnspentOutputsSummary
+	if err := c.Call("getOutputs", rpcAddressesParams{Hashes: hashes}, &o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// UxOut calls the getUxOut JSON-RPC method
+func (c *RPCClient) UxOut(uxID string) (*readable.SpentOutput, error) {
+	var b readable.SpentOutput
+	if err := c.Call("getUxOut", rpcUxOutParams{UxID: uxID}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// InjectEncodedTransaction calls the injectRawTransaction JSON-RPC method.
+// rawTxn is a hex-encoded, serialized transaction
+func (c *RPCClient) InjectEncodedTransaction(rawTxn string) (string, error) {
+	return c.injectEncodedTransaction(rawTxn, false)
+}
+
+// InjectEncodedTransactionNoBroadcast calls the injectRawTransaction JSON-RPC method
+// but does not broadcast the transaction.
+// rawTxn is a hex-encoded, serialized transaction
+func (c *RPCClient) InjectEncodedTransactionNoBroadcast(rawTxn string) (string, error) {
+	return c.injectEncodedTransaction(rawTxn, true)
+}
+
+func (c *RPCClient) injectEncodedTransaction(rawTxn string, noBroadcast bool) (string, error) {
+	v := InjectTransactionRequest{
+		RawTxn:      rawTxn,
+		NoBroadcast: noBroadcast,
+	}
+
+	var txid string
+	if err := c.Call("injectRawTransaction", v, &txid); err != nil {
+		return "", err
+	}
+	return txid, nil
+}
+
+// CreateTransaction calls the createTransaction JSON-RPC method
+func (c *RPCClient) CreateTransaction(req CreateTransactionRequest) (*CreateTransactionResponse, error) {
+	var r CreateTransactionResponse
+	if err := c.Call("createTransaction", req, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,517 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/apitoken"
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/readable"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+func TestJSONRPCHandler(t *testing.T) {
+	b := &coin.SignedBlock{
+		Block: coin.Block{
+			Head: coin.BlockHeader{
+				BkSeq: 1,
+				Time:  1523168686,
+			},
+		},
+	}
+	rb, err := readable.NewBlock(b.Block)
+	require.NoError(t, err)
+	blockJSON, err := json.Marshal(rb)
+	require.NoError(t, err)
+
+	addr := testutil.MakeAddress()
+	bals := []wallet.BalancePair{
+		{
+			Confirmed: wallet.Balance{Coins: 1000000, Hours: 10},
+			Predicted: wallet.Balance{Coins: 1000000, Hours: 10},
+		},
+	}
+	balance, err := newBalanceResponse([]cipher.Address{addr}, bals)
+	require.NoError(t, err)
+	balanceJSON, err := json.Marshal(balance)
+	require.NoError(t, err)
+
+	txn := makeTransaction(t)
+	rawTxn, err := txn.SerializeHex()
+	require.NoError(t, err)
+
+	notifications := make([]string, jsonRPCMaxBatchLength+1)
+	for i := range notifications {
+		notifications[i] = `{"jsonrpc": "2.0", "method": "getBlock", "params": {"seq": 1}}`
+	}
+	longBatch := "[" + strings.Join(notifications, ",") + "]"
+
+	cases := []struct {
+		name           string
+		method         string
+		body           string
+		enabledAPISets map[string]struct{}
+		status         int
+		resp           string
+	}{
+		{
+			name:   "405",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+			resp:   `{"error": {"message": "Method Not Allowed", "code": 405}}`,
+		},
+		{
+			name:   "403 endpoint disabled",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 1}}`,
+			enabledAPISets: map[string]struct{}{
+				EndpointsNetCtrl: struct{}{},
+			},
+			status: http.StatusForbidden,
+			resp:   `{"error": {"message": "Endpoint is disabled", "code": 403}}`,
+		},
+		{
+			name:   "parse error",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock"`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": null, "error": {"code": -32700, "message": "Parse error"}}`,
+		},
+		{
+			name:   "invalid request not an object",
+			method: http.MethodPost,
+			body:   `1`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": null, "error": {"code": -32600, "message": "Invalid Request"}}`,
+		},
+		{
+			name:   "invalid request wrong version",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "1.0", "id": "a", "method": "getBlock"}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": "a", "error": {"code": -32600, "message": "Invalid Request: jsonrpc must be \"2.0\""}}`,
+		},
+		{
+			name:   "invalid request missing method",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": "a"}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": "a", "error": {"code": -32600, "message": "Invalid Request: method is required"}}`,
+		},
+		{
+			name:   "method not found",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "foo"}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32601, "message": "Method not found"}}`,
+		},
+		{
+			name:   "method disabled",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "createTransaction"}`,
+			enabledAPISets: map[string]struct{}{
+				EndpointsRead: struct{}{},
+			},
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32001, "message": "Method is disabled"}}`,
+		},
+		{
+			name:   "invalid params positional",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": [1]}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "params must be an object"}}`,
+		},
+		{
+			name:   "invalid params unknown field",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"foo": 1}}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "json: unknown field \"foo\""}}`,
+		},
+		{
+			name:   "getBlock missing filter",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock"}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "should specify one filter, hash or seq"}}`,
+		},
+		{
+			name:   "getBlock not found",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 2}}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32002, "message": "block not found"}}`,
+		},
+		{
+			name:   "getBlock",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 1}}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "result": ` + string(blockJSON) + `}`,
+		},
+		{
+			name:   "getBalance invalid address",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBalance", "params": {"addrs": ["foo"]}}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "address \"foo\" is invalid: Invalid address length"}}`,
+		},
+		{
+			name:   "getBalance",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBalance", "params": {"addrs": ["` + addr.String() + `"]}}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "result": ` + string(balanceJSON) + `}`,
+		},
+		{
+			name:   "injectRawTransaction rejected",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "injectRawTransaction", "params": {"rawtx": "` + rawTxn + `"}}`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "Transaction violates soft constraint: bad transaction"}}`,
+		},
+		{
+			name:   "injectRawTransaction wallet API set",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "injectRawTransaction", "params": {"rawtx": "` + rawTxn + `"}}`,
+			enabledAPISets: map[string]struct{}{
+				EndpointsWallet: struct{}{},
+			},
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "Transaction violates soft constraint: bad transaction"}}`,
+		},
+		{
+			name:   "getBlock disabled with wallet API set",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 1}}`,
+			enabledAPISets: map[string]struct{}{
+				EndpointsWallet: struct{}{},
+			},
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32001, "message": "Method is disabled"}}`,
+		},
+		{
+			name:   "notification",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "method": "getBlock", "params": {"seq": 1}}`,
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "empty batch",
+			method: http.MethodPost,
+			body:   `[]`,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": null, "error": {"code": -32600, "message": "Invalid Request: empty batch"}}`,
+		},
+		{
+			name:   "batch",
+			method: http.MethodPost,
+			body: `[
+				{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 1}},
+				{"jsonrpc": "2.0", "method": "getBlock", "params": {"seq": 1}},
+				{"jsonrpc": "2.0", "id": 2, "method": "foo"},
+				1
+			]`,
+			status: http.StatusOK,
+			resp: `[
+				{"jsonrpc": "2.0", "id": 1, "result": ` + string(blockJSON) + `},
+				{"jsonrpc": "2.0", "id": 2, "error": {"code": -32601, "message": "Method not found"}},
+				{"jsonrpc": "2.0", "id": null, "error": {"code": -32600, "message": "Invalid Request"}}
+			]`,
+		},
+		{
+			name:   "batch of notifications",
+			method: http.MethodPost,
+			body:   `[{"jsonrpc": "2.0", "method": "getBlock", "params": {"seq": 1}}]`,
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "batch too long",
+			method: http.MethodPost,
+			body:   longBatch,
+			status: http.StatusOK,
+			resp:   `{"jsonrpc": "2.0", "id": null, "error": {"code": -32600, "message": "Invalid Request: batch is longer than 100 requests"}}`,
+		},
+		{
+			name:   "body too large",
+			method: http.MethodPost,
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 1}}` + strings.Repeat(" ", jsonRPCMaxBodySize),
+			status: http.StatusRequestEntityTooLarge,
+			resp:   `{"error": {"message": "Request Entity Too Large", "code": 413}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetSignedBlockBySeq", uint64(1)).Return(b, nil)
+			gateway.On("GetSignedBlockBySeq", uint64(2)).Return(nil, nil)
+			gateway.On("GetBalanceOfAddresses", []cipher.Address{addr}).Return(bals, nil)
+			gateway.On("InjectBroadcastTransaction", txn).Return(visor.NewErrTxnViolatesSoftConstraint(errors.New("bad transaction")))
+
+			req, err := http.NewRequest(tc.method, "/api/v2/jsonrpc", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			cfg := defaultMuxConfig()
+			if tc.enabledAPISets != nil {
+				cfg.enabledAPISets = tc.enabledAPISets
+			}
+			handler := newServerMux(cfg, gateway)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code, rr.Body.String())
+
+			if tc.resp == "" {
+				require.Empty(t, rr.Body.String())
+				return
+			}
+
+			require.JSONEq(t, tc.resp, rr.Body.String())
+		})
+	}
+}
+
+func TestJSONRPCAPITokens(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apitokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "api_tokens.json")
+
+	createToken := func(name string, apiSets, walletIDs []string) string {
+		_, secret, err := apitoken.Create(path, apitoken.CreateOptions{
+			Name:      name,
+			APISets:   apiSets,
+			WalletIDs: walletIDs,
+			Expires:   time.Time{},
+		})
+		require.NoError(t, err)
+		return secret
+	}
+
+	readToken := createToken("dashboard", []string{EndpointsRead}, nil)
+	walletToken := createToken("bot", []string{EndpointsRead, EndpointsWallet}, []string{"foo.wlt"})
+	walletOnlyToken := createToken("wallet", []string{EndpointsWallet}, []string{"foo.wlt"})
+
+	store, err := apitoken.NewStore(path)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		body   string
+		token  string
+		status int
+		errMsg string
+	}{
+		{
+			name:   "read token, read method",
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 2}}`,
+			token:  readToken,
+			status: http.StatusOK,
+			errMsg: "block not found",
+		},
+		{
+			name:   "read token, transaction method",
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "createTransaction"}`,
+			token:  readToken,
+			status: http.StatusOK,
+			errMsg: "API token does not grant access to this method",
+		},
+		{
+			name:   "wallet restricted token, read method",
+			body:   `{"jsonrpc": "2.0", "id": "foo.wlt", "method": "getBlock", "params": {"seq": 2}}`,
+			token:  walletToken,
+			status: http.StatusOK,
+			errMsg: "block not found",
+		},
+		{
+			name:   "wallet restricted token, transaction and wallet method",
+			body:   `{"jsonrpc": "2.0", "id": "foo.wlt", "method": "injectRawTransaction"}`,
+			token:  walletToken,
+			status: http.StatusOK,
+			errMsg: "rawtx is required",
+		},
+		{
+			name:   "wallet only token, transaction and wallet method",
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "injectRawTransaction"}`,
+			token:  walletOnlyToken,
+			status: http.StatusOK,
+			errMsg: "rawtx is required",
+		},
+		{
+			name:   "wallet only token, read method",
+			body:   `{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 2}}`,
+			token:  walletOnlyToken,
+			status: http.StatusOK,
+			errMsg: "API token does not grant access to this method",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetSignedBlockBySeq", uint64(2)).Return(nil, nil)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v2/jsonrpc", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+
+			cfg := defaultMuxConfig()
+			cfg.apiTokens = store
+			handler := newServerMux(cfg, gateway)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code, rr.Body.String())
+
+			var resp RPCResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.NotNil(t, resp.Error)
+			require.Equal(t, tc.errMsg, resp.Error.Message)
+		})
+	}
+}
+
+func TestJSONRPCRateLimit(t *testing.T) {
+	b := &coin.SignedBlock{
+		Block: coin.Block{
+			Head: coin.BlockHeader{
+				BkSeq: 1,
+				Time:  1523168686,
+			},
+		},
+	}
+	rb, err := readable.NewBlock(b.Block)
+	require.NoError(t, err)
+	blockJSON, err := json.Marshal(rb)
+	require.NoError(t, err)
+
+	gateway := &MockGatewayer{}
+	gateway.On("GetSignedBlockBySeq", uint64(1)).Return(b, nil)
+
+	cfg := defaultMuxConfig()
+	cfg.rateLimiter = NewRateLimiter(RateLimitConfig{
+		Rate:  1,
+		Burst: 6,
+		EndpointCosts: map[string]int{
+			"/api/v1/block":           2,
+			"/api/v1/block?verbose=1": 10,
+		},
+	})
+	now := time.Now()
+	cfg.rateLimiter.now = func() time.Time {
+		return now
+	}
+	handler := newServerMux(cfg, gateway)
+
+	// The request costs 1 and each call costs the cost of /api/v1/block. The verbose call exceeds the rate limit
+	// and is not charged, so the next call is allowed, until the bucket is empty.
+	body := `[
+		{"jsonrpc": "2.0", "id": 1, "method": "getBlock", "params": {"seq": 1}},
+		{"jsonrpc": "2.0", "id": 2, "method": "getBlock", "params": {"seq": 1, "verbose": true}},
+		{"jsonrpc": "2.0", "id": 3, "method": "getBlock", "params": {"seq": 1}},
+		{"jsonrpc": "2.0", "id": 4, "method": "getBlock", "params": {"seq": 1}}
+	]`
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v2/jsonrpc", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", ContentTypeJSON)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.JSONEq(t, `[
+		{"jsonrpc": "2.0", "id": 1, "result": `+string(blockJSON)+`},
+		{"jsonrpc": "2.0", "id": 2, "error": {"code": -32004, "message": "Too Many Requests"}},
+		{"jsonrpc": "2.0", "id": 3, "result": `+string(blockJSON)+`},
+		{"jsonrpc": "2.0", "id": 4, "error": {"code": -32004, "message": "Too Many Requests"}}
+	]`, rr.Body.String())
+}
+
+func TestRPCMethodsEndpoints(t *testing.T) {
+	// The equivalent endpoint of each method must exist, since its cost is charged for the method calls
+	endpoints := make(map[string]struct{})
+	for _, rt := range apiRoutes(defaultMuxConfig(), nil) {
+		endpoints[rt.endpoint] = struct{}{}
+	}
+
+	for name, m := range rpcMethods() {
+		_, ok := endpoints[m.endpoint]
+		require.True(t, ok, "%s endpoint %q does not exist", name, m.endpoint)
+	}
+}
+
+func TestRPCClient(t *testing.T) {
+	b := &coin.SignedBlock{
+		Block: coin.Block{
+			Head: coin.BlockHeader{
+				BkSeq: 1,
+			},
+		},
+	}
+
+	gateway := &MockGatewayer{}
+	gateway.On("GetSignedBlockBySeq", uint64(1)).Return(b, nil)
+	gateway.On("GetSignedBlockBySeq", uint64(2)).Return(nil, nil)
+
+	cfg := defaultMuxConfig()
+	cfg.disableHeaderCheck = true
+	server := httptest.NewServer(newServerMux(cfg, gateway))
+	defer server.Close()
+
+	c := NewRPCClient(server.URL)
+
+	block, err := c.BlockBySeq(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), block.Head.BkSeq)
+
+	_, err = c.BlockBySeq(2)
+	require.Equal(t, &RPCError{
+		Code:    RPCErrorNotFound,
+		Message: "block not found",
+	}, err)
+
+	err = c.Call("foo", nil, nil)
+	require.Equal(t, &RPCError{
+		Code:    RPCErrorMethodNotFound,
+		Message: "Method not found",
+	}, err)
+
+	req1, err := NewRPCRequest("1", "getBlock", rpcBlockParams{Seq: &b.Head.BkSeq})
+	require.NoError(t, err)
+	req2, err := NewRPCRequest("", "getBlock", rpcBlockParams{Seq: &b.Head.BkSeq})
+	require.NoError(t, err)
+	req3, err := NewRPCRequest("3", "foo", nil)
+	require.NoError(t, err)
+
+	resps, err := c.Batch([]RPCRequest{req1, req2, req3})
+	require.NoError(t, err)
+	require.Len(t, resps, 2)
+
+	require.Equal(t, `"1"`, string(resps[0].ID))
+	var rb readable.Block
+	require.NoError(t, resps[0].Decode(&rb))
+	require.Equal(t, uint64(1), rb.Head.BkSeq)
+
+	require.Equal(t, `"3"`, string(resps[1].ID))
+	require.Equal(t, RPCErrorMethodNotFound, resps[1].Decode(nil).(*RPCError).Code)
+}
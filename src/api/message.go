@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+// MessageSignRequest is the request data for POST /api/v2/message/sign
+type MessageSignRequest struct {
+	WalletID string `json:"wallet_id"`
+	Password string `json:"password"`
+	Address  string `json:"address"`
+	Message  string `json:"message"`
+}
+
+// MessageSignResponse is returned by POST /api/v2/message/sign
+type MessageSignResponse struct {
+	Address   string `json:"address"`
+	Signature string `json:"signature"`
+}
+
+// messageSignHandler signs a message with the secret key of a wallet address,
+// to prove the ownership of the address without moving coins
+// Method: POST
+// URI: /api/v2/message/sign
+// Args: JSON body
+func messageSignHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		var req MessageSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			resp := NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		if req.WalletID == "" {
+			resp := NewHTTPErrorResponse(http.StatusBadRequest, "wallet_id is required")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		if req.Address == "" {
+			resp := NewHTTPErrorResponse(http.StatusBadRequest, "address is required")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		addr, err := cipher.DecodeAddress(params.AddressHRP, req.Address)
+		if err != nil {
+			resp := NewHTTPErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid address: %v", err))
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		sig, err := gateway.SignMessage(req.WalletID, []byte(req.Password), addr, []byte(req.Message))
+		if err != nil {
+			var resp HTTPResponse
+			switch err.(type) {
+			case wallet.Error:
+				switch err {
+				case wallet.ErrWalletNotExist:
+					resp = NewHTTPErrorResponse(http.StatusNotFound, err.Error())
+				case wallet.ErrWalletAPIDisabled:
+					resp = NewHTTPErrorResponse(http.StatusForbidden, err.Error())
+				default:
+					resp = NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+				}
+			default:
+				resp = NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+			}
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: MessageSignResponse{
+				Address:   addr.String(),
+				Signature: sig.Hex(),
+			},
+		})
+	}
+}
+
+// MessageVerifyRequest is the request data for POST /api/v2/message/verify
+type MessageVerifyRequest struct {
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// MessageVerifyResponse is returned by POST /api/v2/message/verify
+type MessageVerifyResponse struct {
+	Address string `json:"address"`
+}
+
+// messageVerifyHandler verifies that a message was signed by the owner of an address
+// Method: POST
+// URI: /api/v2/message/verify
+// Args: JSON body
+func messageVerifyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	var req MessageVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	if req.Address == "" {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, "address is required")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	if req.Signature == "" {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, "signature is required")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	addr, err := cipher.DecodeAddress(params.AddressHRP, req.Address)
+	if err != nil {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid address: %v", err))
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	sig, err := cipher.SigFromHex(req.Signature)
+	if err != nil {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid signature: %v", err))
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	if err := cipher.VerifyAddressSignedMessage(addr, sig, []byte(req.Message)); err != nil {
+		resp := NewHTTPErrorResponse(http.StatusUnprocessableEntity, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	writeHTTPResponse(w, HTTPResponse{
+		Data: MessageVerifyResponse{
+			Address: addr.String(),
+		},
+	})
+}
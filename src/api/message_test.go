@@ -0,0 +1,413 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+func TestMessageSign(t *testing.T) {
+	pub, sec := cipher.MustGenerateDeterministicKeyPair([]byte("seed"))
+	addr := cipher.AddressFromPubKey(pub)
+	bech32Addr, err := addr.Bech32("sky")
+	require.NoError(t, err)
+	msg := "I own this address"
+	sig, err := cipher.SignMessage([]byte(msg), sec)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		contentType  string
+		httpBody     string
+		req          *MessageSignRequest
+		gatewaySig   cipher.Sig
+		gatewayErr   error
+		httpResponse HTTPResponse
+	}{
+		{
+			name:         "405",
+			method:       http.MethodGet,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, ""),
+		},
+		{
+			name:         "415 - Unsupported Media Type",
+			method:       http.MethodPost,
+			contentType:  ContentTypeForm,
+			status:       http.StatusUnsupportedMediaType,
+			httpResponse: NewHTTPErrorResponse(http.StatusUnsupportedMediaType, ""),
+		},
+		{
+			name:         "400 - EOF",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "EOF"),
+		},
+		{
+			name:   "400 - missing wallet_id",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			httpBody: toJSON(t, MessageSignRequest{
+				Address: addr.String(),
+				Message: msg,
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "wallet_id is required"),
+		},
+		{
+			name:   "400 - missing address",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			httpBody: toJSON(t, MessageSignRequest{
+				WalletID: "foo.wlt",
+				Message:  msg,
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "address is required"),
+		},
+		{
+			name:   "400 - invalid address",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			httpBody: toJSON(t, MessageSignRequest{
+				WalletID: "foo.wlt",
+				Address:  "xxx",
+				Message:  msg,
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid address: Invalid address length"),
+		},
+		{
+			name:   "404 - wallet not exist",
+			method: http.MethodPost,
+			status: http.StatusNotFound,
+			req: &MessageSignRequest{
+				WalletID: "foo.wlt",
+				Address:  addr.String(),
+				Message:  msg,
+			},
+			gatewayErr:   wallet.ErrWalletNotExist,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, "wallet doesn't exist"),
+		},
+		{
+			name:   "403 - wallet api disabled",
+			method: http.MethodPost,
+			status: http.StatusForbidden,
+			req: &MessageSignRequest{
+				WalletID: "foo.wlt",
+				Address:  addr.String(),
+				Message:  msg,
+			},
+			gatewayErr:   wallet.ErrWalletAPIDisabled,
+			httpResponse: NewHTTPErrorResponse(http.StatusForbidden, "wallet api is disabled"),
+		},
+		{
+			name:   "400 - missing password",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			req: &MessageSignRequest{
+				WalletID: "foo.wlt",
+				Address:  addr.String(),
+				Message:  msg,
+			},
+			gatewayErr:   wallet.ErrMissingPassword,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "missing password"),
+		},
+		{
+			name:   "400 - address not in wallet",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			req: &MessageSignRequest{
+				WalletID: "foo.wlt",
+				Address:  addr.String(),
+				Message:  msg,
+			},
+			gatewayErr:   wallet.ErrUnknownAddress,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, wallet.ErrUnknownAddress.Error()),
+		},
+		{
+			name:   "500 - other error",
+			method: http.MethodPost,
+			status: http.StatusInternalServerError,
+			req: &MessageSignRequest{
+				WalletID: "foo.wlt",
+				Address:  addr.String(),
+				Message:  msg,
+			},
+			gatewayErr:   errors.New("failed"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "failed"),
+		},
+		{
+			name:   "200",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			req: &MessageSignRequest{
+				WalletID: "foo.wlt",
+				Password: "pwd",
+				Address:  addr.String(),
+				Message:  msg,
+			},
+			gatewaySig: sig,
+			httpResponse: HTTPResponse{
+				Data: MessageSignResponse{
+					Address:   addr.String(),
+					Signature: sig.Hex(),
+				},
+			},
+		},
+		{
+			name:   "200 - bech32 address",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			req: &MessageSignRequest{
+				WalletID: "foo.wlt",
+				Address:  bech32Addr,
+				Message:  msg,
+			},
+			gatewaySig: sig,
+			httpResponse: HTTPResponse{
+				Data: MessageSignResponse{
+					Address:   addr.String(),
+					Signature: sig.Hex(),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint := "/api/v2/message/sign"
+			gateway := &MockGatewayer{}
+
+			body := tc.httpBody
+			if tc.req != nil {
+				body = toJSON(t, tc.req)
+				gateway.On("SignMessage", tc.req.WalletID, []byte(tc.req.Password), addr, []byte(tc.req.Message)).Return(tc.gatewaySig, tc.gatewayErr)
+			}
+
+			req, err := http.NewRequest(tc.method, endpoint, strings.NewReader(body))
+			require.NoError(t, err)
+
+			contentType := tc.contentType
+			if contentType == "" {
+				contentType = ContentTypeJSON
+			}
+
+			req.Header.Set("Content-Type", contentType)
+			setCSRFParameters(t, tokenValid, req)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+			} else {
+				require.NotNil(t, tc.httpResponse.Data)
+
+				var signRsp MessageSignResponse
+				err := json.Unmarshal(rsp.Data, &signRsp)
+				require.NoError(t, err)
+				require.Equal(t, tc.httpResponse.Data, signRsp)
+
+				s, err := cipher.SigFromHex(signRsp.Signature)
+				require.NoError(t, err)
+				require.NoError(t, cipher.VerifyAddressSignedMessage(addr, s, []byte(msg)))
+			}
+		})
+	}
+}
+
+func TestMessageVerify(t *testing.T) {
+	pub, sec := cipher.MustGenerateDeterministicKeyPair([]byte("seed"))
+	addr := cipher.AddressFromPubKey(pub)
+	bech32Addr, err := addr.Bech32("sky")
+	require.NoError(t, err)
+	msg := "I own this address"
+	sig, err := cipher.SignMessage([]byte(msg), sec)
+	require.NoError(t, err)
+
+	otherPub, _ := cipher.MustGenerateDeterministicKeyPair([]byte("other"))
+	otherAddr := cipher.AddressFromPubKey(otherPub)
+
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		contentType  string
+		httpBody     string
+		httpResponse HTTPResponse
+	}{
+		{
+			name:         "405",
+			method:       http.MethodGet,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, ""),
+		},
+		{
+			name:         "415 - Unsupported Media Type",
+			method:       http.MethodPost,
+			contentType:  ContentTypeForm,
+			status:       http.StatusUnsupportedMediaType,
+			httpResponse: NewHTTPErrorResponse(http.StatusUnsupportedMediaType, ""),
+		},
+		{
+			name:         "400 - EOF",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "EOF"),
+		},
+		{
+			name:   "400 - missing address",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			httpBody: toJSON(t, MessageVerifyRequest{
+				Message:   msg,
+				Signature: sig.Hex(),
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "address is required"),
+		},
+		{
+			name:   "400 - missing signature",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			httpBody: toJSON(t, MessageVerifyRequest{
+				Address: addr.String(),
+				Message: msg,
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "signature is required"),
+		},
+		{
+			name:   "400 - invalid address",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			httpBody: toJSON(t, MessageVerifyRequest{
+				Address:   "xxx",
+				Message:   msg,
+				Signature: sig.Hex(),
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid address: Invalid address length"),
+		},
+		{
+			name:   "400 - invalid signature",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			httpBody: toJSON(t, MessageVerifyRequest{
+				Address:   addr.String(),
+				Message:   msg,
+				Signature: "abcd",
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid signature: Invalid signature length"),
+		},
+		{
+			name:   "422 - other message",
+			method: http.MethodPost,
+			status: http.StatusUnprocessableEntity,
+			httpBody: toJSON(t, MessageVerifyRequest{
+				Address:   addr.String(),
+				Message:   msg + ".",
+				Signature: sig.Hex(),
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusUnprocessableEntity, cipher.ErrInvalidAddressForSig.Error()),
+		},
+		{
+			name:   "422 - other address",
+			method: http.MethodPost,
+			status: http.StatusUnprocessableEntity,
+			httpBody: toJSON(t, MessageVerifyRequest{
+				Address:   otherAddr.String(),
+				Message:   msg,
+				Signature: sig.Hex(),
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusUnprocessableEntity, cipher.ErrInvalidAddressForSig.Error()),
+		},
+		{
+			name:   "200",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			httpBody: toJSON(t, MessageVerifyRequest{
+				Address:   addr.String(),
+				Message:   msg,
+				Signature: sig.Hex(),
+			}),
+			httpResponse: HTTPResponse{
+				Data: MessageVerifyResponse{
+					Address: addr.String(),
+				},
+			},
+		},
+		{
+			name:   "200 - bech32 address",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			httpBody: toJSON(t, MessageVerifyRequest{
+				Address:   bech32Addr,
+				Message:   msg,
+				Signature: sig.Hex(),
+			}),
+			httpResponse: HTTPResponse{
+				Data: MessageVerifyResponse{
+					Address: addr.String(),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint := "/api/v2/message/verify"
+			gateway := &MockGatewayer{}
+
+			req, err := http.NewRequest(tc.method, endpoint, strings.NewReader(tc.httpBody))
+			require.NoError(t, err)
+
+			contentType := tc.contentType
+			if contentType == "" {
+				contentType = ContentTypeJSON
+			}
+
+			req.Header.Set("Content-Type", contentType)
+			setCSRFParameters(t, tokenValid, req)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+			} else {
+				require.NotNil(t, tc.httpResponse.Data)
+
+				var verifyRsp MessageVerifyResponse
+				err := json.Unmarshal(rsp.Data, &verifyRsp)
+				require.NoError(t, err)
+				require.Equal(t, tc.httpResponse.Data, verifyRsp)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/skycoin/skycoin/src/daemon"
+	wh "github.com/skycoin/skycoin/src/util/http"
+	"github.com/skycoin/skycoin/src/util/metrics"
+)
+
+var (
+	requestDuration = metrics.NewHistogramVec("skycoin_api_request_duration_seconds", "Duration of the API requests, by route and method", nil, "route", "method")
+)
+
+// requestMetrics records the duration of the requests to a route
+func requestMetrics(route string, f http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t := time.Now()
+		f.ServeHTTP(w, r)
+		requestDuration.ObserveDuration(time.Since(t), route, requestMethodLabel(r.Method))
+	})
+}
+
+// requestMethodLabel returns the method label of a request, limiting the label values to the standard methods
+func requestMethodLabel(method string) string {
+	switch method {
+	case http.MethodGet,
+		http.MethodHead,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+		http.MethodConnect,
+		http.MethodOptions,
+		http.MethodTrace:
+		return method
+	default:
+		return "OTHER"
+	}
+}
+
+// collectNodeMetrics returns a registry with the gauges of the node state
+func collectNodeMetrics(gateway Gatewayer) (*metrics.Registry, error) {
+	metadata, err := gateway.GetBlockchainMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("gateway.GetBlockchainMetadata failed: %v", err)
+	}
+
+	txns, err := gateway.GetAllUnconfirmedTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("gateway.GetAllUnconfirmedTransactions failed: %v", err)
+	}
+
+	var txnsSize uint64
+	for _, txn := range txns {
+		size, err := txn.Transaction.Size()
+		if err != nil {
+			return nil, fmt.Errorf("Transaction.Size failed: %v", err)
+		}
+		txnsSize += uint64(size)
+	}
+
+	conns, err := gateway.GetConnections(func(c daemon.Connection) bool {
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gateway.GetConnections failed: %v", err)
+	}
+
+	r := metrics.NewRegistry()
+
+	headSeq := metrics.NewGaugeVec("skycoin_blockchain_head_seq", "Sequence number of the head block")
+	headSeq.Set(float64(metadata.HeadBlock.Head.BkSeq))
+	r.MustRegister(headSeq)
+
+	height := metrics.NewGaugeVec("skycoin_blockchain_height", "Number of blocks in the blockchain")
+	height.Set(float64(metadata.HeadBlock.Head.BkSeq + 1))
+	r.MustRegister(height)
+
+	headTime := metrics.NewGaugeVec("skycoin_blockchain_head_time_seconds", "Timestamp of the head block")
+	headTime.Set(float64(metadata.HeadBlock.Head.Time))
+	r.MustRegister(headTime)
+
+	unspents := metrics.NewGaugeVec("skycoin_blockchain_unspents", "Number of unspent outputs")
+	unspents.Set(float64(metadata.Unspents))
+	r.MustRegister(unspents)
+
+	mempoolTxns := metrics.NewGaugeVec("skycoin_mempool_transactions", "Number of unconfirmed transactions")
+	mempoolTxns.Set(float64(len(txns)))
+	r.MustRegister(mempoolTxns)
+
+	mempoolBytes := metrics.NewGaugeVec("skycoin_mempool_bytes", "Size of the unconfirmed transactions in bytes")
+	mempoolBytes.Set(float64(txnsSize))
+	r.MustRegister(mempoolBytes)
+
+	connections := metrics.NewGaugeVec("skycoin_connections", "Number of connections, by state and direction", "state", "direction")
+	for _, s := range []daemon.ConnectionState{
+		daemon.ConnectionStatePending,
+		daemon.ConnectionStateConnected,
+		daemon.ConnectionStateIntroduced,
+	} {
+		connections.Set(0, string(s), "outgoing")
+		connections.Set(0, string(s), "incoming")
+	}
+	for _, c := range conns {
+		direction := "incoming"
+		if c.Outgoing {
+			direction = "outgoing"
+		}
+		connections.Add(1, string(c.State), direction)
+	}
+	r.MustRegister(connections)
+
+	return r, nil
+}
+
+// metricsHandler returns node metrics in the Prometheus text format
+// URI: /metrics
+// Method: GET
+func metricsHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			wh.Error405(w)
+			return
+		}
+
+		nodeMetrics, err := collectNodeMetrics(gateway)
+		if err != nil {
+			wh.Error500(w, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", metrics.ContentType)
+
+		if err := nodeMetrics.WriteText(w); err != nil {
+			logger.WithError(err).Error("Write node metrics failed")
+			return
+		}
+
+		if err := metrics.DefaultRegistry.WriteText(w); err != nil {
+			logger.WithError(err).Error("Write metrics failed")
+		}
+	}
+}
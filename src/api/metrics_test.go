@@ -0,0 +1,152 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/daemon"
+	"github.com/skycoin/skycoin/src/util/metrics"
+	"github.com/skycoin/skycoin/src/visor"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	txn := makeTransaction(t)
+	txnSize, err := txn.Size()
+	require.NoError(t, err)
+
+	metadata := &visor.BlockchainMetadata{
+		HeadBlock: coin.SignedBlock{
+			Block: coin.Block{
+				Head: coin.BlockHeader{
+					BkSeq: 21175,
+					Time:  1523168686,
+				},
+			},
+		},
+		Unspents:    10,
+		Unconfirmed: 2,
+	}
+
+	conns := []daemon.Connection{
+		{
+			ConnectionDetails: daemon.ConnectionDetails{
+				Outgoing: true,
+				State:    daemon.ConnectionStateIntroduced,
+			},
+		},
+		{
+			ConnectionDetails: daemon.ConnectionDetails{
+				Outgoing: true,
+				State:    daemon.ConnectionStateIntroduced,
+			},
+		},
+		{
+			ConnectionDetails: daemon.ConnectionDetails{
+				Outgoing: false,
+				State:    daemon.ConnectionStatePending,
+			},
+		},
+	}
+
+	cases := []struct {
+		name                     string
+		method                   string
+		enabledAPISets           map[string]struct{}
+		status                   int
+		err                      string
+		getBlockchainMetadataErr error
+		contains                 []string
+	}{
+		{
+			name:           "405",
+			method:         http.MethodPost,
+			enabledAPISets: allAPISetsEnabled,
+			status:         http.StatusMethodNotAllowed,
+			err:            "405 Method Not Allowed",
+		},
+		{
+			name:   "403 endpoint disabled",
+			method: http.MethodGet,
+			enabledAPISets: map[string]struct{}{
+				EndpointsRead:   struct{}{},
+				EndpointsStatus: struct{}{},
+			},
+			status: http.StatusForbidden,
+			err:    "403 Forbidden - Endpoint is disabled",
+		},
+		{
+			name:                     "500 gateway.GetBlockchainMetadata error",
+			method:                   http.MethodGet,
+			enabledAPISets:           allAPISetsEnabled,
+			status:                   http.StatusInternalServerError,
+			err:                      "500 Internal Server Error - gateway.GetBlockchainMetadata failed: GetBlockchainMetadata failed",
+			getBlockchainMetadataErr: errors.New("GetBlockchainMetadata failed"),
+		},
+		{
+			name:           "200",
+			method:         http.MethodGet,
+			enabledAPISets: allAPISetsEnabled,
+			status:         http.StatusOK,
+			contains: []string{
+				"# TYPE skycoin_blockchain_head_seq gauge\nskycoin_blockchain_head_seq 21175\n",
+				"skycoin_blockchain_height 21176\n",
+				"skycoin_blockchain_head_time_seconds 1.523168686e+09\n",
+				"skycoin_blockchain_unspents 10\n",
+				"skycoin_mempool_transactions 2\n",
+				fmt.Sprintf("skycoin_mempool_bytes %d\n", 2*txnSize),
+				`skycoin_connections{state="introduced",direction="outgoing"} 2` + "\n",
+				`skycoin_connections{state="introduced",direction="incoming"} 0` + "\n",
+				`skycoin_connections{state="pending",direction="incoming"} 1` + "\n",
+				"# TYPE skycoin_api_request_duration_seconds histogram\n",
+				"# TYPE skycoin_db_tx_duration_seconds histogram\n",
+				"# TYPE skycoin_gnet_messages_total counter\n",
+				"# TYPE skycoin_strand_queue_duration_seconds histogram\n",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetBlockchainMetadata").Return(metadata, tc.getBlockchainMetadataErr)
+			gateway.On("GetAllUnconfirmedTransactions").Return([]visor.UnconfirmedTransaction{
+				{Transaction: txn},
+				{Transaction: txn},
+			}, nil)
+			gateway.On("GetConnections", mock.Anything).Return(conns, nil)
+
+			req, err := http.NewRequest(tc.method, "/metrics", nil)
+			require.NoError(t, err)
+
+			cfg := defaultMuxConfig()
+			cfg.enabledAPISets = tc.enabledAPISets
+			handler := newServerMux(cfg, gateway)
+
+			requests := requestDuration.Count("/metrics", tc.method)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+			require.Equal(t, requests+1, requestDuration.Count("/metrics", tc.method))
+
+			if tc.status != http.StatusOK {
+				require.Equal(t, tc.err, strings.TrimSpace(rr.Body.String()))
+				return
+			}
+
+			require.Equal(t, metrics.ContentType, rr.Header().Get("Content-Type"))
+			for _, s := range tc.contains {
+				require.Contains(t, rr.Body.String(), s)
+			}
+		})
+	}
+}
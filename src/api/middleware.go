@@ -1,12 +1,19 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"crypto/subtle"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/skycoin/skycoin/src/apitoken"
 	"github.com/skycoin/skycoin/src/cipher"
 	wh "github.com/skycoin/skycoin/src/util/http"
 	"github.com/skycoin/skycoin/src/util/iputil"
@@ -196,6 +203,219 @@ func basicAuth(apiVersion, username, password, realm string, f http.Handler) htt
 	}
 }
 
+type contextKey int
+
+const (
+	apiTokenContextKey contextKey = iota
+)
+
+// bearerToken returns the token of an "Authorization: Bearer" header, if present
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) < len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(auth[len(prefix):]), true
+}
+
+// apiTokenFromContext returns the API token that authenticated the request, if any
+func apiTokenFromContext(ctx context.Context) *apitoken.Token {
+	t, _ := ctx.Value(apiTokenContextKey).(*apitoken.Token)
+	return t
+}
+
+// tokenAuth authenticates requests with an "Authorization: Bearer" header against the API tokens store.
+// The authenticated token is added to the request context, for forMethodAPISets to enforce its permissions.
+// Requests without a bearer token are passed to the fallback handler, which handles basic auth.
+// Failed authentications are charged to the IP address of the client in the rate limiter, if rate limiting is enabled,
+// since they are rejected before reaching the rate limiter of the endpoint.
+func tokenAuth(apiVersion string, tokens *apitoken.Store, l *RateLimiter, f, fallback http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		secret, ok := bearerToken(r)
+		if !ok {
+			fallback.ServeHTTP(w, r)
+			return
+		}
+
+		if tokens == nil {
+			writeError(w, apiVersion, http.StatusUnauthorized, "API tokens are not enabled")
+			return
+		}
+
+		t, err := tokens.Authenticate(secret, time.Now())
+		if err != nil {
+			switch err {
+			case apitoken.ErrInvalidToken, apitoken.ErrTokenExpired:
+				if l != nil {
+					if ok, wait := l.take(rateLimitKey(r), rateLimitAuthFailureCost); !ok {
+						writeTooManyRequests(w, apiVersion, wait)
+						return
+					}
+				}
+				writeError(w, apiVersion, http.StatusUnauthorized, err.Error())
+			default:
+				logger.WithError(err).Error("API token authentication failed")
+				writeError(w, apiVersion, http.StatusInternalServerError, "")
+			}
+			return
+		}
+
+		f.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiTokenContextKey, t)))
+	}
+}
+
+var (
+	errMethodDisabled   = errors.New("Method is disabled")
+	errMethodNotGranted = errors.New("API token does not grant access to this method")
+	errWalletNotGranted = errors.New("API token does not grant access to this wallet")
+
+	errAmbiguousWalletID = errors.New("id and wallet_id can't both be specified")
+	errWalletIDMismatch  = errors.New("wallet ID of the query does not match the wallet ID of the request body")
+)
+
+// authorizeAPISets checks that one of the API sets of an endpoint or method is enabled and granted by
+// the API token which authenticated the call, if any.
+// Returns true if the call is only authorized through the wallet API sets.
+func authorizeAPISets(enabledAPISets map[string]struct{}, token *apitoken.Token, apiSets []string) (bool, error) {
+	enabled := false
+	var granted []string
+	for _, k := range apiSets {
+		if _, ok := enabledAPISets[k]; !ok {
+			continue
+		}
+
+		enabled = true
+		if token == nil || token.HasAPISet(k) {
+			granted = append(granted, k)
+		}
+	}
+
+	if !enabled {
+		return false, errMethodDisabled
+	}
+
+	if len(granted) == 0 {
+		return false, errMethodNotGranted
+	}
+
+	return onlyWalletAPISets(granted), nil
+}
+
+// restrictsWallet returns true if a call authorized by token must refer to one of the wallets of the token.
+// This is the case for tokens restricted to specific wallets, if the call is only authorized through the wallet API sets
+// and the method either reads a wallet ID or belongs to the wallet API sets alone, such as listing the wallets.
+// Methods which also belong to another API set and don't refer to a wallet, such as injecting a transaction, are not restricted.
+func restrictsWallet(token *apitoken.Token, walletOnly bool, apiSets []string, src walletIDSource) bool {
+	if token == nil || !token.RestrictsWallets() || !walletOnly {
+		return false
+	}
+
+	return src.field != "" || onlyWalletAPISets(apiSets)
+}
+
+// checkMethodAccess checks that one of the API sets of a JSON-RPC or gRPC method is enabled and granted by
+// the API token which authenticated the call, if any, in the same way as forMethodAPISets does for endpoints.
+// None of the methods read a wallet ID, so tokens restricted to specific wallets can't use the methods
+// which belong to the wallet API sets alone.
+func checkMethodAccess(enabledAPISets map[string]struct{}, token *apitoken.Token, apiSets []string) error {
+	walletOnly, err := authorizeAPISets(enabledAPISets, token, apiSets)
+	if err != nil {
+		return err
+	}
+
+	if restrictsWallet(token, walletOnly, apiSets, walletIDSource{}) {
+		return errWalletNotGranted
+	}
+
+	return nil
+}
+
+// isWalletAPISet returns true if k is one of the wallet API sets
+func isWalletAPISet(k string) bool {
+	switch k {
+	case EndpointsWallet, EndpointsInsecureWalletSeed:
+		return true
+	default:
+		return false
+	}
+}
+
+// onlyWalletAPISets returns true if all of apiSets are wallet API sets
+func onlyWalletAPISets(apiSets []string) bool {
+	if len(apiSets) == 0 {
+		return false
+	}
+
+	for _, k := range apiSets {
+		if !isWalletAPISet(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// walletIDSource is where the handler of a wallet method reads the ID of the wallet the request refers to from
+type walletIDSource struct {
+	// field is the name of the query or form parameter, or of the JSON request body field
+	field string
+	// body is true if the field is a field of the JSON request body
+	body bool
+}
+
+// requestWalletID returns the wallet ID a request refers to, read from the field which the handler reads it from.
+// Requests which specify both "id" and "wallet_id", or whose query and JSON body specify different wallet IDs,
+// are rejected, so that the wallet checked against the API token is always the wallet used by the handler.
+func requestWalletID(r *http.Request, src walletIDSource) (string, error) {
+	if src.field == "" {
+		return "", nil
+	}
+
+	if r.FormValue("id") != "" && r.FormValue("wallet_id") != "" {
+		return "", errAmbiguousWalletID
+	}
+
+	if !src.body {
+		return r.FormValue(src.field), nil
+	}
+
+	if r.Method != http.MethodPost || r.Body == nil || !isContentTypeJSON(r.Header.Get("Content-Type")) {
+		return "", nil
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return "", err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var req struct {
+		ID       string `json:"id"`
+		WalletID string `json:"wallet_id"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		// Invalid bodies are rejected by the handler
+		return "", nil
+	}
+
+	if req.ID != "" && req.WalletID != "" {
+		return "", errAmbiguousWalletID
+	}
+
+	id := req.ID
+	if src.field == "wallet_id" {
+		id = req.WalletID
+	}
+
+	for _, k := range []string{"id", "wallet_id"} {
+		if v := r.FormValue(k); v != "" && v != id {
+			return "", errWalletIDMismatch
+		}
+	}
+
+	return id, nil
+}
+
 func writeError(w http.ResponseWriter, apiVersion string, code int, msg string) {
 	switch apiVersion {
 	case apiVersion1:
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/apitoken"
 )
 
 func TestOriginRefererCheck(t *testing.T) {
@@ -282,3 +284,66 @@ func TestIsContentTypeJSON(t *testing.T) {
 	require.False(t, isContentTypeJSON("application/x-www-form-urlencoded"))
 	require.False(t, isContentTypeJSON(ContentTypeForm))
 }
+
+func TestCheckMethodAccess(t *testing.T) {
+	enabled := map[string]struct{}{
+		EndpointsRead:        struct{}{},
+		EndpointsTransaction: struct{}{},
+		EndpointsWallet:      struct{}{},
+	}
+
+	walletToken := &apitoken.Token{APISets: []string{EndpointsWallet}, WalletIDs: []string{"foo.wlt"}}
+	txnWalletToken := &apitoken.Token{APISets: []string{EndpointsTransaction, EndpointsWallet}, WalletIDs: []string{"foo.wlt"}}
+	walletAllToken := &apitoken.Token{APISets: []string{EndpointsWallet}}
+
+	cases := []struct {
+		name    string
+		token   *apitoken.Token
+		apiSets []string
+		err     error
+	}{
+		{
+			name:    "no token",
+			apiSets: []string{EndpointsWallet},
+		},
+		{
+			name:    "disabled",
+			token:   walletAllToken,
+			apiSets: []string{EndpointsNetCtrl},
+			err:     errMethodDisabled,
+		},
+		{
+			name:    "not granted",
+			token:   walletAllToken,
+			apiSets: []string{EndpointsRead},
+			err:     errMethodNotGranted,
+		},
+		{
+			name:    "unrestricted token, wallet method",
+			token:   walletAllToken,
+			apiSets: []string{EndpointsWallet},
+		},
+		{
+			name:    "restricted token, wallet method",
+			token:   walletToken,
+			apiSets: []string{EndpointsWallet},
+			err:     errWalletNotGranted,
+		},
+		{
+			name:    "restricted token, inject transaction through the wallet api set",
+			token:   walletToken,
+			apiSets: []string{EndpointsTransaction, EndpointsWallet},
+		},
+		{
+			name:    "restricted token, inject transaction through the transaction api set",
+			token:   txnWalletToken,
+			apiSets: []string{EndpointsTransaction, EndpointsWallet},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, checkMethodAccess(enabled, tc.token, tc.apiSets))
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	payout "github.com/skycoin/skycoin/src/payout"
+
 	time "time"
 
 	transaction "github.com/skycoin/skycoin/src/transaction"
@@ -21,6 +23,8 @@ import (
 	visor "github.com/skycoin/skycoin/src/visor"
 
 	wallet "github.com/skycoin/skycoin/src/wallet"
+
+	webhook "github.com/skycoin/skycoin/src/webhook"
 )
 
 // MockGatewayer is an autogenerated mock type for the Gatewayer type
@@ -63,6 +67,29 @@ func (_m *MockGatewayer) AddressCount() (uint64, error) {
 	return r0, r1
 }
 
+// CreatePayoutJob provides a mock function with given fields: r, password
+func (_m *MockGatewayer) CreatePayoutJob(r payout.JobRequest, password []byte) (*payout.Job, error) {
+	ret := _m.Called(r, password)
+
+	var r0 *payout.Job
+	if rf, ok := ret.Get(0).(func(payout.JobRequest, []byte) *payout.Job); ok {
+		r0 = rf(r, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payout.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(payout.JobRequest, []byte) error); ok {
+		r1 = rf(r, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTransaction provides a mock function with given fields: p, wp
 func (_m *MockGatewayer) CreateTransaction(p transaction.Params, wp visor.CreateTransactionParams) (*coin.Transaction, []visor.TransactionInput, error) {
 	ret := _m.Called(p, wp)
@@ -118,6 +145,29 @@ func (_m *MockGatewayer) CreateWallet(wltName string, options wallet.Options) (w
 	return r0, r1
 }
 
+// CreateWebhook provides a mock function with given fields: r
+func (_m *MockGatewayer) CreateWebhook(r webhook.Request) (*webhook.Webhook, error) {
+	ret := _m.Called(r)
+
+	var r0 *webhook.Webhook
+	if rf, ok := ret.Get(0).(func(webhook.Request) *webhook.Webhook); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(webhook.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DaemonConfig provides a mock function with given fields:
 func (_m *MockGatewayer) DaemonConfig() daemon.DaemonConfig {
 	ret := _m.Called()
@@ -155,6 +205,20 @@ func (_m *MockGatewayer) DecryptWallet(wltID string, password []byte) (wallet.Wa
 	return r0, r1
 }
 
+// DeleteWebhook provides a mock function with given fields: id
+func (_m *MockGatewayer) DeleteWebhook(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DisconnectByGnetID provides a mock function with given fields: gnetID
 func (_m *MockGatewayer) DisconnectByGnetID(gnetID uint64) error {
 	ret := _m.Called(gnetID)
@@ -192,6 +256,45 @@ func (_m *MockGatewayer) EncryptWallet(wltID string, password []byte) (wallet.Wa
 	return r0, r1
 }
 
+// GetAddressUxOutsPage provides a mock function with given fields: addr, after, order, limit
+func (_m *MockGatewayer) GetAddressUxOutsPage(addr cipher.Address, after *visor.Cursor, order visor.SortOrder, limit uint64) ([]historydb.UxOut, *visor.Cursor, uint64, error) {
+	ret := _m.Called(addr, after, order, limit)
+
+	var r0 []historydb.UxOut
+	if rf, ok := ret.Get(0).(func(cipher.Address, *visor.Cursor, visor.SortOrder, uint64) []historydb.UxOut); ok {
+		r0 = rf(addr, after, order, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]historydb.UxOut)
+		}
+	}
+
+	var r1 *visor.Cursor
+	if rf, ok := ret.Get(1).(func(cipher.Address, *visor.Cursor, visor.SortOrder, uint64) *visor.Cursor); ok {
+		r1 = rf(addr, after, order, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*visor.Cursor)
+		}
+	}
+
+	var r2 uint64
+	if rf, ok := ret.Get(2).(func(cipher.Address, *visor.Cursor, visor.SortOrder, uint64) uint64); ok {
+		r2 = rf(addr, after, order, limit)
+	} else {
+		r2 = ret.Get(2).(uint64)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(cipher.Address, *visor.Cursor, visor.SortOrder, uint64) error); ok {
+		r3 = rf(addr, after, order, limit)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 // GetAllStorageValues provides a mock function with given fields: storageType
 func (_m *MockGatewayer) GetAllStorageValues(storageType kvstorage.Type) (map[string]string, error) {
 	ret := _m.Called(storageType)
@@ -293,6 +396,38 @@ func (_m *MockGatewayer) GetBalanceOfAddresses(addrs []cipher.Address) ([]wallet
 	return r0, r1
 }
 
+// GetBalancesAt provides a mock function with given fields: addrs, seq
+func (_m *MockGatewayer) GetBalancesAt(addrs []cipher.Address, seq uint64) ([]wallet.Balance, *coin.SignedBlock, error) {
+	ret := _m.Called(addrs, seq)
+
+	var r0 []wallet.Balance
+	if rf, ok := ret.Get(0).(func([]cipher.Address, uint64) []wallet.Balance); ok {
+		r0 = rf(addrs, seq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wallet.Balance)
+		}
+	}
+
+	var r1 *coin.SignedBlock
+	if rf, ok := ret.Get(1).(func([]cipher.Address, uint64) *coin.SignedBlock); ok {
+		r1 = rf(addrs, seq)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*coin.SignedBlock)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func([]cipher.Address, uint64) error); ok {
+		r2 = rf(addrs, seq)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetBlockchainMetadata provides a mock function with given fields:
 func (_m *MockGatewayer) GetBlockchainMetadata() (*visor.BlockchainMetadata, error) {
 	ret := _m.Called()
@@ -410,6 +545,79 @@ func (_m *MockGatewayer) GetBlocksInRangeVerbose(start uint64, end uint64) ([]co
 	return r0, r1, r2
 }
 
+// GetBlocksPage provides a mock function with given fields: after, order, limit
+func (_m *MockGatewayer) GetBlocksPage(after *visor.Cursor, order visor.SortOrder, limit uint64) ([]coin.SignedBlock, *visor.Cursor, error) {
+	ret := _m.Called(after, order, limit)
+
+	var r0 []coin.SignedBlock
+	if rf, ok := ret.Get(0).(func(*visor.Cursor, visor.SortOrder, uint64) []coin.SignedBlock); ok {
+		r0 = rf(after, order, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]coin.SignedBlock)
+		}
+	}
+
+	var r1 *visor.Cursor
+	if rf, ok := ret.Get(1).(func(*visor.Cursor, visor.SortOrder, uint64) *visor.Cursor); ok {
+		r1 = rf(after, order, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*visor.Cursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*visor.Cursor, visor.SortOrder, uint64) error); ok {
+		r2 = rf(after, order, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBlocksPageVerbose provides a mock function with given fields: after, order, limit
+func (_m *MockGatewayer) GetBlocksPageVerbose(after *visor.Cursor, order visor.SortOrder, limit uint64) ([]coin.SignedBlock, [][][]visor.TransactionInput, *visor.Cursor, error) {
+	ret := _m.Called(after, order, limit)
+
+	var r0 []coin.SignedBlock
+	if rf, ok := ret.Get(0).(func(*visor.Cursor, visor.SortOrder, uint64) []coin.SignedBlock); ok {
+		r0 = rf(after, order, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]coin.SignedBlock)
+		}
+	}
+
+	var r1 [][][]visor.TransactionInput
+	if rf, ok := ret.Get(1).(func(*visor.Cursor, visor.SortOrder, uint64) [][][]visor.TransactionInput); ok {
+		r1 = rf(after, order, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([][][]visor.TransactionInput)
+		}
+	}
+
+	var r2 *visor.Cursor
+	if rf, ok := ret.Get(2).(func(*visor.Cursor, visor.SortOrder, uint64) *visor.Cursor); ok {
+		r2 = rf(after, order, limit)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*visor.Cursor)
+		}
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(*visor.Cursor, visor.SortOrder, uint64) error); ok {
+		r3 = rf(after, order, limit)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 // GetBlocksVerbose provides a mock function with given fields: seqs
 func (_m *MockGatewayer) GetBlocksVerbose(seqs []uint64) ([]coin.SignedBlock, [][][]visor.TransactionInput, error) {
 	ret := _m.Called(seqs)
@@ -575,6 +783,52 @@ func (_m *MockGatewayer) GetLastBlocksVerbose(num uint64) ([]coin.SignedBlock, [
 	return r0, r1, r2
 }
 
+// GetPayoutJob provides a mock function with given fields: key
+func (_m *MockGatewayer) GetPayoutJob(key string) (*payout.Job, error) {
+	ret := _m.Called(key)
+
+	var r0 *payout.Job
+	if rf, ok := ret.Get(0).(func(string) *payout.Job); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payout.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayoutJobs provides a mock function with given fields:
+func (_m *MockGatewayer) GetPayoutJobs() ([]payout.Job, error) {
+	ret := _m.Called()
+
+	var r0 []payout.Job
+	if rf, ok := ret.Get(0).(func() []payout.Job); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]payout.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRichlist provides a mock function with given fields: includeDistribution
 func (_m *MockGatewayer) GetRichlist(includeDistribution bool) (visor.Richlist, error) {
 	ret := _m.Called(includeDistribution)
@@ -598,6 +852,38 @@ func (_m *MockGatewayer) GetRichlist(includeDistribution bool) (visor.Richlist,
 	return r0, r1
 }
 
+// GetRichlistAt provides a mock function with given fields: seq, includeDistribution
+func (_m *MockGatewayer) GetRichlistAt(seq uint64, includeDistribution bool) (visor.Richlist, *coin.SignedBlock, error) {
+	ret := _m.Called(seq, includeDistribution)
+
+	var r0 visor.Richlist
+	if rf, ok := ret.Get(0).(func(uint64, bool) visor.Richlist); ok {
+		r0 = rf(seq, includeDistribution)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(visor.Richlist)
+		}
+	}
+
+	var r1 *coin.SignedBlock
+	if rf, ok := ret.Get(1).(func(uint64, bool) *coin.SignedBlock); ok {
+		r1 = rf(seq, includeDistribution)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*coin.SignedBlock)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(uint64, bool) error); ok {
+		r2 = rf(seq, includeDistribution)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSignedBlockByHash provides a mock function with given fields: hash
 func (_m *MockGatewayer) GetSignedBlockByHash(hash cipher.SHA256) (*coin.SignedBlock, error) {
 	ret := _m.Called(hash)
@@ -844,6 +1130,79 @@ func (_m *MockGatewayer) GetTransactions(flts []visor.TxFilter, order visor.Sort
 	return r0, r1, r2
 }
 
+// GetTransactionsPage provides a mock function with given fields: addrs, after, order, limit
+func (_m *MockGatewayer) GetTransactionsPage(addrs []cipher.Address, after *visor.Cursor, order visor.SortOrder, limit uint64) ([]visor.Transaction, *visor.Cursor, error) {
+	ret := _m.Called(addrs, after, order, limit)
+
+	var r0 []visor.Transaction
+	if rf, ok := ret.Get(0).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) []visor.Transaction); ok {
+		r0 = rf(addrs, after, order, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]visor.Transaction)
+		}
+	}
+
+	var r1 *visor.Cursor
+	if rf, ok := ret.Get(1).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) *visor.Cursor); ok {
+		r1 = rf(addrs, after, order, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*visor.Cursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) error); ok {
+		r2 = rf(addrs, after, order, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTransactionsPageWithInputs provides a mock function with given fields: addrs, after, order, limit
+func (_m *MockGatewayer) GetTransactionsPageWithInputs(addrs []cipher.Address, after *visor.Cursor, order visor.SortOrder, limit uint64) ([]visor.Transaction, [][]visor.TransactionInput, *visor.Cursor, error) {
+	ret := _m.Called(addrs, after, order, limit)
+
+	var r0 []visor.Transaction
+	if rf, ok := ret.Get(0).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) []visor.Transaction); ok {
+		r0 = rf(addrs, after, order, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]visor.Transaction)
+		}
+	}
+
+	var r1 [][]visor.TransactionInput
+	if rf, ok := ret.Get(1).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) [][]visor.TransactionInput); ok {
+		r1 = rf(addrs, after, order, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([][]visor.TransactionInput)
+		}
+	}
+
+	var r2 *visor.Cursor
+	if rf, ok := ret.Get(2).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) *visor.Cursor); ok {
+		r2 = rf(addrs, after, order, limit)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*visor.Cursor)
+		}
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) error); ok {
+		r3 = rf(addrs, after, order, limit)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 // GetTransactionsWithInputs provides a mock function with given fields: flts, order, page
 func (_m *MockGatewayer) GetTransactionsWithInputs(flts []visor.TxFilter, order visor.SortOrder, page *visor.PageIndex) ([]visor.Transaction, [][]visor.TransactionInput, uint64, error) {
 	ret := _m.Called(flts, order, page)
@@ -899,6 +1258,47 @@ func (_m *MockGatewayer) GetTrustConnections() []string {
 	return r0
 }
 
+// GetUnspentOutputsPage provides a mock function with given fields: addrs, after, order, limit
+func (_m *MockGatewayer) GetUnspentOutputsPage(addrs []cipher.Address, after *visor.Cursor, order visor.SortOrder, limit uint64) ([]visor.UnspentOutput, *visor.Cursor, *coin.SignedBlock, error) {
+	ret := _m.Called(addrs, after, order, limit)
+
+	var r0 []visor.UnspentOutput
+	if rf, ok := ret.Get(0).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) []visor.UnspentOutput); ok {
+		r0 = rf(addrs, after, order, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]visor.UnspentOutput)
+		}
+	}
+
+	var r1 *visor.Cursor
+	if rf, ok := ret.Get(1).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) *visor.Cursor); ok {
+		r1 = rf(addrs, after, order, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*visor.Cursor)
+		}
+	}
+
+	var r2 *coin.SignedBlock
+	if rf, ok := ret.Get(2).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) *coin.SignedBlock); ok {
+		r2 = rf(addrs, after, order, limit)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*coin.SignedBlock)
+		}
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func([]cipher.Address, *visor.Cursor, visor.SortOrder, uint64) error); ok {
+		r3 = rf(addrs, after, order, limit)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 // GetUnspentOutputsSummary provides a mock function with given fields: filters
 func (_m *MockGatewayer) GetUnspentOutputsSummary(filters []visor.OutputsFilter) (*visor.UnspentOutputsSummary, error) {
 	ret := _m.Called(filters)
@@ -952,6 +1352,29 @@ func (_m *MockGatewayer) GetUxOutByID(id cipher.SHA256) (*historydb.UxOut, uint6
 	return r0, r1, r2
 }
 
+// GetUxOutsMeta provides a mock function with given fields: wltID
+func (_m *MockGatewayer) GetUxOutsMeta(wltID string) (wallet.UxOutsMeta, error) {
+	ret := _m.Called(wltID)
+
+	var r0 wallet.UxOutsMeta
+	if rf, ok := ret.Get(0).(func(string) wallet.UxOutsMeta); ok {
+		r0 = rf(wltID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(wallet.UxOutsMeta)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(wltID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWallet provides a mock function with given fields: wltID
 func (_m *MockGatewayer) GetWallet(wltID string) (wallet.Wallet, error) {
 	ret := _m.Called(wltID)
@@ -1005,6 +1428,27 @@ func (_m *MockGatewayer) GetWalletBalance(wltID string) (wallet.BalancePair, wal
 	return r0, r1, r2
 }
 
+// GetWalletDescriptor provides a mock function with given fields: wltID, password
+func (_m *MockGatewayer) GetWalletDescriptor(wltID string, password []byte) (string, error) {
+	ret := _m.Called(wltID, password)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, []byte) string); ok {
+		r0 = rf(wltID, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(wltID, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWalletSeed provides a mock function with given fields: wltID, password
 func (_m *MockGatewayer) GetWalletSeed(wltID string, password []byte) (string, string, error) {
 	ret := _m.Called(wltID, password)
@@ -1111,6 +1555,75 @@ func (_m *MockGatewayer) GetWallets() (wallet.Wallets, error) {
 	return r0, r1
 }
 
+// GetWebhook provides a mock function with given fields: id
+func (_m *MockGatewayer) GetWebhook(id string) (*webhook.Webhook, error) {
+	ret := _m.Called(id)
+
+	var r0 *webhook.Webhook
+	if rf, ok := ret.Get(0).(func(string) *webhook.Webhook); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDeliveries provides a mock function with given fields: id
+func (_m *MockGatewayer) GetWebhookDeliveries(id string) ([]webhook.Delivery, error) {
+	ret := _m.Called(id)
+
+	var r0 []webhook.Delivery
+	if rf, ok := ret.Get(0).(func(string) []webhook.Delivery); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields:
+func (_m *MockGatewayer) GetWebhooks() ([]webhook.Webhook, error) {
+	ret := _m.Called()
+
+	var r0 []webhook.Webhook
+	if rf, ok := ret.Get(0).(func() []webhook.Webhook); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HeadBkSeq provides a mock function with given fields:
 func (_m *MockGatewayer) HeadBkSeq() (uint64, bool, error) {
 	ret := _m.Called()
@@ -1303,6 +1816,52 @@ func (_m *MockGatewayer) ScanWalletAddresses(wltID string, password []byte, num
 	return r0, r1
 }
 
+// Search provides a mock function with given fields: query, limit
+func (_m *MockGatewayer) Search(query string, limit int) (*visor.SearchResult, error) {
+	ret := _m.Called(query, limit)
+
+	var r0 *visor.SearchResult
+	if rf, ok := ret.Get(0).(func(string, int) *visor.SearchResult); ok {
+		r0 = rf(query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*visor.SearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignMessage provides a mock function with given fields: wltID, password, addr, msg
+func (_m *MockGatewayer) SignMessage(wltID string, password []byte, addr cipher.Address, msg []byte) (cipher.Sig, error) {
+	ret := _m.Called(wltID, password, addr, msg)
+
+	var r0 cipher.Sig
+	if rf, ok := ret.Get(0).(func(string, []byte, cipher.Address, []byte) cipher.Sig); ok {
+		r0 = rf(wltID, password, addr, msg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cipher.Sig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, cipher.Address, []byte) error); ok {
+		r1 = rf(wltID, password, addr, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StartedAt provides a mock function with given fields:
 func (_m *MockGatewayer) StartedAt() time.Time {
 	ret := _m.Called()
@@ -1361,6 +1920,29 @@ func (_m *MockGatewayer) UpdateWalletLabel(wltID string, label string) error {
 	return r0
 }
 
+// UpdateWalletUxOutsMeta provides a mock function with given fields: wltID, hashes, f
+func (_m *MockGatewayer) UpdateWalletUxOutsMeta(wltID string, hashes []cipher.SHA256, f func(*wallet.UxOutMeta)) (wallet.UxOutsMeta, error) {
+	ret := _m.Called(wltID, hashes, f)
+
+	var r0 wallet.UxOutsMeta
+	if rf, ok := ret.Get(0).(func(string, []cipher.SHA256, func(*wallet.UxOutMeta)) wallet.UxOutsMeta); ok {
+		r0 = rf(wltID, hashes, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(wallet.UxOutsMeta)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []cipher.SHA256, func(*wallet.UxOutMeta)) error); ok {
+		r1 = rf(wltID, hashes, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyTxnVerbose provides a mock function with given fields: txn, signed
 func (_m *MockGatewayer) VerifyTxnVerbose(txn *coin.Transaction, signed visor.TxnSignedFlag) ([]visor.TransactionInput, bool, error) {
 	ret := _m.Called(txn, signed)
@@ -0,0 +1,477 @@
+package api
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	wh "github.com/skycoin/skycoin/src/util/http"
+)
+
+const (
+	// openAPIVersion is the version of the OpenAPI specification format
+	openAPIVersion = "3.0.2"
+)
+
+// openAPIOneOf is the response of a method which returns one of several schemas
+type openAPIOneOf []interface{}
+
+// openAPISchema is a JSON schema object of the OpenAPI specification
+type openAPISchema map[string]interface{}
+
+// openAPISpec is an OpenAPI 3 document
+type openAPISpec struct {
+	OpenAPI    string                            `json:"openapi"`
+	Info       openAPIInfo                       `json:"info"`
+	Paths      map[string]map[string]interface{} `json:"paths"`
+	Components openAPIComponents                 `json:"components"`
+	Security   []map[string][]string             `json:"security"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]openAPISchema `json:"schemas"`
+	SecuritySchemes map[string]openAPISchema `json:"securitySchemes"`
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+)
+
+// openAPISchemas builds the JSON schemas of Go types. Named struct types are added to the components
+// of the specification and referenced.
+type openAPISchemas struct {
+	components map[string]openAPISchema
+	names      map[reflect.Type]string
+}
+
+func newOpenAPISchemas() *openAPISchemas {
+	return &openAPISchemas{
+		components: make(map[string]openAPISchema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// schema returns the JSON schema of a value
+func (s *openAPISchemas) schema(v interface{}) openAPISchema {
+	if oneOf, ok := v.(openAPIOneOf); ok {
+		schemas := make([]openAPISchema, len(oneOf))
+		for i, x := range oneOf {
+			schemas[i] = s.schema(x)
+		}
+		return openAPISchema{
+			"oneOf": schemas,
+		}
+	}
+
+	return s.typeSchema(reflect.TypeOf(v))
+}
+
+func (s *openAPISchemas) typeSchema(t reflect.Type) openAPISchema {
+	// json.RawMessage can be any value
+	if t == rawMessageType {
+		return openAPISchema{}
+	}
+
+	// Types with custom JSON encodings, such as hashes, addresses and durations, are encoded as strings
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) || t == timeType {
+		return openAPISchema{
+			"type": "string",
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return s.typeSchema(t.Elem())
+	case reflect.Bool:
+		return openAPISchema{
+			"type": "boolean",
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openAPISchema{
+			"type": "integer",
+		}
+	case reflect.Float32, reflect.Float64:
+		return openAPISchema{
+			"type": "number",
+		}
+	case reflect.String:
+		return openAPISchema{
+			"type": "string",
+		}
+	case reflect.Slice, reflect.Array:
+		// []byte is encoded as a base64 string
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return openAPISchema{
+				"type":   "string",
+				"format": "byte",
+			}
+		}
+		return openAPISchema{
+			"type":  "array",
+			"items": s.typeSchema(t.Elem()),
+		}
+	case reflect.Map:
+		return openAPISchema{
+			"type":                 "object",
+			"additionalProperties": s.typeSchema(t.Elem()),
+		}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return s.ref(t)
+	default:
+		// interface{} can be any value
+		return openAPISchema{}
+	}
+}
+
+// ref adds a named struct type to the components and returns a reference to it
+func (s *openAPISchemas) ref(t reflect.Type) openAPISchema {
+	name, ok := s.names[t]
+	if !ok {
+		name = path.Base(t.PkgPath()) + "." + t.Name()
+		s.names[t] = name
+		// Register the name before building the schema, in case the type is recursive
+		s.components[name] = nil
+		s.components[name] = s.structSchema(t)
+	}
+
+	return openAPISchema{
+		"$ref": "#/components/schemas/" + name,
+	}
+}
+
+// structSchema returns the schema of a struct, following the encoding/json rules for field names and embedded structs
+func (s *openAPISchemas) structSchema(t reflect.Type) openAPISchema {
+	properties := make(map[string]openAPISchema)
+	s.addFields(t, properties)
+
+	return openAPISchema{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func (s *openAPISchemas) addFields(t reflect.Type, properties map[string]openAPISchema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			s.addFields(ft, properties)
+			continue
+		}
+
+		if f.PkgPath != "" {
+			// Unexported field
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		if strings.Contains(tag, ",string") {
+			properties[name] = openAPISchema{
+				"type": "string",
+			}
+			continue
+		}
+
+		properties[name] = s.typeSchema(f.Type)
+	}
+}
+
+// newOpenAPISpec generates the OpenAPI specification of API routes
+func newOpenAPISpec(version string, routes []apiRoute) openAPISpec {
+	schemas := newOpenAPISchemas()
+
+	errorSchema := schemas.schema(HTTPError{})
+
+	spec := openAPISpec{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:       "Skycoin node API",
+			Description: "API of the skycoin node. Endpoints belong to API sets, listed in x-api-sets, which are enabled with the -enable-api-sets option.",
+			Version:     version,
+		},
+		Paths: make(map[string]map[string]interface{}, len(routes)),
+		Security: []map[string][]string{
+			{},
+			{"basicAuth": {}},
+			{"bearerAuth": {}},
+		},
+	}
+
+	for _, rt := range routes {
+		ops := make(map[string]interface{}, len(rt.methods))
+		for _, m := range rt.methods {
+			ops[strings.ToLower(m.method)] = newOpenAPIOperation(schemas, errorSchema, rt, m)
+		}
+		spec.Paths[rt.endpoint] = ops
+	}
+
+	spec.Components = openAPIComponents{
+		Schemas: schemas.components,
+		SecuritySchemes: map[string]openAPISchema{
+			"basicAuth": {
+				"type":   "http",
+				"scheme": "basic",
+			},
+			"bearerAuth": {
+				"type":        "http",
+				"scheme":      "bearer",
+				"description": "API token",
+			},
+		},
+	}
+
+	return spec
+}
+
+// openAPIOperationID returns the operation ID of an endpoint method, e.g. "getApiV1WalletBalance"
+func openAPIOperationID(method, endpoint string) string {
+	words := strings.FieldsFunc(endpoint, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	id := strings.ToLower(method)
+	for _, w := range words {
+		id += strings.ToUpper(w[:1]) + w[1:]
+	}
+	return id
+}
+
+func newOpenAPIOperation(schemas *openAPISchemas, errorSchema openAPISchema, rt apiRoute, m apiMethod) map[string]interface{} {
+	op := map[string]interface{}{
+		"summary":     rt.summary,
+		"operationId": openAPIOperationID(m.method, rt.endpoint),
+	}
+
+	if m.description != "" {
+		op["description"] = m.description
+	}
+
+	apiSets := m.apiSets
+	if rt.anyAPISet {
+		apiSets = []string{}
+	}
+	op["x-api-sets"] = apiSets
+
+	if rt.apiVersion == apiVersion2 {
+		op["tags"] = []string{"v2"}
+	} else {
+		op["tags"] = []string{"v1"}
+	}
+
+	var params []map[string]interface{}
+
+	if m.method == http.MethodPost && !rt.skipCSRFCheck {
+		params = append(params, map[string]interface{}{
+			"name":        CSRFHeaderName,
+			"in":          "header",
+			"description": "CSRF token, required if CSRF is enabled",
+			"schema":      openAPISchema{"type": "string"},
+		})
+	}
+
+	switch {
+	case m.request != nil:
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				ContentTypeJSON: map[string]interface{}{
+					"schema": schemas.schema(m.request),
+				},
+			},
+		}
+
+	case m.method == http.MethodPost && len(m.params) != 0:
+		properties := make(map[string]openAPISchema, len(m.params))
+		var required []string
+		for _, p := range m.params {
+			properties[p.name] = openAPISchema{
+				"type":        p.typ,
+				"description": p.description,
+			}
+			if p.required {
+				required = append(required, p.name)
+			}
+		}
+
+		formSchema := openAPISchema{
+			"type":       "object",
+			"properties": properties,
+		}
+		if len(required) != 0 {
+			formSchema["required"] = required
+		}
+
+		op["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				ContentTypeForm: map[string]interface{}{
+					"schema": formSchema,
+				},
+			},
+		}
+
+	default:
+		for _, p := range m.params {
+			params = append(params, map[string]interface{}{
+				"name":        p.name,
+				"in":          "query",
+				"description": p.description,
+				"required":    p.required,
+				"schema":      openAPISchema{"type": p.typ},
+			})
+		}
+	}
+
+	if len(params) != 0 {
+		op["parameters"] = params
+	}
+
+	op["responses"] = map[string]interface{}{
+		"200":     newOpenAPIResponse(schemas, rt, m),
+		"default": newOpenAPIErrorResponse(rt, errorSchema),
+	}
+
+	return op
+}
+
+func newOpenAPIResponse(schemas *openAPISchemas, rt apiRoute, m apiMethod) map[string]interface{} {
+	resp := map[string]interface{}{
+		"description": "OK",
+	}
+
+	switch {
+	case m.contentType != "":
+		schema := openAPISchema{"type": "string"}
+		if m.response != nil {
+			schema = schemas.schema(m.response)
+		}
+
+		resp["content"] = map[string]interface{}{
+			m.contentType: map[string]interface{}{
+				"schema": schema,
+			},
+		}
+
+	case rt.apiVersion == apiVersion2:
+		properties := map[string]openAPISchema{}
+		if m.response != nil {
+			properties["data"] = schemas.schema(m.response)
+		}
+
+		resp["content"] = map[string]interface{}{
+			ContentTypeJSON: map[string]interface{}{
+				"schema": openAPISchema{
+					"type":       "object",
+					"properties": properties,
+				},
+			},
+		}
+
+	case m.response != nil:
+		resp["content"] = map[string]interface{}{
+			ContentTypeJSON: map[string]interface{}{
+				"schema": schemas.schema(m.response),
+			},
+		}
+	}
+
+	return resp
+}
+
+func newOpenAPIErrorResponse(rt apiRoute, errorSchema openAPISchema) map[string]interface{} {
+	if rt.apiVersion == apiVersion2 {
+		return map[string]interface{}{
+			"description": "Error",
+			"content": map[string]interface{}{
+				ContentTypeJSON: map[string]interface{}{
+					"schema": openAPISchema{
+						"type": "object",
+						"properties": map[string]openAPISchema{
+							"error": errorSchema,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"description": "Error, the body is the status text followed by the error message",
+		"content": map[string]interface{}{
+			"text/plain": map[string]interface{}{
+				"schema": openAPISchema{"type": "string"},
+			},
+		},
+	}
+}
+
+// openAPIEndpoints returns the endpoints and methods of a specification, sorted by endpoint
+func openAPIEndpoints(spec openAPISpec) map[string][]string {
+	endpoints := make(map[string][]string, len(spec.Paths))
+	for endpoint, ops := range spec.Paths {
+		methods := make([]string, 0, len(ops))
+		for m := range ops {
+			methods = append(methods, strings.ToUpper(m))
+		}
+		sort.Strings(methods)
+		endpoints[endpoint] = methods
+	}
+	return endpoints
+}
+
+// openAPIHandler returns the OpenAPI specification of the API
+// URI: /api/v2/openapi.json
+// Method: GET
+func openAPIHandler(c muxConfig) http.HandlerFunc {
+	var once sync.Once
+	var spec openAPISpec
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		// The routes are only used to describe the API, their handlers are never called
+		once.Do(func() {
+			spec = newOpenAPISpec(c.health.BuildInfo.Version, apiRoutes(c, nil))
+		})
+
+		wh.SendJSONOr500(logger, w, spec)
+	}
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/readable"
+)
+
+// TestOpenAPISpecEndpoints fails if an endpoint is missing from the specification or from endpointsMethods
+func TestOpenAPISpecEndpoints(t *testing.T) {
+	spec := newOpenAPISpec("0.0.0", apiRoutes(defaultMuxConfig(), nil))
+
+	expected := map[string][]string{
+		// The csrf endpoint is not in endpointsMethods since it does not check the CSRF token
+		"/api/v1/csrf": []string{http.MethodGet},
+	}
+	for e, methods := range endpointsMethods {
+		methods = append([]string{}, methods...)
+		sort.Strings(methods)
+		expected[e] = methods
+	}
+
+	require.Equal(t, expected, openAPIEndpoints(spec))
+
+	// All the endpoints of the specification are served
+	handler := newServerMux(defaultMuxConfig(), &MockGatewayer{})
+	for e := range spec.Paths {
+		req, err := http.NewRequest(http.MethodPut, e, nil)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", ContentTypeJSON)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusMethodNotAllowed, rr.Code, e)
+	}
+}
+
+func TestAPIRoutesWalletIDField(t *testing.T) {
+	// The wallet ID field of wallet methods must be the wallet ID parameter or request body field read by the handler,
+	// since API tokens restricted to specific wallets are checked against it
+	for _, rt := range apiRoutes(defaultMuxConfig(), nil) {
+		for _, mt := range rt.methods {
+			walletMethod := false
+			for _, k := range mt.apiSets {
+				if isWalletAPISet(k) {
+					walletMethod = true
+				}
+			}
+
+			// The id field of JSON-RPC requests is the request ID, and the JSON-RPC methods read no wallet ID
+			if !walletMethod || rt.endpoint == "/api/v2/jsonrpc" {
+				require.Empty(t, mt.walletIDField, "%s %s", mt.method, rt.endpoint)
+				continue
+			}
+
+			var fields []string
+			for _, p := range mt.params {
+				switch p.name {
+				case "id", "wallet_id":
+					fields = append(fields, p.name)
+				}
+			}
+
+			if mt.request != nil {
+				typ := reflect.TypeOf(mt.request)
+				for i := 0; i < typ.NumField(); i++ {
+					switch name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]; name {
+					case "id", "wallet_id":
+						fields = append(fields, name)
+					}
+				}
+			}
+
+			switch len(fields) {
+			case 0:
+				require.Empty(t, mt.walletIDField, "%s %s", mt.method, rt.endpoint)
+			case 1:
+				require.Equal(t, fields[0], mt.walletIDField, "%s %s", mt.method, rt.endpoint)
+			default:
+				t.Fatalf("%s %s has several wallet ID fields", mt.method, rt.endpoint)
+			}
+		}
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	cfg := defaultMuxConfig()
+	cfg.health.BuildInfo = readable.BuildInfo{
+		Version: "1.2.3",
+	}
+	handler := newServerMux(cfg, &MockGatewayer{})
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v2/openapi.json", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+
+	req, err = http.NewRequest(http.MethodGet, "/api/v2/openapi.json", nil)
+	require.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &spec))
+
+	require.Equal(t, openAPIVersion, spec.OpenAPI)
+	require.Equal(t, "1.2.3", spec.Info.Version)
+
+	var health struct {
+		APISets   []string `json:"x-api-sets"`
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema map[string]string `json:"schema"`
+			} `json:"content"`
+		} `json:"responses"`
+	}
+	require.NoError(t, json.Unmarshal(spec.Paths["/api/v1/health"]["get"], &health))
+	require.Equal(t, []string{EndpointsRead, EndpointsStatus}, health.APISets)
+	require.Equal(t, "#/components/schemas/api.HealthResponse", health.Responses["200"].Content[ContentTypeJSON].Schema["$ref"])
+
+	var healthSchema struct {
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(spec.Components.Schemas["api.HealthResponse"], &healthSchema))
+	require.Equal(t, "#/components/schemas/api.RateLimitStats", healthSchema.Properties["rate_limit"]["$ref"])
+	require.Equal(t, "string", healthSchema.Properties["uptime"]["type"])
+	require.Equal(t, "integer", healthSchema.Properties["open_connections"]["type"])
+
+	// All the references are defined
+	for _, ref := range strings.Split(rr.Body.String(), `"$ref": "#/components/schemas/`)[1:] {
+		name := ref[:strings.Index(ref, `"`)]
+		_, ok := spec.Components.Schemas[name]
+		require.True(t, ok, name)
+	}
+}
@@ -75,3 +75,69 @@ func outputsHandler(gateway Gatewayer) http.HandlerFunc {
 		wh.SendJSONOr500(logger, w, rSummary)
 	}
 }
+
+// OutputsPageResponse is returned by GET /api/v2/outputs
+type OutputsPageResponse struct {
+	CursorInfo readable.CursorInfo     `json:"cursor_info"`
+	Head       readable.BlockHeader    `json:"head"`
+	Outputs    readable.UnspentOutputs `json:"outputs"`
+}
+
+// outputsHandlerV2 returns a page of the confirmed unspent outputs of a set of addresses,
+// ordered by the block seq, transaction index and output index of their creation
+// URI: /api/v2/outputs
+// Method: GET
+// Args:
+//    addrs: comma-separated list of addresses [required]
+//    cursor: cursor returned with the previous page, empty for the first page
+//    limit: number of outputs per page [optional, default to 10, must be <= 100]
+//    sort: "asc" or "desc" [optional, default to "asc"]
+func outputsHandlerV2(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError405Response(w)
+			return
+		}
+
+		addrs, err := parseAddressesFromStr(r.FormValue("addrs"))
+		if err != nil {
+			writeError400Response(w, err.Error())
+			return
+		}
+
+		if len(addrs) == 0 {
+			writeError400Response(w, "addrs is required")
+			return
+		}
+
+		cursor, limit, order, err := parseCursorPageParams(r)
+		if err != nil {
+			writeError400Response(w, err.Error())
+			return
+		}
+
+		outputs, next, head, err := gateway.GetUnspentOutputsPage(addrs, cursor, order, limit)
+		if err != nil {
+			writeError500Response(w, err.Error())
+			return
+		}
+
+		// readable.NewUnspentOutputs sorts the outputs, so the page order is kept by converting them one by one
+		rOutputs := make(readable.UnspentOutputs, len(outputs))
+		for i, o := range outputs {
+			rOutputs[i], err = readable.NewUnspentOutput(o)
+			if err != nil {
+				writeError500Response(w, err.Error())
+				return
+			}
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: OutputsPageResponse{
+				CursorInfo: readable.NewCursorInfo(limit, next),
+				Head:       readable.NewBlockHeader(head.Head),
+				Outputs:    rOutputs,
+			},
+		})
+	}
+}
@@ -13,9 +13,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/readable"
 	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/historydb"
 )
 
 func TestGetOutputsHandler(t *testing.T) {
@@ -160,3 +162,163 @@ func TestGetOutputsHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOutputsHandlerV2(t *testing.T) {
+	addrs := []cipher.Address{makeAddress(), makeAddress()}
+
+	// Outputs are returned in the order of the page, oldest first
+	var outputs []visor.UnspentOutput
+	for i := 0; i < 3; i++ {
+		ux, _ := makeUxOutWithSecret(t)
+		ux.Head.BkSeq = uint64(i)
+		o, err := visor.NewUnspentOutput(ux, 1000)
+		require.NoError(t, err)
+		outputs = append(outputs, o)
+	}
+
+	rOutputs := make(readable.UnspentOutputs, len(outputs))
+	for i, o := range outputs {
+		var err error
+		rOutputs[i], err = readable.NewUnspentOutput(o)
+		require.NoError(t, err)
+	}
+
+	head := &coin.SignedBlock{
+		Block: coin.Block{
+			Head: coin.BlockHeader{
+				BkSeq: 10,
+				Time:  1000,
+			},
+		},
+	}
+
+	cursor := &visor.Cursor{
+		Position: historydb.Position{
+			BlockSeq: 2,
+			TxnIndex: 1,
+			OutIndex: 3,
+		},
+	}
+
+	tt := []struct {
+		name           string
+		method         string
+		args           url.Values
+		status         int
+		err            string
+		gatewayAddrs   []cipher.Address
+		gatewayCursor  *visor.Cursor
+		gatewayOrder   visor.SortOrder
+		gatewayLimit   uint64
+		gatewayOutputs []visor.UnspentOutput
+		gatewayNext    *visor.Cursor
+		gatewayErr     error
+		httpResponse   OutputsPageResponse
+	}{
+		{
+			name:   "405",
+			method: http.MethodPost,
+			status: http.StatusMethodNotAllowed,
+			err:    "Method Not Allowed",
+		},
+		{
+			name:   "400 - addrs is required",
+			method: http.MethodGet,
+			status: http.StatusBadRequest,
+			err:    "addrs is required",
+		},
+		{
+			name:   "400 - invalid address",
+			method: http.MethodGet,
+			args:   url.Values{"addrs": {"abcd"}},
+			status: http.StatusBadRequest,
+			err:    `address "abcd" is invalid: Invalid address length`,
+		},
+		{
+			name:   "400 - invalid limit",
+			method: http.MethodGet,
+			args:   url.Values{"addrs": {addrs[0].String()}, "limit": {"x"}},
+			status: http.StatusBadRequest,
+			err:    `invalid 'limit' value: strconv.ParseUint: parsing "x": invalid syntax`,
+		},
+		{
+			name:         "500 - gateway error",
+			method:       http.MethodGet,
+			args:         url.Values{"addrs": {addrs[0].String()}},
+			status:       http.StatusInternalServerError,
+			err:          "gateway error",
+			gatewayAddrs: addrs[:1],
+			gatewayOrder: visor.AscOrder,
+			gatewayLimit: visor.DefaultTxnPageSize,
+			gatewayErr:   errors.New("gateway error"),
+		},
+		{
+			name:           "200 - last page",
+			method:         http.MethodGet,
+			args:           url.Values{"addrs": {addrs[0].String()}},
+			status:         http.StatusOK,
+			gatewayAddrs:   addrs[:1],
+			gatewayOrder:   visor.AscOrder,
+			gatewayLimit:   visor.DefaultTxnPageSize,
+			gatewayOutputs: outputs,
+			httpResponse: OutputsPageResponse{
+				CursorInfo: readable.CursorInfo{Limit: 10},
+				Head:       readable.NewBlockHeader(head.Head),
+				Outputs:    rOutputs,
+			},
+		},
+		{
+			name:           "200 - next page",
+			method:         http.MethodGet,
+			args:           url.Values{"addrs": {addrs[0].String() + "," + addrs[1].String()}, "cursor": {cursor.String()}, "limit": {"3"}, "sort": {"desc"}},
+			status:         http.StatusOK,
+			gatewayAddrs:   addrs,
+			gatewayCursor:  cursor,
+			gatewayOrder:   visor.DescOrder,
+			gatewayLimit:   3,
+			gatewayOutputs: outputs,
+			gatewayNext:    cursor,
+			httpResponse: OutputsPageResponse{
+				CursorInfo: readable.CursorInfo{Limit: 3, NextCursor: cursor.String()},
+				Head:       readable.NewBlockHeader(head.Head),
+				Outputs:    rOutputs,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetUnspentOutputsPage", tc.gatewayAddrs, tc.gatewayCursor, tc.gatewayOrder, tc.gatewayLimit).Return(tc.gatewayOutputs, tc.gatewayNext, head, tc.gatewayErr)
+
+			endpoint := "/api/v2/outputs"
+			if len(tc.args) > 0 {
+				endpoint += "?" + tc.args.Encode()
+			}
+
+			req, err := http.NewRequest(tc.method, endpoint, nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var rsp ReceivedHTTPResponse
+			err = json.NewDecoder(rr.Body).Decode(&rsp)
+			require.NoError(t, err)
+
+			if rr.Code != http.StatusOK {
+				require.Equal(t, tc.err, rsp.Error.Message)
+				return
+			}
+
+			var page OutputsPageResponse
+			err = json.Unmarshal(rsp.Data, &page)
+			require.NoError(t, err)
+			require.Equal(t, tc.httpResponse, page)
+		})
+	}
+}
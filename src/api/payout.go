@@ -0,0 +1,308 @@
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/skycoin/skycoin/src/daemon"
+	"github.com/skycoin/skycoin/src/payout"
+	"github.com/skycoin/skycoin/src/transaction"
+	"github.com/skycoin/skycoin/src/util/amount"
+	"github.com/skycoin/skycoin/src/util/fee"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/blockdb"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+// payoutRequest is the request data for POST /api/v2/payout
+type payoutRequest struct {
+	IdempotencyKey string         `json:"idempotency_key"`
+	WalletID       string         `json:"wallet_id"`
+	Password       string         `json:"password"`
+	HoursSelection hoursSelection `json:"hours_selection"`
+	To             []receiver     `json:"to"`
+}
+
+// Validate validates payoutRequest data
+func (r payoutRequest) Validate() error {
+	if r.IdempotencyKey == "" {
+		return errors.New("missing idempotency_key")
+	}
+
+	if len(r.IdempotencyKey) > payout.MaxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency_key must be at most %d bytes", payout.MaxIdempotencyKeyLength)
+	}
+
+	if r.WalletID == "" {
+		return errors.New("missing wallet_id")
+	}
+
+	return r.createTransactionRequest().Validate()
+}
+
+// createTransactionRequest returns the createTransactionRequest used to validate and convert the recipients
+func (r payoutRequest) createTransactionRequest() createTransactionRequest {
+	return createTransactionRequest{
+		HoursSelection: r.HoursSelection,
+		To:             r.To,
+	}
+}
+
+// JobRequest converts payoutRequest to payout.JobRequest
+func (r payoutRequest) JobRequest() payout.JobRequest {
+	p := r.createTransactionRequest().TransactionParams()
+	return payout.JobRequest{
+		IdempotencyKey: r.IdempotencyKey,
+		WalletID:       r.WalletID,
+		HoursSelection: p.HoursSelection,
+		To:             p.To,
+	}
+}
+
+// PayoutJob is a payout job
+type PayoutJob struct {
+	IdempotencyKey string              `json:"idempotency_key"`
+	WalletID       string              `json:"wallet_id"`
+	Status         string              `json:"status"`
+	To             []Receiver          `json:"to"`
+	Transactions   []PayoutTransaction `json:"transactions"`
+	LastError      string              `json:"last_error,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// PayoutTransaction is a transaction created for a payout job
+type PayoutTransaction struct {
+	TxID               string `json:"txid"`
+	EncodedTransaction string `json:"encoded_transaction"`
+	Status             string `json:"status"`
+	// Recipients are the indexes of the job recipients that the transaction pays
+	Recipients    []int  `json:"recipients"`
+	BlockSeq      uint64 `json:"block_seq"`
+	Confirmations uint64 `json:"confirmations"`
+	Error         string `json:"error,omitempty"`
+}
+
+// NewPayoutJob creates a PayoutJob from a payout.Job
+func NewPayoutJob(j payout.Job) (*PayoutJob, error) {
+	to := make([]Receiver, len(j.To))
+	for i, o := range j.To {
+		coins, err := amount.Amount(o.Coins).ToString()
+		if err != nil {
+			return nil, err
+		}
+
+		to[i] = Receiver{
+			Address: o.Address.String(),
+			Coins:   coins,
+			Hours:   strconv.FormatUint(o.Hours, 10),
+		}
+	}
+
+	txns := make([]PayoutTransaction, len(j.Transactions))
+	for i, t := range j.Transactions {
+		b, err := t.Transaction.Serialize()
+		if err != nil {
+			return nil, err
+		}
+
+		txns[i] = PayoutTransaction{
+			TxID:               t.TxID().Hex(),
+			EncodedTransaction: hex.EncodeToString(b),
+			Status:             string(t.Status),
+			Recipients:         t.Recipients,
+			BlockSeq:           t.BlockSeq,
+			Confirmations:      t.Confirmations,
+			Error:              t.Error,
+		}
+	}
+
+	return &PayoutJob{
+		IdempotencyKey: j.IdempotencyKey,
+		WalletID:       j.WalletID,
+		Status:         string(j.Status),
+		To:             to,
+		Transactions:   txns,
+		LastError:      j.LastError,
+		CreatedAt:      j.CreatedAt,
+		UpdatedAt:      j.UpdatedAt,
+	}, nil
+}
+
+// Dispatches /payout endpoint.
+// Method: GET, POST
+// URI: /api/v2/payout
+func payoutHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getPayoutHandler(w, r, gateway)
+		case http.MethodPost:
+			createPayoutHandler(w, r, gateway)
+		default:
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+		}
+	}
+}
+
+// Creates a payout job, or resumes the job with the same idempotency key.
+// If the job cannot be completed, the job is returned along with the error.
+// Args: JSON body
+func createPayoutHandler(w http.ResponseWriter, r *http.Request, gateway Gatewayer) {
+	var req payoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	j, err := gateway.CreatePayoutJob(req.JobRequest(), []byte(req.Password))
+
+	var resp HTTPResponse
+	switch err {
+	case nil, payout.ErrJobFailed:
+		// A failed job is not an error of the request, the status of the job reports it
+	default:
+		resp = payoutErrorResponse(err)
+	}
+
+	if j != nil {
+		rj, err := NewPayoutJob(*j)
+		if err != nil {
+			resp = NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+			writeHTTPResponse(w, resp)
+			return
+		}
+		resp.Data = rj
+	}
+
+	writeHTTPResponse(w, resp)
+}
+
+// Returns a payout job
+// Args:
+//
+//	idempotency_key: the idempotency key of the job [required]
+//	wallet_id: only return the job if it spends from this wallet [optional]
+func getPayoutHandler(w http.ResponseWriter, r *http.Request, gateway Gatewayer) {
+	key := r.FormValue("idempotency_key")
+	if key == "" {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, "idempotency_key is required")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	j, err := gateway.GetPayoutJob(key)
+	if err != nil {
+		writeHTTPResponse(w, payoutErrorResponse(err))
+		return
+	}
+
+	if walletID := r.FormValue("wallet_id"); walletID != "" && walletID != j.WalletID {
+		writeHTTPResponse(w, payoutErrorResponse(payout.ErrJobNotFound))
+		return
+	}
+
+	rj, err := NewPayoutJob(*j)
+	if err != nil {
+		resp := NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	writeHTTPResponse(w, HTTPResponse{
+		Data: rj,
+	})
+}
+
+// Returns all payout jobs, oldest first
+// Method: GET
+// URI: /api/v2/payouts
+// Args:
+//
+//	wallet_id: only return the jobs that spend from this wallet [optional]
+func payoutsHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		jobs, err := gateway.GetPayoutJobs()
+		if err != nil {
+			writeHTTPResponse(w, payoutErrorResponse(err))
+			return
+		}
+
+		walletID := r.FormValue("wallet_id")
+
+		rjobs := make([]PayoutJob, 0, len(jobs))
+		for _, j := range jobs {
+			if walletID != "" && walletID != j.WalletID {
+				continue
+			}
+
+			rj, err := NewPayoutJob(j)
+			if err != nil {
+				resp := NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+				writeHTTPResponse(w, resp)
+				return
+			}
+			rjobs = append(rjobs, *rj)
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: rjobs,
+		})
+	}
+}
+
+func payoutErrorResponse(err error) HTTPResponse {
+	switch err.(type) {
+	case payout.Error:
+		switch err {
+		case payout.ErrJobNotFound:
+			return NewHTTPErrorResponse(http.StatusNotFound, "")
+		case payout.ErrIdempotencyKeyConflict:
+			return NewHTTPErrorResponse(http.StatusConflict, err.Error())
+		default:
+			return NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		}
+	case wallet.Error:
+		switch err {
+		case wallet.ErrWalletNotExist:
+			return NewHTTPErrorResponse(http.StatusNotFound, err.Error())
+		case wallet.ErrWalletAPIDisabled:
+			return NewHTTPErrorResponse(http.StatusForbidden, "")
+		default:
+			return NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		}
+	case blockdb.ErrUnspentNotExist,
+		transaction.Error,
+		visor.UserError:
+		return NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+	default:
+		switch {
+		case err == fee.ErrTxnNoFee,
+			err == fee.ErrTxnInsufficientCoinHours:
+			return NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		case daemon.IsBroadcastFailure(err):
+			return NewHTTPErrorResponse(http.StatusServiceUnavailable, err.Error())
+		default:
+			return NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+		}
+	}
+}
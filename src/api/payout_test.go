@@ -0,0 +1,472 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/daemon"
+	"github.com/skycoin/skycoin/src/payout"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/transaction"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+func makePayoutJob(t *testing.T, status payout.JobStatus) payout.Job {
+	to := []coin.TransactionOutput{
+		{
+			Address: testutil.MakeAddress(),
+			Coins:   1e6,
+			Hours:   10,
+		},
+		{
+			Address: testutil.MakeAddress(),
+			Coins:   2e6,
+			Hours:   20,
+		},
+	}
+
+	txn := coin.Transaction{}
+	err := txn.PushInput(testutil.RandSHA256(t))
+	require.NoError(t, err)
+	for _, o := range to {
+		err := txn.PushOutput(o.Address, o.Coins, o.Hours)
+		require.NoError(t, err)
+	}
+	err = txn.UpdateHeader()
+	require.NoError(t, err)
+
+	txnStatus := payout.TxnStatusBroadcast
+	if status == payout.JobStatusFailed {
+		txnStatus = payout.TxnStatusFailed
+	}
+
+	now := time.Now().UTC()
+	return payout.Job{
+		IdempotencyKey: "foo",
+		WalletID:       "foo.wlt",
+		HoursSelection: transaction.HoursSelection{
+			Type: transaction.HoursSelectionTypeManual,
+		},
+		To:     to,
+		Status: status,
+		Transactions: []payout.JobTransaction{
+			{
+				Transaction: txn,
+				Recipients:  []int{0, 1},
+				Status:      txnStatus,
+			},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func makePayoutRequest(t *testing.T, j payout.Job) PayoutRequest {
+	rj, err := NewPayoutJob(j)
+	require.NoError(t, err)
+
+	return PayoutRequest{
+		IdempotencyKey: j.IdempotencyKey,
+		WalletID:       j.WalletID,
+		Password:       "pwd",
+		HoursSelection: HoursSelection{
+			Type: j.HoursSelection.Type,
+		},
+		To: rj.To,
+	}
+}
+
+// requirePayoutJobEqual compares PayoutJobs, ignoring the time zone of their timestamps
+func requirePayoutJobEqual(t *testing.T, expected, actual PayoutJob) {
+	require.True(t, expected.CreatedAt.Equal(actual.CreatedAt))
+	require.True(t, expected.UpdatedAt.Equal(actual.UpdatedAt))
+	expected.CreatedAt = actual.CreatedAt
+	expected.UpdatedAt = actual.UpdatedAt
+	require.Equal(t, expected, actual)
+}
+
+func TestPayout(t *testing.T) {
+	job := makePayoutJob(t, payout.JobStatusBroadcast)
+	failedJob := makePayoutJob(t, payout.JobStatusFailed)
+
+	rspJob, err := NewPayoutJob(job)
+	require.NoError(t, err)
+	rspFailedJob, err := NewPayoutJob(failedJob)
+	require.NoError(t, err)
+
+	pendingJob := job
+	pendingJob.Status = payout.JobStatusPending
+	pendingJob.Transactions = nil
+	pendingJob.LastError = daemon.ErrNetworkingDisabled.Error()
+	rspPendingJob, err := NewPayoutJob(pendingJob)
+	require.NoError(t, err)
+
+	validReq := makePayoutRequest(t, job)
+
+	noKeyReq := validReq
+	noKeyReq.IdempotencyKey = ""
+
+	longKeyReq := validReq
+	longKeyReq.IdempotencyKey = strings.Repeat("a", payout.MaxIdempotencyKeyLength+1)
+
+	noWalletReq := validReq
+	noWalletReq.WalletID = ""
+
+	noToReq := validReq
+	noToReq.To = nil
+
+	autoHoursReq := validReq
+	autoHoursReq.HoursSelection.Type = transaction.HoursSelectionTypeAuto
+	autoHoursReq.HoursSelection.Mode = transaction.HoursSelectionModeShare
+
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		query        string
+		walletID     string
+		req          *PayoutRequest
+		httpBody     string
+		job          *payout.Job
+		gatewayErr   error
+		httpResponse HTTPResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodDelete,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "get idempotency_key missing",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "idempotency_key is required"),
+		},
+		{
+			name:         "get job not found",
+			method:       http.MethodGet,
+			status:       http.StatusNotFound,
+			query:        "bar",
+			gatewayErr:   payout.ErrJobNotFound,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, ""),
+		},
+		{
+			name:   "get ok",
+			method: http.MethodGet,
+			status: http.StatusOK,
+			query:  "foo",
+			job:    &job,
+			httpResponse: HTTPResponse{
+				Data: *rspJob,
+			},
+		},
+		{
+			name:         "get job of other wallet",
+			method:       http.MethodGet,
+			status:       http.StatusNotFound,
+			query:        "foo",
+			walletID:     "bar.wlt",
+			job:          &job,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, ""),
+		},
+		{
+			name:     "get ok with wallet_id",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			query:    "foo",
+			walletID: "foo.wlt",
+			job:      &job,
+			httpResponse: HTTPResponse{
+				Data: *rspJob,
+			},
+		},
+		{
+			name:         "post empty json body",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			httpBody:     "",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "EOF"),
+		},
+		{
+			name:         "post idempotency_key missing",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &noKeyReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "missing idempotency_key"),
+		},
+		{
+			name:         "post idempotency_key too long",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &longKeyReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "idempotency_key must be at most 128 bytes"),
+		},
+		{
+			name:         "post wallet_id missing",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &noWalletReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "missing wallet_id"),
+		},
+		{
+			name:         "post to missing",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &noToReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "to is empty"),
+		},
+		{
+			name:         "post hours with auto hours selection",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &autoHoursReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "to[0].hours must not be specified for auto hours_selection.mode"),
+		},
+		{
+			name:         "post idempotency key conflict",
+			method:       http.MethodPost,
+			status:       http.StatusConflict,
+			req:          &validReq,
+			gatewayErr:   payout.ErrIdempotencyKeyConflict,
+			httpResponse: NewHTTPErrorResponse(http.StatusConflict, payout.ErrIdempotencyKeyConflict.Error()),
+		},
+		{
+			name:         "post wallet does not exist",
+			method:       http.MethodPost,
+			status:       http.StatusNotFound,
+			req:          &validReq,
+			gatewayErr:   wallet.ErrWalletNotExist,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, wallet.ErrWalletNotExist.Error()),
+		},
+		{
+			name:         "post wallet api disabled",
+			method:       http.MethodPost,
+			status:       http.StatusForbidden,
+			req:          &validReq,
+			gatewayErr:   wallet.ErrWalletAPIDisabled,
+			httpResponse: NewHTTPErrorResponse(http.StatusForbidden, ""),
+		},
+		{
+			name:         "post insufficient balance",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &validReq,
+			gatewayErr:   transaction.ErrInsufficientBalance,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, transaction.ErrInsufficientBalance.Error()),
+		},
+		{
+			name:       "post broadcast failure returns job",
+			method:     http.MethodPost,
+			status:     http.StatusServiceUnavailable,
+			req:        &validReq,
+			job:        &pendingJob,
+			gatewayErr: daemon.ErrNetworkingDisabled,
+			httpResponse: HTTPResponse{
+				Error: &HTTPError{
+					Code:    http.StatusServiceUnavailable,
+					Message: daemon.ErrNetworkingDisabled.Error(),
+				},
+				Data: *rspPendingJob,
+			},
+		},
+		{
+			name:         "post other error",
+			method:       http.MethodPost,
+			status:       http.StatusInternalServerError,
+			req:          &validReq,
+			gatewayErr:   errors.New("db error"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "db error"),
+		},
+		{
+			name:       "post failed job",
+			method:     http.MethodPost,
+			status:     http.StatusOK,
+			req:        &validReq,
+			job:        &failedJob,
+			gatewayErr: payout.ErrJobFailed,
+			httpResponse: HTTPResponse{
+				Data: *rspFailedJob,
+			},
+		},
+		{
+			name:   "post ok",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			req:    &validReq,
+			job:    &job,
+			httpResponse: HTTPResponse{
+				Data: *rspJob,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetPayoutJob", tc.query).Return(tc.job, tc.gatewayErr)
+			gateway.On("CreatePayoutJob", mock.Anything, []byte("pwd")).Return(
+				func(r payout.JobRequest, _ []byte) *payout.Job {
+					require.Equal(t, job.IdempotencyKey, r.IdempotencyKey)
+					require.Equal(t, job.WalletID, r.WalletID)
+					require.Equal(t, job.HoursSelection, r.HoursSelection)
+					require.Equal(t, job.To, r.To)
+					return tc.job
+				}, tc.gatewayErr)
+
+			if tc.httpBody == "" && tc.req != nil {
+				tc.httpBody = toJSON(t, tc.req)
+			}
+
+			endpoint := "/api/v2/payout"
+			if tc.query != "" {
+				endpoint += "?idempotency_key=" + tc.query
+			}
+			if tc.walletID != "" {
+				endpoint += "&wallet_id=" + tc.walletID
+			}
+			req, err := http.NewRequest(tc.method, endpoint, strings.NewReader(tc.httpBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+			} else {
+				require.NotNil(t, tc.httpResponse.Data)
+
+				var jobRsp PayoutJob
+				err := json.Unmarshal(rsp.Data, &jobRsp)
+				require.NoError(t, err)
+
+				requirePayoutJobEqual(t, tc.httpResponse.Data.(PayoutJob), jobRsp)
+			}
+		})
+	}
+}
+
+func TestPayouts(t *testing.T) {
+	job := makePayoutJob(t, payout.JobStatusBroadcast)
+	rspJob, err := NewPayoutJob(job)
+	require.NoError(t, err)
+
+	otherJob := makePayoutJob(t, payout.JobStatusBroadcast)
+	otherJob.IdempotencyKey = "bar"
+	otherJob.WalletID = "bar.wlt"
+
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		walletID     string
+		jobs         []payout.Job
+		gatewayErr   error
+		httpResponse HTTPResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodPost,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "gateway error",
+			method:       http.MethodGet,
+			status:       http.StatusInternalServerError,
+			gatewayErr:   errors.New("db error"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "db error"),
+		},
+		{
+			name:   "no jobs",
+			method: http.MethodGet,
+			status: http.StatusOK,
+			httpResponse: HTTPResponse{
+				Data: []PayoutJob{},
+			},
+		},
+		{
+			name:   "ok",
+			method: http.MethodGet,
+			status: http.StatusOK,
+			jobs:   []payout.Job{job},
+			httpResponse: HTTPResponse{
+				Data: []PayoutJob{*rspJob},
+			},
+		},
+		{
+			name:     "ok with wallet_id",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			walletID: "foo.wlt",
+			jobs:     []payout.Job{job, otherJob},
+			httpResponse: HTTPResponse{
+				Data: []PayoutJob{*rspJob},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetPayoutJobs").Return(tc.jobs, tc.gatewayErr)
+
+			endpoint := "/api/v2/payouts"
+			if tc.walletID != "" {
+				endpoint += "?wallet_id=" + tc.walletID
+			}
+			req, err := http.NewRequest(tc.method, endpoint, nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+			} else {
+				require.NotNil(t, tc.httpResponse.Data)
+
+				var jobsRsp []PayoutJob
+				err := json.Unmarshal(rsp.Data, &jobsRsp)
+				require.NoError(t, err)
+
+				expected := tc.httpResponse.Data.([]PayoutJob)
+				require.Len(t, jobsRsp, len(expected))
+				for i := range expected {
+					requirePayoutJobEqual(t, expected[i], jobsRsp[i])
+				}
+			}
+		})
+	}
+}
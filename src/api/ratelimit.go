@@ -0,0 +1,249 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/skycoin/skycoin/src/apitoken"
+)
+
+const (
+	// rateLimitSweepInterval is how often idle clients are removed from the rate limiter
+	rateLimitSweepInterval = time.Minute
+	// rateLimitAuthFailureCost is the cost charged to the IP address of a client for a failed API token authentication
+	rateLimitAuthFailureCost = 1
+)
+
+// RateLimitConfig configures the per-client rate limiting of API requests.
+// Clients are identified by their API token if the request is authenticated with one, otherwise by their IP address.
+// Each request has a cost, clients can spend Rate cost units per second, up to Burst cost units at once.
+type RateLimitConfig struct {
+	// Rate is the number of cost units a client can spend per second. If 0, rate limiting is disabled
+	Rate float64
+	// Burst is the maximum number of cost units a client can spend at once
+	Burst int
+	// EndpointCosts are the costs of endpoints, keyed by path, e.g. "/api/v1/richlist".
+	// A key with a "?verbose=1" suffix is the cost of the verbose requests of the endpoint.
+	EndpointCosts map[string]int
+	// APISetCosts are the costs of the endpoints of an API set, for endpoints without an endpoint cost.
+	// If an endpoint belongs to several API sets, the highest cost applies. The default cost is 1.
+	APISetCosts map[string]int
+}
+
+// RateLimitStats are the rate limiter statistics reported by /health
+type RateLimitStats struct {
+	Enabled bool    `json:"enabled"`
+	Rate    float64 `json:"rate"`
+	Burst   int     `json:"burst"`
+	// Clients is the number of clients currently tracked by the rate limiter
+	Clients int `json:"clients"`
+	// AllowedRequests is the number of requests allowed since the node started
+	AllowedRequests uint64 `json:"allowed_requests"`
+	// LimitedRequests is the number of requests rejected with 429 Too Many Requests since the node started
+	LimitedRequests uint64 `json:"limited_requests"`
+}
+
+// rateLimitBucket is the token bucket of a client
+type rateLimitBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter limits the rate of API requests per client, with token buckets
+type RateLimiter struct {
+	sync.Mutex
+	config    RateLimitConfig
+	buckets   map[string]*rateLimitBucket
+	lastSweep time.Time
+	allowed   uint64
+	limited   uint64
+	now       func() time.Time
+}
+
+// NewRateLimiter creates a RateLimiter. Returns nil if rate limiting is disabled
+func NewRateLimiter(c RateLimitConfig) *RateLimiter {
+	if c.Rate <= 0 {
+		return nil
+	}
+
+	if c.Burst < 1 {
+		c.Burst = 1
+	}
+
+	return &RateLimiter{
+		config:  c,
+		buckets: make(map[string]*rateLimitBucket),
+		now:     time.Now,
+	}
+}
+
+// cost returns the cost of a request to an endpoint with the given API sets.
+// The verbose flag is parsed like the handlers parse it. An invalid value is rejected by the handler,
+// and is charged the non-verbose cost.
+func (l *RateLimiter) cost(endpoint string, r *http.Request, apiSets []string) int {
+	verbose, _ := parseBoolFlag(r.FormValue("verbose"))
+	return l.endpointCost(endpoint, verbose, apiSets)
+}
+
+// endpointCost returns the cost of a request to an endpoint with the given API sets
+func (l *RateLimiter) endpointCost(endpoint string, verbose bool, apiSets []string) int {
+	if verbose {
+		if c, ok := l.config.EndpointCosts[endpoint+"?verbose=1"]; ok {
+			return c
+		}
+	}
+
+	if c, ok := l.config.EndpointCosts[endpoint]; ok {
+		return c
+	}
+
+	cost := 0
+	for _, k := range apiSets {
+		if c, ok := l.config.APISetCosts[k]; ok && c > cost {
+			cost = c
+		}
+	}
+
+	if cost == 0 {
+		cost = 1
+	}
+
+	return cost
+}
+
+// take spends cost units of the client's bucket. If the bucket does not have enough units,
+// returns false and the time to wait until it does.
+func (l *RateLimiter) take(key string, cost int) (bool, time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	// A request costing more than the burst could never be allowed
+	if cost > l.config.Burst {
+		cost = l.config.Burst
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &rateLimitBucket{
+			tokens: float64(l.config.Burst),
+			last:   now,
+		}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(l.config.Burst), b.tokens+now.Sub(b.last).Seconds()*l.config.Rate)
+	b.last = now
+
+	if b.tokens < float64(cost) {
+		l.limited++
+		wait := (float64(cost) - b.tokens) / l.config.Rate
+		return false, time.Duration(wait * float64(time.Second))
+	}
+
+	b.tokens -= float64(cost)
+	l.allowed++
+	return true, 0
+}
+
+// takeRPC spends the cost of a JSON-RPC method call, which is the cost of its equivalent REST endpoint,
+// from the client's bucket. Returns true if rate limiting is disabled.
+func (l *RateLimiter) takeRPC(key string, m rpcMethod, params json.RawMessage) bool {
+	if l == nil {
+		return true
+	}
+
+	// Invalid params are rejected by the method, the call is charged the non-verbose cost
+	var p struct {
+		Verbose bool `json:"verbose"`
+	}
+	json.Unmarshal(params, &p) //nolint:errcheck
+
+	ok, _ := l.take(key, l.endpointCost(m.endpoint, p.Verbose, m.apiSets))
+	return ok
+}
+
+// sweep removes the buckets of clients that have been idle long enough for their bucket to be full again
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	refill := time.Duration(float64(l.config.Burst) / l.config.Rate * float64(time.Second))
+	for k, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// stats returns the rate limiter statistics
+func (l *RateLimiter) stats() RateLimitStats {
+	if l == nil {
+		return RateLimitStats{}
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	return RateLimitStats{
+		Enabled:         true,
+		Rate:            l.config.Rate,
+		Burst:           l.config.Burst,
+		Clients:         len(l.buckets),
+		AllowedRequests: l.allowed,
+		LimitedRequests: l.limited,
+	}
+}
+
+// rateLimitKey returns the key identifying the client of a request
+func rateLimitKey(r *http.Request) string {
+	return rateLimitClientKey(apiTokenFromContext(r.Context()), r.RemoteAddr)
+}
+
+// rateLimitClientKey returns the key identifying a client by its API token, if it has one, otherwise by the IP address of addr
+func rateLimitClientKey(t *apitoken.Token, addr string) string {
+	if t != nil {
+		return "token:" + t.Name
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	return "ip:" + host
+}
+
+// rateLimit rejects requests with 429 Too Many Requests if the client exceeded its rate limit.
+// The Retry-After header is set to the number of seconds to wait before retrying.
+func rateLimit(apiVersion, endpoint string, l *RateLimiter, methodsAPISets map[string][]string, f http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cost := l.cost(endpoint, r, methodsAPISets[r.Method])
+
+		if ok, wait := l.take(rateLimitKey(r), cost); !ok {
+			writeTooManyRequests(w, apiVersion, wait)
+			return
+		}
+
+		f.ServeHTTP(w, r)
+	})
+}
+
+// writeTooManyRequests writes a 429 Too Many Requests response, with a Retry-After header
+// set to the number of seconds to wait before retrying
+func writeTooManyRequests(w http.ResponseWriter, apiVersion string, wait time.Duration) {
+	retryAfter := int64(math.Ceil(wait.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	writeError(w, apiVersion, http.StatusTooManyRequests, "")
+}
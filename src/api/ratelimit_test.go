@@ -0,0 +1,280 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/apitoken"
+)
+
+func TestRateLimiterCost(t *testing.T) {
+	l := NewRateLimiter(RateLimitConfig{
+		Rate:  1,
+		Burst: 10,
+		EndpointCosts: map[string]int{
+			"/api/v1/richlist":               20,
+			"/api/v1/transactions":           5,
+			"/api/v1/transactions?verbose=1": 15,
+		},
+		APISetCosts: map[string]int{
+			EndpointsRead:   2,
+			EndpointsStatus: 3,
+		},
+	})
+
+	cases := []struct {
+		name     string
+		endpoint string
+		query    string
+		apiSets  []string
+		cost     int
+	}{
+		{
+			name:     "endpoint cost",
+			endpoint: "/api/v1/richlist",
+			apiSets:  []string{EndpointsRead},
+			cost:     20,
+		},
+		{
+			name:     "endpoint cost not verbose",
+			endpoint: "/api/v1/transactions",
+			query:    "verbose=0",
+			apiSets:  []string{EndpointsRead},
+			cost:     5,
+		},
+		{
+			name:     "endpoint cost verbose",
+			endpoint: "/api/v1/transactions",
+			query:    "verbose=1",
+			apiSets:  []string{EndpointsRead},
+			cost:     15,
+		},
+		{
+			name:     "endpoint cost verbose true",
+			endpoint: "/api/v1/transactions",
+			query:    "verbose=true",
+			apiSets:  []string{EndpointsRead},
+			cost:     15,
+		},
+		{
+			name:     "endpoint cost verbose uppercase",
+			endpoint: "/api/v1/transactions",
+			query:    "verbose=TRUE",
+			apiSets:  []string{EndpointsRead},
+			cost:     15,
+		},
+		{
+			name:     "endpoint cost verbose t",
+			endpoint: "/api/v1/transactions",
+			query:    "verbose=t",
+			apiSets:  []string{EndpointsRead},
+			cost:     15,
+		},
+		{
+			name:     "endpoint cost verbose invalid",
+			endpoint: "/api/v1/transactions",
+			query:    "verbose=foo",
+			apiSets:  []string{EndpointsRead},
+			cost:     5,
+		},
+		{
+			name:     "endpoint cost verbose fallback",
+			endpoint: "/api/v1/richlist",
+			query:    "verbose=1",
+			apiSets:  []string{EndpointsRead},
+			cost:     20,
+		},
+		{
+			name:     "highest api set cost",
+			endpoint: "/api/v1/health",
+			apiSets:  []string{EndpointsRead, EndpointsStatus},
+			cost:     3,
+		},
+		{
+			name:     "default cost",
+			endpoint: "/api/v1/wallet",
+			apiSets:  []string{EndpointsWallet},
+			cost:     1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodGet, tc.endpoint+"?"+tc.query, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.cost, l.cost(tc.endpoint, r, tc.apiSets))
+		})
+	}
+}
+
+func TestRateLimiterTake(t *testing.T) {
+	require.Nil(t, NewRateLimiter(RateLimitConfig{}))
+	require.Equal(t, RateLimitStats{}, (*RateLimiter)(nil).stats())
+
+	l := NewRateLimiter(RateLimitConfig{
+		Rate:  2,
+		Burst: 10,
+	})
+
+	now := time.Now()
+	l.now = func() time.Time {
+		return now
+	}
+
+	ok, _ := l.take("a", 8)
+	require.True(t, ok)
+
+	// Not enough units left, 6 units missing at 2 units per second
+	ok, wait := l.take("a", 8)
+	require.False(t, ok)
+	require.Equal(t, 3*time.Second, wait)
+
+	// Other clients have their own bucket
+	ok, _ = l.take("b", 10)
+	require.True(t, ok)
+
+	// A cost higher than the burst is capped to the burst
+	now = now.Add(5 * time.Second)
+	ok, _ = l.take("a", 100)
+	require.True(t, ok)
+
+	// The bucket refills over time
+	now = now.Add(time.Second)
+	ok, _ = l.take("a", 2)
+	require.True(t, ok)
+	ok, wait = l.take("a", 1)
+	require.False(t, ok)
+	require.Equal(t, 500*time.Millisecond, wait)
+
+	require.Equal(t, RateLimitStats{
+		Enabled:         true,
+		Rate:            2,
+		Burst:           10,
+		Clients:         2,
+		AllowedRequests: 4,
+		LimitedRequests: 2,
+	}, l.stats())
+
+	// Idle clients are removed once their bucket is full again
+	now = now.Add(rateLimitSweepInterval)
+	ok, _ = l.take("a", 1)
+	require.True(t, ok)
+	require.Equal(t, 1, l.stats().Clients)
+}
+
+func TestRateLimit(t *testing.T) {
+	cases := []struct {
+		name       string
+		endpoint   string
+		remoteAddr string
+		status     int
+		retryAfter string
+		body       string
+	}{
+		{
+			name:       "v1 allowed",
+			endpoint:   "/api/v1/addresscount",
+			remoteAddr: "1.2.3.4:1000",
+			status:     http.StatusOK,
+		},
+		{
+			name:       "v1 limited",
+			endpoint:   "/api/v1/addresscount",
+			remoteAddr: "1.2.3.4:1001",
+			status:     http.StatusTooManyRequests,
+			retryAfter: "2",
+			body:       "429 Too Many Requests",
+		},
+		{
+			name:       "v2 allowed",
+			endpoint:   "/api/v2/payouts",
+			remoteAddr: "1.2.3.4:1002",
+			status:     http.StatusOK,
+		},
+		{
+			name:       "v2 limited",
+			endpoint:   "/api/v2/payouts",
+			remoteAddr: "1.2.3.4:1002",
+			status:     http.StatusTooManyRequests,
+			retryAfter: "1",
+			body:       "{\n    \"error\": {\n        \"message\": \"Too Many Requests\",\n        \"code\": 429\n    }\n}",
+		},
+		{
+			name:       "other client allowed",
+			endpoint:   "/api/v1/addresscount",
+			remoteAddr: "5.6.7.8:1000",
+			status:     http.StatusOK,
+		},
+	}
+
+	gateway := &MockGatewayer{}
+	gateway.On("AddressCount").Return(uint64(1), nil)
+	gateway.On("GetPayoutJobs").Return(nil, nil)
+
+	cfg := defaultMuxConfig()
+	cfg.rateLimiter = NewRateLimiter(RateLimitConfig{
+		Rate:  1,
+		Burst: 4,
+		EndpointCosts: map[string]int{
+			"/api/v1/addresscount": 3,
+		},
+	})
+	handler := newServerMux(cfg, gateway)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.endpoint, nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+			req.RemoteAddr = tc.remoteAddr
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code, rr.Body.String())
+			require.Equal(t, tc.retryAfter, rr.Header().Get("Retry-After"))
+			if tc.body != "" {
+				require.Equal(t, tc.body, strings.TrimSpace(rr.Body.String()))
+			}
+		})
+	}
+}
+
+func TestRateLimitAuthFailures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apitokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := apitoken.NewStore(filepath.Join(dir, "api_tokens.json"))
+	require.NoError(t, err)
+
+	cfg := defaultMuxConfig()
+	cfg.apiTokens = store
+	cfg.rateLimiter = NewRateLimiter(RateLimitConfig{
+		Rate:  1,
+		Burst: 2,
+	})
+	handler := newServerMux(cfg, &MockGatewayer{})
+
+	// Failed authentications are charged to the IP address of the client, until it is rate limited
+	for _, status := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/addresscount", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer foo")
+		req.RemoteAddr = "1.2.3.4:1000"
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, status, rr.Code, rr.Body.String())
+	}
+
+	require.Equal(t, uint64(1), cfg.rateLimiter.stats().LimitedRequests)
+}
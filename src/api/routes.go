@@ -0,0 +1,1190 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/skycoin/skycoin/src/readable"
+)
+
+// apiRoute describes an API endpoint.
+// newServerMux registers the endpoints returned by apiRoutes and the OpenAPI specification
+// served by /api/v2/openapi.json is generated from them, so that they can't drift apart.
+type apiRoute struct {
+	apiVersion string
+	endpoint   string
+	summary    string
+	handler    http.Handler
+	// anyAPISet endpoints are always available, regardless of the enabled API sets
+	anyAPISet bool
+	// skipCSRFCheck endpoints do not check the CSRF token
+	skipCSRFCheck bool
+	methods       []apiMethod
+}
+
+// apiMethod describes a method of an API endpoint
+type apiMethod struct {
+	method      string
+	description string
+	apiSets     []string
+	// params are the query parameters of GET requests and the form parameters of v1 POST requests
+	params []apiParam
+	// request is the JSON request body of v2 POST requests
+	request interface{}
+	// response is the JSON response body. For v2 endpoints, it is the data of the response.
+	// Use openAPIOneOf if the response depends on the parameters, e.g. verbose responses.
+	response interface{}
+	// contentType is the content type of a response which is not JSON, or not wrapped in the v2 response data
+	contentType string
+	// walletIDField is the field which the handler reads the ID of the wallet the request refers to from,
+	// a field of the JSON request body if the method has one, otherwise a query or form parameter.
+	// API tokens restricted to specific wallets can only use the wallet methods which declare it.
+	walletIDField string
+}
+
+// apiParam describes a query or form parameter
+type apiParam struct {
+	name        string
+	typ         string
+	description string
+	required    bool
+}
+
+// methodAPISets returns the API sets of the methods of the endpoint, as expected by newServerMux.
+// Returns nil if the endpoint is always available.
+func (rt apiRoute) methodAPISets() map[string][]string {
+	if rt.anyAPISet {
+		return nil
+	}
+
+	m := make(map[string][]string, len(rt.methods))
+	for _, mt := range rt.methods {
+		m[mt.method] = mt.apiSets
+	}
+	return m
+}
+
+// walletIDs returns where the handler of each method of the endpoint reads the wallet ID from, as expected by newServerMux
+func (rt apiRoute) walletIDs() map[string]walletIDSource {
+	m := make(map[string]walletIDSource, len(rt.methods))
+	for _, mt := range rt.methods {
+		if mt.walletIDField == "" {
+			continue
+		}
+
+		m[mt.method] = walletIDSource{
+			field: mt.walletIDField,
+			body:  mt.request != nil,
+		}
+	}
+	return m
+}
+
+var (
+	verboseParam = apiParam{
+		name:        "verbose",
+		typ:         "boolean",
+		description: "Include verbose transaction input data",
+	}
+	walletIDParam = apiParam{
+		name:        "id",
+		typ:         "string",
+		description: "Wallet ID",
+		required:    true,
+	}
+	walletPasswordParam = apiParam{
+		name:        "password",
+		typ:         "string",
+		description: "Wallet password, if the wallet is encrypted",
+	}
+	webhookIDParam = apiParam{
+		name:        "id",
+		typ:         "string",
+		description: "Webhook ID",
+		required:    true,
+	}
+)
+
+// apiRoutes returns the endpoints of the API, except for the GUI static files
+func apiRoutes(c muxConfig, gateway Gatewayer) []apiRoute {
+	return []apiRoute{
+		// CSRF and specification endpoints
+		{
+			apiVersion:    apiVersion1,
+			endpoint:      "/api/v1/csrf",
+			summary:       "Get current CSRF token",
+			handler:       getCSRFToken(c.disableCSRF),
+			anyAPISet:     true,
+			skipCSRFCheck: true,
+			methods: []apiMethod{{
+				method: http.MethodGet,
+				response: struct {
+					CSRFToken string `json:"csrf_token"`
+				}{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/openapi.json",
+			summary:    "OpenAPI specification of the API",
+			handler:    openAPIHandler(c),
+			anyAPISet:  true,
+			methods: []apiMethod{{
+				method:      http.MethodGet,
+				response:    map[string]interface{}{},
+				contentType: ContentTypeJSON,
+			}},
+		},
+
+		// Status endpoints
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/version",
+			summary:    "Version info",
+			handler:    versionHandler(c.health.BuildInfo),
+			anyAPISet:  true,
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				response: readable.BuildInfo{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/health",
+			summary:    "Health check",
+			handler:    healthHandler(c, gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsRead, EndpointsStatus},
+				response: HealthResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/metrics",
+			summary:    "Metrics in the Prometheus text format",
+			handler:    metricsHandler(gateway),
+			methods: []apiMethod{{
+				method:      http.MethodGet,
+				apiSets:     []string{EndpointsMetrics},
+				contentType: "text/plain",
+			}},
+		},
+
+		// Wallet endpoints
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet",
+			summary:    "Get wallet",
+			handler:    walletHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodGet,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params:        []apiParam{walletIDParam},
+				response:      WalletResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/create",
+			summary:    "Create wallet",
+			handler:    walletCreateHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodPost,
+				apiSets: []string{EndpointsWallet},
+				params: []apiParam{
+					{name: "type", typ: "string", description: `Wallet type, "deterministic", "bip44" or "xpub"`, required: true},
+					{name: "seed", typ: "string", description: "Wallet seed, required for deterministic and bip44 wallets"},
+					{name: "seed-passphrase", typ: "string", description: "Seed passphrase of bip44 wallets"},
+					{name: "bip44-coin", typ: "integer", description: "BIP44 coin type of bip44 wallets"},
+					{name: "xpub", typ: "string", description: "xpub key, required for xpub wallets unless a descriptor is given"},
+					{name: "descriptor", typ: "string", description: "Output descriptor of bip44 and xpub wallets"},
+					{name: "label", typ: "string", description: "Wallet label", required: true},
+					{name: "scan", typ: "integer", description: "Number of addresses to scan ahead for balances"},
+					{name: "encrypt", typ: "boolean", description: "Encrypt the wallet"},
+					{name: "password", typ: "string", description: "Password to encrypt the wallet with"},
+				},
+				response: WalletResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/newAddress",
+			summary:    "Generate new address in wallet",
+			handler:    walletNewAddressesHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params: []apiParam{
+					walletIDParam,
+					{name: "num", typ: "integer", description: "Number of addresses to generate, defaults to 1"},
+					walletPasswordParam,
+				},
+				response: struct {
+					Addresses []string `json:"addresses"`
+				}{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/scan",
+			summary:    "Scan addresses in wallet",
+			handler:    walletScanAddressesHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params: []apiParam{
+					walletIDParam,
+					{name: "num", typ: "integer", description: "Number of addresses to scan ahead for balances, defaults to 20"},
+					walletPasswordParam,
+				},
+				response: struct {
+					Addresses []string `json:"addresses"`
+				}{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/balance",
+			summary:    "Get wallet balance",
+			handler:    walletBalanceHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodGet,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params:        []apiParam{walletIDParam},
+				response:      BalanceResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/transaction",
+			summary:    "Create transaction",
+			handler:    walletCreateTransactionHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "wallet_id",
+				request:       walletCreateTransactionRequest{},
+				response:      CreateTransactionResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/wallet/transaction/sign",
+			summary:    "Sign transaction",
+			handler:    walletSignTransactionHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "wallet_id",
+				request:       WalletSignTransactionRequest{},
+				response:      CreateTransactionResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/transactions",
+			summary:    "Get unconfirmed transactions of a wallet",
+			handler:    walletTransactionsHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodGet,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params:        []apiParam{walletIDParam, verboseParam},
+				response:      openAPIOneOf{UnconfirmedTxnsResponse{}, UnconfirmedTxnsVerboseResponse{}},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/update",
+			summary:    "Change wallet label",
+			handler:    walletUpdateHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params: []apiParam{
+					walletIDParam,
+					{name: "label", typ: "string", description: "New wallet label", required: true},
+				},
+				response: "",
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallets",
+			summary:    "Get wallets",
+			handler:    walletsHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsWallet},
+				response: []WalletResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallets/folderName",
+			summary:    "Get wallet folder name",
+			handler:    walletFolderHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsWallet},
+				response: WalletFolder{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/newSeed",
+			summary:    "Generate wallet seed",
+			handler:    newSeedHandler(),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsWallet},
+				params: []apiParam{
+					{name: "entropy", typ: "integer", description: "Entropy bitsize, 128 or 256, defaults to 128"},
+					{name: "language", typ: "string", description: "Language of the mnemonic, defaults to english"},
+				},
+				response: struct {
+					Seed string `json:"seed"`
+				}{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/seed",
+			summary:    "Get wallet seed",
+			handler:    walletSeedHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsInsecureWalletSeed},
+				walletIDField: "id",
+				params:        []apiParam{walletIDParam, walletPasswordParam},
+				response:      WalletSeedResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/wallet/seed/verify",
+			summary:    "Verify wallet seed",
+			handler:    http.HandlerFunc(walletVerifySeedHandler),
+			methods: []apiMethod{{
+				method:   http.MethodPost,
+				apiSets:  []string{EndpointsWallet},
+				request:  VerifySeedRequest{},
+				response: VerifySeedResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/wallet/outputs/meta",
+			summary:    "Wallet outputs metadata",
+			handler:    walletOutputsMetaHandler(gateway),
+			methods: []apiMethod{
+				{
+					method:        http.MethodGet,
+					description:   "Get wallet outputs metadata",
+					apiSets:       []string{EndpointsWallet},
+					walletIDField: "id",
+					params:        []apiParam{walletIDParam},
+					response:      readable.UxOutsMeta{},
+				},
+				{
+					method:        http.MethodPost,
+					description:   "Update wallet outputs metadata",
+					apiSets:       []string{EndpointsWallet},
+					walletIDField: "id",
+					request:       WalletOutputsMetaRequest{},
+					response:      readable.UxOutsMeta{},
+				},
+			},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/payout",
+			summary:    "Payouts",
+			handler:    payoutHandler(gateway),
+			methods: []apiMethod{
+				{
+					method:        http.MethodGet,
+					description:   "Get payout",
+					apiSets:       []string{EndpointsWallet},
+					walletIDField: "wallet_id",
+					params: []apiParam{
+						{name: "idempotency_key", typ: "string", description: "Idempotency key of the payout", required: true},
+						{name: "wallet_id", typ: "string", description: "Only return the payout if it spends from this wallet"},
+					},
+					response: PayoutJob{},
+				},
+				{
+					method:        http.MethodPost,
+					description:   "Create payout",
+					apiSets:       []string{EndpointsWallet},
+					walletIDField: "wallet_id",
+					request:       payoutRequest{},
+					response:      PayoutJob{},
+				},
+			},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/payouts",
+			summary:    "Get payouts",
+			handler:    payoutsHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodGet,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "wallet_id",
+				params: []apiParam{
+					{name: "wallet_id", typ: "string", description: "Only return the payouts that spend from this wallet"},
+				},
+				response: []PayoutJob{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/unload",
+			summary:    "Unload wallet",
+			handler:    walletUnloadHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params:        []apiParam{walletIDParam},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/encrypt",
+			summary:    "Encrypt wallet",
+			handler:    walletEncryptHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params:        []apiParam{walletIDParam, walletPasswordParam},
+				response:      WalletResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/wallet/decrypt",
+			summary:    "Decrypt wallet",
+			handler:    walletDecryptHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				params:        []apiParam{walletIDParam, walletPasswordParam},
+				response:      WalletResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/wallet/recover",
+			summary:    "Recover encrypted wallet by seed",
+			handler:    walletRecoverHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				request:       WalletRecoverRequest{},
+				response:      WalletResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/wallet/descriptor",
+			summary:    "Get wallet output descriptor",
+			handler:    walletDescriptorHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "id",
+				request:       WalletDescriptorRequest{},
+				response:      WalletDescriptorResponse{},
+			}},
+		},
+
+		// Blockchain endpoints
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/blockchain/metadata",
+			summary:    "Get blockchain metadata",
+			handler:    blockchainMetadataHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsRead, EndpointsStatus},
+				response: readable.BlockchainMetadata{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/blockchain/progress",
+			summary:    "Get blockchain progress",
+			handler:    blockchainProgressHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsRead, EndpointsStatus},
+				response: readable.BlockchainProgress{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/block",
+			summary:    "Get block by hash or seq",
+			handler:    blockHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "hash", typ: "string", description: "Block hash"},
+					{name: "seq", typ: "integer", description: "Block sequence number"},
+					verboseParam,
+				},
+				response: openAPIOneOf{readable.Block{}, readable.BlockVerbose{}},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/blocks",
+			summary:    "Get blocks in specific range",
+			handler:    blocksHandler(gateway),
+			methods: []apiMethod{
+				{
+					method:  http.MethodGet,
+					apiSets: []string{EndpointsRead},
+					params: []apiParam{
+						{name: "start", typ: "integer", description: "First block sequence number of the range"},
+						{name: "end", typ: "integer", description: "Last block sequence number of the range"},
+						{name: "seqs", typ: "string", description: "Comma separated block sequence numbers, instead of a range"},
+						verboseParam,
+					},
+					response: openAPIOneOf{readable.Blocks{}, readable.BlocksVerbose{}},
+				},
+				{
+					method:  http.MethodPost,
+					apiSets: []string{EndpointsRead},
+					params: []apiParam{
+						{name: "start", typ: "integer", description: "First block sequence number of the range"},
+						{name: "end", typ: "integer", description: "Last block sequence number of the range"},
+						{name: "seqs", typ: "string", description: "Comma separated block sequence numbers, instead of a range"},
+						verboseParam,
+					},
+					response: openAPIOneOf{readable.Blocks{}, readable.BlocksVerbose{}},
+				},
+			},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/last_blocks",
+			summary:    "Get last N blocks",
+			handler:    lastBlocksHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "num", typ: "integer", description: "Number of blocks", required: true},
+					verboseParam,
+				},
+				response: openAPIOneOf{readable.Blocks{}, readable.BlocksVerbose{}},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/blocks",
+			summary:    "Get blocks with cursor pagination",
+			handler:    blocksHandlerV2(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "cursor", typ: "string", description: "Cursor returned with the previous page, empty for the first page"},
+					{name: "limit", typ: "integer", description: "Number of blocks per page, defaults to 10"},
+					{name: "sort", typ: "string", description: `Sort order by block seq, "asc" or "desc". Defaults to "asc"`},
+					verboseParam,
+				},
+				response: openAPIOneOf{BlocksPageResponse{}, BlocksPageVerboseResponse{}},
+			}},
+		},
+
+		// Network stats endpoints
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/network/connection",
+			summary:    "Get information for a specific connection",
+			handler:    connectionHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead, EndpointsStatus},
+				params: []apiParam{
+					{name: "addr", typ: "string", description: "IP:Port address of the connection", required: true},
+				},
+				response: readable.Connection{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/network/connections",
+			summary:    "Get a list of all connections",
+			handler:    connectionsHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead, EndpointsStatus},
+				params: []apiParam{
+					{name: "states", typ: "string", description: `Comma separated connection states, "pending", "connected" or "introduced". Defaults to "connected,introduced"`},
+					{name: "direction", typ: "string", description: `Connection direction, "outgoing" or "incoming"`},
+				},
+				response: Connections{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/network/defaultConnections",
+			summary:    "Get a list of all default connections",
+			handler:    defaultConnectionsHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsRead, EndpointsStatus},
+				response: []string{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/network/connections/trust",
+			summary:    "Get a list of all trusted connections",
+			handler:    trustConnectionsHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsRead, EndpointsStatus},
+				response: []string{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/network/connections/exchange",
+			summary:    "Get a list of all connections discovered through peer exchange",
+			handler:    exchgConnectionsHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsRead, EndpointsStatus},
+				response: []string{},
+			}},
+		},
+
+		// Network admin endpoints
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/network/connection/disconnect",
+			summary:    "Disconnect a peer",
+			handler:    disconnectHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodPost,
+				apiSets: []string{EndpointsNetCtrl},
+				params: []apiParam{
+					{name: "id", typ: "integer", description: "Connection ID", required: true},
+				},
+				response: struct{}{},
+			}},
+		},
+
+		// Transaction endpoints
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/pendingTxs",
+			summary:    "Get unconfirmed transactions",
+			handler:    pendingTxnsHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsRead},
+				params:   []apiParam{verboseParam},
+				response: openAPIOneOf{[]readable.UnconfirmedTransactions{}, []readable.UnconfirmedTransactionVerbose{}},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/transaction",
+			summary:    "Get transaction info by id",
+			handler:    transactionHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "txid", typ: "string", description: "Transaction ID", required: true},
+					verboseParam,
+					{name: "encoded", typ: "boolean", description: "Return the encoded transaction"},
+				},
+				response: openAPIOneOf{readable.TransactionWithStatus{}, readable.TransactionWithStatusVerbose{}, TransactionEncodedResponse{}},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/transaction",
+			summary:    "Create transaction from unspent outputs or addresses",
+			handler:    transactionHandlerV2(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodPost,
+				apiSets:  []string{EndpointsTransaction},
+				request:  createTransactionRequest{},
+				response: CreateTransactionResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/transaction/verify",
+			summary:    "Verify encoded transaction",
+			handler:    verifyTxnHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodPost,
+				apiSets:  []string{EndpointsRead},
+				request:  VerifyTransactionRequest{},
+				response: VerifyTransactionResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/transactions",
+			summary:    "Get transactions for addresses",
+			handler:    transactionsHandler(gateway),
+			methods: []apiMethod{
+				{
+					method:  http.MethodGet,
+					apiSets: []string{EndpointsRead},
+					params: []apiParam{
+						{name: "addrs", typ: "string", description: "Comma separated addresses"},
+						{name: "confirmed", typ: "boolean", description: "Only return confirmed or unconfirmed transactions"},
+						verboseParam,
+					},
+					response: openAPIOneOf{[]readable.TransactionWithStatus{}, []readable.TransactionWithStatusVerbose{}},
+				},
+				{
+					method:  http.MethodPost,
+					apiSets: []string{EndpointsRead},
+					params: []apiParam{
+						{name: "addrs", typ: "string", description: "Comma separated addresses"},
+						{name: "confirmed", typ: "boolean", description: "Only return confirmed or unconfirmed transactions"},
+						verboseParam,
+					},
+					response: openAPIOneOf{[]readable.TransactionWithStatus{}, []readable.TransactionWithStatusVerbose{}},
+				},
+			},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/transactions",
+			summary:    "Get transactions with pagination",
+			handler:    transactionsHandlerV2(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "addrs", typ: "string", description: "Comma separated addresses"},
+					{name: "confirmed", typ: "boolean", description: "Only return confirmed or unconfirmed transactions"},
+					verboseParam,
+					{name: "page", typ: "integer", description: "Page number, defaults to 1"},
+					{name: "cursor", typ: "string", description: "Cursor returned with the previous page, empty for the first page. Only returns confirmed transactions, and can't be combined with page"},
+					{name: "limit", typ: "integer", description: "Number of transactions per page, defaults to 10"},
+					{name: "sort", typ: "string", description: `Sort order by block seq, "asc" or "desc". Defaults to "asc"`},
+				},
+				response: openAPIOneOf{
+					struct {
+						PageInfo readable.PageInfo                `json:"page_info"`
+						Txns     []readable.TransactionWithStatus `json:"txns"`
+					}{},
+					struct {
+						PageInfo readable.PageInfo                       `json:"page_info"`
+						Txns     []readable.TransactionWithStatusVerbose `json:"txns"`
+					}{},
+					TransactionsPageResponse{},
+					TransactionsPageVerboseResponse{},
+				},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/injectTransaction",
+			summary:    "Inject raw transaction",
+			handler:    injectTransactionHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodPost,
+				apiSets:  []string{EndpointsTransaction, EndpointsWallet},
+				request:  InjectTransactionRequest{},
+				response: "",
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/resendUnconfirmedTxns",
+			summary:    "Resend unconfirmed transactions",
+			handler:    resendUnconfirmedTxnsHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodPost,
+				apiSets:  []string{EndpointsTransaction, EndpointsWallet},
+				response: ResendResult{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/rawtx",
+			summary:    "Get raw transaction by id",
+			handler:    rawTxnHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "txid", typ: "string", description: "Transaction ID", required: true},
+				},
+				response: "",
+			}},
+		},
+
+		// Unspent output endpoints
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/outputs",
+			summary:    "Get unspent output set of address or hash",
+			handler:    outputsHandler(gateway),
+			methods: []apiMethod{
+				{
+					method:  http.MethodGet,
+					apiSets: []string{EndpointsRead},
+					params: []apiParam{
+						{name: "addrs", typ: "string", description: "Comma separated addresses"},
+						{name: "hashes", typ: "string", description: "Comma separated unspent output hashes"},
+					},
+					response: readable.UnspentOutputsSummary{},
+				},
+				{
+					method:  http.MethodPost,
+					apiSets: []string{EndpointsRead},
+					params: []apiParam{
+						{name: "addrs", typ: "string", description: "Comma separated addresses"},
+						{name: "hashes", typ: "string", description: "Comma separated unspent output hashes"},
+					},
+					response: readable.UnspentOutputsSummary{},
+				},
+			},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/outputs",
+			summary:    "Get unspent outputs of addresses with cursor pagination",
+			handler:    outputsHandlerV2(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "addrs", typ: "string", description: "Comma separated addresses", required: true},
+					{name: "cursor", typ: "string", description: "Cursor returned with the previous page, empty for the first page"},
+					{name: "limit", typ: "integer", description: "Number of outputs per page, defaults to 10"},
+					{name: "sort", typ: "string", description: `Sort order by block seq, "asc" or "desc". Defaults to "asc"`},
+				},
+				response: OutputsPageResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/balance",
+			summary:    "Get balance of addresses",
+			handler:    balanceHandler(gateway),
+			methods: []apiMethod{
+				{
+					method:  http.MethodGet,
+					apiSets: []string{EndpointsRead},
+					params: []apiParam{
+						{name: "addrs", typ: "string", description: "Comma separated addresses", required: true},
+					},
+					response: BalanceResponse{},
+				},
+				{
+					method:  http.MethodPost,
+					apiSets: []string{EndpointsRead},
+					params: []apiParam{
+						{name: "addrs", typ: "string", description: "Comma separated addresses", required: true},
+					},
+					response: BalanceResponse{},
+				},
+			},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/balance",
+			summary:    "Get balance of addresses at a block",
+			handler:    balanceHandlerV2(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "addrs", typ: "string", description: "Comma separated addresses", required: true},
+					{name: "seq", typ: "integer", description: "Block seq, defaults to the head block"},
+				},
+				response: BalanceAtResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/uxout",
+			summary:    "Get uxout",
+			handler:    uxOutHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "uxid", typ: "string", description: "Unspent output ID", required: true},
+				},
+				response: readable.SpentOutput{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/address_uxouts",
+			summary:    "Get historical unspent outputs for an address",
+			handler:    addrUxOutsHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "address", typ: "string", description: "Address", required: true},
+				},
+				response: []readable.SpentOutput{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/address_uxouts",
+			summary:    "Get historical outputs of an address with cursor pagination",
+			handler:    addrUxOutsHandlerV2(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "address", typ: "string", description: "Address", required: true},
+					{name: "cursor", typ: "string", description: "Cursor returned with the previous page, empty for the first page"},
+					{name: "limit", typ: "integer", description: "Number of outputs per page, defaults to 10"},
+					{name: "sort", typ: "string", description: `Sort order by block seq, "asc" or "desc". Defaults to "asc"`},
+				},
+				response: AddressUxOutsPageResponse{},
+			}},
+		},
+
+		// Address endpoints
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/address/verify",
+			summary:    "Verify an address",
+			handler:    http.HandlerFunc(addressVerifyHandler),
+			methods: []apiMethod{{
+				method:   http.MethodPost,
+				apiSets:  []string{EndpointsRead},
+				request:  VerifyAddressRequest{},
+				response: VerifyAddressResponse{},
+			}},
+		},
+
+		// Message endpoints
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/message/sign",
+			summary:    "Sign a message with a wallet address",
+			handler:    messageSignHandler(gateway),
+			methods: []apiMethod{{
+				method:        http.MethodPost,
+				apiSets:       []string{EndpointsWallet},
+				walletIDField: "wallet_id",
+				request:       MessageSignRequest{},
+				response:      MessageSignResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/message/verify",
+			summary:    "Verify a signed message",
+			handler:    http.HandlerFunc(messageVerifyHandler),
+			methods: []apiMethod{{
+				method:   http.MethodPost,
+				apiSets:  []string{EndpointsRead},
+				request:  MessageVerifyRequest{},
+				response: MessageVerifyResponse{},
+			}},
+		},
+
+		// Explorer endpoints
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/coinSupply",
+			summary:    "Coin supply",
+			handler:    coinSupplyHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsRead},
+				response: CoinSupply{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/richlist",
+			summary:    "Richlist show top N addresses by uxouts",
+			handler:    richlistHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "n", typ: "integer", description: "Number of addresses, defaults to 20. 0 returns all addresses"},
+					{name: "include-distribution", typ: "boolean", description: "Include the distribution addresses"},
+				},
+				response: Richlist{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/richlist",
+			summary:    "Richlist show top N addresses at a block",
+			handler:    richlistHandlerV2(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				params: []apiParam{
+					{name: "seq", typ: "integer", description: "Block seq, defaults to the head block"},
+					{name: "n", typ: "integer", description: "Number of addresses, defaults to 20. 0 returns all addresses"},
+					{name: "include-distribution", typ: "boolean", description: "Include the distribution addresses"},
+				},
+				response: RichlistAtResponse{},
+			}},
+		},
+		{
+			apiVersion: apiVersion1,
+			endpoint:   "/api/v1/addresscount",
+			summary:    "Count the addresses that currently have unspent outputs",
+			handler:    addressCountHandler(gateway),
+			methods: []apiMethod{{
+				method:  http.MethodGet,
+				apiSets: []string{EndpointsRead},
+				response: struct {
+					Count uint64 `json:"count"`
+				}{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/search",
+			summary:    "Search blocks, transactions and addresses by prefix",
+			handler:    searchHandler(gateway),
+			methods: []apiMethod{{
+				method:      http.MethodGet,
+				description: "A block seq matches the block, a hex prefix matches block and transaction hashes, and a base58 prefix matches addresses. Only confirmed transactions are searched.",
+				apiSets:     []string{EndpointsRead},
+				params: []apiParam{
+					{name: "q", typ: "string", description: "Block seq, or prefix of a block hash, transaction hash or address", required: true},
+					{name: "limit", typ: "integer", description: "Maximum number of results of each kind, defaults to 10"},
+				},
+				response: SearchResponse{},
+			}},
+		},
+
+		// JSON-RPC endpoint
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/jsonrpc",
+			summary:    "JSON-RPC 2.0 interface",
+			handler:    jsonRPCHandler(c, gateway),
+			methods: []apiMethod{{
+				method:      http.MethodPost,
+				description: "Call a JSON-RPC method, or a batch of methods. Each method is available if its API set is enabled.",
+				apiSets:     jsonRPCAPISets(),
+				request:     openAPIOneOf{RPCRequest{}, []RPCRequest{}},
+				response:    openAPIOneOf{RPCResponse{}, []RPCResponse{}},
+				contentType: ContentTypeJSON,
+			}},
+		},
+
+		// Storage endpoints
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/data",
+			summary:    "Key-value storage",
+			handler:    storageHandler(gateway),
+			methods: []apiMethod{
+				{
+					method:      http.MethodGet,
+					description: "Get all storage values, or the value of a key",
+					apiSets:     []string{EndpointsStorage},
+					params: []apiParam{
+						{name: "type", typ: "string", description: "Storage type", required: true},
+						{name: "key", typ: "string", description: "Key of the value to get. If not set, all values are returned"},
+					},
+					response: openAPIOneOf{map[string]string{}, ""},
+				},
+				{
+					method:      http.MethodPost,
+					description: "Add value to storage",
+					apiSets:     []string{EndpointsStorage},
+					request:     StorageRequest{},
+				},
+				{
+					method:      http.MethodDelete,
+					description: "Remove value from storage",
+					apiSets:     []string{EndpointsStorage},
+					params: []apiParam{
+						{name: "type", typ: "string", description: "Storage type", required: true},
+						{name: "key", typ: "string", description: "Key of the value to remove", required: true},
+					},
+				},
+			},
+		},
+
+		// Webhook endpoints
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/webhook",
+			summary:    "Webhooks",
+			handler:    webhookHandler(gateway),
+			methods: []apiMethod{
+				{
+					method:      http.MethodGet,
+					description: "Get webhook",
+					apiSets:     []string{EndpointsWebhook},
+					params:      []apiParam{webhookIDParam},
+					response:    Webhook{},
+				},
+				{
+					method:      http.MethodPost,
+					description: "Register webhook. The response includes the secret that signs the deliveries.",
+					apiSets:     []string{EndpointsWebhook},
+					request:     webhookRequest{},
+					response:    Webhook{},
+				},
+				{
+					method:      http.MethodDelete,
+					description: "Delete webhook and its deliveries",
+					apiSets:     []string{EndpointsWebhook},
+					params:      []apiParam{webhookIDParam},
+				},
+			},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/webhooks",
+			summary:    "Get webhooks",
+			handler:    webhooksHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsWebhook},
+				response: []Webhook{},
+			}},
+		},
+		{
+			apiVersion: apiVersion2,
+			endpoint:   "/api/v2/webhook/deliveries",
+			summary:    "Get webhook deliveries, newest first",
+			handler:    webhookDeliveriesHandler(gateway),
+			methods: []apiMethod{{
+				method:   http.MethodGet,
+				apiSets:  []string{EndpointsWebhook},
+				params:   []apiParam{webhookIDParam},
+				response: []WebhookDelivery{},
+			}},
+		},
+	}
+}
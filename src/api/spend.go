@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 
 	"github.com/shopspring/decimal"
 
@@ -13,10 +12,9 @@ import (
 	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/transaction"
-	"github.com/skycoin/skycoin/src/util/droplet"
+	"github.com/skycoin/skycoin/src/util/amount"
 	"github.com/skycoin/skycoin/src/util/fee"
 	wh "github.com/skycoin/skycoin/src/util/http"
-	"github.com/skycoin/skycoin/src/util/mathutil"
 	"github.com/skycoin/skycoin/src/visor"
 	"github.com/skycoin/skycoin/src/visor/blockdb"
 	"github.com/skycoin/skycoin/src/wallet"
@@ -65,30 +63,29 @@ func NewCreatedTransaction(txn *coin.Transaction, inputs []visor.TransactionInpu
 		return nil, errors.New("len(txn.In) != len(inputs)")
 	}
 
-	var outputHours uint64
+	var outputHours amount.Hours
 	for _, o := range txn.Out {
 		var err error
-		outputHours, err = mathutil.AddUint64(outputHours, o.Hours)
+		outputHours, err = outputHours.Add(amount.Hours(o.Hours))
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	var inputHours uint64
+	var inputHours amount.Hours
 	for _, i := range inputs {
 		var err error
-		inputHours, err = mathutil.AddUint64(inputHours, i.CalculatedHours)
+		inputHours, err = inputHours.Add(amount.Hours(i.CalculatedHours))
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if inputHours < outputHours {
+	fee, err := inputHours.Sub(outputHours)
+	if err != nil {
 		return nil, errors.New("inputHours unexpectedly less than output hours")
 	}
 
-	fee := inputHours - outputHours
-
 	sigs := make([]string, len(txn.Sigs))
 	for i, s := range txn.Sigs {
 		sigs[i] = s.Hex()
@@ -118,7 +115,7 @@ func NewCreatedTransaction(txn *coin.Transaction, inputs []visor.TransactionInpu
 		Type:      txn.Type,
 		TxID:      txID.Hex(),
 		InnerHash: txn.InnerHash.Hex(),
-		Fee:       fmt.Sprint(fee),
+		Fee:       fee.String(),
 
 		Sigs: sigs,
 		In:   in,
@@ -161,25 +158,25 @@ func (r *CreatedTransaction) ToTransaction() (*coin.Transaction, error) {
 
 	out := make([]coin.TransactionOutput, len(r.Out))
 	for i, o := range r.Out {
-		addr, err := cipher.DecodeBase58Address(o.Address)
+		addr, err := cipher.DecodeAddress(params.AddressHRP, o.Address)
 		if err != nil {
 			return nil, err
 		}
 
-		coins, err := droplet.FromString(o.Coins)
+		coins, err := amount.ParseAmount(o.Coins)
 		if err != nil {
 			return nil, err
 		}
 
-		hours, err := strconv.ParseUint(o.Hours, 10, 64)
+		hours, err := amount.ParseHours(o.Hours)
 		if err != nil {
 			return nil, err
 		}
 
 		out[i] = coin.TransactionOutput{
 			Address: addr,
-			Coins:   coins,
-			Hours:   hours,
+			Coins:   coins.Value(),
+			Hours:   hours.Value(),
 		}
 	}
 
@@ -207,7 +204,7 @@ type CreatedTransactionOutput struct {
 
 // NewCreatedTransactionOutput creates CreatedTransactionOutput
 func NewCreatedTransactionOutput(out coin.TransactionOutput, txid cipher.SHA256) (*CreatedTransactionOutput, error) {
-	coins, err := droplet.ToString(out.Coins)
+	coins, err := amount.Amount(out.Coins).ToString()
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +213,7 @@ func NewCreatedTransactionOutput(out coin.TransactionOutput, txid cipher.SHA256)
 		UxID:    out.UxID(txid).Hex(),
 		Address: out.Address.String(),
 		Coins:   coins,
-		Hours:   fmt.Sprint(out.Hours),
+		Hours:   amount.Hours(out.Hours).String(),
 	}, nil
 }
 
@@ -234,7 +231,7 @@ type CreatedTransactionInput struct {
 
 // NewCreatedTransactionInput creates CreatedTransactionInput
 func NewCreatedTransactionInput(out visor.TransactionInput) (*CreatedTransactionInput, error) {
-	coins, err := droplet.ToString(out.UxOut.Body.Coins)
+	coins, err := amount.Amount(out.UxOut.Body.Coins).ToString()
 	if err != nil {
 		return nil, err
 	}
@@ -244,8 +241,8 @@ func NewCreatedTransactionInput(out visor.TransactionInput) (*CreatedTransaction
 	}
 
 	addr := out.UxOut.Body.Address.String()
-	hours := fmt.Sprint(out.UxOut.Body.Hours)
-	calculatedHours := fmt.Sprint(out.CalculatedHours)
+	hours := amount.Hours(out.UxOut.Body.Hours).String()
+	calculatedHours := amount.Hours(out.CalculatedHours).String()
 	txID := out.UxOut.Body.SrcTransaction.Hex()
 
 	return &CreatedTransactionInput{
@@ -265,6 +262,7 @@ type createTransactionRequest struct {
 	IgnoreUnconfirmed bool           `json:"ignore_unconfirmed"`
 	HoursSelection    hoursSelection `json:"hours_selection"`
 	ChangeAddress     *wh.Address    `json:"change_address,omitempty"`
+	ChooseStrategy    string         `json:"choose_strategy,omitempty"`
 	To                []receiver     `json:"to"`
 	UxOuts            []wh.SHA256    `json:"unspents,omitempty"`
 	Addresses         []wh.Address   `json:"addresses,omitempty"`
@@ -380,7 +378,7 @@ func (r createTransactionRequest) Validate() error {
 			return fmt.Errorf("to[%d].coins must not be zero", i)
 		}
 
-		if to.Coins.Value()%params.UserVerifyTxn.MaxDropletDivisor() != 0 {
+		if err := to.Coins.CheckPrecision(params.UserVerifyTxn.MaxDropletPrecision); err != nil {
 			return fmt.Errorf("to[%d].coins has too many decimal places", i)
 		}
 	}
@@ -410,6 +408,16 @@ func (r createTransactionRequest) Validate() error {
 		outputs[txo] = struct{}{}
 	}
 
+	// The choose strategy is only validated by transaction.Params, so that new strategies are added in one place
+	if err := r.TransactionParams().Validate(); err != nil {
+		switch err {
+		case transaction.ErrInvalidChooseStrategy:
+			return errors.New("invalid choose_strategy")
+		default:
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -440,8 +448,9 @@ func (r createTransactionRequest) TransactionParams() transaction.Params {
 			Mode:        r.HoursSelection.Mode,
 			ShareFactor: r.HoursSelection.ShareFactor,
 		},
-		ChangeAddress: changeAddress,
-		To:            to,
+		ChangeAddress:  changeAddress,
+		ChooseStrategy: r.ChooseStrategy,
+		To:             to,
 	}
 }
 
@@ -40,6 +40,7 @@ type rawCreateTxnRequest struct {
 	Addresses      []string          `json:"addresses,omitempty"`
 	HoursSelection rawHoursSelection `json:"hours_selection"`
 	ChangeAddress  string            `json:"change_address,omitempty"`
+	ChooseStrategy string            `json:"choose_strategy,omitempty"`
 	To             []rawReceiver     `json:"to"`
 	Password       string            `json:"password"`
 }
@@ -559,6 +560,28 @@ func TestCreateTransaction(t *testing.T) {
 			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "to contains duplicate values"),
 		},
 
+		{
+			name:   "400 - invalid choose strategy",
+			method: http.MethodPost,
+			body: &rawCreateTxnRequest{
+				HoursSelection: rawHoursSelection{
+					Type:        transaction.HoursSelectionTypeAuto,
+					Mode:        transaction.HoursSelectionModeShare,
+					ShareFactor: newStrPtr("0.5"),
+				},
+				ChangeAddress:  changeAddress.String(),
+				ChooseStrategy: "foo",
+				To: []rawReceiver{
+					{
+						Address: destinationAddress.String(),
+						Coins:   "1.2",
+					},
+				},
+			},
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid choose_strategy"),
+		},
+
 		{
 			name:   "400 - both uxouts and addresses specified",
 			method: http.MethodPost,
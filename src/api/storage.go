@@ -105,7 +105,8 @@ type StorageRequest struct {
 	Val         string         `json:"val"`
 }
 
-// Adds the value to the storage of a given type
+// Adds the value to the storage of a given type.
+// Values of the address book storage must be JSON encoded contacts, whose addresses are validated.
 // Args:
 //     type: storage type
 //     key: key
@@ -140,7 +141,12 @@ func addStorageValueHandler(w http.ResponseWriter, r *http.Request, gateway Gate
 		case kvstorage.ErrUnknownKVStorageType:
 			resp = NewHTTPErrorResponse(http.StatusBadRequest, "unknown storage")
 		default:
-			resp = NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+			switch err.(type) {
+			case kvstorage.Error:
+				resp = NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+			default:
+				resp = NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+			}
 		}
 		writeHTTPResponse(w, resp)
 		return
@@ -504,6 +504,22 @@ func TestAddStorageValueHandler(t *testing.T) {
 			addStorageValueErr: kvstorage.ErrNoSuchStorage,
 			httpResponse:       NewHTTPErrorResponse(http.StatusNotFound, "storage is not loaded"),
 		},
+		{
+			name:        "400 - invalid contact",
+			method:      http.MethodPost,
+			contentType: ContentTypeJSON,
+			httpBody: toJSON(t, StorageRequest{
+				StorageType: kvstorage.TypeAddressBook,
+				Key:         "alice",
+				Val:         `{"addresses":[]}`,
+			}),
+			status:             http.StatusBadRequest,
+			storageType:        kvstorage.TypeAddressBook,
+			key:                "alice",
+			val:                `{"addresses":[]}`,
+			addStorageValueErr: kvstorage.ErrContactMissingAddresses,
+			httpResponse:       NewHTTPErrorResponse(http.StatusBadRequest, kvstorage.ErrContactMissingAddresses.Error()),
+		},
 		{
 			name:        "400 - missing key",
 			method:      http.MethodPost,
@@ -14,8 +14,8 @@ import (
 	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/daemon"
 	"github.com/skycoin/skycoin/src/readable"
+	"github.com/skycoin/skycoin/src/util/amount"
 	wh "github.com/skycoin/skycoin/src/util/http"
-	"github.com/skycoin/skycoin/src/util/mathutil"
 	"github.com/skycoin/skycoin/src/visor"
 )
 
@@ -338,6 +338,8 @@ func transactionsHandler(gateway Gatewayer) http.HandlerFunc {
 //     limit: the number of transactions per page [optional, default to 10, must be <= 100]
 //     sort: Sort the transactions by block seq. [optional, must be desc or asc]; if not provided, return
 //     in asc order.
+//     cursor: cursor returned with the previous page, empty for the first page. Cursor pagination
+//     only returns confirmed transactions, and can't be combined with page.
 func transactionsHandlerV2(gateway Gatewayer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -358,6 +360,12 @@ func transactionsHandlerV2(gateway Gatewayer) http.HandlerFunc {
 			return
 		}
 
+		// Cursor pagination is used if the cursor parameter is present, even if empty
+		if _, ok := r.Form["cursor"]; ok {
+			transactionsCursorPageV2(w, r, gateway, addrs, verbose)
+			return
+		}
+
 		// Initialize transaction filters
 		flts := []visor.TxFilter{}
 		if len(addrs) > 0 {
@@ -465,6 +473,84 @@ func transactionsHandlerV2(gateway Gatewayer) http.HandlerFunc {
 	}
 }
 
+// TransactionsPageResponse is returned by GET /api/v2/transactions with a cursor
+type TransactionsPageResponse struct {
+	CursorInfo readable.CursorInfo              `json:"cursor_info"`
+	Txns       []readable.TransactionWithStatus `json:"txns"`
+}
+
+// TransactionsPageVerboseResponse is returned by GET /api/v2/transactions with a cursor and verbose=1
+type TransactionsPageVerboseResponse struct {
+	CursorInfo readable.CursorInfo                     `json:"cursor_info"`
+	Txns       []readable.TransactionWithStatusVerbose `json:"txns"`
+}
+
+// transactionsCursorPageV2 writes a page of confirmed transactions of /api/v2/transactions with cursor pagination
+func transactionsCursorPageV2(w http.ResponseWriter, r *http.Request, gateway Gatewayer, addrs []cipher.Address, verbose bool) {
+	if r.FormValue("page") != "" {
+		writeError400Response(w, "page and cursor cannot be combined")
+		return
+	}
+
+	if confirmedStr := r.FormValue("confirmed"); confirmedStr != "" {
+		confirmed, err := strconv.ParseBool(confirmedStr)
+		if err != nil {
+			writeError400Response(w, fmt.Sprintf("invalid 'confirmed' value: %v", err))
+			return
+		}
+
+		if !confirmed {
+			writeError400Response(w, "cursor pagination only returns confirmed transactions")
+			return
+		}
+	}
+
+	cursor, limit, order, err := parseCursorPageParams(r)
+	if err != nil {
+		writeError400Response(w, err.Error())
+		return
+	}
+
+	var resp HTTPResponse
+	if verbose {
+		txns, inputs, next, err := gateway.GetTransactionsPageWithInputs(addrs, cursor, order, limit)
+		if err != nil {
+			writeError500Response(w, err.Error())
+			return
+		}
+
+		rTxns, err := NewTransactionsWithStatusVerbose(txns, inputs)
+		if err != nil {
+			writeError500Response(w, err.Error())
+			return
+		}
+
+		resp.Data = TransactionsPageVerboseResponse{
+			CursorInfo: readable.NewCursorInfo(limit, next),
+			Txns:       rTxns.Transactions,
+		}
+	} else {
+		txns, next, err := gateway.GetTransactionsPage(addrs, cursor, order, limit)
+		if err != nil {
+			writeError500Response(w, err.Error())
+			return
+		}
+
+		rTxns, err := NewTransactionsWithStatus(txns)
+		if err != nil {
+			writeError500Response(w, err.Error())
+			return
+		}
+
+		resp.Data = TransactionsPageResponse{
+			CursorInfo: readable.NewCursorInfo(limit, next),
+			Txns:       rTxns.Transactions,
+		}
+	}
+
+	writeHTTPResponse(w, resp)
+}
+
 // InjectTransactionRequest is sent to POST /api/v1/injectTransaction
 type InjectTransactionRequest struct {
 	RawTxn      string `json:"rawtx"`
@@ -747,32 +833,28 @@ func newCreatedTransactionFuzzy(txn *coin.Transaction, inputs []visor.Transactio
 		return nil, errors.New("len(txn.In) != len(inputs)")
 	}
 
-	var outputHours uint64
+	var outputHours amount.Hours
 	var feeInvalid bool
 	for _, o := range txn.Out {
 		var err error
-		outputHours, err = mathutil.AddUint64(outputHours, o.Hours)
+		outputHours, err = outputHours.Add(amount.Hours(o.Hours))
 		if err != nil {
 			feeInvalid = true
 		}
 	}
 
-	var inputHours uint64
+	var inputHours amount.Hours
 	for _, i := range inputs {
 		var err error
-		inputHours, err = mathutil.AddUint64(inputHours, i.CalculatedHours)
+		inputHours, err = inputHours.Add(amount.Hours(i.CalculatedHours))
 		if err != nil {
 			feeInvalid = true
 		}
 	}
 
-	if inputHours < outputHours {
-		feeInvalid = true
-	}
-
-	var fee uint64
-	if !feeInvalid {
-		fee = inputHours - outputHours
+	fee, err := inputHours.Sub(outputHours)
+	if err != nil || feeInvalid {
+		fee = 0
 	}
 
 	sigs := make([]string, len(txn.Sigs))
@@ -814,7 +896,7 @@ func newCreatedTransactionFuzzy(txn *coin.Transaction, inputs []visor.Transactio
 		Type:      txn.Type,
 		TxID:      txID.Hex(),
 		InnerHash: txn.InnerHash.Hex(),
-		Fee:       fmt.Sprint(fee),
+		Fee:       fee.String(),
 
 		Sigs: sigs,
 		In:   in,
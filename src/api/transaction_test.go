@@ -26,6 +26,7 @@ import (
 	"github.com/skycoin/skycoin/src/readable"
 	"github.com/skycoin/skycoin/src/testutil"
 	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/historydb"
 )
 
 func createUnconfirmedTxn(t *testing.T) visor.UnconfirmedTransaction {
@@ -1592,6 +1593,178 @@ func TestTransactionsHandlerV2(t *testing.T) {
 	}
 }
 
+func TestTransactionsHandlerV2Cursor(t *testing.T) {
+	addrs := []cipher.Address{makeAddress(), makeAddress()}
+
+	var txns []visor.Transaction
+	var txnsInputs [][]visor.TransactionInput
+	for i := 0; i < 3; i++ {
+		txnAndInputs := prepareTxnAndInputs(t)
+		txns = append(txns, visor.Transaction{
+			Transaction: txnAndInputs.txn,
+			Status:      visor.TransactionStatus{Confirmed: true, BlockSeq: uint64(i + 100)},
+		})
+		txnsInputs = append(txnsInputs, txnAndInputs.inputs)
+	}
+
+	cursor := &visor.Cursor{
+		Position: historydb.Position{
+			BlockSeq: 100,
+			TxnIndex: 1,
+		},
+	}
+	next := &visor.Cursor{
+		Position: historydb.Position{
+			BlockSeq: 102,
+		},
+	}
+
+	tt := []struct {
+		name             string
+		args             []string
+		verbose          bool
+		gatewayAddrs     []cipher.Address
+		gatewayCursor    *visor.Cursor
+		gatewayOrder     visor.SortOrder
+		gatewayLimit     uint64
+		gatewayErr       error
+		next             *visor.Cursor
+		expectStatusCode int
+		expectErrMsg     string
+		expectCursorInfo readable.CursorInfo
+	}{
+		{
+			name:             "first page",
+			args:             []string{"cursor="},
+			gatewayOrder:     visor.AscOrder,
+			gatewayLimit:     visor.DefaultTxnPageSize,
+			expectStatusCode: 200,
+			expectCursorInfo: readable.CursorInfo{Limit: 10},
+		},
+		{
+			name:             "next page with addrs",
+			args:             []string{"cursor=" + cursor.String(), "addrs=" + addrs[0].String() + "," + addrs[1].String(), "limit=3", "sort=desc"},
+			gatewayAddrs:     addrs,
+			gatewayCursor:    cursor,
+			gatewayOrder:     visor.DescOrder,
+			gatewayLimit:     3,
+			next:             next,
+			expectStatusCode: 200,
+			expectCursorInfo: readable.CursorInfo{Limit: 3, NextCursor: next.String()},
+		},
+		{
+			name:             "verbose confirmed=1",
+			args:             []string{"cursor=" + cursor.String(), "verbose=1", "confirmed=1"},
+			verbose:          true,
+			gatewayCursor:    cursor,
+			gatewayOrder:     visor.AscOrder,
+			gatewayLimit:     visor.DefaultTxnPageSize,
+			next:             next,
+			expectStatusCode: 200,
+			expectCursorInfo: readable.CursorInfo{Limit: 10, NextCursor: next.String()},
+		},
+		{
+			name:             "invalid cursor",
+			args:             []string{"cursor=abc"},
+			expectStatusCode: 400,
+			expectErrMsg:     "invalid 'cursor' value: invalid cursor",
+		},
+		{
+			name:             "cursor with page",
+			args:             []string{"cursor=", "page=2"},
+			expectStatusCode: 400,
+			expectErrMsg:     "page and cursor cannot be combined",
+		},
+		{
+			name:             "cursor with confirmed=0",
+			args:             []string{"cursor=", "confirmed=0"},
+			expectStatusCode: 400,
+			expectErrMsg:     "cursor pagination only returns confirmed transactions",
+		},
+		{
+			name:             "limit too large",
+			args:             []string{"cursor=", "limit=101"},
+			expectStatusCode: 400,
+			expectErrMsg:     "transaction page size must be not greater than 100",
+		},
+		{
+			name:             "limit=0",
+			args:             []string{"cursor=", "limit=0"},
+			expectStatusCode: 400,
+			expectErrMsg:     "page size must be greater than 0",
+		},
+		{
+			name:             "invalid sort",
+			args:             []string{"cursor=", "sort=foo"},
+			expectStatusCode: 400,
+			expectErrMsg:     "invalid 'sort' value: Unknown sort order",
+		},
+		{
+			name:             "gateway error",
+			args:             []string{"cursor="},
+			gatewayOrder:     visor.AscOrder,
+			gatewayLimit:     visor.DefaultTxnPageSize,
+			gatewayErr:       errors.New("gateway error"),
+			expectStatusCode: 500,
+			expectErrMsg:     "gateway error",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint := "/api/v2/transactions?" + strings.Join(tc.args, "&")
+			req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+			require.NoError(t, err)
+
+			if tc.gatewayAddrs == nil {
+				tc.gatewayAddrs = []cipher.Address{}
+			}
+
+			gateway := &MockGatewayer{}
+			if tc.gatewayErr != nil {
+				gateway.On("GetTransactionsPage", tc.gatewayAddrs, tc.gatewayCursor, tc.gatewayOrder, tc.gatewayLimit).Return(nil, nil, tc.gatewayErr)
+			} else {
+				gateway.On("GetTransactionsPage", tc.gatewayAddrs, tc.gatewayCursor, tc.gatewayOrder, tc.gatewayLimit).Return(txns, tc.next, nil)
+				gateway.On("GetTransactionsPageWithInputs", tc.gatewayAddrs, tc.gatewayCursor, tc.gatewayOrder, tc.gatewayLimit).Return(txns, txnsInputs, tc.next, nil)
+			}
+
+			rec := httptest.NewRecorder()
+			srv := newServerMux(defaultMuxConfig(), gateway)
+			srv.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code, rec.Body.String())
+
+			var rsp ReceivedHTTPResponse
+			err = json.NewDecoder(rec.Body).Decode(&rsp)
+			require.NoError(t, err)
+			if rec.Code != http.StatusOK {
+				require.Equal(t, tc.expectErrMsg, rsp.Error.Message)
+				return
+			}
+
+			if tc.verbose {
+				expectTxns, err := NewTransactionsWithStatusVerbose(txns, txnsInputs)
+				require.NoError(t, err)
+
+				var txnRsp TransactionsPageVerboseResponse
+				err = json.Unmarshal(rsp.Data, &txnRsp)
+				require.NoError(t, err)
+				require.Equal(t, tc.expectCursorInfo, txnRsp.CursorInfo)
+				require.Equal(t, expectTxns.Transactions, txnRsp.Txns)
+			} else {
+				expectTxns, err := NewTransactionsWithStatus(txns)
+				require.NoError(t, err)
+
+				var txnRsp TransactionsPageResponse
+				err = json.Unmarshal(rsp.Data, &txnRsp)
+				require.NoError(t, err)
+				require.Equal(t, tc.expectCursorInfo, txnRsp.CursorInfo)
+				require.Equal(t, expectTxns.Transactions, txnRsp.Txns)
+			}
+		})
+	}
+}
+
 type transactionAndInputs struct {
 	txn    coin.Transaction
 	inputs []visor.TransactionInput
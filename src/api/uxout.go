@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/readable"
 	wh "github.com/skycoin/skycoin/src/util/http"
 )
@@ -71,7 +72,7 @@ func addrUxOutsHandler(gateway Gatewayer) http.HandlerFunc {
 			return
 		}
 
-		cipherAddr, err := cipher.DecodeBase58Address(addr)
+		cipherAddr, err := cipher.DecodeAddress(params.AddressHRP, addr)
 		if err != nil {
 			wh.Error400(w, err.Error())
 			return
@@ -96,3 +97,68 @@ func addrUxOutsHandler(gateway Gatewayer) http.HandlerFunc {
 		wh.SendJSONOr500(logger, w, ret)
 	}
 }
+
+// AddressUxOutsPageResponse is returned by GET /api/v2/address_uxouts
+type AddressUxOutsPageResponse struct {
+	CursorInfo readable.CursorInfo    `json:"cursor_info"`
+	UxOuts     []readable.SpentOutput `json:"uxouts"`
+}
+
+// URI: /api/v2/address_uxouts
+// Method: GET
+// Args:
+//	address: address [required]
+//	cursor: cursor returned with the previous page, empty for the first page
+//	limit: number of outputs per page [optional, default to 10, must be <= 100]
+//	sort: "asc" or "desc" [optional, default to "asc"]
+// Returns a page of the outputs received by an address, spent or unspent,
+// ordered by the block seq, transaction index and output index of their creation
+func addrUxOutsHandlerV2(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError405Response(w)
+			return
+		}
+
+		addr := r.FormValue("address")
+		if addr == "" {
+			writeError400Response(w, "address is empty")
+			return
+		}
+
+		cipherAddr, err := cipher.DecodeAddress(params.AddressHRP, addr)
+		if err != nil {
+			writeError400Response(w, err.Error())
+			return
+		}
+
+		cursor, limit, order, err := parseCursorPageParams(r)
+		if err != nil {
+			writeError400Response(w, err.Error())
+			return
+		}
+
+		uxs, next, headTime, err := gateway.GetAddressUxOutsPage(cipherAddr, cursor, order, limit)
+		if err != nil {
+			writeError500Response(w, err.Error())
+			return
+		}
+
+		outs, err := readable.NewSpentOutputs(uxs, headTime)
+		if err != nil {
+			writeError500Response(w, err.Error())
+			return
+		}
+
+		if outs == nil {
+			outs = []readable.SpentOutput{}
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: AddressUxOutsPageResponse{
+				CursorInfo: readable.NewCursorInfo(limit, next),
+				UxOuts:     outs,
+			},
+		})
+	}
+}
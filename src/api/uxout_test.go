@@ -17,6 +17,7 @@ import (
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/readable"
 	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/visor"
 	"github.com/skycoin/skycoin/src/visor/historydb"
 )
 
@@ -355,3 +356,141 @@ func TestGetAddrUxOuts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAddrUxOutsV2(t *testing.T) {
+	headTime := uint64(time.Now().UTC().Unix())
+	uxout, seckey := makeUxOutWithSecret(t)
+	addr := cipher.MustAddressFromSecKey(seckey)
+
+	txnHash := cipher.SumSHA256(cipher.RandByte(10))
+	uxOuts := []historydb.UxOut{
+		{Out: uxout},
+		{Out: uxout, SpentTxnID: txnHash, SpentBlockSeq: 100},
+	}
+	rUxOuts, err := readable.NewSpentOutputs(uxOuts, headTime)
+	require.NoError(t, err)
+
+	cursor := &visor.Cursor{
+		Position: historydb.Position{
+			BlockSeq: 10,
+			TxnIndex: 2,
+			OutIndex: 1,
+		},
+	}
+
+	tt := []struct {
+		name          string
+		method        string
+		args          url.Values
+		status        int
+		err           string
+		gatewayCursor *visor.Cursor
+		gatewayOrder  visor.SortOrder
+		gatewayLimit  uint64
+		gatewayUxOuts []historydb.UxOut
+		gatewayNext   *visor.Cursor
+		gatewayErr    error
+		httpResponse  AddressUxOutsPageResponse
+	}{
+		{
+			name:   "405",
+			method: http.MethodPost,
+			status: http.StatusMethodNotAllowed,
+			err:    "Method Not Allowed",
+		},
+		{
+			name:   "400 - address is empty",
+			method: http.MethodGet,
+			status: http.StatusBadRequest,
+			err:    "address is empty",
+		},
+		{
+			name:   "400 - invalid address",
+			method: http.MethodGet,
+			args:   url.Values{"address": {"abcd"}},
+			status: http.StatusBadRequest,
+			err:    "Invalid address length",
+		},
+		{
+			name:   "400 - invalid cursor",
+			method: http.MethodGet,
+			args:   url.Values{"address": {addr.String()}, "cursor": {"abcd"}},
+			status: http.StatusBadRequest,
+			err:    "invalid 'cursor' value: invalid cursor",
+		},
+		{
+			name:         "500 - gateway error",
+			method:       http.MethodGet,
+			args:         url.Values{"address": {addr.String()}},
+			status:       http.StatusInternalServerError,
+			err:          "gateway error",
+			gatewayOrder: visor.AscOrder,
+			gatewayLimit: visor.DefaultTxnPageSize,
+			gatewayErr:   errors.New("gateway error"),
+		},
+		{
+			name:         "200 - no outputs",
+			method:       http.MethodGet,
+			args:         url.Values{"address": {addr.String()}},
+			status:       http.StatusOK,
+			gatewayOrder: visor.AscOrder,
+			gatewayLimit: visor.DefaultTxnPageSize,
+			httpResponse: AddressUxOutsPageResponse{
+				CursorInfo: readable.CursorInfo{Limit: 10},
+				UxOuts:     []readable.SpentOutput{},
+			},
+		},
+		{
+			name:          "200 - next page",
+			method:        http.MethodGet,
+			args:          url.Values{"address": {addr.String()}, "cursor": {cursor.String()}, "limit": {"2"}, "sort": {"desc"}},
+			status:        http.StatusOK,
+			gatewayCursor: cursor,
+			gatewayOrder:  visor.DescOrder,
+			gatewayLimit:  2,
+			gatewayUxOuts: uxOuts,
+			gatewayNext:   cursor,
+			httpResponse: AddressUxOutsPageResponse{
+				CursorInfo: readable.CursorInfo{Limit: 2, NextCursor: cursor.String()},
+				UxOuts:     rUxOuts,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetAddressUxOutsPage", addr, tc.gatewayCursor, tc.gatewayOrder, tc.gatewayLimit).Return(tc.gatewayUxOuts, tc.gatewayNext, headTime, tc.gatewayErr)
+
+			endpoint := "/api/v2/address_uxouts"
+			if len(tc.args) > 0 {
+				endpoint += "?" + tc.args.Encode()
+			}
+
+			req, err := http.NewRequest(tc.method, endpoint, nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+			setCSRFParameters(t, tokenValid, req)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var rsp ReceivedHTTPResponse
+			err = json.NewDecoder(rr.Body).Decode(&rsp)
+			require.NoError(t, err)
+
+			if rr.Code != http.StatusOK {
+				require.Equal(t, tc.err, rsp.Error.Message)
+				return
+			}
+
+			var page AddressUxOutsPageResponse
+			err = json.Unmarshal(rsp.Data, &page)
+			require.NoError(t, err)
+			require.Equal(t, tc.httpResponse, page)
+		})
+	}
+}
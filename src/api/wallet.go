@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 
+	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/cipher/bip39"
 	"github.com/skycoin/skycoin/src/cipher/bip44"
 	"github.com/skycoin/skycoin/src/readable"
@@ -60,11 +61,13 @@ func NewWalletResponse(w wallet.Wallet) (*WalletResponse, error) {
 			return nil, errors.New("Wallet has no Bip44Coin meta data")
 		}
 		wr.Meta.Bip44Coin = bip44Coin
+		wr.Meta.Descriptor = w.Descriptor()
 
 		// get entries on both external and change chains
 		options = append(options, wallet.OptionExternal(), wallet.OptionChange())
 	case wallet.WalletTypeXPub:
 		wr.Meta.XPub = w.XPub()
+		wr.Meta.Descriptor = w.Descriptor()
 	}
 
 	entries, err := w.GetEntries(options...)
@@ -169,33 +172,42 @@ func balanceHandler(gateway Gatewayer) http.HandlerFunc {
 			return
 		}
 
-		// create map of address to balance
-		addressBalances := make(readable.AddressBalances, len(addrs))
-		for idx, addr := range addrs {
-			addressBalances[addr.String()] = readable.NewBalancePair(bals[idx])
+		resp, err := newBalanceResponse(addrs, bals)
+		if err != nil {
+			wh.Error500(w, err.Error())
+			return
 		}
 
-		var balance wallet.BalancePair
-		for _, bal := range bals {
-			var err error
-			balance.Confirmed, err = balance.Confirmed.Add(bal.Confirmed)
-			if err != nil {
-				wh.Error500(w, err.Error())
-				return
-			}
+		wh.SendJSONOr500(logger, w, resp)
+	}
+}
 
-			balance.Predicted, err = balance.Predicted.Add(bal.Predicted)
-			if err != nil {
-				wh.Error500(w, err.Error())
-				return
-			}
+// newBalanceResponse creates a BalanceResponse from the balances of addresses
+func newBalanceResponse(addrs []cipher.Address, bals []wallet.BalancePair) (*BalanceResponse, error) {
+	// create map of address to balance
+	addressBalances := make(readable.AddressBalances, len(addrs))
+	for idx, addr := range addrs {
+		addressBalances[addr.String()] = readable.NewBalancePair(bals[idx])
+	}
+
+	var balance wallet.BalancePair
+	for _, bal := range bals {
+		var err error
+		balance.Confirmed, err = balance.Confirmed.Add(bal.Confirmed)
+		if err != nil {
+			return nil, err
 		}
 
-		wh.SendJSONOr500(logger, w, BalanceResponse{
-			BalancePair: readable.NewBalancePair(balance),
-			Addresses:   addressBalances,
-		})
+		balance.Predicted, err = balance.Predicted.Add(bal.Predicted)
+		if err != nil {
+			return nil, err
+		}
 	}
+
+	return &BalanceResponse{
+		BalancePair: readable.NewBalancePair(balance),
+		Addresses:   addressBalances,
+	}, nil
 }
 
 // Loads wallet from seed, will scan ahead N address and
@@ -207,7 +219,8 @@ func balanceHandler(gateway Gatewayer) http.HandlerFunc {
 //     seed-passphrase: wallet seed passphrase [optional, bip44 type wallet only]
 //     type: wallet type [required, one of "deterministic", "bip44" or "xpub"]
 //     bip44-coin: BIP44 coin type [optional, defaults to 8000 (skycoin's coin type), only valid if type is "bip44"]
-//     xpub: xpub key [required for xpub wallets]
+//     xpub: xpub key [required for xpub wallets, unless descriptor is provided]
+//     descriptor: output descriptor [optional, bip44 and xpub type wallets only]
 //     label: wallet label [required]
 //     scan: the number of addresses to scan ahead for balances [optional, must be > 0]
 //     encrypt: bool value, whether encrypt the wallet [optional]
@@ -310,6 +323,7 @@ func walletCreateHandler(gateway Gatewayer) http.HandlerFunc {
 			SeedPassphrase: r.FormValue("seed-passphrase"),
 			Bip44Coin:      bip44Coin,
 			XPub:           r.FormValue("xpub"),
+			Descriptor:     r.FormValue("descriptor"),
 			TF:             gateway.TransactionsFinder(),
 		})
 		if err != nil {
@@ -699,6 +713,7 @@ func walletFolderHandler(s Walleter) http.HandlerFunc {
 // Method: GET
 // Args:
 //     entropy: entropy bitsize [optional, default value of 128 will be used if not set]
+//     language: language of the mnemonic [optional, default value of english will be used if not set]
 func newSeedHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -723,6 +738,15 @@ func newSeedHandler() http.HandlerFunc {
 			return
 		}
 
+		language := bip39.DefaultLanguage
+		if v := r.FormValue("language"); v != "" {
+			language, err = bip39.ParseLanguage(v)
+			if err != nil {
+				wh.Error400(w, "invalid language")
+				return
+			}
+		}
+
 		entropy, err := bip39.NewEntropy(entropyBits)
 		if err != nil {
 			err = fmt.Errorf("bip39.NewEntropy failed: %v", err)
@@ -730,9 +754,9 @@ func newSeedHandler() http.HandlerFunc {
 			return
 		}
 
-		mnemonic, err := bip39.NewMnemonic(entropy)
+		mnemonic, err := bip39.NewMnemonicInLanguage(entropy, language)
 		if err != nil {
-			err = fmt.Errorf("bip39.NewDefaultMnemonic failed: %v", err)
+			err = fmt.Errorf("bip39.NewMnemonicInLanguage failed: %v", err)
 			wh.Error500(w, err.Error())
 			return
 		}
@@ -807,6 +831,12 @@ type VerifySeedRequest struct {
 	Seed string `json:"seed"`
 }
 
+// VerifySeedResponse is returned by POST /api/v2/wallet/seed/verify
+type VerifySeedResponse struct {
+	// Language is the detected language of the seed
+	Language string `json:"language"`
+}
+
 // walletVerifySeedHandler verifies a wallet seed
 // Method: POST
 // URI: /api/v2/wallet/seed/verify
@@ -836,7 +866,18 @@ func walletVerifySeedHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeHTTPResponse(w, HTTPResponse{Data: struct{}{}})
+	language, err := bip39.DetectLanguage(req.Seed)
+	if err != nil {
+		resp := NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	writeHTTPResponse(w, HTTPResponse{
+		Data: VerifySeedResponse{
+			Language: string(language),
+		},
+	})
 }
 
 // Unloads wallet from the wallet service
@@ -1059,3 +1100,208 @@ func walletRecoverHandler(gateway Gatewayer) http.HandlerFunc {
 		})
 	}
 }
+
+// WalletDescriptorRequest is the request data for POST /api/v2/wallet/descriptor
+type WalletDescriptorRequest struct {
+	ID       string `json:"id"`
+	Password string `json:"password"`
+}
+
+// WalletDescriptorResponse is returned by POST /api/v2/wallet/descriptor
+type WalletDescriptorResponse struct {
+	Descriptor string `json:"descriptor"`
+}
+
+// URI: /api/v2/wallet/descriptor
+// Method: POST
+// Args:
+//  id: wallet id
+//  password: [optional] wallet password
+// Returns the output descriptor of a bip44 or xpub wallet.
+// The descriptor of a bip44 wallet created without a descriptor is derived from
+// its seed, in which case the password of an encrypted wallet is required.
+func walletDescriptorHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		var req WalletDescriptorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			resp := NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		if req.ID == "" {
+			resp := NewHTTPErrorResponse(http.StatusBadRequest, "id is required")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		var password []byte
+		if req.Password != "" {
+			password = []byte(req.Password)
+		}
+
+		defer func() {
+			req.Password = ""
+			password = nil
+		}()
+
+		desc, err := gateway.GetWalletDescriptor(req.ID, password)
+		if err != nil {
+			var resp HTTPResponse
+			switch err.(type) {
+			case wallet.Error:
+				switch err {
+				case wallet.ErrWalletNotExist:
+					resp = NewHTTPErrorResponse(http.StatusNotFound, "")
+				case wallet.ErrWalletAPIDisabled:
+					resp = NewHTTPErrorResponse(http.StatusForbidden, "")
+				default:
+					resp = NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+				}
+			default:
+				resp = NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+			}
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: WalletDescriptorResponse{
+				Descriptor: desc,
+			},
+		})
+	}
+}
+
+// WalletOutputsMetaRequest is the request data for POST /api/v2/wallet/outputs/meta
+type WalletOutputsMetaRequest struct {
+	ID     string   `json:"id"`
+	Hashes []string `json:"hashes"`
+	// Fields that are not set are left unchanged
+	Frozen *bool   `json:"frozen,omitempty"`
+	Label  *string `json:"label,omitempty"`
+	// Tags replaces the existing tags if not null, an empty list clears them
+	Tags []string `json:"tags"`
+}
+
+// Dispatches /wallet/outputs/meta endpoint.
+// Method: GET, POST
+// URI: /api/v2/wallet/outputs/meta
+func walletOutputsMetaHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getWalletOutputsMetaHandler(w, r, gateway)
+		case http.MethodPost:
+			updateWalletOutputsMetaHandler(w, r, gateway)
+		default:
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+		}
+	}
+}
+
+// Returns the coin control metadata (frozen, label, tags) of a wallet's unspent outputs
+// Args:
+//
+//	id: wallet id [required]
+func getWalletOutputsMetaHandler(w http.ResponseWriter, r *http.Request, gateway Gatewayer) {
+	wltID := r.FormValue("id")
+	if wltID == "" {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, "id is required")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	m, err := gateway.GetUxOutsMeta(wltID)
+	if err != nil {
+		writeHTTPResponse(w, walletOutputsMetaErrorResponse(err))
+		return
+	}
+
+	writeHTTPResponse(w, HTTPResponse{
+		Data: readable.NewUxOutsMeta(m),
+	})
+}
+
+// Updates the coin control metadata of a wallet's unspent outputs, returns the updated metadata
+// Args:
+//
+//	id: wallet id [required]
+//	hashes: unspent output hashes [required]
+//	frozen: freeze or unfreeze the outputs [optional]
+//	label: output label [optional]
+//	tags: output tags, replaces the existing tags [optional]
+func updateWalletOutputsMetaHandler(w http.ResponseWriter, r *http.Request, gateway Gatewayer) {
+	var req WalletOutputsMetaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	if req.ID == "" {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, "id is required")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	if len(req.Hashes) == 0 {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, "hashes is required")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	hashes := make([]cipher.SHA256, len(req.Hashes))
+	for i, h := range req.Hashes {
+		var err error
+		hashes[i], err = cipher.SHA256FromHex(h)
+		if err != nil {
+			resp := NewHTTPErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid hash %q: %v", h, err))
+			writeHTTPResponse(w, resp)
+			return
+		}
+	}
+
+	m, err := gateway.UpdateWalletUxOutsMeta(req.ID, hashes, func(meta *wallet.UxOutMeta) {
+		if req.Frozen != nil {
+			meta.Frozen = *req.Frozen
+		}
+		if req.Label != nil {
+			meta.Label = *req.Label
+		}
+		if req.Tags != nil {
+			meta.Tags = append([]string(nil), req.Tags...)
+		}
+	})
+	if err != nil {
+		writeHTTPResponse(w, walletOutputsMetaErrorResponse(err))
+		return
+	}
+
+	writeHTTPResponse(w, HTTPResponse{
+		Data: readable.NewUxOutsMeta(m),
+	})
+}
+
+func walletOutputsMetaErrorResponse(err error) HTTPResponse {
+	switch err.(type) {
+	case wallet.Error:
+		switch err {
+		case wallet.ErrWalletNotExist:
+			return NewHTTPErrorResponse(http.StatusNotFound, "")
+		case wallet.ErrWalletAPIDisabled:
+			return NewHTTPErrorResponse(http.StatusForbidden, "")
+		default:
+			return NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		}
+	default:
+		return NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+	}
+}
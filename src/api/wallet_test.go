@@ -14,6 +14,7 @@ import (
 
 	"encoding/json"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/skycoin/skycoin/src/cipher"
@@ -882,6 +883,7 @@ func TestWalletCreateHandler(t *testing.T) {
 		SeedPassphrase string
 		Bip44Coin      string
 		XPub           string
+		Descriptor     string
 	}
 	tt := []struct {
 		name                      string
@@ -1196,6 +1198,47 @@ func TestWalletCreateHandler(t *testing.T) {
 				Entries: responseEntries[:],
 			},
 		},
+		{
+			name:   "200 - OK - xpub descriptor",
+			method: http.MethodPost,
+			body: &httpBody{
+				Type:       wallet.WalletTypeXPub,
+				Label:      "bar",
+				ScanN:      "2",
+				Descriptor: "pkh(xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8/*)",
+			},
+			status:  http.StatusOK,
+			err:     "",
+			wltName: "filename",
+			options: wallet.Options{
+				Type:       wallet.WalletTypeXPub,
+				Label:      "bar",
+				Password:   []byte{},
+				ScanN:      2,
+				Descriptor: "pkh(xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8/*)",
+			},
+			gatewayCreateWalletResult: func(_ string, _ wallet.Options) wallet.Wallet {
+				w, err := deterministic.NewWallet(
+					"filename",
+					"",
+					"seed",
+					wallet.OptionGenerateN(5),
+				)
+				require.NoError(t, err)
+				w.SetTimestamp(0)
+				return w
+			},
+			responseBody: WalletResponse{
+				Meta: readable.WalletMeta{
+					Coin:       "skycoin",
+					Filename:   "filename",
+					Type:       "deterministic",
+					Version:    "0.4",
+					CryptoType: "scrypt-chacha20poly1305",
+				},
+				Entries: responseEntries[:],
+			},
+		},
 		// CSRF Tests
 		{
 			name:   "200 - OK - CSRF disabled",
@@ -1333,6 +1376,10 @@ func TestWalletCreateHandler(t *testing.T) {
 				if tc.body.XPub != "" {
 					v.Add("xpub", tc.body.XPub)
 				}
+
+				if tc.body.Descriptor != "" {
+					v.Add("descriptor", tc.body.Descriptor)
+				}
 			}
 
 			req, err := http.NewRequest(tc.method, endpoint, strings.NewReader(v.Encode()))
@@ -1372,7 +1419,8 @@ func TestWalletCreateHandler(t *testing.T) {
 
 func TestWalletNewSeed(t *testing.T) {
 	type httpBody struct {
-		Entropy string
+		Entropy  string
+		Language string
 	}
 	tt := []struct {
 		name      string
@@ -1382,6 +1430,7 @@ func TestWalletNewSeed(t *testing.T) {
 		err       string
 		entropy   string
 		resultLen int
+		language  bip39.Language
 	}{
 		{
 			name:   "405",
@@ -1409,6 +1458,15 @@ func TestWalletNewSeed(t *testing.T) {
 			err:     "400 Bad Request - entropy length must be 128 or 256",
 			entropy: "200",
 		},
+		{
+			name:   "400 - invalid language",
+			method: http.MethodGet,
+			body: &httpBody{
+				Language: "klingon",
+			},
+			status: http.StatusBadRequest,
+			err:    "400 Bad Request - invalid language",
+		},
 		{
 			name:      "200 - OK with no entropy",
 			method:    http.MethodGet,
@@ -1437,6 +1495,29 @@ func TestWalletNewSeed(t *testing.T) {
 			entropy:   "256",
 			resultLen: 24,
 		},
+		{
+			name:   "200 - OK | japanese seed",
+			method: http.MethodGet,
+			body: &httpBody{
+				Entropy:  "256",
+				Language: "japanese",
+			},
+			status:    http.StatusOK,
+			entropy:   "256",
+			resultLen: 24,
+			language:  bip39.Japanese,
+		},
+		{
+			name:   "200 - OK | spanish seed",
+			method: http.MethodGet,
+			body: &httpBody{
+				Language: "Spanish",
+			},
+			status:    http.StatusOK,
+			entropy:   "128",
+			resultLen: 12,
+			language:  bip39.Spanish,
+		},
 	}
 
 	// Loop over each test case
@@ -1452,6 +1533,9 @@ func TestWalletNewSeed(t *testing.T) {
 				if tc.body.Entropy != "" {
 					v.Add("entropy", tc.body.Entropy)
 				}
+				if tc.body.Language != "" {
+					v.Add("language", tc.body.Language)
+				}
 			}
 			if len(v) > 0 {
 				endpoint += "?" + v.Encode()
@@ -1487,6 +1571,14 @@ func TestWalletNewSeed(t *testing.T) {
 				require.NoError(t, err)
 				// check that expected length is equal to response length
 				require.Equal(t, tc.resultLen, len(strings.Fields(msg.Seed)), tc.name)
+
+				language := tc.language
+				if language == "" {
+					language = bip39.English
+				}
+				detected, err := bip39.DetectLanguage(msg.Seed)
+				require.NoError(t, err)
+				require.Equal(t, language, detected)
 			}
 		})
 	}
@@ -1549,7 +1641,26 @@ func TestVerifySeed(t *testing.T) {
 			httpBody: toJSON(t, VerifySeedRequest{
 				Seed: "chief stadium sniff exhibit ostrich exit fruit noodle good lava coin supply",
 			}),
-			httpResponse: HTTPResponse{Data: struct{}{}},
+			httpResponse: HTTPResponse{Data: VerifySeedResponse{Language: "english"}},
+		},
+		{
+			name:   "200 - japanese",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			httpBody: toJSON(t, VerifySeedRequest{
+				Seed: "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+			}),
+			httpResponse: HTTPResponse{Data: VerifySeedResponse{Language: "japanese"}},
+		},
+		{
+			name:        "422 - Invalid checksum",
+			method:      http.MethodPost,
+			contentType: ContentTypeJSON,
+			status:      http.StatusUnprocessableEntity,
+			httpBody: toJSON(t, VerifySeedRequest{
+				Seed: "chief stadium sniff exhibit ostrich exit fruit noodle good coin coin supply",
+			}),
+			httpResponse: NewHTTPErrorResponse(http.StatusUnprocessableEntity, bip39.ErrChecksumIncorrect.Error()),
 		},
 		{
 			name:   "200 - csrf disabled",
@@ -1558,7 +1669,7 @@ func TestVerifySeed(t *testing.T) {
 			httpBody: toJSON(t, VerifySeedRequest{
 				Seed: "chief stadium sniff exhibit ostrich exit fruit noodle good lava coin supply",
 			}),
-			httpResponse: HTTPResponse{Data: struct{}{}},
+			httpResponse: HTTPResponse{Data: VerifySeedResponse{Language: "english"}},
 			csrfDisabled: true,
 		},
 	}
@@ -1606,11 +1717,11 @@ func TestVerifySeed(t *testing.T) {
 			} else {
 				require.NotNil(t, tc.httpResponse.Data)
 
-				var addrRsp struct{}
-				err := json.Unmarshal(rsp.Data, &addrRsp)
+				var seedRsp VerifySeedResponse
+				err := json.Unmarshal(rsp.Data, &seedRsp)
 				require.NoError(t, err)
 
-				require.Equal(t, tc.httpResponse.Data, addrRsp)
+				require.Equal(t, tc.httpResponse.Data, seedRsp)
 			}
 
 		})
@@ -3236,3 +3347,367 @@ func TestWalletRecover(t *testing.T) {
 		})
 	}
 }
+
+func TestWalletDescriptor(t *testing.T) {
+	desc := "pkh([9599ae00/44'/8000'/0']xpub6DFYZEYzFwhPbgUqKvL7SzkaQWg743C3raLHe1f2Q5kdWdKoDjsKKQumxpfLQW1TA9SPY762P1dQGux3rBN63BP91NormooHGAs8rQXY6f5/<0;1>/*)#cvv67n3t"
+
+	cases := []struct {
+		name          string
+		method        string
+		status        int
+		req           *WalletDescriptorRequest
+		httpBody      string
+		httpResponse  HTTPResponse
+		gatewayReturn string
+		gatewayErr    error
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodGet,
+			status:       http.StatusMethodNotAllowed,
+			httpBody:     toJSON(t, WalletDescriptorRequest{}),
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "empty json body",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			httpBody:     "",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "EOF"),
+		},
+		{
+			name:         "id missing",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &WalletDescriptorRequest{},
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "id is required"),
+		},
+		{
+			name:   "wallet type without descriptor",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			req: &WalletDescriptorRequest{
+				ID: "foo",
+			},
+			gatewayErr:   wallet.ErrWalletDescriptor,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, wallet.ErrWalletDescriptor.Error()),
+		},
+		{
+			name:   "missing password",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			req: &WalletDescriptorRequest{
+				ID: "foo",
+			},
+			gatewayErr:   wallet.ErrMissingPassword,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, wallet.ErrMissingPassword.Error()),
+		},
+		{
+			name:   "wallet does not exist",
+			method: http.MethodPost,
+			status: http.StatusNotFound,
+			req: &WalletDescriptorRequest{
+				ID: "foo",
+			},
+			gatewayErr:   wallet.ErrWalletNotExist,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, "Not Found"),
+		},
+		{
+			name:   "wallet api disabled",
+			method: http.MethodPost,
+			status: http.StatusForbidden,
+			req: &WalletDescriptorRequest{
+				ID: "foo",
+			},
+			gatewayErr:   wallet.ErrWalletAPIDisabled,
+			httpResponse: NewHTTPErrorResponse(http.StatusForbidden, ""),
+		},
+		{
+			name:   "wallet other error",
+			method: http.MethodPost,
+			status: http.StatusInternalServerError,
+			req: &WalletDescriptorRequest{
+				ID: "foo",
+			},
+			gatewayErr:   errors.New("wallet error"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "wallet error"),
+		},
+		{
+			name:   "ok",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			req: &WalletDescriptorRequest{
+				ID: "foo",
+			},
+			gatewayReturn: desc,
+			httpResponse: HTTPResponse{
+				Data: WalletDescriptorResponse{
+					Descriptor: desc,
+				},
+			},
+		},
+		{
+			name:   "ok, password",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			req: &WalletDescriptorRequest{
+				ID:       "foo",
+				Password: "foopassword",
+			},
+			gatewayReturn: desc,
+			httpResponse: HTTPResponse{
+				Data: WalletDescriptorResponse{
+					Descriptor: desc,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			if tc.req != nil {
+				var password []byte
+				if tc.req.Password != "" {
+					password = []byte(tc.req.Password)
+				}
+				gateway.On("GetWalletDescriptor", tc.req.ID, password).Return(tc.gatewayReturn, tc.gatewayErr)
+			}
+
+			if tc.httpBody == "" && tc.req != nil {
+				tc.httpBody = toJSON(t, tc.req)
+			}
+
+			endpoint := "/api/v2/wallet/descriptor"
+			req, err := http.NewRequest(tc.method, endpoint, strings.NewReader(tc.httpBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			setCSRFParameters(t, tokenValid, req)
+
+			rr := httptest.NewRecorder()
+
+			cfg := defaultMuxConfig()
+			cfg.disableCSRF = false
+
+			handler := newServerMux(cfg, gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+			} else {
+				require.NotNil(t, tc.httpResponse.Data)
+
+				var descRsp WalletDescriptorResponse
+				err := json.Unmarshal(rsp.Data, &descRsp)
+				require.NoError(t, err)
+
+				require.Equal(t, tc.httpResponse.Data.(WalletDescriptorResponse), descRsp)
+			}
+		})
+	}
+}
+
+func TestWalletOutputsMeta(t *testing.T) {
+	hash := testutil.RandSHA256(t)
+	frozen := true
+	label := "cold storage"
+
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		query        string
+		req          *WalletOutputsMetaRequest
+		httpBody     string
+		getMeta      wallet.UxOutsMeta
+		gatewayErr   error
+		httpResponse HTTPResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodDelete,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "get id missing",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "id is required"),
+		},
+		{
+			name:         "get wallet does not exist",
+			method:       http.MethodGet,
+			status:       http.StatusNotFound,
+			query:        "foo",
+			gatewayErr:   wallet.ErrWalletNotExist,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, ""),
+		},
+		{
+			name:         "get wallet api disabled",
+			method:       http.MethodGet,
+			status:       http.StatusForbidden,
+			query:        "foo",
+			gatewayErr:   wallet.ErrWalletAPIDisabled,
+			httpResponse: NewHTTPErrorResponse(http.StatusForbidden, ""),
+		},
+		{
+			name:   "get ok",
+			method: http.MethodGet,
+			status: http.StatusOK,
+			query:  "foo",
+			getMeta: wallet.UxOutsMeta{
+				hash: {Frozen: true, Tags: []string{"a"}},
+			},
+			httpResponse: HTTPResponse{
+				Data: readable.UxOutsMeta{
+					hash.Hex(): {Frozen: true, Tags: []string{"a"}},
+				},
+			},
+		},
+		{
+			name:         "post empty json body",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			httpBody:     "",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "EOF"),
+		},
+		{
+			name:   "post id missing",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			req: &WalletOutputsMetaRequest{
+				Hashes: []string{hash.Hex()},
+			},
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "id is required"),
+		},
+		{
+			name:   "post hashes missing",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			req: &WalletOutputsMetaRequest{
+				ID: "foo",
+			},
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "hashes is required"),
+		},
+		{
+			name:   "post invalid hash",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			req: &WalletOutputsMetaRequest{
+				ID:     "foo",
+				Hashes: []string{"abc"},
+			},
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, `invalid hash "abc": encoding/hex: odd length hex string`),
+		},
+		{
+			name:   "post invalid tag",
+			method: http.MethodPost,
+			status: http.StatusBadRequest,
+			req: &WalletOutputsMetaRequest{
+				ID:     "foo",
+				Hashes: []string{hash.Hex()},
+				Tags:   []string{""},
+			},
+			gatewayErr:   wallet.ErrInvalidUxOutTag,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, wallet.ErrInvalidUxOutTag.Error()),
+		},
+		{
+			name:   "post other error",
+			method: http.MethodPost,
+			status: http.StatusInternalServerError,
+			req: &WalletOutputsMetaRequest{
+				ID:     "foo",
+				Hashes: []string{hash.Hex()},
+				Frozen: &frozen,
+			},
+			gatewayErr:   errors.New("wallet error"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "wallet error"),
+		},
+		{
+			name:   "post ok",
+			method: http.MethodPost,
+			status: http.StatusOK,
+			req: &WalletOutputsMetaRequest{
+				ID:     "foo",
+				Hashes: []string{hash.Hex()},
+				Frozen: &frozen,
+				Label:  &label,
+			},
+			httpResponse: HTTPResponse{
+				Data: readable.UxOutsMeta{
+					hash.Hex(): {Frozen: true, Label: label, Tags: []string{}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetUxOutsMeta", tc.query).Return(tc.getMeta, tc.gatewayErr)
+			if tc.req != nil {
+				gateway.On("UpdateWalletUxOutsMeta", tc.req.ID, mock.Anything, mock.Anything).Return(
+					func(_ string, hashes []cipher.SHA256, f func(*wallet.UxOutMeta)) wallet.UxOutsMeta {
+						if tc.gatewayErr != nil {
+							return nil
+						}
+						m := make(wallet.UxOutsMeta, len(hashes))
+						for _, h := range hashes {
+							var meta wallet.UxOutMeta
+							f(&meta)
+							m[h] = meta
+						}
+						return m
+					}, tc.gatewayErr)
+			}
+
+			if tc.httpBody == "" && tc.req != nil {
+				tc.httpBody = toJSON(t, tc.req)
+			}
+
+			endpoint := "/api/v2/wallet/outputs/meta"
+			if tc.query != "" {
+				endpoint += "?id=" + tc.query
+			}
+			req, err := http.NewRequest(tc.method, endpoint, strings.NewReader(tc.httpBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+			} else {
+				require.NotNil(t, tc.httpResponse.Data)
+
+				var metaRsp readable.UxOutsMeta
+				err := json.Unmarshal(rsp.Data, &metaRsp)
+				require.NoError(t, err)
+
+				require.Equal(t, tc.httpResponse.Data.(readable.UxOutsMeta), metaRsp)
+			}
+		})
+	}
+}
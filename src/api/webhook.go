@@ -0,0 +1,313 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/webhook"
+)
+
+// webhookRequest is the request data for POST /api/v2/webhook
+type webhookRequest struct {
+	URL           string   `json:"url"`
+	Event         string   `json:"event"`
+	Secret        string   `json:"secret"`
+	Addresses     []string `json:"addresses"`
+	TxID          string   `json:"txid"`
+	Confirmations uint64   `json:"confirmations"`
+}
+
+// Request converts webhookRequest to webhook.Request
+func (r webhookRequest) Request() (*webhook.Request, error) {
+	if r.URL == "" {
+		return nil, errors.New("missing url")
+	}
+
+	if r.Event == "" {
+		return nil, errors.New("missing event")
+	}
+
+	req := webhook.Request{
+		URL:           r.URL,
+		Event:         webhook.EventType(r.Event),
+		Secret:        r.Secret,
+		Confirmations: r.Confirmations,
+	}
+
+	for i, a := range r.Addresses {
+		addr, err := cipher.DecodeAddress(params.AddressHRP, a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address at index %d: %v", i, err)
+		}
+		req.Addresses = append(req.Addresses, addr)
+	}
+
+	if r.TxID != "" {
+		txid, err := cipher.SHA256FromHex(r.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid txid: %v", err)
+		}
+		req.TxID = txid
+	}
+
+	return &req, nil
+}
+
+// Webhook is a registered webhook
+type Webhook struct {
+	ID     string `json:"id"`
+	URL    string `json:"url"`
+	Event  string `json:"event"`
+	Status string `json:"status"`
+	// Secret is only returned when the webhook is created
+	Secret        string    `json:"secret,omitempty"`
+	Addresses     []string  `json:"addresses,omitempty"`
+	TxID          string    `json:"txid,omitempty"`
+	Confirmations uint64    `json:"confirmations,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NewWebhook creates a Webhook from a webhook.Webhook. The secret is not included.
+func NewWebhook(w webhook.Webhook) Webhook {
+	return Webhook{
+		ID:            w.ID,
+		URL:           w.URL,
+		Event:         string(w.Event),
+		Status:        string(w.Status),
+		Addresses:     w.Addresses,
+		TxID:          w.TxID,
+		Confirmations: w.Confirmations,
+		CreatedAt:     w.CreatedAt,
+		UpdatedAt:     w.UpdatedAt,
+	}
+}
+
+// WebhookDelivery is the notification of an event to a webhook
+type WebhookDelivery struct {
+	ID     string `json:"id"`
+	Event  string `json:"event"`
+	Status string `json:"status"`
+	// Payload is the JSON body sent to the webhook URL
+	Payload  json.RawMessage          `json:"payload"`
+	Attempts []WebhookDeliveryAttempt `json:"attempts"`
+	// NextAttemptAt is only set for pending deliveries
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// WebhookDeliveryAttempt is an attempt to send a delivery
+type WebhookDeliveryAttempt struct {
+	Time       time.Time `json:"time"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// NewWebhookDelivery creates a WebhookDelivery from a webhook.Delivery
+func NewWebhookDelivery(d webhook.Delivery) WebhookDelivery {
+	attempts := make([]WebhookDeliveryAttempt, len(d.Attempts))
+	for i, a := range d.Attempts {
+		attempts[i] = WebhookDeliveryAttempt{
+			Time:       a.Time,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+		}
+	}
+
+	var next *time.Time
+	if d.Status == webhook.DeliveryStatusPending {
+		t := d.NextAttemptAt
+		next = &t
+	}
+
+	return WebhookDelivery{
+		ID:            d.ID,
+		Event:         string(d.Event),
+		Status:        string(d.Status),
+		Payload:       d.Body,
+		Attempts:      attempts,
+		NextAttemptAt: next,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+// Dispatches /webhook endpoint.
+// Method: GET, POST, DELETE
+// URI: /api/v2/webhook
+func webhookHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getWebhookHandler(w, r, gateway)
+		case http.MethodPost:
+			createWebhookHandler(w, r, gateway)
+		case http.MethodDelete:
+			deleteWebhookHandler(w, r, gateway)
+		default:
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+		}
+	}
+}
+
+// Registers a webhook. The response includes the secret that signs the deliveries,
+// which is not returned by the other endpoints.
+// Args: JSON body
+func createWebhookHandler(w http.ResponseWriter, r *http.Request, gateway Gatewayer) {
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	wr, err := req.Request()
+	if err != nil {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	wh, err := gateway.CreateWebhook(*wr)
+	if err != nil {
+		writeHTTPResponse(w, webhookErrorResponse(err))
+		return
+	}
+
+	rw := NewWebhook(*wh)
+	rw.Secret = wh.Secret
+
+	writeHTTPResponse(w, HTTPResponse{
+		Data: rw,
+	})
+}
+
+// Returns a webhook
+// Args:
+//
+//	id: the ID of the webhook [required]
+func getWebhookHandler(w http.ResponseWriter, r *http.Request, gateway Gatewayer) {
+	id := r.FormValue("id")
+	if id == "" {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, "id is required")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	wh, err := gateway.GetWebhook(id)
+	if err != nil {
+		writeHTTPResponse(w, webhookErrorResponse(err))
+		return
+	}
+
+	writeHTTPResponse(w, HTTPResponse{
+		Data: NewWebhook(*wh),
+	})
+}
+
+// Deletes a webhook and its deliveries
+// Args:
+//
+//	id: the ID of the webhook [required]
+func deleteWebhookHandler(w http.ResponseWriter, r *http.Request, gateway Gatewayer) {
+	id := r.FormValue("id")
+	if id == "" {
+		resp := NewHTTPErrorResponse(http.StatusBadRequest, "id is required")
+		writeHTTPResponse(w, resp)
+		return
+	}
+
+	if err := gateway.DeleteWebhook(id); err != nil {
+		writeHTTPResponse(w, webhookErrorResponse(err))
+		return
+	}
+
+	writeHTTPResponse(w, HTTPResponse{})
+}
+
+// Returns all webhooks, oldest first
+// Method: GET
+// URI: /api/v2/webhooks
+func webhooksHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		webhooks, err := gateway.GetWebhooks()
+		if err != nil {
+			writeHTTPResponse(w, webhookErrorResponse(err))
+			return
+		}
+
+		rw := make([]Webhook, len(webhooks))
+		for i, wh := range webhooks {
+			rw[i] = NewWebhook(wh)
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: rw,
+		})
+	}
+}
+
+// Returns the deliveries of a webhook, newest first
+// Method: GET
+// URI: /api/v2/webhook/deliveries
+// Args:
+//
+//	id: the ID of the webhook [required]
+func webhookDeliveriesHandler(gateway Gatewayer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			resp := NewHTTPErrorResponse(http.StatusMethodNotAllowed, "")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		id := r.FormValue("id")
+		if id == "" {
+			resp := NewHTTPErrorResponse(http.StatusBadRequest, "id is required")
+			writeHTTPResponse(w, resp)
+			return
+		}
+
+		deliveries, err := gateway.GetWebhookDeliveries(id)
+		if err != nil {
+			writeHTTPResponse(w, webhookErrorResponse(err))
+			return
+		}
+
+		rd := make([]WebhookDelivery, len(deliveries))
+		for i, d := range deliveries {
+			rd[i] = NewWebhookDelivery(d)
+		}
+
+		writeHTTPResponse(w, HTTPResponse{
+			Data: rd,
+		})
+	}
+}
+
+func webhookErrorResponse(err error) HTTPResponse {
+	switch err.(type) {
+	case webhook.Error:
+		switch err {
+		case webhook.ErrWebhookNotFound:
+			return NewHTTPErrorResponse(http.StatusNotFound, "")
+		default:
+			return NewHTTPErrorResponse(http.StatusBadRequest, err.Error())
+		}
+	default:
+		return NewHTTPErrorResponse(http.StatusInternalServerError, err.Error())
+	}
+}
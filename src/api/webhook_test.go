@@ -0,0 +1,514 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/webhook"
+)
+
+func makeWebhook(t *testing.T) webhook.Webhook {
+	now := time.Now().UTC()
+	return webhook.Webhook{
+		ID:            "0123456789abcdef0123456789abcdef",
+		URL:           "https://example.com/hook",
+		Secret:        "secret",
+		Event:         webhook.EventTxnConfirmations,
+		Status:        webhook.StatusActive,
+		TxID:          testutil.RandSHA256(t).Hex(),
+		Confirmations: 3,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// requireWebhookEqual compares Webhooks, ignoring the time zone of their timestamps
+func requireWebhookEqual(t *testing.T, expected, actual Webhook) {
+	require.True(t, expected.CreatedAt.Equal(actual.CreatedAt))
+	require.True(t, expected.UpdatedAt.Equal(actual.UpdatedAt))
+	expected.CreatedAt = actual.CreatedAt
+	expected.UpdatedAt = actual.UpdatedAt
+	require.Equal(t, expected, actual)
+}
+
+func TestWebhook(t *testing.T) {
+	wh := makeWebhook(t)
+
+	rspWebhook := NewWebhook(wh)
+	require.Empty(t, rspWebhook.Secret)
+
+	rspCreated := rspWebhook
+	rspCreated.Secret = wh.Secret
+
+	addr := testutil.MakeAddress()
+
+	validReq := WebhookRequest{
+		URL:           wh.URL,
+		Event:         string(wh.Event),
+		TxID:          wh.TxID,
+		Confirmations: wh.Confirmations,
+	}
+
+	noURLReq := validReq
+	noURLReq.URL = ""
+
+	noEventReq := validReq
+	noEventReq.Event = ""
+
+	badTxIDReq := validReq
+	badTxIDReq.TxID = "abcd"
+
+	badAddrReq := WebhookRequest{
+		URL:       wh.URL,
+		Event:     string(webhook.EventPaymentReceived),
+		Addresses: []string{addr.String(), "foo"},
+	}
+
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		query        string
+		req          *WebhookRequest
+		httpBody     string
+		webhook      *webhook.Webhook
+		gatewayErr   error
+		httpResponse HTTPResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodPut,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "get id missing",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "id is required"),
+		},
+		{
+			name:         "get not found",
+			method:       http.MethodGet,
+			status:       http.StatusNotFound,
+			query:        "bar",
+			gatewayErr:   webhook.ErrWebhookNotFound,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, ""),
+		},
+		{
+			name:    "get ok",
+			method:  http.MethodGet,
+			status:  http.StatusOK,
+			query:   wh.ID,
+			webhook: &wh,
+			httpResponse: HTTPResponse{
+				Data: rspWebhook,
+			},
+		},
+		{
+			name:         "delete id missing",
+			method:       http.MethodDelete,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "id is required"),
+		},
+		{
+			name:         "delete not found",
+			method:       http.MethodDelete,
+			status:       http.StatusNotFound,
+			query:        "bar",
+			gatewayErr:   webhook.ErrWebhookNotFound,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, ""),
+		},
+		{
+			name:   "delete ok",
+			method: http.MethodDelete,
+			status: http.StatusOK,
+			query:  wh.ID,
+		},
+		{
+			name:         "post empty json body",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			httpBody:     "",
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "EOF"),
+		},
+		{
+			name:         "post url missing",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &noURLReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "missing url"),
+		},
+		{
+			name:         "post event missing",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &noEventReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "missing event"),
+		},
+		{
+			name:         "post invalid txid",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &badTxIDReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid txid: Invalid hex length"),
+		},
+		{
+			name:         "post invalid address",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &badAddrReq,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "invalid address at index 1: Invalid address length"),
+		},
+		{
+			name:         "post invalid request",
+			method:       http.MethodPost,
+			status:       http.StatusBadRequest,
+			req:          &validReq,
+			gatewayErr:   webhook.ErrInvalidURL,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, webhook.ErrInvalidURL.Error()),
+		},
+		{
+			name:         "post other error",
+			method:       http.MethodPost,
+			status:       http.StatusInternalServerError,
+			req:          &validReq,
+			gatewayErr:   errors.New("db error"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "db error"),
+		},
+		{
+			name:    "post ok",
+			method:  http.MethodPost,
+			status:  http.StatusOK,
+			req:     &validReq,
+			webhook: &wh,
+			httpResponse: HTTPResponse{
+				Data: rspCreated,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetWebhook", tc.query).Return(tc.webhook, tc.gatewayErr)
+			gateway.On("DeleteWebhook", tc.query).Return(tc.gatewayErr)
+			gateway.On("CreateWebhook", mock.Anything).Return(
+				func(r webhook.Request) *webhook.Webhook {
+					require.Equal(t, wh.URL, r.URL)
+					require.Equal(t, wh.Event, r.Event)
+					require.Equal(t, wh.TxID, r.TxID.Hex())
+					require.Equal(t, wh.Confirmations, r.Confirmations)
+					require.Empty(t, r.Addresses)
+					return tc.webhook
+				}, tc.gatewayErr)
+
+			if tc.httpBody == "" && tc.req != nil {
+				tc.httpBody = toJSON(t, tc.req)
+			}
+
+			endpoint := "/api/v2/webhook"
+			if tc.query != "" {
+				endpoint += "?id=" + tc.query
+			}
+			req, err := http.NewRequest(tc.method, endpoint, strings.NewReader(tc.httpBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+			} else {
+				require.NotNil(t, tc.httpResponse.Data)
+
+				var webhookRsp Webhook
+				err := json.Unmarshal(rsp.Data, &webhookRsp)
+				require.NoError(t, err)
+
+				requireWebhookEqual(t, tc.httpResponse.Data.(Webhook), webhookRsp)
+			}
+		})
+	}
+}
+
+func TestWebhookRequestAddresses(t *testing.T) {
+	addrs := []cipher.Address{testutil.MakeAddress(), testutil.MakeAddress()}
+
+	r, err := webhookRequest{
+		URL:       "https://example.com/hook",
+		Event:     string(webhook.EventPaymentReceived),
+		Addresses: []string{addrs[0].String(), addrs[1].String()},
+	}.Request()
+	require.NoError(t, err)
+	require.Equal(t, addrs, r.Addresses)
+	require.True(t, r.TxID.Null())
+}
+
+func TestWebhooks(t *testing.T) {
+	wh := makeWebhook(t)
+	other := makeWebhook(t)
+	other.ID = "fedcba9876543210fedcba9876543210"
+
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		webhooks     []webhook.Webhook
+		gatewayErr   error
+		httpResponse HTTPResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodPost,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "gateway error",
+			method:       http.MethodGet,
+			status:       http.StatusInternalServerError,
+			gatewayErr:   errors.New("db error"),
+			httpResponse: NewHTTPErrorResponse(http.StatusInternalServerError, "db error"),
+		},
+		{
+			name:   "no webhooks",
+			method: http.MethodGet,
+			status: http.StatusOK,
+			httpResponse: HTTPResponse{
+				Data: []Webhook{},
+			},
+		},
+		{
+			name:     "ok",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			webhooks: []webhook.Webhook{wh, other},
+			httpResponse: HTTPResponse{
+				Data: []Webhook{NewWebhook(wh), NewWebhook(other)},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetWebhooks").Return(tc.webhooks, tc.gatewayErr)
+
+			req, err := http.NewRequest(tc.method, "/api/v2/webhooks", nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+				return
+			}
+
+			var webhooksRsp []Webhook
+			err = json.Unmarshal(rsp.Data, &webhooksRsp)
+			require.NoError(t, err)
+
+			expected := tc.httpResponse.Data.([]Webhook)
+			require.Len(t, webhooksRsp, len(expected))
+			for i := range expected {
+				require.Empty(t, webhooksRsp[i].Secret)
+				requireWebhookEqual(t, expected[i], webhooksRsp[i])
+			}
+		})
+	}
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	pending := webhook.Delivery{
+		ID:        "a",
+		WebhookID: "foo",
+		Seq:       2,
+		Event:     webhook.EventPaymentReceived,
+		Status:    webhook.DeliveryStatusPending,
+		Body:      json.RawMessage(`{"id":"a"}`),
+		Attempts: []webhook.Attempt{
+			{
+				Time:       now,
+				StatusCode: http.StatusInternalServerError,
+				Error:      "unexpected response status 500 Internal Server Error",
+			},
+		},
+		NextAttemptAt: now.Add(time.Minute),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	delivered := webhook.Delivery{
+		ID:        "b",
+		WebhookID: "foo",
+		Seq:       1,
+		Event:     webhook.EventPaymentReceived,
+		Status:    webhook.DeliveryStatusDelivered,
+		Body:      json.RawMessage(`{"id":"b"}`),
+		Attempts: []webhook.Attempt{
+			{
+				Time:       now,
+				StatusCode: http.StatusOK,
+			},
+		},
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	next := now.Add(time.Minute)
+
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		query        string
+		deliveries   []webhook.Delivery
+		gatewayErr   error
+		httpResponse HTTPResponse
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodPost,
+			status:       http.StatusMethodNotAllowed,
+			httpResponse: NewHTTPErrorResponse(http.StatusMethodNotAllowed, "Method Not Allowed"),
+		},
+		{
+			name:         "id missing",
+			method:       http.MethodGet,
+			status:       http.StatusBadRequest,
+			httpResponse: NewHTTPErrorResponse(http.StatusBadRequest, "id is required"),
+		},
+		{
+			name:         "not found",
+			method:       http.MethodGet,
+			status:       http.StatusNotFound,
+			query:        "bar",
+			gatewayErr:   webhook.ErrWebhookNotFound,
+			httpResponse: NewHTTPErrorResponse(http.StatusNotFound, ""),
+		},
+		{
+			name:       "ok",
+			method:     http.MethodGet,
+			status:     http.StatusOK,
+			query:      "foo",
+			deliveries: []webhook.Delivery{pending, delivered},
+			httpResponse: HTTPResponse{
+				Data: []WebhookDelivery{
+					{
+						ID:      "a",
+						Event:   string(webhook.EventPaymentReceived),
+						Status:  string(webhook.DeliveryStatusPending),
+						Payload: json.RawMessage(`{"id":"a"}`),
+						Attempts: []WebhookDeliveryAttempt{
+							{
+								Time:       now,
+								StatusCode: http.StatusInternalServerError,
+								Error:      "unexpected response status 500 Internal Server Error",
+							},
+						},
+						NextAttemptAt: &next,
+						CreatedAt:     now,
+						UpdatedAt:     now,
+					},
+					{
+						ID:      "b",
+						Event:   string(webhook.EventPaymentReceived),
+						Status:  string(webhook.DeliveryStatusDelivered),
+						Payload: json.RawMessage(`{"id":"b"}`),
+						Attempts: []WebhookDeliveryAttempt{
+							{
+								Time:       now,
+								StatusCode: http.StatusOK,
+							},
+						},
+						CreatedAt: now,
+						UpdatedAt: now,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &MockGatewayer{}
+			gateway.On("GetWebhookDeliveries", tc.query).Return(tc.deliveries, tc.gatewayErr)
+
+			endpoint := "/api/v2/webhook/deliveries"
+			if tc.query != "" {
+				endpoint += "?id=" + tc.query
+			}
+			req, err := http.NewRequest(tc.method, endpoint, nil)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeJSON)
+
+			rr := httptest.NewRecorder()
+			handler := newServerMux(defaultMuxConfig(), gateway)
+			handler.ServeHTTP(rr, req)
+
+			status := rr.Code
+			require.Equal(t, tc.status, status, "got `%v` want `%v`", status, tc.status)
+
+			var rsp ReceivedHTTPResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &rsp)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.httpResponse.Error, rsp.Error)
+
+			if rsp.Data == nil {
+				require.Nil(t, tc.httpResponse.Data)
+				return
+			}
+
+			var deliveriesRsp []WebhookDelivery
+			err = json.Unmarshal(rsp.Data, &deliveriesRsp)
+			require.NoError(t, err)
+
+			// The payload is indented in the response
+			for i := range deliveriesRsp {
+				var b bytes.Buffer
+				err := json.Compact(&b, deliveriesRsp[i].Payload)
+				require.NoError(t, err)
+				deliveriesRsp[i].Payload = b.Bytes()
+			}
+
+			require.Equal(t, tc.httpResponse.Data, deliveriesRsp)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Package apitoken implements named API tokens, which grant access to a subset of the API sets
+and optionally to specific wallets only.
+
+Tokens are stored in a JSON file. Only the SHA256 hash of a token secret is stored,
+the secret itself is shown once when the token is created.
+*/
+package apitoken
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/util/file"
+)
+
+const (
+	// secretLength is the number of random bytes in a token secret
+	secretLength = 32
+)
+
+var (
+	// ErrInvalidToken is returned if a token secret does not match any token
+	ErrInvalidToken = errors.New("invalid API token")
+	// ErrTokenExpired is returned if a token has expired
+	ErrTokenExpired = errors.New("API token has expired")
+	// ErrTokenNotFound is returned if a named token does not exist
+	ErrTokenNotFound = errors.New("API token not found")
+	// ErrTokenExists is returned when creating a token with the name of an existing token
+	ErrTokenExists = errors.New("API token with this name already exists")
+	// ErrMissingName is returned when creating a token without a name
+	ErrMissingName = errors.New("API token name is required")
+	// ErrMissingAPISets is returned when creating a token without API sets
+	ErrMissingAPISets = errors.New("API token must grant at least one API set")
+)
+
+// Token is a named API token
+type Token struct {
+	Name string `json:"name"`
+	// Hash is the hex encoded SHA256 hash of the token secret
+	Hash string `json:"hash"`
+	// APISets are the API sets the token grants access to
+	APISets []string `json:"api_sets"`
+	// WalletIDs are the wallets the token grants access to. If empty, all wallets are accessible
+	WalletIDs []string `json:"wallet_ids,omitempty"`
+	// Created is the unix time the token was created at
+	Created int64 `json:"created"`
+	// Expires is the unix time the token expires at. If 0, the token does not expire
+	Expires int64 `json:"expires,omitempty"`
+}
+
+// Expired returns true if the token has expired at time t
+func (t Token) Expired(now time.Time) bool {
+	return t.Expires != 0 && now.Unix() >= t.Expires
+}
+
+// HasAPISet returns true if the token grants access to the API set
+func (t Token) HasAPISet(apiSet string) bool {
+	for _, s := range t.APISets {
+		if s == apiSet {
+			return true
+		}
+	}
+	return false
+}
+
+// RestrictsWallets returns true if the token grants access to specific wallets only
+func (t Token) RestrictsWallets() bool {
+	return len(t.WalletIDs) != 0
+}
+
+// HasWallet returns true if the token grants access to the wallet
+func (t Token) HasWallet(id string) bool {
+	if !t.RestrictsWallets() {
+		return true
+	}
+
+	for _, w := range t.WalletIDs {
+		if w == id {
+			return true
+		}
+	}
+	return false
+}
+
+// tokensFile is the JSON format of the tokens file
+type tokensFile struct {
+	Tokens []Token `json:"tokens"`
+}
+
+// hashSecret returns the hex encoded SHA256 hash of a token secret
+func hashSecret(secret string) string {
+	h := cipher.SumSHA256([]byte(secret))
+	return h.Hex()
+}
+
+// Load loads the tokens from a tokens file.
+// If the file does not exist, no tokens are returned.
+func Load(path string) ([]Token, error) {
+	var f tokensFile
+	if err := file.LoadJSON(path, &f); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("load API tokens file %s failed: %v", path, err)
+	}
+
+	return f.Tokens, nil
+}
+
+func save(path string, tokens []Token) error {
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Name < tokens[j].Name
+	})
+
+	return file.SaveJSON(path, tokensFile{Tokens: tokens}, 0600)
+}
+
+// CreateOptions are the options of a new token
+type CreateOptions struct {
+	Name      string
+	APISets   []string
+	WalletIDs []string
+	// Expires is the time the token expires at. If zero, the token does not expire
+	Expires time.Time
+}
+
+// Create creates a token in the tokens file and returns the token and its secret.
+// The secret is not stored, it can not be recovered later.
+func Create(path string, opts CreateOptions) (*Token, string, error) {
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		return nil, "", ErrMissingName
+	}
+
+	if len(opts.APISets) == 0 {
+		return nil, "", ErrMissingAPISets
+	}
+
+	tokens, err := Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, t := range tokens {
+		if t.Name == name {
+			return nil, "", ErrTokenExists
+		}
+	}
+
+	secret := hex.EncodeToString(cipher.RandByte(secretLength))
+
+	t := Token{
+		Name:      name,
+		Hash:      hashSecret(secret),
+		APISets:   opts.APISets,
+		WalletIDs: opts.WalletIDs,
+		Created:   time.Now().UTC().Unix(),
+	}
+	if !opts.Expires.IsZero() {
+		t.Expires = opts.Expires.UTC().Unix()
+	}
+
+	if err := save(path, append(tokens, t)); err != nil {
+		return nil, "", err
+	}
+
+	return &t, secret, nil
+}
+
+// Remove removes the named token from the tokens file
+func Remove(path, name string) error {
+	tokens, err := Load(path)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range tokens {
+		if t.Name == name {
+			return save(path, append(tokens[:i], tokens[i+1:]...))
+		}
+	}
+
+	return ErrTokenNotFound
+}
+
+// Store authenticates token secrets against a tokens file.
+// The file is reloaded when it changes, so that tokens can be managed while the node is running.
+type Store struct {
+	sync.Mutex
+	path    string
+	modTime time.Time
+	size    int64
+	tokens  map[string]Token
+}
+
+// NewStore creates a Store for the tokens file
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+	}
+
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// reload reloads the tokens file if it has changed since it was last loaded
+func (s *Store) reload() error {
+	var modTime time.Time
+	var size int64
+	fi, err := os.Stat(s.path)
+	switch {
+	case err == nil:
+		modTime = fi.ModTime()
+		size = fi.Size()
+	case os.IsNotExist(err):
+	default:
+		return err
+	}
+
+	if s.tokens != nil && modTime.Equal(s.modTime) && size == s.size {
+		return nil
+	}
+
+	tokens, err := Load(s.path)
+	if err != nil {
+		return err
+	}
+
+	s.tokens = make(map[string]Token, len(tokens))
+	for _, t := range tokens {
+		s.tokens[t.Hash] = t
+	}
+	s.modTime = modTime
+	s.size = size
+
+	return nil
+}
+
+// Authenticate returns the token matching the secret.
+// Returns ErrInvalidToken if no token matches and ErrTokenExpired if the token has expired.
+func (s *Store) Authenticate(secret string, now time.Time) (*Token, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	t, ok := s.tokens[hashSecret(secret)]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	if t.Expired(now) {
+		return nil, ErrTokenExpired
+	}
+
+	return &t, nil
+}
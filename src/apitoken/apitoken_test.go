@@ -0,0 +1,128 @@
+package apitoken
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupTokensFile(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "apitoken")
+	require.NoError(t, err)
+	return filepath.Join(dir, "api_tokens.json"), func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCreateRemove(t *testing.T) {
+	path, cleanup := setupTokensFile(t)
+	defer cleanup()
+
+	tokens, err := Load(path)
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+
+	_, _, err = Create(path, CreateOptions{APISets: []string{"READ"}})
+	require.Equal(t, ErrMissingName, err)
+
+	_, _, err = Create(path, CreateOptions{Name: "dashboard"})
+	require.Equal(t, ErrMissingAPISets, err)
+
+	tk, secret, err := Create(path, CreateOptions{
+		Name:    "dashboard",
+		APISets: []string{"READ", "STATUS"},
+	})
+	require.NoError(t, err)
+	require.Len(t, secret, secretLength*2)
+	require.Equal(t, hashSecret(secret), tk.Hash)
+	require.Equal(t, int64(0), tk.Expires)
+
+	_, _, err = Create(path, CreateOptions{
+		Name:    "dashboard",
+		APISets: []string{"READ"},
+	})
+	require.Equal(t, ErrTokenExists, err)
+
+	expires := time.Now().Add(time.Hour)
+	_, _, err = Create(path, CreateOptions{
+		Name:      "bot",
+		APISets:   []string{"WALLET"},
+		WalletIDs: []string{"foo.wlt"},
+		Expires:   expires,
+	})
+	require.NoError(t, err)
+
+	tokens, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	require.Equal(t, "bot", tokens[0].Name)
+	require.Equal(t, expires.Unix(), tokens[0].Expires)
+	require.Equal(t, "dashboard", tokens[1].Name)
+
+	// The secret is not stored
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), secret)
+
+	require.Equal(t, ErrTokenNotFound, Remove(path, "foo"))
+	require.NoError(t, Remove(path, "bot"))
+
+	tokens, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, "dashboard", tokens[0].Name)
+}
+
+func TestStoreAuthenticate(t *testing.T) {
+	path, cleanup := setupTokensFile(t)
+	defer cleanup()
+
+	s, err := NewStore(path)
+	require.NoError(t, err)
+
+	_, err = s.Authenticate("foo", time.Now())
+	require.Equal(t, ErrInvalidToken, err)
+
+	now := time.Now()
+	tk, secret, err := Create(path, CreateOptions{
+		Name:      "bot",
+		APISets:   []string{"WALLET"},
+		WalletIDs: []string{"foo.wlt"},
+		Expires:   now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	// The store reloads the changed file
+	authTk, err := s.Authenticate(secret, now)
+	require.NoError(t, err)
+	require.Equal(t, tk, authTk)
+	require.True(t, authTk.HasAPISet("WALLET"))
+	require.False(t, authTk.HasAPISet("READ"))
+	require.True(t, authTk.HasWallet("foo.wlt"))
+	require.False(t, authTk.HasWallet("bar.wlt"))
+
+	_, err = s.Authenticate(secret+"0", now)
+	require.Equal(t, ErrInvalidToken, err)
+
+	_, err = s.Authenticate(secret, now.Add(time.Hour))
+	require.Equal(t, ErrTokenExpired, err)
+
+	require.NoError(t, Remove(path, "bot"))
+
+	// Ensure the modification time changes on filesystems with coarse timestamps
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	_, err = s.Authenticate(secret, now)
+	require.Equal(t, ErrInvalidToken, err)
+}
+
+func TestTokenHasWallet(t *testing.T) {
+	tk := Token{APISets: []string{"WALLET"}}
+	require.False(t, tk.RestrictsWallets())
+	require.True(t, tk.HasWallet("foo.wlt"))
+}
@@ -3,8 +3,10 @@ package cipher
 import (
 	"errors"
 	"log"
+	"strings"
 
 	"github.com/skycoin/skycoin/src/cipher/base58"
+	"github.com/skycoin/skycoin/src/cipher/bech32"
 )
 
 var (
@@ -20,6 +22,8 @@ var (
 	ErrAddressInvalidFirstByte = errors.New("first byte invalid")
 	// ErrAddressInvalidLastByte 33rd byte in wallet import format string is invalid
 	ErrAddressInvalidLastByte = errors.New("invalid 33rd byte")
+	// ErrAddressInvalidHRP Bech32 address human-readable part does not match the coin's
+	ErrAddressInvalidHRP = errors.New("Invalid address human-readable part")
 )
 
 /*
@@ -37,6 +41,10 @@ In base 58 format the address is 20+1+4 bytes
 - the next 4 bytes are a checksum
 -- the first 4 bytes of the SHA256 of the 21 bytes that come before
 
+In bech32 format the address is the coin's human-readable part, the separator "1",
+the version as one 5-bit value, the 20 byte key in 5-bit values and a bech32m checksum.
+Bech32 strings are case insensitive and up to 2 wrong characters can be located.
+
 */
 
 // Checksum 4 bytes
@@ -106,6 +114,72 @@ func MustDecodeBase58Address(addr string) Address {
 	return a
 }
 
+// DecodeBech32Address creates an Address from its bech32 encoding with the human-readable part hrp
+func DecodeBech32Address(hrp, addr string) (Address, error) {
+	addrHRP, data, enc, err := bech32.Decode(addr)
+	if err != nil {
+		return Address{}, err
+	}
+
+	if addrHRP != strings.ToLower(hrp) {
+		return Address{}, ErrAddressInvalidHRP
+	}
+
+	// Addresses use the bech32m checksum only
+	if enc != bech32.Bech32m {
+		return Address{}, ErrAddressInvalidChecksum
+	}
+
+	if len(data) == 0 {
+		return Address{}, ErrAddressInvalidLength
+	}
+
+	key, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return Address{}, err
+	}
+	if len(key) != len(Ripemd160{}) {
+		return Address{}, ErrAddressInvalidLength
+	}
+
+	if data[0] != 0 {
+		return Address{}, ErrAddressInvalidVersion
+	}
+
+	a := Address{
+		Version: data[0],
+	}
+	copy(a.Key[:], key)
+	return a, nil
+}
+
+// HasBech32Prefix returns true if addr starts with the human-readable part hrp and the separator "1",
+// ignoring case, which makes DecodeAddress decode it as a bech32 address first
+func HasBech32Prefix(hrp, addr string) bool {
+	return strings.HasPrefix(strings.ToLower(addr), strings.ToLower(hrp)+"1")
+}
+
+// DecodeAddress creates an Address from its base58 encoding or its bech32 encoding
+// with the human-readable part hrp.
+// Strings that start with the human-readable part and the separator "1" are decoded as bech32 first.
+// If they are not valid base58 addresses either, the bech32 error is returned.
+func DecodeAddress(hrp, addr string) (Address, error) {
+	if !HasBech32Prefix(hrp, addr) {
+		return DecodeBase58Address(addr)
+	}
+
+	a, err := DecodeBech32Address(hrp, addr)
+	if err == nil {
+		return a, nil
+	}
+
+	if a, err := DecodeBase58Address(addr); err == nil {
+		return a, nil
+	}
+
+	return Address{}, err
+}
+
 // AddressFromBytes converts []byte to an Address
 func AddressFromBytes(b []byte) (Address, error) {
 	if len(b) != 20+1+4 {
@@ -173,6 +247,20 @@ func (addr Address) String() string {
 	return string(base58.Encode(addr.Bytes()))
 }
 
+// Bech32 returns the address as a bech32m encoded string with the human-readable part hrp
+func (addr Address) Bech32(hrp string) (string, error) {
+	if addr.Version > 31 {
+		return "", ErrAddressInvalidVersion
+	}
+
+	key, err := bech32.ConvertBits(addr.Key[:], 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32.Encode(hrp, append([]byte{addr.Version}, key...), bech32.Bech32m)
+}
+
 // Checksum returns Address Checksum which is the first 4 bytes of sha256(key+version)
 func (addr Address) Checksum() Checksum {
 	r1 := append(addr.Key[:], []byte{addr.Version}...)
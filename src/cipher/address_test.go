@@ -2,11 +2,13 @@ package cipher
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/skycoin/skycoin/src/cipher/base58"
+	"github.com/skycoin/skycoin/src/cipher/bech32"
 )
 
 func TestMustDecodeBase58Address(t *testing.T) {
@@ -101,6 +103,148 @@ func TestDecodeBase58Address(t *testing.T) {
 	require.Equal(t, ErrAddressInvalidChecksum, err)
 }
 
+func TestAddressBech32(t *testing.T) {
+	a := MustDecodeBase58Address("2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6")
+
+	s, err := a.Bech32("sky")
+	require.NoError(t, err)
+	require.Equal(t, "sky1qlruuv3rh9hznw0v9uygffepcmac85skf7kewda", s)
+
+	s, err = a.Bech32("TST")
+	require.NoError(t, err)
+	require.Equal(t, "tst1qlruuv3rh9hznw0v9uygffepcmac85skf3kmktf", s)
+
+	_, err = Address{Version: 32}.Bech32("sky")
+	require.Equal(t, ErrAddressInvalidVersion, err)
+}
+
+func TestDecodeBech32Address(t *testing.T) {
+	for i := 0; i < 64; i++ {
+		p, _ := GenerateKeyPair()
+		a := AddressFromPubKey(p)
+
+		s, err := a.Bech32("sky")
+		require.NoError(t, err)
+
+		a2, err := DecodeBech32Address("sky", s)
+		require.NoError(t, err)
+		require.Equal(t, a, a2)
+
+		// Bech32 addresses are case insensitive
+		a2, err = DecodeBech32Address("SKY", strings.ToUpper(s))
+		require.NoError(t, err)
+		require.Equal(t, a, a2)
+	}
+
+	a := MustDecodeBase58Address("2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6")
+	key, err := bech32.ConvertBits(a.Key[:], 8, 5, true)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		hrp  string
+		addr string
+		err  error
+	}{
+		{
+			name: "wrong human-readable part",
+			hrp:  "tst",
+			addr: "sky1qlruuv3rh9hznw0v9uygffepcmac85skf7kewda",
+			err:  ErrAddressInvalidHRP,
+		},
+		{
+			name: "substituted character",
+			hrp:  "sky",
+			addr: "sky1qlruuv3rh9hznw0v9uygffepcmac85skf7kewdq",
+			err:  bech32.ErrInvalidChecksum,
+		},
+		{
+			name: "mixed case",
+			hrp:  "sky",
+			addr: "sky1qlruuv3rh9hznw0v9uygffepcmac85skf7keWda",
+			err:  bech32.ErrMixedCase,
+		},
+		{
+			name: "base58 address",
+			hrp:  "sky",
+			addr: "2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6",
+			err:  bech32.ErrMixedCase,
+		},
+		{
+			name: "bech32 checksum",
+			hrp:  "sky",
+			addr: mustEncodeBech32(t, append([]byte{0}, key...), bech32.Bech32),
+			err:  ErrAddressInvalidChecksum,
+		},
+		{
+			name: "short key",
+			hrp:  "sky",
+			addr: mustEncodeBech32(t, append([]byte{0}, key[:24]...), bech32.Bech32m),
+			err:  ErrAddressInvalidLength,
+		},
+		{
+			name: "no data",
+			hrp:  "sky",
+			addr: mustEncodeBech32(t, nil, bech32.Bech32m),
+			err:  ErrAddressInvalidLength,
+		},
+		{
+			name: "version 1",
+			hrp:  "sky",
+			addr: mustEncodeBech32(t, append([]byte{1}, key...), bech32.Bech32m),
+			err:  ErrAddressInvalidVersion,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DecodeBech32Address(tc.hrp, tc.addr)
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func mustEncodeBech32(t *testing.T, data []byte, enc bech32.Encoding) string {
+	s, err := bech32.Encode("sky", data, enc)
+	require.NoError(t, err)
+	return s
+}
+
+func TestDecodeAddress(t *testing.T) {
+	a := MustDecodeBase58Address("2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6")
+
+	for _, s := range []string{
+		"2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6",
+		"sky1qlruuv3rh9hznw0v9uygffepcmac85skf7kewda",
+		"SKY1QLRUUV3RH9HZNW0V9UYGFFEPCMAC85SKF7KEWDA",
+	} {
+		a2, err := DecodeAddress("sky", s)
+		require.NoError(t, err)
+		require.Equal(t, a, a2)
+	}
+
+	require.True(t, HasBech32Prefix("sky", "SKY1QLRUUV3RH9HZNW0V9UYGFFEPCMAC85SKF7KEWDA"))
+	require.False(t, HasBech32Prefix("sky", "2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6"))
+	require.False(t, HasBech32Prefix("sky", "skyqlruuv3rh9hznw0v9uygffepcmac85skf7kewda"))
+
+	// Bech32 errors are returned for strings that start with the human-readable part
+	_, err := DecodeAddress("sky", "sky1qlruuv3rh9hznw0v9uygffepcmac85skf7kewdq")
+	require.Equal(t, bech32.ErrInvalidChecksum, err)
+
+	// Base58 errors are returned otherwise
+	_, err = DecodeAddress("sky", "tst1qlruuv3rh9hznw0v9uygffepcmac85skf3kmktf")
+	require.Equal(t, base58.ErrInvalidChar, err)
+
+	_, err = DecodeAddress("sky", "2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os7")
+	require.Equal(t, ErrAddressInvalidChecksum, err)
+
+	// Base58 addresses that start with the human-readable part and the separator are decoded
+	a = MustDecodeBase58Address("21N2iJ1qnQRiJWcEqNRxXwfNp8QcmiyhtPy")
+	a2, err := DecodeAddress("2", "21N2iJ1qnQRiJWcEqNRxXwfNp8QcmiyhtPy")
+	require.NoError(t, err)
+	require.Equal(t, a, a2)
+}
+
 func TestAddressFromBytes(t *testing.T) {
 	p, _ := GenerateKeyPair()
 	a := AddressFromPubKey(p)
@@ -0,0 +1,186 @@
+package cipher
+
+import (
+	"runtime"
+	"sync"
+)
+
+/*
+Batch signature verification
+
+A BatchVerifier verifies many signatures concurrently with a pool of workers,
+for example all the input signatures of a block or of a large transaction.
+
+Signatures that are verified as valid are added to a SigCache, if the BatchVerifier has one,
+and are not verified again. Only valid signatures are cached, so an invalid signature is
+verified every time and always reports the same error.
+*/
+
+// SignedHash is a signature of a hash, to be verified against the address of the signer
+type SignedHash struct {
+	Address Address
+	Sig     Sig
+	Hash    SHA256
+	// Schnorr is true if Sig is a Schnorr signature made with SignHashSchnorr
+	Schnorr bool
+}
+
+// Verify checks that the signature signed the hash with the secret key of the address
+func (sh SignedHash) Verify() error {
+	if sh.Schnorr {
+		return VerifyAddressSchnorrSignedHash(sh.Address, sh.Sig, sh.Hash)
+	}
+	return VerifyAddressSignedHash(sh.Address, sh.Sig, sh.Hash)
+}
+
+// SigCache is a cache of valid signatures, safe for concurrent use.
+// When the cache is full, a random signature is evicted to make room for a new one.
+type SigCache struct {
+	sync.RWMutex
+	size int
+	sigs map[SignedHash]struct{}
+}
+
+// NewSigCache creates a SigCache which holds up to size signatures
+func NewSigCache(size int) *SigCache {
+	return &SigCache{
+		size: size,
+		sigs: make(map[SignedHash]struct{}),
+	}
+}
+
+// Contains returns true if the signature is in the cache
+func (c *SigCache) Contains(sh SignedHash) bool {
+	if c == nil {
+		return false
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+
+	_, ok := c.sigs[sh]
+	return ok
+}
+
+// Add adds a valid signature to the cache
+func (c *SigCache) Add(sh SignedHash) {
+	if c == nil || c.size <= 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.sigs[sh]; ok {
+		return
+	}
+
+	if len(c.sigs) >= c.size {
+		// Map iteration order is random, evict the first signature
+		for k := range c.sigs {
+			delete(c.sigs, k)
+			break
+		}
+	}
+
+	c.sigs[sh] = struct{}{}
+}
+
+// Len returns the number of signatures in the cache
+func (c *SigCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(c.sigs)
+}
+
+// BatchVerifier verifies signatures concurrently with a pool of workers,
+// and skips the signatures found in its cache.
+// A nil *BatchVerifier verifies signatures one after another, without a cache.
+type BatchVerifier struct {
+	workers int
+	cache   *SigCache
+}
+
+// NewBatchVerifier creates a BatchVerifier with a pool of workers.
+// If workers is 0, runtime.NumCPU() workers are used.
+// The cache is optional.
+func NewBatchVerifier(workers int, cache *SigCache) *BatchVerifier {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	return &BatchVerifier{
+		workers: workers,
+		cache:   cache,
+	}
+}
+
+// Cache returns the cache of the BatchVerifier, nil if it has none
+func (v *BatchVerifier) Cache() *SigCache {
+	if v == nil {
+		return nil
+	}
+	return v.cache
+}
+
+// Verify verifies a single signature, and caches it if valid
+func (v *BatchVerifier) Verify(sh SignedHash) error {
+	cache := v.Cache()
+
+	if cache.Contains(sh) {
+		return nil
+	}
+
+	if err := sh.Verify(); err != nil {
+		return err
+	}
+
+	cache.Add(sh)
+	return nil
+}
+
+// VerifyBatch verifies the signatures with the pool of workers.
+// Returns the error of each signature, in the order of sigs. The error is nil for a valid signature.
+func (v *BatchVerifier) VerifyBatch(sigs []SignedHash) []error {
+	errs := make([]error, len(sigs))
+
+	workers := 1
+	if v != nil {
+		workers = v.workers
+	}
+	if workers > len(sigs) {
+		workers = len(sigs)
+	}
+
+	if workers <= 1 {
+		for i, sh := range sigs {
+			errs[i] = v.Verify(sh)
+		}
+		return errs
+	}
+
+	indexC := make(chan int, len(sigs))
+	for i := range sigs {
+		indexC <- i
+	}
+	close(indexC)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range indexC {
+				errs[j] = v.Verify(sigs[j])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
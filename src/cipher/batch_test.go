@@ -0,0 +1,168 @@
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeSignedHashes(t *testing.T, n int) []SignedHash {
+	sigs := make([]SignedHash, n)
+	for i := range sigs {
+		p, s := GenerateKeyPair()
+		h := SumSHA256(randBytes(t, 32))
+		sigs[i] = SignedHash{
+			Address: AddressFromPubKey(p),
+			Hash:    h,
+			Schnorr: i%2 == 1,
+		}
+
+		if sigs[i].Schnorr {
+			sigs[i].Sig = MustSignHashSchnorr(h, s)
+		} else {
+			sigs[i].Sig = MustSignHash(h, s)
+		}
+	}
+	return sigs
+}
+
+func TestSignedHashVerify(t *testing.T) {
+	sigs := makeSignedHashes(t, 2)
+	for _, sh := range sigs {
+		require.NoError(t, sh.Verify())
+
+		// Wrong signature scheme
+		sh.Schnorr = !sh.Schnorr
+		require.Error(t, sh.Verify())
+	}
+}
+
+func TestSigCache(t *testing.T) {
+	sigs := makeSignedHashes(t, 4)
+
+	c := NewSigCache(3)
+	require.Equal(t, 0, c.Len())
+	require.False(t, c.Contains(sigs[0]))
+
+	c.Add(sigs[0])
+	require.True(t, c.Contains(sigs[0]))
+	require.Equal(t, 1, c.Len())
+
+	// Adding twice does not change the cache
+	c.Add(sigs[0])
+	require.Equal(t, 1, c.Len())
+
+	c.Add(sigs[1])
+	c.Add(sigs[2])
+	require.Equal(t, 3, c.Len())
+
+	// A signature is evicted when the cache is full
+	c.Add(sigs[3])
+	require.Equal(t, 3, c.Len())
+	require.True(t, c.Contains(sigs[3]))
+
+	n := 0
+	for _, sh := range sigs[:3] {
+		if c.Contains(sh) {
+			n++
+		}
+	}
+	require.Equal(t, 2, n)
+
+	// A nil cache is empty
+	var nilCache *SigCache
+	nilCache.Add(sigs[0])
+	require.False(t, nilCache.Contains(sigs[0]))
+	require.Equal(t, 0, nilCache.Len())
+}
+
+func TestBatchVerifier(t *testing.T) {
+	sigs := makeSignedHashes(t, 20)
+
+	// Make some signatures invalid
+	invalid := map[int]struct{}{
+		3:  {},
+		10: {},
+		17: {},
+	}
+	for i := range invalid {
+		sigs[i].Hash = SumSHA256(randBytes(t, 32))
+	}
+
+	cases := []struct {
+		name     string
+		verifier *BatchVerifier
+	}{
+		{
+			name:     "nil verifier",
+			verifier: nil,
+		},
+		{
+			name:     "1 worker, no cache",
+			verifier: NewBatchVerifier(1, nil),
+		},
+		{
+			name:     "4 workers, cache",
+			verifier: NewBatchVerifier(4, NewSigCache(100)),
+		},
+		{
+			name:     "default workers, small cache",
+			verifier: NewBatchVerifier(0, NewSigCache(5)),
+		},
+		{
+			name:     "more workers than signatures",
+			verifier: NewBatchVerifier(50, NewSigCache(100)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			check := func() {
+				errs := tc.verifier.VerifyBatch(sigs)
+				require.Len(t, errs, len(sigs))
+				for i, err := range errs {
+					if _, ok := invalid[i]; ok {
+						require.Equal(t, ErrInvalidAddressForSig, err, "signature %d", i)
+					} else {
+						require.NoError(t, err, "signature %d", i)
+					}
+				}
+			}
+
+			check()
+
+			// Only valid signatures are cached
+			cache := tc.verifier.Cache()
+			if cache != nil {
+				n := len(sigs) - len(invalid)
+				if cache.size < n {
+					n = cache.size
+				}
+				require.Equal(t, n, cache.Len())
+				for i := range invalid {
+					require.False(t, cache.Contains(sigs[i]))
+				}
+			}
+
+			// Verifying again gives the same results
+			check()
+
+			require.Empty(t, tc.verifier.VerifyBatch(nil))
+		})
+	}
+}
+
+func TestBatchVerifierCache(t *testing.T) {
+	sigs := makeSignedHashes(t, 2)
+	v := NewBatchVerifier(2, NewSigCache(10))
+
+	require.NoError(t, v.Verify(sigs[0]))
+	require.True(t, v.Cache().Contains(sigs[0]))
+
+	// A cached signature is not verified again.
+	// A modified signature is a different cache entry, and is verified.
+	bad := sigs[0]
+	bad.Sig = sigs[1].Sig
+	require.Error(t, v.Verify(bad))
+	require.False(t, v.Cache().Contains(bad))
+}
@@ -0,0 +1,385 @@
+/*
+Package bech32 implements the bech32 (BIP173) and bech32m (BIP350) encodings.
+
+A bech32 string is made of a human-readable part, the separator "1" and a data part of
+5-bit values followed by a 6 character checksum. The checksum detects any error affecting
+up to 4 characters, and LocateErrors finds the positions of up to 2 substituted characters.
+*/
+package bech32
+
+import (
+	"errors"
+	"strings"
+)
+
+// Encoding is the checksum variant of a bech32 string
+type Encoding int
+
+const (
+	// Bech32 is the original encoding of BIP173
+	Bech32 Encoding = iota + 1
+	// Bech32m is the encoding of BIP350, which fixes a weakness of Bech32 to inserted or deleted characters
+	Bech32m
+)
+
+// String returns the name of the encoding
+func (e Encoding) String() string {
+	switch e {
+	case Bech32:
+		return "bech32"
+	case Bech32m:
+		return "bech32m"
+	default:
+		return "invalid"
+	}
+}
+
+// constant returns the value that the checksum of the encoding makes the polymod equal to
+func (e Encoding) constant() uint32 {
+	switch e {
+	case Bech32:
+		return 1
+	case Bech32m:
+		return 0x2bc830a3
+	default:
+		panic("invalid bech32 encoding")
+	}
+}
+
+const (
+	// MaxLength is the maximum length of a bech32 string
+	MaxLength = 90
+	// checksumLength is the number of checksum characters
+	checksumLength = 6
+	// separator separates the human-readable part from the data part
+	separator = '1'
+)
+
+const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var charsetRev [128]int8
+
+func init() {
+	for i := range charsetRev {
+		charsetRev[i] = -1
+	}
+	for i, c := range charset {
+		charsetRev[c] = int8(i)
+	}
+}
+
+var (
+	// ErrInvalidLength the string is too long or too short
+	ErrInvalidLength = errors.New("Invalid bech32 string length")
+	// ErrMixedCase the string has both lowercase and uppercase characters
+	ErrMixedCase = errors.New("Bech32 string has mixed case characters")
+	// ErrMissingSeparator the string has no separator, or it is too close to the start or end
+	ErrMissingSeparator = errors.New("Bech32 string separator is missing or misplaced")
+	// ErrInvalidHRP the human-readable part has invalid characters
+	ErrInvalidHRP = errors.New("Invalid bech32 human-readable part")
+	// ErrInvalidChar the data part has a character which is not in the bech32 character set
+	ErrInvalidChar = errors.New("Invalid bech32 character")
+	// ErrInvalidChecksum the checksum does not match the bech32 or bech32m checksum
+	ErrInvalidChecksum = errors.New("Invalid bech32 checksum")
+	// ErrInvalidDataValue a data value does not fit in 5 bits
+	ErrInvalidDataValue = errors.New("Invalid bech32 data value")
+	// ErrInvalidPadding the padding of the data is not valid in ConvertBits
+	ErrInvalidPadding = errors.New("Invalid bech32 data padding")
+)
+
+// polymod computes the BCH checksum polynomial of the values
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		chk = polymodStep(chk) ^ uint32(v)
+	}
+	return chk
+}
+
+// polymodStep multiplies the checksum state by x, reducing it modulo the generator
+func polymodStep(chk uint32) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	b := chk >> 25
+	chk = (chk & 0x1ffffff) << 5
+	for i := uint(0); i < 5; i++ {
+		if (b>>i)&1 == 1 {
+			chk ^= gen[i]
+		}
+	}
+	return chk
+}
+
+// hrpExpand returns the values of the human-readable part that are included in the checksum
+func hrpExpand(hrp string) []byte {
+	v := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		v = append(v, hrp[i]>>5)
+	}
+	v = append(v, 0)
+	for i := 0; i < len(hrp); i++ {
+		v = append(v, hrp[i]&31)
+	}
+	return v
+}
+
+// residue returns the polymod of the human-readable part and the data, including the checksum
+func residue(hrp string, data []byte) uint32 {
+	return polymod(append(hrpExpand(hrp), data...))
+}
+
+// checksum returns the checksum values of the data
+func checksum(hrp string, data []byte, enc Encoding) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, make([]byte, checksumLength)...)
+	mod := polymod(values) ^ enc.constant()
+
+	c := make([]byte, checksumLength)
+	for i := range c {
+		c[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return c
+}
+
+// validHRP returns true if the human-readable part has 1 to 83 characters in the range [33, 126]
+func validHRP(hrp string) bool {
+	if len(hrp) == 0 || len(hrp) > MaxLength-checksumLength-2 {
+		return false
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode encodes 5-bit data values with the human-readable part hrp.
+// The human-readable part is converted to lowercase.
+func Encode(hrp string, data []byte, enc Encoding) (string, error) {
+	hrp = strings.ToLower(hrp)
+	if !validHRP(hrp) {
+		return "", ErrInvalidHRP
+	}
+	if len(hrp)+1+len(data)+checksumLength > MaxLength {
+		return "", ErrInvalidLength
+	}
+	for _, v := range data {
+		if v > 31 {
+			return "", ErrInvalidDataValue
+		}
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(hrp) + 1 + len(data) + checksumLength)
+	sb.WriteString(hrp)
+	sb.WriteByte(separator)
+	for _, v := range data {
+		sb.WriteByte(charset[v])
+	}
+	for _, v := range checksum(hrp, data, enc) {
+		sb.WriteByte(charset[v])
+	}
+	return sb.String(), nil
+}
+
+// split checks the case and structure of s, and returns its lowercase human-readable part
+// and the 5-bit values of its data part, including the checksum.
+// If the data part has invalid characters, their positions in s are returned with ErrInvalidChar.
+func split(s string) (string, []byte, []int, error) {
+	if len(s) > MaxLength {
+		return "", nil, nil, ErrInvalidLength
+	}
+
+	// Only ASCII letters are case converted, other characters are checked below
+	var hasLower, hasUpper bool
+	b := []byte(s)
+	for i, c := range b {
+		switch {
+		case c >= 'a' && c <= 'z':
+			hasLower = true
+		case c >= 'A' && c <= 'Z':
+			hasUpper = true
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	if hasLower && hasUpper {
+		return "", nil, nil, ErrMixedCase
+	}
+	lower := string(b)
+
+	sep := strings.LastIndexByte(lower, separator)
+	if sep < 1 || sep+1+checksumLength > len(lower) {
+		return "", nil, nil, ErrMissingSeparator
+	}
+
+	hrp := lower[:sep]
+	if !validHRP(hrp) {
+		return "", nil, nil, ErrInvalidHRP
+	}
+
+	var invalid []int
+	data := make([]byte, 0, len(lower)-sep-1)
+	for i := sep + 1; i < len(lower); i++ {
+		c := lower[i]
+		if c >= 128 || charsetRev[c] == -1 {
+			invalid = append(invalid, i)
+			continue
+		}
+		data = append(data, byte(charsetRev[c]))
+	}
+	if len(invalid) != 0 {
+		return "", nil, invalid, ErrInvalidChar
+	}
+
+	return hrp, data, nil, nil
+}
+
+// Decode decodes a bech32 or bech32m string. Returns its lowercase human-readable part,
+// the 5-bit data values without the checksum, and the encoding of its checksum.
+func Decode(s string) (string, []byte, Encoding, error) {
+	hrp, data, _, err := split(s)
+	if err != nil {
+		return "", nil, 0, err
+	}
+
+	var enc Encoding
+	switch residue(hrp, data) {
+	case Bech32.constant():
+		enc = Bech32
+	case Bech32m.constant():
+		enc = Bech32m
+	default:
+		return "", nil, 0, ErrInvalidChecksum
+	}
+
+	return hrp, data[:len(data)-checksumLength], enc, nil
+}
+
+// LocateErrors returns the positions in s of the characters that are likely to be wrong,
+// in increasing order, if s is not a valid bech32 or bech32m string.
+//
+// The positions of characters that are not in the bech32 character set are always returned.
+// Otherwise, up to 2 substituted characters in the data part are located. The checksum of
+// either encoding is used, whichever needs fewer errors to be explained. No positions are
+// returned if s is valid, if the errors can't be located, or if s has a structural error
+// such as mixed case or a missing separator.
+func LocateErrors(s string) []int {
+	hrp, data, invalid, err := split(s)
+	if err == ErrInvalidChar {
+		return invalid
+	}
+	if err != nil {
+		return nil
+	}
+
+	r := residue(hrp, data)
+	if r == Bech32.constant() || r == Bech32m.constant() {
+		return nil
+	}
+
+	offset := len(hrp) + 1
+	var best []int
+	for _, enc := range []Encoding{Bech32, Bech32m} {
+		pos := locateSubstitutions(r^enc.constant(), len(data))
+		if pos != nil && (best == nil || len(pos) < len(best)) {
+			best = pos
+		}
+	}
+
+	for i := range best {
+		best[i] += offset
+	}
+	return best
+}
+
+// errorEffect is a substitution error in the data part, identified by the position of the
+// character and the XOR difference between the wrong and the correct 5-bit values
+type errorEffect struct {
+	pos   int
+	delta byte
+}
+
+// locateSubstitutions finds up to two substitution errors in n data values that change the
+// polymod by diff. Returns the positions of the errors, or nil if there are more than two errors.
+//
+// The polymod is linear over GF(2), so an error changes it by an amount that depends only on the
+// position and the difference of the error, and not on the other values. The change of every
+// single error is tabulated, then diff is looked up directly for one error, or as the sum of the
+// change of each possible first error and a second error from the table for two errors.
+// The bech32 checksum has a distance of at least 5 for strings of up to 90 characters, so an
+// error pattern of one or two substitutions is unique.
+func locateSubstitutions(diff uint32, n int) []int {
+	effects := make(map[uint32]errorEffect, n*31)
+	var bitEffects [5]uint32
+	for pos := n - 1; pos >= 0; pos-- {
+		// The change of a single bit error at pos is the bit shifted through the remaining values
+		if pos == n-1 {
+			for b := range bitEffects {
+				bitEffects[b] = 1 << uint(b)
+			}
+		} else {
+			for b := range bitEffects {
+				bitEffects[b] = polymodStep(bitEffects[b])
+			}
+		}
+
+		for delta := byte(1); delta < 32; delta++ {
+			var e uint32
+			for b := uint(0); b < 5; b++ {
+				if (delta>>b)&1 == 1 {
+					e ^= bitEffects[b]
+				}
+			}
+			effects[e] = errorEffect{pos: pos, delta: delta}
+		}
+	}
+
+	if e, ok := effects[diff]; ok {
+		return []int{e.pos}
+	}
+
+	for e1, err1 := range effects {
+		err2, ok := effects[diff^e1]
+		if !ok || err2.pos == err1.pos {
+			continue
+		}
+		if err1.pos < err2.pos {
+			return []int{err1.pos, err2.pos}
+		}
+		return []int{err2.pos, err1.pos}
+	}
+
+	return nil
+}
+
+// ConvertBits regroups the bits of data values of fromBits bits each into values of toBits bits each.
+// If pad is true, the last value is padded with zeros. If pad is false, the data must have
+// fewer than fromBits bits of zero padding left over.
+func ConvertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<toBits - 1
+	out := make([]byte, 0, (uint(len(data))*fromBits+toBits-1)/toBits)
+
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, ErrInvalidDataValue
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte((acc>>bits)&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			out = append(out, byte((acc<<(toBits-bits))&maxv))
+		}
+	} else if bits >= fromBits || (acc<<(toBits-bits))&maxv != 0 {
+		return nil, ErrInvalidPadding
+	}
+
+	return out, nil
+}
@@ -0,0 +1,174 @@
+package bech32
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeValid(t *testing.T) {
+	cases := []struct {
+		s   string
+		hrp string
+		enc Encoding
+	}{
+		// BIP173 test vectors
+		{"A12UEL5L", "a", Bech32},
+		{"a12uel5l", "a", Bech32},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", "abcdef", Bech32},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", "split", Bech32},
+		{"?1ezyfcl", "?", Bech32},
+		// BIP350 test vectors
+		{"A1LQFN3A", "a", Bech32m},
+		{"a1lqfn3a", "a", Bech32m},
+		{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", "abcdef", Bech32m},
+		{"split1checkupstagehandshakeupstreamerranterredcaperredlc445v", "split", Bech32m},
+		{"?1v759aa", "?", Bech32m},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.s, func(t *testing.T) {
+			hrp, data, enc, err := Decode(tc.s)
+			require.NoError(t, err)
+			require.Equal(t, tc.hrp, hrp)
+			require.Equal(t, tc.enc, enc)
+			require.Empty(t, LocateErrors(tc.s))
+
+			s, err := Encode(hrp, data, enc)
+			require.NoError(t, err)
+			require.Equal(t, strings.ToLower(tc.s), s)
+
+			// Changing a character invalidates the checksum
+			b := []byte(strings.ToLower(tc.s))
+			i := len(b) - 1
+			if b[i] == 'q' {
+				b[i] = 'p'
+			} else {
+				b[i] = 'q'
+			}
+			_, _, _, err = Decode(string(b))
+			require.Equal(t, ErrInvalidChecksum, err)
+			require.Equal(t, []int{i}, LocateErrors(string(b)))
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	cases := []struct {
+		s   string
+		err error
+	}{
+		{"pzry9x0s0muk", ErrMissingSeparator},
+		{"1pzry9x0s0muk", ErrMissingSeparator},
+		{"x1b4n0q5v", ErrInvalidChar},
+		{"li1dgmt3", ErrMissingSeparator},
+		{"A1G7SGD8", ErrInvalidChecksum},
+		{"10a06t8", ErrMissingSeparator},
+		{"1qzzfhee", ErrMissingSeparator},
+		{"a12UEL5L", ErrMixedCase},
+		{"\x201nwldj5", ErrInvalidHRP},
+		{"de1lg7wt\xff", ErrInvalidChar},
+		{"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", ErrInvalidLength},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.s, func(t *testing.T) {
+			_, _, _, err := Decode(tc.s)
+			require.Equal(t, tc.err, err)
+		})
+	}
+
+	require.Equal(t, []int{2}, LocateErrors("x1b4n0q5v"))
+	require.Empty(t, LocateErrors("a12UEL5L"))
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	_, err := Encode("", nil, Bech32)
+	require.Equal(t, ErrInvalidHRP, err)
+
+	_, err = Encode("a b", nil, Bech32)
+	require.Equal(t, ErrInvalidHRP, err)
+
+	_, err = Encode("a", []byte{32}, Bech32)
+	require.Equal(t, ErrInvalidDataValue, err)
+
+	_, err = Encode("a", make([]byte, 83), Bech32)
+	require.Equal(t, ErrInvalidLength, err)
+
+	s, err := Encode("a", make([]byte, 82), Bech32)
+	require.NoError(t, err)
+	require.Len(t, s, MaxLength)
+
+	// The human-readable part is lowercased
+	s, err = Encode("SKY", []byte{1, 2, 3}, Bech32m)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(s, "sky1"))
+}
+
+func TestLocateErrors(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, enc := range []Encoding{Bech32, Bech32m} {
+		for i := 0; i < 100; i++ {
+			data := make([]byte, 1+r.Intn(60))
+			for j := range data {
+				data[j] = byte(r.Intn(32))
+			}
+			s, err := Encode("sky", data, enc)
+			require.NoError(t, err)
+
+			// Substitute 1 or 2 characters of the data part
+			nErrs := 1 + i%2
+			b := []byte(s)
+			var positions []int
+			for len(positions) < nErrs {
+				pos := 4 + r.Intn(len(b)-4)
+				if len(positions) == 1 && positions[0] == pos {
+					continue
+				}
+				positions = append(positions, pos)
+				c := b[pos]
+				for c == b[pos] {
+					c = charset[r.Intn(32)]
+				}
+				b[pos] = c
+			}
+			if len(positions) == 2 && positions[0] > positions[1] {
+				positions[0], positions[1] = positions[1], positions[0]
+			}
+
+			_, _, _, err = Decode(string(b))
+			require.Equal(t, ErrInvalidChecksum, err)
+			require.Equal(t, positions, LocateErrors(string(b)), "%s %s", s, string(b))
+
+			// Uppercase strings are located the same way
+			require.Equal(t, positions, LocateErrors(strings.ToUpper(string(b))))
+		}
+	}
+}
+
+func TestConvertBits(t *testing.T) {
+	data := []byte{0x00, 0x01, 0xFF, 0x80}
+	b5, err := ConvertBits(data, 8, 5, true)
+	require.NoError(t, err)
+	require.Len(t, b5, 7)
+
+	b8, err := ConvertBits(b5, 5, 8, false)
+	require.NoError(t, err)
+	require.Equal(t, data, b8)
+
+	// Non-zero padding
+	b5[len(b5)-1] |= 1
+	_, err = ConvertBits(b5, 5, 8, false)
+	require.Equal(t, ErrInvalidPadding, err)
+
+	// Too much padding, a whole value of zeros is left over
+	_, err = ConvertBits([]byte{0}, 5, 8, false)
+	require.Equal(t, ErrInvalidPadding, err)
+
+	// Values too large
+	_, err = ConvertBits([]byte{32}, 5, 8, true)
+	require.Equal(t, ErrInvalidDataValue, err)
+}
@@ -9,15 +9,16 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
-	"sync"
 
-	"github.com/skycoin/skycoin/src/cipher"
-	"github.com/skycoin/skycoin/src/cipher/bip39/wordlists"
+	"golang.org/x/text/unicode/norm"
 
+	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/cipher/pbkdf2"
 )
 
@@ -45,16 +46,6 @@ var (
 		18: big.NewInt(4),
 		21: big.NewInt(2),
 	}
-
-	// wordList is the set of words to use
-	wordList []string
-
-	// wordMap is a reverse lookup map for wordList
-	wordMap map[string]int
-
-	// wordListMutex is held when changing wordList
-	// TODO -- make wordList non-global
-	wordListMutex sync.Mutex
 )
 
 var (
@@ -76,38 +67,13 @@ var (
 
 	// ErrInvalidNumberOfWords is returned if a mnemonic sentence does not have 12, 15, 18, 21 or 24 words
 	ErrInvalidNumberOfWords = errors.New("Mnemonic must have 12, 15, 18, 21 or 24 words")
-)
 
-func init() {
-	setWordList(wordlists.English)
-}
-
-// setWordList sets the list of words to use for mnemonics. Currently the list
-// that is set is used package-wide.
-func setWordList(list []string) {
-	wordListMutex.Lock()
-	defer wordListMutex.Unlock()
-	wordList = list
-	wordMap = map[string]int{}
-	for i, v := range wordList {
-		wordMap[v] = i
-	}
-}
-
-// getWordList gets the list of words to use for mnemonics.
-func getWordList() []string {
-	wordListMutex.Lock()
-	defer wordListMutex.Unlock()
-	return wordList
-}
+	// ErrInvalidDiceRoll is returned if dice rolls have a character other than the digits 1 to 6
+	ErrInvalidDiceRoll = errors.New("Dice rolls must be digits from 1 to 6")
 
-// getWordIndex gets word index in wordMap.
-func getWordIndex(word string) (int, bool) {
-	wordListMutex.Lock()
-	defer wordListMutex.Unlock()
-	idx, ok := wordMap[word]
-	return idx, ok
-}
+	// ErrNotEnoughDiceRolls is returned if there are too few dice rolls for the requested entropy size
+	ErrNotEnoughDiceRolls = errors.New("Not enough dice rolls for the entropy size")
+)
 
 // DefaultMnemonicEntropyBitSize is the default bit size for NewDefaultMnemonic's entropy
 // TODO -- make 24 word seeds default? (256 bits entropy - recommended for HD wallets)
@@ -146,11 +112,58 @@ func NewEntropy(bitSize int) ([]byte, error) {
 	return entropy, err
 }
 
+// EntropyFromHex decodes hex encoded entropy, such as entropy generated
+// outside of this library. The entropy must have a valid size for a mnemonic.
+func EntropyFromHex(s string) ([]byte, error) {
+	entropy, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateEntropyBitSize(len(entropy) * 8); err != nil {
+		return nil, err
+	}
+
+	return entropy, nil
+}
+
+// MinDiceRolls returns the number of rolls of a six-sided die needed to make
+// entropy of bitSize bits with EntropyFromDiceRolls
+func MinDiceRolls(bitSize int) int {
+	return int(math.Ceil(float64(bitSize) / math.Log2(6)))
+}
+
+// EntropyFromDiceRolls makes entropy of bitSize bits from rolls of a six-sided die,
+// written as a string of digits from 1 to 6, such as "3216...".
+//
+// The entropy is the SHA256 hash of the rolls, truncated to bitSize bits. There must be
+// at least MinDiceRolls(bitSize) rolls, so that the rolls have at least bitSize bits
+// of entropy. Hashing the rolls keeps the entropy uniform if the die is slightly biased.
+func EntropyFromDiceRolls(rolls string, bitSize int) ([]byte, error) {
+	if err := validateEntropyBitSize(bitSize); err != nil {
+		return nil, err
+	}
+
+	for _, c := range rolls {
+		if c < '1' || c > '6' {
+			return nil, ErrInvalidDiceRoll
+		}
+	}
+
+	if len(rolls) < MinDiceRolls(bitSize) {
+		return nil, ErrNotEnoughDiceRolls
+	}
+
+	h := sha256.Sum256([]byte(rolls))
+	return h[:bitSize/8], nil
+}
+
 // EntropyFromMnemonic takes a mnemonic generated by this library,
 // and returns the input entropy used to generate the given mnemonic.
+// The language of the mnemonic is detected as by DetectLanguage.
 // An error is returned if the given mnemonic is invalid.
 func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
-	words, err := splitMnemonicWords(mnemonic)
+	words, lang, err := splitMnemonicWords(mnemonic)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +171,7 @@ func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
 	// Decode the words into a big.Int.
 	b := big.NewInt(0)
 	for _, v := range words {
-		index, found := wordMap[v]
+		index, found := wordLists[lang].index[v]
 		if !found {
 			// This should have been caught by splitMnemonicWords()
 			panic(fmt.Sprintf("word %q not found in reverse map", v))
@@ -197,10 +210,23 @@ func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
 	return entropy, nil
 }
 
-// NewMnemonic will return a string consisting of the mnemonic words for
+// NewMnemonic will return a string consisting of the english mnemonic words for
 // the given entropy.
 // If the provide entropy is invalid, an error will be returned.
 func NewMnemonic(entropy []byte) (string, error) {
+	return NewMnemonicInLanguage(entropy, DefaultLanguage)
+}
+
+// NewMnemonicInLanguage will return a string consisting of the mnemonic words
+// of the language for the given entropy. The words of japanese mnemonics are
+// separated by ideographic spaces, as in the BIP39 spec.
+// If the provide entropy is invalid, an error will be returned.
+func NewMnemonicInLanguage(entropy []byte, lang Language) (string, error) {
+	wl, ok := wordLists[lang]
+	if !ok {
+		return "", ErrUnknownLanguage
+	}
+
 	// Compute some lengths for convenience.
 	entropyBitLength := len(entropy) * 8
 	checksumBitLength := entropyBitLength / 32
@@ -238,10 +264,10 @@ func NewMnemonic(entropy []byte) (string, error) {
 		wordBytes := padByteSlice(word.Bytes(), 2)
 
 		// Convert bytes to an index and add that word to the list.
-		words[i] = wordList[binary.BigEndian.Uint16(wordBytes)]
+		words[i] = wl.words[binary.BigEndian.Uint16(wordBytes)]
 	}
 
-	return strings.Join(words, " "), nil
+	return strings.Join(words, lang.separator()), nil
 }
 
 // NewSeed creates a hashed seed output given the mnemonic string and a password.
@@ -256,25 +282,31 @@ func NewSeed(mnemonic string, password string) ([]byte, error) {
 
 // newSeed creates a hashed seed output given a provided string and password.
 // No checking is performed to validate that the string provided is a valid mnemonic.
+//
+// The mnemonic is NFKD normalized as in the BIP39 spec, so that the seed does not depend
+// on the unicode form of its accented characters or the separator of japanese mnemonics.
+// The password is used as is, to keep the seeds of existing wallets unchanged. It must
+// be NFKD normalized by the caller for the seed to match other BIP39 implementations
+// if it has non-ASCII characters.
 func newSeed(mnemonic, password string) []byte {
-	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+password), 2048, 64, sha512.New)
+	return pbkdf2.Key([]byte(norm.NFKD.String(mnemonic)), []byte("mnemonic"+password), 2048, 64, sha512.New)
 }
 
 // ValidateMnemonic returns an error if a mnemonic is invalid. It can be invalid
 // for these reasons:
 // - Number of words not a multiple of 3 and not at least 12 or at most 24 words
-// - Words are not separated by exactly one ascii space
+// - Words are not separated by exactly one ascii space, or one ideographic space
 // - Mnemonic string has leading or trailing whitespace
-// - Any word is not present in the wordlist
+// - No wordlist has all of the words
 // - The mnemonic checksum is incorrect
-// Note: this only works on ascii wordlists. Japanese, Chinese etc are not supported yet.
+// The mnemonic can be in any of the supported languages, see DetectLanguage.
 func ValidateMnemonic(mnemonic string) error {
-	words, err := splitMnemonicWords(mnemonic)
+	words, lang, err := splitMnemonicWords(mnemonic)
 	if err != nil {
 		return err
 	}
 
-	if !isMnemonicChecksumValid(words) {
+	if !isMnemonicChecksumValid(words, lang) {
 		return ErrChecksumIncorrect
 	}
 
@@ -283,20 +315,24 @@ func ValidateMnemonic(mnemonic string) error {
 
 // splitMnemonicWords attempts to verify that the provided mnemonic is valid.
 // Validity is determined by both the number of words being appropriate,
-// and that all the words in the mnemonic are present in the word list.
-func splitMnemonicWords(mnemonic string) ([]string, error) {
+// and that all the words in the mnemonic are present in the word list of a language.
+// Returns the NFKD normalized words and the language of the mnemonic.
+func splitMnemonicWords(mnemonic string) ([]string, Language, error) {
 	// Make sure no leading/trailing whitespace
 	if mnemonic != strings.TrimSpace(mnemonic) {
-		return nil, ErrSurroundingWhitespace
+		return nil, "", ErrSurroundingWhitespace
 	}
 
+	// NFKD normalization also converts the ideographic spaces of japanese mnemonics to ascii spaces
+	mnemonic = norm.NFKD.String(mnemonic)
+
 	// Create a list of all the words in the mnemonic sentence
 	words := strings.Split(mnemonic, " ")
 
 	// Detect duplicate whitespace
 	for _, w := range words {
 		if w == "" {
-			return nil, ErrInvalidSeparator
+			return nil, "", ErrInvalidSeparator
 		}
 	}
 
@@ -304,21 +340,20 @@ func splitMnemonicWords(mnemonic string) ([]string, error) {
 
 	// The number of words should be 12, 15, 18, 21 or 24
 	if numOfWords%3 != 0 || numOfWords < 12 || numOfWords > 24 {
-		return nil, ErrInvalidNumberOfWords
+		return nil, "", ErrInvalidNumberOfWords
 	}
 
-	// Check if all words belong in the wordlist
-	for _, word := range words {
-		if _, ok := wordMap[word]; !ok {
-			return nil, ErrUnknownWord
-		}
+	// Check if all words belong in the wordlist of a language
+	lang, err := detectLanguage(words)
+	if err != nil {
+		return nil, "", err
 	}
 
-	return words, nil
+	return words, lang, nil
 }
 
-// isMnemonicChecksumValid validates the checksum value of a mnemonic
-func isMnemonicChecksumValid(words []string) bool {
+// isMnemonicChecksumValid validates the checksum value of a mnemonic of NFKD normalized words in the language
+func isMnemonicChecksumValid(words []string, lang Language) bool {
 	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
 		panic("invalid number of words") // caller should validate words before passing to this function
 	}
@@ -334,7 +369,7 @@ func isMnemonicChecksumValid(words []string) bool {
 	checksummedEntropy := big.NewInt(0)
 	modulo := big.NewInt(2048)
 	for _, v := range words {
-		index := big.NewInt(int64(wordMap[v]))
+		index := big.NewInt(int64(wordLists[lang].index[v]))
 		checksummedEntropy.Mul(checksummedEntropy, modulo)
 		checksummedEntropy.Add(checksummedEntropy, index)
 	}
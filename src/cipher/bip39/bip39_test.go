@@ -2,11 +2,13 @@ package bip39
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/unicode/norm"
 
 	"github.com/skycoin/skycoin/src/cipher/bip39/wordlists"
 )
@@ -18,21 +20,31 @@ type vector struct {
 	err      error
 }
 
-func TestGetWordList(t *testing.T) {
-	require.Equal(t, wordlists.English, getWordList())
-}
+func TestWordList(t *testing.T) {
+	words, err := WordList(English)
+	require.NoError(t, err)
+	require.Equal(t, wordlists.English, words)
+
+	_, err = WordList(Language("klingon"))
+	require.Equal(t, ErrUnknownLanguage, err)
+
+	for _, lang := range Languages() {
+		t.Run(string(lang), func(t *testing.T) {
+			words, err := WordList(lang)
+			require.NoError(t, err)
+			require.Len(t, words, 2048)
 
-func TestGetWordIndex(t *testing.T) {
-	for expectedIdx, word := range wordList {
-		actualIdx, ok := getWordIndex(word)
-		require.True(t, ok)
-		require.Equal(t, actualIdx, expectedIdx)
+			for expectedIdx, word := range words {
+				actualIdx, ok := wordLists[lang].index[norm.NFKD.String(word)]
+				require.True(t, ok)
+				require.Equal(t, expectedIdx, actualIdx)
+			}
+		})
 	}
 
 	for _, word := range []string{"a", "set", "of", "invalid", "words"} {
-		actualIdx, ok := getWordIndex(word)
+		_, ok := wordLists[English].index[word]
 		require.False(t, ok)
-		require.Equal(t, actualIdx, 0)
 	}
 }
 
@@ -51,6 +63,166 @@ func TestNewMnemonic(t *testing.T) {
 	}
 }
 
+func TestNewMnemonicInLanguage(t *testing.T) {
+	for _, lang := range Languages() {
+		t.Run(string(lang), func(t *testing.T) {
+			h := sha256.Sum256([]byte(lang))
+			for _, size := range []int{16, 20, 24, 28, 32} {
+				entropy := h[:size]
+
+				mnemonic, err := NewMnemonicInLanguage(entropy, lang)
+				require.NoError(t, err)
+				require.Equal(t, size*3/4, len(strings.Split(mnemonic, lang.separator())))
+
+				require.NoError(t, ValidateMnemonic(mnemonic))
+
+				detected, err := DetectLanguage(mnemonic)
+				require.NoError(t, err)
+				require.Equal(t, lang, detected)
+
+				e, err := EntropyFromMnemonic(mnemonic)
+				require.NoError(t, err)
+				require.Equal(t, entropy, e)
+			}
+		})
+	}
+
+	_, err := NewMnemonicInLanguage(make([]byte, 16), Language("klingon"))
+	require.Equal(t, ErrUnknownLanguage, err)
+
+	// The words shared by the chinese wordlists have the same index in both,
+	// so a mnemonic of these words is valid in both languages
+	mnemonic, err := NewMnemonicInLanguage(make([]byte, 16), ChineseTraditional)
+	require.NoError(t, err)
+	lang, err := DetectLanguage(mnemonic)
+	require.NoError(t, err)
+	require.Equal(t, ChineseSimplified, lang)
+}
+
+func TestNewSeedJapanese(t *testing.T) {
+	// Test vector of https://github.com/bip32JP/bip32JP.github.io/blob/master/test_JP_BIP39.json
+	entropy, err := hex.DecodeString("00000000000000000000000000000000")
+	require.NoError(t, err)
+
+	mnemonic, err := NewMnemonicInLanguage(entropy, Japanese)
+	require.NoError(t, err)
+	require.Equal(t, norm.NFKD.String("あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら"), norm.NFKD.String(mnemonic))
+
+	// The password is not normalized by NewSeed
+	password := norm.NFKD.String("㍍ガバヴァぱばぐゞちぢ十人十色")
+	expectedSeed := "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55"
+
+	seed, err := NewSeed(mnemonic, password)
+	require.NoError(t, err)
+	require.Equal(t, expectedSeed, hex.EncodeToString(seed))
+
+	// The words may also be separated by ascii spaces
+	seed, err = NewSeed(strings.Replace(mnemonic, ideographicSpace, " ", -1), password)
+	require.NoError(t, err)
+	require.Equal(t, expectedSeed, hex.EncodeToString(seed))
+
+	// Separators can't be repeated
+	err = ValidateMnemonic(strings.Replace(mnemonic, ideographicSpace, ideographicSpace+ideographicSpace, 1))
+	require.Equal(t, ErrInvalidSeparator, err)
+}
+
+func TestNewSeedUnicodeForms(t *testing.T) {
+	entropy := sha256.Sum256([]byte("french"))
+	mnemonic, err := NewMnemonicInLanguage(entropy[:16], French)
+	require.NoError(t, err)
+
+	nfc := norm.NFC.String(mnemonic)
+	nfd := norm.NFD.String(mnemonic)
+	require.NotEqual(t, nfc, nfd)
+
+	seedNFC, err := NewSeed(nfc, "")
+	require.NoError(t, err)
+	seedNFD, err := NewSeed(nfd, "")
+	require.NoError(t, err)
+	require.Equal(t, seedNFC, seedNFD)
+}
+
+func TestParseLanguage(t *testing.T) {
+	for _, lang := range Languages() {
+		l, err := ParseLanguage(string(lang))
+		require.NoError(t, err)
+		require.Equal(t, lang, l)
+	}
+
+	l, err := ParseLanguage("Chinese-Simplified")
+	require.NoError(t, err)
+	require.Equal(t, ChineseSimplified, l)
+
+	_, err = ParseLanguage("")
+	require.Equal(t, ErrUnknownLanguage, err)
+
+	_, err = ParseLanguage("klingon")
+	require.Equal(t, ErrUnknownLanguage, err)
+}
+
+func TestDetectLanguageMixed(t *testing.T) {
+	words := strings.Split(MustNewDefaultMnemonic(), " ")
+
+	// Replace a word by a word which is only in the spanish wordlist
+	words[3] = "ábaco"
+	_, err := DetectLanguage(strings.Join(words, " "))
+	require.Equal(t, ErrUnknownWord, err)
+
+	// A mnemonic with only this word is spanish, whatever its unicode form
+	for _, m := range []string{
+		strings.TrimSpace(strings.Repeat("ábaco ", 12)),
+		norm.NFD.String(strings.TrimSpace(strings.Repeat("ábaco ", 12))),
+	} {
+		lang, err := DetectLanguage(m)
+		require.NoError(t, err)
+		require.Equal(t, Spanish, lang)
+	}
+}
+
+func TestEntropyFromHex(t *testing.T) {
+	e, err := EntropyFromHex("066dca1a2bb7e8a1db2832148ce9933eea0f3ac9548d793112d9a95c9407efad")
+	require.NoError(t, err)
+	require.Len(t, e, 32)
+
+	_, err = EntropyFromHex("066dca1a")
+	require.Equal(t, ErrInvalidEntropyLength, err)
+
+	_, err = EntropyFromHex("xx")
+	require.Error(t, err)
+}
+
+func TestEntropyFromDiceRolls(t *testing.T) {
+	require.Equal(t, 50, MinDiceRolls(128))
+	require.Equal(t, 62, MinDiceRolls(160))
+	require.Equal(t, 75, MinDiceRolls(192))
+	require.Equal(t, 87, MinDiceRolls(224))
+	require.Equal(t, 100, MinDiceRolls(256))
+
+	rolls := strings.Repeat("1234561", 15)[:100]
+
+	e, err := EntropyFromDiceRolls(rolls, 256)
+	require.NoError(t, err)
+	h := sha256.Sum256([]byte(rolls))
+	require.Equal(t, h[:], e)
+
+	e, err = EntropyFromDiceRolls(rolls[:50], 128)
+	require.NoError(t, err)
+	h = sha256.Sum256([]byte(rolls[:50]))
+	require.Equal(t, h[:16], e)
+
+	_, err = EntropyFromDiceRolls(rolls[:99], 256)
+	require.Equal(t, ErrNotEnoughDiceRolls, err)
+
+	_, err = EntropyFromDiceRolls(rolls[:49]+"7", 128)
+	require.Equal(t, ErrInvalidDiceRoll, err)
+
+	_, err = EntropyFromDiceRolls(rolls[:49]+" 1", 128)
+	require.Equal(t, ErrInvalidDiceRoll, err)
+
+	_, err = EntropyFromDiceRolls(rolls, 100)
+	require.Equal(t, ErrInvalidEntropyLength, err)
+}
+
 func TestNewMnemonicInvalidEntropy(t *testing.T) {
 	_, err := NewMnemonic([]byte{})
 	require.Error(t, err)
@@ -206,7 +378,7 @@ func TestIsMnemonicChecksumValidForDifferentArrayLangths(t *testing.T) {
 			t.Errorf("%v", err)
 		}
 
-		isValid := isMnemonicChecksumValid(strings.Split(mnemonic, " "))
+		isValid := isMnemonicChecksumValid(strings.Split(mnemonic, " "), English)
 		require.True(t, isValid)
 	}
 }
@@ -305,7 +477,7 @@ func TestIsMnemonicChecksumValidForZeroLeadingSeeds(t *testing.T) {
 		mnemonic, err := NewMnemonic(seed)
 		require.NoError(t, err)
 
-		isValid := isMnemonicChecksumValid(strings.Split(mnemonic, " "))
+		isValid := isMnemonicChecksumValid(strings.Split(mnemonic, " "), English)
 		require.True(t, isValid)
 	}
 }
@@ -0,0 +1,185 @@
+package bip39
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Correction is the replacement of one word of an invalid mnemonic that makes it valid
+type Correction struct {
+	// Position is the 0-based position of the replaced word in the mnemonic
+	Position int
+	// Word is the replaced word
+	Word string
+	// Replacement is the word of the wordlist that replaces it
+	Replacement string
+	// Mnemonic is the corrected mnemonic
+	Mnemonic string
+	// distance is the edit distance between Word and Replacement
+	distance int
+}
+
+// SuggestCorrections returns the corrections of a single mistyped word that make
+// an invalid mnemonic valid, most likely first.
+//
+// If a word of the mnemonic is not in the wordlist, only that word is corrected. Otherwise,
+// the mistyped word may have become another word of the wordlist, and every word is tried.
+// Replacements are words of the wordlist that are within 2 edits of the mistyped word,
+// or that start with the same 4 characters, and that make the checksum valid.
+//
+// The words may be separated by any whitespace. No corrections are returned for a valid
+// mnemonic, or for a mnemonic with an invalid number of words or with more than one
+// word that is not in the wordlist.
+func SuggestCorrections(mnemonic string) []Correction {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if n := len(words); n%3 != 0 || n < 12 || n > 24 {
+		return nil
+	}
+
+	lang, unknown := closestLanguage(words)
+	wl := wordLists[lang]
+
+	var positions []int
+	switch len(unknown) {
+	case 0:
+		if isMnemonicChecksumValid(words, lang) {
+			return nil
+		}
+		for i := range words {
+			positions = append(positions, i)
+		}
+	case 1:
+		positions = unknown
+	default:
+		return nil
+	}
+
+	var corrections []Correction
+	candidate := make([]string, len(words))
+	for _, pos := range positions {
+		word := []rune(words[pos])
+		for _, w := range wl.words {
+			replacement := norm.NFKD.String(w)
+			if replacement == words[pos] {
+				continue
+			}
+
+			d, ok := similarWords(word, []rune(replacement))
+			if !ok {
+				continue
+			}
+
+			copy(candidate, words)
+			candidate[pos] = replacement
+			if !isMnemonicChecksumValid(candidate, lang) {
+				continue
+			}
+
+			corrections = append(corrections, Correction{
+				Position:    pos,
+				Word:        words[pos],
+				Replacement: w,
+				Mnemonic:    wl.mnemonic(candidate, lang),
+				distance:    d,
+			})
+		}
+	}
+
+	sort.SliceStable(corrections, func(i, j int) bool {
+		if corrections[i].distance != corrections[j].distance {
+			return corrections[i].distance < corrections[j].distance
+		}
+		return corrections[i].Position < corrections[j].Position
+	})
+
+	return corrections
+}
+
+// closestLanguage returns the language whose wordlist has the most of the NFKD normalized
+// words, and the positions of the words that are not in its wordlist
+func closestLanguage(words []string) (Language, []int) {
+	var best Language
+	var bestUnknown []int
+	for _, lang := range languages {
+		var unknown []int
+		for i, w := range words {
+			if _, ok := wordLists[lang].index[w]; !ok {
+				unknown = append(unknown, i)
+			}
+		}
+
+		if best == "" || len(unknown) < len(bestUnknown) {
+			best = lang
+			bestUnknown = unknown
+		}
+	}
+	return best, bestUnknown
+}
+
+// mnemonic returns the mnemonic of NFKD normalized words of the wordlist
+func (wl *wordList) mnemonic(words []string, lang Language) string {
+	ws := make([]string, len(words))
+	for i, w := range words {
+		ws[i] = wl.words[wl.index[w]]
+	}
+	return strings.Join(ws, lang.separator())
+}
+
+// similarWords returns the edit distance between a mistyped word and a word of a wordlist,
+// and whether the word is a plausible correction of the mistyped word
+func similarWords(mistyped, word []rune) (int, bool) {
+	d := editDistance(mistyped, word)
+	if d <= 2 && d < len(word) {
+		return d, true
+	}
+
+	// The words of most wordlists are unique by their first 4 characters
+	if len(mistyped) >= 4 && len(word) >= 4 && string(mistyped[:4]) == string(word[:4]) {
+		return d, true
+	}
+
+	return d, false
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and transpositions
+// of adjacent characters that change a into b (the optimal string alignment distance)
+func editDistance(a, b []rune) int {
+	// d[i][j] is the distance between a[:i] and b[:j]
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
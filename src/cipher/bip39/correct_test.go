@@ -0,0 +1,172 @@
+package bip39
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestCorrections(t *testing.T) {
+	valid := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	require.NoError(t, ValidateMnemonic(valid))
+
+	requireCorrection := func(t *testing.T, corrections []Correction, c Correction) {
+		for _, x := range corrections {
+			if x.Position == c.Position && x.Replacement == c.Replacement {
+				require.Equal(t, c.Word, x.Word)
+				require.Equal(t, c.Mnemonic, x.Mnemonic)
+				require.NoError(t, ValidateMnemonic(x.Mnemonic))
+				return
+			}
+		}
+		t.Fatalf("correction %+v not found in %+v", c, corrections)
+	}
+
+	cases := []struct {
+		name     string
+		mnemonic string
+		first    *Correction
+		contains *Correction
+		none     bool
+	}{
+		{
+			name:     "valid",
+			mnemonic: valid,
+			none:     true,
+		},
+		{
+			name:     "transposed letters",
+			mnemonic: "legal winner thank year wave sausgae worth useful legal winner thank yellow",
+			first: &Correction{
+				Position:    5,
+				Word:        "sausgae",
+				Replacement: "sausage",
+				Mnemonic:    valid,
+			},
+		},
+		{
+			name:     "missing letter, extra whitespace",
+			mnemonic: " legal winner  thank year wave sausage worth useful legal winner thank yelow ",
+			contains: &Correction{
+				Position:    11,
+				Word:        "yelow",
+				Replacement: "yellow",
+				Mnemonic:    valid,
+			},
+		},
+		{
+			name:     "truncated word",
+			mnemonic: "legal winner thank year wave sausa worth useful legal winner thank yellow",
+			contains: &Correction{
+				Position:    5,
+				Word:        "sausa",
+				Replacement: "sausage",
+				Mnemonic:    valid,
+			},
+		},
+		{
+			name:     "mistyped into another word, invalid checksum",
+			mnemonic: "legal winner thank year save sausage worth useful legal winner thank yellow",
+			contains: &Correction{
+				Position:    4,
+				Word:        "save",
+				Replacement: "wave",
+				Mnemonic:    valid,
+			},
+		},
+		{
+			name:     "two unknown words",
+			mnemonic: "legal winner thank year wave sausgae worth useful legal winner thank yelow",
+			none:     true,
+		},
+		{
+			name:     "invalid number of words",
+			mnemonic: "legal winner thank year wave sausgae worth useful legal winner thank",
+			none:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			corrections := SuggestCorrections(tc.mnemonic)
+			if tc.none {
+				require.Empty(t, corrections)
+				return
+			}
+
+			require.NotEmpty(t, corrections)
+			for _, c := range corrections {
+				require.NoError(t, ValidateMnemonic(c.Mnemonic))
+			}
+
+			if tc.first != nil {
+				c := corrections[0]
+				require.Equal(t, tc.first.Position, c.Position)
+				require.Equal(t, tc.first.Word, c.Word)
+				require.Equal(t, tc.first.Replacement, c.Replacement)
+				require.Equal(t, tc.first.Mnemonic, c.Mnemonic)
+			}
+
+			if tc.contains != nil {
+				requireCorrection(t, corrections, *tc.contains)
+			}
+		})
+	}
+}
+
+func TestSuggestCorrectionsLanguages(t *testing.T) {
+	for _, lang := range []Language{Spanish, French, Italian, Japanese, Korean} {
+		t.Run(string(lang), func(t *testing.T) {
+			entropy := make([]byte, 16)
+			for i := range entropy {
+				entropy[i] = byte(i * 17)
+			}
+			mnemonic, err := NewMnemonicInLanguage(entropy, lang)
+			require.NoError(t, err)
+
+			// Drop the last character of a word
+			words := strings.Split(mnemonic, lang.separator())
+			word := []rune(words[2])
+			words[2] = string(word[:len(word)-1])
+			if _, ok := wordLists[lang].index[words[2]]; ok {
+				t.Skip("the shortened word is in the wordlist")
+			}
+
+			corrections := SuggestCorrections(strings.Join(words, " "))
+			require.NotEmpty(t, corrections)
+
+			var found bool
+			for _, c := range corrections {
+				require.Equal(t, 2, c.Position)
+				require.NoError(t, ValidateMnemonic(c.Mnemonic))
+				if c.Mnemonic == mnemonic {
+					found = true
+				}
+			}
+			require.True(t, found)
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		d    int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"abc", "abd", 1},
+		{"abc", "acb", 1},
+		{"abc", "ab", 1},
+		{"abc", "abcd", 1},
+		{"kitten", "sitting", 3},
+		{"ábaco", "abaco", 1},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.d, editDistance([]rune(tc.a), []rune(tc.b)), "%s %s", tc.a, tc.b)
+		require.Equal(t, tc.d, editDistance([]rune(tc.b), []rune(tc.a)), "%s %s", tc.b, tc.a)
+	}
+}
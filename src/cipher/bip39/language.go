@@ -0,0 +1,173 @@
+package bip39
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/skycoin/skycoin/src/cipher/bip39/wordlists"
+)
+
+// Language is the language of a mnemonic wordlist
+type Language string
+
+const (
+	// English is the english wordlist
+	English Language = "english"
+	// ChineseSimplified is the simplified chinese wordlist
+	ChineseSimplified Language = "chinese_simplified"
+	// ChineseTraditional is the traditional chinese wordlist
+	ChineseTraditional Language = "chinese_traditional"
+	// French is the french wordlist
+	French Language = "french"
+	// Italian is the italian wordlist
+	Italian Language = "italian"
+	// Japanese is the japanese wordlist
+	Japanese Language = "japanese"
+	// Korean is the korean wordlist
+	Korean Language = "korean"
+	// Spanish is the spanish wordlist
+	Spanish Language = "spanish"
+)
+
+// DefaultLanguage is the language of mnemonics generated by NewMnemonic and NewDefaultMnemonic
+const DefaultLanguage = English
+
+// ideographicSpace separates the words of japanese mnemonics
+const ideographicSpace = "　"
+
+// ErrUnknownLanguage is returned for a language that has no wordlist
+var ErrUnknownLanguage = errors.New("Unknown mnemonic language")
+
+// wordList is a wordlist with a reverse lookup map of its words
+type wordList struct {
+	words []string
+	// index maps the NFKD normalized words to their position in words
+	index map[string]int
+}
+
+var (
+	// languages are the supported languages, in the order in which they are
+	// tried when the language of a mnemonic is detected
+	languages = []Language{
+		English,
+		Spanish,
+		French,
+		Italian,
+		Japanese,
+		Korean,
+		ChineseSimplified,
+		ChineseTraditional,
+	}
+
+	wordLists = map[Language]*wordList{}
+)
+
+func init() {
+	for lang, words := range map[Language][]string{
+		English:            wordlists.English,
+		ChineseSimplified:  wordlists.ChineseSimplified,
+		ChineseTraditional: wordlists.ChineseTraditional,
+		French:             wordlists.French,
+		Italian:            wordlists.Italian,
+		Japanese:           wordlists.Japanese,
+		Korean:             wordlists.Korean,
+		Spanish:            wordlists.Spanish,
+	} {
+		if len(words) != 2048 {
+			panic("bip39 wordlist " + string(lang) + " must have 2048 words")
+		}
+
+		wl := &wordList{
+			words: words,
+			index: make(map[string]int, len(words)),
+		}
+		for i, w := range words {
+			wl.index[norm.NFKD.String(w)] = i
+		}
+		wordLists[lang] = wl
+	}
+}
+
+// Languages returns the languages that have a wordlist
+func Languages() []Language {
+	langs := make([]Language, len(languages))
+	copy(langs, languages)
+	return langs
+}
+
+// ParseLanguage parses a language name, case insensitively.
+// "-" is accepted in place of "_", e.g. "chinese-simplified".
+func ParseLanguage(s string) (Language, error) {
+	lang := Language(strings.Replace(strings.ToLower(s), "-", "_", -1))
+	if _, ok := wordLists[lang]; !ok {
+		return "", ErrUnknownLanguage
+	}
+	return lang, nil
+}
+
+// WordList returns the wordlist of a language
+func WordList(lang Language) ([]string, error) {
+	wl, ok := wordLists[lang]
+	if !ok {
+		return nil, ErrUnknownLanguage
+	}
+
+	words := make([]string, len(wl.words))
+	copy(words, wl.words)
+	return words, nil
+}
+
+// DetectLanguage returns the language of a mnemonic, which is the language of the
+// wordlist that has all of its words. A few words are in more than one wordlist,
+// such as the words shared by the chinese wordlists. When the words of the mnemonic
+// are in several wordlists, the language in which its checksum is valid is returned.
+//
+// The mnemonic is checked as by ValidateMnemonic, except for its checksum.
+func DetectLanguage(mnemonic string) (Language, error) {
+	_, lang, err := splitMnemonicWords(mnemonic)
+	return lang, err
+}
+
+// detectLanguage returns the language of the NFKD normalized words of a mnemonic
+func detectLanguage(words []string) (Language, error) {
+	var candidates []Language
+	for _, lang := range languages {
+		if wordLists[lang].hasWords(words) {
+			candidates = append(candidates, lang)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", ErrUnknownWord
+	case 1:
+		return candidates[0], nil
+	}
+
+	for _, lang := range candidates {
+		if isMnemonicChecksumValid(words, lang) {
+			return lang, nil
+		}
+	}
+	return candidates[0], nil
+}
+
+// hasWords returns true if all of the NFKD normalized words are in the wordlist
+func (wl *wordList) hasWords(words []string) bool {
+	for _, w := range words {
+		if _, ok := wl.index[w]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// separator returns the separator of the words of a mnemonic in the language
+func (lang Language) separator() string {
+	if lang == Japanese {
+		return ideographicSpace
+	}
+	return " "
+}
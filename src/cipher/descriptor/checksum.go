@@ -0,0 +1,119 @@
+package descriptor
+
+import (
+	"errors"
+	"strings"
+)
+
+// The descriptor checksum is the BCH code of bip380, computed over the characters of the descriptor.
+// https://github.com/bitcoin/bips/blob/master/bip-0380.mediawiki#checksum
+
+const (
+	// inputCharset are the characters that can be used in a descriptor, in groups of 32
+	inputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+		"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+		"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+
+	// checksumCharset are the characters of the checksum
+	checksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// checksumLength is the number of characters of the checksum
+	checksumLength = 8
+)
+
+var (
+	// ErrInvalidCharacter is returned if a descriptor contains a character that can't be used in a descriptor
+	ErrInvalidCharacter = errors.New("Descriptor contains an invalid character")
+	// ErrInvalidChecksum is returned if the checksum of a descriptor is invalid
+	ErrInvalidChecksum = errors.New("Invalid descriptor checksum")
+)
+
+var generator = [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+
+func polymod(c uint64, v int) uint64 {
+	top := c >> 35
+	c = (c&0x7ffffffff)<<5 ^ uint64(v)
+	for i, g := range generator {
+		if (top>>uint(i))&1 == 1 {
+			c ^= g
+		}
+	}
+	return c
+}
+
+// Checksum returns the checksum of a descriptor without a checksum
+func Checksum(desc string) (string, error) {
+	c := uint64(1)
+	cls := 0
+	clsCount := 0
+	for _, ch := range desc {
+		pos := strings.IndexRune(inputCharset, ch)
+		if pos == -1 {
+			return "", ErrInvalidCharacter
+		}
+
+		// Emit a symbol for the position inside the group, for every character
+		c = polymod(c, pos&31)
+
+		// Accumulate the group numbers, and emit a symbol for every 3 characters
+		cls = cls*3 + pos>>5
+		clsCount++
+		if clsCount == 3 {
+			c = polymod(c, cls)
+			cls = 0
+			clsCount = 0
+		}
+	}
+
+	if clsCount > 0 {
+		c = polymod(c, cls)
+	}
+
+	for i := 0; i < checksumLength; i++ {
+		c = polymod(c, 0)
+	}
+	c ^= 1
+
+	var sb strings.Builder
+	for i := 0; i < checksumLength; i++ {
+		sb.WriteByte(checksumCharset[(c>>(5*uint(checksumLength-1-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+// AddChecksum appends the checksum to a descriptor without a checksum
+func AddChecksum(desc string) (string, error) {
+	cs, err := Checksum(desc)
+	if err != nil {
+		return "", err
+	}
+	return desc + "#" + cs, nil
+}
+
+// splitChecksum splits a descriptor into the descriptor and its checksum, and verifies
+// the checksum. The checksum is optional.
+func splitChecksum(s string) (string, error) {
+	i := strings.LastIndex(s, "#")
+	if i == -1 {
+		if _, err := Checksum(s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+
+	desc, cs := s[:i], s[i+1:]
+	if len(cs) != checksumLength {
+		return "", ErrInvalidChecksum
+	}
+
+	expected, err := Checksum(desc)
+	if err != nil {
+		return "", err
+	}
+
+	if cs != expected {
+		return "", ErrInvalidChecksum
+	}
+
+	return desc, nil
+}
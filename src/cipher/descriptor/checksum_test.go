@@ -0,0 +1,81 @@
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksum(t *testing.T) {
+	cs, err := Checksum("raw(deadbeef)")
+	require.NoError(t, err)
+	require.Equal(t, "89f8spxm", cs)
+
+	s, err := AddChecksum("raw(deadbeef)")
+	require.NoError(t, err)
+	require.Equal(t, "raw(deadbeef)#89f8spxm", s)
+
+	_, err = Checksum("raw(Ü)")
+	require.Equal(t, ErrInvalidCharacter, err)
+}
+
+func TestSplitChecksum(t *testing.T) {
+	// Test vectors of bip380
+	cases := []struct {
+		name string
+		s    string
+		desc string
+		err  error
+	}{
+		{
+			name: "valid checksum",
+			s:    "raw(deadbeef)#89f8spxm",
+			desc: "raw(deadbeef)",
+		},
+		{
+			name: "no checksum",
+			s:    "raw(deadbeef)",
+			desc: "raw(deadbeef)",
+		},
+		{
+			name: "missing checksum",
+			s:    "raw(deadbeef)#",
+			err:  ErrInvalidChecksum,
+		},
+		{
+			name: "checksum too long",
+			s:    "raw(deadbeef)#89f8spxmx",
+			err:  ErrInvalidChecksum,
+		},
+		{
+			name: "checksum too short",
+			s:    "raw(deadbeef)#89f8spx",
+			err:  ErrInvalidChecksum,
+		},
+		{
+			name: "error in payload",
+			s:    "raw(deedbeef)#89f8spxm",
+			err:  ErrInvalidChecksum,
+		},
+		{
+			name: "error in checksum",
+			s:    "raw(deadbeef)##9f8spxm",
+			err:  ErrInvalidChecksum,
+		},
+		{
+			name: "invalid characters in payload",
+			s:    "raw(Ü)#00000000",
+			err:  ErrInvalidCharacter,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			desc, err := splitChecksum(tc.s)
+			require.Equal(t, tc.err, err)
+			if err == nil {
+				require.Equal(t, tc.desc, desc)
+			}
+		})
+	}
+}
@@ -0,0 +1,423 @@
+/*
+Package descriptor implements output descriptors of bip32 extended public keys, as specified by
+bip380 https://github.com/bitcoin/bips/blob/master/bip-0380.mediawiki
+
+A descriptor describes the addresses derived from an extended public key, and where the key comes from.
+Only pay-to-pubkey-hash descriptors of xpub keys with ranged derivation are supported, for example:
+
+	pkh([9599ae00/44'/8000'/0']xpub6DFYZEYzFwhPbgUqKvL7SzkaQWg743C3raLHe1f2Q5kdWdKoDjsKKQumxpfLQW1TA9SPY762P1dQGux3rBN63BP91NormooHGAs8rQXY6f5/<0;1>/*)#cvv67n3t
+
+The key origin in square brackets has the fingerprint of the master key and the bip32 path from the
+master key to the xpub key. It is optional. The xpub key is followed by unhardened derivation steps,
+the last of which can be a multipath step of bip389 that lists the indexes of several chains,
+such as the external and change chains of bip44. The final `/*` stands for the index of the address.
+
+Hardened steps are written with `'` or `h`. The checksum after `#` is optional when parsing,
+and always added when formatting.
+*/
+package descriptor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/skycoin/skycoin/src/cipher/bip32"
+	"github.com/skycoin/skycoin/src/cipher/bip44"
+)
+
+var (
+	// ErrUnsupportedScript is returned if a descriptor is not a pkh() descriptor
+	ErrUnsupportedScript = errors.New("Only pkh() descriptors are supported")
+	// ErrInvalidKeyOrigin is returned if the key origin of a descriptor is invalid
+	ErrInvalidKeyOrigin = errors.New("Invalid descriptor key origin")
+	// ErrInvalidPathStep is returned if a derivation step of a descriptor is not a valid index
+	ErrInvalidPathStep = errors.New("Descriptor derivation step is not a valid index")
+	// ErrHardenedDerivation is returned if a descriptor has a hardened derivation step after its xpub key
+	ErrHardenedDerivation = errors.New("Descriptor can't have hardened derivation steps after the xpub key")
+	// ErrMissingWildcard is returned if the derivation path of a descriptor does not end with /*
+	ErrMissingWildcard = errors.New("Descriptor derivation path must end with /*")
+	// ErrInvalidMultipath is returned if the multipath step of a descriptor is invalid
+	ErrInvalidMultipath = errors.New("Descriptor multipath step must be the last step before /* and have at least 2 distinct indexes")
+	// ErrInvalidChain is returned when deriving a chain that the descriptor does not have
+	ErrInvalidChain = errors.New("Descriptor does not have this chain")
+	// ErrFingerprintMismatch is returned if the master key does not have the fingerprint of the key origin of a descriptor
+	ErrFingerprintMismatch = errors.New("Master key fingerprint does not match the descriptor key origin")
+	// ErrKeyMismatch is returned if the key derived from the master key is not the xpub key of a descriptor
+	ErrKeyMismatch = errors.New("Derived key does not match the descriptor xpub key")
+)
+
+// KeyOrigin is the origin of the xpub key of a descriptor
+type KeyOrigin struct {
+	// Fingerprint is the fingerprint of the master key
+	Fingerprint [4]byte
+	// Path is the bip32 path from the master key to the xpub key, hardened indexes included
+	Path []uint32
+}
+
+// Descriptor is a pkh() output descriptor of an xpub key
+type Descriptor struct {
+	// Origin is the origin of the xpub key, if known
+	Origin *KeyOrigin
+	// XPub is the xpub key
+	XPub *bip32.PublicKey
+	// Path is the unhardened derivation path from the xpub key, excluding the multipath step
+	Path []uint32
+	// Chains are the indexes of the multipath step, if the descriptor has one
+	Chains []uint32
+}
+
+// New creates a descriptor of the key derived from a master key at a bip32 path, e.g. 44'/8000'/0'.
+// The chains are the indexes of the chains that are derived from the key, e.g. 0 and 1 for the external
+// and change chains of bip44. A single chain is an ordinary derivation step, and no chains derive the
+// addresses from the key itself.
+func New(master *bip32.PrivateKey, path []uint32, chains ...uint32) (*Descriptor, error) {
+	k := master
+	if len(path) > 0 {
+		var err error
+		k, err = master.DeriveSubpath(pathNodes(path))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d := &Descriptor{
+		Origin: &KeyOrigin{
+			Path: append([]uint32{}, path...),
+		},
+		XPub: k.PublicKey(),
+	}
+	copy(d.Origin.Fingerprint[:], master.Fingerprint())
+
+	switch len(chains) {
+	case 0:
+	case 1:
+		d.Path = []uint32{chains[0]}
+	default:
+		d.Chains = append([]uint32{}, chains...)
+	}
+
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// NewBip44 creates a descriptor of a bip44 account, m/44'/coin_type'/account'/<0;1>/*
+func NewBip44(master *bip32.PrivateKey, coinType bip44.CoinType, account uint32) (*Descriptor, error) {
+	if uint32(coinType) >= bip32.FirstHardenedChild {
+		return nil, bip44.ErrInvalidCoinType
+	}
+	if account >= bip32.FirstHardenedChild {
+		return nil, bip44.ErrInvalidAccount
+	}
+
+	path := []uint32{
+		44 + bip32.FirstHardenedChild,
+		uint32(coinType) + bip32.FirstHardenedChild,
+		account + bip32.FirstHardenedChild,
+	}
+	return New(master, path, bip44.ExternalChainIndex, bip44.ChangeChainIndex)
+}
+
+// Parse parses a descriptor
+func Parse(s string) (*Descriptor, error) {
+	desc, err := splitChecksum(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(desc, "pkh(") || !strings.HasSuffix(desc, ")") {
+		return nil, ErrUnsupportedScript
+	}
+	keyExpr := desc[len("pkh(") : len(desc)-1]
+
+	d := &Descriptor{}
+	if strings.HasPrefix(keyExpr, "[") {
+		end := strings.Index(keyExpr, "]")
+		if end == -1 {
+			return nil, ErrInvalidKeyOrigin
+		}
+
+		d.Origin, err = parseKeyOrigin(keyExpr[1:end])
+		if err != nil {
+			return nil, err
+		}
+		keyExpr = keyExpr[end+1:]
+	}
+
+	steps := strings.Split(keyExpr, "/")
+	d.XPub, err = bip32.DeserializeEncodedPublicKey(steps[0])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid descriptor xpub key: %v", err)
+	}
+
+	steps = steps[1:]
+	if len(steps) == 0 {
+		return nil, ErrMissingWildcard
+	}
+
+	switch steps[len(steps)-1] {
+	case "*":
+	case "*'", "*h":
+		return nil, ErrHardenedDerivation
+	default:
+		return nil, ErrMissingWildcard
+	}
+	steps = steps[:len(steps)-1]
+
+	for i, step := range steps {
+		if strings.HasPrefix(step, "<") {
+			if i != len(steps)-1 || !strings.HasSuffix(step, ">") {
+				return nil, ErrInvalidMultipath
+			}
+
+			for _, x := range strings.Split(step[1:len(step)-1], ";") {
+				n, err := parseIndex(x)
+				if err != nil {
+					return nil, err
+				}
+				d.Chains = append(d.Chains, n)
+			}
+			continue
+		}
+
+		n, err := parseIndex(step)
+		if err != nil {
+			return nil, err
+		}
+		d.Path = append(d.Path, n)
+	}
+
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func parseKeyOrigin(s string) (*KeyOrigin, error) {
+	parts := strings.Split(s, "/")
+
+	fp, err := hex.DecodeString(parts[0])
+	if err != nil || len(fp) != 4 || strings.ToLower(parts[0]) != parts[0] {
+		return nil, ErrInvalidKeyOrigin
+	}
+
+	o := &KeyOrigin{}
+	copy(o.Fingerprint[:], fp)
+
+	for _, x := range parts[1:] {
+		n, err := parseIndex(x)
+		if err != nil {
+			return nil, err
+		}
+		o.Path = append(o.Path, n)
+	}
+
+	return o, nil
+}
+
+// parseIndex parses a derivation step, with a trailing ' or h if it is hardened
+func parseIndex(x string) (uint32, error) {
+	hardened := false
+	if strings.HasSuffix(x, "'") || strings.HasSuffix(x, "h") {
+		hardened = true
+		x = x[:len(x)-1]
+	}
+
+	n, err := strconv.ParseUint(x, 10, 32)
+	if err != nil || uint32(n) >= bip32.FirstHardenedChild {
+		return 0, ErrInvalidPathStep
+	}
+
+	if hardened {
+		return uint32(n) + bip32.FirstHardenedChild, nil
+	}
+	return uint32(n), nil
+}
+
+func (d *Descriptor) validate() error {
+	for _, path := range [][]uint32{d.Path, d.Chains} {
+		for _, n := range path {
+			if n >= bip32.FirstHardenedChild {
+				return ErrHardenedDerivation
+			}
+		}
+	}
+
+	if len(d.Chains) == 1 {
+		return ErrInvalidMultipath
+	}
+
+	seen := make(map[uint32]struct{}, len(d.Chains))
+	for _, n := range d.Chains {
+		if _, ok := seen[n]; ok {
+			return ErrInvalidMultipath
+		}
+		seen[n] = struct{}{}
+	}
+
+	return nil
+}
+
+// String returns the descriptor with its checksum
+func (d *Descriptor) String() string {
+	var sb strings.Builder
+	sb.WriteString("pkh(")
+
+	if d.Origin != nil {
+		sb.WriteString("[")
+		sb.WriteString(hex.EncodeToString(d.Origin.Fingerprint[:]))
+		for _, n := range d.Origin.Path {
+			sb.WriteString("/")
+			sb.WriteString(formatIndex(n))
+		}
+		sb.WriteString("]")
+	}
+
+	sb.WriteString(d.XPub.String())
+
+	for _, n := range d.Path {
+		sb.WriteString("/")
+		sb.WriteString(formatIndex(n))
+	}
+
+	if len(d.Chains) > 0 {
+		chains := make([]string, len(d.Chains))
+		for i, n := range d.Chains {
+			chains[i] = formatIndex(n)
+		}
+		sb.WriteString("/<")
+		sb.WriteString(strings.Join(chains, ";"))
+		sb.WriteString(">")
+	}
+
+	sb.WriteString("/*)")
+
+	s, err := AddChecksum(sb.String())
+	if err != nil {
+		// All of the characters of a formatted descriptor are valid
+		panic(err)
+	}
+	return s
+}
+
+// NumChains returns the number of chains of the descriptor
+func (d *Descriptor) NumChains() int {
+	if len(d.Chains) == 0 {
+		return 1
+	}
+	return len(d.Chains)
+}
+
+// ChainKey returns the xpub key of a chain, from which the addresses of the chain are derived.
+// The chain is the position of its index in the multipath step. A descriptor without a multipath step
+// has a single chain, 0.
+func (d *Descriptor) ChainKey(chain int) (*bip32.PublicKey, error) {
+	if chain < 0 || chain >= d.NumChains() {
+		return nil, ErrInvalidChain
+	}
+
+	path := d.Path
+	if len(d.Chains) > 0 {
+		path = append(append([]uint32{}, d.Path...), d.Chains[chain])
+	}
+
+	k := d.XPub
+	for _, n := range path {
+		var err error
+		k, err = k.NewPublicChildKey(n)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return k, nil
+}
+
+// DeriveKey derives the private key of the xpub key of the descriptor from a master key.
+// Returns ErrFingerprintMismatch or ErrKeyMismatch if the xpub key was not derived from the master key.
+// Without a key origin, the xpub key must be the public key of the master key.
+func (d *Descriptor) DeriveKey(master *bip32.PrivateKey) (*bip32.PrivateKey, error) {
+	k := master
+	if d.Origin != nil {
+		if !bytes.Equal(master.Fingerprint(), d.Origin.Fingerprint[:]) {
+			return nil, ErrFingerprintMismatch
+		}
+
+		if len(d.Origin.Path) > 0 {
+			var err error
+			k, err = master.DeriveSubpath(pathNodes(d.Origin.Path))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	pk := k.PublicKey()
+	if !bytes.Equal(pk.Key, d.XPub.Key) || !bytes.Equal(pk.ChainCode, d.XPub.ChainCode) {
+		return nil, ErrKeyMismatch
+	}
+
+	return k, nil
+}
+
+// Bip44Account returns the coin type and account of a descriptor whose key origin path is
+// a bip44 account path, m/44'/coin_type'/account', and whose chains are the bip44 external
+// and change chains
+func (d *Descriptor) Bip44Account() (bip44.CoinType, uint32, bool) {
+	if d.Origin == nil || len(d.Origin.Path) != 3 || len(d.Path) != 0 {
+		return 0, 0, false
+	}
+
+	if len(d.Chains) != 2 || d.Chains[0] != bip44.ExternalChainIndex || d.Chains[1] != bip44.ChangeChainIndex {
+		return 0, 0, false
+	}
+
+	for _, n := range d.Origin.Path {
+		if n < bip32.FirstHardenedChild {
+			return 0, 0, false
+		}
+	}
+
+	if d.Origin.Path[0] != 44+bip32.FirstHardenedChild {
+		return 0, 0, false
+	}
+
+	coinType := bip44.CoinType(d.Origin.Path[1] - bip32.FirstHardenedChild)
+	account := d.Origin.Path[2] - bip32.FirstHardenedChild
+	return coinType, account, true
+}
+
+// FormatPath formats a bip32 path from the master key, e.g. m/44'/8000'/0'
+func FormatPath(path []uint32) string {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, n := range path {
+		sb.WriteString("/")
+		sb.WriteString(formatIndex(n))
+	}
+	return sb.String()
+}
+
+func formatIndex(n uint32) string {
+	if n >= bip32.FirstHardenedChild {
+		return strconv.FormatUint(uint64(n-bip32.FirstHardenedChild), 10) + "'"
+	}
+	return strconv.FormatUint(uint64(n), 10)
+}
+
+func pathNodes(path []uint32) []bip32.PathNode {
+	nodes := make([]bip32.PathNode, len(path))
+	for i, n := range path {
+		nodes[i] = bip32.PathNode{
+			ChildNumber: n,
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,361 @@
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher/bip32"
+	"github.com/skycoin/skycoin/src/cipher/bip39"
+	"github.com/skycoin/skycoin/src/cipher/bip44"
+)
+
+const (
+	// testMnemonic is the mnemonic of the bip44 package example
+	testMnemonic = "dizzy cigar grant ramp inmate uniform gold success able payment faith practice"
+
+	// account m/44'/0'/0' of testMnemonic and its external and change chains
+	testAccountXPub  = "xpub6CJWevR9X57jrYr8jrAqctxF4o78p4GCYHH34rajeL8J9D1bWSHKBht4yzwiTQ4FP4HyQpx99iLxvU54rbEbcxBUgxzTGGudBVXb1N2gcHF"
+	testExternalXPub = "xpub6DdcsnxN5cW5SecV81gXYepGaNevR23YrwEUx275QDNa1k4wEvLRafEWcpP5gKP3AkR67td8nx2PykEWxzUvJCgeoUKuM8px7uhAmYCQWEg"
+	testChangeXPub   = "xpub6DdcsnxN5cW5V6tEP6dKFY4GN7vkwozuAL3L5c578fnzZARn3RY4faxQ5rqy1dR8mY8GUWoQJtLBLyXnFiGE9j3r4ShiVb12W5NPSmkgrpp"
+
+	testBitcoinDescriptor = "pkh([9599ae00/44'/0'/0']" + testAccountXPub + "/<0;1>/*)#g4rtez9u"
+	testSkycoinDescriptor = "pkh([9599ae00/44'/8000'/0']xpub6DFYZEYzFwhPbgUqKvL7SzkaQWg743C3raLHe1f2Q5kdWdKoDjsKKQumxpfLQW1TA9SPY762P1dQGux3rBN63BP91NormooHGAs8rQXY6f5/<0;1>/*)#cvv67n3t"
+	testMasterXPub        = "xpub661MyMwAqRbcG5kDNDW4ekw2CNZ1Z8YApyG6YLAVE5AuSE4izkuZhQtyeeWvTcbr4dFMhewMtXGJVFf5rf5N5bKLHFsMZZS3kRwhZiG7Roc"
+)
+
+func testMasterKey(t *testing.T) *bip32.PrivateKey {
+	seed, err := bip39.NewSeed(testMnemonic, "")
+	require.NoError(t, err)
+	m, err := bip32.NewMasterKey(seed)
+	require.NoError(t, err)
+	return m
+}
+
+func TestNewBip44(t *testing.T) {
+	m := testMasterKey(t)
+
+	d, err := NewBip44(m, bip44.CoinTypeBitcoin, 0)
+	require.NoError(t, err)
+	require.Equal(t, testBitcoinDescriptor, d.String())
+	require.Equal(t, testAccountXPub, d.XPub.String())
+	require.Equal(t, "m/44'/0'/0'", FormatPath(d.Origin.Path))
+
+	d, err = NewBip44(m, bip44.CoinTypeSkycoin, 0)
+	require.NoError(t, err)
+	require.Equal(t, testSkycoinDescriptor, d.String())
+
+	_, err = NewBip44(m, bip44.CoinType(bip32.FirstHardenedChild), 0)
+	require.Equal(t, bip44.ErrInvalidCoinType, err)
+
+	_, err = NewBip44(m, bip44.CoinTypeSkycoin, bip32.FirstHardenedChild)
+	require.Equal(t, bip44.ErrInvalidAccount, err)
+}
+
+func TestNew(t *testing.T) {
+	m := testMasterKey(t)
+
+	d, err := New(m, []uint32{bip32.FirstHardenedChild}, 0)
+	require.NoError(t, err)
+	require.Equal(t, "pkh([9599ae00/0']xpub68zGrEH2Y2VwMksgUNTYMSdDffPrDnGzVARurvdz2nn7ETg8sNJf6aqRxh4iAkpZjz8DwsX1W9ta7iGk8xXBJDnDynN4qgL7XGSBs9wdHq6/0/*)#x53sf9gz", d.String())
+	require.Equal(t, []uint32{0}, d.Path)
+	require.Empty(t, d.Chains)
+	require.Equal(t, 1, d.NumChains())
+
+	d, err = New(m, nil)
+	require.NoError(t, err)
+	require.Equal(t, testMasterXPub, d.XPub.String())
+	require.Empty(t, d.Origin.Path)
+
+	_, err = New(m, nil, 0, 0)
+	require.Equal(t, ErrInvalidMultipath, err)
+
+	_, err = New(m, nil, bip32.FirstHardenedChild)
+	require.Equal(t, ErrHardenedDerivation, err)
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      string
+		origin *KeyOrigin
+		xpub   string
+		path   []uint32
+		chains []uint32
+		str    string
+		err    error
+	}{
+		{
+			name: "bip44 account",
+			s:    testBitcoinDescriptor,
+			origin: &KeyOrigin{
+				Fingerprint: [4]byte{0x95, 0x99, 0xae, 0x00},
+				Path:        []uint32{44 + bip32.FirstHardenedChild, bip32.FirstHardenedChild, bip32.FirstHardenedChild},
+			},
+			xpub:   testAccountXPub,
+			chains: []uint32{0, 1},
+			str:    testBitcoinDescriptor,
+		},
+		{
+			name: "no checksum, hardened steps with h",
+			s:    "pkh([9599ae00/44h/0h/0h]" + testAccountXPub + "/<0;1>/*)",
+			origin: &KeyOrigin{
+				Fingerprint: [4]byte{0x95, 0x99, 0xae, 0x00},
+				Path:        []uint32{44 + bip32.FirstHardenedChild, bip32.FirstHardenedChild, bip32.FirstHardenedChild},
+			},
+			xpub:   testAccountXPub,
+			chains: []uint32{0, 1},
+			str:    testBitcoinDescriptor,
+		},
+		{
+			name: "no key origin",
+			s:    "pkh(" + testExternalXPub + "/*)",
+			xpub: testExternalXPub,
+			str:  "pkh(" + testExternalXPub + "/*)#zdhwgvfn",
+		},
+		{
+			name:   "path and multipath",
+			s:      "pkh(" + testAccountXPub + "/7/<2;3;4>/*)",
+			xpub:   testAccountXPub,
+			path:   []uint32{7},
+			chains: []uint32{2, 3, 4},
+			str:    "pkh(" + testAccountXPub + "/7/<2;3;4>/*)#f4d6ccea",
+		},
+		{
+			name: "invalid checksum",
+			s:    "pkh([9599ae00/44'/0'/0']" + testAccountXPub + "/<0;1>/*)#g4rtez9v",
+			err:  ErrInvalidChecksum,
+		},
+		{
+			name: "wpkh",
+			s:    "wpkh(" + testAccountXPub + "/0/*)",
+			err:  ErrUnsupportedScript,
+		},
+		{
+			name: "xprv",
+			s:    "pkh(xprv9yKAFQtFghZSe4mfdpdqFm1WWmGeQbYMB4MSGUB85zbKGQgSxty4duZb8k6hNoHVd2UR7Y3QhWU3rS9wox9ewgVG7gDLyYTL4yzEuqUCjvF/0/*)",
+			err:  bip32.ErrInvalidPublicKeyVersion,
+		},
+		{
+			name: "missing wildcard",
+			s:    "pkh(" + testAccountXPub + "/0)",
+			err:  ErrMissingWildcard,
+		},
+		{
+			name: "no derivation",
+			s:    "pkh(" + testAccountXPub + ")",
+			err:  ErrMissingWildcard,
+		},
+		{
+			name: "hardened wildcard",
+			s:    "pkh(" + testAccountXPub + "/0/*')",
+			err:  ErrHardenedDerivation,
+		},
+		{
+			name: "hardened step",
+			s:    "pkh(" + testAccountXPub + "/0'/*)",
+			err:  ErrHardenedDerivation,
+		},
+		{
+			name: "invalid step",
+			s:    "pkh(" + testAccountXPub + "/x/*)",
+			err:  ErrInvalidPathStep,
+		},
+		{
+			name: "step too large",
+			s:    "pkh(" + testAccountXPub + "/2147483648/*)",
+			err:  ErrInvalidPathStep,
+		},
+		{
+			name: "multipath not last",
+			s:    "pkh(" + testAccountXPub + "/<0;1>/0/*)",
+			err:  ErrInvalidMultipath,
+		},
+		{
+			name: "multipath single index",
+			s:    "pkh(" + testAccountXPub + "/<0>/*)",
+			err:  ErrInvalidMultipath,
+		},
+		{
+			name: "multipath duplicate index",
+			s:    "pkh(" + testAccountXPub + "/<1;1>/*)",
+			err:  ErrInvalidMultipath,
+		},
+		{
+			name: "unterminated key origin",
+			s:    "pkh([9599ae00/44'/0'/0'" + testAccountXPub + "/<0;1>/*)",
+			err:  ErrInvalidKeyOrigin,
+		},
+		{
+			name: "short fingerprint",
+			s:    "pkh([9599ae/44'/0'/0']" + testAccountXPub + "/<0;1>/*)",
+			err:  ErrInvalidKeyOrigin,
+		},
+		{
+			name: "uppercase fingerprint",
+			s:    "pkh([9599AE00/44'/0'/0']" + testAccountXPub + "/<0;1>/*)",
+			err:  ErrInvalidKeyOrigin,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := Parse(tc.s)
+			if tc.err != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.origin, d.Origin)
+			require.Equal(t, tc.xpub, d.XPub.String())
+			require.Equal(t, tc.path, d.Path)
+			require.Equal(t, tc.chains, d.Chains)
+			require.Equal(t, tc.str, d.String())
+		})
+	}
+}
+
+func TestChainKey(t *testing.T) {
+	d, err := Parse(testBitcoinDescriptor)
+	require.NoError(t, err)
+	require.Equal(t, 2, d.NumChains())
+
+	k, err := d.ChainKey(0)
+	require.NoError(t, err)
+	require.Equal(t, testExternalXPub, k.String())
+
+	k, err = d.ChainKey(1)
+	require.NoError(t, err)
+	require.Equal(t, testChangeXPub, k.String())
+
+	_, err = d.ChainKey(2)
+	require.Equal(t, ErrInvalidChain, err)
+
+	d, err = Parse("pkh(" + testAccountXPub + "/1/*)")
+	require.NoError(t, err)
+	require.Equal(t, 1, d.NumChains())
+
+	k, err = d.ChainKey(0)
+	require.NoError(t, err)
+	require.Equal(t, testChangeXPub, k.String())
+
+	d, err = Parse("pkh(" + testExternalXPub + "/*)")
+	require.NoError(t, err)
+
+	k, err = d.ChainKey(0)
+	require.NoError(t, err)
+	require.Equal(t, testExternalXPub, k.String())
+}
+
+func TestDeriveKey(t *testing.T) {
+	m := testMasterKey(t)
+
+	d, err := Parse(testBitcoinDescriptor)
+	require.NoError(t, err)
+
+	k, err := d.DeriveKey(m)
+	require.NoError(t, err)
+	require.Equal(t, testAccountXPub, k.PublicKey().String())
+
+	// Another master key
+	seed, err := bip39.NewSeed(testMnemonic, "passphrase")
+	require.NoError(t, err)
+	m2, err := bip32.NewMasterKey(seed)
+	require.NoError(t, err)
+
+	_, err = d.DeriveKey(m2)
+	require.Equal(t, ErrFingerprintMismatch, err)
+
+	// The origin path does not lead to the xpub key
+	d, err = Parse("pkh([9599ae00/44'/0'/1']" + testAccountXPub + "/<0;1>/*)")
+	require.NoError(t, err)
+	_, err = d.DeriveKey(m)
+	require.Equal(t, ErrKeyMismatch, err)
+
+	// Without a key origin, the xpub key is the master public key
+	d, err = Parse("pkh(" + testMasterXPub + "/<0;1>/*)")
+	require.NoError(t, err)
+	k, err = d.DeriveKey(m)
+	require.NoError(t, err)
+	require.Equal(t, testMasterXPub, k.PublicKey().String())
+
+	d, err = Parse("pkh(" + testAccountXPub + "/<0;1>/*)")
+	require.NoError(t, err)
+	_, err = d.DeriveKey(m)
+	require.Equal(t, ErrKeyMismatch, err)
+}
+
+func TestBip44Account(t *testing.T) {
+	cases := []struct {
+		name     string
+		s        string
+		coinType bip44.CoinType
+		account  uint32
+		ok       bool
+	}{
+		{
+			name:     "bitcoin",
+			s:        testBitcoinDescriptor,
+			coinType: bip44.CoinTypeBitcoin,
+			ok:       true,
+		},
+		{
+			name:     "skycoin",
+			s:        testSkycoinDescriptor,
+			coinType: bip44.CoinTypeSkycoin,
+			ok:       true,
+		},
+		{
+			name:     "account 3",
+			s:        "pkh([9599ae00/44'/8000'/3']" + testAccountXPub + "/<0;1>/*)",
+			coinType: bip44.CoinTypeSkycoin,
+			account:  3,
+			ok:       true,
+		},
+		{
+			name: "no key origin",
+			s:    "pkh(" + testAccountXPub + "/<0;1>/*)",
+		},
+		{
+			name: "bip49 purpose",
+			s:    "pkh([9599ae00/49'/0'/0']" + testAccountXPub + "/<0;1>/*)",
+		},
+		{
+			name: "unhardened account",
+			s:    "pkh([9599ae00/44'/0'/0]" + testAccountXPub + "/<0;1>/*)",
+		},
+		{
+			name: "single chain",
+			s:    "pkh([9599ae00/44'/0'/0']" + testAccountXPub + "/0/*)",
+		},
+		{
+			name: "other chains",
+			s:    "pkh([9599ae00/44'/0'/0']" + testAccountXPub + "/<1;0>/*)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := Parse(tc.s)
+			require.NoError(t, err)
+
+			coinType, account, ok := d.Bip44Account()
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.coinType, coinType)
+			require.Equal(t, tc.account, account)
+		})
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	require.Equal(t, "m", FormatPath(nil))
+	require.Equal(t, "m/44'/8000'/0'/1/5", FormatPath([]uint32{
+		44 + bip32.FirstHardenedChild,
+		8000 + bip32.FirstHardenedChild,
+		bip32.FirstHardenedChild,
+		1,
+		5,
+	}))
+}
@@ -0,0 +1,174 @@
+package skyencoder
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GeneratedHeader is the first line of generated Go files
+const GeneratedHeader = "// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT."
+
+// File is a generated file
+type File struct {
+	// Path is the path of the file, relative to the package directory
+	Path string
+	Data []byte
+}
+
+// Generator generates files from a package schema
+type Generator interface {
+	// Name is the name used to select the generator
+	Name() string
+	// Generate generates the files of a package
+	Generate(p *Package) ([]File, error)
+}
+
+// DefaultGenerators returns the generators of Go encoders, their tests and the test vectors
+func DefaultGenerators() []Generator {
+	return []Generator{
+		GoEncoders{},
+		GoTests{},
+		Vectors{},
+	}
+}
+
+// Generate runs generators on a package. The files are sorted by path.
+func Generate(p *Package, gens []Generator) ([]File, error) {
+	var files []File
+	paths := make(map[string]string)
+	for _, g := range gens {
+		gf, err := g.Generate(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s generator: %v", g.Name(), err)
+		}
+
+		for _, f := range gf {
+			if other, ok := paths[f.Path]; ok {
+				return nil, fmt.Errorf("%s generator: %s is also generated by the %s generator", g.Name(), f.Path, other)
+			}
+			paths[f.Path] = g.Name()
+		}
+
+		files = append(files, gf...)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	return files, nil
+}
+
+// WriteFiles writes generated files to the package directory, and removes
+// generated encoder files that are no longer generated
+func WriteFiles(p *Package, files []File) error {
+	stale, err := staleFiles(p, files)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		filename := filepath.Join(p.Dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filename, f.Data, 0644); err != nil {
+			return err
+		}
+	}
+
+	for _, filename := range stale {
+		if err := os.Remove(filename); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckFiles verifies that the generated files in the package directory are up to date
+func CheckFiles(p *Package, files []File) error {
+	var msgs []string
+	for _, f := range files {
+		filename := filepath.Join(p.Dir, filepath.FromSlash(f.Path))
+		data, err := ioutil.ReadFile(filename)
+		switch {
+		case os.IsNotExist(err):
+			msgs = append(msgs, fmt.Sprintf("%s is missing", filename))
+		case err != nil:
+			return err
+		case !bytes.Equal(data, f.Data):
+			msgs = append(msgs, fmt.Sprintf("%s is out of date", filename))
+		}
+	}
+
+	stale, err := staleFiles(p, files)
+	if err != nil {
+		return err
+	}
+	for _, filename := range stale {
+		msgs = append(msgs, fmt.Sprintf("%s is not generated by the schema", filename))
+	}
+
+	if len(msgs) != 0 {
+		return fmt.Errorf("generated files do not match %s:\n%s", filepath.Join(p.Dir, SchemaFilename), strings.Join(msgs, "\n"))
+	}
+
+	return nil
+}
+
+// staleFiles returns the generated encoder files of the package directory which are not in files
+func staleFiles(p *Package, files []File) ([]string, error) {
+	generated := make(map[string]struct{}, len(files))
+	for _, f := range files {
+		generated[filepath.Join(p.Dir, filepath.FromSlash(f.Path))] = struct{}{}
+	}
+
+	var stale []string
+	for _, pattern := range []string{"*_skyencoder.go", "*_skyencoder_test.go"} {
+		matches, err := filepath.Glob(filepath.Join(p.Dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, filename := range matches {
+			if _, ok := generated[filename]; ok {
+				continue
+			}
+
+			data, err := ioutil.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+			if bytes.HasPrefix(data, []byte("// Code generated by")) {
+				stale = append(stale, filename)
+			}
+		}
+	}
+
+	return stale, nil
+}
+
+// snakeCase converts a type name to the snake case form used in file names, e.g. IPAddr to ip_addr
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
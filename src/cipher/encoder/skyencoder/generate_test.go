@@ -0,0 +1,218 @@
+package skyencoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchemas verifies the schema files of the repository against the Go source of their packages,
+// and that the generated files are up to date. Run "make generate" to update the generated files.
+func TestSchemas(t *testing.T) {
+	l, err := NewLoader(".")
+	require.NoError(t, err)
+
+	var dirs []string
+	err = filepath.Walk(filepath.Join(l.Root(), "src"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == "testdata" {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && info.Name() == SchemaFilename {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, dirs)
+
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(l.Root(), dir)
+		require.NoError(t, err)
+
+		t.Run(filepath.ToSlash(rel), func(t *testing.T) {
+			p, err := l.Load(dir)
+			require.NoError(t, err)
+
+			require.NoError(t, p.CheckSource())
+
+			files, err := Generate(p, DefaultGenerators())
+			require.NoError(t, err)
+			require.NoError(t, CheckFiles(p, files))
+
+			encoders, err := p.Encoders()
+			require.NoError(t, err)
+			if len(encoders) == 0 {
+				return
+			}
+
+			// The JSON values of the test vectors encode to the test vector encodings
+			vectors, err := ReadVectors(filepath.Join(dir, VectorsFilename), "")
+			require.NoError(t, err)
+			require.Len(t, vectors, len(encoders)*vectorsPerType)
+
+			for i, v := range vectors {
+				typ := encoders[i/vectorsPerType]
+				require.Equal(t, typ.QualifiedName(), v.Type)
+
+				d := json.NewDecoder(bytes.NewReader(v.Value))
+				d.UseNumber()
+				var value interface{}
+				require.NoError(t, d.Decode(&value))
+
+				data, err := EncodeValue(typ, value)
+				require.NoError(t, err)
+				require.Equal(t, v.Encoded, data, "vector %d", i)
+			}
+		})
+	}
+}
+
+const testSchemaSource = `package a
+
+type Hash [32]byte
+
+type Hashes []Hash
+
+type Thing struct {
+	Flag   bool
+	N      int16
+	hidden uint64
+	Skip   uint64 ` + "`enc:\"-\"`" + `
+	Hashes Hashes ` + "`enc:\",maxlen=8\"`" + `
+	Name   string ` + "`enc:\",omitempty\"`" + `
+}
+`
+
+func TestCheckSource(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		err    string
+	}{
+		{
+			name:   "ok",
+			source: testSchemaSource,
+		},
+		{
+			name:   "missing type",
+			source: "package a\n\ntype Hash [32]byte\n\ntype Hashes []Hash\n",
+			err:    "type Thing of the schema is not declared in example.com/m/a",
+		},
+		{
+			name:   "different array length",
+			source: strings.Replace(testSchemaSource, "[32]byte", "[33]byte", 1),
+			err:    "type Hash does not match the schema: declared as [...]byte",
+		},
+		{
+			name:   "missing maxlen",
+			source: strings.Replace(testSchemaSource, "`enc:\",maxlen=8\"`", "", 1),
+			err:    "field Hashes has maxlen 0, the schema has 8",
+		},
+		{
+			name:   "extra field",
+			source: strings.Replace(testSchemaSource, "\thidden uint64", "\tHidden uint64", 1),
+			err:    "type Thing does not match the schema: has 5 encoded fields, the schema has 4",
+		},
+		{
+			name:   "different field type",
+			source: strings.Replace(testSchemaSource, "\tN      int16", "\tN      uint16", 1),
+			err:    "field N has a different type than the schema",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeModule(t, map[string]string{
+				"a/skyencoder.json": testSchemaA,
+				"a/a.go":            tc.source,
+			})
+
+			l, err := NewLoader(dir)
+			require.NoError(t, err)
+			p, err := l.Load(filepath.Join(dir, "a"))
+			require.NoError(t, err)
+
+			err = p.CheckSource()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"a/skyencoder.json":           testSchemaA,
+		"a/a.go":                      testSchemaSource,
+		"a/old_skyencoder.go":         GeneratedHeader + "\n\npackage a\n",
+		"a/handwritten_skyencoder.go": "package a\n",
+		"b/skyencoder.json":           testSchemaB,
+	})
+
+	l, err := NewLoader(dir)
+	require.NoError(t, err)
+	p, err := l.Load(filepath.Join(dir, "a"))
+	require.NoError(t, err)
+
+	files, err := Generate(p, DefaultGenerators())
+	require.NoError(t, err)
+
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	require.Equal(t, []string{
+		VectorsFilename,
+		"thing_skyencoder.go",
+		"thing_skyencoder_test.go",
+	}, paths)
+
+	err = CheckFiles(p, files)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "thing_skyencoder.go is missing")
+	require.Contains(t, err.Error(), "old_skyencoder.go is not generated by the schema")
+	require.NotContains(t, err.Error(), "handwritten_skyencoder.go")
+
+	require.NoError(t, WriteFiles(p, files))
+	require.NoError(t, CheckFiles(p, files))
+
+	_, err = os.Stat(filepath.Join(dir, "a", "old_skyencoder.go"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "a", "handwritten_skyencoder.go"))
+	require.NoError(t, err)
+
+	filename := filepath.Join(dir, "a", "thing_skyencoder.go")
+	data, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data, []byte(GeneratedHeader+"\n\npackage a\n")))
+	require.NoError(t, ioutil.WriteFile(filename, append(data, '\n'), 0600))
+
+	err = CheckFiles(p, files)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "thing_skyencoder.go is out of date")
+
+	// Encoders of types of other packages are generated in the package of the schema
+	b, err := l.Import("example.com/m/b")
+	require.NoError(t, err)
+
+	files, err = Generate(b, []Generator{GoEncoders{}})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, "thing_skyencoder.go", files[0].Path)
+	require.Contains(t, string(files[0].Data), "func encodeSizeThing(obj *a.Thing) uint64 {")
+	require.Contains(t, string(files[0].Data), "\t\"example.com/m/a\"\n")
+	require.Equal(t, "wrapper_skyencoder.go", files[1].Path)
+	require.Contains(t, string(files[1].Data), "func decodeWrapper(buf []byte, obj *wrapper) (uint64, error) {")
+}
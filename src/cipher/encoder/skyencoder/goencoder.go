@@ -0,0 +1,474 @@
+package skyencoder
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// GoEncoders generates Go encoders for the encoder types of a package.
+// The encoder of a type T is written to the file t_skyencoder.go, and consists of the
+// unexported functions encodeSizeT, encodeT, encodeTToBuffer, decodeT and decodeTExact.
+type GoEncoders struct{}
+
+// Name returns the name of the generator
+func (GoEncoders) Name() string {
+	return "go"
+}
+
+// Generate generates the Go encoders of a package
+func (GoEncoders) Generate(p *Package) ([]File, error) {
+	types, err := p.Encoders()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(types))
+	for _, t := range types {
+		data, err := generateGoEncoder(p, t)
+		if err != nil {
+			return nil, fmt.Errorf("encoder %s: %v", t.QualifiedName(), err)
+		}
+
+		files = append(files, File{
+			Path: snakeCase(t.Name) + "_skyencoder.go",
+			Data: data,
+		})
+	}
+
+	return files, nil
+}
+
+// goWriter writes the Go source of a generated file
+type goWriter struct {
+	pkg     *Package
+	imports map[string]string
+	buf     bytes.Buffer
+	err     error
+}
+
+func newGoWriter(p *Package) *goWriter {
+	return &goWriter{
+		pkg:     p,
+		imports: make(map[string]string),
+	}
+}
+
+func (w *goWriter) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&w.buf, format, args...)
+}
+
+// addImport adds an import of a package
+func (w *goWriter) addImport(name, path string) {
+	w.imports[path] = name
+}
+
+// typeExpr returns the Go expression of a type in the generated package
+func (w *goWriter) typeExpr(t *Type) string {
+	if t.Name != "" {
+		if t.PkgPath == w.pkg.Path {
+			return t.Name
+		}
+		if !isExported(t.Name) && w.err == nil {
+			w.err = fmt.Errorf("unexported type %s can't be used outside of its package", t.QualifiedName())
+		}
+		w.addImport(t.PkgName, t.PkgPath)
+		return t.PkgName + "." + t.Name
+	}
+
+	switch t.Kind {
+	case Array:
+		return fmt.Sprintf("[%d]%s", t.Len, w.typeExpr(t.Elem))
+	case Slice:
+		return "[]" + w.typeExpr(t.Elem)
+	case Uint8:
+		return "byte"
+	default:
+		return t.Kind.String()
+	}
+}
+
+// source returns the formatted source, with the header and imports
+func (w *goWriter) source() ([]byte, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage %s\n\n", GeneratedHeader, w.pkg.Schema.Package)
+
+	paths := make([]string, 0, len(w.imports))
+	for path := range w.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var std, other []string
+	for _, path := range paths {
+		spec := fmt.Sprintf("%q", path)
+		if name := w.imports[path]; name != "" && name != path[strings.LastIndex(path, "/")+1:] {
+			spec = name + " " + spec
+		}
+
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			other = append(other, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+
+	switch {
+	case len(std)+len(other) == 1:
+		fmt.Fprintf(&out, "import %s\n\n", strings.Join(append(std, other...), ""))
+	case len(std)+len(other) > 1:
+		out.WriteString("import (\n")
+		for _, s := range std {
+			fmt.Fprintf(&out, "%s\n", s)
+		}
+		if len(std) != 0 && len(other) != 0 {
+			out.WriteString("\n")
+		}
+		for _, s := range other {
+			fmt.Fprintf(&out, "%s\n", s)
+		}
+		out.WriteString(")\n\n")
+	}
+
+	out.Write(w.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go source: %v", err)
+	}
+	return src, nil
+}
+
+func isExported(name string) bool {
+	return name != "" && strings.ToUpper(name[:1]) == name[:1]
+}
+
+// hasLengthPrefix returns true if the encoding of a type contains a length prefix
+func hasLengthPrefix(t *Type) bool {
+	switch t.Kind {
+	case String, Slice:
+		return true
+	case Array:
+		return hasLengthPrefix(t.Elem)
+	case Struct:
+		for _, f := range t.Fields {
+			if hasLengthPrefix(f.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// methodName returns the name of the encoder.Encoder and encoder.Decoder method of a bool or integer kind
+func methodName(k Kind) string {
+	s := k.String()
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+const encoderPath = "github.com/skycoin/skycoin/src/cipher/encoder"
+
+func generateGoEncoder(p *Package, t *Type) ([]byte, error) {
+	w := newGoWriter(p)
+	w.addImport("encoder", encoderPath)
+	if hasLengthPrefix(t) {
+		w.addImport("errors", "errors")
+		w.addImport("math", "math")
+	}
+
+	name := encoderName(t)
+	typ := w.typeExpr(t)
+
+	w.printf("// encodeSize%s computes the size of an encoded object of type %s\n", name, t.Name)
+	w.printf("func encodeSize%s(obj *%s) uint64 {\n", name, typ)
+	w.printf("i0 := uint64(0)\n\n")
+	w.sizeFields(t, "obj", 0)
+	w.printf("return i0\n}\n\n")
+
+	w.printf(`// encode%[1]s encodes an object of type %[3]s to a buffer allocated to the exact size
+// required to encode the object.
+func encode%[1]s(obj *%[2]s) ([]byte, error) {
+	n := encodeSize%[1]s(obj)
+	buf := make([]byte, n)
+
+	if err := encode%[1]sToBuffer(buf, obj); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// encode%[1]sToBuffer encodes an object of type %[3]s to a []byte buffer.
+// The buffer must be large enough to encode the object, otherwise an error is returned.
+func encode%[1]sToBuffer(buf []byte, obj *%[2]s) error {
+	if uint64(len(buf)) < encodeSize%[1]s(obj) {
+		return encoder.ErrBufferUnderflow
+	}
+
+	e := &encoder.Encoder{
+		Buffer: buf[:],
+	}
+
+`, name, typ, t.Name)
+	w.encodeFields(t, "obj")
+	w.printf("return nil\n}\n\n")
+
+	w.printf(`// decode%[1]s decodes an object of type %[3]s from a buffer.
+// Returns the number of bytes used from the buffer to decode the object.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+func decode%[1]s(buf []byte, obj *%[2]s) (uint64, error) {
+	d := &encoder.Decoder{
+		Buffer: buf[:],
+	}
+
+`, name, typ, t.Name)
+	w.decodeFields(t, "obj", 0)
+	w.printf(`return uint64(len(buf) - len(d.Buffer)), nil
+}
+
+// decode%[1]sExact decodes an object of type %[3]s from a buffer.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+// If the buffer is longer than required to decode the object, returns encoder.ErrRemainingBytes.
+func decode%[1]sExact(buf []byte, obj *%[2]s) error {
+	if n, err := decode%[1]s(buf, obj); err != nil {
+		return err
+	} else if n != uint64(len(buf)) {
+		return encoder.ErrRemainingBytes
+	}
+
+	return nil
+}
+`, name, typ, t.Name)
+
+	return w.source()
+}
+
+// sizeFields writes the size computation of the fields of a struct, which are added to the accumulator i<depth>
+func (w *goWriter) sizeFields(t *Type, path string, depth int) {
+	for _, f := range t.Fields {
+		fpath := path + "." + f.Name
+		if f.OmitEmpty {
+			w.printf("// omitempty\nif len(%s) != 0 {\n\n", fpath)
+			w.size(f.Type, fpath, depth)
+			w.printf("}\n\n")
+			continue
+		}
+		w.size(f.Type, fpath, depth)
+	}
+}
+
+// size writes the size computation of a value, which is added to the accumulator i<depth>
+func (w *goWriter) size(t *Type, path string, depth int) {
+	acc := fmt.Sprintf("i%d", depth)
+
+	switch t.Kind {
+	case Struct:
+		w.sizeFields(t, path, depth)
+
+	case String:
+		w.printf("// %s\n%s += 4 + uint64(len(%s))\n\n", path, acc, path)
+
+	case Array, Slice:
+		if t.IsBytes() {
+			if t.Kind == Array {
+				w.printf("// %s\n%s += %d\n\n", path, acc, t.Len)
+			} else {
+				w.printf("// %s\n%s += 4 + uint64(len(%s))\n\n", path, acc, path)
+			}
+			return
+		}
+
+		w.printf("// %s\n", path)
+		if t.Kind == Slice {
+			w.printf("%s += 4\n", acc)
+		}
+
+		x := fmt.Sprintf("x%d", depth+1)
+		elemAcc := fmt.Sprintf("i%d", depth+1)
+		if _, ok := t.Elem.FixedSize(); ok {
+			w.printf("{\n%s := uint64(0)\n\n", elemAcc)
+			w.size(t.Elem, x, depth+1)
+			if t.Kind == Array {
+				// The path of an array can be an undeclared element variable of a fixed size slice
+				w.printf("%s += %d * %s\n}\n\n", acc, t.Len, elemAcc)
+			} else {
+				w.printf("%s += uint64(len(%s)) * %s\n}\n\n", acc, path, elemAcc)
+			}
+		} else {
+			w.printf("for _, %s := range %s {\n%s := uint64(0)\n\n", x, path, elemAcc)
+			w.size(t.Elem, x, depth+1)
+			w.printf("%s += %s\n}\n\n", acc, elemAcc)
+		}
+
+	default:
+		if t.Kind.size() == 1 {
+			w.printf("// %s\n%s++\n\n", path, acc)
+		} else {
+			w.printf("// %s\n%s += %d\n\n", path, acc, t.Kind.size())
+		}
+	}
+}
+
+// encodeFields writes the encoding of the fields of a struct
+func (w *goWriter) encodeFields(t *Type, path string) {
+	for _, f := range t.Fields {
+		fpath := path + "." + f.Name
+		if f.OmitEmpty {
+			w.printf("// omitempty\nif len(%s) != 0 {\n\n", fpath)
+			w.encode(f.Type, fpath, f.MaxLen)
+			w.printf("}\n\n")
+			continue
+		}
+		w.encode(f.Type, fpath, f.MaxLen)
+	}
+}
+
+// encodeLength writes the encoding of the length prefix of a string or slice
+func (w *goWriter) encodeLength(path string, maxLen int) {
+	if maxLen > 0 {
+		w.printf("// %s maxlen check\nif len(%s) > %d {\nreturn encoder.ErrMaxLenExceeded\n}\n\n", path, path, maxLen)
+	}
+	w.printf("// %s length check\nif uint64(len(%s)) > math.MaxUint32 {\nreturn errors.New(\"%s length exceeds math.MaxUint32\")\n}\n\n", path, path, path)
+	w.printf("// %s length\ne.Uint32(uint32(len(%s)))\n\n", path, path)
+}
+
+// encode writes the encoding of a value
+func (w *goWriter) encode(t *Type, path string, maxLen int) {
+	switch t.Kind {
+	case Struct:
+		w.encodeFields(t, path)
+
+	case String:
+		w.encodeLength(path, maxLen)
+		w.printf("// %s copy\ne.CopyBytes([]byte(%s))\n\n", path, path)
+
+	case Array, Slice:
+		if t.IsBytes() && t.Kind == Array {
+			w.printf("// %s\ne.CopyBytes(%s[:])\n\n", path, path)
+			return
+		}
+
+		if t.Kind == Slice {
+			w.encodeLength(path, maxLen)
+		}
+
+		if t.IsBytes() {
+			w.printf("// %s copy\ne.CopyBytes(%s)\n\n", path, path)
+			return
+		}
+
+		w.printf("// %s\nfor _, x := range %s {\n\n", path, path)
+		w.encode(t.Elem, "x", 0)
+		w.printf("}\n\n")
+
+	default:
+		value := path
+		if t.Name != "" {
+			value = fmt.Sprintf("%s(%s)", t.Kind, path)
+		}
+		w.printf("// %s\ne.%s(%s)\n\n", path, methodName(t.Kind), value)
+	}
+}
+
+// decodeFields writes the decoding of the fields of a struct
+func (w *goWriter) decodeFields(t *Type, path string, depth int) {
+	for _, f := range t.Fields {
+		w.decode(f.Type, path+"."+f.Name, depth+1, f.MaxLen, f.OmitEmpty)
+	}
+}
+
+// decodeLength writes the decoding of the length prefix of a string or slice
+func (w *goWriter) decodeLength(maxLen int, omitEmpty bool) {
+	if omitEmpty {
+		w.printf("if len(d.Buffer) == 0 {\nreturn uint64(len(buf) - len(d.Buffer)), nil\n}\n\n")
+	}
+
+	w.printf(`ul, err := d.Uint32()
+if err != nil {
+	return 0, err
+}
+
+length := int(ul)
+if length < 0 || length > len(d.Buffer) {
+	return 0, encoder.ErrBufferUnderflow
+}
+
+`)
+
+	if maxLen > 0 {
+		w.printf("if length > %d {\nreturn 0, encoder.ErrMaxLenExceeded\n}\n\n", maxLen)
+	}
+}
+
+// decode writes the decoding of a value
+func (w *goWriter) decode(t *Type, path string, depth, maxLen int, omitEmpty bool) {
+	switch t.Kind {
+	case Struct:
+		w.decodeFields(t, path, depth)
+
+	case String:
+		w.printf("{\n// %s\n\n", path)
+		w.decodeLength(maxLen, omitEmpty)
+		value := "string(d.Buffer[:length])"
+		if t.Name != "" {
+			value = fmt.Sprintf("%s(%s)", w.typeExpr(t), value)
+		}
+		w.printf("%s = %s\nd.Buffer = d.Buffer[length:]\n}\n\n", path, value)
+
+	case Array:
+		if t.IsBytes() {
+			w.printf(`{
+// %[1]s
+if len(d.Buffer) < len(%[1]s) {
+	return 0, encoder.ErrBufferUnderflow
+}
+copy(%[1]s[:], d.Buffer[:len(%[1]s)])
+d.Buffer = d.Buffer[len(%[1]s):]
+}
+
+`, path)
+			return
+		}
+
+		z := fmt.Sprintf("z%d", depth)
+		w.printf("{\n// %s\n\nfor %s := range %s {\n", path, z, path)
+		w.decode(t.Elem, fmt.Sprintf("%s[%s]", path, z), depth+1, 0, false)
+		w.printf("}\n}\n\n")
+
+	case Slice:
+		w.printf("{\n// %s\n\n", path)
+		w.decodeLength(maxLen, omitEmpty)
+
+		if t.IsBytes() {
+			w.printf("if length != 0 {\n%s = make(%s, length)\n\ncopy(%s[:], d.Buffer[:length])\nd.Buffer = d.Buffer[length:]\n}\n}\n\n", path, w.typeExpr(&Type{Kind: Slice, Elem: t.Elem}), path)
+			return
+		}
+
+		z := fmt.Sprintf("z%d", depth)
+		w.printf("if length != 0 {\n%s = make(%s, length)\n\nfor %s := range %s {\n", path, w.typeExpr(&Type{Kind: Slice, Elem: t.Elem}), z, path)
+		w.decode(t.Elem, fmt.Sprintf("%s[%s]", path, z), depth+1, 0, false)
+		w.printf("}\n}\n}\n\n")
+
+	default:
+		value := "i"
+		if t.Name != "" {
+			value = fmt.Sprintf("%s(i)", w.typeExpr(t))
+		}
+		w.printf(`{
+// %s
+i, err := d.%s()
+if err != nil {
+	return 0, err
+}
+%s = %s
+}
+
+`, path, methodName(t.Kind), path, value)
+	}
+}
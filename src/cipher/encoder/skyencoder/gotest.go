@@ -0,0 +1,504 @@
+package skyencoder
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// GoTests generates tests of the Go encoders generated by GoEncoders.
+// The tests compare the generated encoders with the reflection based encoder of package encoder
+// on random objects, and check that they reproduce the test vectors generated by Vectors.
+type GoTests struct{}
+
+// Name returns the name of the generator
+func (GoTests) Name() string {
+	return "go-test"
+}
+
+// Generate generates the tests of the Go encoders of a package
+func (GoTests) Generate(p *Package) ([]File, error) {
+	types, err := p.Encoders()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(types))
+	for _, t := range types {
+		w := newGoWriter(p)
+		for _, path := range []string{
+			"bytes",
+			"fmt",
+			"reflect",
+			"strings",
+			"testing",
+			"time",
+			"github.com/google/go-cmp/cmp",
+			"github.com/google/go-cmp/cmp/cmpopts",
+			"github.com/skycoin/encodertest",
+			encoderPath,
+			encoderPath + "/skyencoder",
+		} {
+			w.addImport("", path)
+		}
+		w.addImport("mathrand", "math/rand")
+
+		if err := goTestTemplate.Execute(&w.buf, struct {
+			Name        string
+			Type        string
+			VectorsFile string
+		}{
+			Name:        encoderName(t),
+			Type:        w.typeExpr(t),
+			VectorsFile: VectorsFilename,
+		}); err != nil {
+			return nil, err
+		}
+
+		data, err := w.source()
+		if err != nil {
+			return nil, fmt.Errorf("encoder %s: %v", t.QualifiedName(), err)
+		}
+
+		files = append(files, File{
+			Path: snakeCase(t.Name) + "_skyencoder_test.go",
+			Data: data,
+		})
+	}
+
+	return files, nil
+}
+
+var goTestTemplate = template.Must(template.New("test").Parse(`func newEmpty{{.Name}}ForEncodeTest() *{{.Type}} {
+	var obj {{.Type}}
+	return &obj
+}
+
+func newRandom{{.Name}}ForEncodeTest(t *testing.T, rand *mathrand.Rand) *{{.Type}} {
+	var obj {{.Type}}
+	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
+		MaxRandLen: 4,
+		MinRandLen: 1,
+	})
+	if err != nil {
+		t.Fatalf("encodertest.PopulateRandom failed: %v", err)
+	}
+	return &obj
+}
+
+func newRandomZeroLen{{.Name}}ForEncodeTest(t *testing.T, rand *mathrand.Rand) *{{.Type}} {
+	var obj {{.Type}}
+	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
+		MaxRandLen:    0,
+		MinRandLen:    0,
+		EmptySliceNil: false,
+		EmptyMapNil:   false,
+	})
+	if err != nil {
+		t.Fatalf("encodertest.PopulateRandom failed: %v", err)
+	}
+	return &obj
+}
+
+func newRandomZeroLenNil{{.Name}}ForEncodeTest(t *testing.T, rand *mathrand.Rand) *{{.Type}} {
+	var obj {{.Type}}
+	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
+		MaxRandLen:    0,
+		MinRandLen:    0,
+		EmptySliceNil: true,
+		EmptyMapNil:   true,
+	})
+	if err != nil {
+		t.Fatalf("encodertest.PopulateRandom failed: %v", err)
+	}
+	return &obj
+}
+
+func testSkyencoder{{.Name}}(t *testing.T, obj *{{.Type}}) {
+	isEncodableField := func(f reflect.StructField) bool {
+		// Skip unexported fields
+		if f.PkgPath != "" {
+			return false
+		}
+
+		// Skip fields disabled with and enc:"- struct tag
+		tag := f.Tag.Get("enc")
+		return !strings.HasPrefix(tag, "-,") && tag != "-"
+	}
+
+	hasOmitEmptyField := func(obj interface{}) bool {
+		v := reflect.ValueOf(obj)
+		switch v.Kind() {
+		case reflect.Ptr:
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			t := v.Type()
+			n := v.NumField()
+			f := t.Field(n - 1)
+			tag := f.Tag.Get("enc")
+			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
+		default:
+			return false
+		}
+	}
+
+	// returns the number of bytes encoded by an omitempty field on a given object
+	omitEmptyLen := func(obj interface{}) uint64 {
+		if !hasOmitEmptyField(obj) {
+			return 0
+		}
+
+		v := reflect.ValueOf(obj)
+		switch v.Kind() {
+		case reflect.Ptr:
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			n := v.NumField()
+			f := v.Field(n - 1)
+			if f.Len() == 0 {
+				return 0
+			}
+			return uint64(4 + f.Len())
+
+		default:
+			return 0
+		}
+	}
+
+	// encodeSize
+
+	n1 := encoder.Size(obj)
+	n2 := encodeSize{{.Name}}(obj)
+
+	if uint64(n1) != n2 {
+		t.Fatalf("encoder.Size() != encodeSize{{.Name}}() (%d != %d)", n1, n2)
+	}
+
+	// Encode
+
+	// encoder.Serialize
+	data1 := encoder.Serialize(obj)
+
+	// Encode
+	data2, err := encode{{.Name}}(obj)
+	if err != nil {
+		t.Fatalf("encode{{.Name}} failed: %v", err)
+	}
+	if uint64(len(data2)) != n2 {
+		t.Fatal("encode{{.Name}} produced bytes of unexpected length")
+	}
+	if len(data1) != len(data2) {
+		t.Fatalf("len(encoder.Serialize()) != len(encode{{.Name}}()) (%d != %d)", len(data1), len(data2))
+	}
+
+	// EncodeToBuffer
+	data3 := make([]byte, n2+5)
+	if err := encode{{.Name}}ToBuffer(data3, obj); err != nil {
+		t.Fatalf("encode{{.Name}}ToBuffer failed: %v", err)
+	}
+
+	if !bytes.Equal(data1, data2) {
+		t.Fatal("encoder.Serialize() != encode{{.Name}}()")
+	}
+
+	// Decode
+
+	// encoder.DeserializeRaw
+	var obj2 {{.Type}}
+	if n, err := encoder.DeserializeRaw(data1, &obj2); err != nil {
+		t.Fatalf("encoder.DeserializeRaw failed: %v", err)
+	} else if n != uint64(len(data1)) {
+		t.Fatalf("encoder.DeserializeRaw failed: %v", encoder.ErrRemainingBytes)
+	}
+	if !cmp.Equal(*obj, obj2, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("encoder.DeserializeRaw result wrong")
+	}
+
+	// Decode
+	var obj3 {{.Type}}
+	if n, err := decode{{.Name}}(data2, &obj3); err != nil {
+		t.Fatalf("decode{{.Name}} failed: %v", err)
+	} else if n != uint64(len(data2)) {
+		t.Fatalf("decode{{.Name}} bytes read length should be %d, is %d", len(data2), n)
+	}
+	if !cmp.Equal(obj2, obj3, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("encoder.DeserializeRaw() != decode{{.Name}}()")
+	}
+
+	// Decode, excess buffer
+	var obj4 {{.Type}}
+	n, err := decode{{.Name}}(data3, &obj4)
+	if err != nil {
+		t.Fatalf("decode{{.Name}} failed: %v", err)
+	}
+
+	if hasOmitEmptyField(&obj4) && omitEmptyLen(&obj4) == 0 {
+		// 4 bytes read for the omitEmpty length, which should be zero (see the 5 bytes added above)
+		if n != n2+4 {
+			t.Fatalf("decode{{.Name}} bytes read length should be %d, is %d", n2+4, n)
+		}
+	} else {
+		if n != n2 {
+			t.Fatalf("decode{{.Name}} bytes read length should be %d, is %d", n2, n)
+		}
+	}
+	if !cmp.Equal(obj2, obj4, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("encoder.DeserializeRaw() != decode{{.Name}}()")
+	}
+
+	// DecodeExact
+	var obj5 {{.Type}}
+	if err := decode{{.Name}}Exact(data2, &obj5); err != nil {
+		t.Fatalf("decode{{.Name}} failed: %v", err)
+	}
+	if !cmp.Equal(obj2, obj5, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("encoder.DeserializeRaw() != decode{{.Name}}()")
+	}
+
+	// Check that the bytes read value is correct when providing an extended buffer
+	if !hasOmitEmptyField(&obj3) || omitEmptyLen(&obj3) > 0 {
+		padding := []byte{0xFF, 0xFE, 0xFD, 0xFC}
+		data4 := append(data2[:], padding...)
+		if n, err := decode{{.Name}}(data4, &obj3); err != nil {
+			t.Fatalf("decode{{.Name}} failed: %v", err)
+		} else if n != uint64(len(data2)) {
+			t.Fatalf("decode{{.Name}} bytes read length should be %d, is %d", len(data2), n)
+		}
+	}
+}
+
+func TestSkyencoder{{.Name}}(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
+
+	type testCase struct {
+		name string
+		obj  *{{.Type}}
+	}
+
+	cases := []testCase{
+		{
+			name: "empty object",
+			obj:  newEmpty{{.Name}}ForEncodeTest(),
+		},
+	}
+
+	nRandom := 10
+
+	for i := 0; i < nRandom; i++ {
+		cases = append(cases, testCase{
+			name: fmt.Sprintf("randomly populated object %d", i),
+			obj:  newRandom{{.Name}}ForEncodeTest(t, rand),
+		})
+		cases = append(cases, testCase{
+			name: fmt.Sprintf("randomly populated object %d with zero length variable length contents", i),
+			obj:  newRandomZeroLen{{.Name}}ForEncodeTest(t, rand),
+		})
+		cases = append(cases, testCase{
+			name: fmt.Sprintf("randomly populated object %d with zero length variable length contents set to nil", i),
+			obj:  newRandomZeroLenNil{{.Name}}ForEncodeTest(t, rand),
+		})
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSkyencoder{{.Name}}(t, tc.obj)
+		})
+	}
+}
+
+func decode{{.Name}}ExpectError(t *testing.T, buf []byte, expectedErr error) {
+	var obj {{.Type}}
+	if _, err := decode{{.Name}}(buf, &obj); err == nil {
+		t.Fatal("decode{{.Name}}: expected error, got nil")
+	} else if err != expectedErr {
+		t.Fatalf("decode{{.Name}}: expected error %q, got %q", expectedErr, err)
+	}
+}
+
+func decode{{.Name}}ExactExpectError(t *testing.T, buf []byte, expectedErr error) {
+	var obj {{.Type}}
+	if err := decode{{.Name}}Exact(buf, &obj); err == nil {
+		t.Fatal("decode{{.Name}}Exact: expected error, got nil")
+	} else if err != expectedErr {
+		t.Fatalf("decode{{.Name}}Exact: expected error %q, got %q", expectedErr, err)
+	}
+}
+
+func testSkyencoder{{.Name}}DecodeErrors(t *testing.T, k int, tag string, obj *{{.Type}}) {
+	isEncodableField := func(f reflect.StructField) bool {
+		// Skip unexported fields
+		if f.PkgPath != "" {
+			return false
+		}
+
+		// Skip fields disabled with and enc:"- struct tag
+		tag := f.Tag.Get("enc")
+		return !strings.HasPrefix(tag, "-,") && tag != "-"
+	}
+
+	numEncodableFields := func(obj interface{}) int {
+		v := reflect.ValueOf(obj)
+		switch v.Kind() {
+		case reflect.Ptr:
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			t := v.Type()
+
+			n := 0
+			for i := 0; i < v.NumField(); i++ {
+				f := t.Field(i)
+				if !isEncodableField(f) {
+					continue
+				}
+				n++
+			}
+			return n
+		default:
+			return 0
+		}
+	}
+
+	hasOmitEmptyField := func(obj interface{}) bool {
+		v := reflect.ValueOf(obj)
+		switch v.Kind() {
+		case reflect.Ptr:
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			t := v.Type()
+			n := v.NumField()
+			f := t.Field(n - 1)
+			tag := f.Tag.Get("enc")
+			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
+		default:
+			return false
+		}
+	}
+
+	// returns the number of bytes encoded by an omitempty field on a given object
+	omitEmptyLen := func(obj interface{}) uint64 {
+		if !hasOmitEmptyField(obj) {
+			return 0
+		}
+
+		v := reflect.ValueOf(obj)
+		switch v.Kind() {
+		case reflect.Ptr:
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			n := v.NumField()
+			f := v.Field(n - 1)
+			if f.Len() == 0 {
+				return 0
+			}
+			return uint64(4 + f.Len())
+
+		default:
+			return 0
+		}
+	}
+
+	n := encodeSize{{.Name}}(obj)
+	buf, err := encode{{.Name}}(obj)
+	if err != nil {
+		t.Fatalf("encode{{.Name}} failed: %v", err)
+	}
+
+	// A nil buffer cannot decode, unless the object is a struct with a single omitempty field
+	if hasOmitEmptyField(obj) && numEncodableFields(obj) > 1 {
+		t.Run(fmt.Sprintf("%d %s buffer underflow nil", k, tag), func(t *testing.T) {
+			decode{{.Name}}ExpectError(t, nil, encoder.ErrBufferUnderflow)
+		})
+
+		t.Run(fmt.Sprintf("%d %s exact buffer underflow nil", k, tag), func(t *testing.T) {
+			decode{{.Name}}ExactExpectError(t, nil, encoder.ErrBufferUnderflow)
+		})
+	}
+
+	// Test all possible truncations of the encoded byte array, but skip
+	// a truncation that would be valid where omitempty is removed
+	skipN := n - omitEmptyLen(obj)
+	for i := uint64(0); i < n; i++ {
+		if i == skipN {
+			continue
+		}
+
+		t.Run(fmt.Sprintf("%d %s buffer underflow bytes=%d", k, tag, i), func(t *testing.T) {
+			decode{{.Name}}ExpectError(t, buf[:i], encoder.ErrBufferUnderflow)
+		})
+
+		t.Run(fmt.Sprintf("%d %s exact buffer underflow bytes=%d", k, tag, i), func(t *testing.T) {
+			decode{{.Name}}ExactExpectError(t, buf[:i], encoder.ErrBufferUnderflow)
+		})
+	}
+
+	// Append 5 bytes for omit empty with a 0 length prefix, to cause an ErrRemainingBytes.
+	// If only 1 byte is appended, the decoder will try to read the 4-byte length prefix,
+	// and return an ErrBufferUnderflow instead
+	if hasOmitEmptyField(obj) {
+		buf = append(buf, []byte{0, 0, 0, 0, 0}...)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	t.Run(fmt.Sprintf("%d %s exact buffer remaining bytes", k, tag), func(t *testing.T) {
+		decode{{.Name}}ExactExpectError(t, buf, encoder.ErrRemainingBytes)
+	})
+}
+
+func TestSkyencoder{{.Name}}DecodeErrors(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
+	n := 10
+
+	for i := 0; i < n; i++ {
+		emptyObj := newEmpty{{.Name}}ForEncodeTest()
+		fullObj := newRandom{{.Name}}ForEncodeTest(t, rand)
+		testSkyencoder{{.Name}}DecodeErrors(t, i, "empty", emptyObj)
+		testSkyencoder{{.Name}}DecodeErrors(t, i, "full", fullObj)
+	}
+}
+
+func TestSkyencoder{{.Name}}Vectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("{{.VectorsFile}}", "{{.Name}}")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj {{.Type}}
+			if err := decode{{.Name}}Exact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decode{{.Name}}Exact failed: %v", err)
+			}
+
+			data, err := encode{{.Name}}(&obj)
+			if err != nil {
+				t.Fatalf("encode{{.Name}} failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encode{{.Name}}() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
+`))
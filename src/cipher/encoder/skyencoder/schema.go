@@ -0,0 +1,643 @@
+// Package skyencoder generates code and test vectors for the skycoin binary encoding format
+// (see package encoder) from schema files.
+//
+// Every package with encoded types has a skyencoder.json schema file, which describes
+// its encoded types and lists the types to generate encoders for:
+//
+//	{
+//	    "package": "coin",
+//	    "imports": {
+//	        "cipher": "github.com/skycoin/skycoin/src/cipher"
+//	    },
+//	    "types": [
+//	        {
+//	            "name": "Transaction",
+//	            "fields": [
+//	                {"name": "Length", "type": "uint32"},
+//	                {"name": "Sigs", "type": "[]cipher.Sig", "maxlen": 65535}
+//	            ]
+//	        },
+//	        {"name": "Transactions", "type": "[]Transaction"}
+//	    ],
+//	    "encoders": ["Transaction"]
+//	}
+//
+// Types are written as Go type expressions. A type with fields is a struct,
+// otherwise "type" is the underlying type of a named type. Types of other packages
+// are qualified with a name of the imports, and are resolved from the schema of that package.
+// Only the fields that are encoded are described, in the order of the struct.
+// "maxlen" and "omitempty" correspond to the `enc` struct tag options of package encoder.
+//
+// The files of a package are produced by Generators. The default generators write the Go encoders
+// of the encoder types and their tests, and a file of test vectors to verify other implementations
+// of the encoding against.
+package skyencoder
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaFilename is the name of the schema file of a package
+const SchemaFilename = "skyencoder.json"
+
+// Schema is the schema file of a package
+type Schema struct {
+	// Package is the name of the package
+	Package string `json:"package"`
+	// Imports maps the package names used in type expressions to import paths
+	Imports map[string]string `json:"imports,omitempty"`
+	// Types are the encoded types defined by the package
+	Types []TypeSpec `json:"types"`
+	// Encoders are the types to generate encoders for, which can be types of other packages
+	Encoders []string `json:"encoders,omitempty"`
+}
+
+// TypeSpec describes a named type
+type TypeSpec struct {
+	Name string `json:"name"`
+	// Type is the underlying type of a type that is not a struct
+	Type string `json:"type,omitempty"`
+	// Fields are the encoded fields of a struct
+	Fields []FieldSpec `json:"fields,omitempty"`
+}
+
+// FieldSpec describes an encoded struct field
+type FieldSpec struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	MaxLen    int    `json:"maxlen,omitempty"`
+	OmitEmpty bool   `json:"omitempty,omitempty"`
+}
+
+// Kind is the kind of an encoded type
+type Kind int
+
+const (
+	// Bool is encoded as a single byte, 0 or 1
+	Bool Kind = iota + 1
+	// Uint8 is encoded as a single byte
+	Uint8
+	// Uint16 is encoded in 2 bytes, little endian
+	Uint16
+	// Uint32 is encoded in 4 bytes, little endian
+	Uint32
+	// Uint64 is encoded in 8 bytes, little endian
+	Uint64
+	// Int8 is encoded as a single byte
+	Int8
+	// Int16 is encoded in 2 bytes, little endian
+	Int16
+	// Int32 is encoded in 4 bytes, little endian
+	Int32
+	// Int64 is encoded in 8 bytes, little endian
+	Int64
+	// String is encoded as a uint32 length prefix followed by the bytes of the string
+	String
+	// Array is encoded as its elements, without a length prefix
+	Array
+	// Slice is encoded as a uint32 length prefix followed by its elements
+	Slice
+	// Struct is encoded as its fields, in order
+	Struct
+)
+
+var basicKinds = map[string]Kind{
+	"bool":   Bool,
+	"byte":   Uint8,
+	"uint8":  Uint8,
+	"uint16": Uint16,
+	"uint32": Uint32,
+	"uint64": Uint64,
+	"int8":   Int8,
+	"int16":  Int16,
+	"int32":  Int32,
+	"int64":  Int64,
+	"string": String,
+}
+
+var kindNames = map[Kind]string{
+	Bool:   "bool",
+	Uint8:  "uint8",
+	Uint16: "uint16",
+	Uint32: "uint32",
+	Uint64: "uint64",
+	Int8:   "int8",
+	Int16:  "int16",
+	Int32:  "int32",
+	Int64:  "int64",
+	String: "string",
+	Array:  "array",
+	Slice:  "slice",
+	Struct: "struct",
+}
+
+// String returns the name of the kind
+func (k Kind) String() string {
+	if s, ok := kindNames[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// IsInteger returns true for the integer kinds
+func (k Kind) IsInteger() bool {
+	return k >= Uint8 && k <= Int64
+}
+
+// size returns the encoded size of a bool or integer kind
+func (k Kind) size() uint64 {
+	switch k {
+	case Bool, Uint8, Int8:
+		return 1
+	case Uint16, Int16:
+		return 2
+	case Uint32, Int32:
+		return 4
+	case Uint64, Int64:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// Type is a resolved encoded type
+type Type struct {
+	Kind Kind
+	// Name is the name of a named type, empty for a type literal
+	Name string
+	// PkgPath and PkgName are the import path and name of the package of a named type
+	PkgPath string
+	PkgName string
+	// Len is the length of an array
+	Len int
+	// Elem is the element type of an array or slice
+	Elem *Type
+	// Fields are the encoded fields of a struct
+	Fields []Field
+}
+
+// Field is an encoded struct field
+type Field struct {
+	Name      string
+	Type      *Type
+	MaxLen    int
+	OmitEmpty bool
+}
+
+// QualifiedName returns the name of a named type qualified with its import path
+func (t *Type) QualifiedName() string {
+	if t.Name == "" {
+		return ""
+	}
+	return t.PkgPath + "." + t.Name
+}
+
+// IsBytes returns true for arrays and slices of bytes, which are copied rather than encoded element by element
+func (t *Type) IsBytes() bool {
+	return (t.Kind == Array || t.Kind == Slice) && t.Elem.Kind == Uint8
+}
+
+// FixedSize returns the encoded size of a type whose size doesn't depend on its value.
+// Returns false if the type contains a string or slice.
+func (t *Type) FixedSize() (uint64, bool) {
+	switch t.Kind {
+	case String, Slice:
+		return 0, false
+	case Array:
+		n, ok := t.Elem.FixedSize()
+		return uint64(t.Len) * n, ok
+	case Struct:
+		var n uint64
+		for _, f := range t.Fields {
+			m, ok := f.Type.FixedSize()
+			if !ok {
+				return 0, false
+			}
+			n += m
+		}
+		return n, true
+	default:
+		return t.Kind.size(), true
+	}
+}
+
+// Package is a loaded package schema
+type Package struct {
+	// Path is the import path of the package
+	Path string
+	// Dir is the directory of the package
+	Dir    string
+	Schema Schema
+
+	loader    *Loader
+	specs     map[string]*TypeSpec
+	types     map[string]*Type
+	resolving map[string]bool
+}
+
+// Loader loads the package schemas of a module
+type Loader struct {
+	root   string
+	module string
+	pkgs   map[string]*Package
+}
+
+// NewLoader creates a Loader for the module that contains dir
+func NewLoader(dir string) (*Loader, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for d := dir; ; {
+		module, err := readModulePath(filepath.Join(d, "go.mod"))
+		if err == nil {
+			return &Loader{
+				root:   d,
+				module: module,
+				pkgs:   make(map[string]*Package),
+			}, nil
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		parent := filepath.Dir(d)
+		if parent == d {
+			return nil, fmt.Errorf("no go.mod found in %s or its parents", dir)
+		}
+		d = parent
+	}
+}
+
+func readModulePath(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("%s has no module directive", filename)
+}
+
+// Root returns the root directory of the module
+func (l *Loader) Root() string {
+	return l.root
+}
+
+// Load loads the schema of the package in a directory
+func (l *Loader) Load(dir string) (*Package, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(l.root, dir)
+	if err != nil {
+		return nil, err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%s is not in module %s", dir, l.module)
+	}
+
+	path := l.module
+	if rel != "." {
+		path += "/" + filepath.ToSlash(rel)
+	}
+
+	return l.Import(path)
+}
+
+// Import loads the schema of a package by import path
+func (l *Loader) Import(path string) (*Package, error) {
+	if p, ok := l.pkgs[path]; ok {
+		return p, nil
+	}
+
+	if path != l.module && !strings.HasPrefix(path, l.module+"/") {
+		return nil, fmt.Errorf("package %s is not in module %s", path, l.module)
+	}
+
+	dir := filepath.Join(l.root, filepath.FromSlash(strings.TrimPrefix(path, l.module)))
+	filename := filepath.Join(dir, SchemaFilename)
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Package{
+		Path:      path,
+		Dir:       dir,
+		loader:    l,
+		specs:     make(map[string]*TypeSpec),
+		types:     make(map[string]*Type),
+		resolving: make(map[string]bool),
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p.Schema); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+
+	l.pkgs[path] = p
+	return p, nil
+}
+
+func (p *Package) validate() error {
+	if !token.IsIdentifier(p.Schema.Package) {
+		return fmt.Errorf("invalid package name %q", p.Schema.Package)
+	}
+
+	if p.Schema.Package != filepath.Base(p.Dir) && p.Schema.Package != "main" {
+		return fmt.Errorf("package name %q does not match the directory %s", p.Schema.Package, filepath.Base(p.Dir))
+	}
+
+	for name := range p.Schema.Imports {
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("invalid import name %q", name)
+		}
+	}
+
+	for i := range p.Schema.Types {
+		ts := &p.Schema.Types[i]
+		if !token.IsIdentifier(ts.Name) {
+			return fmt.Errorf("invalid type name %q", ts.Name)
+		}
+		if _, ok := p.specs[ts.Name]; ok {
+			return fmt.Errorf("type %s is defined more than once", ts.Name)
+		}
+		if ts.Type != "" && len(ts.Fields) != 0 {
+			return fmt.Errorf("type %s has both a type and fields", ts.Name)
+		}
+		p.specs[ts.Name] = ts
+	}
+
+	return nil
+}
+
+// Lookup returns a type defined by the package
+func (p *Package) Lookup(name string) (*Type, error) {
+	if t, ok := p.types[name]; ok {
+		return t, nil
+	}
+
+	ts, ok := p.specs[name]
+	if !ok {
+		return nil, fmt.Errorf("type %s is not defined in the schema of %s", name, p.Path)
+	}
+
+	if p.resolving[name] {
+		return nil, fmt.Errorf("type %s.%s is recursive", p.Path, name)
+	}
+	p.resolving[name] = true
+	defer delete(p.resolving, name)
+
+	t, err := p.resolveSpec(ts)
+	if err != nil {
+		return nil, fmt.Errorf("type %s.%s: %v", p.Path, name, err)
+	}
+
+	p.types[name] = t
+	return t, nil
+}
+
+func (p *Package) resolveSpec(ts *TypeSpec) (*Type, error) {
+	t := &Type{
+		Name:    ts.Name,
+		PkgPath: p.Path,
+		PkgName: p.Schema.Package,
+	}
+
+	if ts.Type != "" {
+		u, err := p.Resolve(ts.Type)
+		if err != nil {
+			return nil, err
+		}
+		t.Kind = u.Kind
+		t.Len = u.Len
+		t.Elem = u.Elem
+		t.Fields = u.Fields
+		return t, nil
+	}
+
+	t.Kind = Struct
+	t.Fields = make([]Field, len(ts.Fields))
+	names := make(map[string]struct{}, len(ts.Fields))
+	for i, fs := range ts.Fields {
+		if !token.IsIdentifier(fs.Name) || !token.IsExported(fs.Name) {
+			return nil, fmt.Errorf("invalid field name %q, encoded fields must be exported", fs.Name)
+		}
+		if _, ok := names[fs.Name]; ok {
+			return nil, fmt.Errorf("field %s is defined more than once", fs.Name)
+		}
+		names[fs.Name] = struct{}{}
+
+		ft, err := p.Resolve(fs.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", fs.Name, err)
+		}
+
+		if fs.MaxLen < 0 {
+			return nil, fmt.Errorf("field %s: maxlen must be positive", fs.Name)
+		}
+		if (fs.MaxLen != 0 || fs.OmitEmpty) && ft.Kind != Slice && ft.Kind != String {
+			return nil, fmt.Errorf("field %s: maxlen and omitempty are only valid for slices and strings", fs.Name)
+		}
+		if fs.OmitEmpty && i != len(ts.Fields)-1 {
+			return nil, fmt.Errorf("field %s: omitempty is only valid for the last field", fs.Name)
+		}
+
+		t.Fields[i] = Field{
+			Name:      fs.Name,
+			Type:      ft,
+			MaxLen:    fs.MaxLen,
+			OmitEmpty: fs.OmitEmpty,
+		}
+	}
+
+	return t, nil
+}
+
+// Resolve resolves a type expression of the schema
+func (p *Package) Resolve(expr string) (*Type, error) {
+	x, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q: %v", expr, err)
+	}
+
+	t, err := p.resolveExpr(x, p.importPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q: %v", expr, err)
+	}
+	return t, nil
+}
+
+func (p *Package) importPath(name string) (string, bool) {
+	path, ok := p.Schema.Imports[name]
+	return path, ok
+}
+
+// resolveExpr resolves the type of a Go type expression. importPath maps the package names
+// of qualified identifiers to import paths.
+func (p *Package) resolveExpr(x ast.Expr, importPath func(string) (string, bool)) (*Type, error) {
+	switch x := x.(type) {
+	case *ast.ParenExpr:
+		return p.resolveExpr(x.X, importPath)
+
+	case *ast.Ident:
+		if _, ok := p.specs[x.Name]; ok {
+			return p.Lookup(x.Name)
+		}
+		if k, ok := basicKinds[x.Name]; ok {
+			return &Type{Kind: k}, nil
+		}
+		return nil, fmt.Errorf("undefined type %s", x.Name)
+
+	case *ast.SelectorExpr:
+		pkgIdent, ok := x.X.(*ast.Ident)
+		if !ok {
+			return nil, errors.New("unsupported qualified type")
+		}
+		path, ok := importPath(pkgIdent.Name)
+		if !ok {
+			return nil, fmt.Errorf("unknown package %s", pkgIdent.Name)
+		}
+		q, err := p.loader.Import(path)
+		if err != nil {
+			return nil, err
+		}
+		return q.Lookup(x.Sel.Name)
+
+	case *ast.ArrayType:
+		elem, err := p.resolveExpr(x.Elt, importPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if x.Len == nil {
+			return &Type{
+				Kind: Slice,
+				Elem: elem,
+			}, nil
+		}
+
+		n, err := evalLen(x.Len)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Type{
+			Kind: Array,
+			Len:  n,
+			Elem: elem,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type %T", x)
+	}
+}
+
+// evalLen evaluates a constant array length expression, e.g. "64 + 1"
+func evalLen(x ast.Expr) (int, error) {
+	switch x := x.(type) {
+	case *ast.ParenExpr:
+		return evalLen(x.X)
+
+	case *ast.BasicLit:
+		if x.Kind != token.INT {
+			return 0, fmt.Errorf("invalid array length %s", x.Value)
+		}
+		n, err := strconv.ParseInt(x.Value, 0, 32)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid array length %s", x.Value)
+		}
+		return int(n), nil
+
+	case *ast.BinaryExpr:
+		a, err := evalLen(x.X)
+		if err != nil {
+			return 0, err
+		}
+		b, err := evalLen(x.Y)
+		if err != nil {
+			return 0, err
+		}
+
+		switch x.Op {
+		case token.ADD:
+			return a + b, nil
+		case token.SUB:
+			return a - b, nil
+		case token.MUL:
+			return a * b, nil
+		default:
+			return 0, fmt.Errorf("unsupported operator %s in array length", x.Op)
+		}
+
+	default:
+		return 0, errors.New("array length must be a constant")
+	}
+}
+
+// Encoders returns the types to generate encoders for, sorted by name
+func (p *Package) Encoders() ([]*Type, error) {
+	types := make([]*Type, 0, len(p.Schema.Encoders))
+	names := make(map[string]string, len(p.Schema.Encoders))
+	for _, e := range p.Schema.Encoders {
+		t, err := p.Resolve(e)
+		if err != nil {
+			return nil, fmt.Errorf("encoder %s: %v", e, err)
+		}
+
+		if t.Kind != Struct || t.Name == "" {
+			return nil, fmt.Errorf("encoder %s: encoders can only be generated for structs", e)
+		}
+
+		name := encoderName(t)
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("encoders %s and %s have the same name %s", other, e, name)
+		}
+		names[name] = e
+
+		types = append(types, t)
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return encoderName(types[i]) < encoderName(types[j])
+	})
+
+	return types, nil
+}
+
+// encoderName is the name used in the function names of the encoder of a type
+func encoderName(t *Type) string {
+	return strings.ToUpper(t.Name[:1]) + t.Name[1:]
+}
@@ -0,0 +1,220 @@
+package skyencoder
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeModule writes a module with the given files to a temporary directory
+func writeModule(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "skyencoder")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir) // nolint: errcheck
+	})
+
+	files["go.mod"] = "module example.com/m\n\ngo 1.14\n"
+	for name, data := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0750))
+		require.NoError(t, ioutil.WriteFile(filename, []byte(data), 0600))
+	}
+
+	return dir
+}
+
+const testSchemaA = `{
+    "package": "a",
+    "types": [
+        {"name": "Hash", "type": "[16 * 2]byte"},
+        {"name": "Hashes", "type": "[]Hash"},
+        {
+            "name": "Thing",
+            "fields": [
+                {"name": "Flag", "type": "bool"},
+                {"name": "N", "type": "int16"},
+                {"name": "Hashes", "type": "Hashes", "maxlen": 8},
+                {"name": "Name", "type": "string", "omitempty": true}
+            ]
+        }
+    ],
+    "encoders": ["Thing"]
+}`
+
+const testSchemaB = `{
+    "package": "b",
+    "imports": {
+        "a": "example.com/m/a"
+    },
+    "types": [
+        {
+            "name": "wrapper",
+            "fields": [
+                {"name": "Things", "type": "[]a.Thing"},
+                {"name": "Grid", "type": "[2][3]uint64"}
+            ]
+        }
+    ],
+    "encoders": ["wrapper", "a.Thing"]
+}`
+
+func TestLoad(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"a/skyencoder.json": testSchemaA,
+		"b/skyencoder.json": testSchemaB,
+	})
+
+	l, err := NewLoader(filepath.Join(dir, "b"))
+	require.NoError(t, err)
+
+	b, err := l.Load(filepath.Join(dir, "b"))
+	require.NoError(t, err)
+	require.Equal(t, "example.com/m/b", b.Path)
+
+	a, err := l.Import("example.com/m/a")
+	require.NoError(t, err)
+
+	hash, err := a.Lookup("Hash")
+	require.NoError(t, err)
+	require.Equal(t, Array, hash.Kind)
+	require.Equal(t, 32, hash.Len)
+	require.True(t, hash.IsBytes())
+	require.Equal(t, "example.com/m/a.Hash", hash.QualifiedName())
+
+	thing, err := a.Lookup("Thing")
+	require.NoError(t, err)
+	require.Equal(t, Struct, thing.Kind)
+	require.Len(t, thing.Fields, 4)
+	require.Equal(t, Bool, thing.Fields[0].Type.Kind)
+	require.Equal(t, Int16, thing.Fields[1].Type.Kind)
+	require.Equal(t, Slice, thing.Fields[2].Type.Kind)
+	require.Equal(t, 8, thing.Fields[2].MaxLen)
+	require.True(t, hash == thing.Fields[2].Type.Elem)
+	require.True(t, thing.Fields[3].OmitEmpty)
+	_, fixed := thing.FixedSize()
+	require.False(t, fixed)
+
+	encoders, err := b.Encoders()
+	require.NoError(t, err)
+	require.Len(t, encoders, 2)
+	require.True(t, thing == encoders[0])
+	require.Equal(t, "wrapper", encoders[1].Name)
+
+	grid := encoders[1].Fields[1].Type
+	n, fixed := grid.FixedSize()
+	require.True(t, fixed)
+	require.Equal(t, uint64(48), n)
+
+	_, err = l.Load(dir)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestLoadErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{
+			name:   "unknown type",
+			schema: `{"package": "a", "types": [{"name": "A", "fields": [{"name": "X", "type": "float64"}]}]}`,
+			err:    "field X: invalid type \"float64\": undefined type float64",
+		},
+		{
+			name:   "unknown package",
+			schema: `{"package": "a", "types": [{"name": "A", "fields": [{"name": "X", "type": "c.X"}]}]}`,
+			err:    "field X: invalid type \"c.X\": unknown package c",
+		},
+		{
+			name:   "recursive type",
+			schema: `{"package": "a", "types": [{"name": "A", "fields": [{"name": "X", "type": "[]A"}]}]}`,
+			err:    "recursive",
+		},
+		{
+			name:   "maxlen of an integer",
+			schema: `{"package": "a", "types": [{"name": "A", "fields": [{"name": "X", "type": "uint8", "maxlen": 2}]}]}`,
+			err:    "field X: maxlen and omitempty are only valid for slices and strings",
+		},
+		{
+			name:   "omitempty not last",
+			schema: `{"package": "a", "types": [{"name": "A", "fields": [{"name": "X", "type": "[]byte", "omitempty": true}, {"name": "Y", "type": "uint8"}]}]}`,
+			err:    "field X: omitempty is only valid for the last field",
+		},
+		{
+			name:   "unexported field",
+			schema: `{"package": "a", "types": [{"name": "A", "fields": [{"name": "x", "type": "uint8"}]}]}`,
+			err:    "invalid field name \"x\", encoded fields must be exported",
+		},
+		{
+			name:   "encoder of a slice",
+			schema: `{"package": "a", "types": [{"name": "A", "type": "[]byte"}], "encoders": ["A"]}`,
+			err:    "encoder A: encoders can only be generated for structs",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeModule(t, map[string]string{
+				"a/skyencoder.json": tc.schema,
+			})
+
+			l, err := NewLoader(dir)
+			require.NoError(t, err)
+			p, err := l.Load(filepath.Join(dir, "a"))
+			require.NoError(t, err)
+
+			_, err = p.Encoders()
+			if err == nil {
+				_, err = p.Lookup("A")
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestLoadInvalidSchema(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"a/skyencoder.json": `{"package": "a", "types": [{"name": "A", "type": "uint8"}, {"name": "A", "type": "uint16"}]}`,
+		"b/skyencoder.json": `{"package": "a", "types": []}`,
+		"c/skyencoder.json": `{"package": "c", "types": [], "unknown": 1}`,
+	})
+
+	l, err := NewLoader(dir)
+	require.NoError(t, err)
+
+	_, err = l.Import("example.com/m/a")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type A is defined more than once")
+
+	_, err = l.Import("example.com/m/b")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "package name \"a\" does not match the directory b")
+
+	_, err = l.Import("example.com/m/c")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown field")
+
+	_, err = l.Import("example.com/other")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not in module example.com/m")
+}
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Transaction":         "transaction",
+		"IPAddr":              "ip_addr",
+		"hashPairsWrapper":    "hash_pairs_wrapper",
+		"UxOut":               "ux_out",
+		"IntroductionMessage": "introduction_message",
+		"SHA256":              "sha256",
+	}
+
+	for name, expected := range cases {
+		require.Equal(t, expected, snakeCase(name), name)
+	}
+}
@@ -0,0 +1,232 @@
+package skyencoder
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// CheckSource verifies that the types of the schema match the type declarations of the Go source
+// of the package. The encoded fields of a struct are its exported fields without an `enc:"-"` tag.
+func (p *Package) CheckSource() error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, p.Dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	pkg, ok := pkgs[p.Schema.Package]
+	if !ok {
+		return fmt.Errorf("package %s not found in %s", p.Schema.Package, p.Dir)
+	}
+
+	type decl struct {
+		spec *ast.TypeSpec
+		file *ast.File
+	}
+	decls := make(map[string]decl)
+	for _, f := range pkg.Files {
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gd.Specs {
+				ts := s.(*ast.TypeSpec)
+				decls[ts.Name.Name] = decl{ts, f}
+			}
+		}
+	}
+
+	for _, ts := range p.Schema.Types {
+		d, ok := decls[ts.Name]
+		if !ok {
+			return fmt.Errorf("type %s of the schema is not declared in %s", ts.Name, p.Path)
+		}
+
+		if err := p.checkTypeDecl(d.spec, fileImports(d.file)); err != nil {
+			return fmt.Errorf("%s: type %s does not match the schema: %v", fset.Position(d.spec.Pos()), ts.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// fileImports returns a function mapping the package names of the imports of a file to their paths
+func fileImports(f *ast.File) func(string) (string, bool) {
+	imports := make(map[string]string, len(f.Imports))
+	for _, s := range f.Imports {
+		path, err := strconv.Unquote(s.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := path[strings.LastIndex(path, "/")+1:]
+		if s.Name != nil {
+			name = s.Name.Name
+		}
+		imports[name] = path
+	}
+
+	return func(name string) (string, bool) {
+		path, ok := imports[name]
+		return path, ok
+	}
+}
+
+func (p *Package) checkTypeDecl(ts *ast.TypeSpec, importPath func(string) (string, bool)) error {
+	t, err := p.Lookup(ts.Name.Name)
+	if err != nil {
+		return err
+	}
+
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		if t.Kind == Struct {
+			return fmt.Errorf("declared as %T, not a struct", ts.Type)
+		}
+
+		u, err := p.resolveExpr(ts.Type, importPath)
+		if err != nil {
+			return err
+		}
+		su, err := p.Resolve(p.specs[ts.Name.Name].Type)
+		if err != nil {
+			return err
+		}
+		if !identical(u, su) {
+			return fmt.Errorf("declared as %s", formatExpr(ts.Type))
+		}
+		return nil
+	}
+
+	if t.Kind != Struct {
+		return fmt.Errorf("declared as a struct, the schema type is a %s", t.Kind)
+	}
+
+	var fields []Field
+	for _, f := range st.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			s, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return err
+			}
+			tag = reflect.StructTag(s)
+		}
+
+		encTag := tag.Get("enc")
+		if encTag == "-" || strings.HasPrefix(encTag, "-,") {
+			continue
+		}
+
+		var maxLen int
+		var omitEmpty bool
+		for _, opt := range strings.Split(encTag, ",")[1:] {
+			switch {
+			case opt == "omitempty":
+				omitEmpty = true
+			case strings.HasPrefix(opt, "maxlen="):
+				n, err := strconv.Atoi(strings.TrimPrefix(opt, "maxlen="))
+				if err != nil {
+					return fmt.Errorf("invalid maxlen tag option %q", opt)
+				}
+				maxLen = n
+			}
+		}
+
+		names := make([]string, len(f.Names))
+		for i, n := range f.Names {
+			names[i] = n.Name
+		}
+		if len(names) == 0 {
+			// The name of an embedded field is the name of its type
+			switch x := f.Type.(type) {
+			case *ast.Ident:
+				names = []string{x.Name}
+			case *ast.SelectorExpr:
+				names = []string{x.Sel.Name}
+			default:
+				return fmt.Errorf("unsupported embedded field %s", formatExpr(f.Type))
+			}
+		}
+
+		for _, name := range names {
+			if !token.IsExported(name) {
+				continue
+			}
+
+			ft, err := p.resolveExpr(f.Type, importPath)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+
+			fields = append(fields, Field{
+				Name:      name,
+				Type:      ft,
+				MaxLen:    maxLen,
+				OmitEmpty: omitEmpty,
+			})
+		}
+	}
+
+	if len(fields) != len(t.Fields) {
+		return fmt.Errorf("has %d encoded fields, the schema has %d", len(fields), len(t.Fields))
+	}
+
+	for i, f := range fields {
+		sf := t.Fields[i]
+		switch {
+		case f.Name != sf.Name:
+			return fmt.Errorf("encoded field %d is %s, the schema field is %s", i, f.Name, sf.Name)
+		case !identical(f.Type, sf.Type):
+			return fmt.Errorf("field %s has a different type than the schema", f.Name)
+		case f.MaxLen != sf.MaxLen:
+			return fmt.Errorf("field %s has maxlen %d, the schema has %d", f.Name, f.MaxLen, sf.MaxLen)
+		case f.OmitEmpty != sf.OmitEmpty:
+			return fmt.Errorf("field %s omitempty is %v, the schema has %v", f.Name, f.OmitEmpty, sf.OmitEmpty)
+		}
+	}
+
+	return nil
+}
+
+// identical returns true if two resolved types are identical. Named types are resolved once,
+// so named types are identical if they are the same object.
+func identical(a, b *Type) bool {
+	if a == b {
+		return true
+	}
+	if a.Name != "" || b.Name != "" || a.Kind != b.Kind || a.Len != b.Len {
+		return false
+	}
+	if a.Elem != nil || b.Elem != nil {
+		return a.Elem != nil && b.Elem != nil && identical(a.Elem, b.Elem)
+	}
+	return a.Kind != Struct
+}
+
+func formatExpr(x ast.Expr) string {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		return formatExpr(x.X) + "." + x.Sel.Name
+	case *ast.StarExpr:
+		return "*" + formatExpr(x.X)
+	case *ast.ArrayType:
+		if x.Len == nil {
+			return "[]" + formatExpr(x.Elt)
+		}
+		return "[...]" + formatExpr(x.Elt)
+	default:
+		return fmt.Sprintf("%T", x)
+	}
+}
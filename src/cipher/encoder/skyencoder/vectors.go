@@ -0,0 +1,512 @@
+package skyencoder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"math"
+	mathrand "math/rand"
+	"strconv"
+)
+
+// VectorsFilename is the path of the test vectors file, relative to the package directory
+const VectorsFilename = "testdata/skyencoder-vectors.json"
+
+const (
+	// vectorsPerType is the number of test vectors generated for each encoder type.
+	// The first vector is the zero value of the type, the others are random.
+	vectorsPerType = 4
+	// vectorsMaxLen is the maximum length of the random strings and slices of the test vectors
+	vectorsMaxLen = 3
+)
+
+// Vectors generates test vectors of the encoder types of a package, for verifying
+// other implementations of the encoding. The vectors file is a JSON object:
+//
+//	{
+//	    "package": "github.com/skycoin/skycoin/src/coin",
+//	    "vectors": [
+//	        {
+//	            "name": "Transaction",
+//	            "type": "github.com/skycoin/skycoin/src/coin.Transaction",
+//	            "value": {"Length": 0, "Type": 0, ...},
+//	            "encoded": "00000000..."
+//	        }
+//	    ]
+//	}
+//
+// The value of a vector is represented in JSON as:
+//   - bool: a boolean
+//   - 8, 16 and 32 bit integers: a number
+//   - 64 bit integers: a decimal string, to preserve precision in languages with float64 numbers
+//   - byte arrays and []byte: a hex string
+//   - strings: a string
+//   - other arrays and slices: an array
+//   - structs: an object of the encoded fields, in order
+//
+// The encoding of a vector is a hex string.
+type Vectors struct{}
+
+// Name returns the name of the generator
+func (Vectors) Name() string {
+	return "vectors"
+}
+
+// Generate generates the test vectors file of a package
+func (Vectors) Generate(p *Package) ([]File, error) {
+	types, err := p.Encoders()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	vf := vectorsFile{
+		Package: p.Path,
+		Vectors: make([]vectorJSON, 0, len(types)*vectorsPerType),
+	}
+
+	for _, t := range types {
+		h := fnv.New64a()
+		h.Write([]byte(t.QualifiedName())) // nolint: errcheck
+		rand := mathrand.New(mathrand.NewSource(int64(h.Sum64())))
+
+		for i := 0; i < vectorsPerType; i++ {
+			var v interface{}
+			if i == 0 {
+				v = zeroValue(t)
+			} else {
+				v = randomValue(t, rand)
+			}
+
+			data, err := EncodeValue(t, v)
+			if err != nil {
+				return nil, fmt.Errorf("encoder %s: %v", t.QualifiedName(), err)
+			}
+
+			vf.Vectors = append(vf.Vectors, vectorJSON{
+				Name:    encoderName(t),
+				Type:    t.QualifiedName(),
+				Value:   v,
+				Encoded: hex.EncodeToString(data),
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(vf, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	return []File{
+		{
+			Path: VectorsFilename,
+			Data: append(data, '\n'),
+		},
+	}, nil
+}
+
+type vectorsFile struct {
+	Package string       `json:"package"`
+	Vectors []vectorJSON `json:"vectors"`
+}
+
+type vectorJSON struct {
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Value   interface{} `json:"value"`
+	Encoded string      `json:"encoded"`
+}
+
+// Vector is a test vector
+type Vector struct {
+	// Name is the name of the encoder, e.g. "SignedBlock"
+	Name string
+	// Type is the type qualified with its import path
+	Type string
+	// Value is the JSON value that is encoded
+	Value json.RawMessage
+	// Encoded is the encoding of the value
+	Encoded []byte
+}
+
+// ReadVectors reads the test vectors of an encoder from a test vectors file.
+// If name is empty, all test vectors are returned.
+func ReadVectors(filename, name string) ([]Vector, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var vf struct {
+		Package string `json:"package"`
+		Vectors []struct {
+			Name    string          `json:"name"`
+			Type    string          `json:"type"`
+			Value   json.RawMessage `json:"value"`
+			Encoded string          `json:"encoded"`
+		} `json:"vectors"`
+	}
+	if err := json.Unmarshal(data, &vf); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+
+	var vectors []Vector
+	for i, v := range vf.Vectors {
+		if name != "" && v.Name != name {
+			continue
+		}
+
+		encoded, err := hex.DecodeString(v.Encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%s: vector %d: invalid encoded value: %v", filename, i, err)
+		}
+
+		vectors = append(vectors, Vector{
+			Name:    v.Name,
+			Type:    v.Type,
+			Value:   v.Value,
+			Encoded: encoded,
+		})
+	}
+
+	return vectors, nil
+}
+
+// member is a field of an object
+type member struct {
+	name  string
+	value interface{}
+}
+
+// object is a JSON object which preserves the order of its members
+type object []member
+
+// MarshalJSON marshals the object with its members in order
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(m.name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// zeroValue returns the zero value of a type, in the JSON form of the test vectors
+func zeroValue(t *Type) interface{} {
+	switch t.Kind {
+	case Bool:
+		return false
+	case Uint64, Int64:
+		return "0"
+	case String:
+		return ""
+	case Array:
+		if t.IsBytes() {
+			return hex.EncodeToString(make([]byte, t.Len))
+		}
+		a := make([]interface{}, t.Len)
+		for i := range a {
+			a[i] = zeroValue(t.Elem)
+		}
+		return a
+	case Slice:
+		if t.IsBytes() {
+			return ""
+		}
+		return []interface{}{}
+	case Struct:
+		o := make(object, len(t.Fields))
+		for i, f := range t.Fields {
+			o[i] = member{f.Name, zeroValue(f.Type)}
+		}
+		return o
+	default:
+		return int64(0)
+	}
+}
+
+// randomValue returns a random value of a type, in the JSON form of the test vectors
+func randomValue(t *Type, rand *mathrand.Rand) interface{} {
+	return randomValueMaxLen(t, rand, 0)
+}
+
+func randomValueMaxLen(t *Type, rand *mathrand.Rand, maxLen int) interface{} {
+	length := func() int {
+		n := vectorsMaxLen
+		if maxLen > 0 && maxLen < n {
+			n = maxLen
+		}
+		return rand.Intn(n + 1)
+	}
+
+	switch t.Kind {
+	case Bool:
+		return rand.Intn(2) == 1
+	case Uint8, Uint16, Uint32:
+		return int64(rand.Uint64() >> (64 - 8*t.Kind.size()))
+	case Int8, Int16, Int32:
+		return int64(rand.Uint64()) >> (64 - 8*t.Kind.size())
+	case Uint64:
+		return strconv.FormatUint(rand.Uint64(), 10)
+	case Int64:
+		return strconv.FormatInt(int64(rand.Uint64()), 10)
+	case String:
+		b := make([]byte, length())
+		for i := range b {
+			b[i] = byte('a' + rand.Intn(26))
+		}
+		return string(b)
+	case Array, Slice:
+		n := t.Len
+		if t.Kind == Slice {
+			n = length()
+		}
+
+		if t.IsBytes() {
+			b := make([]byte, n)
+			rand.Read(b) // nolint: errcheck
+			return hex.EncodeToString(b)
+		}
+
+		a := make([]interface{}, n)
+		for i := range a {
+			a[i] = randomValueMaxLen(t.Elem, rand, 0)
+		}
+		return a
+	case Struct:
+		o := make(object, len(t.Fields))
+		for i, f := range t.Fields {
+			o[i] = member{f.Name, randomValueMaxLen(f.Type, rand, f.MaxLen)}
+		}
+		return o
+	default:
+		panic(fmt.Sprintf("invalid kind %s", t.Kind))
+	}
+}
+
+// EncodeValue encodes a value in the JSON form of the test vectors.
+// The value can be decoded from JSON with json.Decoder.UseNumber.
+// This is a reference implementation of the encoding, independent from package encoder.
+func EncodeValue(t *Type, v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeValue(&buf, t, v, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeValue(buf *bytes.Buffer, t *Type, v interface{}, maxLen int) error {
+	switch t.Kind {
+	case Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expected a bool, got %T", v)
+		}
+		if b {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+		return nil
+
+	case String:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %T", v)
+		}
+		return encodeBytes(buf, []byte(s), maxLen)
+
+	case Array, Slice:
+		if t.IsBytes() {
+			s, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("expected a hex string, got %T", v)
+			}
+			b, err := hex.DecodeString(s)
+			if err != nil {
+				return err
+			}
+			if t.Kind == Slice {
+				return encodeBytes(buf, b, maxLen)
+			}
+			if len(b) != t.Len {
+				return fmt.Errorf("expected %d bytes, got %d", t.Len, len(b))
+			}
+			buf.Write(b)
+			return nil
+		}
+
+		a, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected an array, got %T", v)
+		}
+		if t.Kind == Slice {
+			if err := encodeLength(buf, len(a), maxLen); err != nil {
+				return err
+			}
+		} else if len(a) != t.Len {
+			return fmt.Errorf("expected %d elements, got %d", t.Len, len(a))
+		}
+
+		for i, x := range a {
+			if err := encodeValue(buf, t.Elem, x, 0); err != nil {
+				return fmt.Errorf("[%d]: %v", i, err)
+			}
+		}
+		return nil
+
+	case Struct:
+		values, err := fieldValues(t, v)
+		if err != nil {
+			return err
+		}
+
+		for i, f := range t.Fields {
+			if f.OmitEmpty && isEmptyValue(values[i]) {
+				continue
+			}
+			if err := encodeValue(buf, f.Type, values[i], f.MaxLen); err != nil {
+				return fmt.Errorf("%s: %v", f.Name, err)
+			}
+		}
+		return nil
+
+	default:
+		return encodeInteger(buf, t.Kind, v)
+	}
+}
+
+// fieldValues returns the values of the fields of a struct, given as an object or a map
+func fieldValues(t *Type, v interface{}) ([]interface{}, error) {
+	values := make([]interface{}, len(t.Fields))
+
+	switch v := v.(type) {
+	case object:
+		if len(v) != len(t.Fields) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(t.Fields), len(v))
+		}
+		for i, m := range v {
+			if m.name != t.Fields[i].Name {
+				return nil, fmt.Errorf("expected field %s, got %s", t.Fields[i].Name, m.name)
+			}
+			values[i] = m.value
+		}
+
+	case map[string]interface{}:
+		if len(v) != len(t.Fields) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(t.Fields), len(v))
+		}
+		for i, f := range t.Fields {
+			x, ok := v[f.Name]
+			if !ok {
+				return nil, fmt.Errorf("missing field %s", f.Name)
+			}
+			values[i] = x
+		}
+
+	default:
+		return nil, fmt.Errorf("expected an object, got %T", v)
+	}
+
+	return values, nil
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func encodeLength(buf *bytes.Buffer, n, maxLen int) error {
+	if maxLen > 0 && n > maxLen {
+		return errors.New("maxlen exceeded")
+	}
+	if uint64(n) > math.MaxUint32 {
+		return errors.New("length exceeds math.MaxUint32")
+	}
+
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(n))
+	buf.Write(b[:])
+	return nil
+}
+
+func encodeBytes(buf *bytes.Buffer, b []byte, maxLen int) error {
+	if err := encodeLength(buf, len(b), maxLen); err != nil {
+		return err
+	}
+	buf.Write(b)
+	return nil
+}
+
+// encodeInteger encodes an integer, which is a number if it has less than 64 bits, and a decimal string otherwise
+func encodeInteger(buf *bytes.Buffer, k Kind, v interface{}) error {
+	wide := k == Uint64 || k == Int64
+
+	var s string
+	switch v := v.(type) {
+	case string:
+		if !wide {
+			return errors.New("expected a number, got a string")
+		}
+		s = v
+	case json.Number, int64, int:
+		if wide {
+			return fmt.Errorf("expected a decimal string, got %T", v)
+		}
+		s = fmt.Sprint(v)
+	default:
+		return fmt.Errorf("expected an integer, got %T", v)
+	}
+
+	size := k.size()
+	var x uint64
+	switch k {
+	case Uint8, Uint16, Uint32, Uint64:
+		u, err := strconv.ParseUint(s, 10, int(8*size))
+		if err != nil {
+			return err
+		}
+		x = u
+	default:
+		i, err := strconv.ParseInt(s, 10, int(8*size))
+		if err != nil {
+			return err
+		}
+		x = uint64(i)
+	}
+
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	buf.Write(b[:size])
+	return nil
+}
@@ -0,0 +1,35 @@
+package cipher
+
+import "strconv"
+
+/*
+Messages are signed to prove the ownership of an address without moving coins.
+
+The signed hash of a message is the SHA256 of MessagePrefix, the decimal length of the
+message in bytes, a newline and the message. The prefix makes a message signature unusable
+as the signature of a transaction input or of any other hash, and the length makes the
+hashed data unambiguous.
+*/
+
+// MessagePrefix is prepended to a message before it is hashed for signing
+const MessagePrefix = "Skycoin Signed Message:\n"
+
+// HashMessage returns the hash that is signed to sign a message
+func HashMessage(msg []byte) SHA256 {
+	b := make([]byte, 0, len(MessagePrefix)+20+1+len(msg))
+	b = append(b, MessagePrefix...)
+	b = strconv.AppendInt(b, int64(len(msg)), 10)
+	b = append(b, '\n')
+	b = append(b, msg...)
+	return SumSHA256(b)
+}
+
+// SignMessage signs a message with a secret key
+func SignMessage(msg []byte, sec SecKey) (Sig, error) {
+	return SignHash(HashMessage(msg), sec)
+}
+
+// VerifyAddressSignedMessage checks that the owner of the address signed the message
+func VerifyAddressSignedMessage(address Address, sig Sig, msg []byte) error {
+	return VerifyAddressSignedHash(address, sig, HashMessage(msg))
+}
@@ -0,0 +1,44 @@
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashMessage(t *testing.T) {
+	require.Equal(t, MustSHA256FromHex("94ca527d107990fe2e084f8911cca2e53227fb63ce50c00f686f90a787a481af"), HashMessage([]byte("hello")))
+	require.Equal(t, MustSHA256FromHex("91769aa927ec9e641d557fe6c0efc330933f0c5e80e89af7e1f3aad8d54bfff8"), HashMessage(nil))
+
+	// The message is not signed as a plain hash
+	require.NotEqual(t, SumSHA256([]byte("hello")), HashMessage([]byte("hello")))
+}
+
+func TestSignMessage(t *testing.T) {
+	p, s := GenerateKeyPair()
+	addr := AddressFromPubKey(p)
+	msg := []byte("I own this address")
+
+	sig, err := SignMessage(msg, s)
+	require.NoError(t, err)
+	require.NoError(t, VerifyAddressSignedMessage(addr, sig, msg))
+
+	// The signature is the signature of the message hash
+	require.NoError(t, VerifyAddressSignedHash(addr, sig, HashMessage(msg)))
+
+	// Empty messages can be signed
+	sig2, err := SignMessage(nil, s)
+	require.NoError(t, err)
+	require.NoError(t, VerifyAddressSignedMessage(addr, sig2, []byte{}))
+
+	// Another message
+	require.Equal(t, ErrInvalidAddressForSig, VerifyAddressSignedMessage(addr, sig, []byte("I own this address.")))
+
+	// Another address
+	p2, _ := GenerateKeyPair()
+	require.Equal(t, ErrInvalidAddressForSig, VerifyAddressSignedMessage(AddressFromPubKey(p2), sig, msg))
+
+	// Invalid secret key
+	_, err = SignMessage(msg, SecKey{})
+	require.Equal(t, ErrInvalidSecKey, err)
+}
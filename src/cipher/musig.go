@@ -0,0 +1,222 @@
+package cipher
+
+import (
+	"encoding/hex"
+	"errors"
+	"log"
+
+	secp256k1 "github.com/skycoin/skycoin/src/cipher/secp256k1-go"
+)
+
+/*
+MuSig key aggregation and multi-signatures
+
+The public keys of n signers are aggregated into a single public key with MuSigAggregatePubKeys,
+following BIP327. Coins sent to the address of the aggregated public key can only be spent with a
+Schnorr signature made by all the signers together, in two rounds:
+
+1. Each signer generates a nonce with GenerateMuSigNonce, keeps the MuSigSecNonce and shares the MuSigPubNonce.
+   The public nonces are aggregated with MuSigAggregateNonces.
+2. Each signer signs the hash with MuSigPartialSign and shares the MuSigPartialSig.
+   The partial signatures can be checked with MuSigPartialVerify,
+   and are aggregated into a Schnorr signature with MuSigAggregatePartialSigs.
+
+The signature is verified with VerifyAddressSchnorrSignedHash, like the signature of a single key.
+
+A MuSigSecNonce must never be used for more than one signature, otherwise the secret key
+can be computed from the partial signatures. MuSigPartialSign zeroes it after use.
+*/
+
+var (
+	// ErrMuSigNonceUsed the secret nonce was already used for a partial signature
+	ErrMuSigNonceUsed = errors.New("MuSig secret nonce was already used")
+	// ErrInvalidLengthMuSigPubNonce Invalid MuSig public nonce length
+	ErrInvalidLengthMuSigPubNonce = errors.New("Invalid MuSig public nonce length")
+	// ErrInvalidLengthMuSigPartialSig Invalid MuSig partial signature length
+	ErrInvalidLengthMuSigPartialSig = errors.New("Invalid MuSig partial signature length")
+)
+
+// MuSigSecNonce is the secret nonce of a MuSig signer
+type MuSigSecNonce [64]byte
+
+// Null returns true if the secret nonce is zeroed
+func (n MuSigSecNonce) Null() bool {
+	return n == MuSigSecNonce{}
+}
+
+// MuSigPubNonce is the public nonce of a MuSig signer, or the aggregated nonce of all the signers
+type MuSigPubNonce [66]byte
+
+// NewMuSigPubNonce converts []byte to a MuSigPubNonce
+func NewMuSigPubNonce(b []byte) (MuSigPubNonce, error) {
+	var n MuSigPubNonce
+	if len(b) != len(n) {
+		return MuSigPubNonce{}, ErrInvalidLengthMuSigPubNonce
+	}
+	copy(n[:], b)
+	return n, nil
+}
+
+// MuSigPubNonceFromHex converts a hex string to a MuSigPubNonce
+func MuSigPubNonceFromHex(s string) (MuSigPubNonce, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return MuSigPubNonce{}, err
+	}
+	return NewMuSigPubNonce(b)
+}
+
+// Hex converts the public nonce to a hex string
+func (n MuSigPubNonce) Hex() string {
+	return hex.EncodeToString(n[:])
+}
+
+// MuSigPartialSig is the partial signature of a MuSig signer
+type MuSigPartialSig [32]byte
+
+// NewMuSigPartialSig converts []byte to a MuSigPartialSig
+func NewMuSigPartialSig(b []byte) (MuSigPartialSig, error) {
+	var s MuSigPartialSig
+	if len(b) != len(s) {
+		return MuSigPartialSig{}, ErrInvalidLengthMuSigPartialSig
+	}
+	copy(s[:], b)
+	return s, nil
+}
+
+// MuSigPartialSigFromHex converts a hex string to a MuSigPartialSig
+func MuSigPartialSigFromHex(s string) (MuSigPartialSig, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return MuSigPartialSig{}, err
+	}
+	return NewMuSigPartialSig(b)
+}
+
+// Hex converts the partial signature to a hex string
+func (s MuSigPartialSig) Hex() string {
+	return hex.EncodeToString(s[:])
+}
+
+func muSigPubKeysBytes(pubkeys []PubKey) [][]byte {
+	b := make([][]byte, len(pubkeys))
+	for i := range pubkeys {
+		b[i] = pubkeys[i][:]
+	}
+	return b
+}
+
+// MuSigAggregatePubKeys aggregates the public keys of the signers.
+// The order of the public keys changes the aggregated public key,
+// and all the signers must use the same order.
+func MuSigAggregatePubKeys(pubkeys []PubKey) (PubKey, error) {
+	q, err := secp256k1.MuSigKeyAgg(muSigPubKeysBytes(pubkeys))
+	if err != nil {
+		return PubKey{}, err
+	}
+	return NewPubKey(q)
+}
+
+// MustMuSigAggregatePubKeys aggregates the public keys of the signers, panics on error
+func MustMuSigAggregatePubKeys(pubkeys []PubKey) PubKey {
+	q, err := MuSigAggregatePubKeys(pubkeys)
+	if err != nil {
+		log.Panic(err)
+	}
+	return q
+}
+
+// muSigKeyCommit returns the address bytes of the aggregated public key, which the signature commits to
+func muSigKeyCommit(pubkeys []PubKey) ([]byte, error) {
+	q, err := MuSigAggregatePubKeys(pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	return AddressFromPubKey(q).Bytes(), nil
+}
+
+// GenerateMuSigNonce generates a random secret nonce and its public nonce
+func GenerateMuSigNonce() (MuSigSecNonce, MuSigPubNonce) {
+	sec, pub := secp256k1.MuSigNonceGen(RandByte(32))
+
+	var secNonce MuSigSecNonce
+	var pubNonce MuSigPubNonce
+	copy(secNonce[:], sec)
+	copy(pubNonce[:], pub)
+	return secNonce, pubNonce
+}
+
+// MuSigAggregateNonces aggregates the public nonces of all the signers
+func MuSigAggregateNonces(nonces []MuSigPubNonce) (MuSigPubNonce, error) {
+	b := make([][]byte, len(nonces))
+	for i := range nonces {
+		b[i] = nonces[i][:]
+	}
+
+	aggNonce, err := secp256k1.MuSigNonceAgg(b)
+	if err != nil {
+		return MuSigPubNonce{}, err
+	}
+
+	return NewMuSigPubNonce(aggNonce)
+}
+
+// MuSigPartialSign makes the partial signature of the hash by the signer of sec.
+// pubkeys are the public keys of all the signers, in the order of MuSigAggregatePubKeys.
+// The secret nonce is zeroed, and can't be used again.
+func MuSigPartialSign(secNonce *MuSigSecNonce, sec SecKey, pubkeys []PubKey, aggNonce MuSigPubNonce, hash SHA256) (MuSigPartialSig, error) {
+	if secNonce.Null() {
+		return MuSigPartialSig{}, ErrMuSigNonceUsed
+	}
+
+	// Null hashes can't be signed
+	if hash.Null() {
+		return MuSigPartialSig{}, ErrNullSignHash
+	}
+
+	keyCommit, err := muSigKeyCommit(pubkeys)
+	if err != nil {
+		return MuSigPartialSig{}, err
+	}
+
+	// secp256k1.MuSigPartialSign zeroes the secret nonce
+	psig, err := secp256k1.MuSigPartialSign(secNonce[:], sec[:], muSigPubKeysBytes(pubkeys), aggNonce[:], hash[:], keyCommit)
+	if err != nil {
+		return MuSigPartialSig{}, err
+	}
+
+	return NewMuSigPartialSig(psig)
+}
+
+// MuSigPartialVerify checks the partial signature of the signer of pubkey, made with the public nonce pubNonce
+func MuSigPartialVerify(psig MuSigPartialSig, pubNonce MuSigPubNonce, pubkey PubKey, pubkeys []PubKey, aggNonce MuSigPubNonce, hash SHA256) error {
+	keyCommit, err := muSigKeyCommit(pubkeys)
+	if err != nil {
+		return err
+	}
+
+	return secp256k1.MuSigPartialVerify(psig[:], pubNonce[:], pubkey[:], muSigPubKeysBytes(pubkeys), aggNonce[:], hash[:], keyCommit)
+}
+
+// MuSigAggregatePartialSigs aggregates the partial signatures of all the signers into a Schnorr signature
+// of the hash, for the address of the aggregated public key
+func MuSigAggregatePartialSigs(psigs []MuSigPartialSig, pubkeys []PubKey, aggNonce MuSigPubNonce, hash SHA256) (Sig, error) {
+	keyCommit, err := muSigKeyCommit(pubkeys)
+	if err != nil {
+		return Sig{}, err
+	}
+
+	b := make([][]byte, len(psigs))
+	for i := range psigs {
+		b[i] = psigs[i][:]
+	}
+
+	s, err := secp256k1.MuSigPartialSigAgg(b, muSigPubKeysBytes(pubkeys), aggNonce[:], hash[:], keyCommit)
+	if err != nil {
+		return Sig{}, err
+	}
+
+	var sig Sig
+	copy(sig[:], s)
+	return sig, nil
+}
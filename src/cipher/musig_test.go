@@ -0,0 +1,114 @@
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuSig(t *testing.T) {
+	for _, n := range []int{1, 2, 3} {
+		pubkeys := make([]PubKey, n)
+		seckeys := make([]SecKey, n)
+		for i := range pubkeys {
+			pubkeys[i], seckeys[i] = GenerateKeyPair()
+		}
+
+		q, err := MuSigAggregatePubKeys(pubkeys)
+		require.NoError(t, err)
+		require.Equal(t, q, MustMuSigAggregatePubKeys(pubkeys))
+		addr := AddressFromPubKey(q)
+
+		h := SumSHA256(randBytes(t, 256))
+
+		// Round 1
+		secNonces := make([]MuSigSecNonce, n)
+		pubNonces := make([]MuSigPubNonce, n)
+		for i := range secNonces {
+			secNonces[i], pubNonces[i] = GenerateMuSigNonce()
+			require.False(t, secNonces[i].Null())
+
+			nonce, err := MuSigPubNonceFromHex(pubNonces[i].Hex())
+			require.NoError(t, err)
+			require.Equal(t, pubNonces[i], nonce)
+		}
+
+		aggNonce, err := MuSigAggregateNonces(pubNonces)
+		require.NoError(t, err)
+
+		// Round 2
+		psigs := make([]MuSigPartialSig, n)
+		for i := range psigs {
+			psigs[i], err = MuSigPartialSign(&secNonces[i], seckeys[i], pubkeys, aggNonce, h)
+			require.NoError(t, err)
+			require.True(t, secNonces[i].Null())
+
+			psig, err := MuSigPartialSigFromHex(psigs[i].Hex())
+			require.NoError(t, err)
+			require.Equal(t, psigs[i], psig)
+
+			require.NoError(t, MuSigPartialVerify(psigs[i], pubNonces[i], pubkeys[i], pubkeys, aggNonce, h))
+		}
+
+		// A secret nonce can't be used twice
+		_, err = MuSigPartialSign(&secNonces[0], seckeys[0], pubkeys, aggNonce, h)
+		require.Equal(t, ErrMuSigNonceUsed, err)
+
+		sig, err := MuSigAggregatePartialSigs(psigs, pubkeys, aggNonce, h)
+		require.NoError(t, err)
+
+		require.NoError(t, VerifyAddressSchnorrSignedHash(addr, sig, h))
+
+		if n > 1 {
+			// The signature is not valid for the address of a single signer
+			require.Equal(t, ErrInvalidAddressForSig, VerifyAddressSchnorrSignedHash(AddressFromPubKey(pubkeys[0]), sig, h))
+
+			// All the partial signatures are required
+			sig, err := MuSigAggregatePartialSigs(psigs[:n-1], pubkeys, aggNonce, h)
+			require.NoError(t, err)
+			require.Equal(t, ErrInvalidAddressForSig, VerifyAddressSchnorrSignedHash(addr, sig, h))
+
+			// The order of the public keys matters
+			reversed := make([]PubKey, n)
+			for i := range pubkeys {
+				reversed[n-1-i] = pubkeys[i]
+			}
+			q2, err := MuSigAggregatePubKeys(reversed)
+			require.NoError(t, err)
+			require.NotEqual(t, q, q2)
+		}
+	}
+}
+
+func TestMuSigErrors(t *testing.T) {
+	p, s := GenerateKeyPair()
+	p2, _ := GenerateKeyPair()
+	h := SumSHA256(randBytes(t, 256))
+
+	_, err := MuSigAggregatePubKeys(nil)
+	require.Error(t, err)
+
+	_, err = MuSigAggregatePubKeys([]PubKey{p, {}})
+	require.Error(t, err)
+
+	_, err = NewMuSigPubNonce(randBytes(t, 65))
+	require.Equal(t, ErrInvalidLengthMuSigPubNonce, err)
+
+	_, err = NewMuSigPartialSig(randBytes(t, 33))
+	require.Equal(t, ErrInvalidLengthMuSigPartialSig, err)
+
+	_, err = MuSigAggregateNonces([]MuSigPubNonce{{}})
+	require.Error(t, err)
+
+	secNonce, pubNonce := GenerateMuSigNonce()
+	aggNonce, err := MuSigAggregateNonces([]MuSigPubNonce{pubNonce})
+	require.NoError(t, err)
+
+	_, err = MuSigPartialSign(&secNonce, s, []PubKey{p2}, aggNonce, SHA256{})
+	require.Equal(t, ErrNullSignHash, err)
+
+	// The signer is not one of the signers
+	_, err = MuSigPartialSign(&secNonce, s, []PubKey{p2}, aggNonce, h)
+	require.Error(t, err)
+	require.True(t, secNonce.Null())
+}
@@ -0,0 +1,114 @@
+package cipher
+
+import (
+	"errors"
+	"log"
+
+	secp256k1 "github.com/skycoin/skycoin/src/cipher/secp256k1-go"
+)
+
+/*
+Schnorr signatures
+
+Schnorr signatures are BIP340-style signatures over secp256k1, with the tagged hashes
+and the even y coordinate nonce of BIP340. They differ from BIP340 in that the challenge
+hash commits to the address of the public key in place of the x-only public key, so that the
+public key can be recovered from the signature and the address, like the public key of an
+ECDSA signature. This lets a Schnorr signature spend an output owned by an existing address,
+and fit in a Sig.
+
+The 64 byte R.x||s signature is stored in the first 64 bytes of a Sig, and the last byte is zero.
+*/
+
+var (
+	// ErrInvalidSchnorrSig Invalid Schnorr signature
+	ErrInvalidSchnorrSig = errors.New("Invalid Schnorr signature")
+)
+
+// SignHashSchnorr signs a hash with a Schnorr signature, which commits to the address of sec
+func SignHashSchnorr(hash SHA256, sec SecKey) (Sig, error) {
+	pubkey, err := PubKeyFromSecKey(sec)
+	if err != nil {
+		return Sig{}, err
+	}
+
+	// Null hashes can't be signed
+	if hash.Null() {
+		return Sig{}, ErrNullSignHash
+	}
+
+	address := AddressFromPubKey(pubkey)
+
+	s := secp256k1.SchnorrSignCommit(sec[:], hash[:], RandByte(32), address.Bytes())
+	if s == nil {
+		return Sig{}, ErrInvalidSecKey
+	}
+
+	var sig Sig
+	copy(sig[:], s)
+
+	if DebugLevel2 || DebugLevel1 {
+		// Guard against coin loss;
+		// if the generated signature is somehow invalid, coins would be lost,
+		// make sure that the signature is valid
+		if VerifyAddressSchnorrSignedHash(address, sig, hash) != nil {
+			log.Panic("SignHashSchnorr error: VerifyAddressSchnorrSignedHash failed for signature")
+		}
+	}
+
+	return sig, nil
+}
+
+// MustSignHashSchnorr signs a hash with a Schnorr signature, panics on error
+func MustSignHashSchnorr(hash SHA256, sec SecKey) Sig {
+	sig, err := SignHashSchnorr(hash, sec)
+	if err != nil {
+		log.Panic(err)
+	}
+	return sig
+}
+
+// VerifySchnorrSignatureValidity checks that a Schnorr signature is well formed.
+// It does not check that the signature signed the hash.
+// The address is required to verify that the signature signed the hash.
+func VerifySchnorrSignatureValidity(sig Sig) error {
+	if sig[64] != 0 {
+		return ErrInvalidSchnorrSig
+	}
+
+	if !secp256k1.SchnorrSigIsValid(sig[:64]) {
+		return ErrInvalidSchnorrSig
+	}
+
+	return nil
+}
+
+// PubKeyFromSchnorrSig recovers the public key of a Schnorr signature which commits to the address.
+// The recovered public key is only the signer's if its address is the address.
+func PubKeyFromSchnorrSig(sig Sig, hash SHA256, address Address) (PubKey, error) {
+	if err := VerifySchnorrSignatureValidity(sig); err != nil {
+		return PubKey{}, err
+	}
+
+	rawPubKey := secp256k1.SchnorrRecoverCommit(hash[:], sig[:64], address.Bytes())
+	if rawPubKey == nil {
+		return PubKey{}, ErrInvalidSigPubKeyRecovery
+	}
+
+	return NewPubKey(rawPubKey)
+}
+
+// VerifyAddressSchnorrSignedHash checks that the Schnorr signature signed the hash
+// with the secret key of the address
+func VerifyAddressSchnorrSignedHash(address Address, sig Sig, hash SHA256) error {
+	pubkey, err := PubKeyFromSchnorrSig(sig, hash, address)
+	if err != nil {
+		return err
+	}
+
+	if address != AddressFromPubKey(pubkey) {
+		return ErrInvalidAddressForSig
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignHashSchnorr(t *testing.T) {
+	p, s := GenerateKeyPair()
+	addr := AddressFromPubKey(p)
+	h := SumSHA256(randBytes(t, 256))
+
+	sig, err := SignHashSchnorr(h, s)
+	require.NoError(t, err)
+	require.NotEqual(t, sig, Sig{})
+	require.Equal(t, byte(0), sig[64])
+
+	require.NoError(t, VerifySchnorrSignatureValidity(sig))
+	require.NoError(t, VerifyAddressSchnorrSignedHash(addr, sig, h))
+
+	pubkey, err := PubKeyFromSchnorrSig(sig, h, addr)
+	require.NoError(t, err)
+	require.Equal(t, p, pubkey)
+
+	// The signature is randomized
+	sig2, err := SignHashSchnorr(h, s)
+	require.NoError(t, err)
+	require.NotEqual(t, sig, sig2)
+	require.NoError(t, VerifyAddressSchnorrSignedHash(addr, sig2, h))
+
+	// Null hash
+	_, err = SignHashSchnorr(SHA256{}, s)
+	require.Equal(t, ErrNullSignHash, err)
+
+	// Invalid secret key
+	_, err = SignHashSchnorr(h, SecKey{})
+	require.Equal(t, ErrPubKeyFromNullSecKey, err)
+
+	require.Panics(t, func() {
+		MustSignHashSchnorr(h, SecKey{})
+	})
+}
+
+func TestVerifyAddressSchnorrSignedHash(t *testing.T) {
+	p, s := GenerateKeyPair()
+	addr := AddressFromPubKey(p)
+	h := SumSHA256(randBytes(t, 256))
+	sig := MustSignHashSchnorr(h, s)
+
+	require.NoError(t, VerifyAddressSchnorrSignedHash(addr, sig, h))
+
+	// Different hash
+	h2 := SumSHA256(randBytes(t, 256))
+	require.Equal(t, ErrInvalidAddressForSig, VerifyAddressSchnorrSignedHash(addr, sig, h2))
+
+	// Different address
+	p2, _ := GenerateKeyPair()
+	require.Equal(t, ErrInvalidAddressForSig, VerifyAddressSchnorrSignedHash(AddressFromPubKey(p2), sig, h))
+
+	// Modified s
+	badSig := sig
+	badSig[40] ^= 1
+	require.Equal(t, ErrInvalidAddressForSig, VerifyAddressSchnorrSignedHash(addr, badSig, h))
+
+	// The last byte must be zero
+	badSig = sig
+	badSig[64] = 1
+	require.Equal(t, ErrInvalidSchnorrSig, VerifyAddressSchnorrSignedHash(addr, badSig, h))
+	require.Equal(t, ErrInvalidSchnorrSig, VerifySchnorrSignatureValidity(badSig))
+
+	// R.x is not on the curve
+	badSig = sig
+	for i := 0; i < 32; i++ {
+		badSig[i] = 0xFF
+	}
+	require.Equal(t, ErrInvalidSchnorrSig, VerifyAddressSchnorrSignedHash(addr, badSig, h))
+
+	// ECDSA signatures are not valid Schnorr signatures
+	ecdsaSig := MustSignHash(h, s)
+	require.Error(t, VerifyAddressSchnorrSignedHash(addr, ecdsaSig, h))
+
+	// Schnorr signatures are not valid ECDSA signatures
+	require.Error(t, VerifyAddressSignedHash(addr, sig, h))
+}
@@ -0,0 +1,85 @@
+//go:build secp256k1ct
+// +build secp256k1ct
+
+package secp256k1
+
+import (
+	"log"
+
+	secp "github.com/skycoin/skycoin/src/cipher/secp256k1-go/secp256k1-ct"
+	secp256k1go "github.com/skycoin/skycoin/src/cipher/secp256k1-go/secp256k1-go2"
+)
+
+// The constant-time secp256k1-ct backend is used when building with the secp256k1ct tag
+
+func seckeyIsValid(seckey []byte) int {
+	return secp.SeckeyIsValid(seckey)
+}
+
+func pubkeyIsValid(pubkey []byte) int {
+	return secp.PubkeyIsValid(pubkey)
+}
+
+func generatePublicKey(seckey []byte) []byte {
+	return secp.GeneratePublicKey(seckey)
+}
+
+func uncompressPubkey(pubkey []byte) []byte {
+	return secp.UncompressPubkey(pubkey)
+}
+
+func sign(seckey, msg, nonce []byte) ([]byte, int, int) {
+	return secp.Sign(seckey, msg, nonce)
+}
+
+func recoverPublicKey(sig, msg []byte, recid int) ([]byte, int) {
+	return secp.RecoverPublicKey(sig, msg, recid)
+}
+
+func multiply(pubkey, k []byte) []byte {
+	return secp.Multiply(pubkey, k)
+}
+
+func schnorrSignCommit(seckey, msg, auxRand, keyCommit []byte) []byte {
+	return secp.SchnorrSignCommit(seckey, msg, auxRand, keyCommit)
+}
+
+func muSigNonceGen(rand []byte) ([]byte, []byte) {
+	return secp.MuSigNonceGen(rand)
+}
+
+func muSigPartialSign(secnonce, seckey []byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) ([]byte, error) {
+	if len(secnonce) != 64 {
+		return nil, secp256k1go.ErrMuSigInvalidNonce
+	}
+
+	// Zero the secret nonce before anything else, so that it can't be reused even if signing fails
+	k := append([]byte{}, secnonce...)
+	for i := range secnonce {
+		secnonce[i] = 0
+	}
+	defer func() {
+		for i := range k {
+			k[i] = 0
+		}
+	}()
+
+	if secp.SeckeyIsValid(k[:32]) != 1 || secp.SeckeyIsValid(k[32:]) != 1 {
+		return nil, secp256k1go.ErrMuSigInvalidNonce
+	}
+
+	if len(seckey) != 32 {
+		log.Panic("secret key length must be 32 bytes")
+	}
+	if secp.SeckeyIsValid(seckey) != 1 {
+		return nil, secp256k1go.ErrMuSigInvalidSeckey
+	}
+
+	// Only the scalar arithmetic with the secret nonce and key is done by the constant-time backend
+	b, ea, negNonce, err := secp256k1go.MuSigSignCoefs(secp.GeneratePublicKey(seckey), pubkeys, aggnonce, msg, keyCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	return secp.MuSigPartialSign(k, seckey, b, ea, negNonce), nil
+}
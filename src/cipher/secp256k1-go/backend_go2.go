@@ -0,0 +1,68 @@
+//go:build !secp256k1ct
+// +build !secp256k1ct
+
+package secp256k1
+
+import (
+	"log"
+
+	secp "github.com/skycoin/skycoin/src/cipher/secp256k1-go/secp256k1-go2"
+)
+
+// The secp256k1-go2 backend is used by default.
+// Build with the secp256k1ct tag to use the constant-time secp256k1-ct backend instead.
+
+func seckeyIsValid(seckey []byte) int {
+	return secp.SeckeyIsValid(seckey)
+}
+
+func pubkeyIsValid(pubkey []byte) int {
+	return secp.PubkeyIsValid(pubkey)
+}
+
+func generatePublicKey(seckey []byte) []byte {
+	return secp.GeneratePublicKey(seckey)
+}
+
+func uncompressPubkey(pubkey []byte) []byte {
+	var pubXY secp.XY
+	if err := pubXY.ParsePubkey(pubkey); err != nil {
+		log.Panicf("ERROR: impossible, pubkey parse fail: %v", err)
+	}
+	return pubXY.BytesUncompressed()
+}
+
+func sign(seckey, msg, nonce []byte) ([]byte, int, int) {
+	var cSig secp.Signature
+	var seckey1, msg1, nonce1 secp.Number
+	var recid int
+
+	seckey1.SetBytes(seckey)
+	msg1.SetBytes(msg)
+	nonce1.SetBytes(nonce)
+
+	if ret := cSig.Sign(&seckey1, &msg1, &nonce1, &recid); ret != 1 {
+		return nil, 0, ret
+	}
+	return cSig.Bytes(), recid, 1
+}
+
+func recoverPublicKey(sig, msg []byte, recid int) ([]byte, int) {
+	return secp.RecoverPublicKey(sig, msg, recid)
+}
+
+func multiply(pubkey, k []byte) []byte {
+	return secp.Multiply(pubkey, k)
+}
+
+func schnorrSignCommit(seckey, msg, auxRand, keyCommit []byte) []byte {
+	return secp.SchnorrSignCommit(seckey, msg, auxRand, keyCommit)
+}
+
+func muSigNonceGen(rand []byte) ([]byte, []byte) {
+	return secp.MuSigNonceGen(rand)
+}
+
+func muSigPartialSign(secnonce, seckey []byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) ([]byte, error) {
+	return secp.MuSigPartialSign(secnonce, seckey, pubkeys, aggnonce, msg, keyCommit)
+}
@@ -0,0 +1,57 @@
+package secp256k1
+
+import (
+	secp256k1go "github.com/skycoin/skycoin/src/cipher/secp256k1-go/secp256k1-go2"
+)
+
+// Schnorr signatures and MuSig, see secp256k1-go2 for their description.
+// The functions which handle secret keys and nonces use the selected backend,
+// the others only handle public data and use secp256k1-go2 with either backend.
+
+// SchnorrSignCommit signs a 32 byte message with a Schnorr signature whose challenge hash commits to keyCommit.
+// Returns nil if the secret key is invalid.
+func SchnorrSignCommit(seckey, msg, auxRand, keyCommit []byte) []byte {
+	return schnorrSignCommit(seckey, msg, auxRand, keyCommit)
+}
+
+// SchnorrRecoverCommit recovers the public key of a signature made by SchnorrSignCommit.
+// Returns nil if the signature is invalid.
+func SchnorrRecoverCommit(msg, sig, keyCommit []byte) []byte {
+	return secp256k1go.SchnorrRecoverCommit(msg, sig, keyCommit)
+}
+
+// SchnorrSigIsValid returns true if a 64 byte Schnorr signature is well formed
+func SchnorrSigIsValid(sig []byte) bool {
+	return secp256k1go.SchnorrSigIsValid(sig)
+}
+
+// MuSigKeyAgg aggregates 33 byte compressed public keys into a 33 byte compressed public key
+func MuSigKeyAgg(pubkeys [][]byte) ([]byte, error) {
+	return secp256k1go.MuSigKeyAgg(pubkeys)
+}
+
+// MuSigNonceGen derives a 64 byte secret nonce and its 66 byte public nonce from 32 random bytes
+func MuSigNonceGen(rand []byte) ([]byte, []byte) {
+	return muSigNonceGen(rand)
+}
+
+// MuSigNonceAgg aggregates the 66 byte public nonces of the signers into a 66 byte aggregated nonce
+func MuSigNonceAgg(pubnonces [][]byte) ([]byte, error) {
+	return secp256k1go.MuSigNonceAgg(pubnonces)
+}
+
+// MuSigPartialSign makes the 32 byte partial signature of a signer, with its secret nonce and secret key.
+// The secret nonce is zeroed so that it can't be reused.
+func MuSigPartialSign(secnonce, seckey []byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) ([]byte, error) {
+	return muSigPartialSign(secnonce, seckey, pubkeys, aggnonce, msg, keyCommit)
+}
+
+// MuSigPartialVerify verifies the partial signature of the signer with the public key and the public nonce
+func MuSigPartialVerify(psig, pubnonce, pubkey []byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) error {
+	return secp256k1go.MuSigPartialVerify(psig, pubnonce, pubkey, pubkeys, aggnonce, msg, keyCommit)
+}
+
+// MuSigPartialSigAgg aggregates the partial signatures of all the signers into a 64 byte signature
+func MuSigPartialSigAgg(psigs [][]byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) ([]byte, error) {
+	return secp256k1go.MuSigPartialSigAgg(psigs, pubkeys, aggnonce, msg, keyCommit)
+}
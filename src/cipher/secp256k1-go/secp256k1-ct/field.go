@@ -0,0 +1,269 @@
+package secp256k1ct
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// fe is an element of the field of integers modulo p = 2^256 - 2^32 - 977,
+// in four little-endian 64-bit limbs. Field elements are always fully reduced, in [0, p).
+type fe [4]uint64
+
+// feC is 2^256 - p. Since 2^256 = feC (mod p), the high half of a product is reduced
+// by multiplying it by feC and adding it to the low half.
+const feC = 0x1000003D1
+
+// p in little-endian limbs
+var feP = fe{0xFFFFFFFEFFFFFC2F, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}
+
+// feSetBytes sets z to the big-endian 32 byte value b.
+// Returns false, and leaves z unchanged, if b is not less than p.
+func feSetBytes(z *fe, b []byte) bool {
+	var x fe
+	x[3] = binary.BigEndian.Uint64(b[0:8])
+	x[2] = binary.BigEndian.Uint64(b[8:16])
+	x[1] = binary.BigEndian.Uint64(b[16:24])
+	x[0] = binary.BigEndian.Uint64(b[24:32])
+
+	// x < p if x + feC does not overflow
+	_, c := bits.Add64(x[0], feC, 0)
+	_, c = bits.Add64(x[1], 0, c)
+	_, c = bits.Add64(x[2], 0, c)
+	_, c = bits.Add64(x[3], 0, c)
+	if c != 0 {
+		return false
+	}
+
+	*z = x
+	return true
+}
+
+// feBytes writes the big-endian 32 byte value of x to b
+func feBytes(b []byte, x *fe) {
+	binary.BigEndian.PutUint64(b[0:8], x[3])
+	binary.BigEndian.PutUint64(b[8:16], x[2])
+	binary.BigEndian.PutUint64(b[16:24], x[1])
+	binary.BigEndian.PutUint64(b[24:32], x[0])
+}
+
+// feReduceOnce sets z to x mod p, for x < 2^256 < 2p
+func feReduceOnce(z *fe, x *fe) {
+	// x >= p if x + feC overflows, and then x - p = x + feC mod 2^256
+	var t fe
+	var c uint64
+	t[0], c = bits.Add64(x[0], feC, 0)
+	t[1], c = bits.Add64(x[1], 0, c)
+	t[2], c = bits.Add64(x[2], 0, c)
+	t[3], c = bits.Add64(x[3], 0, c)
+	feSelect(z, &t, x, c)
+}
+
+// feSelect sets z to a if cond is 1, and to b if cond is 0, in constant time
+func feSelect(z, a, b *fe, cond uint64) {
+	mask := -cond
+	z[0] = (a[0] & mask) | (b[0] &^ mask)
+	z[1] = (a[1] & mask) | (b[1] &^ mask)
+	z[2] = (a[2] & mask) | (b[2] &^ mask)
+	z[3] = (a[3] & mask) | (b[3] &^ mask)
+}
+
+// feAdd sets z = x + y
+func feAdd(z, x, y *fe) {
+	var s fe
+	var c uint64
+	s[0], c = bits.Add64(x[0], y[0], 0)
+	s[1], c = bits.Add64(x[1], y[1], c)
+	s[2], c = bits.Add64(x[2], y[2], c)
+	s[3], c = bits.Add64(x[3], y[3], c)
+
+	// x + y < 2p. If the sum overflowed, s + feC is the reduced sum and does not overflow.
+	var t fe
+	var c2 uint64
+	t[0], c2 = bits.Add64(s[0], feC, 0)
+	t[1], c2 = bits.Add64(s[1], 0, c2)
+	t[2], c2 = bits.Add64(s[2], 0, c2)
+	t[3], c2 = bits.Add64(s[3], 0, c2)
+	feSelect(z, &t, &s, c|c2)
+}
+
+// feSub sets z = x - y
+func feSub(z, x, y *fe) {
+	var d fe
+	var b uint64
+	d[0], b = bits.Sub64(x[0], y[0], 0)
+	d[1], b = bits.Sub64(x[1], y[1], b)
+	d[2], b = bits.Sub64(x[2], y[2], b)
+	d[3], b = bits.Sub64(x[3], y[3], b)
+
+	// Add p back if the difference is negative
+	mask := -b
+	var c uint64
+	z[0], c = bits.Add64(d[0], feP[0]&mask, 0)
+	z[1], c = bits.Add64(d[1], feP[1]&mask, c)
+	z[2], c = bits.Add64(d[2], feP[2]&mask, c)
+	z[3], _ = bits.Add64(d[3], feP[3]&mask, c)
+}
+
+// feNeg sets z = -x
+func feNeg(z, x *fe) {
+	var zero fe
+	feSub(z, &zero, x)
+}
+
+// feSqr sets z = x^2
+func feSqr(z, x *fe) {
+	feMul(z, x, x)
+}
+
+// feSqrN sets z = x^(2^n)
+func feSqrN(z, x *fe, n int) {
+	*z = *x
+	for i := 0; i < n; i++ {
+		feSqr(z, z)
+	}
+}
+
+// feMulGeneric sets z = x * y, with the portable math/bits implementation
+func feMulGeneric(z, x, y *fe) {
+	var t [8]uint64
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(x[i], y[j])
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+		t[i+4] = carry
+	}
+
+	// t = lo + hi*2^256 = lo + hi*feC (mod p)
+	var r fe
+	var carry uint64
+	for i := 0; i < 4; i++ {
+		hi, lo := bits.Mul64(t[4+i], feC)
+		var c uint64
+		lo, c = bits.Add64(lo, t[i], 0)
+		hi += c
+		lo, c = bits.Add64(lo, carry, 0)
+		hi += c
+		r[i] = lo
+		carry = hi
+	}
+
+	// Fold the carry, which is less than 2^34
+	hi, lo := bits.Mul64(carry, feC)
+	var c uint64
+	r[0], c = bits.Add64(r[0], lo, 0)
+	r[1], c = bits.Add64(r[1], hi, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], c = bits.Add64(r[3], 0, c)
+
+	// A final overflow leaves r small, so adding feC cannot overflow again
+	r[0], c = bits.Add64(r[0], feC&-c, 0)
+	r[1], c = bits.Add64(r[1], 0, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], _ = bits.Add64(r[3], 0, c)
+
+	feReduceOnce(z, &r)
+}
+
+// feIsZero returns 1 if x is zero, 0 otherwise
+func feIsZero(x *fe) uint64 {
+	v := x[0] | x[1] | x[2] | x[3]
+	return 1 ^ ((v | -v) >> 63)
+}
+
+// feEqual returns 1 if x == y, 0 otherwise
+func feEqual(x, y *fe) uint64 {
+	var d fe
+	d[0] = x[0] ^ y[0]
+	d[1] = x[1] ^ y[1]
+	d[2] = x[2] ^ y[2]
+	d[3] = x[3] ^ y[3]
+	return feIsZero(&d)
+}
+
+// feIsOdd returns 1 if x is odd, 0 otherwise
+func feIsOdd(x *fe) uint64 {
+	return x[0] & 1
+}
+
+// fePow2x223 computes x^(2^223 - 1), with x^(2^22 - 1) and x^(2^2 - 1) that are
+// needed for the inversion and square root addition chains.
+// The chains are the ones used by libsecp256k1.
+func fePow2x223(x *fe) (x223, x22, x2 fe) {
+	var x3, x6, x9, x11, x44, x88, x176, x220, t fe
+
+	feSqr(&x2, x)
+	feMul(&x2, &x2, x)
+
+	feSqr(&x3, &x2)
+	feMul(&x3, &x3, x)
+
+	feSqrN(&x6, &x3, 3)
+	feMul(&x6, &x6, &x3)
+
+	feSqrN(&x9, &x6, 3)
+	feMul(&x9, &x9, &x3)
+
+	feSqrN(&x11, &x9, 2)
+	feMul(&x11, &x11, &x2)
+
+	feSqrN(&x22, &x11, 11)
+	feMul(&x22, &x22, &x11)
+
+	feSqrN(&x44, &x22, 22)
+	feMul(&x44, &x44, &x22)
+
+	feSqrN(&x88, &x44, 44)
+	feMul(&x88, &x88, &x44)
+
+	feSqrN(&x176, &x88, 88)
+	feMul(&x176, &x176, &x88)
+
+	feSqrN(&x220, &x176, 44)
+	feMul(&x220, &x220, &x44)
+
+	feSqrN(&t, &x220, 3)
+	feMul(&x223, &t, &x3)
+
+	return
+}
+
+// feInv sets z = x^-1, computed as x^(p-2). The inverse of zero is zero.
+func feInv(z, x *fe) {
+	x223, x22, x2 := fePow2x223(x)
+
+	var t fe
+	feSqrN(&t, &x223, 23)
+	feMul(&t, &t, &x22)
+	feSqrN(&t, &t, 5)
+	feMul(&t, &t, x)
+	feSqrN(&t, &t, 3)
+	feMul(&t, &t, &x2)
+	feSqrN(&t, &t, 2)
+	feMul(z, &t, x)
+}
+
+// feSqrt sets z to a square root of x, computed as x^((p+1)/4).
+// Returns 1 if x is a square, 0 otherwise.
+func feSqrt(z, x *fe) uint64 {
+	x223, x22, x2 := fePow2x223(x)
+
+	var r, t fe
+	feSqrN(&r, &x223, 23)
+	feMul(&r, &r, &x22)
+	feSqrN(&r, &r, 6)
+	feMul(&r, &r, &x2)
+	feSqrN(&r, &r, 2)
+
+	feSqr(&t, &r)
+	ok := feEqual(&t, x)
+	*z = r
+	return ok
+}
@@ -0,0 +1,9 @@
+//go:build amd64 && !gccgo && !appengine
+// +build amd64,!gccgo,!appengine
+
+package secp256k1ct
+
+// feMul sets z = x * y. It is implemented in field_amd64.s.
+//
+//go:noescape
+func feMul(z, x, y *fe)
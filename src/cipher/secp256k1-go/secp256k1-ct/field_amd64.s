@@ -0,0 +1,111 @@
+//go:build amd64 && !gccgo && !appengine
+// +build amd64,!gccgo,!appengine
+
+#include "textflag.h"
+
+// mulRow adds x[i] * y to the accumulator t0..t4, where t4 is overwritten
+// with the carry. x[i] is at xoff(SI) and y is at DI.
+#define mulRow(xoff, t0, t1, t2, t3, t4) \
+	MOVQ xoff(SI), AX; MULQ 0(DI); ADDQ AX, t0; ADCQ $0, DX; MOVQ DX, CX; \
+	MOVQ xoff(SI), AX; MULQ 8(DI); ADDQ CX, t1; ADCQ $0, DX; ADDQ AX, t1; ADCQ $0, DX; MOVQ DX, CX; \
+	MOVQ xoff(SI), AX; MULQ 16(DI); ADDQ CX, t2; ADCQ $0, DX; ADDQ AX, t2; ADCQ $0, DX; MOVQ DX, CX; \
+	MOVQ xoff(SI), AX; MULQ 24(DI); ADDQ CX, t3; ADCQ $0, DX; ADDQ AX, t3; ADCQ $0, DX; MOVQ DX, t4
+
+// func feMul(z, x, y *fe)
+TEXT ·feMul(SB), NOSPLIT, $0-24
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+
+	// 512-bit product in R8, R9, R10, R11, R12, R13, R14, BX
+	MOVQ 0(SI), AX
+	MULQ 0(DI)
+	MOVQ AX, R8
+	MOVQ DX, R9
+	MOVQ 0(SI), AX
+	MULQ 8(DI)
+	ADDQ AX, R9
+	ADCQ $0, DX
+	MOVQ DX, R10
+	MOVQ 0(SI), AX
+	MULQ 16(DI)
+	ADDQ AX, R10
+	ADCQ $0, DX
+	MOVQ DX, R11
+	MOVQ 0(SI), AX
+	MULQ 24(DI)
+	ADDQ AX, R11
+	ADCQ $0, DX
+	MOVQ DX, R12
+
+	mulRow(8, R9, R10, R11, R12, R13)
+	mulRow(16, R10, R11, R12, R13, R14)
+	mulRow(24, R11, R12, R13, R14, BX)
+
+	// Reduce: lo + hi * 0x1000003D1
+	MOVQ $0x1000003D1, SI
+
+	MOVQ R12, AX
+	MULQ SI
+	ADDQ AX, R8
+	ADCQ $0, DX
+	MOVQ DX, CX
+
+	MOVQ R13, AX
+	MULQ SI
+	ADDQ CX, R9
+	ADCQ $0, DX
+	ADDQ AX, R9
+	ADCQ $0, DX
+	MOVQ DX, CX
+
+	MOVQ R14, AX
+	MULQ SI
+	ADDQ CX, R10
+	ADCQ $0, DX
+	ADDQ AX, R10
+	ADCQ $0, DX
+	MOVQ DX, CX
+
+	MOVQ BX, AX
+	MULQ SI
+	ADDQ CX, R11
+	ADCQ $0, DX
+	ADDQ AX, R11
+	ADCQ $0, DX
+
+	// Fold the carry in DX
+	MOVQ DX, AX
+	MULQ SI
+	ADDQ AX, R8
+	ADCQ DX, R9
+	ADCQ $0, R10
+	ADCQ $0, R11
+
+	// Fold a final overflow, which cannot overflow again
+	SBBQ CX, CX
+	ANDQ SI, CX
+	ADDQ CX, R8
+	ADCQ $0, R9
+	ADCQ $0, R10
+	ADCQ $0, R11
+
+	// Subtract p if the result is not less than p, that is if adding 2^256 - p overflows
+	MOVQ R8, R12
+	MOVQ R9, R13
+	MOVQ R10, R14
+	MOVQ R11, BX
+	ADDQ SI, R12
+	ADCQ $0, R13
+	ADCQ $0, R14
+	ADCQ $0, BX
+	CMOVQCS R12, R8
+	CMOVQCS R13, R9
+	CMOVQCS R14, R10
+	CMOVQCS BX, R11
+
+	MOVQ z+0(FP), DI
+	MOVQ R8, 0(DI)
+	MOVQ R9, 8(DI)
+	MOVQ R10, 16(DI)
+	MOVQ R11, 24(DI)
+	RET
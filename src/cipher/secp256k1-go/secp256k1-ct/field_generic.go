@@ -0,0 +1,9 @@
+//go:build !amd64 || gccgo || appengine
+// +build !amd64 gccgo appengine
+
+package secp256k1ct
+
+// feMul sets z = x * y
+func feMul(z, x, y *fe) {
+	feMulGeneric(z, x, y)
+}
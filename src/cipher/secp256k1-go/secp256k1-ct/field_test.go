@@ -0,0 +1,170 @@
+package secp256k1ct
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+var bigP, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+
+func feToBig(x *fe) *big.Int {
+	var b [32]byte
+	feBytes(b[:], x)
+	return new(big.Int).SetBytes(b[:])
+}
+
+func bigToFe(t *testing.T, v *big.Int) fe {
+	var x fe
+	if !feSetBytes(&x, bigBytes(v)) {
+		t.Fatalf("%x is not a field element", v)
+	}
+	return x
+}
+
+// randFe returns a random field element, with edge values mixed in
+func randFe(t *testing.T, i int) fe {
+	switch i {
+	case 0:
+		return fe{}
+	case 1:
+		return fe{1}
+	case 2:
+		return bigToFe(t, new(big.Int).Sub(bigP, big.NewInt(1)))
+	case 3:
+		return bigToFe(t, new(big.Int).Sub(bigP, big.NewInt(0x1000003D1)))
+	}
+
+	v, err := rand.Int(rand.Reader, bigP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bigToFe(t, v)
+}
+
+func TestFeSetBytes(t *testing.T) {
+	var x fe
+	b := bigBytes(bigP)
+	if feSetBytes(&x, b) {
+		t.Fatal("p is not a field element")
+	}
+
+	b[31]--
+	if !feSetBytes(&x, b) {
+		t.Fatal("p-1 is a field element")
+	}
+
+	var b2 [32]byte
+	feBytes(b2[:], &x)
+	if string(b) != string(b2[:]) {
+		t.Fatal("bytes roundtrip failed")
+	}
+}
+
+func TestFeArithmetic(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		x := randFe(t, i%8)
+		y := randFe(t, i/8%8)
+		bx := feToBig(&x)
+		by := feToBig(&y)
+
+		check := func(name string, z fe, want *big.Int) {
+			t.Helper()
+			want.Mod(want, bigP)
+			if feToBig(&z).Cmp(want) != 0 {
+				t.Fatalf("%s(%x, %x) = %x, want %x", name, bx, by, feToBig(&z), want)
+			}
+		}
+
+		var z fe
+		feAdd(&z, &x, &y)
+		check("add", z, new(big.Int).Add(bx, by))
+
+		feSub(&z, &x, &y)
+		check("sub", z, new(big.Int).Sub(bx, by))
+
+		feNeg(&z, &x)
+		check("neg", z, new(big.Int).Neg(bx))
+
+		feMul(&z, &x, &y)
+		check("mul", z, new(big.Int).Mul(bx, by))
+
+		feMulGeneric(&z, &x, &y)
+		check("mulGeneric", z, new(big.Int).Mul(bx, by))
+
+		feSqr(&z, &x)
+		check("sqr", z, new(big.Int).Mul(bx, bx))
+
+		feInv(&z, &x)
+		want := new(big.Int).ModInverse(bx, bigP)
+		if want == nil {
+			want = new(big.Int)
+		}
+		check("inv", z, want)
+
+		ok := feSqrt(&z, &x)
+		want = new(big.Int).ModSqrt(bx, bigP)
+		if (want != nil) != (ok == 1) {
+			t.Fatalf("sqrt(%x) ok=%d", bx, ok)
+		}
+		if want != nil {
+			var z2 fe
+			feSqr(&z2, &z)
+			check("sqrt", z2, new(big.Int).Set(bx))
+		}
+
+		if feEqual(&x, &y) != uint64(boolToInt(bx.Cmp(by) == 0)) {
+			t.Fatal("equal")
+		}
+		if feIsZero(&x) != uint64(boolToInt(bx.Sign() == 0)) {
+			t.Fatal("isZero")
+		}
+		if feIsOdd(&x) != uint64(bx.Bit(0)) {
+			t.Fatal("isOdd")
+		}
+	}
+}
+
+func TestFeAliasing(t *testing.T) {
+	x := randFe(t, 10)
+	bx := feToBig(&x)
+
+	z := x
+	feMul(&z, &z, &z)
+	if feToBig(&z).Cmp(new(big.Int).Mod(new(big.Int).Mul(bx, bx), bigP)) != 0 {
+		t.Fatal("aliased mul")
+	}
+
+	z = x
+	feInv(&z, &z)
+	if feToBig(&z).Cmp(new(big.Int).ModInverse(bx, bigP)) != 0 {
+		t.Fatal("aliased inv")
+	}
+}
+
+// bigBytes returns v as 32 big-endian bytes
+func bigBytes(v *big.Int) []byte {
+	b := v.Bytes()
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func BenchmarkFeMul(b *testing.B) {
+	x := fe{0x0123456789ABCDEF, 0xFEDCBA9876543210, 0x0F1E2D3C4B5A6978, 0x1122334455667788}
+	for i := 0; i < b.N; i++ {
+		feMul(&x, &x, &x)
+	}
+}
+
+func BenchmarkFeMulGeneric(b *testing.B) {
+	x := fe{0x0123456789ABCDEF, 0xFEDCBA9876543210, 0x0F1E2D3C4B5A6978, 0x1122334455667788}
+	for i := 0; i < b.N; i++ {
+		feMulGeneric(&x, &x, &x)
+	}
+}
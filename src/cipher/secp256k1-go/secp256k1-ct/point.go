@@ -0,0 +1,243 @@
+package secp256k1ct
+
+// point is a point on the curve y^2 = x^3 + 7 in homogeneous projective coordinates,
+// x = X/Z and y = Y/Z. The point at infinity is (0:1:0).
+//
+// Points are added and doubled with the complete formulas of Renes, Costello and Batina,
+// "Complete addition formulas for prime order elliptic curves" (https://eprint.iacr.org/2015/1060),
+// algorithms 7 and 9 for a = 0. The formulas have no special cases, so the same sequence of
+// field operations is done for any input, including the point at infinity and doubling.
+type point struct {
+	X, Y, Z fe
+}
+
+// affinePoint is a point in affine coordinates. It can't be the point at infinity.
+type affinePoint struct {
+	X, Y fe
+}
+
+// b3 is 3 * b, where b = 7 is the curve constant
+var feB3 = fe{21}
+
+// feB is the curve constant b = 7
+var feB = fe{7}
+
+// generator is the base point G of the curve
+var generator = affinePoint{
+	X: fe{0x59F2815B16F81798, 0x029BFCDB2DCE28D9, 0x55A06295CE870B07, 0x79BE667EF9DCBBAC},
+	Y: fe{0x9C47D08FFB10D4B8, 0xFD17B448A6855419, 0x5DA4FBFC0E1108A8, 0x483ADA7726A3C465},
+}
+
+// setInfinity sets p to the point at infinity
+func (p *point) setInfinity() {
+	p.X = fe{}
+	p.Y = fe{1}
+	p.Z = fe{}
+}
+
+// setAffine sets p to the affine point a
+func (p *point) setAffine(a *affinePoint) {
+	p.X = a.X
+	p.Y = a.Y
+	p.Z = fe{1}
+}
+
+// isInfinity returns 1 if p is the point at infinity, 0 otherwise
+func (p *point) isInfinity() uint64 {
+	return feIsZero(&p.Z)
+}
+
+// toAffine converts p to affine coordinates. The result is meaningless for the point at infinity.
+func (p *point) toAffine(a *affinePoint) {
+	var zinv fe
+	feInv(&zinv, &p.Z)
+	feMul(&a.X, &p.X, &zinv)
+	feMul(&a.Y, &p.Y, &zinv)
+}
+
+// selectPoint sets r to a if cond is 1, and to b if cond is 0, in constant time
+func selectPoint(r, a, b *point, cond uint64) {
+	feSelect(&r.X, &a.X, &b.X, cond)
+	feSelect(&r.Y, &a.Y, &b.Y, cond)
+	feSelect(&r.Z, &a.Z, &b.Z, cond)
+}
+
+// add sets r = p + q
+func (r *point) add(p, q *point) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 fe
+
+	feMul(&t0, &p.X, &q.X)
+	feMul(&t1, &p.Y, &q.Y)
+	feMul(&t2, &p.Z, &q.Z)
+	feAdd(&t3, &p.X, &p.Y)
+	feAdd(&t4, &q.X, &q.Y)
+	feMul(&t3, &t3, &t4)
+	feAdd(&t4, &t0, &t1)
+	feSub(&t3, &t3, &t4)
+	feAdd(&t4, &p.Y, &p.Z)
+	feAdd(&x3, &q.Y, &q.Z)
+	feMul(&t4, &t4, &x3)
+	feAdd(&x3, &t1, &t2)
+	feSub(&t4, &t4, &x3)
+	feAdd(&x3, &p.X, &p.Z)
+	feAdd(&y3, &q.X, &q.Z)
+	feMul(&x3, &x3, &y3)
+	feAdd(&y3, &t0, &t2)
+	feSub(&y3, &x3, &y3)
+	feAdd(&x3, &t0, &t0)
+	feAdd(&t0, &x3, &t0)
+	feMul(&t2, &feB3, &t2)
+	feAdd(&z3, &t1, &t2)
+	feSub(&t1, &t1, &t2)
+	feMul(&y3, &feB3, &y3)
+	feMul(&x3, &t4, &y3)
+	feMul(&t2, &t3, &t1)
+	feSub(&x3, &t2, &x3)
+	feMul(&y3, &y3, &t0)
+	feMul(&t1, &t1, &z3)
+	feAdd(&y3, &t1, &y3)
+	feMul(&t0, &t0, &t3)
+	feMul(&z3, &z3, &t4)
+	feAdd(&z3, &z3, &t0)
+
+	r.X = x3
+	r.Y = y3
+	r.Z = z3
+}
+
+// double sets r = 2p
+func (r *point) double(p *point) {
+	var t0, t1, t2, x3, y3, z3 fe
+
+	feSqr(&t0, &p.Y)
+	feAdd(&z3, &t0, &t0)
+	feAdd(&z3, &z3, &z3)
+	feAdd(&z3, &z3, &z3)
+	feMul(&t1, &p.Y, &p.Z)
+	feSqr(&t2, &p.Z)
+	feMul(&t2, &feB3, &t2)
+	feMul(&x3, &t2, &z3)
+	feAdd(&y3, &t0, &t2)
+	feMul(&z3, &t1, &z3)
+	feAdd(&t1, &t2, &t2)
+	feAdd(&t2, &t1, &t2)
+	feSub(&t0, &t0, &t2)
+	feMul(&y3, &t0, &y3)
+	feAdd(&y3, &x3, &y3)
+	feMul(&t1, &p.X, &p.Y)
+	feMul(&x3, &t0, &t1)
+	feAdd(&x3, &x3, &x3)
+
+	r.X = x3
+	r.Y = y3
+	r.Z = z3
+}
+
+// isOnCurve returns 1 if the affine point a satisfies y^2 = x^3 + 7, 0 otherwise
+func (a *affinePoint) isOnCurve() uint64 {
+	var y2, x3 fe
+	feSqr(&y2, &a.Y)
+	feSqr(&x3, &a.X)
+	feMul(&x3, &x3, &a.X)
+	feAdd(&x3, &x3, &feB)
+	return feEqual(&y2, &x3)
+}
+
+// setXO sets a to the point with the x coordinate x and a y coordinate of parity odd.
+// Returns 1 if there is such a point, 0 otherwise.
+func (a *affinePoint) setXO(x *fe, odd uint64) uint64 {
+	var y2, y, ny fe
+	feSqr(&y2, x)
+	feMul(&y2, &y2, x)
+	feAdd(&y2, &y2, &feB)
+	ok := feSqrt(&y, &y2)
+
+	feNeg(&ny, &y)
+	feSelect(&a.Y, &ny, &y, feIsOdd(&y)^odd)
+	a.X = *x
+	return ok
+}
+
+// scalarNibble returns the i-th 4-bit window of k, counting from the least significant
+func scalarNibble(k *scalar, i int) uint64 {
+	return (k[i/16] >> (uint(i%16) * 4)) & 0xF
+}
+
+// genTable holds j * 16^i * G for each 4-bit window i of a scalar and j in [1, 16),
+// at genTable[i][j-1]
+var genTable [64][15]affinePoint
+
+func init() {
+	var base point
+	base.setAffine(&generator)
+	for i := range genTable {
+		p := base
+		for j := range genTable[i] {
+			p.toAffine(&genTable[i][j])
+			p.add(&p, &base)
+		}
+		// p is now 16 * base
+		base = p
+	}
+}
+
+// lookupAffine sets r to table[w-1], or to the point at infinity if w is 0,
+// reading every entry of the table so that the memory access pattern does not depend on w
+func lookupAffine(r *point, table *[15]affinePoint, w uint64) {
+	r.setInfinity()
+	one := fe{1}
+	for j := range table {
+		cond := ctEqual(uint64(j+1), w)
+		feSelect(&r.X, &table[j].X, &r.X, cond)
+		feSelect(&r.Y, &table[j].Y, &r.Y, cond)
+		feSelect(&r.Z, &one, &r.Z, cond)
+	}
+}
+
+// lookupPoint sets r to table[w], reading every entry of the table
+func lookupPoint(r *point, table *[16]point, w uint64) {
+	*r = table[0]
+	for j := 1; j < len(table); j++ {
+		selectPoint(r, &table[j], r, ctEqual(uint64(j), w))
+	}
+}
+
+// ctEqual returns 1 if x == y, 0 otherwise, in constant time
+func ctEqual(x, y uint64) uint64 {
+	v := x ^ y
+	return 1 ^ ((v | -v) >> 63)
+}
+
+// scalarBaseMult sets r = k * G in constant time, by adding one precomputed point
+// for each 4-bit window of k
+func (r *point) scalarBaseMult(k *scalar) {
+	var acc, t point
+	acc.setInfinity()
+	for i := range genTable {
+		lookupAffine(&t, &genTable[i], scalarNibble(k, i))
+		acc.add(&acc, &t)
+	}
+	*r = acc
+}
+
+// scalarMult sets r = k * p in constant time, with a fixed 4-bit window
+func (r *point) scalarMult(p *point, k *scalar) {
+	var table [16]point
+	table[0].setInfinity()
+	table[1] = *p
+	for j := 2; j < len(table); j++ {
+		table[j].add(&table[j-1], p)
+	}
+
+	var acc, t point
+	acc.setInfinity()
+	for i := 63; i >= 0; i-- {
+		acc.double(&acc)
+		acc.double(&acc)
+		acc.double(&acc)
+		acc.double(&acc)
+		lookupPoint(&t, &table, scalarNibble(k, i))
+		acc.add(&acc, &t)
+	}
+	*r = acc
+}
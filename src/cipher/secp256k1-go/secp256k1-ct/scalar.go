@@ -0,0 +1,205 @@
+package secp256k1ct
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// scalar is an integer modulo the order n of the curve, in four little-endian 64-bit limbs.
+// Scalars are always fully reduced, in [0, n).
+type scalar [4]uint64
+
+// scN is the order of the curve
+var scN = scalar{0xBFD25E8CD0364141, 0xBAAEDCE6AF48A03B, 0xFFFFFFFFFFFFFFFE, 0xFFFFFFFFFFFFFFFF}
+
+// scHalfN is n / 2, the largest low S value of a signature
+var scHalfN = scalar{0xDFE92F46681B20A0, 0x5D576E7357A4501D, 0xFFFFFFFFFFFFFFFF, 0x7FFFFFFFFFFFFFFF}
+
+// scC is 2^256 - n, which is 129 bits long
+var scC = [3]uint64{0x402DA1732FC9BEBF, 0x4551231950B75FC4, 1}
+
+// scSetBytes sets z to the big-endian 32 byte value b, reduced modulo n.
+// Returns 1 if b was not less than n, 0 otherwise.
+func scSetBytes(z *scalar, b []byte) uint64 {
+	var x scalar
+	x[3] = binary.BigEndian.Uint64(b[0:8])
+	x[2] = binary.BigEndian.Uint64(b[8:16])
+	x[1] = binary.BigEndian.Uint64(b[16:24])
+	x[0] = binary.BigEndian.Uint64(b[24:32])
+	return scReduceOnce(z, &x)
+}
+
+// scSetBytesAny sets z to the big-endian value b of any length, reduced modulo n
+func scSetBytesAny(z *scalar, b []byte) {
+	// Left pad b to a multiple of 32 bytes, then accumulate 256 bits at a time,
+	// using 2^256 = scC (mod n)
+	n := (len(b) + 31) / 32 * 32
+	buf := make([]byte, n)
+	copy(buf[n-len(b):], b)
+
+	var c scalar
+	copy(c[:3], scC[:])
+
+	var acc scalar
+	for i := 0; i < n; i += 32 {
+		var x scalar
+		scSetBytes(&x, buf[i:i+32])
+		scMul(&acc, &acc, &c)
+		scAdd(&acc, &acc, &x)
+	}
+	*z = acc
+}
+
+// scBytes writes the big-endian 32 byte value of x to b
+func scBytes(b []byte, x *scalar) {
+	binary.BigEndian.PutUint64(b[0:8], x[3])
+	binary.BigEndian.PutUint64(b[8:16], x[2])
+	binary.BigEndian.PutUint64(b[16:24], x[1])
+	binary.BigEndian.PutUint64(b[24:32], x[0])
+}
+
+// scSelect sets z to a if cond is 1, and to b if cond is 0, in constant time
+func scSelect(z, a, b *scalar, cond uint64) {
+	mask := -cond
+	z[0] = (a[0] & mask) | (b[0] &^ mask)
+	z[1] = (a[1] & mask) | (b[1] &^ mask)
+	z[2] = (a[2] & mask) | (b[2] &^ mask)
+	z[3] = (a[3] & mask) | (b[3] &^ mask)
+}
+
+// scReduceOnce sets z to x mod n, for x < 2^256 < 2n.
+// Returns 1 if x was not less than n, 0 otherwise.
+func scReduceOnce(z, x *scalar) uint64 {
+	// x >= n if x + scC overflows, and then x - n = x + scC mod 2^256
+	var t scalar
+	var c uint64
+	t[0], c = bits.Add64(x[0], scC[0], 0)
+	t[1], c = bits.Add64(x[1], scC[1], c)
+	t[2], c = bits.Add64(x[2], scC[2], c)
+	t[3], c = bits.Add64(x[3], 0, c)
+	scSelect(z, &t, x, c)
+	return c
+}
+
+// scIsZero returns 1 if x is zero, 0 otherwise
+func scIsZero(x *scalar) uint64 {
+	v := x[0] | x[1] | x[2] | x[3]
+	return 1 ^ ((v | -v) >> 63)
+}
+
+// scIsHigh returns 1 if x > n/2, 0 otherwise
+func scIsHigh(x *scalar) uint64 {
+	var b uint64
+	_, b = bits.Sub64(scHalfN[0], x[0], 0)
+	_, b = bits.Sub64(scHalfN[1], x[1], b)
+	_, b = bits.Sub64(scHalfN[2], x[2], b)
+	_, b = bits.Sub64(scHalfN[3], x[3], b)
+	return b
+}
+
+// scAdd sets z = x + y
+func scAdd(z, x, y *scalar) {
+	var s scalar
+	var c uint64
+	s[0], c = bits.Add64(x[0], y[0], 0)
+	s[1], c = bits.Add64(x[1], y[1], c)
+	s[2], c = bits.Add64(x[2], y[2], c)
+	s[3], c = bits.Add64(x[3], y[3], c)
+
+	// x + y < 2n. If the sum overflowed, s + scC is the reduced sum and does not overflow.
+	var t scalar
+	var c2 uint64
+	t[0], c2 = bits.Add64(s[0], scC[0], 0)
+	t[1], c2 = bits.Add64(s[1], scC[1], c2)
+	t[2], c2 = bits.Add64(s[2], scC[2], c2)
+	t[3], c2 = bits.Add64(s[3], 0, c2)
+	scSelect(z, &t, &s, c|c2)
+}
+
+// scNeg sets z = -x
+func scNeg(z, x *scalar) {
+	var d scalar
+	var b uint64
+	d[0], b = bits.Sub64(scN[0], x[0], 0)
+	d[1], b = bits.Sub64(scN[1], x[1], b)
+	d[2], b = bits.Sub64(scN[2], x[2], b)
+	d[3], _ = bits.Sub64(scN[3], x[3], b)
+
+	// -0 is 0, not n
+	var zero scalar
+	scSelect(z, &zero, &d, scIsZero(x))
+}
+
+// scFold sets t = lo + hi * scC, where t = lo + hi * 2^256, which is the same value modulo n
+func scFold(t *[8]uint64) {
+	var r [8]uint64
+	copy(r[:4], t[:4])
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 3; j++ {
+			hi, lo := bits.Mul64(t[4+i], scC[j])
+			var c uint64
+			lo, c = bits.Add64(lo, r[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			r[i+j] = lo
+			carry = hi
+		}
+		for k := i + 3; k < 8; k++ {
+			r[k], carry = bits.Add64(r[k], carry, 0)
+		}
+	}
+	*t = r
+}
+
+// scMul sets z = x * y
+func scMul(z, x, y *scalar) {
+	var t [8]uint64
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(x[i], y[j])
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+		t[i+4] = carry
+	}
+
+	// Each fold shrinks the product: below 2^386, 2^260, 2^256 + 2^134 and finally 2^256
+	for i := 0; i < 4; i++ {
+		scFold(&t)
+	}
+
+	r := scalar{t[0], t[1], t[2], t[3]}
+	scReduceOnce(z, &r)
+}
+
+// scInv sets z = x^-1, computed as x^(n-2) with fixed 4-bit windows.
+// The exponent is public, so only the multiplications depend on x.
+func scInv(z, x *scalar) {
+	var table [16]scalar
+	table[0] = scalar{1}
+	for i := 1; i < 16; i++ {
+		scMul(&table[i], &table[i-1], x)
+	}
+
+	e := scN
+	e[0] -= 2
+
+	var r scalar
+	r[0] = 1
+	for i := 63; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			scMul(&r, &r, &r)
+		}
+		w := (e[i/16] >> (uint(i%16) * 4)) & 0xF
+		scMul(&r, &r, &table[w])
+	}
+	*z = r
+}
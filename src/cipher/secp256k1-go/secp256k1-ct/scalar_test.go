@@ -0,0 +1,107 @@
+package secp256k1ct
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+var bigN, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+func scToBig(x *scalar) *big.Int {
+	var b [32]byte
+	scBytes(b[:], x)
+	return new(big.Int).SetBytes(b[:])
+}
+
+// randScalar returns a random scalar, with edge values mixed in
+func randScalar(t *testing.T, i int) scalar {
+	var v *big.Int
+	switch i {
+	case 0:
+		v = new(big.Int)
+	case 1:
+		v = big.NewInt(1)
+	case 2:
+		v = new(big.Int).Sub(bigN, big.NewInt(1))
+	case 3:
+		v = new(big.Int).Rsh(bigN, 1)
+	default:
+		var err error
+		v, err = rand.Int(rand.Reader, bigN)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var x scalar
+	if scSetBytes(&x, bigBytes(v)) != 0 {
+		t.Fatalf("%x is not a scalar", v)
+	}
+	return x
+}
+
+func TestScSetBytes(t *testing.T) {
+	var x scalar
+	if scSetBytes(&x, bigBytes(bigN)) != 1 || scIsZero(&x) != 1 {
+		t.Fatal("n should overflow to 0")
+	}
+
+	max := bigBytes(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)))
+	if scSetBytes(&x, max) != 1 {
+		t.Fatal("2^256-1 should overflow")
+	}
+	want := new(big.Int).Mod(new(big.Int).SetBytes(max), bigN)
+	if scToBig(&x).Cmp(want) != 0 {
+		t.Fatal("2^256-1 is not reduced")
+	}
+
+	for _, n := range []int{0, 1, 20, 32, 33, 64, 100} {
+		b := make([]byte, n)
+		if _, err := rand.Read(b); err != nil {
+			t.Fatal(err)
+		}
+		scSetBytesAny(&x, b)
+		want := new(big.Int).Mod(new(big.Int).SetBytes(b), bigN)
+		if scToBig(&x).Cmp(want) != 0 {
+			t.Fatalf("scSetBytesAny with %d bytes", n)
+		}
+	}
+}
+
+func TestScArithmetic(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		x := randScalar(t, i%8)
+		y := randScalar(t, i/8%8)
+		bx := scToBig(&x)
+		by := scToBig(&y)
+
+		check := func(name string, z scalar, want *big.Int) {
+			t.Helper()
+			want.Mod(want, bigN)
+			if scToBig(&z).Cmp(want) != 0 {
+				t.Fatalf("%s(%x, %x) = %x, want %x", name, bx, by, scToBig(&z), want)
+			}
+		}
+
+		var z scalar
+		scAdd(&z, &x, &y)
+		check("add", z, new(big.Int).Add(bx, by))
+
+		scNeg(&z, &x)
+		check("neg", z, new(big.Int).Neg(bx))
+
+		scMul(&z, &x, &y)
+		check("mul", z, new(big.Int).Mul(bx, by))
+
+		if bx.Sign() != 0 {
+			scInv(&z, &x)
+			check("inv", z, new(big.Int).ModInverse(bx, bigN))
+		}
+
+		high := bx.Cmp(new(big.Int).Rsh(bigN, 1)) > 0
+		if scIsHigh(&x) != uint64(boolToInt(high)) {
+			t.Fatalf("isHigh(%x)", bx)
+		}
+	}
+}
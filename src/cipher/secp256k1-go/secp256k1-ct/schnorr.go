@@ -0,0 +1,163 @@
+package secp256k1ct
+
+import (
+	"crypto/sha256"
+	"log"
+)
+
+// The signing functions of the Schnorr and MuSig variants of secp256k1-go2 which handle
+// secret keys and nonces. Verification, public key recovery and the aggregation of public
+// keys and nonces only handle public data, and are left to secp256k1-go2.
+
+const (
+	bip340AuxTag       = "BIP0340/aux"
+	commitNonceTag     = "SkycoinSchnorr/nonce"
+	commitChallengeTag = "SkycoinSchnorr/challenge"
+	muSigNonceTag      = "MuSig/nonce"
+)
+
+// taggedHash returns SHA256(SHA256(tag) || SHA256(tag) || msgs...), as defined in BIP340
+func taggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	h := sha256.New()
+	h.Write(tagHash[:]) //nolint:errcheck
+	h.Write(tagHash[:]) //nolint:errcheck
+	for _, m := range msgs {
+		h.Write(m) //nolint:errcheck
+	}
+
+	return h.Sum(nil)
+}
+
+// SchnorrSignCommit signs a 32 byte message with a Schnorr signature whose challenge hash
+// commits to keyCommit in place of the public key, like secp256k1-go2.SchnorrSignCommit.
+// auxRand is 32 bytes of auxiliary randomness, which may be zero.
+// Returns nil if the secret key is invalid.
+func SchnorrSignCommit(seckey, msg, auxRand, keyCommit []byte) []byte {
+	if len(msg) != 32 || len(auxRand) != 32 {
+		log.Panic("SchnorrSignCommit requires a 32 byte message and 32 bytes of auxiliary randomness")
+	}
+	if len(seckey) != 32 {
+		log.Panic("secret key length must be 32 bytes")
+	}
+	if SeckeyIsValid(seckey) != 1 {
+		return nil
+	}
+
+	var d scalar
+	scSetBytes(&d, seckey)
+
+	// The nonce is derived from the secret key masked with the auxiliary randomness
+	t := make([]byte, 32)
+	aux := taggedHash(bip340AuxTag, auxRand)
+	for i := range t {
+		t[i] = seckey[i] ^ aux[i]
+	}
+
+	var k scalar
+	scSetBytesAny(&k, taggedHash(commitNonceTag, t, keyCommit, msg))
+	if scIsZero(&k) == 1 {
+		return nil
+	}
+
+	// R = k*G, with k negated if R has an odd y coordinate
+	var rp point
+	var ra affinePoint
+	rp.scalarBaseMult(&k)
+	rp.toAffine(&ra)
+
+	var negK scalar
+	scNeg(&negK, &k)
+	scSelect(&k, &negK, &k, feIsOdd(&ra.Y))
+
+	sig := make([]byte, 64)
+	feBytes(sig[:32], &ra.X)
+
+	var e scalar
+	scSetBytesAny(&e, taggedHash(commitChallengeTag, sig[:32], keyCommit, msg))
+	if scIsZero(&e) == 1 {
+		return nil
+	}
+
+	// s = k + e*d
+	var s scalar
+	scMul(&s, &e, &d)
+	scAdd(&s, &s, &k)
+	scBytes(sig[32:], &s)
+
+	return sig
+}
+
+// MuSigNonceGen derives a 64 byte secret nonce and its 66 byte public nonce from 32 random bytes,
+// like secp256k1-go2.MuSigNonceGen
+func MuSigNonceGen(rand []byte) ([]byte, []byte) {
+	if len(rand) != 32 {
+		log.Panic("MuSigNonceGen requires 32 random bytes")
+	}
+
+	secnonce := make([]byte, 64)
+	pubnonce := make([]byte, 0, 66)
+	for i := 0; i < 2; i++ {
+		var k scalar
+		scSetBytesAny(&k, taggedHash(muSigNonceTag, rand, []byte{byte(i)}))
+		if scIsZero(&k) == 1 {
+			log.Panic("MuSigNonceGen derived a zero nonce")
+		}
+
+		var rp point
+		var ra affinePoint
+		rp.scalarBaseMult(&k)
+		rp.toAffine(&ra)
+
+		scBytes(secnonce[i*32:(i+1)*32], &k)
+		pubnonce = append(pubnonce, ra.bytes()...)
+	}
+
+	return secnonce, pubnonce
+}
+
+// MuSigPartialSign returns the 32 byte partial signature s = k1 + b*k2 + ea*d of a signer,
+// with k1 and k2 negated if negNonce is true.
+// secnonce is the 64 byte k1||k2 secret nonce, which must hold two valid secret keys, and seckey is the valid
+// secret key d. b is the nonce coefficient and ea is the challenge multiplied by the key aggregation
+// coefficient of the signer, which only depend on public data and are computed by secp256k1-go2.
+func MuSigPartialSign(secnonce, seckey, b, ea []byte, negNonce bool) []byte {
+	if len(secnonce) != 64 || SeckeyIsValid(secnonce[:32]) != 1 || SeckeyIsValid(secnonce[32:]) != 1 {
+		log.Panic("MuSigPartialSign requires a valid secret nonce")
+	}
+	if SeckeyIsValid(seckey) != 1 {
+		log.Panic("MuSigPartialSign requires a valid secret key")
+	}
+	if len(b) != 32 || len(ea) != 32 {
+		log.Panic("MuSigPartialSign requires 32 byte coefficients")
+	}
+
+	var k1, k2, d, bs, eas scalar
+	scSetBytes(&k1, secnonce[:32])
+	scSetBytes(&k2, secnonce[32:])
+	scSetBytes(&d, seckey)
+	scSetBytes(&bs, b)
+	scSetBytes(&eas, ea)
+
+	// k = k1 + b*k2, negated with the final nonce
+	var k, negK scalar
+	scMul(&k, &bs, &k2)
+	scAdd(&k, &k, &k1)
+	scNeg(&negK, &k)
+
+	var neg uint64
+	if negNonce {
+		neg = 1
+	}
+	scSelect(&k, &negK, &k, neg)
+
+	// s = k + ea*d
+	var s scalar
+	scMul(&s, &eas, &d)
+	scAdd(&s, &s, &k)
+
+	psig := make([]byte, 32)
+	scBytes(psig, &s)
+	return psig
+}
@@ -0,0 +1,93 @@
+package secp256k1ct
+
+import (
+	"bytes"
+	"testing"
+
+	secp "github.com/skycoin/skycoin/src/cipher/secp256k1-go/secp256k1-go2"
+)
+
+func TestSchnorrSignCommit(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		seckey := randSeckey(t)
+		msg := randSeckey(t)
+		auxRand := randSeckey(t)
+		keyCommit := randSeckey(t)[:20]
+
+		sig := SchnorrSignCommit(seckey, msg, auxRand, keyCommit)
+		if sig == nil {
+			t.Fatal("SchnorrSignCommit failed")
+		}
+
+		// Signatures match secp256k1-go2
+		sig2 := secp.SchnorrSignCommit(seckey, msg, auxRand, keyCommit)
+		if !bytes.Equal(sig, sig2) {
+			t.Fatalf("signature mismatch: %x %x", sig, sig2)
+		}
+
+		recovered := secp.SchnorrRecoverCommit(msg, sig, keyCommit)
+		if !bytes.Equal(recovered, GeneratePublicKey(seckey)) {
+			t.Fatal("recovered public key mismatch")
+		}
+	}
+
+	// Invalid secret key
+	if SchnorrSignCommit(make([]byte, 32), randSeckey(t), randSeckey(t), nil) != nil {
+		t.Fatal("signing with a zero secret key should fail")
+	}
+}
+
+func TestMuSigPartialSign(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		seckeys := [][]byte{randSeckey(t), randSeckey(t), randSeckey(t)}
+		pubkeys := make([][]byte, len(seckeys))
+		secnonces := make([][]byte, len(seckeys))
+		pubnonces := make([][]byte, len(seckeys))
+		for j, seckey := range seckeys {
+			pubkeys[j] = GeneratePublicKey(seckey)
+
+			rand := randSeckey(t)
+			secnonces[j], pubnonces[j] = MuSigNonceGen(rand)
+
+			// Nonces match secp256k1-go2
+			secnonce2, pubnonce2 := secp.MuSigNonceGen(rand)
+			if !bytes.Equal(secnonces[j], secnonce2) || !bytes.Equal(pubnonces[j], pubnonce2) {
+				t.Fatal("nonce mismatch")
+			}
+		}
+
+		aggnonce, err := secp.MuSigNonceAgg(pubnonces)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg := randSeckey(t)
+		keyCommit := randSeckey(t)[:20]
+
+		psigs := make([][]byte, len(seckeys))
+		for j, seckey := range seckeys {
+			b, ea, negNonce, err := secp.MuSigSignCoefs(pubkeys[j], pubkeys, aggnonce, msg, keyCommit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			psigs[j] = MuSigPartialSign(secnonces[j], seckey, b, ea, negNonce)
+
+			// Partial signatures match secp256k1-go2
+			psig2, err := secp.MuSigPartialSign(append([]byte{}, secnonces[j]...), seckey, pubkeys, aggnonce, msg, keyCommit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(psigs[j], psig2) {
+				t.Fatalf("partial signature mismatch: %x %x", psigs[j], psig2)
+			}
+
+			if err := secp.MuSigPartialVerify(psigs[j], pubnonces[j], pubkeys[j], pubkeys, aggnonce, msg, keyCommit); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := secp.MuSigPartialSigAgg(psigs, pubkeys, aggnonce, msg, keyCommit); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Package secp256k1ct implements the secp256k1 primitives used by cipher/secp256k1-go
+with constant-time arithmetic.
+
+Field elements and scalars are held in four 64-bit limbs. Field multiplication is
+implemented in assembly on amd64, with a portable math/bits fallback for other architectures.
+Points are added with complete formulas, the generator multiples are precomputed in tables
+and table lookups read every entry, so that key generation, signing and ECDH run in constant
+time with respect to the secret key and nonce.
+
+The functions mirror those of secp256k1-go2 used by cipher/secp256k1-go and produce the same
+keys and signatures. The package is selected in cipher/secp256k1-go with the secp256k1ct build tag.
+*/
+package secp256k1ct
+
+import (
+	"log"
+	"math/bits"
+)
+
+// SeckeyIsValid returns 1 if the secret key is valid, that is 0 < seckey < n.
+// Returns -1 if the secret key is zero, -2 if it is not less than the order of the curve.
+func SeckeyIsValid(seckey []byte) int {
+	if len(seckey) != 32 {
+		log.Panic("SeckeyIsValid seckey must be 32 bytes")
+	}
+
+	var k scalar
+	if scSetBytes(&k, seckey) == 1 {
+		return -2
+	}
+	if scIsZero(&k) == 1 {
+		return -1
+	}
+	return 1
+}
+
+// parsePubkey parses a compressed public key
+func parsePubkey(pubkey []byte) (affinePoint, bool) {
+	var a affinePoint
+	if len(pubkey) != 33 {
+		log.Panic("public key length must be 33 bytes")
+	}
+	if pubkey[0] != 0x02 && pubkey[0] != 0x03 {
+		return a, false
+	}
+
+	var x fe
+	if !feSetBytes(&x, pubkey[1:]) {
+		return a, false
+	}
+
+	ok := a.setXO(&x, uint64(pubkey[0]&1))
+	return a, ok == 1
+}
+
+// PubkeyIsValid returns 1 if the compressed public key is a point on the curve
+func PubkeyIsValid(pubkey []byte) int {
+	if _, ok := parsePubkey(pubkey); !ok {
+		return -1
+	}
+	return 1
+}
+
+// bytes returns the compressed public key of a
+func (a *affinePoint) bytes() []byte {
+	raw := make([]byte, 33)
+	raw[0] = 0x02 | byte(feIsOdd(&a.Y))
+	feBytes(raw[1:], &a.X)
+	return raw
+}
+
+// UncompressPubkey returns the 65 byte uncompressed public key of a compressed public key,
+// or nil if the public key is invalid
+func UncompressPubkey(pubkey []byte) []byte {
+	a, ok := parsePubkey(pubkey)
+	if !ok {
+		return nil
+	}
+
+	raw := make([]byte, 65)
+	raw[0] = 0x04
+	feBytes(raw[1:33], &a.X)
+	feBytes(raw[33:65], &a.Y)
+	return raw
+}
+
+// GeneratePublicKey returns the compressed public key of a valid secret key
+func GeneratePublicKey(seckey []byte) []byte {
+	if SeckeyIsValid(seckey) != 1 {
+		log.Panic("only call for valid seckey, check that seckey is valid first")
+	}
+
+	var k scalar
+	scSetBytes(&k, seckey)
+
+	var p point
+	var a affinePoint
+	p.scalarBaseMult(&k)
+	p.toAffine(&a)
+	return a.bytes()
+}
+
+// Multiply returns k * pubkey, as a compressed public key.
+// Returns nil if the public key is invalid or the result is the point at infinity.
+func Multiply(pubkey, k []byte) []byte {
+	a, ok := parsePubkey(pubkey)
+	if !ok {
+		return nil
+	}
+
+	var n scalar
+	scSetBytes(&n, k)
+
+	var p point
+	p.setAffine(&a)
+	p.scalarMult(&p, &n)
+	if p.isInfinity() == 1 {
+		return nil
+	}
+
+	p.toAffine(&a)
+	return a.bytes()
+}
+
+// Sign signs a message with a secret key and a nonce, which both must be valid secret keys.
+// Returns the 64 byte signature R||S with a low S value, the recovery id, and 1 on success.
+// Signing fails, with a return code of 0, only if the nonce is invalid or S is zero.
+func Sign(seckey, msg, nonce []byte) ([]byte, int, int) {
+	if SeckeyIsValid(seckey) != 1 {
+		log.Panic("Attempting to sign with invalid seckey")
+	}
+	if SeckeyIsValid(nonce) != 1 {
+		return nil, 0, 0
+	}
+
+	var d, k, m, r, s, t scalar
+	scSetBytes(&d, seckey)
+	scSetBytes(&k, nonce)
+	scSetBytesAny(&m, msg)
+
+	// R = k*G, r = R.x mod n
+	var rp point
+	var ra affinePoint
+	rp.scalarBaseMult(&k)
+	rp.toAffine(&ra)
+
+	var rb [32]byte
+	feBytes(rb[:], &ra.X)
+	overflow := scSetBytes(&r, rb[:])
+	if scIsZero(&r) == 1 {
+		return nil, 0, 0
+	}
+	recid := int(overflow<<1 | feIsOdd(&ra.Y))
+
+	// s = k^-1 * (r*d + m)
+	scMul(&t, &r, &d)
+	scAdd(&t, &t, &m)
+	scInv(&s, &k)
+	scMul(&s, &s, &t)
+	if scIsZero(&s) == 1 {
+		return nil, 0, 0
+	}
+
+	// Use the low S value to prevent malleability
+	high := scIsHigh(&s)
+	scNeg(&t, &s)
+	scSelect(&s, &t, &s, high)
+	recid ^= int(high)
+
+	sig := make([]byte, 64)
+	scBytes(sig[0:32], &r)
+	scBytes(sig[32:64], &s)
+	return sig, recid, 1
+}
+
+// RecoverPublicKey recovers a public key from a signature and the message it signed.
+// Returns nil on error with an int error code. Returns 1 on success.
+// Recovery works on public data and uses the same constant-time operations as signing.
+func RecoverPublicKey(sig, msg []byte, recid int) ([]byte, int) {
+	if len(sig) != 64 {
+		log.Panic("must pass in 64 byte signature")
+	}
+
+	var r, s, m scalar
+	if scSetBytes(&r, sig[0:32]) == 1 {
+		return nil, -3
+	}
+	if scIsZero(&r) == 1 {
+		return nil, -1
+	}
+	if scSetBytes(&s, sig[32:64]) == 1 || scIsZero(&s) == 1 {
+		return nil, -5
+	}
+	scSetBytesAny(&m, msg)
+
+	// The x coordinate of R is r, or r + n if the recovery id says r overflowed
+	xr := fe(r)
+	if (recid & 2) != 0 {
+		var c uint64
+		xr[0], c = bits.Add64(xr[0], scN[0], 0)
+		xr[1], c = bits.Add64(xr[1], scN[1], c)
+		xr[2], c = bits.Add64(xr[2], scN[2], c)
+		xr[3], c = bits.Add64(xr[3], scN[3], c)
+		var b [32]byte
+		feBytes(b[:], &xr)
+		if c != 0 || !feSetBytes(&xr, b[:]) {
+			return nil, -6
+		}
+	}
+
+	var ra affinePoint
+	if ra.setXO(&xr, uint64(recid&1)) != 1 {
+		return nil, -6
+	}
+
+	// Q = r^-1 * (s*R - m*G)
+	var rinv, u1, u2 scalar
+	scInv(&rinv, &r)
+	scMul(&u1, &rinv, &m)
+	scNeg(&u1, &u1)
+	scMul(&u2, &rinv, &s)
+
+	var rp, q, g point
+	rp.setAffine(&ra)
+	q.scalarMult(&rp, &u2)
+	g.scalarBaseMult(&u1)
+	q.add(&q, &g)
+	if q.isInfinity() == 1 {
+		return nil, -6
+	}
+
+	var qa affinePoint
+	q.toAffine(&qa)
+	return qa.bytes(), 1
+}
@@ -0,0 +1,304 @@
+package secp256k1ct
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	secp "github.com/skycoin/skycoin/src/cipher/secp256k1-go/secp256k1-go2"
+)
+
+func randSeckey(t *testing.T) []byte {
+	for {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			t.Fatal(err)
+		}
+		if SeckeyIsValid(b) == 1 {
+			return b
+		}
+	}
+}
+
+func TestSeckeyIsValid(t *testing.T) {
+	cases := []struct {
+		seckey string
+		ret    int
+	}{
+		{"0000000000000000000000000000000000000000000000000000000000000000", -1},
+		{"0000000000000000000000000000000000000000000000000000000000000001", 1},
+		{"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364140", 1},
+		{"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", -2},
+		{"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF", -2},
+	}
+
+	for _, tc := range cases {
+		b, err := hex.DecodeString(tc.seckey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ret := SeckeyIsValid(b); ret != tc.ret {
+			t.Errorf("SeckeyIsValid(%s) = %d, want %d", tc.seckey, ret, tc.ret)
+		}
+		if ret := secp.SeckeyIsValid(b); ret != tc.ret {
+			t.Errorf("secp256k1-go2 SeckeyIsValid(%s) = %d, want %d", tc.seckey, ret, tc.ret)
+		}
+	}
+}
+
+func TestPointFormulas(t *testing.T) {
+	var g, inf, neg, r point
+	g.setAffine(&generator)
+	inf.setInfinity()
+	neg = g
+	feNeg(&neg.Y, &neg.Y)
+
+	// G + O = G
+	r.add(&g, &inf)
+	var a affinePoint
+	r.toAffine(&a)
+	if a != generator {
+		t.Fatal("G + O != G")
+	}
+
+	// O + O = O, 2O = O
+	r.add(&inf, &inf)
+	if r.isInfinity() != 1 {
+		t.Fatal("O + O != O")
+	}
+	r.double(&inf)
+	if r.isInfinity() != 1 {
+		t.Fatal("2O != O")
+	}
+
+	// G + -G = O
+	r.add(&g, &neg)
+	if r.isInfinity() != 1 {
+		t.Fatal("G + -G != O")
+	}
+
+	// G + G = 2G
+	var d point
+	var a2 affinePoint
+	r.add(&g, &g)
+	d.double(&g)
+	r.toAffine(&a)
+	d.toAffine(&a2)
+	if a != a2 || a.isOnCurve() != 1 {
+		t.Fatal("G + G != 2G")
+	}
+
+	// n * G = O
+	var n scalar
+	copy(n[:], scN[:])
+	r.scalarMult(&g, &n)
+	if r.isInfinity() != 1 {
+		t.Fatal("n * G != O")
+	}
+}
+
+func TestGeneratePublicKey(t *testing.T) {
+	one := make([]byte, 32)
+	one[31] = 1
+	if !bytes.Equal(GeneratePublicKey(one), secp.GeneratePublicKey(one)) {
+		t.Fatal("public key of 1 is not G")
+	}
+
+	for i := 0; i < 50; i++ {
+		seckey := randSeckey(t)
+		pubkey := GeneratePublicKey(seckey)
+		if !bytes.Equal(pubkey, secp.GeneratePublicKey(seckey)) {
+			t.Fatalf("public key mismatch for seckey %x", seckey)
+		}
+		if PubkeyIsValid(pubkey) != 1 {
+			t.Fatal("generated public key is invalid")
+		}
+
+		var xy secp.XY
+		if err := xy.ParsePubkey(pubkey); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(UncompressPubkey(pubkey), xy.BytesUncompressed()) {
+			t.Fatal("uncompressed public key mismatch")
+		}
+	}
+
+	if !panics(func() { GeneratePublicKey(make([]byte, 32)) }) {
+		t.Fatal("GeneratePublicKey should panic for an invalid seckey")
+	}
+	if !panics(func() { GeneratePublicKey(make([]byte, 31)) }) {
+		t.Fatal("GeneratePublicKey should panic for a short seckey")
+	}
+}
+
+func TestPubkeyIsValid(t *testing.T) {
+	pubkey := GeneratePublicKey(randSeckey(t))
+
+	// Bad prefix
+	bad := append([]byte{}, pubkey...)
+	bad[0] = 0x04
+	if PubkeyIsValid(bad) == 1 || UncompressPubkey(bad) != nil {
+		t.Fatal("prefix 0x04 is invalid")
+	}
+
+	// x >= p
+	bad = append([]byte{0x02}, bytes.Repeat([]byte{0xFF}, 32)...)
+	if PubkeyIsValid(bad) == 1 {
+		t.Fatal("x >= p is invalid")
+	}
+
+	// x = 5 is not on the curve, since 5^3 + 7 is not a square
+	bad = make([]byte, 33)
+	bad[0] = 0x02
+	bad[32] = 5
+	if PubkeyIsValid(bad) == 1 || secp.PubkeyIsValid(bad) == 1 {
+		t.Fatal("x = 5 is not on the curve")
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		pubkey := GeneratePublicKey(randSeckey(t))
+		k := randSeckey(t)
+		if !bytes.Equal(Multiply(pubkey, k), secp.Multiply(pubkey, k)) {
+			t.Fatalf("multiply mismatch for %x * %x", k, pubkey)
+		}
+	}
+
+	// ECDH
+	s1 := randSeckey(t)
+	s2 := randSeckey(t)
+	if !bytes.Equal(Multiply(GeneratePublicKey(s1), s2), Multiply(GeneratePublicKey(s2), s1)) {
+		t.Fatal("ECDH mismatch")
+	}
+
+	// Zero gives the point at infinity
+	if Multiply(GeneratePublicKey(s1), make([]byte, 32)) != nil {
+		t.Fatal("0 * P is the point at infinity")
+	}
+}
+
+func TestSign(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		seckey := randSeckey(t)
+		nonce := randSeckey(t)
+		msg := randSeckey(t)
+
+		sig, recid, ret := Sign(seckey, msg, nonce)
+		if ret != 1 {
+			t.Fatal("Sign failed")
+		}
+
+		// Signatures match secp256k1-go2 with the same nonce
+		var sig2 secp.Signature
+		var seckeyN, msgN, nonceN secp.Number
+		seckeyN.SetBytes(seckey)
+		msgN.SetBytes(msg)
+		nonceN.SetBytes(nonce)
+		var recid2 int
+		if sig2.Sign(&seckeyN, &msgN, &nonceN, &recid2) != 1 {
+			t.Fatal("secp256k1-go2 Sign failed")
+		}
+		if !bytes.Equal(sig, sig2.Bytes()) || recid != recid2 {
+			t.Fatalf("signature mismatch: %x %d, %x %d", sig, recid, sig2.Bytes(), recid2)
+		}
+
+		// S is low
+		if sig[32]>>7 != 0 {
+			t.Fatal("S is high")
+		}
+
+		pubkey := GeneratePublicKey(seckey)
+		recovered, ret := RecoverPublicKey(sig, msg, recid)
+		if ret != 1 || !bytes.Equal(recovered, pubkey) {
+			t.Fatal("recovered public key mismatch")
+		}
+		recovered2, ret2 := secp.RecoverPublicKey(sig, msg, recid)
+		if ret2 != 1 || !bytes.Equal(recovered2, pubkey) {
+			t.Fatal("secp256k1-go2 recovered public key mismatch")
+		}
+
+		// A different recovery id gives a different public key, or fails
+		recovered, ret = RecoverPublicKey(sig, msg, recid^1)
+		if ret == 1 && bytes.Equal(recovered, pubkey) {
+			t.Fatal("recovered the public key with the wrong recovery id")
+		}
+		recovered2, ret2 = secp.RecoverPublicKey(sig, msg, recid^1)
+		if ret != ret2 && !(ret < 0 && ret2 < 0) || !bytes.Equal(recovered, recovered2) {
+			t.Fatalf("recovery with the wrong recovery id mismatch: %d %d", ret, ret2)
+		}
+	}
+
+	// Invalid nonce
+	if _, _, ret := Sign(randSeckey(t), randSeckey(t), make([]byte, 32)); ret != 0 {
+		t.Fatal("signing with a zero nonce should fail")
+	}
+}
+
+func TestRecoverPublicKeyErrors(t *testing.T) {
+	seckey := randSeckey(t)
+	msg := randSeckey(t)
+	sig, recid, _ := Sign(seckey, msg, randSeckey(t))
+
+	// r = 0
+	bad := append([]byte{}, sig...)
+	copy(bad[0:32], make([]byte, 32))
+	if _, ret := RecoverPublicKey(bad, msg, recid); ret != -1 {
+		t.Fatalf("r = 0, ret=%d", ret)
+	}
+
+	// r >= n
+	bad = append([]byte{}, sig...)
+	copy(bad[0:32], bytes.Repeat([]byte{0xFF}, 32))
+	if _, ret := RecoverPublicKey(bad, msg, recid); ret != -3 {
+		t.Fatalf("r >= n, ret=%d", ret)
+	}
+
+	// s = 0
+	bad = append([]byte{}, sig...)
+	copy(bad[32:64], make([]byte, 32))
+	if _, ret := RecoverPublicKey(bad, msg, recid); ret != -5 {
+		t.Fatalf("s = 0, ret=%d", ret)
+	}
+
+	// r + n >= p
+	if _, ret := RecoverPublicKey(sig, msg, recid|2); ret != -6 {
+		t.Fatalf("r + n >= p, ret=%d", ret)
+	}
+}
+
+func panics(f func()) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = true
+		}
+	}()
+	f()
+	return false
+}
+
+func BenchmarkSign(b *testing.B) {
+	seckey := bytes.Repeat([]byte{0x11}, 32)
+	msg := bytes.Repeat([]byte{0x22}, 32)
+	nonce := bytes.Repeat([]byte{0x33}, 32)
+	for i := 0; i < b.N; i++ {
+		Sign(seckey, msg, nonce)
+	}
+}
+
+func BenchmarkGeneratePublicKey(b *testing.B) {
+	seckey := bytes.Repeat([]byte{0x11}, 32)
+	for i := 0; i < b.N; i++ {
+		GeneratePublicKey(seckey)
+	}
+}
+
+func BenchmarkRecoverPublicKey(b *testing.B) {
+	seckey := bytes.Repeat([]byte{0x11}, 32)
+	msg := bytes.Repeat([]byte{0x22}, 32)
+	sig, recid, _ := Sign(seckey, msg, bytes.Repeat([]byte{0x33}, 32))
+	for i := 0; i < b.N; i++ {
+		RecoverPublicKey(sig, msg, recid)
+	}
+}
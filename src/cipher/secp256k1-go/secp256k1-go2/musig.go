@@ -0,0 +1,462 @@
+package secp256k1go
+
+import (
+	"bytes"
+	"errors"
+	"log"
+)
+
+/*
+MuSig2 multi-signatures, following BIP327:
+https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki
+
+n signers aggregate their public keys into a single public key Q,
+then produce a single Schnorr signature for Q in two rounds:
+
+1. Each signer generates a secret nonce and shares its public nonce.
+   The public nonces are aggregated.
+2. Each signer makes a partial signature with its secret key and secret nonce.
+   The partial signatures are aggregated into the final signature.
+
+Key aggregation follows BIP327, with 33 byte compressed public keys.
+The final signature is a SchnorrSignCommit signature, whose challenge hash commits
+to keyCommit in place of the x-only aggregated public key, and which is not negated
+when Q has an odd y coordinate. SchnorrRecoverCommit recovers Q from it.
+
+A secret nonce must never be used for more than one partial signature,
+otherwise the secret key can be computed from the partial signatures.
+*/
+
+const (
+	muSigKeyAggListTag  = "KeyAgg list"
+	muSigKeyAggCoefTag  = "KeyAgg coefficient"
+	muSigNonceTag       = "MuSig/nonce"
+	muSigNonceCoefTag   = "MuSig/noncecoef"
+	muSigPubNonceLength = 66
+	muSigSecNonceLength = 64
+)
+
+var (
+	// ErrMuSigNoPubkeys no public keys to aggregate
+	ErrMuSigNoPubkeys = errors.New("no public keys to aggregate")
+	// ErrMuSigInvalidPubkey a public key is invalid
+	ErrMuSigInvalidPubkey = errors.New("invalid public key")
+	// ErrMuSigInvalidNonce a nonce is invalid
+	ErrMuSigInvalidNonce = errors.New("invalid nonce")
+	// ErrMuSigInvalidSeckey the secret key is invalid
+	ErrMuSigInvalidSeckey = errors.New("invalid secret key")
+	// ErrMuSigSignerNotFound the public key of the secret key is not one of the aggregated public keys
+	ErrMuSigSignerNotFound = errors.New("public key of the secret key is not one of the aggregated public keys")
+	// ErrMuSigInvalidPartialSig a partial signature is invalid
+	ErrMuSigInvalidPartialSig = errors.New("invalid partial signature")
+	// ErrMuSigInfinity the aggregated public key is the point at infinity
+	ErrMuSigInfinity = errors.New("aggregated public key is the point at infinity")
+	// ErrMuSigZeroChallenge the challenge hash is zero, the nonces must be generated again
+	ErrMuSigZeroChallenge = errors.New("challenge is zero")
+)
+
+// muSigKeyAggCtx is the result of the key aggregation
+type muSigKeyAggCtx struct {
+	q     XY
+	coefs map[string]Number
+}
+
+// parsePubkey parses a 33 byte compressed public key, returns false if it is invalid
+func parsePubkey(pubkey []byte) (XY, bool) {
+	var p XY
+	if len(pubkey) != 33 {
+		return p, false
+	}
+	if err := p.ParsePubkey(pubkey); err != nil {
+		return p, false
+	}
+	return p, p.IsValid()
+}
+
+// pointMultiply returns k*P in jacobian coordinates
+func pointMultiply(p *XY, k *Number) XYZ {
+	var pj, r XYZ
+	var zero Number
+	pj.SetXY(p)
+	pj.ECmult(&r, k, &zero)
+	return r
+}
+
+func muSigKeyAgg(pubkeys [][]byte) (*muSigKeyAggCtx, error) {
+	if len(pubkeys) == 0 {
+		return nil, ErrMuSigNoPubkeys
+	}
+
+	points := make([]XY, len(pubkeys))
+	for i, pk := range pubkeys {
+		p, ok := parsePubkey(pk)
+		if !ok {
+			return nil, ErrMuSigInvalidPubkey
+		}
+		points[i] = p
+	}
+
+	l := TaggedHash(muSigKeyAggListTag, pubkeys...)
+
+	// The second distinct public key has the coefficient 1
+	var second []byte
+	for _, pk := range pubkeys[1:] {
+		if !bytes.Equal(pk, pubkeys[0]) {
+			second = pk
+			break
+		}
+	}
+
+	ctx := &muSigKeyAggCtx{
+		coefs: make(map[string]Number, len(pubkeys)),
+	}
+
+	var q XYZ
+	q.Infinity = true
+	for i, pk := range pubkeys {
+		var a Number
+		if second != nil && bytes.Equal(pk, second) {
+			a.SetInt64(1)
+		} else {
+			a = hashToScalar(TaggedHash(muSigKeyAggCoefTag, l, pk))
+		}
+		ctx.coefs[string(pk)] = a
+
+		ap := pointMultiply(&points[i], &a)
+		q.Add(&q, &ap)
+	}
+
+	if q.IsInfinity() {
+		return nil, ErrMuSigInfinity
+	}
+
+	ctx.q.SetXYZ(&q)
+	return ctx, nil
+}
+
+// MuSigKeyAgg aggregates 33 byte compressed public keys into a 33 byte compressed public key.
+// The order of the public keys changes the aggregated public key.
+func MuSigKeyAgg(pubkeys [][]byte) ([]byte, error) {
+	ctx, err := muSigKeyAgg(pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.q.Bytes(), nil
+}
+
+// MuSigNonceGen derives a 64 byte secret nonce and its 66 byte public nonce from 32 random bytes.
+// The random bytes must be generated by a cryptographically secure random number generator,
+// and must never be reused.
+func MuSigNonceGen(rand []byte) ([]byte, []byte) {
+	if len(rand) != 32 {
+		log.Panic("MuSigNonceGen requires 32 random bytes")
+	}
+
+	secnonce := make([]byte, 0, muSigSecNonceLength)
+	pubnonce := make([]byte, 0, muSigPubNonceLength)
+	for i := byte(0); i < 2; i++ {
+		k := hashToScalar(TaggedHash(muSigNonceTag, rand, []byte{i}))
+		if k.Sign() == 0 {
+			log.Panic("MuSigNonceGen derived a zero nonce")
+		}
+
+		r := baseMultiply(&k)
+		secnonce = append(secnonce, scalarBytes(&k)...)
+		pubnonce = append(pubnonce, r.Bytes()...)
+	}
+
+	return secnonce, pubnonce
+}
+
+// parseNoncePoint parses a 33 byte compressed point, where 33 zero bytes are the point at infinity
+func parseNoncePoint(b []byte, allowInfinity bool) (XY, bool) {
+	if allowInfinity && bytes.Equal(b, make([]byte, 33)) {
+		return XY{Infinity: true}, true
+	}
+	return parsePubkey(b)
+}
+
+// noncePointBytes serializes a point as 33 bytes, with 33 zero bytes for the point at infinity
+func noncePointBytes(p *XY) []byte {
+	if p.Infinity {
+		return make([]byte, 33)
+	}
+	return p.Bytes()
+}
+
+// MuSigNonceAgg aggregates the 66 byte public nonces of the signers into a 66 byte aggregated nonce
+func MuSigNonceAgg(pubnonces [][]byte) ([]byte, error) {
+	if len(pubnonces) == 0 {
+		return nil, ErrMuSigInvalidNonce
+	}
+
+	aggnonce := make([]byte, 0, muSigPubNonceLength)
+	for j := 0; j < 2; j++ {
+		var r XYZ
+		r.Infinity = true
+		for _, n := range pubnonces {
+			if len(n) != muSigPubNonceLength {
+				return nil, ErrMuSigInvalidNonce
+			}
+
+			p, ok := parseNoncePoint(n[j*33:(j+1)*33], false)
+			if !ok {
+				return nil, ErrMuSigInvalidNonce
+			}
+			r.AddXY(&r, &p)
+		}
+
+		var rxy XY
+		if r.IsInfinity() {
+			rxy.Infinity = true
+		} else {
+			rxy.SetXYZ(&r)
+		}
+		aggnonce = append(aggnonce, noncePointBytes(&rxy)...)
+	}
+
+	return aggnonce, nil
+}
+
+// muSigSession holds the values shared by the signers for a message
+type muSigSession struct {
+	keyAgg *muSigKeyAggCtx
+	// b is the nonce coefficient
+	b Number
+	// e is the challenge
+	e Number
+	// r is the final nonce, with an even y coordinate
+	r XY
+	// negNonce is true if the nonces are negated, because R1 + b*R2 has an odd y coordinate
+	negNonce bool
+}
+
+func newMuSigSession(pubkeys [][]byte, aggnonce, msg, keyCommit []byte) (*muSigSession, error) {
+	if len(msg) != 32 {
+		log.Panic("MuSig requires a 32 byte message")
+	}
+
+	keyAgg, err := muSigKeyAgg(pubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(aggnonce) != muSigPubNonceLength {
+		return nil, ErrMuSigInvalidNonce
+	}
+
+	r1, ok := parseNoncePoint(aggnonce[:33], true)
+	if !ok {
+		return nil, ErrMuSigInvalidNonce
+	}
+	r2, ok := parseNoncePoint(aggnonce[33:], true)
+	if !ok {
+		return nil, ErrMuSigInvalidNonce
+	}
+
+	s := &muSigSession{
+		keyAgg: keyAgg,
+		b:      hashToScalar(TaggedHash(muSigNonceCoefTag, aggnonce, keyAgg.q.Bytes(), msg)),
+	}
+
+	// R = R1 + b*R2
+	var r XYZ
+	r.Infinity = true
+	if !r2.Infinity {
+		r = pointMultiply(&r2, &s.b)
+	}
+	r.AddXY(&r, &r1)
+
+	if r.IsInfinity() {
+		s.r = TheCurve.G
+	} else {
+		s.r.SetXYZ(&r)
+	}
+
+	if !s.r.hasEvenY() {
+		s.negNonce = true
+		s.r.Neg(&s.r)
+	}
+
+	s.e = hashToScalar(schnorrCommitChallenge(s.r.XBytes(), keyCommit, msg))
+	if s.e.Sign() == 0 {
+		return nil, ErrMuSigZeroChallenge
+	}
+
+	return s, nil
+}
+
+// MuSigPartialSign makes the 32 byte partial signature of a signer, with its secret nonce and secret key.
+// pubkeys are the public keys of all the signers, in the order used for the key aggregation.
+// The secret nonce is zeroed so that it can't be reused.
+func MuSigPartialSign(secnonce, seckey []byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) ([]byte, error) {
+	if len(secnonce) != muSigSecNonceLength {
+		return nil, ErrMuSigInvalidNonce
+	}
+
+	var k1, k2 Number
+	k1.SetBytes(secnonce[:32])
+	k2.SetBytes(secnonce[32:])
+
+	// Zero the secret nonce before anything else, so that it can't be reused even if signing fails
+	for i := range secnonce {
+		secnonce[i] = 0
+	}
+
+	if k1.Sign() == 0 || k1.Cmp(&TheCurve.Order.Int) >= 0 || k2.Sign() == 0 || k2.Cmp(&TheCurve.Order.Int) >= 0 {
+		return nil, ErrMuSigInvalidNonce
+	}
+
+	d, ok := parseSeckey(seckey)
+	if !ok {
+		return nil, ErrMuSigInvalidSeckey
+	}
+
+	s, err := newMuSigSession(pubkeys, aggnonce, msg, keyCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	p := baseMultiply(&d)
+	a, ok := s.keyAgg.coefs[string(p.Bytes())]
+	if !ok {
+		return nil, ErrMuSigSignerNotFound
+	}
+
+	if s.negNonce {
+		k1.Sub(&TheCurve.Order.Int, &k1.Int)
+		k2.Sub(&TheCurve.Order.Int, &k2.Int)
+	}
+
+	// s = k1 + b*k2 + e*a*d
+	var sig, t Number
+	sig.modMul(&s.b, &k2, &TheCurve.Order)
+	sig.Add(&sig.Int, &k1.Int)
+	t.modMul(&s.e, &a, &TheCurve.Order)
+	t.modMul(&t, &d, &TheCurve.Order)
+	sig.Add(&sig.Int, &t.Int)
+	sig.mod(&TheCurve.Order)
+
+	return scalarBytes(&sig), nil
+}
+
+// MuSigSignCoefs returns the values of the partial signature of the signer of pubkey which only depend on
+// public data: the 32 byte nonce coefficient b, the 32 byte product ea of the challenge and the key aggregation
+// coefficient of the signer, and negNonce. The partial signature is s = k1 + b*k2 + ea*d,
+// where the secret nonces k1 and k2 are negated if negNonce is true.
+func MuSigSignCoefs(pubkey []byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) ([]byte, []byte, bool, error) {
+	s, err := newMuSigSession(pubkeys, aggnonce, msg, keyCommit)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	a, ok := s.keyAgg.coefs[string(pubkey)]
+	if !ok {
+		return nil, nil, false, ErrMuSigSignerNotFound
+	}
+
+	var ea Number
+	ea.modMul(&s.e, &a, &TheCurve.Order)
+
+	return scalarBytes(&s.b), scalarBytes(&ea), s.negNonce, nil
+}
+
+// MuSigPartialVerify verifies the partial signature of the signer with the public key and the public nonce
+func MuSigPartialVerify(psig, pubnonce, pubkey []byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) error {
+	if len(psig) != 32 {
+		return ErrMuSigInvalidPartialSig
+	}
+
+	var sig Number
+	sig.SetBytes(psig)
+	if sig.Cmp(&TheCurve.Order.Int) >= 0 {
+		return ErrMuSigInvalidPartialSig
+	}
+
+	if len(pubnonce) != muSigPubNonceLength {
+		return ErrMuSigInvalidNonce
+	}
+	r1, ok := parseNoncePoint(pubnonce[:33], false)
+	if !ok {
+		return ErrMuSigInvalidNonce
+	}
+	r2, ok := parseNoncePoint(pubnonce[33:], false)
+	if !ok {
+		return ErrMuSigInvalidNonce
+	}
+
+	p, ok := parsePubkey(pubkey)
+	if !ok {
+		return ErrMuSigInvalidPubkey
+	}
+
+	s, err := newMuSigSession(pubkeys, aggnonce, msg, keyCommit)
+	if err != nil {
+		return err
+	}
+
+	a, ok := s.keyAgg.coefs[string(pubkey)]
+	if !ok {
+		return ErrMuSigSignerNotFound
+	}
+
+	// Re = R1 + b*R2, negated with the final nonce
+	re := pointMultiply(&r2, &s.b)
+	re.AddXY(&re, &r1)
+	if s.negNonce {
+		re.Neg(&re)
+	}
+
+	// sig*G - e*a*P must equal Re
+	var ea, negEA Number
+	ea.modMul(&s.e, &a, &TheCurve.Order)
+	negEA.Sub(&TheCurve.Order.Int, &ea.Int)
+	negEA.mod(&TheCurve.Order)
+
+	var pj, x XYZ
+	pj.SetXY(&p)
+	pj.ECmult(&x, &negEA, &sig)
+
+	if x.IsInfinity() || re.IsInfinity() {
+		if x.IsInfinity() != re.IsInfinity() {
+			return ErrMuSigInvalidPartialSig
+		}
+		return nil
+	}
+
+	var xxy, rexy XY
+	xxy.SetXYZ(&x)
+	rexy.SetXYZ(&re)
+	if !bytes.Equal(xxy.Bytes(), rexy.Bytes()) {
+		return ErrMuSigInvalidPartialSig
+	}
+
+	return nil
+}
+
+// MuSigPartialSigAgg aggregates the partial signatures of all the signers into a 64 byte signature,
+// which can be verified with SchnorrRecoverCommit.
+func MuSigPartialSigAgg(psigs [][]byte, pubkeys [][]byte, aggnonce, msg, keyCommit []byte) ([]byte, error) {
+	s, err := newMuSigSession(pubkeys, aggnonce, msg, keyCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	var sig Number
+	for _, psig := range psigs {
+		if len(psig) != 32 {
+			return nil, ErrMuSigInvalidPartialSig
+		}
+
+		var si Number
+		si.SetBytes(psig)
+		if si.Cmp(&TheCurve.Order.Int) >= 0 {
+			return nil, ErrMuSigInvalidPartialSig
+		}
+
+		sig.Add(&sig.Int, &si.Int)
+		sig.mod(&TheCurve.Order)
+	}
+
+	return append(s.r.XBytes(), scalarBytes(&sig)...), nil
+}
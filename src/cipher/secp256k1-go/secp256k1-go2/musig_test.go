@@ -0,0 +1,182 @@
+package secp256k1go
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestMuSigKeyAggBIP327Vectors(t *testing.T) {
+	// Test vectors from https://github.com/bitcoin/bips/blob/master/bip-0327/vectors/key_agg_vectors.json
+	x := []string{
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66",
+	}
+
+	cases := []struct {
+		indices  []int
+		expected string
+	}{
+		{
+			indices:  []int{0, 1, 2},
+			expected: "90539EEDE565F5D054F32CC0C220126889ED1E5D193BAF15AEF344FE59D4610C",
+		},
+		{
+			indices:  []int{2, 1, 0},
+			expected: "6204DE8B083426DC6EAF9502D27024D53FC826BF7D2012148A0575435DF54B2B",
+		},
+		{
+			indices:  []int{0, 0, 0},
+			expected: "B436E3BAD62B8CD409969A224731C193D051162D8C5AE8B109306127DA3AA935",
+		},
+		{
+			indices:  []int{0, 0, 1, 1},
+			expected: "69BC22BFA5D106306E48A20679DE1D7389386124D07571D0D872686028C26A3E",
+		},
+	}
+
+	for _, tc := range cases {
+		pubkeys := make([][]byte, len(tc.indices))
+		for i, j := range tc.indices {
+			pubkeys[i] = mustDecodeHex(t, x[j])
+		}
+
+		q, err := MuSigKeyAgg(pubkeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.EqualFold(tc.expected, hex.EncodeToString(q[1:])) {
+			t.Errorf("key aggregation of %v: got %X, expected %s", tc.indices, q[1:], tc.expected)
+		}
+	}
+}
+
+func testSeckey(i int) []byte {
+	h := sha256.Sum256([]byte{byte(i)})
+	return h[:]
+}
+
+func TestMuSigSign(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5} {
+		seckeys := make([][]byte, n)
+		pubkeys := make([][]byte, n)
+		for i := range seckeys {
+			seckeys[i] = testSeckey(i)
+			pubkeys[i] = GeneratePublicKey(seckeys[i])
+		}
+
+		q, err := MuSigKeyAgg(pubkeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg := sha256.Sum256([]byte("musig"))
+		commit := []byte("address of q")
+
+		// Round 1: nonces
+		secnonces := make([][]byte, n)
+		pubnonces := make([][]byte, n)
+		for i := range secnonces {
+			rand := sha256.Sum256([]byte{byte(n), byte(i), 'r'})
+			secnonces[i], pubnonces[i] = MuSigNonceGen(rand[:])
+		}
+
+		aggnonce, err := MuSigNonceAgg(pubnonces)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Round 2: partial signatures
+		psigs := make([][]byte, n)
+		for i := range psigs {
+			psigs[i], err = MuSigPartialSign(secnonces[i], seckeys[i], pubkeys, aggnonce, msg[:], commit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(secnonces[i], make([]byte, 64)) {
+				t.Error("MuSigPartialSign should zero the secret nonce")
+			}
+
+			if err := MuSigPartialVerify(psigs[i], pubnonces[i], pubkeys[i], pubkeys, aggnonce, msg[:], commit); err != nil {
+				t.Errorf("n=%d signer %d: MuSigPartialVerify failed: %v", n, i, err)
+			}
+
+			// The partial signature is not valid for another signer
+			if n > 1 {
+				j := (i + 1) % n
+				if err := MuSigPartialVerify(psigs[i], pubnonces[j], pubkeys[j], pubkeys, aggnonce, msg[:], commit); err != ErrMuSigInvalidPartialSig {
+					t.Errorf("n=%d signer %d: MuSigPartialVerify should fail for another signer, got %v", n, i, err)
+				}
+			}
+		}
+
+		sig, err := MuSigPartialSigAgg(psigs, pubkeys, aggnonce, msg[:], commit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rec := SchnorrRecoverCommit(msg[:], sig, commit); !bytes.Equal(rec, q) {
+			t.Errorf("n=%d: recovered pubkey %x does not match the aggregated pubkey %x", n, rec, q)
+		}
+
+		// A missing partial signature makes the signature invalid
+		if n > 1 {
+			sig, err := MuSigPartialSigAgg(psigs[1:], pubkeys, aggnonce, msg[:], commit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rec := SchnorrRecoverCommit(msg[:], sig, commit); bytes.Equal(rec, q) {
+				t.Errorf("n=%d: signature without all the partial signatures should be invalid", n)
+			}
+		}
+
+		// The secret nonce can't be reused
+		if _, err := MuSigPartialSign(secnonces[0], seckeys[0], pubkeys, aggnonce, msg[:], commit); err != ErrMuSigInvalidNonce {
+			t.Errorf("n=%d: reusing a secret nonce should fail, got %v", n, err)
+		}
+	}
+}
+
+func TestMuSigErrors(t *testing.T) {
+	seckey := testSeckey(1)
+	pubkey := GeneratePublicKey(seckey)
+	other := GeneratePublicKey(testSeckey(2))
+	msg := make([]byte, 32)
+
+	if _, err := MuSigKeyAgg(nil); err != ErrMuSigNoPubkeys {
+		t.Errorf("expected ErrMuSigNoPubkeys, got %v", err)
+	}
+
+	badPubkey := append([]byte{}, pubkey...)
+	badPubkey[0] = 0x05
+	if _, err := MuSigKeyAgg([][]byte{pubkey, badPubkey}); err != ErrMuSigInvalidPubkey {
+		t.Errorf("expected ErrMuSigInvalidPubkey, got %v", err)
+	}
+
+	if _, err := MuSigNonceAgg([][]byte{make([]byte, 65)}); err != ErrMuSigInvalidNonce {
+		t.Errorf("expected ErrMuSigInvalidNonce, got %v", err)
+	}
+
+	rand := sha256.Sum256([]byte("rand"))
+	secnonce, pubnonce := MuSigNonceGen(rand[:])
+	aggnonce, err := MuSigNonceAgg([][]byte{pubnonce})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The signer is not one of the aggregated public keys
+	if _, err := MuSigPartialSign(secnonce, seckey, [][]byte{other}, aggnonce, msg, nil); err != ErrMuSigSignerNotFound {
+		t.Errorf("expected ErrMuSigSignerNotFound, got %v", err)
+	}
+
+	// Invalid partial signature
+	if _, err := MuSigPartialSigAgg([][]byte{TheCurve.Order.Bytes()}, [][]byte{pubkey}, aggnonce, msg, nil); err != ErrMuSigInvalidPartialSig {
+		t.Errorf("expected ErrMuSigInvalidPartialSig, got %v", err)
+	}
+}
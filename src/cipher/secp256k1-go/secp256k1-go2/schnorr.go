@@ -0,0 +1,346 @@
+package secp256k1go
+
+import (
+	"crypto/sha256"
+	"log"
+)
+
+/*
+Schnorr signatures, as specified by BIP340:
+https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+
+Two variants are implemented:
+
+* SchnorrSign and SchnorrVerify follow BIP340 exactly, with 32 byte x-only public keys.
+
+* SchnorrSignCommit and SchnorrRecoverCommit replace the public key in the challenge hash
+  with a commitment to it, such as its address. The secret key is not negated, and the
+  public key can be recovered from the signature when the commitment is known,
+  like the public key of a recoverable ECDSA signature.
+
+Both variants produce 64 byte R.x||s signatures, where R has an even y coordinate.
+*/
+
+const (
+	bip340AuxTag       = "BIP0340/aux"
+	bip340NonceTag     = "BIP0340/nonce"
+	bip340ChallengeTag = "BIP0340/challenge"
+
+	commitNonceTag     = "SkycoinSchnorr/nonce"
+	commitChallengeTag = "SkycoinSchnorr/challenge"
+)
+
+// TaggedHash returns SHA256(SHA256(tag) || SHA256(tag) || msgs...), as defined in BIP340
+func TaggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	h := sha256.New()
+	h.Write(tagHash[:]) //nolint:errcheck
+	h.Write(tagHash[:]) //nolint:errcheck
+	for _, m := range msgs {
+		h.Write(m) //nolint:errcheck
+	}
+
+	return h.Sum(nil)
+}
+
+// LiftX sets the point with the x coordinate and an even y coordinate.
+// Returns false if x is not lower than the field order, or is not the x coordinate of a point on the curve.
+func (xy *XY) LiftX(x []byte) bool {
+	if len(x) != 32 {
+		log.Panic("LiftX requires 32 bytes")
+	}
+
+	var n Number
+	n.SetBytes(x)
+	if n.Cmp(&TheCurve.p.Int) >= 0 {
+		return false
+	}
+
+	var fx Field
+	fx.SetB32(x)
+	xy.SetXO(&fx, false)
+	return xy.IsValid()
+}
+
+// XBytes returns the 32 byte x coordinate of the point
+func (xy *XY) XBytes() []byte {
+	xy.X.Normalize()
+	var b [32]byte
+	xy.X.GetB32(b[:])
+	return b[:]
+}
+
+// hasEvenY returns true if the y coordinate of the point is even
+func (xy *XY) hasEvenY() bool {
+	xy.Y.Normalize()
+	return !xy.Y.IsOdd()
+}
+
+// scalarBytes returns a scalar as 32 big-endian bytes
+func scalarBytes(n *Number) []byte {
+	return LeftPadBytes(n.Bytes(), 32)
+}
+
+// hashToScalar returns the hash as a scalar modulo the curve order
+func hashToScalar(h []byte) Number {
+	var n Number
+	n.SetBytes(h)
+	n.mod(&TheCurve.Order)
+	return n
+}
+
+// parseSeckey parses a 32 byte secret key. Returns false if it is zero or not lower than the curve order.
+func parseSeckey(seckey []byte) (Number, bool) {
+	if len(seckey) != 32 {
+		log.Panic("secret key length must be 32 bytes")
+	}
+
+	var d Number
+	d.SetBytes(seckey)
+	if d.Sign() <= 0 || d.Cmp(&TheCurve.Order.Int) >= 0 {
+		return Number{}, false
+	}
+
+	return d, true
+}
+
+// baseMultiply returns k*G as an affine point
+func baseMultiply(k *Number) XY {
+	var p XY
+	r := ECmultGen(*k)
+	p.SetXYZ(&r)
+	return p
+}
+
+// schnorrNonce derives the signing nonce from the masked secret key, and returns k and R=k*G,
+// where R has an even y coordinate. Returns false if the nonce is zero.
+func schnorrNonce(d *Number, auxRand []byte, nonceTag string, msgs ...[]byte) (Number, XY, bool) {
+	t := scalarBytes(d)
+	aux := TaggedHash(bip340AuxTag, auxRand)
+	for i := range t {
+		t[i] ^= aux[i]
+	}
+
+	k := hashToScalar(TaggedHash(nonceTag, append([][]byte{t}, msgs...)...))
+	if k.Sign() == 0 {
+		return Number{}, XY{}, false
+	}
+
+	r := baseMultiply(&k)
+	if !r.hasEvenY() {
+		k.Sub(&TheCurve.Order.Int, &k.Int)
+	}
+
+	return k, r, true
+}
+
+// schnorrS returns s = k + e*d mod n
+func schnorrS(k, e, d *Number) Number {
+	var s Number
+	s.modMul(e, d, &TheCurve.Order)
+	s.Add(&s.Int, &k.Int)
+	s.mod(&TheCurve.Order)
+	return s
+}
+
+// parseSchnorrSig parses a 64 byte R.x||s signature.
+// Returns false if R.x is not the x coordinate of a point on the curve or s is not lower than the curve order.
+func parseSchnorrSig(sig []byte) (XY, Number, bool) {
+	if len(sig) != 64 {
+		log.Panic("Schnorr signature length must be 64 bytes")
+	}
+
+	var r XY
+	if !r.LiftX(sig[:32]) {
+		return XY{}, Number{}, false
+	}
+
+	var s Number
+	s.SetBytes(sig[32:])
+	if s.Cmp(&TheCurve.Order.Int) >= 0 {
+		return XY{}, Number{}, false
+	}
+
+	return r, s, true
+}
+
+// SchnorrPubkey returns the 32 byte x-only public key of a secret key, as defined in BIP340.
+// Returns nil if the secret key is invalid.
+func SchnorrPubkey(seckey []byte) []byte {
+	d, ok := parseSeckey(seckey)
+	if !ok {
+		return nil
+	}
+
+	p := baseMultiply(&d)
+	return p.XBytes()
+}
+
+// SchnorrSign signs a 32 byte message with the BIP340 signing algorithm.
+// auxRand is 32 bytes of auxiliary randomness, which may be zero.
+// Returns nil if the secret key is invalid.
+func SchnorrSign(seckey, msg, auxRand []byte) []byte {
+	if len(msg) != 32 || len(auxRand) != 32 {
+		log.Panic("SchnorrSign requires a 32 byte message and 32 bytes of auxiliary randomness")
+	}
+
+	d, ok := parseSeckey(seckey)
+	if !ok {
+		return nil
+	}
+
+	p := baseMultiply(&d)
+	if !p.hasEvenY() {
+		d.Sub(&TheCurve.Order.Int, &d.Int)
+	}
+	px := p.XBytes()
+
+	k, r, ok := schnorrNonce(&d, auxRand, bip340NonceTag, px, msg)
+	if !ok {
+		return nil
+	}
+	rx := r.XBytes()
+
+	e := hashToScalar(TaggedHash(bip340ChallengeTag, rx, px, msg))
+	s := schnorrS(&k, &e, &d)
+
+	sig := append(rx, scalarBytes(&s)...)
+
+	if !SchnorrVerify(px, msg, sig) {
+		log.Panic("SchnorrSign produced an invalid signature")
+	}
+
+	return sig
+}
+
+// SchnorrVerify verifies a BIP340 signature of a 32 byte message by a 32 byte x-only public key
+func SchnorrVerify(pubkey, msg, sig []byte) bool {
+	if len(pubkey) != 32 || len(msg) != 32 || len(sig) != 64 {
+		log.Panic("SchnorrVerify requires a 32 byte pubkey, a 32 byte message and a 64 byte signature")
+	}
+
+	var p XY
+	if !p.LiftX(pubkey) {
+		return false
+	}
+
+	var r Number
+	r.SetBytes(sig[:32])
+	if r.Cmp(&TheCurve.p.Int) >= 0 {
+		return false
+	}
+
+	var s Number
+	s.SetBytes(sig[32:])
+	if s.Cmp(&TheCurve.Order.Int) >= 0 {
+		return false
+	}
+
+	e := hashToScalar(TaggedHash(bip340ChallengeTag, sig[:32], pubkey, msg))
+
+	// R = s*G - e*P
+	var negE Number
+	negE.Sub(&TheCurve.Order.Int, &e.Int)
+	negE.mod(&TheCurve.Order)
+
+	var pj, rj XYZ
+	pj.SetXY(&p)
+	pj.ECmult(&rj, &negE, &s)
+	if rj.IsInfinity() {
+		return false
+	}
+
+	var rxy XY
+	rxy.SetXYZ(&rj)
+	if !rxy.hasEvenY() {
+		return false
+	}
+
+	var rx Number
+	rx.SetBytes(rxy.XBytes())
+	return rx.Cmp(&r.Int) == 0
+}
+
+// SchnorrSignCommit signs a 32 byte message with a Schnorr signature whose challenge hash
+// commits to keyCommit in place of the public key.
+// keyCommit must be derived from the public key of seckey, for example its address.
+// auxRand is 32 bytes of auxiliary randomness, which may be zero.
+// Returns nil if the secret key is invalid.
+func SchnorrSignCommit(seckey, msg, auxRand, keyCommit []byte) []byte {
+	if len(msg) != 32 || len(auxRand) != 32 {
+		log.Panic("SchnorrSignCommit requires a 32 byte message and 32 bytes of auxiliary randomness")
+	}
+
+	d, ok := parseSeckey(seckey)
+	if !ok {
+		return nil
+	}
+
+	k, r, ok := schnorrNonce(&d, auxRand, commitNonceTag, keyCommit, msg)
+	if !ok {
+		return nil
+	}
+	rx := r.XBytes()
+
+	e := hashToScalar(schnorrCommitChallenge(rx, keyCommit, msg))
+	if e.Sign() == 0 {
+		return nil
+	}
+	s := schnorrS(&k, &e, &d)
+
+	return append(rx, scalarBytes(&s)...)
+}
+
+func schnorrCommitChallenge(rx, keyCommit, msg []byte) []byte {
+	return TaggedHash(commitChallengeTag, rx, keyCommit, msg)
+}
+
+// SchnorrRecoverCommit recovers the 33 byte compressed public key of a signature made by SchnorrSignCommit.
+// The caller must check that keyCommit matches the recovered public key.
+// Returns nil if the signature is invalid.
+func SchnorrRecoverCommit(msg, sig, keyCommit []byte) []byte {
+	if len(msg) != 32 {
+		log.Panic("SchnorrRecoverCommit requires a 32 byte message")
+	}
+
+	r, s, ok := parseSchnorrSig(sig)
+	if !ok {
+		return nil
+	}
+
+	e := hashToScalar(schnorrCommitChallenge(sig[:32], keyCommit, msg))
+	if e.Sign() == 0 {
+		return nil
+	}
+
+	// P = e^-1*(s*G - R)
+	var eInv, na, ng Number
+	eInv.modInv(&e, &TheCurve.Order)
+	ng.modMul(&eInv, &s, &TheCurve.Order)
+	na.Sub(&TheCurve.Order.Int, &eInv.Int)
+	na.mod(&TheCurve.Order)
+
+	var rj, pj XYZ
+	rj.SetXY(&r)
+	rj.ECmult(&pj, &na, &ng)
+	if pj.IsInfinity() {
+		return nil
+	}
+
+	var p XY
+	p.SetXYZ(&pj)
+	if !p.IsValid() {
+		return nil
+	}
+
+	return p.Bytes()
+}
+
+// SchnorrSigIsValid returns true if a 64 byte R.x||s signature is well formed,
+// that is R.x is the x coordinate of a point on the curve and s is lower than the curve order.
+// It does not check that the signature signed anything.
+func SchnorrSigIsValid(sig []byte) bool {
+	_, _, ok := parseSchnorrSig(sig)
+	return ok
+}
@@ -0,0 +1,209 @@
+package secp256k1go
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// Test vectors from https://github.com/bitcoin/bips/blob/master/bip-0340/test-vectors.csv
+var bip340Vectors = []struct {
+	seckey  string
+	pubkey  string
+	auxRand string
+	msg     string
+	sig     string
+	valid   bool
+}{
+	{
+		seckey:  "0000000000000000000000000000000000000000000000000000000000000003",
+		pubkey:  "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		auxRand: "0000000000000000000000000000000000000000000000000000000000000000",
+		msg:     "0000000000000000000000000000000000000000000000000000000000000000",
+		sig:     "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+		valid:   true,
+	},
+	{
+		seckey:  "B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+		pubkey:  "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		auxRand: "0000000000000000000000000000000000000000000000000000000000000001",
+		msg:     "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		sig:     "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+		valid:   true,
+	},
+	{
+		seckey:  "C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9",
+		pubkey:  "DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+		auxRand: "C87AA53824B4D7AE2EB035A2B5BBBCCC080E76CDC6D1692C4B0B62D798E6D906",
+		msg:     "7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+		sig:     "5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1BAB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+		valid:   true,
+	},
+	{
+		seckey:  "0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710",
+		pubkey:  "25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517",
+		auxRand: "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		msg:     "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		sig:     "7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3",
+		valid:   true,
+	},
+	{
+		pubkey: "D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9",
+		msg:    "4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703",
+		sig:    "00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C6376AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4",
+		valid:  true,
+	},
+	{
+		// public key not on the curve
+		pubkey: "EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+		msg:    "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		sig:    "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		valid:  false,
+	},
+	{
+		// has_even_y(R) is false
+		pubkey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		msg:    "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		sig:    "FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A14602975563CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+		valid:  false,
+	},
+	{
+		// sig[0:32] is not an X coordinate on the curve
+		pubkey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		msg:    "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		sig:    "4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		valid:  false,
+	},
+	{
+		// sig[0:32] is equal to the field size
+		pubkey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		msg:    "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		sig:    "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		valid:  false,
+	},
+	{
+		// sig[32:64] is equal to the curve order
+		pubkey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		msg:    "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		sig:    "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+		valid:  false,
+	},
+	{
+		// public key is not a valid X coordinate because it exceeds the field size
+		pubkey: "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+		msg:    "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		sig:    "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		valid:  false,
+	},
+}
+
+func TestSchnorrBIP340Vectors(t *testing.T) {
+	for i, tc := range bip340Vectors {
+		pubkey := mustDecodeHex(t, tc.pubkey)
+		msg := mustDecodeHex(t, tc.msg)
+		sig := mustDecodeHex(t, tc.sig)
+
+		if tc.seckey != "" {
+			seckey := mustDecodeHex(t, tc.seckey)
+			auxRand := mustDecodeHex(t, tc.auxRand)
+
+			if pk := SchnorrPubkey(seckey); !bytes.Equal(pk, pubkey) {
+				t.Errorf("vector %d: pubkey mismatch %x", i, pk)
+			}
+
+			if s := SchnorrSign(seckey, msg, auxRand); !bytes.Equal(s, sig) {
+				t.Errorf("vector %d: signature mismatch %X", i, s)
+			}
+		}
+
+		if SchnorrVerify(pubkey, msg, sig) != tc.valid {
+			t.Errorf("vector %d: SchnorrVerify should return %v", i, tc.valid)
+		}
+	}
+}
+
+func TestSchnorrCommitRecover(t *testing.T) {
+	seckey := mustDecodeHex(t, "B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF")
+	msg := mustDecodeHex(t, "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89")
+	auxRand := make([]byte, 32)
+	commit := []byte("address of the public key")
+
+	pubkey := GeneratePublicKey(seckey)
+
+	sig := SchnorrSignCommit(seckey, msg, auxRand, commit)
+	if len(sig) != 64 {
+		t.Fatalf("invalid signature length %d", len(sig))
+	}
+
+	if !SchnorrSigIsValid(sig) {
+		t.Error("SchnorrSigIsValid failed")
+	}
+
+	// The signature is deterministic for the same auxiliary randomness
+	if !bytes.Equal(sig, SchnorrSignCommit(seckey, msg, auxRand, commit)) {
+		t.Error("SchnorrSignCommit is not deterministic")
+	}
+
+	if rec := SchnorrRecoverCommit(msg, sig, commit); !bytes.Equal(rec, pubkey) {
+		t.Errorf("recovered pubkey %x does not match %x", rec, pubkey)
+	}
+
+	// A different commitment recovers a different public key
+	if rec := SchnorrRecoverCommit(msg, sig, []byte("another address")); bytes.Equal(rec, pubkey) {
+		t.Error("recovered pubkey should not match with a different commitment")
+	}
+
+	// A different message recovers a different public key
+	msg2 := append([]byte{}, msg...)
+	msg2[0] ^= 1
+	if rec := SchnorrRecoverCommit(msg2, sig, commit); bytes.Equal(rec, pubkey) {
+		t.Error("recovered pubkey should not match with a different message")
+	}
+
+	// s out of range
+	badSig := append([]byte{}, sig[:32]...)
+	badSig = append(badSig, TheCurve.Order.Bytes()...)
+	if SchnorrSigIsValid(badSig) {
+		t.Error("SchnorrSigIsValid should fail with s equal to the order")
+	}
+	if SchnorrRecoverCommit(msg, badSig, commit) != nil {
+		t.Error("SchnorrRecoverCommit should fail with s equal to the order")
+	}
+
+	// R.x not on the curve
+	badSig = mustDecodeHex(t, strings.Repeat("FF", 32))
+	badSig = append(badSig, sig[32:]...)
+	if SchnorrSigIsValid(badSig) {
+		t.Error("SchnorrSigIsValid should fail with R.x not on the curve")
+	}
+
+	// Invalid secret keys
+	if SchnorrSignCommit(make([]byte, 32), msg, auxRand, commit) != nil {
+		t.Error("SchnorrSignCommit should fail with a zero secret key")
+	}
+	if SchnorrSign(TheCurve.Order.Bytes(), msg, auxRand) != nil {
+		t.Error("SchnorrSign should fail with a secret key equal to the order")
+	}
+}
+
+func TestTaggedHash(t *testing.T) {
+	// The tagged hash is SHA256(SHA256(tag) || SHA256(tag) || msg)
+	h := TaggedHash("BIP0340/challenge", []byte("a"), []byte("bc"))
+	h2 := TaggedHash("BIP0340/challenge", []byte("abc"))
+	if !bytes.Equal(h, h2) {
+		t.Error("TaggedHash should hash the concatenation of the messages")
+	}
+
+	if bytes.Equal(h, TaggedHash("BIP0340/nonce", []byte("abc"))) {
+		t.Error("TaggedHash should depend on the tag")
+	}
+}
@@ -7,8 +7,6 @@ import (
 	"bytes"
 	"encoding/hex"
 	"log"
-
-	secp "github.com/skycoin/skycoin/src/cipher/secp256k1-go/secp256k1-go2"
 )
 
 // DebugPrint enable debug print statements
@@ -21,21 +19,21 @@ func pubkeyFromSeckey(seckey []byte) []byte {
 		log.Panic("seckey length invalid")
 	}
 
-	if secp.SeckeyIsValid(seckey) != 1 {
+	if seckeyIsValid(seckey) != 1 {
 		log.Panic("always ensure seckey is valid")
 		return nil
 	}
 
-	pubkey := secp.GeneratePublicKey(seckey)
+	pubkey := generatePublicKey(seckey)
 	if pubkey == nil {
-		log.Panic("ERROR: impossible, secp.GeneratePublicKey should never fail")
+		log.Panic("ERROR: impossible, generatePublicKey should never fail")
 		return nil
 	}
 	if len(pubkey) != 33 {
 		log.Panic("ERROR: impossible, invalid pubkey length")
 	}
 
-	if ret := secp.PubkeyIsValid(pubkey); ret != 1 {
+	if ret := pubkeyIsValid(pubkey); ret != 1 {
 		log.Panicf("ERROR: pubkey invald, ret=%d", ret)
 		return nil
 	}
@@ -58,7 +56,7 @@ func GenerateKeyPair() ([]byte, []byte) {
 
 new_seckey:
 	seckey = RandByte(seckeyLen)
-	if secp.SeckeyIsValid(seckey) != 1 {
+	if seckeyIsValid(seckey) != 1 {
 		goto new_seckey // regen
 	}
 
@@ -67,7 +65,7 @@ new_seckey:
 		log.Panic("IMPOSSIBLE: pubkey invalid from valid seckey")
 		goto new_seckey
 	}
-	if ret := secp.PubkeyIsValid(pubkey); ret != 1 {
+	if ret := pubkeyIsValid(pubkey); ret != 1 {
 		log.Panicf("ERROR: Pubkey invalid, ret=%d", ret)
 		goto new_seckey
 	}
@@ -90,7 +88,7 @@ func PubkeyFromSeckey(seckey []byte) []byte {
 		log.Panic("ERRROR: impossible, pubkey generation failed")
 		return nil
 	}
-	if ret := secp.PubkeyIsValid(pubkey); ret != 1 {
+	if ret := pubkeyIsValid(pubkey); ret != 1 {
 		log.Panicf("ERROR: Pubkey invalid, ret=%d", ret)
 		return nil
 	}
@@ -105,12 +103,7 @@ func UncompressPubkey(pubkey []byte) []byte {
 		return nil
 	}
 
-	var pubXY secp.XY
-	if err := pubXY.ParsePubkey(pubkey); err != nil {
-		log.Panicf("ERROR: impossible, pubkey parse fail: %v", err)
-	}
-
-	var pubkey2 = pubXY.BytesUncompressed()
+	var pubkey2 = uncompressPubkey(pubkey)
 	if pubkey2 == nil {
 		log.Panic("ERROR: pubkey, uncompression fail")
 		return nil
@@ -159,14 +152,14 @@ new_seckey:
 	seed = SumSHA256(seed)
 	copy(seckey, seed)
 
-	if secp.SeckeyIsValid(seckey) != 1 {
+	if seckeyIsValid(seckey) != 1 {
 		if DebugPrint {
 			log.Printf("deterministicKeyPairIteratorStep, secp.SeckeyIsValid fail")
 		}
 		goto new_seckey //regen
 	}
 
-	pubkey := secp.GeneratePublicKey(seckey)
+	pubkey := generatePublicKey(seckey)
 	if pubkey == nil {
 		log.Panic("ERROR: deterministicKeyPairIteratorStep: GeneratePublicKey failed, impossible, secp.BaseMultiply always returns true")
 		goto new_seckey
@@ -176,7 +169,7 @@ new_seckey:
 		log.Panic("ERROR: deterministicKeyPairIteratorStep: impossible, pubkey length wrong")
 	}
 
-	if ret := secp.PubkeyIsValid(pubkey); ret != 1 {
+	if ret := pubkeyIsValid(pubkey); ret != 1 {
 		log.Panicf("ERROR: deterministicKeyPairIteratorStep: PubkeyIsValid failed, ret=%d", ret)
 	}
 
@@ -219,19 +212,13 @@ func DeterministicKeyPairIterator(seedIn []byte) ([]byte, []byte, []byte) {
 	return seed1, pubkey, seckey
 }
 
-func newRandomNonceNumber() secp.Number {
-	nonce := RandByte(32)
-	var n secp.Number
-	n.SetBytes(nonce)
-	return n
-}
-
 // newSigningNonce creates a nonce for signing. This is the `k` parameter in
-// ECDSA signing. `k` must be 0 < k < n, where `n` is the order of the curve
-func newSigningNonce() secp.Number {
-	nonce := newRandomNonceNumber()
-	for nonce.Sign() == 0 || nonce.Cmp(&secp.TheCurve.Order.Int) >= 0 {
-		nonce = newRandomNonceNumber()
+// ECDSA signing. `k` must be 0 < k < n, where `n` is the order of the curve,
+// which is the same condition as for a valid secret key
+func newSigningNonce() []byte {
+	nonce := RandByte(32)
+	for seckeyIsValid(nonce) != 1 {
+		nonce = RandByte(32)
 	}
 	return nonce
 }
@@ -241,7 +228,7 @@ func Sign(msg []byte, seckey []byte) []byte {
 	if len(seckey) != 32 {
 		log.Panic("Sign, Invalid seckey length")
 	}
-	if secp.SeckeyIsValid(seckey) != 1 {
+	if seckeyIsValid(seckey) != 1 {
 		log.Panic("Attempting to sign with invalid seckey")
 	}
 	if len(msg) == 0 {
@@ -251,29 +238,20 @@ func Sign(msg []byte, seckey []byte) []byte {
 		log.Panic("Sign, message must be 32 bytes")
 	}
 
-	nonce := newSigningNonce()
-	sig := make([]byte, 65)
-	var recid int // recovery byte, used to recover pubkey from sig
-
-	var cSig secp.Signature
-
-	var seckey1 secp.Number
-	var msg1 secp.Number
-
-	seckey1.SetBytes(seckey)
-	msg1.SetBytes(msg)
-
-	if msg1.Sign() == 0 {
+	if bytes.Equal(msg, make([]byte, 32)) {
 		log.Panic("Sign: message is 0")
 	}
 
-	ret := cSig.Sign(&seckey1, &msg1, &nonce, &recid)
+	nonce := newSigningNonce()
+	sig := make([]byte, 65)
+
+	// recid is the recovery byte, used to recover pubkey from sig
+	sigBytes, recid, ret := sign(seckey, msg, nonce)
 
 	if ret != 1 {
 		log.Panic("Secp25k1-go, Sign, signature operation failed")
 	}
 
-	sigBytes := cSig.Bytes()
 	for i := 0; i < 64; i++ {
 		sig[i] = sigBytes[i]
 	}
@@ -297,7 +275,7 @@ func VerifySeckey(seckey []byte) int {
 	}
 
 	//does conversion internally if less than order of curve
-	if secp.SeckeyIsValid(seckey) != 1 {
+	if seckeyIsValid(seckey) != 1 {
 		return -2
 	}
 
@@ -315,7 +293,7 @@ func VerifyPubkey(pubkey []byte) int {
 		return -2
 	}
 
-	if secp.PubkeyIsValid(pubkey) != 1 {
+	if pubkeyIsValid(pubkey) != 1 {
 		return -1 // tests parse and validity
 	}
 
@@ -396,7 +374,7 @@ func RecoverPubkey(msg []byte, sig []byte) []byte {
 
 	var recid = int(sig[64])
 
-	pubkey, ret := secp.RecoverPublicKey(sig[0:64], msg, recid)
+	pubkey, ret := recoverPublicKey(sig[0:64], msg, recid)
 
 	if ret != 1 {
 		if DebugPrint {
@@ -408,8 +386,8 @@ func RecoverPubkey(msg []byte, sig []byte) []byte {
 	if pubkey == nil {
 		log.Panic("ERROR: impossible, pubkey nil and ret == 1")
 	}
-	if secp.PubkeyIsValid(pubkey) != 1 {
-		log.Panicf("secp.RecoverPublicKey returned invalid pubkey %s", hex.EncodeToString(pubkey))
+	if pubkeyIsValid(pubkey) != 1 {
+		log.Panicf("RecoverPublicKey returned invalid pubkey %s", hex.EncodeToString(pubkey))
 	}
 
 	return pubkey
@@ -439,7 +417,7 @@ func ECDH(pub, sec []byte) []byte {
 		return nil
 	}
 
-	pubkeyOut := secp.Multiply(pub, sec)
+	pubkeyOut := multiply(pub, sec)
 	if pubkeyOut == nil {
 		return nil
 	}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/skycoin/skycoin/src/api"
+	"github.com/skycoin/skycoin/src/apitoken"
+)
+
+const (
+	apiTokensFilename = "api_tokens.json"
+)
+
+func apiTokenCreateCmd() *cobra.Command {
+	apiTokenCreateCmd := &cobra.Command{
+		Short: "Create an API token",
+		Use:   "apiTokenCreate [name]",
+		Long: fmt.Sprintf(`Create a named API token, which grants access to a subset of the API sets.
+    The token is written to the API tokens file of the node, which is
+    $DATA_DIR/%s by default. The node does not need to be restarted.
+
+    The API sets are given with the --api-sets option, as a comma separated list
+    of %s.
+    If --wallets is set, the token can only use the wallet endpoints of these wallets.
+
+    Only a hash of the token is stored. The token is printed once and cannot be
+    recovered later. Requests are authenticated with the "Authorization: Bearer <token>"
+    header. The CLI uses the token in the RPC_TOKEN environment variable.`, apiTokensFilename, strings.Join(apiTokenAPISets, ", ")),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			path, err := getAPITokensFile(c)
+			if err != nil {
+				return err
+			}
+
+			opts := apitoken.CreateOptions{
+				Name: args[0],
+			}
+
+			apiSets, err := c.Flags().GetString("api-sets")
+			if err != nil {
+				return err
+			}
+			opts.APISets, err = parseAPITokenAPISets(apiSets)
+			if err != nil {
+				return err
+			}
+
+			wallets, err := c.Flags().GetString("wallets")
+			if err != nil {
+				return err
+			}
+			opts.WalletIDs = splitCommaList(wallets)
+
+			expires, err := c.Flags().GetDuration("expires")
+			if err != nil {
+				return err
+			}
+			if expires < 0 {
+				return fmt.Errorf("invalid --expires %s, must be positive", expires)
+			}
+			if expires != 0 {
+				opts.Expires = time.Now().Add(expires)
+			}
+
+			t, secret, err := apitoken.Create(path, opts)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(struct {
+				apitoken.Token
+				Secret string `json:"token"`
+			}{
+				Token:  *t,
+				Secret: secret,
+			})
+		},
+	}
+
+	apiTokenCreateCmd.Flags().StringP("api-sets", "s", "", "Comma separated API sets granted to the token")
+	apiTokenCreateCmd.Flags().StringP("wallets", "w", "", "Comma separated wallet IDs the token is restricted to. Defaults to all wallets")
+	apiTokenCreateCmd.Flags().DurationP("expires", "e", 0, "Duration after which the token expires, e.g. 720h. Defaults to no expiry")
+	apiTokenCreateCmd.Flags().StringP("file", "f", "", fmt.Sprintf("API tokens file. Defaults to $DATA_DIR/%s", apiTokensFilename))
+
+	return apiTokenCreateCmd
+}
+
+func apiTokenListCmd() *cobra.Command {
+	apiTokenListCmd := &cobra.Command{
+		Short:        "List API tokens",
+		Use:          "apiTokenList",
+		Long:         "List the API tokens of the API tokens file. The tokens themselves are not stored and cannot be listed.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, _ []string) error {
+			path, err := getAPITokensFile(c)
+			if err != nil {
+				return err
+			}
+
+			tokens, err := apitoken.Load(path)
+			if err != nil {
+				return err
+			}
+
+			if tokens == nil {
+				tokens = []apitoken.Token{}
+			}
+
+			return printJSON(struct {
+				Tokens []apitoken.Token `json:"tokens"`
+			}{
+				Tokens: tokens,
+			})
+		},
+	}
+
+	apiTokenListCmd.Flags().StringP("file", "f", "", fmt.Sprintf("API tokens file. Defaults to $DATA_DIR/%s", apiTokensFilename))
+
+	return apiTokenListCmd
+}
+
+func apiTokenRemoveCmd() *cobra.Command {
+	apiTokenRemoveCmd := &cobra.Command{
+		Short:        "Remove an API token",
+		Use:          "apiTokenRemove [name]",
+		Long:         "Remove the named API token from the API tokens file. The token is rejected by the node immediately.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			path, err := getAPITokensFile(c)
+			if err != nil {
+				return err
+			}
+
+			return apitoken.Remove(path, args[0])
+		},
+	}
+
+	apiTokenRemoveCmd.Flags().StringP("file", "f", "", fmt.Sprintf("API tokens file. Defaults to $DATA_DIR/%s", apiTokensFilename))
+
+	return apiTokenRemoveCmd
+}
+
+// apiTokenAPISets are the API sets that can be granted to an API token
+var apiTokenAPISets = []string{
+	api.EndpointsRead,
+	api.EndpointsStatus,
+	api.EndpointsTransaction,
+	api.EndpointsWallet,
+	api.EndpointsInsecureWalletSeed,
+	api.EndpointsNetCtrl,
+	api.EndpointsStorage,
+	api.EndpointsMetrics,
+	api.EndpointsWebhook,
+}
+
+func getAPITokensFile(c *cobra.Command) (string, error) {
+	path, err := c.Flags().GetString("file")
+	if err != nil {
+		return "", err
+	}
+
+	if path == "" {
+		path = filepath.Join(cliConfig.DataDir, apiTokensFilename)
+	}
+
+	return path, nil
+}
+
+func parseAPITokenAPISets(s string) ([]string, error) {
+	apiSets := splitCommaList(strings.ToUpper(s))
+	for _, k := range apiSets {
+		valid := false
+		for _, v := range apiTokenAPISets {
+			if k == v {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return nil, fmt.Errorf("invalid API set %q, options are %s", k, strings.Join(apiTokenAPISets, ", "))
+		}
+	}
+
+	return apiSets, nil
+}
+
+// splitCommaList splits a comma separated list, ignoring empty values
+func splitCommaList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -2,13 +2,13 @@ package cli
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/readable"
-	"github.com/skycoin/skycoin/src/util/droplet"
+	"github.com/skycoin/skycoin/src/util/amount"
 	"github.com/skycoin/skycoin/src/wallet"
 )
 
@@ -45,16 +45,22 @@ func walletBalanceCmd() *cobra.Command {
 }
 
 func addressBalanceCmd() *cobra.Command {
-	return &cobra.Command{
+	addressBalanceCmd := &cobra.Command{
 		Short: "Check the balance of specific addresses",
 		Use:   "addressBalance [addresses]",
 		Long: `Check balance of specific addresses, join multiple addresses with space.
-    example: addressBalance "$addr1 $addr2 $addr3"`,
+    example: addressBalance "$addr1 $addr2 $addr3"
+
+    Use the "--seq" option to get the confirmed balance at a past block.`,
 		Args:                  cobra.MinimumNArgs(1),
 		DisableFlagsInUseLine: true,
 		SilenceUsage:          true,
 		RunE:                  addrBalance,
 	}
+
+	addressBalanceCmd.Flags().Uint64("seq", 0, "Block seq of the balance, defaults to the head block")
+
+	return addressBalanceCmd
 }
 
 func checkWltBalance(c *cobra.Command, args []string) error {
@@ -72,17 +78,31 @@ func checkWltBalance(c *cobra.Command, args []string) error {
 	return printJSON(balRlt)
 }
 
-func addrBalance(_ *cobra.Command, args []string) error {
+func addrBalance(c *cobra.Command, args []string) error {
 	numArgs := len(args)
 
 	addrs := make([]string, numArgs)
 
-	var err error
 	for i := 0; i < numArgs; i++ {
-		addrs[i] = args[i]
-		if _, err = cipher.DecodeBase58Address(addrs[i]); err != nil {
-			return fmt.Errorf("invalid address: %v, err: %v", addrs[i], err)
+		addr, err := cipher.DecodeAddress(params.AddressHRP, args[i])
+		if err != nil {
+			return fmt.Errorf("invalid address: %v, err: %v", args[i], err)
+		}
+		addrs[i] = addr.String()
+	}
+
+	if c.Flags().Changed("seq") {
+		seq, err := c.Flags().GetUint64("seq")
+		if err != nil {
+			return err
 		}
+
+		balAt, err := apiClient.BalanceAt(addrs, seq)
+		if err != nil {
+			return err
+		}
+
+		return printJSON(balAt)
 	}
 
 	balRlt, err := GetBalanceOfAddresses(apiClient, addrs)
@@ -136,14 +156,14 @@ func getBalanceOfAddresses(outs *readable.UnspentOutputsSummary, addrs []string)
 			return nil, fmt.Errorf("Found address %s in GetUnspentOutputs result, but this address wasn't requested", o.Address)
 		}
 
-		amt, err := droplet.FromString(o.Coins)
+		amt, err := amount.ParseAmount(o.Coins)
 		if err != nil {
-			return nil, fmt.Errorf("droplet.FromString failed: %v", err)
+			return nil, fmt.Errorf("amount.ParseAmount failed: %v", err)
 		}
 
 		b := addrBalances[o.Address]
 		b.confirmed.Coins += amt
-		b.confirmed.Hours += o.CalculatedHours
+		b.confirmed.Hours += amount.Hours(o.CalculatedHours)
 
 		addrBalances[o.Address] = b
 	}
@@ -154,14 +174,14 @@ func getBalanceOfAddresses(outs *readable.UnspentOutputsSummary, addrs []string)
 			return nil, fmt.Errorf("Found address %s in GetUnspentOutputs result, but this address wasn't requested", o.Address)
 		}
 
-		amt, err := droplet.FromString(o.Coins)
+		amt, err := amount.ParseAmount(o.Coins)
 		if err != nil {
-			return nil, fmt.Errorf("droplet.FromString failed: %v", err)
+			return nil, fmt.Errorf("amount.ParseAmount failed: %v", err)
 		}
 
 		b := addrBalances[o.Address]
 		b.spendable.Coins += amt
-		b.spendable.Hours += o.CalculatedHours
+		b.spendable.Hours += amount.Hours(o.CalculatedHours)
 
 		addrBalances[o.Address] = b
 	}
@@ -172,27 +192,27 @@ func getBalanceOfAddresses(outs *readable.UnspentOutputsSummary, addrs []string)
 			return nil, fmt.Errorf("Found address %s in GetUnspentOutputs result, but this address wasn't requested", o.Address)
 		}
 
-		amt, err := droplet.FromString(o.Coins)
+		amt, err := amount.ParseAmount(o.Coins)
 		if err != nil {
-			return nil, fmt.Errorf("droplet.FromString failed: %v", err)
+			return nil, fmt.Errorf("amount.ParseAmount failed: %v", err)
 		}
 
 		b := addrBalances[o.Address]
 		b.expected.Coins += amt
-		b.expected.Hours += o.CalculatedHours
+		b.expected.Hours += amount.Hours(o.CalculatedHours)
 
 		addrBalances[o.Address] = b
 	}
 
 	toBalance := func(b wallet.Balance) (Balance, error) {
-		coins, err := droplet.ToString(b.Coins)
+		coins, err := b.Coins.ToString()
 		if err != nil {
 			return Balance{}, err
 		}
 
 		return Balance{
 			Coins: coins,
-			Hours: strconv.FormatUint(b.Hours, 10),
+			Hours: b.Hours.String(),
 		}, nil
 	}
 
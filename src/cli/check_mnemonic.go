@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/skycoin/skycoin/src/cipher/bip39"
+)
+
+func checkMnemonicCmd() *cobra.Command {
+	return &cobra.Command{
+		Short: "Check a bip39 mnemonic seed",
+		Use:   "checkMnemonic [mnemonic]",
+		Long: `Check the words and the checksum of a bip39 mnemonic seed, in any of the
+    supported languages. If the mnemonic is invalid, the corrections of a single
+    mistyped word that make it valid are suggested, most likely first.
+
+    The mnemonic can be one quoted argument or one argument per word.
+    Use caution, the mnemonic can be recovered from your command history
+    if it is enabled.`,
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		SilenceUsage:          true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			mnemonic := strings.Join(strings.Fields(strings.Join(args, " ")), " ")
+
+			if err := bip39.ValidateMnemonic(mnemonic); err != nil {
+				return mnemonicError(err, bip39.SuggestCorrections(mnemonic))
+			}
+
+			lang, err := bip39.DetectLanguage(mnemonic)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Valid %s mnemonic\n", lang)
+			return nil
+		},
+	}
+}
+
+// mnemonicError returns the validation error of a mnemonic with its suggested corrections
+func mnemonicError(err error, corrections []bip39.Correction) error {
+	if len(corrections) == 0 {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v, possible corrections:", err)
+	for _, c := range corrections {
+		fmt.Fprintf(&b, "\nword %d: %s -> %s\n    %s", c.Position+1, c.Word, c.Replacement, c.Mnemonic)
+	}
+	return fmt.Errorf("%s", b.String())
+}
@@ -43,6 +43,7 @@ var (
     RPC_ADDR: Address of RPC node. Must be in scheme://host format. Default "%s"
     RPC_USER: Username for RPC API, if enabled in the RPC.
     RPC_PASS: Password for RPC API, if enabled in the RPC.
+    RPC_TOKEN: API token for RPC API, used instead of RPC_USER and RPC_PASS. Created with apiTokenCreate.
     COIN: Name of the coin. Default "%s"
     DATA_DIR: Directory where everything is stored. Default "%s"`, defaultRPCAddress, defaultCoin, defaultDataDir)
 
@@ -94,6 +95,7 @@ type Config struct {
 	RPCAddress  string `json:"rpc_address"`
 	RPCUsername string `json:"-"`
 	RPCPassword string `json:"-"`
+	RPCToken    string `json:"-"`
 }
 
 // LoadConfig loads config from environment, prior to parsing CLI flags
@@ -116,6 +118,7 @@ func LoadConfig() (Config, error) {
 
 	rpcUser := os.Getenv("RPC_USER")
 	rpcPass := os.Getenv("RPC_PASS")
+	rpcToken := os.Getenv("RPC_TOKEN")
 
 	home := file.UserHome()
 
@@ -138,6 +141,7 @@ func LoadConfig() (Config, error) {
 		RPCAddress:  rpcAddr,
 		RPCUsername: rpcUser,
 		RPCPassword: rpcPass,
+		RPCToken:    rpcToken,
 	}, nil
 }
 
@@ -167,6 +171,7 @@ func resolveDBPath(cfg Config, db string) (string, error) {
 func NewCLI(cfg Config) (*cobra.Command, error) {
 	apiClient = api.NewClient(cfg.RPCAddress)
 	apiClient.SetAuth(cfg.RPCUsername, cfg.RPCPassword)
+	apiClient.SetAPIToken(cfg.RPCToken)
 
 	cliConfig = cfg
 
@@ -185,6 +190,7 @@ func NewCLI(cfg Config) (*cobra.Command, error) {
 		broadcastTxCmd(),
 		checkDBCmd(),
 		checkDBEncodingCmd(),
+		checkMnemonicCmd(),
 		createRawTxnCmd(),
 		createRawTxnV2Cmd(),
 		signTxnCmd(),
@@ -196,17 +202,22 @@ func NewCLI(cfg Config) (*cobra.Command, error) {
 		listAddressesCmd(),
 		listWalletsCmd(),
 		sendCmd(),
+		payoutSendCmd(),
+		payoutStatusCmd(),
 		showConfigCmd(),
 		showSeedCmd(),
+		signMessageCmd(),
 		statusCmd(),
 		transactionCmd(),
 		verifyTransactionCmd(),
 		verifyAddressCmd(),
+		verifyMessageCmd(),
 		versionCmd(),
 		walletCreateCmd(),
 		walletAddAddressesCmd(),
 		walletScanAddressesCmd(),
 		walletKeyExportCmd(),
+		walletDescriptorCmd(),
 		walletBalanceCmd(),
 		walletHisCmd(),
 		walletOutputsCmd(),
@@ -215,6 +226,9 @@ func NewCLI(cfg Config) (*cobra.Command, error) {
 		pendingTransactionsCmd(),
 		addresscountCmd(),
 		distributeGenesisCmd(),
+		apiTokenCreateCmd(),
+		apiTokenListCmd(),
+		apiTokenRemoveCmd(),
 	}
 
 	skyCLI.Version = Version
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/kvstorage"
+	"github.com/skycoin/skycoin/src/params"
+)
+
+// addressBook resolves contact names to addresses, using the address book storage of the node
+type addressBook struct {
+	contacts map[string]string
+}
+
+// load loads the address book contacts from the node, once
+func (b *addressBook) load() error {
+	if b.contacts != nil {
+		return nil
+	}
+
+	contacts, err := apiClient.GetAllStorageValues(kvstorage.TypeAddressBook)
+	if err != nil {
+		return err
+	}
+
+	if contacts == nil {
+		contacts = make(map[string]string)
+	}
+	b.contacts = contacts
+
+	return nil
+}
+
+// resolveAddress returns the base58 encoding of addr if it is a valid skycoin address, in base58 or bech32 format.
+// Otherwise, if addr is the name of a contact in the address book, returns the contact's first skycoin address.
+// If the address book cannot be loaded or has no such contact, the address decoding error is returned.
+func (b *addressBook) resolveAddress(addr string) (string, error) {
+	a, addrErr := cipher.DecodeAddress(params.AddressHRP, addr)
+	if addrErr == nil {
+		return a.String(), nil
+	}
+
+	if err := b.load(); err != nil {
+		return "", addrErr
+	}
+
+	val, ok := b.contacts[addr]
+	if !ok {
+		return "", addrErr
+	}
+
+	contact, err := kvstorage.ParseContact(val)
+	if err != nil {
+		return "", fmt.Errorf("invalid contact %q: %v", addr, err)
+	}
+
+	addrs := contact.SkycoinAddresses()
+	if len(addrs) == 0 {
+		return "", fmt.Errorf("contact %q has no skycoin address", addr)
+	}
+
+	return addrs[0].String(), nil
+}
+
+// resolveCSVAddresses replaces the contact names in the address column of CSV fields with their addresses.
+// Values that are not contact names are left unchanged.
+func (b *addressBook) resolveCSVAddresses(fields [][]string) error {
+	for _, f := range fields {
+		if len(f) == 0 {
+			continue
+		}
+
+		name := strings.TrimSpace(f[0])
+		if _, err := cipher.DecodeAddress(params.AddressHRP, name); err == nil {
+			continue
+		}
+
+		addr, err := b.resolveAddress(name)
+		if err != nil {
+			if _, ok := b.contacts[name]; ok {
+				return err
+			}
+			continue
+		}
+
+		f[0] = addr
+	}
+
+	return nil
+}
+
+// resolveSendAmountAddresses replaces the contact names in the addresses of sends with their addresses.
+// Returns an error if an address is neither a valid skycoin address nor the name of a contact with a skycoin address.
+func (b *addressBook) resolveSendAmountAddresses(sends []SendAmount) error {
+	for i := range sends {
+		addr, err := b.resolveAddress(sends[i].Addr)
+		if err != nil {
+			return fmt.Errorf("invalid address %q in -m flag string: %v", sends[i].Addr, err)
+		}
+
+		sends[i].Addr = addr
+	}
+
+	return nil
+}
@@ -49,6 +49,10 @@ func createRawTxnCmd() *cobra.Command {
 
     The [to address] and [amount] arguments can be replaced with the --many/-m or the --csv option.
 
+    The [to address] can also be the name of a contact in the node's address book,
+    in which case the contact's first skycoin address is used. Contact names are
+    also accepted in place of addresses in the --many/-m and --csv options.
+
     Use caution when using the "-p" command. If you have command history enabled
     your wallet encryption password can be recovered from the history log. If you
     do not include the "-p" option you will be prompted to enter your password
@@ -112,6 +116,10 @@ func createRawTxnV2Cmd() *cobra.Command {
 
     The [to address] and [amount] arguments can be replaced with the --csv option.,
 
+    The [to address] can also be the name of a contact in the node's address book,
+    in which case the contact's first skycoin address is used. Contact names are
+    also accepted in place of addresses in the --csv option.
+
     Use caution when using the "-p" command. If you have command history enabled
     your wallet encryption password can be recovered from the history log. If you
     do not include the "-p" option you will be prompted to enter your password
@@ -156,6 +164,9 @@ func createRawTxnV2Cmd() *cobra.Command {
 	createRawTxnCmd.Flags().StringP("hours-selection-type", "", transaction.HoursSelectionTypeAuto, "Hours selection type")
 	createRawTxnCmd.Flags().StringP("hours-selection-mode", "", transaction.HoursSelectionModeShare, "Hours selection mode")
 	createRawTxnCmd.Flags().StringP("hours-selection-share-factor", "", "0.5", "Hour selection share factor")
+	createRawTxnCmd.Flags().String("choose-strategy", transaction.ChooseStrategyMinimizeUxOuts, fmt.Sprintf(`Strategy used to choose the outputs to spend, one of:
+	%s, %s, %s, %s, %s`, transaction.ChooseStrategyMinimizeUxOuts, transaction.ChooseStrategyMaximizeUxOuts,
+		transaction.ChooseStrategyAvoidAddressMerge, transaction.ChooseStrategyExactMatch, transaction.ChooseStrategyMinimizeChange))
 
 	return createRawTxnCmd
 }
@@ -242,6 +253,11 @@ func makeCreateTransactionRequest(c *cobra.Command, args []string, fromAddrs []s
 		changeAddr = &ca
 	}
 
+	chooseStrategy, err := c.Flags().GetString("choose-strategy")
+	if err != nil {
+		return nil, err
+	}
+
 	to, err := getToAddressesV2(c, args[1:])
 	if err != nil {
 		return nil, err
@@ -251,6 +267,7 @@ func makeCreateTransactionRequest(c *cobra.Command, args []string, fromAddrs []s
 		IgnoreUnconfirmed: iu,
 		HoursSelection:    *hoursSelection,
 		ChangeAddress:     changeAddr,
+		ChooseStrategy:    chooseStrategy,
 		Addresses:         fromAddrs,
 		To:                to,
 	}, nil
@@ -262,11 +279,16 @@ func getToAddressesV2(c *cobra.Command, args []string) ([]api.Receiver, error) {
 		return nil, err
 	}
 
+	var book addressBook
+
 	if csvFile != "" {
 		fields, err := openCSV(csvFile)
 		if err != nil {
 			return nil, err
 		}
+		if err := book.resolveCSVAddresses(fields); err != nil {
+			return nil, err
+		}
 		return parseReceiversFromCSV(fields)
 	}
 
@@ -274,8 +296,8 @@ func getToAddressesV2(c *cobra.Command, args []string) ([]api.Receiver, error) {
 		return nil, fmt.Errorf("requires at least 2 arg(s), only received %d", len(args))
 	}
 
-	toAddr := args[0]
-	if _, err := cipher.DecodeBase58Address(toAddr); err != nil {
+	toAddr, err := book.resolveAddress(args[0])
+	if err != nil {
 		return nil, err
 	}
 
@@ -327,14 +349,15 @@ func fromWalletOrAddress(c *cobra.Command, walletFile string) (walletAddress, er
 		Wallet: walletFile,
 	}
 
-	wltAddr.Address = address
-	if wltAddr.Address == "" {
+	if address == "" {
 		return wltAddr, nil
 	}
 
-	if _, err := cipher.DecodeBase58Address(wltAddr.Address); err != nil {
-		return walletAddress{}, fmt.Errorf("invalid address: %s", wltAddr.Address)
+	addr, err := cipher.DecodeAddress(params.AddressHRP, address)
+	if err != nil {
+		return walletAddress{}, fmt.Errorf("invalid address: %s", address)
 	}
+	wltAddr.Address = addr.String()
 
 	return wltAddr, nil
 }
@@ -367,12 +390,12 @@ func getChangeAddress(wltAddr walletAddress, chgAddr string) (string, error) {
 	}
 
 	// validate the address
-	_, err := cipher.DecodeBase58Address(chgAddr)
+	addr, err := cipher.DecodeAddress(params.AddressHRP, chgAddr)
 	if err != nil {
 		return "", fmt.Errorf("invalid change address: %s", chgAddr)
 	}
 
-	return chgAddr, nil
+	return addr.String(), nil
 }
 
 func getToAddresses(c *cobra.Command, args []string) ([]SendAmount, error) {
@@ -389,13 +412,25 @@ func getToAddresses(c *cobra.Command, args []string) ([]SendAmount, error) {
 		return nil, errors.New("-csv and -m cannot be combined")
 	}
 
+	var book addressBook
+
 	if many != "" {
-		return parseSendAmountsFromJSON(many)
+		sends, err := parseSendAmountsFromJSON(many)
+		if err != nil {
+			return nil, err
+		}
+		if err := book.resolveSendAmountAddresses(sends); err != nil {
+			return nil, err
+		}
+		return sends, nil
 	} else if csvFile != "" {
 		fields, err := openCSV(csvFile)
 		if err != nil {
 			return nil, err
 		}
+		if err := book.resolveCSVAddresses(fields); err != nil {
+			return nil, err
+		}
 		return parseSendAmountsFromCSV(fields)
 	}
 
@@ -403,9 +438,8 @@ func getToAddresses(c *cobra.Command, args []string) ([]SendAmount, error) {
 		return nil, fmt.Errorf("requires at least 2 arg(s), only received %d", len(args))
 	}
 
-	toAddr := args[0]
-
-	if _, err := cipher.DecodeBase58Address(toAddr); err != nil {
+	toAddr, err := book.resolveAddress(args[0])
+	if err != nil {
 		return nil, err
 	}
 
@@ -439,11 +473,13 @@ func parseSendAmountsFromCSV(fields [][]string) ([]SendAmount, error) {
 
 		addr = strings.TrimSpace(addr)
 
-		if _, err := cipher.DecodeBase58Address(addr); err != nil {
+		a, err := cipher.DecodeAddress(params.AddressHRP, addr)
+		if err != nil {
 			err = fmt.Errorf("[row %d] Invalid address %s: %v", i, addr, err)
 			errs = append(errs, err)
 			continue
 		}
+		addr = a.String()
 
 		coins, err := droplet.FromString(f[1])
 		if err != nil {
@@ -480,13 +516,15 @@ func parseReceiversFromCSV(fields [][]string) ([]api.Receiver, error) {
 
 		addr = strings.TrimSpace(addr)
 
-		if _, err := cipher.DecodeBase58Address(addr); err != nil {
+		a, err := cipher.DecodeAddress(params.AddressHRP, addr)
+		if err != nil {
 			err = fmt.Errorf("[row %d] Invalid address %s: %v", i, addr, err)
 			errs = append(errs, err)
 			continue
 		}
+		addr = a.String()
 
-		_, err := droplet.FromString(f[1])
+		_, err = droplet.FromString(f[1])
 		if err != nil {
 			err = fmt.Errorf("[row %d] Invalid amount %s: %v", i, f[1], err)
 			errs = append(errs, err)
@@ -618,7 +656,7 @@ func createRawTxnCmdHandler(c *cobra.Command, args []string) (*coin.Transaction,
 func validateSendAmounts(toAddrs []SendAmount) error {
 	for _, arg := range toAddrs {
 		// validate to address
-		_, err := cipher.DecodeBase58Address(arg.Addr)
+		_, err := cipher.DecodeAddress(params.AddressHRP, arg.Addr)
 		if err != nil {
 			return ErrAddress
 		}
@@ -640,7 +678,7 @@ func validateSendAmounts(toAddrs []SendAmount) error {
 // CreateRawTxnFromWallet creates a transaction from any address or combination of addresses in a wallet
 func CreateRawTxnFromWallet(c GetOutputser, walletFile, chgAddr string, toAddrs []SendAmount, pr PasswordReader, distParams params.Distribution) (*coin.Transaction, error) {
 	// check change address
-	cAddr, err := cipher.DecodeBase58Address(chgAddr)
+	cAddr, err := cipher.DecodeAddress(params.AddressHRP, chgAddr)
 	if err != nil {
 		return nil, ErrAddress
 	}
@@ -705,7 +743,7 @@ func CreateRawTxnFromAddress(c GetOutputser, addr, walletFile, chgAddr string, t
 		return nil, err
 	}
 
-	srcAddr, err := cipher.DecodeBase58Address(addr)
+	srcAddr, err := cipher.DecodeAddress(params.AddressHRP, addr)
 	if err != nil {
 		return nil, ErrAddress
 	}
@@ -718,7 +756,7 @@ func CreateRawTxnFromAddress(c GetOutputser, addr, walletFile, chgAddr string, t
 	}
 
 	// validate change address
-	cAddr, err := cipher.DecodeBase58Address(chgAddr)
+	cAddr, err := cipher.DecodeAddress(params.AddressHRP, chgAddr)
 	if err != nil {
 		return nil, ErrAddress
 	}
@@ -964,8 +1002,13 @@ func makeChangeOut(outs []transaction.UxBalance, chgAddr string, toAddrs []SendA
 }
 
 func mustMakeUtxoOutput(addr string, coins, hours uint64) coin.TransactionOutput {
+	a, err := cipher.DecodeAddress(params.AddressHRP, addr)
+	if err != nil {
+		panic(err)
+	}
+
 	uo := coin.TransactionOutput{}
-	uo.Address = cipher.MustDecodeBase58Address(addr)
+	uo.Address = a
 	uo.Coins = coins
 	uo.Hours = hours
 	return uo
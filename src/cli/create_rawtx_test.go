@@ -566,6 +566,19 @@ func TestParseSendAmountsFromCSV(t *testing.T) {
 			},
 		},
 
+		{
+			name: "bech32 address",
+			fields: [][]string{
+				{"sky1qccsprdf5d0l9kym28dfr9jqxpntfp6fe5k2kpa", "123"},
+			},
+			amts: []SendAmount{
+				{
+					Addr:  "2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP",
+					Coins: 123e6,
+				},
+			},
+		},
+
 		{
 			name: "invalid coins value",
 			fields: [][]string{
@@ -598,3 +611,76 @@ func TestParseSendAmountsFromCSV(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveSendAmountAddresses(t *testing.T) {
+	book := addressBook{
+		contacts: map[string]string{
+			"alice":   `{"addresses":["1FeexV6bAHb8ybZjqQMjJrcCrHGW9sb6uF","2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP"]}`,
+			"bob":     `{"addresses":["1FeexV6bAHb8ybZjqQMjJrcCrHGW9sb6uF"]}`,
+			"invalid": `{"addresses":[]}`,
+		},
+	}
+
+	cases := []struct {
+		name  string
+		sends []SendAmount
+		addrs []string
+		err   error
+	}{
+		{
+			name: "addresses and contact",
+			sends: []SendAmount{
+				{Addr: "2UDzBKnxZf4d9pdrBJAqbtoeH641RFLYKxd", Coins: 1},
+				{Addr: "sky1qccsprdf5d0l9kym28dfr9jqxpntfp6fe5k2kpa", Coins: 2},
+				{Addr: "alice", Coins: 3},
+			},
+			addrs: []string{
+				"2UDzBKnxZf4d9pdrBJAqbtoeH641RFLYKxd",
+				"2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP",
+				"2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP",
+			},
+		},
+
+		{
+			name: "unknown contact",
+			sends: []SendAmount{
+				{Addr: "alice", Coins: 1},
+				{Addr: "alcie", Coins: 2},
+			},
+			err: errors.New(`invalid address "alcie" in -m flag string: Invalid base58 character`),
+		},
+
+		{
+			name: "contact without skycoin address",
+			sends: []SendAmount{
+				{Addr: "bob", Coins: 1},
+			},
+			err: errors.New(`invalid address "bob" in -m flag string: contact "bob" has no skycoin address`),
+		},
+
+		{
+			name: "invalid contact",
+			sends: []SendAmount{
+				{Addr: "invalid", Coins: 1},
+			},
+			err: errors.New(`invalid address "invalid" in -m flag string: invalid contact "invalid": contact must have at least one address`),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := book.resolveSendAmountAddresses(tc.sends)
+
+			if tc.err != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.err.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			for i, s := range tc.sends {
+				require.Equal(t, tc.addrs[i], s.Addr)
+			}
+		})
+	}
+}
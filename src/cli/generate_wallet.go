@@ -40,6 +40,9 @@ func walletCreateCmd() *cobra.Command {
 	walletCreateCmd.Flags().BoolP("random", "r", false, "A random alpha numeric seed will be generated.")
 	walletCreateCmd.Flags().BoolP("mnemonic", "m", false, "A mnemonic seed consisting of 12 dictionary words will be generated")
 	walletCreateCmd.Flags().Uint64P("wordcount", "w", 12, "Number of seed words to use for mnemonic. Must be 12, 15, 18, 21 or 24")
+	walletCreateCmd.Flags().StringP("language", "l", string(bip39.DefaultLanguage), "Language of the generated mnemonic seed. Languages are \"english\", \"spanish\", \"french\", \"italian\", \"japanese\", \"korean\", \"chinese_simplified\" or \"chinese_traditional\"")
+	walletCreateCmd.Flags().StringP("entropy", "", "", "Hex encoded entropy of 16, 20, 24, 28 or 32 bytes to make the mnemonic seed from, instead of random entropy")
+	walletCreateCmd.Flags().StringP("dice", "", "", "Rolls of a six-sided die, as digits from 1 to 6, to make the mnemonic seed from, instead of random entropy. 50 rolls are needed for 12 words and 100 rolls for 24 words")
 	walletCreateCmd.Flags().StringP("seed", "s", "", "Your seed")
 	walletCreateCmd.Flags().StringP("seed-passphrase", "", "", "Seed passphrase (bip44 wallets only)")
 	walletCreateCmd.Flags().Uint32P("bip44-coin", "", uint32(bip44.CoinTypeSkycoin), "BIP44 coin type")
@@ -49,6 +52,7 @@ func walletCreateCmd() *cobra.Command {
 	walletCreateCmd.Flags().BoolP("encrypt", "e", true, "Create encrypted wallet.")
 	walletCreateCmd.Flags().StringP("password", "p", "", "Wallet password")
 	walletCreateCmd.Flags().StringP("xpub", "", "", "xpub key for \"xpub\" type wallets")
+	walletCreateCmd.Flags().StringP("descriptor", "", "", "Output descriptor for \"bip44\" and \"xpub\" type wallets, e.g. \"pkh([fingerprint/44'/8000'/0']xpub.../<0;1>/*)\"")
 
 	return walletCreateCmd
 }
@@ -95,8 +99,42 @@ func generateWalletHandler(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	if !mnemonic && c.Flags().Changed("wordcount") {
-		return errors.New("-m must also be set when using -wordcount")
+	entropyHex, err := c.Flags().GetString("entropy")
+	if err != nil {
+		return err
+	}
+
+	diceRolls, err := c.Flags().GetString("dice")
+	if err != nil {
+		return err
+	}
+
+	if entropyHex != "" && diceRolls != "" {
+		return errors.New("--entropy and --dice can't be used together")
+	}
+
+	if entropyHex != "" && c.Flags().Changed("wordcount") {
+		return errors.New("-w can't be used with --entropy, the number of words depends on the entropy size")
+	}
+
+	if !mnemonic && diceRolls == "" && c.Flags().Changed("wordcount") {
+		return errors.New("-m or --dice must also be set when using -wordcount")
+	}
+
+	language, err := bip39.ParseLanguage(c.Flag("language").Value.String())
+	if err != nil {
+		return err
+	}
+
+	if s != "" && c.Flags().Changed("language") {
+		return errors.New("-l can't be used with -s, the language of the seed is detected")
+	}
+
+	mo := mnemonicOptions{
+		wordCount:  wordCount,
+		language:   language,
+		entropyHex: entropyHex,
+		diceRolls:  diceRolls,
 	}
 
 	encrypt, err := c.Flags().GetBool("encrypt")
@@ -128,24 +166,29 @@ func generateWalletHandler(c *cobra.Command, args []string) error {
 		return err
 	}
 
+	descriptor, err := c.Flags().GetString("descriptor")
+	if err != nil {
+		return err
+	}
+
 	var sd string
 	switch walletType {
 	case wallet.WalletTypeBip44:
 		var err error
-		sd, err = parseBip44WalletSeedOptions(s, random, mnemonic, wordCount)
+		sd, err = parseBip44WalletSeedOptions(s, random, mnemonic, mo)
 		if err != nil {
 			return err
 		}
 
 	case wallet.WalletTypeDeterministic:
 		var err error
-		sd, err = parseDeterministicWalletSeedOptions(s, random, mnemonic, wordCount)
+		sd, err = parseDeterministicWalletSeedOptions(s, random, mnemonic, mo)
 		if err != nil {
 			return err
 		}
 
 	case wallet.WalletTypeCollection:
-		if s != "" || random || mnemonic {
+		if s != "" || random || mnemonic || mo.userEntropy() {
 			return fmt.Errorf("%q type wallets do not use seeds", walletType)
 		}
 		if c.Flags().Changed("num") {
@@ -156,7 +199,7 @@ func generateWalletHandler(c *cobra.Command, args []string) error {
 	case wallet.WalletTypeXPub:
 		// xpub wallet does not support encryption
 		encrypt = false
-		if s != "" || random || mnemonic {
+		if s != "" || random || mnemonic || mo.userEntropy() {
 			return fmt.Errorf("%q type wallets do not use seeds", walletType)
 		}
 
@@ -189,6 +232,7 @@ func generateWalletHandler(c *cobra.Command, args []string) error {
 		Bip44Coin:      bip44Coin,
 		ScanN:          scan,
 		XPub:           xpub,
+		Descriptor:     descriptor,
 	}
 
 	wlt, err := apiClient.CreateWallet(opts)
@@ -242,19 +286,51 @@ func wordCountToEntropy(wc uint64) (int, error) {
 	}
 }
 
-func newMnemomic(wc uint64) (string, error) {
-	entropySize, err := wordCountToEntropy(wc)
-	if err != nil {
-		return "", err
-	}
-	e, err := bip39.NewEntropy(entropySize)
-	if err != nil {
-		return "", err
+// mnemonicOptions are the options of a generated mnemonic seed
+type mnemonicOptions struct {
+	wordCount  uint64
+	language   bip39.Language
+	entropyHex string
+	diceRolls  string
+}
+
+// userEntropy returns true if the mnemonic is made from entropy supplied by the user
+func (o mnemonicOptions) userEntropy() bool {
+	return o.entropyHex != "" || o.diceRolls != ""
+}
+
+func newMnemomic(o mnemonicOptions) (string, error) {
+	var e []byte
+	if o.entropyHex != "" {
+		var err error
+		e, err = bip39.EntropyFromHex(o.entropyHex)
+		if err != nil {
+			return "", fmt.Errorf("invalid entropy: %v", err)
+		}
+	} else {
+		entropySize, err := wordCountToEntropy(o.wordCount)
+		if err != nil {
+			return "", err
+		}
+
+		if o.diceRolls != "" {
+			e, err = bip39.EntropyFromDiceRolls(o.diceRolls, entropySize)
+			if err == bip39.ErrNotEnoughDiceRolls {
+				return "", fmt.Errorf("%d dice rolls are needed for %d words, got %d", bip39.MinDiceRolls(entropySize), o.wordCount, len(o.diceRolls))
+			}
+		} else {
+			e, err = bip39.NewEntropy(entropySize)
+		}
+		if err != nil {
+			return "", err
+		}
 	}
-	return bip39.NewMnemonic(e)
+
+	return bip39.NewMnemonicInLanguage(e, o.language)
 }
 
-func parseBip44WalletSeedOptions(s string, r, m bool, wc uint64) (string, error) {
+func parseBip44WalletSeedOptions(s string, r, m bool, mo mnemonicOptions) (string, error) {
+	m = m || mo.userEntropy()
 	if s != "" && (r || m) {
 		return "", errors.New("-r and -m can't be used with -s")
 	}
@@ -265,7 +341,7 @@ func parseBip44WalletSeedOptions(s string, r, m bool, wc uint64) (string, error)
 
 	if m || s == "" {
 		var err error
-		s, err = newMnemomic(wc)
+		s, err = newMnemomic(mo)
 		if err != nil {
 			return "", err
 		}
@@ -278,7 +354,8 @@ func parseBip44WalletSeedOptions(s string, r, m bool, wc uint64) (string, error)
 	return s, nil
 }
 
-func parseDeterministicWalletSeedOptions(s string, r, m bool, wc uint64) (string, error) {
+func parseDeterministicWalletSeedOptions(s string, r, m bool, mo mnemonicOptions) (string, error) {
+	m = m || mo.userEntropy()
 	if s != "" {
 		// 111, 101, 110
 		if r || m {
@@ -299,7 +376,7 @@ func parseDeterministicWalletSeedOptions(s string, r, m bool, wc uint64) (string
 	}
 
 	// 001, 000
-	return newMnemomic(wc)
+	return newMnemomic(mo)
 }
 
 // PUBLIC
@@ -596,6 +596,12 @@ func TestVerifyAddress(t *testing.T) {
 			nil,
 			"",
 		},
+		{
+			"valid bech32 skycoin address",
+			"sky1qh6xtwmnskvufml0lnka7pp0a8ft20gvhtyu54r",
+			nil,
+			"",
+		},
 		{
 			"invalid skycoin address",
 			"2KG9eRXUhx6hrDZvNGB99DKahtrPDQ1W9vn",
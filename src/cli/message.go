@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/skycoin/skycoin/src/api"
+)
+
+func signMessageCmd() *cobra.Command {
+	signMessageCmd := &cobra.Command{
+		Args:  cobra.ExactArgs(3),
+		Use:   "signMessage [wallet] [address] [message]",
+		Short: "Sign a message with an address of a wallet",
+		Long: `Sign a message with the secret key of an address of a wallet, to prove
+    the ownership of the address without moving coins. The signature can be
+    checked with the verifyMessage command.
+
+    Use caution when using the "-p" command. If you have command history enabled
+    your wallet encryption password can be recovered from the history log. If you
+    do not include the "-p" option you will be prompted to enter your password
+    after you enter your command.`,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			password, err := c.Flags().GetString("password")
+			if err != nil {
+				return err
+			}
+
+			pr := NewPasswordReader([]byte(password))
+			rsp, err := signMessage(args[0], args[1], args[2], pr)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(rsp)
+		},
+	}
+
+	signMessageCmd.Flags().StringP("password", "p", "", "Wallet password")
+
+	return signMessageCmd
+}
+
+func signMessage(walletID, addr, msg string, pr PasswordReader) (*api.MessageSignResponse, error) {
+	wlt, err := apiClient.Wallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := api.MessageSignRequest{
+		WalletID: walletID,
+		Address:  addr,
+		Message:  msg,
+	}
+
+	if wlt.Meta.Encrypted {
+		pwd, err := pr.Password()
+		if err != nil {
+			return nil, err
+		}
+		req.Password = string(pwd)
+	}
+
+	return apiClient.SignMessage(req)
+}
+
+func verifyMessageCmd() *cobra.Command {
+	return &cobra.Command{
+		Args:  cobra.ExactArgs(3),
+		Use:   "verifyMessage [address] [message] [signature]",
+		Short: "Verify a signed message",
+		Long: `Verify that a message was signed with the secret key of an address,
+    as done by the signMessage command. The address can be in base58 or bech32 format.`,
+		DisableFlagsInUseLine: true,
+		SilenceUsage:          true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			rsp, err := apiClient.VerifyMessage(api.MessageVerifyRequest{
+				Address:   args[0],
+				Message:   args[1],
+				Signature: args[2],
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("The message was signed by %s\n", rsp.Address)
+			return nil
+		},
+	}
+}
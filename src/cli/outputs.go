@@ -1,23 +1,42 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
+	"github.com/skycoin/skycoin/src/api"
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/readable"
 )
 
 func walletOutputsCmd() *cobra.Command {
-	return &cobra.Command{
-		Short:                 "Display outputs of specific wallet",
-		Use:                   "walletOutputs [wallet]",
-		DisableFlagsInUseLine: true,
-		SilenceUsage:          true,
-		Args:                  cobra.ExactArgs(1),
-		RunE:                  getWalletOutputsCmd,
+	walletOutputsCmd := &cobra.Command{
+		Short: "Display outputs of specific wallet",
+		Use:   "walletOutputs [wallet] [output hashes...]",
+		Long: `Display outputs of specific wallet, together with their coin control
+    metadata (frozen, label and tags).
+
+    The metadata of outputs can be updated by passing their hashes and any
+    of the --freeze, --unfreeze, --label or --tags flags, for example:
+
+    walletOutputs $wallet $hash1 $hash2 --freeze --label "cold storage"
+
+    Frozen outputs are not chosen automatically when creating transactions.
+    They can still be spent by selecting them explicitly with --uxouts.`,
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         getWalletOutputsCmd,
 	}
+
+	walletOutputsCmd.Flags().Bool("freeze", false, "Freeze the outputs")
+	walletOutputsCmd.Flags().Bool("unfreeze", false, "Unfreeze the outputs")
+	walletOutputsCmd.Flags().String("label", "", "Set the label of the outputs")
+	walletOutputsCmd.Flags().StringSlice("tags", nil, "Set the tags of the outputs, comma separated. Pass an empty string to clear the tags")
+
+	return walletOutputsCmd
 }
 
 func addressOutputsCmd() *cobra.Command {
@@ -36,10 +55,25 @@ func addressOutputsCmd() *cobra.Command {
 // OutputsResult the output json format
 type OutputsResult struct {
 	Outputs readable.UnspentOutputsSummary `json:"outputs"`
+	// Meta is the coin control metadata of the outputs of a wallet
+	Meta readable.UxOutsMeta `json:"meta,omitempty"`
 }
 
-func getWalletOutputsCmd(_ *cobra.Command, args []string) error {
-	addrs, err := getWalletAddresses(args[0])
+func getWalletOutputsCmd(c *cobra.Command, args []string) error {
+	wltID := args[0]
+
+	req, err := makeWalletOutputsMetaRequest(c, wltID, args[1:])
+	if err != nil {
+		return err
+	}
+
+	if req != nil {
+		if _, err := apiClient.UpdateWalletOutputsMeta(*req); err != nil {
+			return err
+		}
+	}
+
+	addrs, err := getWalletAddresses(wltID)
 	if err != nil {
 		return err
 	}
@@ -49,20 +83,94 @@ func getWalletOutputsCmd(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	meta, err := apiClient.WalletOutputsMeta(wltID)
+	if err != nil {
+		return err
+	}
+
 	return printJSON(OutputsResult{
 		Outputs: *outputs,
+		Meta:    meta,
 	})
 }
 
+// makeWalletOutputsMetaRequest creates the request for updating the metadata of outputs,
+// returns nil if no update flags are set
+func makeWalletOutputsMetaRequest(c *cobra.Command, wltID string, hashes []string) (*api.WalletOutputsMetaRequest, error) {
+	freeze, err := c.Flags().GetBool("freeze")
+	if err != nil {
+		return nil, err
+	}
+
+	unfreeze, err := c.Flags().GetBool("unfreeze")
+	if err != nil {
+		return nil, err
+	}
+
+	if freeze && unfreeze {
+		return nil, errors.New("--freeze and --unfreeze cannot be combined")
+	}
+
+	req := api.WalletOutputsMetaRequest{
+		ID:     wltID,
+		Hashes: hashes,
+	}
+
+	if freeze || unfreeze {
+		req.Frozen = &freeze
+	}
+
+	if c.Flags().Changed("label") {
+		label, err := c.Flags().GetString("label")
+		if err != nil {
+			return nil, err
+		}
+		req.Label = &label
+	}
+
+	if c.Flags().Changed("tags") {
+		tags, err := c.Flags().GetStringSlice("tags")
+		if err != nil {
+			return nil, err
+		}
+
+		req.Tags = []string{}
+		for _, t := range tags {
+			if t != "" {
+				req.Tags = append(req.Tags, t)
+			}
+		}
+	}
+
+	if req.Frozen == nil && req.Label == nil && req.Tags == nil {
+		if len(hashes) != 0 {
+			return nil, errors.New("output hashes given without any of --freeze, --unfreeze, --label or --tags")
+		}
+		return nil, nil
+	}
+
+	if len(hashes) == 0 {
+		return nil, errors.New("no output hashes given")
+	}
+
+	for _, h := range hashes {
+		if _, err := cipher.SHA256FromHex(h); err != nil {
+			return nil, fmt.Errorf("invalid output hash: %v, err: %v", h, err)
+		}
+	}
+
+	return &req, nil
+}
+
 func getAddressOutputsCmd(_ *cobra.Command, args []string) error {
 	addrs := make([]string, len(args))
 
-	var err error
 	for i := 0; i < len(args); i++ {
-		addrs[i] = args[i]
-		if _, err = cipher.DecodeBase58Address(addrs[i]); err != nil {
-			return fmt.Errorf("invalid address: %v, err: %v", addrs[i], err)
+		addr, err := cipher.DecodeAddress(params.AddressHRP, args[i])
+		if err != nil {
+			return fmt.Errorf("invalid address: %v, err: %v", args[i], err)
 		}
+		addrs[i] = addr.String()
 	}
 
 	outputs, err := apiClient.OutputsForAddresses(addrs)
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/skycoin/skycoin/src/api"
+	"github.com/skycoin/skycoin/src/transaction"
+)
+
+func payoutSendCmd() *cobra.Command {
+	payoutSendCmd := &cobra.Command{
+		Short: "Send coins from a wallet to a batch of recipients",
+		Use:   "payoutSend [wallet] [idempotency key] [to address] [amount]",
+		Long: `Send coins from a wallet to a batch of recipients, as a payout job.
+
+    The recipients are usually given with the --csv option, which replaces the
+    [to address] and [amount] arguments. They are packed into as few transactions
+    as the maximum transaction size allows.
+
+    The [idempotency key] identifies the payout job. If the command fails, for
+    example because the node has no connections, run it again with the same key
+    and recipients: the job resumes and no recipient is paid twice.
+    Use payoutStatus to check the status of the job.
+
+    Use caution when using the "-p" command. If you have command history enabled
+    your wallet encryption password can be recovered from the history log. If you
+    do not include the "-p" option you will be prompted to enter your password
+    after you enter your command.`,
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			req, err := makePayoutRequest(c, args)
+			if err != nil {
+				return err
+			}
+
+			job, err := apiClient.CreatePayout(*req)
+			if job != nil {
+				if printErr := printJSON(job); printErr != nil {
+					return printErr
+				}
+			}
+
+			return err
+		},
+	}
+
+	payoutSendCmd.Flags().String("csv", "", "CSV file containing addresses and amounts to send")
+	payoutSendCmd.Flags().StringP("password", "p", "", "Wallet password")
+	payoutSendCmd.Flags().StringP("hours-selection-type", "", transaction.HoursSelectionTypeAuto, "Hours selection type")
+	payoutSendCmd.Flags().StringP("hours-selection-mode", "", transaction.HoursSelectionModeShare, "Hours selection mode")
+	payoutSendCmd.Flags().StringP("hours-selection-share-factor", "", "0.5", "Hour selection share factor")
+
+	return payoutSendCmd
+}
+
+func payoutStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Short: "Display the status of payout jobs",
+		Use:   "payoutStatus [idempotency key]",
+		Long: `Display the status of the payout job with the given idempotency key,
+    or of all payout jobs if no key is given.`,
+		Args:                  cobra.MaximumNArgs(1),
+		DisableFlagsInUseLine: true,
+		SilenceUsage:          true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				jobs, err := apiClient.Payouts()
+				if err != nil {
+					return err
+				}
+				return printJSON(jobs)
+			}
+
+			job, err := apiClient.Payout(args[0])
+			if err != nil {
+				return err
+			}
+			return printJSON(job)
+		},
+	}
+}
+
+func makePayoutRequest(c *cobra.Command, args []string) (*api.PayoutRequest, error) {
+	w, err := apiClient.Wallet(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	hoursSelection, err := getHoursSelection(c)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := getToAddressesV2(c, args[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	req := api.PayoutRequest{
+		IdempotencyKey: args[1],
+		WalletID:       w.Meta.Filename,
+		HoursSelection: *hoursSelection,
+		To:             to,
+	}
+
+	if w.Meta.Encrypted {
+		p, err := getPassword(c)
+		if err != nil {
+			return nil, err
+		}
+		req.Password = string(p)
+	}
+
+	return &req, nil
+}
@@ -10,18 +10,22 @@ import (
 )
 
 func richlistCmd() *cobra.Command {
-	return &cobra.Command{
+	richlistCmd := &cobra.Command{
 		Short:                 "Get skycoin richlist",
-		Long:                  "Returns top N address (default 20) balances (based on unspent outputs). Optionally include distribution addresses (exluded by default).",
+		Long:                  "Returns top N address (default 20) balances (based on unspent outputs). Optionally include distribution addresses (exluded by default). Use the \"--seq\" option to get the richlist at a past block.",
 		Use:                   "richlist [top N addresses (20 default)] [include distribution addresses (false default)]",
 		Args:                  cobra.MaximumNArgs(2),
 		DisableFlagsInUseLine: true,
 		SilenceUsage:          true,
 		RunE:                  getRichlist,
 	}
+
+	richlistCmd.Flags().Uint64("seq", 0, "Block seq of the richlist, defaults to the head block")
+
+	return richlistCmd
 }
 
-func getRichlist(_ *cobra.Command, args []string) error {
+func getRichlist(c *cobra.Command, args []string) error {
 	// default values
 	num := "20"
 	dist := "false"
@@ -49,6 +53,20 @@ func getRichlist(_ *cobra.Command, args []string) error {
 		IncludeDistribution: d,
 	}
 
+	if c.Flags().Changed("seq") {
+		seq, err := c.Flags().GetUint64("seq")
+		if err != nil {
+			return err
+		}
+
+		richlist, err := apiClient.RichlistAt(seq, params)
+		if err != nil {
+			return err
+		}
+
+		return printJSON(richlist)
+	}
+
 	richlist, err := apiClient.Richlist(params)
 	if err != nil {
 		return err
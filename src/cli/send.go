@@ -17,6 +17,10 @@ func sendCmd() *cobra.Command {
 
     The [to address] and [amount] arguments can be replaced with the --many/-m option.
 
+    The [to address] can also be the name of a contact in the node's address book,
+    in which case the contact's first skycoin address is used. Contact names are
+    also accepted in place of addresses in the --many/-m and --csv options.
+
     If you are sending from a wallet without specifying an address,
     the transaction will use one or more of the addresses within the wallet.
 
@@ -13,6 +13,7 @@ import (
 	"github.com/skycoin/skycoin/src/api"
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/readable"
 
 	"github.com/spf13/cobra"
@@ -208,7 +209,7 @@ func recomputeHashes(rTxn *readable.Transaction) error {
 
 	out := make([]coin.TransactionOutput, len(rTxn.Out))
 	for i, o := range rTxn.Out {
-		addr, err := cipher.DecodeBase58Address(o.Address)
+		addr, err := cipher.DecodeAddress(params.AddressHRP, o.Address)
 		if err != nil {
 			return err
 		}
@@ -255,12 +256,12 @@ func addressTransactionsCmd() *cobra.Command {
 func getAddressTransactionsCmd(c *cobra.Command, args []string) error {
 	// Build the list of addresses from the command line arguments
 	addrs := make([]string, len(args))
-	var err error
 	for i := 0; i < len(args); i++ {
-		addrs[i] = args[i]
-		if _, err = cipher.DecodeBase58Address(addrs[i]); err != nil {
-			return fmt.Errorf("invalid address: %v, err: %v", addrs[i], err)
+		addr, err := cipher.DecodeAddress(params.AddressHRP, args[i])
+		if err != nil {
+			return fmt.Errorf("invalid address: %v, err: %v", args[i], err)
 		}
+		addrs[i] = addr.String()
 	}
 
 	// If one or more addresses have been provided, request their transactions - otherwise report an error
@@ -1,21 +1,46 @@
 package cli
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/cipher/bech32"
+	"github.com/skycoin/skycoin/src/params"
 )
 
 func verifyAddressCmd() *cobra.Command {
 	return &cobra.Command{
-		Short:                 "Verify a skycoin address",
-		Use:                   "verifyAddress [skycoin address]",
+		Short: "Verify a skycoin address",
+		Use:   "verifyAddress [skycoin address]",
+		Long: `Verify a skycoin address in base58 or bech32 format.
+    For an invalid bech32 address, the characters that are likely wrong are marked.`,
 		Args:                  cobra.ExactArgs(1),
 		DisableFlagsInUseLine: true,
 		SilenceUsage:          true,
 		RunE: func(_ *cobra.Command, args []string) error {
-			_, err := cipher.DecodeBase58Address(args[0])
-			return err
+			_, err := cipher.DecodeAddress(params.AddressHRP, args[0])
+			if err == nil || !cipher.HasBech32Prefix(params.AddressHRP, args[0]) {
+				return err
+			}
+
+			positions := bech32.LocateErrors(args[0])
+			if len(positions) == 0 {
+				return err
+			}
+
+			return fmt.Errorf("%v, the characters at positions %v are likely wrong:\n%s\n%s", err, positions, args[0], markPositions(len(args[0]), positions))
 		},
 	}
 }
+
+// markPositions returns a line of length n with a "^" at each position, to be printed under the address
+func markPositions(n int, positions []int) string {
+	b := []byte(strings.Repeat(" ", n))
+	for _, p := range positions {
+		b[p] = '^'
+	}
+	return strings.TrimRight(string(b), " ")
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+func walletDescriptorCmd() *cobra.Command {
+	walletDescriptorCmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "walletDescriptor [wallet]",
+		Short: "Show the output descriptor of a bip44 or xpub wallet",
+		Long: `Print the output descriptor of a bip44 or xpub wallet.
+    The descriptor can be used to create a watch-only "xpub" wallet, or to
+    import the addresses of the wallet into other software.
+
+    The descriptor of a bip44 wallet is the descriptor of its default account.
+    Bip44 wallets created before descriptors were supported derive it from the
+    seed, in which case the password of an encrypted wallet is required.
+
+    Use caution when using the "-p" command. If you have command history enabled
+    your wallet encryption password can be recovered from the history log. If you
+    do not include the "-p" option you will be prompted to enter your password
+    after you enter your command.`,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			id := args[0]
+
+			jsonOutput, err := c.Flags().GetBool("json")
+			if err != nil {
+				return err
+			}
+
+			wlt, err := apiClient.Wallet(id)
+			if err != nil {
+				return err
+			}
+
+			var password []byte
+			if wlt.Meta.Type == wallet.WalletTypeBip44 && wlt.Meta.Descriptor == "" && wlt.Meta.Encrypted {
+				pr := NewPasswordReader([]byte(c.Flag("password").Value.String()))
+				password, err = pr.Password()
+				if err != nil {
+					return err
+				}
+			}
+
+			rsp, err := apiClient.WalletDescriptor(id, string(password))
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return printJSON(rsp)
+			}
+
+			fmt.Println(rsp.Descriptor)
+			return nil
+		},
+	}
+
+	walletDescriptorCmd.Flags().StringP("password", "p", "", "Wallet password")
+	walletDescriptorCmd.Flags().BoolP("json", "j", false, "Returns the results in JSON format.")
+
+	return walletDescriptorCmd
+}
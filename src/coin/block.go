@@ -12,9 +12,6 @@ import (
 	"github.com/skycoin/skycoin/src/cipher"
 )
 
-//go:generate skyencoder -struct BlockHeader -unexported
-//go:generate skyencoder -struct BlockBody -unexported
-
 // MaxBlockTransactions is the maximum number of transactions in a block (see the maxlen struct tag value applied to BlockBody.Transactions)
 const MaxBlockTransactions = 65535
 
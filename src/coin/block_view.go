@@ -0,0 +1,479 @@
+package coin
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"log"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+/*
+Block views decode the binary encoding of a block in place.
+
+Decoding a Block allocates a slice for the transactions and for the Sigs, In and Out
+of every transaction. A BlockView only records the number of transactions and reads the
+fields of the block straight from the encoded bytes, so that a block stored in the db or
+received over the network can be iterated without copying it.
+
+The encoded bytes are validated when the view is created, with the same errors as the
+generated decoders, so the accessors of a view never fail. A view does not own its bytes:
+when the bytes are a bolt value, the view is only valid for the life of the db transaction.
+*/
+
+const (
+	// blockHeaderSize is the encoded size of a BlockHeader
+	blockHeaderSize = 4 + 8 + 8 + 8 + 32 + 32 + 32
+	// transactionHeaderSize is the encoded size of Transaction.Length, Type and InnerHash
+	transactionHeaderSize = 4 + 1 + 32
+	// transactionOutputSize is the encoded size of a TransactionOutput
+	transactionOutputSize = 1 + 20 + 8 + 8
+	// sigSize is the encoded size of a cipher.Sig
+	sigSize = len(cipher.Sig{})
+	// hashSize is the encoded size of a cipher.SHA256
+	hashSize = len(cipher.SHA256{})
+	// maxTransactionArrayLen is the maxlen of Transaction.Sigs, In and Out
+	maxTransactionArrayLen = 65535
+)
+
+// BlockView is a read-only view of an encoded Block
+type BlockView struct {
+	buf   []byte
+	nTxns int
+}
+
+// NewBlockView creates a BlockView of an encoded Block.
+// If the buffer is longer than the encoded block, returns encoder.ErrRemainingBytes.
+func NewBlockView(buf []byte) (BlockView, error) {
+	v, rest, err := ReadBlockView(buf)
+	if err != nil {
+		return BlockView{}, err
+	}
+
+	if len(rest) != 0 {
+		return BlockView{}, encoder.ErrRemainingBytes
+	}
+
+	return v, nil
+}
+
+// ReadBlockView creates a BlockView of the Block encoded at the start of the buffer.
+// Returns the remainder of the buffer after the encoded block.
+func ReadBlockView(buf []byte) (BlockView, []byte, error) {
+	if len(buf) < blockHeaderSize {
+		return BlockView{}, nil, encoder.ErrBufferUnderflow
+	}
+
+	n, rest, err := readArrayLength(buf[blockHeaderSize:], MaxBlockTransactions)
+	if err != nil {
+		return BlockView{}, nil, err
+	}
+
+	for i := 0; i < n; i++ {
+		if _, rest, err = ReadTransactionView(rest); err != nil {
+			return BlockView{}, nil, err
+		}
+	}
+
+	return BlockView{
+		buf:   buf[:len(buf)-len(rest)],
+		nTxns: n,
+	}, rest, nil
+}
+
+// View encodes the block and returns a BlockView of the encoding
+func (b *Block) View() (BlockView, error) {
+	n1 := encodeSizeBlockHeader(&b.Head)
+	n2 := encodeSizeBlockBody(&b.Body)
+	buf := make([]byte, n1+n2)
+
+	if err := encodeBlockHeaderToBuffer(buf[:n1], &b.Head); err != nil {
+		return BlockView{}, err
+	}
+	if err := encodeBlockBodyToBuffer(buf[n1:], &b.Body); err != nil {
+		return BlockView{}, err
+	}
+
+	return BlockView{
+		buf:   buf,
+		nTxns: len(b.Body.Transactions),
+	}, nil
+}
+
+// Bytes returns the encoded block
+func (v BlockView) Bytes() []byte {
+	return v.buf
+}
+
+// Head decodes the block header
+func (v BlockView) Head() BlockHeader {
+	b := v.buf
+	bh := BlockHeader{
+		Version: binary.LittleEndian.Uint32(b[0:]),
+		Time:    binary.LittleEndian.Uint64(b[4:]),
+		BkSeq:   binary.LittleEndian.Uint64(b[12:]),
+		Fee:     binary.LittleEndian.Uint64(b[20:]),
+	}
+	copy(bh.PrevHash[:], b[28:60])
+	copy(bh.BodyHash[:], b[60:92])
+	copy(bh.UxHash[:], b[92:124])
+	return bh
+}
+
+// HashHeader returns the hash of the block header
+func (v BlockView) HashHeader() cipher.SHA256 {
+	return cipher.SHA256(sha256.Sum256(v.buf[:blockHeaderSize]))
+}
+
+// Time returns the head time of the block
+func (v BlockView) Time() uint64 {
+	return binary.LittleEndian.Uint64(v.buf[4:])
+}
+
+// Seq returns the head seq of the block
+func (v BlockView) Seq() uint64 {
+	return binary.LittleEndian.Uint64(v.buf[12:])
+}
+
+// NumTransactions returns the number of transactions in the block
+func (v BlockView) NumTransactions() int {
+	return v.nTxns
+}
+
+// ForEachTransaction calls f on the transactions of the block, in order.
+// Iteration stops at the first error returned by f.
+func (v BlockView) ForEachTransaction(f func(i int, txn TransactionView) error) error {
+	rest := v.buf[blockHeaderSize+4:]
+	for i := 0; i < v.nTxns; i++ {
+		var txn TransactionView
+		txn, rest = nextTransactionView(rest)
+		if err := f(i, txn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Block decodes the block
+func (v BlockView) Block() (Block, error) {
+	var b Block
+	if _, err := decodeBlockHeader(v.buf[:blockHeaderSize], &b.Head); err != nil {
+		return Block{}, err
+	}
+	if err := decodeBlockBodyExact(v.buf[blockHeaderSize:], &b.Body); err != nil {
+		return Block{}, err
+	}
+	return b, nil
+}
+
+// Equal returns true if the view and the block have the same contents
+func (v BlockView) Equal(b *Block) bool {
+	if v.Head() != b.Head || v.nTxns != len(b.Body.Transactions) {
+		return false
+	}
+
+	rest := v.buf[blockHeaderSize+4:]
+	for i := range b.Body.Transactions {
+		var txn TransactionView
+		txn, rest = nextTransactionView(rest)
+		if !txn.Equal(&b.Body.Transactions[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SignedBlockView is a read-only view of an encoded SignedBlock
+type SignedBlockView struct {
+	BlockView
+	Sig cipher.Sig
+}
+
+// ReadSignedBlockView creates a SignedBlockView of the SignedBlock encoded at the start of the buffer.
+// Returns the remainder of the buffer after the encoded signed block.
+func ReadSignedBlockView(buf []byte) (SignedBlockView, []byte, error) {
+	v, rest, err := ReadBlockView(buf)
+	if err != nil {
+		return SignedBlockView{}, nil, err
+	}
+
+	if len(rest) < sigSize {
+		return SignedBlockView{}, nil, encoder.ErrBufferUnderflow
+	}
+
+	b := SignedBlockView{
+		BlockView: v,
+	}
+	copy(b.Sig[:], rest[:sigSize])
+
+	return b, rest[sigSize:], nil
+}
+
+// VerifySignature verifies that the block is signed by pubkey
+func (b SignedBlockView) VerifySignature(pubkey cipher.PubKey) error {
+	return cipher.VerifyPubKeySignedHash(pubkey, b.Sig, b.HashHeader())
+}
+
+// SignedBlock decodes the signed block
+func (b SignedBlockView) SignedBlock() (SignedBlock, error) {
+	block, err := b.Block()
+	if err != nil {
+		return SignedBlock{}, err
+	}
+
+	return SignedBlock{
+		Block: block,
+		Sig:   b.Sig,
+	}, nil
+}
+
+// TransactionView is a read-only view of an encoded Transaction
+type TransactionView struct {
+	buf   []byte
+	nSigs int
+	nIn   int
+	nOut  int
+}
+
+// NewTransactionView creates a TransactionView of an encoded Transaction.
+// If the buffer is longer than the encoded transaction, returns encoder.ErrRemainingBytes.
+func NewTransactionView(buf []byte) (TransactionView, error) {
+	v, rest, err := ReadTransactionView(buf)
+	if err != nil {
+		return TransactionView{}, err
+	}
+
+	if len(rest) != 0 {
+		return TransactionView{}, encoder.ErrRemainingBytes
+	}
+
+	return v, nil
+}
+
+// ReadTransactionView creates a TransactionView of the Transaction encoded at the start of the buffer.
+// Returns the remainder of the buffer after the encoded transaction.
+func ReadTransactionView(buf []byte) (TransactionView, []byte, error) {
+	if len(buf) < transactionHeaderSize {
+		return TransactionView{}, nil, encoder.ErrBufferUnderflow
+	}
+
+	var v TransactionView
+	var err error
+	rest := buf[transactionHeaderSize:]
+
+	if v.nSigs, rest, err = readArray(rest, sigSize); err != nil {
+		return TransactionView{}, nil, err
+	}
+	if v.nIn, rest, err = readArray(rest, hashSize); err != nil {
+		return TransactionView{}, nil, err
+	}
+	if v.nOut, rest, err = readArray(rest, transactionOutputSize); err != nil {
+		return TransactionView{}, nil, err
+	}
+
+	v.buf = buf[:len(buf)-len(rest)]
+	return v, rest, nil
+}
+
+// nextTransactionView returns a view of the transaction at the start of an already validated buffer
+func nextTransactionView(buf []byte) (TransactionView, []byte) {
+	var v TransactionView
+	i := transactionHeaderSize
+
+	v.nSigs = int(binary.LittleEndian.Uint32(buf[i:]))
+	i += 4 + v.nSigs*sigSize
+	v.nIn = int(binary.LittleEndian.Uint32(buf[i:]))
+	i += 4 + v.nIn*hashSize
+	v.nOut = int(binary.LittleEndian.Uint32(buf[i:]))
+	i += 4 + v.nOut*transactionOutputSize
+
+	v.buf = buf[:i]
+	return v, buf[i:]
+}
+
+// readArrayLength reads the length prefix of an encoded slice, with the checks of the generated decoders
+func readArrayLength(buf []byte, maxLen int) (int, []byte, error) {
+	if len(buf) < 4 {
+		return 0, nil, encoder.ErrBufferUnderflow
+	}
+
+	n := int(binary.LittleEndian.Uint32(buf))
+	buf = buf[4:]
+	if n < 0 || n > len(buf) {
+		return 0, nil, encoder.ErrBufferUnderflow
+	}
+
+	if n > maxLen {
+		return 0, nil, encoder.ErrMaxLenExceeded
+	}
+
+	return n, buf, nil
+}
+
+// readArray reads an encoded slice of fixed size elements of a transaction
+func readArray(buf []byte, elemSize int) (int, []byte, error) {
+	n, buf, err := readArrayLength(buf, maxTransactionArrayLen)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if n*elemSize > len(buf) {
+		return 0, nil, encoder.ErrBufferUnderflow
+	}
+
+	return n, buf[n*elemSize:], nil
+}
+
+// Bytes returns the encoded transaction
+func (v TransactionView) Bytes() []byte {
+	return v.buf
+}
+
+// Length returns the Length field of the transaction header
+func (v TransactionView) Length() uint32 {
+	return binary.LittleEndian.Uint32(v.buf)
+}
+
+// Type returns the Type field of the transaction header
+func (v TransactionView) Type() uint8 {
+	return v.buf[4]
+}
+
+// InnerHash returns the InnerHash field of the transaction header
+func (v TransactionView) InnerHash() cipher.SHA256 {
+	var h cipher.SHA256
+	copy(h[:], v.buf[5:transactionHeaderSize])
+	return h
+}
+
+// Hash returns the hash of the transaction, the same as Transaction.Hash
+func (v TransactionView) Hash() cipher.SHA256 {
+	return cipher.SHA256(sha256.Sum256(v.buf))
+}
+
+// NumSigs returns the number of signatures
+func (v TransactionView) NumSigs() int {
+	return v.nSigs
+}
+
+// Sig returns the signature at index i
+func (v TransactionView) Sig(i int) cipher.Sig {
+	if i < 0 || i >= v.nSigs {
+		log.Panicf("TransactionView.Sig index %d out of range", i)
+	}
+
+	var sig cipher.Sig
+	off := v.sigsOffset() + i*sigSize
+	copy(sig[:], v.buf[off:off+sigSize])
+	return sig
+}
+
+// NumIn returns the number of inputs
+func (v TransactionView) NumIn() int {
+	return v.nIn
+}
+
+// In returns the input at index i
+func (v TransactionView) In(i int) cipher.SHA256 {
+	if i < 0 || i >= v.nIn {
+		log.Panicf("TransactionView.In index %d out of range", i)
+	}
+
+	var h cipher.SHA256
+	off := v.inOffset() + i*hashSize
+	copy(h[:], v.buf[off:off+hashSize])
+	return h
+}
+
+// NumOut returns the number of outputs
+func (v TransactionView) NumOut() int {
+	return v.nOut
+}
+
+// Out returns the output at index i
+func (v TransactionView) Out(i int) TransactionOutput {
+	if i < 0 || i >= v.nOut {
+		log.Panicf("TransactionView.Out index %d out of range", i)
+	}
+
+	b := v.buf[v.outOffset()+i*transactionOutputSize:]
+	o := TransactionOutput{
+		Coins: binary.LittleEndian.Uint64(b[21:]),
+		Hours: binary.LittleEndian.Uint64(b[29:]),
+	}
+	o.Address.Version = b[0]
+	copy(o.Address.Key[:], b[1:21])
+	return o
+}
+
+// CreateUnspent creates the unspent output at outIndex, like CreateUnspent.
+// txnHash is the hash of the transaction, passed in to avoid hashing the transaction for every output.
+func (v TransactionView) CreateUnspent(bh BlockHeader, txnHash cipher.SHA256, outIndex int) UxOut {
+	o := v.Out(outIndex)
+
+	var h cipher.SHA256
+	// The genesis block uses the null hash as the SrcTransaction [FIXME hardfork]
+	if bh.BkSeq != 0 {
+		h = txnHash
+	}
+
+	return UxOut{
+		Head: UxHead{
+			Time:  bh.Time,
+			BkSeq: bh.BkSeq,
+		},
+		Body: UxBody{
+			SrcTransaction: h,
+			Address:        o.Address,
+			Coins:          o.Coins,
+			Hours:          o.Hours,
+		},
+	}
+}
+
+// Transaction decodes the transaction
+func (v TransactionView) Transaction() (Transaction, error) {
+	var txn Transaction
+	if err := decodeTransactionExact(v.buf, &txn); err != nil {
+		return Transaction{}, err
+	}
+	return txn, nil
+}
+
+// Equal returns true if the view and the transaction have the same contents
+func (v TransactionView) Equal(txn *Transaction) bool {
+	if v.Length() != txn.Length || v.Type() != txn.Type || v.InnerHash() != txn.InnerHash ||
+		v.nSigs != len(txn.Sigs) || v.nIn != len(txn.In) || v.nOut != len(txn.Out) {
+		return false
+	}
+
+	for i := range txn.Sigs {
+		if v.Sig(i) != txn.Sigs[i] {
+			return false
+		}
+	}
+	for i := range txn.In {
+		if v.In(i) != txn.In[i] {
+			return false
+		}
+	}
+	for i := range txn.Out {
+		if v.Out(i) != txn.Out[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (v TransactionView) sigsOffset() int {
+	return transactionHeaderSize + 4
+}
+
+func (v TransactionView) inOffset() int {
+	return v.sigsOffset() + v.nSigs*sigSize + 4
+}
+
+func (v TransactionView) outOffset() int {
+	return v.inOffset() + v.nIn*hashSize + 4
+}
@@ -0,0 +1,240 @@
+package coin
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+func makeViewBlock(t *testing.T) *Block {
+	b := makeNewBlock(t, cipher.SHA256{})
+	addTransactionToBlock(t, b)
+	txn, _ := makeTransactionMultipleInputs(t, 3)
+	require.NoError(t, txn.PushOutput(makeAddress(), 1e6, 100))
+	require.NoError(t, txn.UpdateHeader())
+	b.Body.Transactions = append(b.Body.Transactions, txn)
+	return b
+}
+
+func encodeTestBlock(t *testing.T, b *Block) []byte {
+	v, err := b.View()
+	require.NoError(t, err)
+	return v.Bytes()
+}
+
+// decodeTestBlock decodes a block with the generated decoders
+func decodeTestBlock(buf []byte) (Block, error) {
+	var b Block
+	n, err := decodeBlockHeader(buf, &b.Head)
+	if err != nil {
+		return Block{}, err
+	}
+	if err := decodeBlockBodyExact(buf[n:], &b.Body); err != nil {
+		return Block{}, err
+	}
+	return b, nil
+}
+
+func TestBlockView(t *testing.T) {
+	b := makeViewBlock(t)
+	buf := append(b.Head.Bytes(), b.Body.Bytes()...)
+	require.Equal(t, buf, encodeTestBlock(t, b))
+
+	v, err := NewBlockView(buf)
+	require.NoError(t, err)
+
+	require.Equal(t, buf, v.Bytes())
+	require.Equal(t, b.Head, v.Head())
+	require.Equal(t, b.HashHeader(), v.HashHeader())
+	require.Equal(t, b.Seq(), v.Seq())
+	require.Equal(t, b.Time(), v.Time())
+	require.Equal(t, len(b.Body.Transactions), v.NumTransactions())
+	require.True(t, v.Equal(b))
+
+	b2, err := v.Block()
+	require.NoError(t, err)
+	require.Equal(t, *b, b2)
+
+	var n int
+	err = v.ForEachTransaction(func(i int, txn TransactionView) error {
+		require.Equal(t, n, i)
+		n++
+
+		expected := b.Body.Transactions[i]
+		require.Equal(t, expected.Length, txn.Length())
+		require.Equal(t, expected.Type, txn.Type())
+		require.Equal(t, expected.InnerHash, txn.InnerHash())
+		require.Equal(t, expected.Hash(), txn.Hash())
+		require.Equal(t, expected.MustSerialize(), txn.Bytes())
+		require.True(t, txn.Equal(&expected))
+
+		require.Equal(t, len(expected.Sigs), txn.NumSigs())
+		for j, sig := range expected.Sigs {
+			require.Equal(t, sig, txn.Sig(j))
+		}
+		require.Equal(t, len(expected.In), txn.NumIn())
+		for j, in := range expected.In {
+			require.Equal(t, in, txn.In(j))
+		}
+		require.Equal(t, len(expected.Out), txn.NumOut())
+		for j, out := range expected.Out {
+			require.Equal(t, out, txn.Out(j))
+		}
+
+		uxs := CreateUnspents(b.Head, expected)
+		for j := range uxs {
+			require.Equal(t, uxs[j], txn.CreateUnspent(b.Head, txn.Hash(), j))
+		}
+
+		// The genesis block uses the null hash as the SrcTransaction
+		genesisHead := b.Head
+		genesisHead.BkSeq = 0
+		uxs = CreateUnspents(genesisHead, expected)
+		for j := range uxs {
+			require.Equal(t, uxs[j], txn.CreateUnspent(genesisHead, txn.Hash(), j))
+		}
+
+		txn2, err := txn.Transaction()
+		require.NoError(t, err)
+		require.Equal(t, expected, txn2)
+
+		require.Panics(t, func() {
+			txn.Out(txn.NumOut())
+		})
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(b.Body.Transactions), n)
+
+	// A different block is not equal
+	b.Body.Transactions[1].Out[0].Hours++
+	require.False(t, v.Equal(b))
+}
+
+func TestBlockViewErrors(t *testing.T) {
+	b := makeViewBlock(t)
+	buf := encodeTestBlock(t, b)
+
+	// Every truncation of the encoded block fails like the generated decoders
+	for i := 0; i < len(buf); i++ {
+		_, err := NewBlockView(buf[:i])
+		_, expectedErr := decodeTestBlock(buf[:i])
+		require.Error(t, expectedErr)
+		require.Equal(t, expectedErr, err, "length %d", i)
+	}
+
+	_, err := NewBlockView(append(buf, 0))
+	require.Equal(t, encoder.ErrRemainingBytes, err)
+
+	v, rest, err := ReadBlockView(append(buf, 1, 2))
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, rest)
+	require.True(t, v.Equal(b))
+
+	// Too many transaction signatures
+	txn := b.Body.Transactions[0]
+	txnBuf := txn.MustSerialize()
+	binary.LittleEndian.PutUint32(txnBuf[transactionHeaderSize:], maxTransactionArrayLen+1)
+	txnBuf = append(txnBuf, make([]byte, (maxTransactionArrayLen+1)*sigSize)...)
+	_, err = NewTransactionView(txnBuf)
+	require.Equal(t, encoder.ErrMaxLenExceeded, err)
+	err = decodeTransactionExact(txnBuf, &txn)
+	require.Equal(t, encoder.ErrMaxLenExceeded, err)
+}
+
+func TestReadSignedBlockView(t *testing.T) {
+	b := makeViewBlock(t)
+	sb := SignedBlock{
+		Block: *b,
+		Sig:   cipher.MustSignHash(b.HashHeader(), genSecret),
+	}
+
+	buf := append(encodeTestBlock(t, b), sb.Sig[:]...)
+	buf = append(buf, 7)
+
+	v, rest, err := ReadSignedBlockView(buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{7}, rest)
+	require.Equal(t, sb.Sig, v.Sig)
+	require.NoError(t, v.VerifySignature(genPublic))
+
+	sb2, err := v.SignedBlock()
+	require.NoError(t, err)
+	require.Equal(t, sb, sb2)
+
+	_, _, err = ReadSignedBlockView(buf[:len(buf)-2])
+	require.Equal(t, encoder.ErrBufferUnderflow, err)
+}
+
+func TestBlockViewAllocs(t *testing.T) {
+	b := makeViewBlock(t)
+	v, err := NewBlockView(encodeTestBlock(t, b))
+	require.NoError(t, err)
+
+	var coins uint64
+	f := func(_ int, txn TransactionView) error {
+		h := txn.Hash()
+		for i := 0; i < txn.NumIn(); i++ {
+			_ = txn.In(i)
+		}
+		for i := 0; i < txn.NumSigs(); i++ {
+			_ = txn.Sig(i)
+		}
+		for i := 0; i < txn.NumOut(); i++ {
+			coins += txn.CreateUnspent(v.Head(), h, i).Body.Coins
+		}
+		return nil
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		v, err := NewBlockView(v.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = v.HashHeader()
+		if err := v.ForEachTransaction(f); err != nil {
+			t.Fatal(err)
+		}
+	})
+	require.Zero(t, allocs)
+	require.NotZero(t, coins)
+}
+
+func BenchmarkBlockView(b *testing.B) {
+	t := &testing.T{}
+	block := makeViewBlock(t)
+	for i := 0; i < 50; i++ {
+		addTransactionToBlock(t, block)
+	}
+	buf := encodeTestBlock(t, block)
+
+	b.Run("decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := decodeTestBlock(buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("view", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v, err := NewBlockView(buf)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := v.ForEachTransaction(func(_ int, txn TransactionView) error {
+				_ = txn.Hash()
+				return nil
+			}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
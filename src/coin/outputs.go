@@ -35,9 +35,6 @@ import (
 	- order spent (for rollbacks)
 */
 
-//go:generate skyencoder -struct UxHead -unexported
-//go:generate skyencoder -struct UxBody -unexported
-
 // UxOut represents uxout
 type UxOut struct {
 	Head UxHead
@@ -1,4 +1,4 @@
-// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT.
 
 package coin
 
@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
 )
 
 func newEmptyTransactionOutputsForEncodeTest() *transactionOutputs {
@@ -152,7 +153,7 @@ func testSkyencoderTransactionOutputs(t *testing.T, obj *transactionOutputs) {
 	}
 
 	if !bytes.Equal(data1, data2) {
-		t.Fatal("encoder.Serialize() != encode[1]s()")
+		t.Fatal("encoder.Serialize() != encodeTransactionOutputs()")
 	}
 
 	// Decode
@@ -419,3 +420,34 @@ func TestSkyencoderTransactionOutputsDecodeErrors(t *testing.T) {
 		testSkyencoderTransactionOutputsDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+func TestSkyencoderTransactionOutputsVectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("testdata/skyencoder-vectors.json", "TransactionOutputs")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj transactionOutputs
+			if err := decodeTransactionOutputsExact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decodeTransactionOutputsExact failed: %v", err)
+			}
+
+			data, err := encodeTransactionOutputs(&obj)
+			if err != nil {
+				t.Fatalf("encodeTransactionOutputs failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encodeTransactionOutputs() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
@@ -20,9 +20,7 @@ var (
 	DebugLevel2 = true
 )
 
-//go:generate skyencoder -struct Transaction -unexported
-//go:generate skyencoder -struct transactionInputs
-//go:generate skyencoder -struct transactionOutputs
+//go:generate go run github.com/skycoin/skycoin/cmd/skyencoder
 
 type transactionInputs struct {
 	In []cipher.SHA256 `enc:",maxlen=65535"`
@@ -51,6 +49,14 @@ The inner hash is SHA256 hash of the serialization of Input and Output array
 The outer hash is the hash of the whole transaction serialization
 */
 
+const (
+	// TransactionTypeDefault is the transaction type with recoverable ECDSA input signatures
+	TransactionTypeDefault uint8 = 0
+	// TransactionTypeSchnorr is the transaction type with Schnorr input signatures.
+	// See cipher.SignHashSchnorr.
+	TransactionTypeSchnorr uint8 = 1
+)
+
 // Transaction transaction struct
 type Transaction struct {
 	Length    uint32        // length prefix
@@ -117,7 +123,7 @@ func (txn *Transaction) verify(signed bool) error {
 		return errors.New("Duplicate spend")
 	}
 
-	if txn.Type != 0 {
+	if txn.Type != TransactionTypeDefault && txn.Type != TransactionTypeSchnorr {
 		return errors.New("transaction type invalid")
 	}
 
@@ -186,6 +192,15 @@ func (txn *Transaction) verify(signed bool) error {
 			continue
 		}
 
+		if txn.Type == TransactionTypeSchnorr {
+			// The public key of a Schnorr signature can only be recovered with the address,
+			// so only the encoding of the signature is checked here
+			if err := cipher.VerifySchnorrSignatureValidity(sig); err != nil {
+				return err
+			}
+			continue
+		}
+
 		hash := cipher.AddSHA256(txn.InnerHash, txn.In[i])
 		if err := cipher.VerifySignatureRecoverPubKey(sig, hash); err != nil {
 			return err
@@ -222,6 +237,28 @@ func (txn Transaction) verifyInputSignaturesPrelude(uxIn UxArray) error {
 
 // VerifyInputSignatures verifies the inputs and signatures
 func (txn Transaction) VerifyInputSignatures(uxIn UxArray) error {
+	return txn.VerifyInputSignaturesBatch(uxIn, nil)
+}
+
+// VerifyInputSignaturesBatch verifies the inputs and signatures, verifying the signatures with a cipher.BatchVerifier.
+// If v is nil, the signatures are verified one after another.
+func (txn Transaction) VerifyInputSignaturesBatch(uxIn UxArray, v *cipher.BatchVerifier) error {
+	return txn.verifyInputSignatures(uxIn, v, false)
+}
+
+// VerifyPartialInputSignatures verifies the inputs and signatures for signatures that are not null
+func (txn Transaction) VerifyPartialInputSignatures(uxIn UxArray) error {
+	return txn.VerifyPartialInputSignaturesBatch(uxIn, nil)
+}
+
+// VerifyPartialInputSignaturesBatch verifies the inputs and signatures for signatures that are not null,
+// verifying the signatures with a cipher.BatchVerifier.
+// If v is nil, the signatures are verified one after another.
+func (txn Transaction) VerifyPartialInputSignaturesBatch(uxIn UxArray, v *cipher.BatchVerifier) error {
+	return txn.verifyInputSignatures(uxIn, v, true)
+}
+
+func (txn Transaction) verifyInputSignatures(uxIn UxArray, v *cipher.BatchVerifier, partial bool) error {
 	if err := txn.verifyInputSignaturesPrelude(uxIn); err != nil {
 		if DebugLevel2 {
 			log.Panic(err)
@@ -229,44 +266,63 @@ func (txn Transaction) VerifyInputSignatures(uxIn UxArray) error {
 		return err
 	}
 
-	// Check signatures against unspent address
+	// Check signatures against unspent address, skipping null signatures
+	sigs := txn.inputSignedHashes(uxIn)
+	errs := v.VerifyBatch(sigs)
+
+	// Report the first invalid input
+	j := 0
 	for i := range txn.In {
 		if txn.Sigs[i].Null() {
+			if partial {
+				continue
+			}
 			return errors.New("Unsigned input in transaction")
 		}
 
-		hash := cipher.AddSHA256(txn.InnerHash, txn.In[i]) // use inner hash, not outer hash
-		err := cipher.VerifyAddressSignedHash(uxIn[i].Body.Address, txn.Sigs[i], hash)
-		if err != nil {
+		if errs[j] != nil {
 			return errors.New("Signature not valid for output being spent")
 		}
+		j++
 	}
 
 	return nil
 }
 
-// VerifyPartialInputSignatures verifies the inputs and signatures for signatures that are not null
-func (txn Transaction) VerifyPartialInputSignatures(uxIn UxArray) error {
+// InputSignedHashes returns the non-null signatures of the inputs, with the hashes they sign
+// and the addresses of the outputs being spent, for verification with a cipher.BatchVerifier
+func (txn Transaction) InputSignedHashes(uxIn UxArray) ([]cipher.SignedHash, error) {
 	if err := txn.verifyInputSignaturesPrelude(uxIn); err != nil {
-		if DebugLevel2 {
-			log.Panic(err)
-		}
-		return err
+		return nil, err
 	}
 
-	// Check signatures against unspent address for signatures that are not null
+	return txn.inputSignedHashes(uxIn), nil
+}
+
+func (txn Transaction) inputSignedHashes(uxIn UxArray) []cipher.SignedHash {
+	sigs := make([]cipher.SignedHash, 0, len(txn.In))
 	for i := range txn.In {
 		if txn.Sigs[i].Null() {
 			continue
 		}
-		hash := cipher.AddSHA256(txn.InnerHash, txn.In[i]) // use inner hash, not outer hash
-		err := cipher.VerifyAddressSignedHash(uxIn[i].Body.Address, txn.Sigs[i], hash)
-		if err != nil {
-			return errors.New("Signature not valid for output being spent")
-		}
+
+		sigs = append(sigs, cipher.SignedHash{
+			Address: uxIn[i].Body.Address,
+			Sig:     txn.Sigs[i],
+			Hash:    cipher.AddSHA256(txn.InnerHash, txn.In[i]), // use inner hash, not outer hash
+			Schnorr: txn.Type == TransactionTypeSchnorr,
+		})
 	}
 
-	return nil
+	return sigs
+}
+
+// signHash signs the hash of an input with the signature scheme of the transaction type
+func (txn *Transaction) signHash(hash cipher.SHA256, key cipher.SecKey) cipher.Sig {
+	if txn.Type == TransactionTypeSchnorr {
+		return cipher.MustSignHashSchnorr(hash, key)
+	}
+	return cipher.MustSignHash(hash, key)
 }
 
 // PushInput adds a unspent output hash to the inputs of a Transaction.
@@ -321,7 +377,7 @@ func (txn *Transaction) SignInput(key cipher.SecKey, index int) error {
 	}
 
 	h := cipher.AddSHA256(txn.InnerHash, txn.In[index])
-	txn.Sigs[index] = cipher.MustSignHash(h, key)
+	txn.Sigs[index] = txn.signHash(h, key)
 
 	return nil
 }
@@ -346,7 +402,7 @@ func (txn *Transaction) SignInputs(keys []cipher.SecKey) {
 	sigs := make([]cipher.Sig, len(txn.In))
 	for i, k := range keys {
 		h := cipher.AddSHA256(txn.InnerHash, txn.In[i]) // hash to sign
-		sigs[i] = cipher.MustSignHash(h, k)
+		sigs[i] = txn.signHash(h, k)
 	}
 	txn.Sigs = sigs
 }
@@ -433,14 +489,17 @@ func (txn *Transaction) SizeHash() (uint32, cipher.SHA256, error) {
 	return s, cipher.SumSHA256(b), nil
 }
 
-// UpdateHeader saves the txn body hash to TransactionHeader.Hash
+// UpdateHeader saves the txn body hash to TransactionHeader.Hash.
+// A Schnorr transaction keeps its type, any other type is reset to TransactionTypeDefault.
 func (txn *Transaction) UpdateHeader() error {
 	s, err := txn.Size()
 	if err != nil {
 		return err
 	}
 	txn.Length = s
-	txn.Type = byte(0x00)
+	if txn.Type != TransactionTypeSchnorr {
+		txn.Type = TransactionTypeDefault
+	}
 	txn.InnerHash = txn.HashInner()
 	return nil
 }
@@ -164,6 +164,17 @@ func TestTransactionVerify(t *testing.T) {
 	// from the unspent being spent
 	// The verification here only checks that the signature is valid at all
 
+	// Invalid transaction type
+	txn = makeTransaction(t)
+	txn.Type = 2
+	testutil.RequireError(t, txn.Verify(), "transaction type invalid")
+
+	// UpdateHeader resets an invalid transaction type
+	err = txn.UpdateHeader()
+	require.NoError(t, err)
+	require.Equal(t, TransactionTypeDefault, txn.Type)
+	require.NoError(t, txn.Verify())
+
 	// Output coins are 0
 	txn = makeTransaction(t)
 	txn.Out[0].Coins = 0
@@ -294,6 +305,197 @@ func TestTransactionVerifyInput(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func makeSchnorrTransactionFromUxOuts(t *testing.T, uxs []UxOut, secs []cipher.SecKey) Transaction {
+	require.Equal(t, len(uxs), len(secs))
+
+	txn := Transaction{
+		Type: TransactionTypeSchnorr,
+	}
+
+	err := txn.PushOutput(makeAddress(), 1e6, 50)
+	require.NoError(t, err)
+
+	for _, ux := range uxs {
+		err = txn.PushInput(ux.Hash())
+		require.NoError(t, err)
+	}
+
+	txn.SignInputs(secs)
+
+	err = txn.UpdateHeader()
+	require.NoError(t, err)
+	return txn
+}
+
+func TestTransactionSchnorr(t *testing.T) {
+	ux, s := makeUxOutWithSecret(t)
+	ux2, s2 := makeUxOutWithSecret(t)
+	txn := makeSchnorrTransactionFromUxOuts(t, []UxOut{ux, ux2}, []cipher.SecKey{s, s2})
+	require.Equal(t, TransactionTypeSchnorr, txn.Type)
+	require.True(t, txn.IsFullySigned())
+
+	require.NoError(t, txn.Verify())
+	require.NoError(t, txn.VerifyInputSignatures(UxArray{ux, ux2}))
+	require.NoError(t, txn.VerifyPartialInputSignatures(UxArray{ux, ux2}))
+
+	for i, sig := range txn.Sigs {
+		h := cipher.AddSHA256(txn.InnerHash, txn.In[i])
+		require.NoError(t, cipher.VerifyAddressSchnorrSignedHash([]UxOut{ux, ux2}[i].Body.Address, sig, h))
+	}
+
+	// UpdateHeader does not change the transaction type
+	txn.InnerHash = cipher.SHA256{}
+	require.NoError(t, txn.UpdateHeader())
+	require.Equal(t, TransactionTypeSchnorr, txn.Type)
+	require.NoError(t, txn.Verify())
+
+	// The transaction encodes and decodes with its type
+	b, err := txn.Serialize()
+	require.NoError(t, err)
+	txn2, err := DeserializeTransaction(b)
+	require.NoError(t, err)
+	require.Equal(t, txn, txn2)
+
+	// Schnorr signatures are not valid for the default transaction type
+	txn2 = copyTransaction(txn)
+	txn2.Type = TransactionTypeDefault
+	require.NoError(t, txn2.UpdateHeader())
+	require.Error(t, txn2.VerifyInputSignatures(UxArray{ux, ux2}))
+
+	// ECDSA signatures are not valid for the Schnorr transaction type
+	txn2 = makeTransactionFromUxOuts(t, []UxOut{ux, ux2}, []cipher.SecKey{s, s2})
+	txn2.Type = TransactionTypeSchnorr
+	require.NoError(t, txn2.UpdateHeader())
+	require.Error(t, txn2.VerifyInputSignatures(UxArray{ux, ux2}))
+
+	// Invalid signature encoding
+	txn2 = copyTransaction(txn)
+	txn2.Sigs[0][64] = 1
+	testutil.RequireError(t, txn2.Verify(), cipher.ErrInvalidSchnorrSig.Error())
+
+	// Signature signed by someone else
+	txn2 = makeSchnorrTransactionFromUxOuts(t, []UxOut{ux, ux2}, []cipher.SecKey{s2, s})
+	require.NoError(t, txn2.Verify())
+	err = txn2.VerifyInputSignatures(UxArray{ux, ux2})
+	testutil.RequireError(t, err, "Signature not valid for output being spent")
+
+	// Partially signed
+	txn2 = copyTransaction(txn)
+	txn2.Sigs[1] = cipher.Sig{}
+	require.NoError(t, txn2.VerifyUnsigned())
+	require.NoError(t, txn2.VerifyPartialInputSignatures(UxArray{ux, ux2}))
+	require.NoError(t, txn2.SignInput(s2, 1))
+	require.NoError(t, txn2.Verify())
+	require.NoError(t, txn2.VerifyInputSignatures(UxArray{ux, ux2}))
+}
+
+func TestTransactionSchnorrMuSig(t *testing.T) {
+	// An output owned by the aggregated public key of 3 signers
+	n := 3
+	pubkeys := make([]cipher.PubKey, n)
+	seckeys := make([]cipher.SecKey, n)
+	for i := range pubkeys {
+		pubkeys[i], seckeys[i] = cipher.GenerateKeyPair()
+	}
+
+	ux, _ := makeUxOutWithSecret(t)
+	ux.Body.Address = cipher.AddressFromPubKey(cipher.MustMuSigAggregatePubKeys(pubkeys))
+
+	txn := Transaction{
+		Type: TransactionTypeSchnorr,
+	}
+	require.NoError(t, txn.PushInput(ux.Hash()))
+	require.NoError(t, txn.PushOutput(makeAddress(), 1e6, 50))
+	txn.Sigs = make([]cipher.Sig, 1)
+	require.NoError(t, txn.UpdateHeader())
+
+	h := cipher.AddSHA256(txn.InnerHash, txn.In[0])
+
+	secNonces := make([]cipher.MuSigSecNonce, n)
+	pubNonces := make([]cipher.MuSigPubNonce, n)
+	for i := range secNonces {
+		secNonces[i], pubNonces[i] = cipher.GenerateMuSigNonce()
+	}
+	aggNonce, err := cipher.MuSigAggregateNonces(pubNonces)
+	require.NoError(t, err)
+
+	psigs := make([]cipher.MuSigPartialSig, n)
+	for i := range psigs {
+		psigs[i], err = cipher.MuSigPartialSign(&secNonces[i], seckeys[i], pubkeys, aggNonce, h)
+		require.NoError(t, err)
+	}
+
+	txn.Sigs[0], err = cipher.MuSigAggregatePartialSigs(psigs, pubkeys, aggNonce, h)
+	require.NoError(t, err)
+
+	require.NoError(t, txn.Verify())
+	require.NoError(t, txn.VerifyInputSignatures(UxArray{ux}))
+
+	// A single signer can't spend the output
+	txn.Sigs = nil
+	txn.SignInputs([]cipher.SecKey{seckeys[0]})
+	require.NoError(t, txn.Verify())
+	err = txn.VerifyInputSignatures(UxArray{ux})
+	testutil.RequireError(t, err, "Signature not valid for output being spent")
+}
+
+func TestTransactionVerifyInputSignaturesBatch(t *testing.T) {
+	n := 10
+	uxs := make(UxArray, n)
+	secs := make([]cipher.SecKey, n)
+	for i := range uxs {
+		uxs[i], secs[i] = makeUxOutWithSecret(t)
+	}
+
+	txn := makeTransactionFromUxOuts(t, uxs, secs)
+	schnorrTxn := makeSchnorrTransactionFromUxOuts(t, uxs, secs)
+
+	for _, v := range []*cipher.BatchVerifier{
+		nil,
+		cipher.NewBatchVerifier(4, nil),
+		cipher.NewBatchVerifier(4, cipher.NewSigCache(100)),
+	} {
+		for _, txn := range []Transaction{txn, schnorrTxn} {
+			require.NoError(t, txn.VerifyInputSignaturesBatch(uxs, v))
+			require.NoError(t, txn.VerifyPartialInputSignaturesBatch(uxs, v))
+
+			sigs, err := txn.InputSignedHashes(uxs)
+			require.NoError(t, err)
+			require.Len(t, sigs, n)
+			for _, sh := range sigs {
+				require.Equal(t, txn.Type == TransactionTypeSchnorr, sh.Schnorr)
+				if v.Cache() != nil {
+					require.True(t, v.Cache().Contains(sh))
+				}
+			}
+
+			// The first invalid input is reported
+			txn2 := copyTransaction(txn)
+			txn2.Sigs[7] = txn2.Sigs[6]
+			txn2.Sigs[8] = cipher.Sig{}
+			err = txn2.VerifyInputSignaturesBatch(uxs, v)
+			testutil.RequireError(t, err, "Signature not valid for output being spent")
+			err = txn2.VerifyPartialInputSignaturesBatch(uxs, v)
+			testutil.RequireError(t, err, "Signature not valid for output being spent")
+
+			txn2 = copyTransaction(txn)
+			txn2.Sigs[2] = cipher.Sig{}
+			txn2.Sigs[8] = txn2.Sigs[6]
+			err = txn2.VerifyInputSignaturesBatch(uxs, v)
+			testutil.RequireError(t, err, "Unsigned input in transaction")
+			err = txn2.VerifyPartialInputSignaturesBatch(uxs, v)
+			testutil.RequireError(t, err, "Signature not valid for output being spent")
+
+			sigs, err = txn2.InputSignedHashes(uxs)
+			require.NoError(t, err)
+			require.Len(t, sigs, n-1)
+
+			_, err = txn2.InputSignedHashes(uxs[1:])
+			testutil.RequireError(t, err, "txn.In != uxIn")
+		}
+	}
+}
+
 func TestTransactionPushInput(t *testing.T) {
 	txn := &Transaction{}
 	ux := makeUxOut(t)
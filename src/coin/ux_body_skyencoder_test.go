@@ -1,4 +1,4 @@
-// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT.
 
 package coin
 
@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
 )
 
 func newEmptyUxBodyForEncodeTest() *UxBody {
@@ -152,7 +153,7 @@ func testSkyencoderUxBody(t *testing.T, obj *UxBody) {
 	}
 
 	if !bytes.Equal(data1, data2) {
-		t.Fatal("encoder.Serialize() != encode[1]s()")
+		t.Fatal("encoder.Serialize() != encodeUxBody()")
 	}
 
 	// Decode
@@ -419,3 +420,34 @@ func TestSkyencoderUxBodyDecodeErrors(t *testing.T) {
 		testSkyencoderUxBodyDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+func TestSkyencoderUxBodyVectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("testdata/skyencoder-vectors.json", "UxBody")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj UxBody
+			if err := decodeUxBodyExact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decodeUxBodyExact failed: %v", err)
+			}
+
+			data, err := encodeUxBody(&obj)
+			if err != nil {
+				t.Fatalf("encodeUxBody failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encodeUxBody() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
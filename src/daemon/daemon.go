@@ -24,6 +24,7 @@ import (
 	"github.com/skycoin/skycoin/src/util/fee"
 	"github.com/skycoin/skycoin/src/util/iputil"
 	"github.com/skycoin/skycoin/src/util/logging"
+	"github.com/skycoin/skycoin/src/util/metrics"
 	"github.com/skycoin/skycoin/src/util/useragent"
 	"github.com/skycoin/skycoin/src/visor"
 	"github.com/skycoin/skycoin/src/visor/dbutil"
@@ -56,6 +57,10 @@ const (
 	daemonRunDurationThreshold = time.Millisecond * 200
 )
 
+var (
+	runEventDuration = metrics.NewHistogramVec("skycoin_daemon_event_duration_seconds", "Duration of the events handled by the daemon run loops", nil, "event")
+)
+
 // Config subsystem configurations
 type Config struct {
 	Daemon   DaemonConfig
@@ -432,7 +437,7 @@ func (dm *Daemon) Run() error {
 	}
 
 	var setupErr error
-	elapser := elapse.NewElapser(daemonRunDurationThreshold, logger)
+	elapser := newRunElapser()
 	wg.Add(1)
 	go dm.startMessageSendResultProcess(&wg)
 	wg.Add(1)
@@ -516,7 +521,7 @@ func (dm *Daemon) startPex(wg *sync.WaitGroup, errC chan error) {
 		}
 	}()
 
-	elapser := elapse.NewElapser(daemonRunDurationThreshold, logger)
+	elapser := newRunElapser()
 	requestPeersTicker := time.NewTicker(dm.pex.Config.RequestRate)
 	defer requestPeersTicker.Stop()
 
@@ -560,7 +565,7 @@ func (dm *Daemon) startConnPool(wg *sync.WaitGroup, errC chan error) {
 		}
 	}()
 
-	elapser := elapse.NewElapser(daemonRunDurationThreshold, logger)
+	elapser := newRunElapser()
 
 	idleCheckTicker := time.NewTicker(dm.pool.Config.IdleCheckRate)
 	defer idleCheckTicker.Stop()
@@ -644,13 +649,22 @@ func (dm *Daemon) startConnPool(wg *sync.WaitGroup, errC chan error) {
 	}
 }
 
+// newRunElapser creates an Elapser for the daemon run loops, which records the duration of the events in a metric
+func newRunElapser() *elapse.Elapser {
+	elapser := elapse.NewElapser(daemonRunDurationThreshold, logger)
+	elapser.SetObserver(func(name string, elapsed time.Duration) {
+		runEventDuration.ObserveDuration(elapsed, name)
+	})
+	return elapser
+}
+
 // Process SendResults in a separate goroutine, otherwise SendResults
 // will fill up much faster than can be processed by the daemon run loop
 // dm.handleMessageSendResult must take care not to perform any operation
 // that would violate thread safety, since it is not serialized by the daemon run loop
 func (dm *Daemon) startMessageSendResultProcess(wg *sync.WaitGroup) {
 	defer wg.Done()
-	elapser := elapse.NewElapser(daemonRunDurationThreshold, logger)
+	elapser := newRunElapser()
 	for {
 		elapser.CheckForDone()
 		select {
@@ -674,7 +688,7 @@ func (dm *Daemon) startUnconfirmedTxnsProcess(wg *sync.WaitGroup) {
 	defer unconfirmedRefreshTicker.Stop()
 	unconfirmedRemoveInvalidTicker := time.NewTicker(dm.config.UnconfirmedRemoveInvalidRate)
 	defer unconfirmedRemoveInvalidTicker.Stop()
-	elapser := elapse.NewElapser(daemonRunDurationThreshold, logger)
+	elapser := newRunElapser()
 	defer wg.Done()
 	for {
 		select {
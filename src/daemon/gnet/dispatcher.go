@@ -12,11 +12,21 @@ import (
 
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 	"github.com/skycoin/skycoin/src/util/mathutil"
+	"github.com/skycoin/skycoin/src/util/metrics"
+)
+
+const (
+	// unknownMessageType is the message type label of messages with an unknown or truncated message ID
+	unknownMessageType = "unknown"
 )
 
 var (
 	// ErrMsgExceedsMaxLen is returned if trying to send a message that exceeds the configured max length
 	ErrMsgExceedsMaxLen = errors.New("Message exceeds max message length")
+
+	messagesTotal      = metrics.NewCounterVec("skycoin_gnet_messages_total", "Number of messages sent and received, by message type", "direction", "type")
+	messageBytesTotal  = metrics.NewCounterVec("skycoin_gnet_message_bytes_total", "Number of message bytes sent and received, by message type", "direction", "type")
+	messageErrorsTotal = metrics.NewCounterVec("skycoin_gnet_message_errors_total", "Number of messages that failed to be sent or decoded, by message type", "direction", "type")
 )
 
 // SendResult result of a single message send
@@ -36,14 +46,34 @@ func newSendResult(addr string, m Message, err error) SendResult {
 
 // Serializes a Message over a net.Conn
 func sendMessage(conn net.Conn, msg Message, timeout time.Duration, maxMsgLength int) error {
+	msgType := messageTypeLabel(reflect.ValueOf(msg).Elem().Type())
+
 	m, err := EncodeMessage(msg)
 	if err != nil {
+		messageErrorsTotal.Inc("sent", msgType)
 		return err
 	}
 	if len(m) > maxMsgLength {
+		messageErrorsTotal.Inc("sent", msgType)
 		return ErrMsgExceedsMaxLen
 	}
-	return sendByteMessage(conn, m, timeout)
+	if err := sendByteMessage(conn, m, timeout); err != nil {
+		messageErrorsTotal.Inc("sent", msgType)
+		return err
+	}
+
+	messagesTotal.Inc("sent", msgType)
+	messageBytesTotal.Add(float64(len(m)), "sent", msgType)
+	return nil
+}
+
+// messageTypeLabel returns the message ID of a registered message type, to label metrics
+func messageTypeLabel(t reflect.Type) string {
+	msgID, ok := MessageIDMap[t]
+	if !ok {
+		return unknownMessageType
+	}
+	return msgIDStringSafe(msgID)
 }
 
 // msgIDStringSafe formats msgID bytes to a string that is safe for logging (e.g. not impacted by ascii control chars)
@@ -57,6 +87,7 @@ func convertToMessage(id uint64, msg []byte, debugPrint bool) (Message, error) {
 	msgID := [4]byte{}
 	if len(msg) < len(msgID) {
 		logger.WithError(ErrDisconnectTruncatedMessageID).WithField("connID", id).Warning()
+		messageErrorsTotal.Inc("received", unknownMessageType)
 		return nil, ErrDisconnectTruncatedMessageID
 	}
 
@@ -66,6 +97,7 @@ func convertToMessage(id uint64, msg []byte, debugPrint bool) (Message, error) {
 		logger.WithField("msgID", msgIDStringSafe(msgID)).Debug("Received message")
 	}
 
+	msgLen := len(msg) + messageLengthPrefixSize
 	msg = msg[len(msgID):]
 	t, ok := MessageIDReverseMap[msgID]
 	if !ok {
@@ -73,9 +105,12 @@ func convertToMessage(id uint64, msg []byte, debugPrint bool) (Message, error) {
 			"msgID":  msgIDStringSafe(msgID),
 			"connID": id,
 		}).Warning()
+		messageErrorsTotal.Inc("received", unknownMessageType)
 		return nil, ErrDisconnectUnknownMessage
 	}
 
+	msgType := msgIDStringSafe(msgID)
+
 	if debugPrint {
 		logger.WithFields(logrus.Fields{
 			"connID":      id,
@@ -99,6 +134,7 @@ func convertToMessage(id uint64, msg []byte, debugPrint bool) (Message, error) {
 			"connID":      id,
 			"messageType": fmt.Sprintf("%v", t),
 		}).Warning("deserializeMessage failed")
+		messageErrorsTotal.Inc("received", msgType)
 		return nil, ErrDisconnectMalformedMessage
 	}
 
@@ -107,9 +143,13 @@ func convertToMessage(id uint64, msg []byte, debugPrint bool) (Message, error) {
 			"connID":      id,
 			"messageType": fmt.Sprintf("%v", t),
 		}).Warning()
+		messageErrorsTotal.Inc("received", msgType)
 		return nil, ErrDisconnectMessageDecodeUnderflow
 	}
 
+	messagesTotal.Inc("received", msgType)
+	messageBytesTotal.Add(float64(msgLen), "received", msgType)
+
 	return m, nil
 }
 
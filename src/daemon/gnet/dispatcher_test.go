@@ -53,6 +53,8 @@ func TestConvertToMessage(t *testing.T) {
 	b := make([]byte, 0)
 	b = append(b, BytePrefix[:]...)
 	b = append(b, byte(7))
+	received := messagesTotal.Value("received", "BYTE")
+	receivedBytes := messageBytesTotal.Value("received", "BYTE")
 	m, err := convertToMessage(c.ID, b, testing.Verbose())
 	require.NoError(t, err)
 	require.NotNil(t, m)
@@ -61,6 +63,8 @@ func TestConvertToMessage(t *testing.T) {
 	}
 	bm := m.(*ByteMessage)
 	require.Equal(t, bm.X, byte(7))
+	require.Equal(t, received+1, messagesTotal.Value("received", "BYTE"))
+	require.Equal(t, receivedBytes+9, messageBytesTotal.Value("received", "BYTE"))
 }
 
 func TestConvertToMessageNoMessageID(t *testing.T) {
@@ -192,11 +196,18 @@ func TestSendMessage(t *testing.T) {
 		require.True(t, bytes.Equal(msg, expect))
 		return nil
 	}
+	sent := messagesTotal.Value("sent", "BYTE")
+	sentBytes := messageBytesTotal.Value("sent", "BYTE")
+	sendErrors := messageErrorsTotal.Value("sent", "BYTE")
+
 	err := sendMessage(nil, m, 0, 1024)
 	require.NoError(t, err)
+	require.Equal(t, sent+1, messagesTotal.Value("sent", "BYTE"))
+	require.Equal(t, sentBytes+9, messageBytesTotal.Value("sent", "BYTE"))
 
 	err = sendMessage(nil, m, 0, 1)
 	testutil.RequireError(t, err, "Message exceeds max message length")
+	require.Equal(t, sendErrors+1, messageErrorsTotal.Value("sent", "BYTE"))
 }
 
 /* Helpers */
@@ -44,18 +44,7 @@ func NewMessageConfig(prefix string, m interface{}) MessageConfig {
 	}
 }
 
-//go:generate skyencoder -unexported -struct IntroductionMessage
-//go:generate skyencoder -unexported -struct GivePeersMessage
-//go:generate skyencoder -unexported -struct GetBlocksMessage
-//go:generate skyencoder -unexported -struct GiveBlocksMessage
-//go:generate skyencoder -unexported -struct AnnounceBlocksMessage
-//go:generate skyencoder -unexported -struct GetTxnsMessage
-//go:generate skyencoder -unexported -struct GiveTxnsMessage
-//go:generate skyencoder -unexported -struct AnnounceTxnsMessage
-//go:generate skyencoder -unexported -struct DisconnectMessage
-//go:generate skyencoder -unexported -struct IPAddr
-//go:generate skyencoder -unexported -output-path . -package daemon -struct SignedBlock github.com/skycoin/skycoin/src/coin
-//go:generate skyencoder -unexported -output-path . -package daemon -struct Transaction github.com/skycoin/skycoin/src/coin
+//go:generate go run github.com/skycoin/skycoin/cmd/skyencoder
 
 // Creates and populates the message configs
 func getMessageConfigs() []MessageConfig {
@@ -1,4 +1,4 @@
-// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT.
 
 package daemon
 
@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
 	"github.com/skycoin/skycoin/src/coin"
 )
 
@@ -153,7 +154,7 @@ func testSkyencoderSignedBlock(t *testing.T, obj *coin.SignedBlock) {
 	}
 
 	if !bytes.Equal(data1, data2) {
-		t.Fatal("encoder.Serialize() != encode[1]s()")
+		t.Fatal("encoder.Serialize() != encodeSignedBlock()")
 	}
 
 	// Decode
@@ -420,3 +421,34 @@ func TestSkyencoderSignedBlockDecodeErrors(t *testing.T) {
 		testSkyencoderSignedBlockDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+func TestSkyencoderSignedBlockVectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("testdata/skyencoder-vectors.json", "SignedBlock")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj coin.SignedBlock
+			if err := decodeSignedBlockExact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decodeSignedBlockExact failed: %v", err)
+			}
+
+			data, err := encodeSignedBlock(&obj)
+			if err != nil {
+				t.Fatalf("encodeSignedBlock failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encodeSignedBlock() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/skycoin/skycoin/src/util/logging"
+	"github.com/skycoin/skycoin/src/util/metrics"
 )
 
 const (
@@ -24,6 +25,9 @@ const (
 var (
 	// Debug enables debug logging
 	Debug = false
+
+	queueDuration = metrics.NewHistogramVec("skycoin_strand_queue_duration_seconds", "Time spent by strand operations waiting in the queue", nil, "operation")
+	callDuration  = metrics.NewHistogramVec("skycoin_strand_call_duration_seconds", "Duration of strand operations", nil, "operation")
 )
 
 // Request is sent to the channel provided to Strand
@@ -45,6 +49,7 @@ func Strand(logger *logging.Logger, c chan Request, name string, f func() error,
 
 	done := make(chan struct{})
 	var err error
+	queued := time.Now()
 
 	req := Request{
 		Name: name,
@@ -55,6 +60,7 @@ func Strand(logger *logging.Logger, c chan Request, name string, f func() error,
 			// logger.Debugf("%s begin", name)
 
 			t := time.Now()
+			queueDuration.ObserveDuration(t.Sub(queued), name)
 
 			// Log function duration at an exponential time interval,
 			// this will notify us of any long running functions to look at.
@@ -91,6 +97,7 @@ func Strand(logger *logging.Logger, c chan Request, name string, f func() error,
 
 			// Notify us if the function call took too long
 			elapsed := time.Since(t)
+			callDuration.ObserveDuration(elapsed, name)
 			if elapsed > logDurationThreshold {
 				logger.WithFields(logrus.Fields{
 					"operation": name,
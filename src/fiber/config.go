@@ -104,6 +104,11 @@ type ParamsConfig struct {
 	DistributionAddresses []string `mapstructure:"distribution_addresses"`
 	// UserBurnFactor inverse fraction of coinhours that must be burned, this value is used when creating transactions
 	UserBurnFactor uint64 `mapstructure:"user_burn_factor"`
+	// AddressHRP is the human-readable part of bech32 encoded addresses
+	AddressHRP string `mapstructure:"address_hrp"`
+	// SchnorrActivationSeq is the sequence number of the first block that can include Schnorr transactions.
+	// Schnorr transactions are disabled if 0
+	SchnorrActivationSeq uint64 `mapstructure:"schnorr_activation_seq"`
 }
 
 // NewConfig loads blockchain config parameters from a config file
@@ -177,4 +182,6 @@ func setDefaults() {
 	viper.SetDefault("params.user_max_decimals", 3)
 	viper.SetDefault("params.user_burn_factor", 10)
 	viper.SetDefault("params.user_max_transaction_size", 32*1024)
+	viper.SetDefault("params.address_hrp", "sky")
+	viper.SetDefault("params.schnorr_activation_seq", 0)
 }
@@ -56,6 +56,8 @@ func TestNewConfig(t *testing.T) {
 			UserBurnFactor:          3,
 			UserMaxTransactionSize:  999,
 			UserMaxDropletPrecision: 2,
+			AddressHRP:              "tst",
+			SchnorrActivationSeq:    1000,
 		},
 	}, coinConfig)
 }
@@ -0,0 +1,95 @@
+package kvstorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/skycoin/skycoin/src/cipher"
+)
+
+var (
+	// ErrContactMissingAddresses is returned if an address book contact has no addresses
+	ErrContactMissingAddresses = NewError(errors.New("contact must have at least one address"))
+	// ErrContactDuplicateAddress is returned if an address book contact has duplicate addresses
+	ErrContactDuplicateAddress = NewError(errors.New("contact has duplicate addresses"))
+)
+
+// Contact is an entry of the address book storage.
+// Contacts are stored JSON encoded, keyed by the contact name.
+type Contact struct {
+	// Addresses are skycoin or bitcoin addresses
+	Addresses []string `json:"addresses"`
+	Notes     string   `json:"notes,omitempty"`
+}
+
+// ParseContact parses and validates a JSON encoded Contact
+func ParseContact(val string) (*Contact, error) {
+	d := json.NewDecoder(bytes.NewReader([]byte(val)))
+	d.DisallowUnknownFields()
+
+	var c Contact
+	if err := d.Decode(&c); err != nil {
+		return nil, NewError(fmt.Errorf("invalid contact: %v", err))
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Validate validates the contact addresses
+func (c Contact) Validate() error {
+	if len(c.Addresses) == 0 {
+		return ErrContactMissingAddresses
+	}
+
+	addrs := make(map[string]struct{}, len(c.Addresses))
+	for _, a := range c.Addresses {
+		if !isValidContactAddress(a) {
+			return NewError(fmt.Errorf("invalid contact address %q: not a valid skycoin or bitcoin address", a))
+		}
+
+		if _, ok := addrs[a]; ok {
+			return ErrContactDuplicateAddress
+		}
+		addrs[a] = struct{}{}
+	}
+
+	return nil
+}
+
+// SkycoinAddresses returns the skycoin addresses of the contact
+func (c Contact) SkycoinAddresses() []cipher.Address {
+	var addrs []cipher.Address
+	for _, a := range c.Addresses {
+		if addr, err := cipher.DecodeBase58Address(a); err == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// isValidContactAddress returns true if the address is a valid skycoin or bitcoin address
+func isValidContactAddress(a string) bool {
+	if _, err := cipher.DecodeBase58Address(a); err == nil {
+		return true
+	}
+
+	_, err := cipher.DecodeBase58BitcoinAddress(a)
+	return err == nil
+}
+
+// validateStorageValue validates a value added to the storage of `storageType`
+func validateStorageValue(storageType Type, val string) error {
+	switch storageType {
+	case TypeAddressBook:
+		_, err := ParseContact(val)
+		return err
+	default:
+		return nil
+	}
+}
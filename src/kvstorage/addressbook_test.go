@@ -0,0 +1,24 @@
+package kvstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+)
+
+func TestContactSkycoinAddresses(t *testing.T) {
+	c, err := ParseContact(`{"addresses":["1FeexV6bAHb8ybZjqQMjJrcCrHGW9sb6uF","2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP","2UDzBKnxZf4d9pdrBJAqbtoeH641RFLYKxd"]}`)
+	require.NoError(t, err)
+
+	require.Equal(t, []cipher.Address{
+		cipher.MustDecodeBase58Address("2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP"),
+		cipher.MustDecodeBase58Address("2UDzBKnxZf4d9pdrBJAqbtoeH641RFLYKxd"),
+	}, c.SkycoinAddresses())
+
+	c, err = ParseContact(`{"addresses":["1FeexV6bAHb8ybZjqQMjJrcCrHGW9sb6uF"],"notes":"bitcoin only"}`)
+	require.NoError(t, err)
+	require.Equal(t, "bitcoin only", c.Notes)
+	require.Empty(t, c.SkycoinAddresses())
+}
@@ -20,6 +20,8 @@ const (
 	TypeTxIDNotes Type = "txid"
 	// TypeGeneral is a type of storage for general user data
 	TypeGeneral Type = "client"
+	// TypeAddressBook is a type of storage containing address book contacts, see Contact
+	TypeAddressBook Type = "addressbook"
 )
 
 const storageFileExtension = ".json"
@@ -183,6 +185,7 @@ func (m *Manager) GetAllStorageValues(storageType Type) (map[string]string, erro
 }
 
 // AddStorageValue adds the `val` with the associated `key` to the storage of `storageType`.
+// Values of the address book storage must be valid JSON encoded Contacts.
 // Returns `ErrNoSuchStorage`, `ErrStorageAPIDisabled`, `ErrUnknownKVStorageType`
 func (m *Manager) AddStorageValue(storageType Type, key, val string) error {
 	if !isStorageTypeValid(storageType) {
@@ -200,6 +203,10 @@ func (m *Manager) AddStorageValue(storageType Type, key, val string) error {
 		return ErrNoSuchStorage
 	}
 
+	if err := validateStorageValue(storageType, val); err != nil {
+		return err
+	}
+
 	return m.storages[storageType].add(key, val)
 }
 
@@ -239,7 +246,7 @@ func (m *Manager) getStorageFilePath(storageType Type) string {
 // isStorageTypeValid validates the given `storageType` against the predefined available types
 func isStorageTypeValid(storageType Type) bool {
 	switch storageType {
-	case TypeTxIDNotes, TypeGeneral:
+	case TypeTxIDNotes, TypeGeneral, TypeAddressBook:
 		return true
 	}
 
@@ -1,6 +1,7 @@
 package kvstorage
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -445,6 +446,80 @@ func TestManagerAddStorageValue(t *testing.T) {
 			key:               "test1",
 			val:               "oiuy",
 		},
+		{
+			name:              "address book invalid json",
+			enableAPI:         true,
+			loadStorage:       true,
+			storageTypeToLoad: TypeAddressBook,
+			storageType:       TypeAddressBook,
+			key:               "alice",
+			val:               "val",
+			expect: expect{
+				expectErr: true,
+				err:       NewError(errors.New("invalid contact: invalid character 'v' looking for beginning of value")),
+			},
+		},
+		{
+			name:              "address book unknown field",
+			enableAPI:         true,
+			loadStorage:       true,
+			storageTypeToLoad: TypeAddressBook,
+			storageType:       TypeAddressBook,
+			key:               "alice",
+			val:               `{"addresses":["2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP"],"foo":"bar"}`,
+			expect: expect{
+				expectErr: true,
+				err:       NewError(errors.New(`invalid contact: json: unknown field "foo"`)),
+			},
+		},
+		{
+			name:              "address book no addresses",
+			enableAPI:         true,
+			loadStorage:       true,
+			storageTypeToLoad: TypeAddressBook,
+			storageType:       TypeAddressBook,
+			key:               "alice",
+			val:               `{"addresses":[],"notes":"friend"}`,
+			expect: expect{
+				expectErr: true,
+				err:       ErrContactMissingAddresses,
+			},
+		},
+		{
+			name:              "address book invalid address",
+			enableAPI:         true,
+			loadStorage:       true,
+			storageTypeToLoad: TypeAddressBook,
+			storageType:       TypeAddressBook,
+			key:               "alice",
+			val:               `{"addresses":["2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmQ"]}`,
+			expect: expect{
+				expectErr: true,
+				err:       NewError(errors.New(`invalid contact address "2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmQ": not a valid skycoin or bitcoin address`)),
+			},
+		},
+		{
+			name:              "address book duplicate address",
+			enableAPI:         true,
+			loadStorage:       true,
+			storageTypeToLoad: TypeAddressBook,
+			storageType:       TypeAddressBook,
+			key:               "alice",
+			val:               `{"addresses":["2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP","2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP"]}`,
+			expect: expect{
+				expectErr: true,
+				err:       ErrContactDuplicateAddress,
+			},
+		},
+		{
+			name:              "address book add contact",
+			enableAPI:         true,
+			loadStorage:       true,
+			storageTypeToLoad: TypeAddressBook,
+			storageType:       TypeAddressBook,
+			key:               "alice",
+			val:               `{"addresses":["2Niqzo12tZ9ioZq5vwPHMVR4g7UVpp9TCmP","1FeexV6bAHb8ybZjqQMjJrcCrHGW9sb6uF"],"notes":"friend"}`,
+		},
 	}
 
 	tmpDir, cleanup := setupTmpDir(t)
@@ -125,4 +125,11 @@ var (
 		// MaxDropletPrecision can be overriden with `USER_MAX_DECIMALS` env var
 		MaxDropletPrecision: 3,
 	}
+
+	// AddressHRP is the human-readable part of bech32 encoded addresses
+	AddressHRP = "sky"
+
+	// SchnorrActivationSeq is the sequence number of the first block that can include Schnorr transactions.
+	// Schnorr transactions are disabled if 0
+	SchnorrActivationSeq uint64 = 0
 )
@@ -37,6 +37,11 @@ func (v VerifyTxn) MaxDropletDivisor() uint64 {
 	return DropletPrecisionToDivisor(v.MaxDropletPrecision)
 }
 
+// SchnorrActive returns true if the block with sequence number seq can include Schnorr transactions
+func SchnorrActive(seq uint64) bool {
+	return SchnorrActivationSeq != 0 && seq >= SchnorrActivationSeq
+}
+
 // Validate validates the configured parameters
 func (v VerifyTxn) Validate() error {
 	if v.BurnFactor < MinBurnFactor {
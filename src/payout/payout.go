@@ -0,0 +1,261 @@
+/*
+Package payout implements batch payouts from a wallet.
+
+A payout job sends coins to a batch of recipients. Jobs are identified by an
+idempotency key chosen by the client, so that a request can be retried safely:
+resubmitting a job with the same key never pays the recipients twice.
+
+The recipients of a job are packed into as few transactions as the maximum transaction
+size allows. Each transaction is saved to the database before it is broadcast, and
+broadcast transactions are tracked until they are confirmed.
+*/
+package payout
+
+import (
+	"errors"
+	"time"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/transaction"
+	"github.com/skycoin/skycoin/src/util/logging"
+	"github.com/skycoin/skycoin/src/visor"
+)
+
+var (
+	logger = logging.MustGetLogger("payout")
+)
+
+// Error wraps payout errors caused by user input
+type Error struct {
+	error
+}
+
+// NewError creates an Error
+func NewError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return Error{err}
+}
+
+var (
+	// ErrMissingIdempotencyKey is returned if a job request has no idempotency key
+	ErrMissingIdempotencyKey = NewError(errors.New("idempotency key is required"))
+	// ErrIdempotencyKeyTooLong is returned if the idempotency key of a job request is too long
+	ErrIdempotencyKeyTooLong = NewError(errors.New("idempotency key is too long"))
+	// ErrMissingWalletID is returned if a job request has no wallet ID
+	ErrMissingWalletID = NewError(errors.New("wallet ID is required"))
+	// ErrIdempotencyKeyConflict is returned if a job with the same idempotency key but different
+	// parameters already exists
+	ErrIdempotencyKeyConflict = NewError(errors.New("a payout job with this idempotency key already exists with different parameters"))
+	// ErrJobNotFound is returned if a job does not exist
+	ErrJobNotFound = NewError(errors.New("payout job not found"))
+	// ErrJobFailed is returned when resuming a job that has failed
+	ErrJobFailed = NewError(errors.New("payout job has failed"))
+	// ErrRecipientTooLarge is returned if a transaction paying a single recipient exceeds the maximum transaction size
+	ErrRecipientTooLarge = NewError(errors.New("transaction paying a single recipient exceeds the maximum transaction size"))
+)
+
+// MaxIdempotencyKeyLength is the maximum length of an idempotency key
+const MaxIdempotencyKeyLength = 128
+
+// JobStatus is the status of a payout job
+type JobStatus string
+
+const (
+	// JobStatusPending some recipients are not part of a broadcast transaction yet
+	JobStatusPending JobStatus = "pending"
+	// JobStatusBroadcast all recipients are part of a broadcast transaction, waiting for confirmations
+	JobStatusBroadcast JobStatus = "broadcast"
+	// JobStatusCompleted all transactions are confirmed
+	JobStatusCompleted JobStatus = "completed"
+	// JobStatusFailed a transaction of the job can no longer be confirmed.
+	// The recipients of the failed transaction were not paid.
+	JobStatusFailed JobStatus = "failed"
+)
+
+// JobRequest is a request to create a payout job
+type JobRequest struct {
+	// IdempotencyKey identifies the job
+	IdempotencyKey string
+	// WalletID is the wallet the coins are sent from
+	WalletID       string
+	HoursSelection transaction.HoursSelection
+	To             []coin.TransactionOutput
+}
+
+// Validate validates the JobRequest
+func (r JobRequest) Validate() error {
+	if r.IdempotencyKey == "" {
+		return ErrMissingIdempotencyKey
+	}
+
+	if len(r.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return ErrIdempotencyKeyTooLong
+	}
+
+	if r.WalletID == "" {
+		return ErrMissingWalletID
+	}
+
+	return r.params(r.To).Validate()
+}
+
+// params returns the transaction.Params to pay a subset of the recipients
+func (r JobRequest) params(to []coin.TransactionOutput) transaction.Params {
+	return transaction.Params{
+		HoursSelection: r.HoursSelection,
+		To:             to,
+	}
+}
+
+// TxnStatus is the status of a transaction of a payout job
+type TxnStatus string
+
+const (
+	// TxnStatusCreated the transaction is saved but was not broadcast yet
+	TxnStatusCreated TxnStatus = "created"
+	// TxnStatusBroadcast the transaction was broadcast and is waiting for confirmations
+	TxnStatusBroadcast TxnStatus = "broadcast"
+	// TxnStatusConfirmed the transaction has the required number of confirmations
+	TxnStatusConfirmed TxnStatus = "confirmed"
+	// TxnStatusFailed the transaction was rejected and will never be confirmed
+	TxnStatusFailed TxnStatus = "failed"
+)
+
+// JobTransaction is a transaction created for a payout job
+type JobTransaction struct {
+	Transaction coin.Transaction `json:"transaction"`
+	// Recipients are the indexes of the job recipients that the transaction pays
+	Recipients []int     `json:"recipients"`
+	Status     TxnStatus `json:"status"`
+	// BlockSeq is the sequence of the block that executed the transaction, if it is in the blockchain
+	BlockSeq uint64 `json:"block_seq"`
+	// Confirmations is the number of blocks since the transaction was executed, including its block
+	Confirmations uint64 `json:"confirmations"`
+	Error         string `json:"error,omitempty"`
+}
+
+// Job is a payout job
+type Job struct {
+	IdempotencyKey string                     `json:"idempotency_key"`
+	WalletID       string                     `json:"wallet_id"`
+	HoursSelection transaction.HoursSelection `json:"hours_selection"`
+	To             []coin.TransactionOutput   `json:"to"`
+	Status         JobStatus                  `json:"status"`
+	Transactions   []JobTransaction           `json:"transactions"`
+	// LastError is the last error that stopped the processing of the job
+	LastError string    `json:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newJob creates a Job from a JobRequest
+func newJob(r JobRequest, now time.Time) *Job {
+	return &Job{
+		IdempotencyKey: r.IdempotencyKey,
+		WalletID:       r.WalletID,
+		HoursSelection: r.HoursSelection,
+		To:             r.To,
+		Status:         JobStatusPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// matches returns true if the job was created from the same request parameters
+func (j *Job) matches(r JobRequest) bool {
+	if j.WalletID != r.WalletID || len(j.To) != len(r.To) {
+		return false
+	}
+
+	if j.HoursSelection.Type != r.HoursSelection.Type || j.HoursSelection.Mode != r.HoursSelection.Mode {
+		return false
+	}
+
+	a, b := j.HoursSelection.ShareFactor, r.HoursSelection.ShareFactor
+	if (a == nil) != (b == nil) || (a != nil && !a.Equal(*b)) {
+		return false
+	}
+
+	for i, to := range j.To {
+		if to != r.To[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// request returns the JobRequest of the job
+func (j *Job) request() JobRequest {
+	return JobRequest{
+		IdempotencyKey: j.IdempotencyKey,
+		WalletID:       j.WalletID,
+		HoursSelection: j.HoursSelection,
+		To:             j.To,
+	}
+}
+
+// unassigned returns the indexes of the recipients that are not paid by any transaction of the job
+func (j *Job) unassigned() []int {
+	assigned := make(map[int]struct{}, len(j.To))
+	for _, txn := range j.Transactions {
+		for _, i := range txn.Recipients {
+			assigned[i] = struct{}{}
+		}
+	}
+
+	var idxs []int
+	for i := range j.To {
+		if _, ok := assigned[i]; !ok {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs
+}
+
+// updateStatus updates the job status from the status of its transactions
+func (j *Job) updateStatus() {
+	confirmed := true
+	created := false
+	for _, txn := range j.Transactions {
+		switch txn.Status {
+		case TxnStatusFailed:
+			j.Status = JobStatusFailed
+			return
+		case TxnStatusConfirmed:
+		case TxnStatusCreated:
+			created = true
+			confirmed = false
+		default:
+			confirmed = false
+		}
+	}
+
+	switch {
+	case created || len(j.unassigned()) != 0:
+		j.Status = JobStatusPending
+	case confirmed:
+		j.Status = JobStatusCompleted
+	default:
+		j.Status = JobStatusBroadcast
+	}
+}
+
+// TxID returns the transaction ID
+func (t JobTransaction) TxID() cipher.SHA256 {
+	return t.Transaction.Hash()
+}
+
+// Visorer is the interface of the visor.Visor methods used by Processor
+type Visorer interface {
+	WalletCreateTransactionSigned(wltID string, password []byte, p transaction.Params, wp visor.CreateTransactionParams) (*coin.Transaction, []visor.TransactionInput, error)
+	GetTransaction(txid cipher.SHA256) (*visor.Transaction, error)
+}
+
+// Broadcaster is the interface of the daemon.Daemon methods used by Processor
+type Broadcaster interface {
+	InjectBroadcastTransaction(txn coin.Transaction) error
+}
@@ -0,0 +1,331 @@
+package payout
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/transaction"
+	"github.com/skycoin/skycoin/src/visor"
+)
+
+// fakeNode implements Visorer and Broadcaster
+type fakeNode struct {
+	created      int
+	createErr    error
+	broadcastErr error
+	broadcast    map[cipher.SHA256]coin.Transaction
+	confirmed    map[cipher.SHA256]uint64
+}
+
+func newFakeNode() *fakeNode {
+	return &fakeNode{
+		broadcast: make(map[cipher.SHA256]coin.Transaction),
+		confirmed: make(map[cipher.SHA256]uint64),
+	}
+}
+
+func (n *fakeNode) WalletCreateTransactionSigned(wltID string, password []byte, p transaction.Params, wp visor.CreateTransactionParams) (*coin.Transaction, []visor.TransactionInput, error) {
+	if n.createErr != nil {
+		return nil, nil, n.createErr
+	}
+
+	if !wp.IgnoreUnconfirmed {
+		return nil, nil, errors.New("IgnoreUnconfirmed must be set")
+	}
+
+	n.created++
+
+	txn := &coin.Transaction{}
+	if err := txn.PushInput(cipher.SumSHA256([]byte(fmt.Sprint(n.created)))); err != nil {
+		return nil, nil, err
+	}
+	for _, to := range p.To {
+		if err := txn.PushOutput(to.Address, to.Coins, to.Hours); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := txn.UpdateHeader(); err != nil {
+		return nil, nil, err
+	}
+
+	return txn, nil, nil
+}
+
+func (n *fakeNode) GetTransaction(txid cipher.SHA256) (*visor.Transaction, error) {
+	txn, ok := n.broadcast[txid]
+	if !ok {
+		return nil, nil
+	}
+
+	status := visor.NewUnconfirmedTransactionStatus()
+	if height, ok := n.confirmed[txid]; ok {
+		status = visor.NewConfirmedTransactionStatus(height, 10)
+	}
+
+	return &visor.Transaction{
+		Transaction: txn,
+		Status:      status,
+	}, nil
+}
+
+func (n *fakeNode) InjectBroadcastTransaction(txn coin.Transaction) error {
+	if n.broadcastErr != nil {
+		return n.broadcastErr
+	}
+	n.broadcast[txn.Hash()] = txn
+	return nil
+}
+
+func makeRecipients(n int) []coin.TransactionOutput {
+	to := make([]coin.TransactionOutput, n)
+	for i := range to {
+		to[i] = coin.TransactionOutput{
+			Address: testutil.MakeAddress(),
+			Coins:   uint64(i+1) * 1e6,
+			Hours:   1,
+		}
+	}
+	return to
+}
+
+func newTestProcessor(t *testing.T, node *fakeNode, maxSize uint32) (*Processor, func()) {
+	db, shutdown := testutil.PrepareDB(t)
+
+	c := NewConfig()
+	c.Confirmations = 2
+	if maxSize != 0 {
+		c.MaxTransactionSize = maxSize
+	}
+
+	p, err := NewProcessor(c, db, node, node)
+	require.NoError(t, err)
+
+	return p, shutdown
+}
+
+func makeJobRequest(key string, to []coin.TransactionOutput) JobRequest {
+	return JobRequest{
+		IdempotencyKey: key,
+		WalletID:       "foo.wlt",
+		HoursSelection: transaction.HoursSelection{
+			Type: transaction.HoursSelectionTypeManual,
+		},
+		To: to,
+	}
+}
+
+func TestJobRequestValidate(t *testing.T) {
+	to := makeRecipients(2)
+
+	r := makeJobRequest("", to)
+	require.Equal(t, ErrMissingIdempotencyKey, r.Validate())
+
+	r = makeJobRequest(string(make([]byte, MaxIdempotencyKeyLength+1)), to)
+	require.Equal(t, ErrIdempotencyKeyTooLong, r.Validate())
+
+	r = makeJobRequest("foo", to)
+	r.WalletID = ""
+	require.Equal(t, ErrMissingWalletID, r.Validate())
+
+	r = makeJobRequest("foo", nil)
+	require.Equal(t, transaction.ErrMissingReceivers, r.Validate())
+
+	r = makeJobRequest("foo", to)
+	require.NoError(t, r.Validate())
+}
+
+func TestCreatePayoutJob(t *testing.T) {
+	node := newFakeNode()
+	p, shutdown := newTestProcessor(t, node, 0)
+	defer shutdown()
+
+	to := makeRecipients(3)
+	j, err := p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusBroadcast, j.Status)
+	require.Len(t, j.Transactions, 1)
+	require.Equal(t, []int{0, 1, 2}, j.Transactions[0].Recipients)
+	require.Equal(t, TxnStatusBroadcast, j.Transactions[0].Status)
+	require.Equal(t, 1, node.created)
+
+	// Resubmitting the same job does not create a new transaction
+	j2, err := p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.NoError(t, err)
+	require.Equal(t, j.Transactions, j2.Transactions)
+	require.Equal(t, 1, node.created)
+
+	// Resubmitting with different parameters is a conflict
+	_, err = p.CreatePayoutJob(makeJobRequest("job1", to[:2]), nil)
+	require.Equal(t, ErrIdempotencyKeyConflict, err)
+
+	j3, err := p.GetPayoutJob("job1")
+	require.NoError(t, err)
+	require.Equal(t, j2.Transactions, j3.Transactions)
+
+	_, err = p.GetPayoutJob("job2")
+	require.Equal(t, ErrJobNotFound, err)
+
+	jobs, err := p.GetPayoutJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, "job1", jobs[0].IdempotencyKey)
+}
+
+func TestCreatePayoutJobPacking(t *testing.T) {
+	node := newFakeNode()
+
+	// Find the size of a transaction paying 4 recipients
+	to := makeRecipients(10)
+	txn, _, err := node.WalletCreateTransactionSigned("", nil, transaction.Params{
+		To: to[:4],
+	}, visor.CreateTransactionParams{
+		IgnoreUnconfirmed: true,
+	})
+	require.NoError(t, err)
+	size, err := txn.Size()
+	require.NoError(t, err)
+
+	p, shutdown := newTestProcessor(t, node, size)
+	defer shutdown()
+
+	j, err := p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusBroadcast, j.Status)
+	require.Len(t, j.Transactions, 3)
+
+	var paid []int
+	for _, txn := range j.Transactions {
+		txnSize, err := txn.Transaction.Size()
+		require.NoError(t, err)
+		require.True(t, txnSize <= size)
+		require.Len(t, txn.Transaction.Out, len(txn.Recipients))
+		paid = append(paid, txn.Recipients...)
+	}
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, paid)
+
+	// A single recipient that does not fit in a transaction
+	p, shutdown2 := newTestProcessor(t, node, 1)
+	defer shutdown2()
+
+	j, err = p.CreatePayoutJob(makeJobRequest("job2", to), nil)
+	require.Equal(t, ErrRecipientTooLarge, err)
+	require.Equal(t, JobStatusPending, j.Status)
+	require.Equal(t, ErrRecipientTooLarge.Error(), j.LastError)
+	require.Empty(t, j.Transactions)
+}
+
+func TestCreatePayoutJobBroadcastFailure(t *testing.T) {
+	node := newFakeNode()
+	p, shutdown := newTestProcessor(t, node, 0)
+	defer shutdown()
+
+	to := makeRecipients(2)
+
+	// The transaction is saved even if broadcasting it fails
+	node.broadcastErr = errors.New("no connections")
+	j, err := p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.Equal(t, node.broadcastErr, err)
+	require.Equal(t, JobStatusPending, j.Status)
+	require.Equal(t, "no connections", j.LastError)
+	require.Len(t, j.Transactions, 1)
+	require.Equal(t, TxnStatusCreated, j.Transactions[0].Status)
+
+	// Resuming the job broadcasts the saved transaction instead of creating a new one
+	node.broadcastErr = nil
+	j, err = p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusBroadcast, j.Status)
+	require.Empty(t, j.LastError)
+	require.Len(t, j.Transactions, 1)
+	require.Equal(t, TxnStatusBroadcast, j.Transactions[0].Status)
+	require.Equal(t, 1, node.created)
+	require.Contains(t, node.broadcast, j.Transactions[0].TxID())
+}
+
+func TestCreatePayoutJobRejected(t *testing.T) {
+	node := newFakeNode()
+	p, shutdown := newTestProcessor(t, node, 0)
+	defer shutdown()
+
+	to := makeRecipients(2)
+
+	node.broadcastErr = visor.NewErrTxnViolatesHardConstraint(errors.New("unspent output does not exist"))
+	j, err := p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.Equal(t, ErrJobFailed, err)
+	require.Equal(t, JobStatusFailed, j.Status)
+	require.Equal(t, TxnStatusFailed, j.Transactions[0].Status)
+	require.Equal(t, node.broadcastErr.Error(), j.Transactions[0].Error)
+
+	// A failed job is not resumed
+	node.broadcastErr = nil
+	j, err = p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.Equal(t, ErrJobFailed, err)
+	require.Equal(t, JobStatusFailed, j.Status)
+	require.Equal(t, 1, node.created)
+}
+
+func TestCreatePayoutJobCreateFailure(t *testing.T) {
+	node := newFakeNode()
+	p, shutdown := newTestProcessor(t, node, 0)
+	defer shutdown()
+
+	to := makeRecipients(2)
+
+	node.createErr = transaction.ErrInsufficientBalance
+	j, err := p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.Equal(t, transaction.ErrInsufficientBalance, err)
+	require.Equal(t, JobStatusPending, j.Status)
+	require.Equal(t, transaction.ErrInsufficientBalance.Error(), j.LastError)
+	require.Empty(t, j.Transactions)
+
+	node.createErr = nil
+	j, err = p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusBroadcast, j.Status)
+	require.Len(t, j.Transactions, 1)
+}
+
+func TestCheckPayoutJobs(t *testing.T) {
+	node := newFakeNode()
+	p, shutdown := newTestProcessor(t, node, 0)
+	defer shutdown()
+
+	to := makeRecipients(2)
+	j, err := p.CreatePayoutJob(makeJobRequest("job1", to), nil)
+	require.NoError(t, err)
+	txid := j.Transactions[0].TxID()
+
+	// Not enough confirmations
+	node.confirmed[txid] = 1
+	require.NoError(t, p.CheckPayoutJobs())
+	j, err = p.GetPayoutJob("job1")
+	require.NoError(t, err)
+	require.Equal(t, JobStatusBroadcast, j.Status)
+	require.Equal(t, TxnStatusBroadcast, j.Transactions[0].Status)
+	require.Equal(t, uint64(1), j.Transactions[0].Confirmations)
+	require.Equal(t, uint64(10), j.Transactions[0].BlockSeq)
+
+	node.confirmed[txid] = 2
+	require.NoError(t, p.CheckPayoutJobs())
+	j, err = p.GetPayoutJob("job1")
+	require.NoError(t, err)
+	require.Equal(t, JobStatusCompleted, j.Status)
+	require.Equal(t, TxnStatusConfirmed, j.Transactions[0].Status)
+
+	// A transaction dropped by the node is broadcast again
+	j2, err := p.CreatePayoutJob(makeJobRequest("job2", makeRecipients(1)), nil)
+	require.NoError(t, err)
+	delete(node.broadcast, j2.Transactions[0].TxID())
+
+	require.NoError(t, p.CheckPayoutJobs())
+	require.Contains(t, node.broadcast, j2.Transactions[0].TxID())
+	j2, err = p.GetPayoutJob("job2")
+	require.NoError(t, err)
+	require.Equal(t, JobStatusBroadcast, j2.Status)
+}
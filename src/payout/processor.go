@@ -0,0 +1,384 @@
+package payout
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// Config configures the Processor
+type Config struct {
+	// CheckInterval is how often the transactions of unfinished jobs are checked
+	CheckInterval time.Duration
+	// Confirmations is the number of confirmations for a transaction to be considered confirmed
+	Confirmations uint64
+	// MaxTransactionSize is the maximum size of a created transaction
+	MaxTransactionSize uint32
+}
+
+// NewConfig creates a default Config
+func NewConfig() Config {
+	return Config{
+		CheckInterval:      time.Second * 30,
+		Confirmations:      1,
+		MaxTransactionSize: params.UserVerifyTxn.MaxTransactionSize,
+	}
+}
+
+// Processor creates payout jobs and tracks their transactions until they are confirmed
+type Processor struct {
+	config      Config
+	db          *dbutil.DB
+	visor       Visorer
+	broadcaster Broadcaster
+
+	// jobsLock serializes the processing of jobs, so that a recipient is never paid twice
+	jobsLock sync.Mutex
+
+	quit     chan struct{}
+	quitOnce sync.Once
+}
+
+// NewProcessor creates a Processor
+func NewProcessor(c Config, db *dbutil.DB, v Visorer, b Broadcaster) (*Processor, error) {
+	if c.Confirmations == 0 {
+		return nil, errors.New("Confirmations must be > 0")
+	}
+	if c.MaxTransactionSize == 0 {
+		return nil, errors.New("MaxTransactionSize must be > 0")
+	}
+
+	return &Processor{
+		config:      c,
+		db:          db,
+		visor:       v,
+		broadcaster: b,
+		quit:        make(chan struct{}),
+	}, nil
+}
+
+// Run periodically checks the transactions of unfinished jobs, until Shutdown is called
+func (p *Processor) Run() {
+	if p.config.CheckInterval <= 0 {
+		return
+	}
+
+	logger.Info("Payout processor started")
+	defer logger.Info("Payout processor stopped")
+
+	ticker := time.NewTicker(p.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.quit:
+			return
+		case <-ticker.C:
+			if err := p.CheckPayoutJobs(); err != nil {
+				logger.WithError(err).Error("CheckPayoutJobs failed")
+			}
+		}
+	}
+}
+
+// Shutdown stops Run
+func (p *Processor) Shutdown() {
+	p.quitOnce.Do(func() {
+		close(p.quit)
+	})
+}
+
+// CreatePayoutJob creates a payout job, or resumes the job with the same idempotency key.
+// Transactions are created and broadcast for the recipients that are not paid by a transaction of the job yet.
+// If a job with the same idempotency key exists but was created with different parameters,
+// ErrIdempotencyKeyConflict is returned.
+// The job is returned along with the error that stopped its processing, if any.
+// The processing of a job that was stopped by an error resumes when the job is created again.
+func (p *Processor) CreatePayoutJob(r JobRequest, password []byte) (*Job, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	p.jobsLock.Lock()
+	defer p.jobsLock.Unlock()
+
+	var j *Job
+	if err := p.db.Update("CreatePayoutJob", func(tx *dbutil.Tx) error {
+		var err error
+		j, err = getJob(tx, r.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+
+		if j != nil {
+			if !j.matches(r) {
+				return ErrIdempotencyKeyConflict
+			}
+			return nil
+		}
+
+		j = newJob(r, time.Now().UTC())
+		return putJob(tx, j)
+	}); err != nil {
+		return nil, err
+	}
+
+	if j.Status == JobStatusFailed {
+		return j, ErrJobFailed
+	}
+
+	return j, p.processJob(j, password)
+}
+
+// GetPayoutJob returns the payout job with the given idempotency key
+func (p *Processor) GetPayoutJob(key string) (*Job, error) {
+	var j *Job
+	if err := p.db.View("GetPayoutJob", func(tx *dbutil.Tx) error {
+		var err error
+		j, err = getJob(tx, key)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if j == nil {
+		return nil, ErrJobNotFound
+	}
+
+	return j, nil
+}
+
+// GetPayoutJobs returns all payout jobs, oldest first
+func (p *Processor) GetPayoutJobs() ([]Job, error) {
+	var jobs []Job
+	if err := p.db.View("GetPayoutJobs", func(tx *dbutil.Tx) error {
+		var err error
+		jobs, err = getJobs(tx, nil)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// CheckPayoutJobs updates the status of the transactions of unfinished jobs.
+// Transactions that are not known to the node are broadcast again.
+// No new transactions are created; the remaining recipients of a pending job are paid
+// when the job is created again.
+func (p *Processor) CheckPayoutJobs() error {
+	p.jobsLock.Lock()
+	defer p.jobsLock.Unlock()
+
+	var jobs []Job
+	if err := p.db.View("CheckPayoutJobs", func(tx *dbutil.Tx) error {
+		var err error
+		jobs, err = getJobs(tx, func(j *Job) bool {
+			return j.Status == JobStatusPending || j.Status == JobStatusBroadcast
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	for i := range jobs {
+		j := &jobs[i]
+		if len(j.Transactions) == 0 {
+			continue
+		}
+
+		if err := p.checkTransactions(j); err != nil {
+			logger.WithError(err).WithField("idempotencyKey", j.IdempotencyKey).Warning("Checking payout job transactions failed")
+			j.LastError = err.Error()
+		}
+
+		j.updateStatus()
+		if err := p.saveJob(j); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processJob pays the unassigned recipients of a job
+func (p *Processor) processJob(j *Job, password []byte) error {
+	// All transactions of the job must be known to the node before new ones are created,
+	// otherwise the new transactions could spend the same outputs
+	if err := p.checkTransactions(j); err != nil {
+		return p.stopJob(j, err)
+	}
+
+	j.updateStatus()
+	if j.Status == JobStatusFailed {
+		if err := p.saveJob(j); err != nil {
+			return err
+		}
+		return ErrJobFailed
+	}
+
+	for {
+		idxs := j.unassigned()
+		if len(idxs) == 0 {
+			break
+		}
+
+		txn, paid, err := p.createTransaction(j, idxs, password)
+		if err != nil {
+			return p.stopJob(j, err)
+		}
+
+		// Save the transaction before broadcasting it, so that its recipients are never paid twice
+		j.Transactions = append(j.Transactions, JobTransaction{
+			Transaction: *txn,
+			Recipients:  paid,
+			Status:      TxnStatusCreated,
+		})
+		if err := p.saveJob(j); err != nil {
+			return err
+		}
+
+		t := &j.Transactions[len(j.Transactions)-1]
+		if err := p.broadcastTransaction(t); err != nil {
+			return p.stopJob(j, err)
+		}
+
+		j.updateStatus()
+		if err := p.saveJob(j); err != nil {
+			return err
+		}
+
+		if j.Status == JobStatusFailed {
+			return ErrJobFailed
+		}
+	}
+
+	j.LastError = ""
+	j.updateStatus()
+	return p.saveJob(j)
+}
+
+// stopJob saves the error that stopped the processing of a job and returns it
+func (p *Processor) stopJob(j *Job, err error) error {
+	j.LastError = err.Error()
+	j.updateStatus()
+	if saveErr := p.saveJob(j); saveErr != nil {
+		logger.WithError(saveErr).Error("saveJob failed")
+	}
+	return err
+}
+
+// createTransaction creates a signed transaction paying as many of the recipients as the
+// maximum transaction size allows. Returns the transaction and the recipients it pays.
+func (p *Processor) createTransaction(j *Job, idxs []int, password []byte) (*coin.Transaction, []int, error) {
+	r := j.request()
+	n := len(idxs)
+	for {
+		to := make([]coin.TransactionOutput, n)
+		for i, idx := range idxs[:n] {
+			to[i] = r.To[idx]
+		}
+
+		txn, _, err := p.visor.WalletCreateTransactionSigned(r.WalletID, password, r.params(to), visor.CreateTransactionParams{
+			IgnoreUnconfirmed: true,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		size, err := txn.Size()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if size <= p.config.MaxTransactionSize {
+			return txn, append([]int{}, idxs[:n]...), nil
+		}
+
+		if n == 1 {
+			return nil, nil, ErrRecipientTooLarge
+		}
+
+		// Shrink the batch proportionally to the excess size
+		next := int(uint64(n) * uint64(p.config.MaxTransactionSize) / uint64(size))
+		if next >= n {
+			next = n - 1
+		}
+		if next < 1 {
+			next = 1
+		}
+		n = next
+	}
+}
+
+// checkTransactions updates the status of the unconfirmed transactions of a job.
+// Transactions unknown to the node are broadcast again.
+func (p *Processor) checkTransactions(j *Job) error {
+	for i := range j.Transactions {
+		t := &j.Transactions[i]
+		if t.Status == TxnStatusConfirmed || t.Status == TxnStatusFailed {
+			continue
+		}
+
+		txn, err := p.visor.GetTransaction(t.TxID())
+		if err != nil {
+			return err
+		}
+
+		if txn == nil {
+			if err := p.broadcastTransaction(t); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !txn.Status.Confirmed {
+			t.Status = TxnStatusBroadcast
+			continue
+		}
+
+		t.BlockSeq = txn.Status.BlockSeq
+		t.Confirmations = txn.Status.Height
+		if t.Confirmations >= p.config.Confirmations {
+			t.Status = TxnStatusConfirmed
+		} else {
+			t.Status = TxnStatusBroadcast
+		}
+	}
+
+	return nil
+}
+
+// broadcastTransaction injects and broadcasts a transaction of a job.
+// If the transaction is rejected it is marked as failed, and no error is returned.
+func (p *Processor) broadcastTransaction(t *JobTransaction) error {
+	err := p.broadcaster.InjectBroadcastTransaction(t.Transaction)
+	switch err.(type) {
+	case nil:
+		t.Status = TxnStatusBroadcast
+		t.Error = ""
+		return nil
+	case visor.ErrTxnViolatesHardConstraint,
+		visor.ErrTxnViolatesSoftConstraint,
+		visor.ErrTxnViolatesUserConstraint:
+		logger.WithError(err).WithField("txid", t.TxID().Hex()).Warning("Payout transaction rejected")
+		t.Status = TxnStatusFailed
+		t.Error = err.Error()
+		return nil
+	default:
+		return err
+	}
+}
+
+// saveJob saves a job
+func (p *Processor) saveJob(j *Job) error {
+	j.UpdatedAt = time.Now().UTC()
+	return p.db.Update("saveJob", func(tx *dbutil.Tx) error {
+		return putJob(tx, j)
+	})
+}
@@ -0,0 +1,75 @@
+package payout
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// JobsBkt stores payout jobs, keyed by idempotency key, JSON encoded
+var JobsBkt = []byte("payout_jobs")
+
+// getJob returns the job with the given idempotency key, or nil if it does not exist
+func getJob(tx *dbutil.Tx, key string) (*Job, error) {
+	var j Job
+	ok, err := dbutil.GetBucketObjectJSON(tx, JobsBkt, []byte(key), &j)
+	if err != nil {
+		switch err.(type) {
+		case dbutil.ErrBucketNotExist:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &j, nil
+}
+
+// putJob saves a job
+func putJob(tx *dbutil.Tx, j *Job) error {
+	if _, err := tx.CreateBucketIfNotExists(JobsBkt); err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.PutBucketValue(tx, JobsBkt, []byte(j.IdempotencyKey), v)
+}
+
+// getJobs returns all jobs that satisfy the filter, oldest first
+func getJobs(tx *dbutil.Tx, filter func(*Job) bool) ([]Job, error) {
+	var jobs []Job
+	if err := dbutil.ForEach(tx, JobsBkt, func(_, v []byte) error {
+		var j Job
+		if err := json.Unmarshal(v, &j); err != nil {
+			return err
+		}
+
+		if filter == nil || filter(&j) {
+			jobs = append(jobs, j)
+		}
+
+		return nil
+	}); err != nil {
+		switch err.(type) {
+		case dbutil.ErrBucketNotExist:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+	})
+
+	return jobs, nil
+}
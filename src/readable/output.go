@@ -9,8 +9,7 @@ import (
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/transaction"
-	"github.com/skycoin/skycoin/src/util/droplet"
-	"github.com/skycoin/skycoin/src/util/mathutil"
+	"github.com/skycoin/skycoin/src/util/amount"
 	"github.com/skycoin/skycoin/src/visor"
 	"github.com/skycoin/skycoin/src/visor/historydb"
 	"github.com/skycoin/skycoin/src/wallet"
@@ -30,7 +29,7 @@ type UnspentOutput struct {
 
 // NewUnspentOutput creates a readable output
 func NewUnspentOutput(uxOut visor.UnspentOutput) (UnspentOutput, error) {
-	coinStr, err := droplet.ToString(uxOut.Body.Coins)
+	coinStr, err := amount.Amount(uxOut.Body.Coins).ToString()
 	if err != nil {
 		return UnspentOutput{}, err
 	}
@@ -77,17 +76,15 @@ func NewUnspentOutputs(uxs []visor.UnspentOutput) (UnspentOutputs, error) {
 func (ros UnspentOutputs) Balance() (wallet.Balance, error) {
 	var bal wallet.Balance
 	for _, out := range ros {
-		coins, err := droplet.FromString(out.Coins)
+		coins, err := amount.ParseAmount(out.Coins)
 		if err != nil {
 			return wallet.Balance{}, err
 		}
 
-		bal.Coins, err = mathutil.AddUint64(bal.Coins, coins)
-		if err != nil {
-			return wallet.Balance{}, err
-		}
-
-		bal.Hours, err = mathutil.AddUint64(bal.Hours, out.CalculatedHours)
+		bal, err = bal.Add(wallet.Balance{
+			Coins: coins,
+			Hours: amount.Hours(out.CalculatedHours),
+		})
 		if err != nil {
 			return wallet.Balance{}, err
 		}
@@ -100,7 +97,7 @@ func (ros UnspentOutputs) Balance() (wallet.Balance, error) {
 func (ros UnspentOutputs) ToUxArray() (coin.UxArray, error) {
 	var uxs coin.UxArray
 	for _, o := range ros {
-		coins, err := droplet.FromString(o.Coins)
+		coins, err := amount.ParseAmount(o.Coins)
 		if err != nil {
 			return nil, err
 		}
@@ -123,7 +120,7 @@ func (ros UnspentOutputs) ToUxArray() (coin.UxArray, error) {
 			Body: coin.UxBody{
 				SrcTransaction: srcTx,
 				Address:        addr,
-				Coins:          coins,
+				Coins:          coins.Value(),
 				Hours:          o.Hours,
 			},
 		})
@@ -145,7 +142,7 @@ func OutputsToUxBalances(ros UnspentOutputs) ([]transaction.UxBalance, error) {
 			return nil, fmt.Errorf("UnspentOutput hash is invalid: %v", err)
 		}
 
-		coins, err := droplet.FromString(ro.Coins)
+		coins, err := amount.ParseAmount(ro.Coins)
 		if err != nil {
 			return nil, fmt.Errorf("UnspentOutput coins is invalid: %v", err)
 		}
@@ -166,7 +163,7 @@ func OutputsToUxBalances(ros UnspentOutputs) ([]transaction.UxBalance, error) {
 			BkSeq:          ro.BkSeq,
 			SrcTransaction: srcTx,
 			Address:        addr,
-			Coins:          coins,
+			Coins:          coins.Value(),
 			Hours:          ro.CalculatedHours,
 			InitialHours:   ro.Hours,
 		}
@@ -1,8 +1,28 @@
 package readable
 
+import "github.com/skycoin/skycoin/src/visor"
+
 // PageInfo represents the pagination info
 type PageInfo struct {
 	TotalPages  uint64 `json:"total_pages"`
 	PageSize    uint64 `json:"page_size"`
 	CurrentPage uint64 `json:"current_page"`
 }
+
+// CursorInfo represents the cursor pagination info
+type CursorInfo struct {
+	Limit uint64 `json:"limit"`
+	// NextCursor is the cursor of the next page, empty if this is the last page
+	NextCursor string `json:"next_cursor"`
+}
+
+// NewCursorInfo creates a CursorInfo from the cursor of the next page, which is nil on the last page
+func NewCursorInfo(limit uint64, next *visor.Cursor) CursorInfo {
+	info := CursorInfo{
+		Limit: limit,
+	}
+	if next != nil {
+		info.NextCursor = next.String()
+	}
+	return info
+}
@@ -1,7 +1,7 @@
 package readable
 
 import (
-	"github.com/skycoin/skycoin/src/util/droplet"
+	"github.com/skycoin/skycoin/src/util/amount"
 	"github.com/skycoin/skycoin/src/visor"
 )
 
@@ -16,7 +16,7 @@ type RichlistBalance struct {
 func NewRichlistBalances(visorRichlist visor.Richlist) ([]RichlistBalance, error) {
 	richlist := make([]RichlistBalance, len(visorRichlist))
 	for i, v := range visorRichlist {
-		coins, err := droplet.ToString(v.Coins)
+		coins, err := amount.Amount(v.Coins).ToString()
 		if err != nil {
 			return nil, err
 		}
@@ -7,7 +7,7 @@ import (
 
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/coin"
-	"github.com/skycoin/skycoin/src/util/droplet"
+	"github.com/skycoin/skycoin/src/util/amount"
 	"github.com/skycoin/skycoin/src/util/logging"
 	"github.com/skycoin/skycoin/src/util/timeutil"
 	"github.com/skycoin/skycoin/src/visor"
@@ -55,7 +55,7 @@ type TransactionInput struct {
 
 // NewTransactionOutput creates a TransactionOutput
 func NewTransactionOutput(txn *coin.TransactionOutput, txid cipher.SHA256) (*TransactionOutput, error) {
-	coinStr, err := droplet.ToString(txn.Coins)
+	coinStr, err := amount.Amount(txn.Coins).ToString()
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +70,7 @@ func NewTransactionOutput(txn *coin.TransactionOutput, txid cipher.SHA256) (*Tra
 
 // NewTransactionInput creates a TransactionInput from a visor.TransactionInput
 func NewTransactionInput(input visor.TransactionInput) (TransactionInput, error) {
-	coinStr, err := droplet.ToString(input.UxOut.Body.Coins)
+	coinStr, err := amount.Amount(input.UxOut.Body.Coins).ToString()
 	if err != nil {
 		logger.Errorf("Failed to convert coins to string: %v", err)
 		return TransactionInput{}, err
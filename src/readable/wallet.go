@@ -15,8 +15,8 @@ type Balance struct {
 // NewBalance copies from wallet.Balance
 func NewBalance(b wallet.Balance) Balance {
 	return Balance{
-		Coins: b.Coins,
-		Hours: b.Hours,
+		Coins: b.Coins.Value(),
+		Hours: b.Hours.Value(),
 	}
 }
 
@@ -66,4 +66,32 @@ type WalletMeta struct {
 	Encrypted  bool              `json:"encrypted"`
 	Bip44Coin  *bip44.CoinType   `json:"bip44_coin,omitempty"` // For bip44
 	XPub       string            `json:"xpub,omitempty"`       // For xpub
+	Descriptor string            `json:"descriptor,omitempty"` // For bip44 and xpub
+}
+
+// UxOutMeta is the coin control metadata of a wallet's unspent output
+type UxOutMeta struct {
+	Frozen bool     `json:"frozen"`
+	Label  string   `json:"label"`
+	Tags   []string `json:"tags"`
+}
+
+// UxOutsMeta maps unspent output hashes to their metadata
+type UxOutsMeta map[string]UxOutMeta
+
+// NewUxOutsMeta copies from wallet.UxOutsMeta
+func NewUxOutsMeta(m wallet.UxOutsMeta) UxOutsMeta {
+	rm := make(UxOutsMeta, len(m))
+	for k, v := range m {
+		tags := v.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		rm[k.Hex()] = UxOutMeta{
+			Frozen: v.Frozen,
+			Label:  v.Label,
+			Tags:   tags,
+		}
+	}
+	return rm
 }
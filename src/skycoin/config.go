@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -73,6 +74,18 @@ type NodeConfig struct {
 	// Comma separate list of hostnames to accept in the Host header, used to bypass the Host header check which only applies to localhost addresses
 	HostWhitelist string
 	hostWhitelist []string
+	// Number of request cost units each API client can spend per second. Set to 0 to disable rate limiting.
+	// Clients are identified by their API token, or by their IP address
+	RateLimit float64
+	// Maximum number of request cost units an API client can spend at once
+	RateLimitBurst int
+	// Comma separated list of endpoint:cost request costs. An endpoint with a ?verbose=1 suffix applies to verbose requests
+	RateLimitEndpointCosts string
+	// Comma separated list of API_SET:cost request costs, for endpoints without an endpoint cost
+	RateLimitAPISetCosts string
+
+	rateLimitEndpointCosts map[string]int
+	rateLimitAPISetCosts   map[string]int
 
 	// Only run on localhost and only connect to others on localhost
 	LocalhostOnly bool
@@ -116,6 +129,16 @@ type NodeConfig struct {
 	WebInterfacePassword string
 	// Allow web interface auth without HTTPS
 	WebInterfacePlaintextAuth bool
+	// API tokens file, holds the hashed API tokens that are accepted by the web interface.
+	// Defaults to ${DataDirectory}/api_tokens.json
+	APITokensFile string
+
+	// Enable the gRPC server. It uses the API sets, credentials, API tokens and HTTPS setting of the web interface
+	GRPC bool
+	// gRPC server address
+	GRPCAddr string
+	// gRPC server port
+	GRPCPort int
 
 	// Launch System Default Browser after client startup
 	LaunchBrowser bool
@@ -152,6 +175,14 @@ type NodeConfig struct {
 	CreateBlockVerifyTxn params.VerifyTxn
 	// Maximum total size of transactions in a block
 	MaxBlockTransactionsSize uint32
+	// Number of blocks between snapshots of the address balances, used by the historical richlist.
+	// Set to 0 to disable the snapshots
+	BalanceSnapshotInterval uint64
+	// Number of workers verifying input signatures concurrently. Set to 0 to use one worker per CPU
+	SigVerifyWorkers int
+	// Maximum number of valid input signatures cached, so that transactions in the unconfirmed pool
+	// are not verified again when they are included in a block. Set to 0 to disable the cache
+	SigCacheSize int
 
 	unconfirmedBurnFactor          uint64
 	maxUnconfirmedTransactionSize  uint64
@@ -166,6 +197,31 @@ type NodeConfig struct {
 	WalletDirectory string
 	// Wallet crypto type
 	WalletCryptoType string
+	// Number of unused addresses kept ahead on each bip44 and xpub wallet address chain.
+	// Set to 0 to disable address discovery
+	WalletAddressGapLimit uint64
+	// How often wallets are checked for address discovery
+	WalletAddressDiscoveryInterval time.Duration
+
+	// Payouts
+	// How often the transactions of unfinished payout jobs are checked
+	PayoutCheckInterval time.Duration
+	// Number of confirmations for a payout transaction to be considered confirmed
+	PayoutConfirmations uint64
+
+	// Webhooks
+	// How often webhooks are checked for events and pending deliveries are sent
+	WebhookCheckInterval time.Duration
+	// Number of attempts to send a webhook delivery before it fails
+	WebhookMaxAttempts int
+	// Delay before retrying a failed webhook delivery, doubled after each failed attempt
+	WebhookRetryInterval time.Duration
+	// Timeout of a webhook delivery request
+	WebhookTimeout time.Duration
+	// Allow webhook deliveries to loopback, link-local, private and other non-public addresses
+	WebhookAllowPrivateURLs bool
+	// Number of delivered and failed deliveries kept for each webhook
+	WebhookMaxDeliveries int
 
 	// Key-value storage
 	// Default to ${DataDirectory}/data
@@ -271,6 +327,9 @@ func NewNodeConfig(mode string, node fiber.NodeConfig) NodeConfig {
 		WebInterfaceCert:  "",
 		WebInterfaceKey:   "",
 		WebInterfaceHTTPS: false,
+		GRPC:              false,
+		GRPCAddr:          "127.0.0.1",
+		GRPCPort:          6430,
 		EnabledAPISets: strings.Join([]string{
 			api.EndpointsRead,
 			api.EndpointsTransaction,
@@ -304,16 +363,52 @@ func NewNodeConfig(mode string, node fiber.NodeConfig) NodeConfig {
 			MaxDropletPrecision: node.CreateBlockMaxDropletPrecision,
 		},
 		MaxBlockTransactionsSize: node.MaxBlockTransactionsSize,
+		BalanceSnapshotInterval:  10000,
+
+		// Signature verification
+		SigVerifyWorkers: 0,
+		SigCacheSize:     100000,
 
 		// Wallets
 		WalletDirectory:  "",
 		WalletCryptoType: string(crypto.DefaultCryptoType),
 
+		WalletAddressGapLimit:          20,
+		WalletAddressDiscoveryInterval: time.Minute,
+
+		// Payouts
+		PayoutCheckInterval: time.Second * 30,
+		PayoutConfirmations: 1,
+
+		// Webhooks
+		WebhookCheckInterval: time.Second * 10,
+		WebhookMaxAttempts:   8,
+		WebhookRetryInterval: time.Second * 10,
+		WebhookTimeout:       time.Second * 10,
+		WebhookMaxDeliveries: 100,
+
+		// API rate limiting, disabled by default
+		RateLimit:      0,
+		RateLimitBurst: 100,
+		RateLimitEndpointCosts: strings.Join([]string{
+			"/api/v1/richlist:20",
+			"/api/v2/richlist:20",
+			"/api/v2/balance:10",
+			"/api/v1/addresscount:20",
+			"/api/v1/outputs:10",
+			"/api/v1/transactions:5",
+			"/api/v1/transactions?verbose=1:20",
+			"/api/v2/transactions:5",
+			"/api/v2/transactions?verbose=1:20",
+		}, ","),
+		RateLimitAPISetCosts: "",
+
 		// Key-value storage
 		KVStorageDirectory: "",
 		EnabledStorageTypes: []kvstorage.Type{
 			kvstorage.TypeTxIDNotes,
 			kvstorage.TypeGeneral,
+			kvstorage.TypeAddressBook,
 		},
 
 		// Timeout settings for http.Server
@@ -410,6 +505,12 @@ func (c *Config) postProcess() error {
 		c.Node.WalletDirectory = replaceHome(c.Node.WalletDirectory, home)
 	}
 
+	if c.Node.APITokensFile == "" {
+		c.Node.APITokensFile = filepath.Join(c.Node.DataDirectory, "api_tokens.json")
+	} else {
+		c.Node.APITokensFile = replaceHome(c.Node.APITokensFile, home)
+	}
+
 	if c.Node.KVStorageDirectory == "" {
 		c.Node.KVStorageDirectory = filepath.Join(c.Node.DataDirectory, "data")
 	} else {
@@ -419,6 +520,7 @@ func (c *Config) postProcess() error {
 		c.Node.EnabledStorageTypes = []kvstorage.Type{
 			kvstorage.TypeGeneral,
 			kvstorage.TypeTxIDNotes,
+			kvstorage.TypeAddressBook,
 		}
 	}
 
@@ -447,6 +549,40 @@ func (c *Config) postProcess() error {
 
 	// Don't open browser to load wallets if wallet apis are disabled.
 	c.Node.enabledAPISets = apiSets
+
+	if c.Node.SigVerifyWorkers < 0 {
+		return errors.New("-sig-verify-workers must be >= 0")
+	}
+
+	if c.Node.SigCacheSize < 0 {
+		return errors.New("-sig-cache-size must be >= 0")
+	}
+
+	if c.Node.RateLimit < 0 {
+		return errors.New("-rate-limit must be >= 0")
+	}
+
+	if c.Node.RateLimit > 0 && c.Node.RateLimitBurst < 1 {
+		return errors.New("-rate-limit-burst must be >= 1")
+	}
+
+	c.Node.rateLimitEndpointCosts, err = parseRateLimitCosts("-rate-limit-endpoint-costs", c.Node.RateLimitEndpointCosts)
+	if err != nil {
+		return err
+	}
+
+	c.Node.rateLimitAPISetCosts, err = parseRateLimitCosts("-rate-limit-api-set-costs", c.Node.RateLimitAPISetCosts)
+	if err != nil {
+		return err
+	}
+
+	rateLimitAPISets := make([]string, 0, len(c.Node.rateLimitAPISetCosts))
+	for k := range c.Node.rateLimitAPISetCosts {
+		rateLimitAPISets = append(rateLimitAPISets, k)
+	}
+	if err := validateAPISets("-rate-limit-api-set-costs", rateLimitAPISets); err != nil {
+		return err
+	}
 	if _, ok := c.Node.enabledAPISets[api.EndpointsWallet]; !ok {
 		c.Node.EnableGUI = false
 		c.Node.LaunchBrowser = false
@@ -593,6 +729,8 @@ func buildAPISets(c NodeConfig) (map[string]struct{}, error) {
 		api.EndpointsTransaction,
 		api.EndpointsNetCtrl,
 		api.EndpointsStorage,
+		api.EndpointsMetrics,
+		api.EndpointsWebhook,
 		// Do not include insecure or deprecated API sets, they must always
 		// be explicitly enabled through -enable-api-sets
 	}
@@ -626,7 +764,9 @@ func validateAPISets(opt string, apiSets []string) error {
 			api.EndpointsWallet,
 			api.EndpointsInsecureWalletSeed,
 			api.EndpointsNetCtrl,
-			api.EndpointsStorage:
+			api.EndpointsStorage,
+			api.EndpointsMetrics,
+			api.EndpointsWebhook:
 		case "":
 			continue
 		default:
@@ -636,6 +776,30 @@ func validateAPISets(opt string, apiSets []string) error {
 	return nil
 }
 
+// parseRateLimitCosts parses a comma separated list of key:cost request costs
+func parseRateLimitCosts(opt, s string) (map[string]int, error) {
+	costs := make(map[string]int)
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+
+		i := strings.LastIndex(kv, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("Invalid value in %s: %q, must be key:cost", opt, kv)
+		}
+
+		cost, err := strconv.Atoi(kv[i+1:])
+		if err != nil || cost < 1 {
+			return nil, fmt.Errorf("Invalid cost in %s: %q, must be a positive integer", opt, kv)
+		}
+
+		costs[kv[:i]] = cost
+	}
+	return costs, nil
+}
+
 // RegisterFlags binds CLI flags to config values
 func (c *NodeConfig) RegisterFlags() {
 	flag.BoolVar(&help, "help", false, "Show help")
@@ -658,6 +822,9 @@ func (c *NodeConfig) RegisterFlags() {
 	flag.StringVar(&c.WebInterfaceCert, "web-interface-cert", c.WebInterfaceCert, "skycoind.cert file for web interface HTTPS. If not provided, will autogenerate or use skycoind.cert in --data-dir")
 	flag.StringVar(&c.WebInterfaceKey, "web-interface-key", c.WebInterfaceKey, "skycoind.key file for web interface HTTPS. If not provided, will autogenerate or use skycoind.key in --data-dir")
 	flag.BoolVar(&c.WebInterfaceHTTPS, "web-interface-https", c.WebInterfaceHTTPS, "enable HTTPS for web interface")
+	flag.BoolVar(&c.GRPC, "grpc", c.GRPC, "enable the gRPC server")
+	flag.StringVar(&c.GRPCAddr, "grpc-addr", c.GRPCAddr, "addr to serve the gRPC server on")
+	flag.IntVar(&c.GRPCPort, "grpc-port", c.GRPCPort, "port to serve the gRPC server on")
 	flag.StringVar(&c.HostWhitelist, "host-whitelist", c.HostWhitelist, "Hostnames to whitelist in the Host header check. Only applies when the web interface is bound to localhost.")
 
 	allAPISets := []string{
@@ -668,6 +835,8 @@ func (c *NodeConfig) RegisterFlags() {
 		api.EndpointsNetCtrl,
 		api.EndpointsInsecureWalletSeed,
 		api.EndpointsStorage,
+		api.EndpointsMetrics,
+		api.EndpointsWebhook,
 	}
 	flag.StringVar(&c.EnabledAPISets, "enable-api-sets", c.EnabledAPISets, fmt.Sprintf("enable API set. Options are %s. Multiple values should be separated by comma", strings.Join(allAPISets, ", ")))
 	flag.StringVar(&c.DisabledAPISets, "disable-api-sets", c.DisabledAPISets, fmt.Sprintf("disable API set. Options are %s. Multiple values should be separated by comma", strings.Join(allAPISets, ", ")))
@@ -676,6 +845,11 @@ func (c *NodeConfig) RegisterFlags() {
 	flag.StringVar(&c.WebInterfaceUsername, "web-interface-username", c.WebInterfaceUsername, "username for the web interface")
 	flag.StringVar(&c.WebInterfacePassword, "web-interface-password", c.WebInterfacePassword, "password for the web interface")
 	flag.BoolVar(&c.WebInterfacePlaintextAuth, "web-interface-plaintext-auth", c.WebInterfacePlaintextAuth, "allow web interface auth without https")
+	flag.Float64Var(&c.RateLimit, "rate-limit", c.RateLimit, "number of request cost units each API client can spend per second. Clients are identified by their API token or IP address. Set to 0 to disable rate limiting")
+	flag.IntVar(&c.RateLimitBurst, "rate-limit-burst", c.RateLimitBurst, "maximum number of request cost units an API client can spend at once")
+	flag.StringVar(&c.RateLimitEndpointCosts, "rate-limit-endpoint-costs", c.RateLimitEndpointCosts, "comma separated list of endpoint:cost request costs. An endpoint with a ?verbose=1 suffix applies to verbose requests")
+	flag.StringVar(&c.RateLimitAPISetCosts, "rate-limit-api-set-costs", c.RateLimitAPISetCosts, "comma separated list of API_SET:cost request costs, for endpoints without an endpoint cost. The default cost is 1")
+	flag.StringVar(&c.APITokensFile, "api-tokens-file", c.APITokensFile, "API tokens file, managed with the CLI apiToken commands. Defaults to ~/.skycoin/api_tokens.json")
 
 	flag.BoolVar(&c.LaunchBrowser, "launch-browser", c.LaunchBrowser, "launch system default webbrowser at client startup")
 	flag.StringVar(&c.DataDirectory, "data-dir", c.DataDirectory, "directory to store app data (defaults to ~/.skycoin)")
@@ -706,6 +880,9 @@ func (c *NodeConfig) RegisterFlags() {
 	flag.Uint64Var(&c.createBlockMaxTransactionSize, "max-txn-size-create-block", uint64(c.CreateBlockVerifyTxn.MaxTransactionSize), "maximum size of a transaction applied when creating blocks")
 	flag.Uint64Var(&c.createBlockMaxDropletPrecision, "max-decimals-create-block", uint64(c.CreateBlockVerifyTxn.MaxDropletPrecision), "max number of decimal places applied when creating blocks")
 	flag.Uint64Var(&c.maxBlockSize, "max-block-size", uint64(c.MaxBlockTransactionsSize), "maximum total size of transactions in a block")
+	flag.Uint64Var(&c.BalanceSnapshotInterval, "balance-snapshot-interval", c.BalanceSnapshotInterval, "number of blocks between snapshots of the address balances, which speed up the historical richlist. Set to 0 to disable the snapshots")
+	flag.IntVar(&c.SigVerifyWorkers, "sig-verify-workers", c.SigVerifyWorkers, "number of workers verifying transaction signatures concurrently. Set to 0 to use one worker per CPU")
+	flag.IntVar(&c.SigCacheSize, "sig-cache-size", c.SigCacheSize, "maximum number of valid transaction signatures cached, so that they are not verified again when the transaction is included in a block. Set to 0 to disable the cache")
 
 	flag.BoolVar(&c.RunBlockPublisher, "block-publisher", c.RunBlockPublisher, "run the daemon as a block publisher")
 	flag.StringVar(&c.BlockchainPubkeyStr, "blockchain-public-key", c.BlockchainPubkeyStr, "public key of the blockchain")
@@ -727,6 +904,17 @@ func (c *NodeConfig) RegisterFlags() {
 	flag.IntVar(&c.MaxIncomingMessageLength, "max-in-msg-len", c.MaxIncomingMessageLength, "Maximum length of incoming wire messages")
 	flag.BoolVar(&c.LocalhostOnly, "localhost-only", c.LocalhostOnly, "Run on localhost and only connect to localhost peers")
 	flag.StringVar(&c.WalletCryptoType, "wallet-crypto-type", c.WalletCryptoType, "wallet crypto type. Can be sha256-xor or scrypt-chacha20poly1305")
+	flag.Uint64Var(&c.WalletAddressGapLimit, "wallet-address-gap-limit", c.WalletAddressGapLimit, "number of unused addresses to keep ahead on each bip44 and xpub wallet address chain. Set to 0 to disable address discovery")
+	flag.DurationVar(&c.WalletAddressDiscoveryInterval, "wallet-address-discovery-interval", c.WalletAddressDiscoveryInterval, "how often to check wallets for address discovery")
+
+	flag.DurationVar(&c.PayoutCheckInterval, "payout-check-interval", c.PayoutCheckInterval, "how often to check the transactions of unfinished payout jobs")
+	flag.Uint64Var(&c.PayoutConfirmations, "payout-confirmations", c.PayoutConfirmations, "number of confirmations for a payout transaction to be considered confirmed")
+	flag.DurationVar(&c.WebhookCheckInterval, "webhook-check-interval", c.WebhookCheckInterval, "how often to check webhooks for events and send pending deliveries")
+	flag.IntVar(&c.WebhookMaxAttempts, "webhook-max-attempts", c.WebhookMaxAttempts, "number of attempts to send a webhook delivery before it fails")
+	flag.DurationVar(&c.WebhookRetryInterval, "webhook-retry-interval", c.WebhookRetryInterval, "delay before retrying a failed webhook delivery, doubled after each failed attempt")
+	flag.DurationVar(&c.WebhookTimeout, "webhook-timeout", c.WebhookTimeout, "timeout of a webhook delivery request")
+	flag.BoolVar(&c.WebhookAllowPrivateURLs, "webhook-allow-private-urls", c.WebhookAllowPrivateURLs, "allow webhook deliveries to loopback, link-local, private and other non-public addresses")
+	flag.IntVar(&c.WebhookMaxDeliveries, "webhook-max-deliveries", c.WebhookMaxDeliveries, "number of delivered and failed deliveries kept for each webhook")
 	flag.BoolVar(&c.Version, "version", false, "show node version")
 }
 
@@ -20,11 +20,13 @@ import (
 	"github.com/toqueteos/webbrowser"
 
 	"github.com/skycoin/skycoin/src/api"
+	"github.com/skycoin/skycoin/src/apitoken"
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/daemon"
 	"github.com/skycoin/skycoin/src/kvstorage"
 	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/payout"
 	"github.com/skycoin/skycoin/src/readable"
 	"github.com/skycoin/skycoin/src/util/apputil"
 	"github.com/skycoin/skycoin/src/util/certutil"
@@ -34,6 +36,7 @@ import (
 	"github.com/skycoin/skycoin/src/visor/dbutil"
 	"github.com/skycoin/skycoin/src/wallet"
 	"github.com/skycoin/skycoin/src/wallet/crypto"
+	"github.com/skycoin/skycoin/src/webhook"
 )
 
 var (
@@ -57,8 +60,11 @@ func (c *Coin) Run() error {
 	var v *visor.Visor
 	var d *daemon.Daemon
 	var s *kvstorage.Manager
+	var po *payout.Processor
+	var wd *webhook.Dispatcher
 	var gw *api.Gateway
 	var webInterface *api.Server
+	var grpcServer *api.GRPCServer
 	var retErr error
 	errC := make(chan error, 10)
 
@@ -146,6 +152,8 @@ func (c *Coin) Run() error {
 	dconf := c.ConfigureDaemon()
 	vconf := c.ConfigureVisor()
 	sconf := c.ConfigureStorage()
+	pconf := c.ConfigurePayout()
+	whconf := c.ConfigureWebhook()
 
 	// Open the database
 	c.logger.Infof("Opening database %s", c.config.Node.DBPath)
@@ -222,11 +230,42 @@ func (c *Coin) Run() error {
 		return err
 	}
 
+	c.logger.Info("payout.NewProcessor")
+	po, err = payout.NewProcessor(pconf, db, v, d)
+	if err != nil {
+		c.logger.WithError(err).Error("payout.NewProcessor failed")
+		return err
+	}
+
+	c.logger.Info("webhook.NewDispatcher")
+	wd, err = webhook.NewDispatcher(whconf, db, v)
+	if err != nil {
+		c.logger.WithError(err).Error("webhook.NewDispatcher failed")
+		return err
+	}
+
 	c.logger.Info("api.NewGateway")
-	gw = api.NewGateway(d, v, w, s)
+	gw = api.NewGateway(d, v, w, s, po, wd)
+
+	var apiTokens *apitoken.Store
+	if c.config.Node.WebInterface || c.config.Node.GRPC {
+		apiTokens, err = apitoken.NewStore(c.config.Node.APITokensFile)
+		if err != nil {
+			c.logger.WithError(err).Error("apitoken.NewStore failed")
+			return err
+		}
+	}
+
+	// The web interface and the gRPC server share the rate limiter, so that a client has one quota for both
+	rateLimiter := api.NewRateLimiter(api.RateLimitConfig{
+		Rate:          c.config.Node.RateLimit,
+		Burst:         c.config.Node.RateLimitBurst,
+		EndpointCosts: c.config.Node.rateLimitEndpointCosts,
+		APISetCosts:   c.config.Node.rateLimitAPISetCosts,
+	})
 
 	if c.config.Node.WebInterface {
-		webInterface, err = c.createGUI(gw, host)
+		webInterface, err = c.createGUI(gw, host, apiTokens, rateLimiter)
 		if err != nil {
 			c.logger.WithError(err).Error("c.createGUI failed")
 			return err
@@ -236,6 +275,16 @@ func (c *Coin) Run() error {
 		c.logger.Critical().Infof("Full address: %s", fullAddress)
 	}
 
+	if c.config.Node.GRPC {
+		grpcServer, err = c.createGRPC(gw, apiTokens, rateLimiter)
+		if err != nil {
+			c.logger.WithError(err).Error("c.createGRPC failed")
+			return err
+		}
+
+		c.logger.Critical().Infof("gRPC address: %s", grpcServer.Addr())
+	}
+
 	c.logger.Info("visor.Init")
 	if err := v.Init(); err != nil {
 		c.logger.WithError(err).Error("visor.Init failed")
@@ -253,6 +302,33 @@ func (c *Coin) Run() error {
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		c.logger.Info("wallet.RunAddressDiscovery")
+		w.RunAddressDiscovery(v.TransactionsFinder())
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		c.logger.Info("payout.Run")
+		po.Run()
+	}()
+
+	// Webhooks can only be created with the WEBHOOK API set, the dispatcher has nothing to do without it
+	if _, ok := c.config.Node.enabledAPISets[api.EndpointsWebhook]; ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			c.logger.Info("webhook.Run")
+			wd.Run()
+		}()
+	}
+
 	if c.config.Node.WebInterface {
 		cancelLaunchBrowser := make(chan struct{})
 
@@ -285,6 +361,19 @@ func (c *Coin) Run() error {
 		}
 	}
 
+	if grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			c.logger.Info("grpcServer.Serve")
+			if err := grpcServer.Serve(); err != nil {
+				c.logger.WithError(err).Error("grpcServer.Serve failed")
+				errC <- err
+			}
+		}()
+	}
+
 	select {
 	case <-quit:
 	case retErr = <-errC:
@@ -298,9 +387,23 @@ func (c *Coin) Run() error {
 		webInterface.Shutdown()
 	}
 
+	if grpcServer != nil {
+		c.logger.Info("Closing gRPC server")
+		grpcServer.Shutdown()
+	}
+
 	c.logger.Info("Closing daemon")
 	d.Shutdown()
 
+	c.logger.Info("Stopping wallet address discovery")
+	w.Shutdown()
+
+	c.logger.Info("Stopping payout processor")
+	po.Shutdown()
+
+	c.logger.Info("Stopping webhook dispatcher")
+	wd.Shutdown()
+
 	c.logger.Info("Waiting for goroutines to finish")
 	wg.Wait()
 
@@ -353,6 +456,9 @@ func (c *Coin) ConfigureVisor() visor.Config {
 	vc.UnconfirmedVerifyTxn = c.config.Node.UnconfirmedVerifyTxn
 	vc.CreateBlockVerifyTxn = c.config.Node.CreateBlockVerifyTxn
 	vc.MaxBlockTransactionsSize = c.config.Node.MaxBlockTransactionsSize
+	vc.BalanceSnapshotInterval = c.config.Node.BalanceSnapshotInterval
+	vc.SigVerifyWorkers = c.config.Node.SigVerifyWorkers
+	vc.SigCacheSize = c.config.Node.SigCacheSize
 
 	vc.GenesisAddress = c.config.Node.genesisAddress
 	vc.GenesisSignature = c.config.Node.genesisSignature
@@ -381,6 +487,9 @@ func (c *Coin) ConfigureWallet() wallet.Config {
 	bc := c.config.Node.Fiber.Bip44Coin
 	wc.Bip44Coin = &bc
 
+	wc.AddressGapLimit = c.config.Node.WalletAddressGapLimit
+	wc.AddressDiscoveryInterval = c.config.Node.WalletAddressDiscoveryInterval
+
 	return wc
 }
 
@@ -395,6 +504,35 @@ func (c *Coin) ConfigureStorage() kvstorage.Config {
 	return sc
 }
 
+// ConfigurePayout sets the payout processor config values
+func (c *Coin) ConfigurePayout() payout.Config {
+	pc := payout.NewConfig()
+
+	pc.CheckInterval = c.config.Node.PayoutCheckInterval
+	pc.Confirmations = c.config.Node.PayoutConfirmations
+
+	return pc
+}
+
+// ConfigureWebhook sets the webhook dispatcher config values
+func (c *Coin) ConfigureWebhook() webhook.Config {
+	wc := webhook.NewConfig()
+
+	wc.CheckInterval = c.config.Node.WebhookCheckInterval
+	wc.MaxAttempts = c.config.Node.WebhookMaxAttempts
+	wc.RetryInterval = c.config.Node.WebhookRetryInterval
+	wc.Timeout = c.config.Node.WebhookTimeout
+	wc.AllowPrivateURLs = c.config.Node.WebhookAllowPrivateURLs
+	wc.MaxDeliveries = c.config.Node.WebhookMaxDeliveries
+
+	// Keep the default maximum retry interval unless the retry interval exceeds it
+	if wc.MaxRetryInterval < wc.RetryInterval {
+		wc.MaxRetryInterval = wc.RetryInterval
+	}
+
+	return wc
+}
+
 // ConfigureDaemon sets the daemon config values
 func (c *Coin) ConfigureDaemon() daemon.Config {
 	dc := daemon.NewConfig()
@@ -444,7 +582,7 @@ func (c *Coin) ConfigureDaemon() daemon.Config {
 	return dc
 }
 
-func (c *Coin) createGUI(gw *api.Gateway, host string) (*api.Server, error) {
+func (c *Coin) createGUI(gw *api.Gateway, host string, apiTokens *apitoken.Store, rateLimiter *api.RateLimiter) (*api.Server, error) {
 	config := api.Config{
 		StaticDir:          c.config.Node.GUIDirectory,
 		DisableCSRF:        c.config.Node.DisableCSRF,
@@ -466,37 +604,25 @@ func (c *Coin) createGUI(gw *api.Gateway, host string) (*api.Server, error) {
 			DaemonUserAgent: c.config.Node.userAgent,
 			BlockPublisher:  c.config.Node.RunBlockPublisher,
 		},
-		Username: c.config.Node.WebInterfaceUsername,
-		Password: c.config.Node.WebInterfacePassword,
+		Username:    c.config.Node.WebInterfaceUsername,
+		Password:    c.config.Node.WebInterfacePassword,
+		APITokens:   apiTokens,
+		RateLimiter: rateLimiter,
 	}
 
 	var s *api.Server
+	var err error
 	if c.config.Node.WebInterfaceHTTPS {
-		// Verify cert/key parameters, and if neither exist, create them
-		exists, err := checkCertFiles(c.config.Node.WebInterfaceCert, c.config.Node.WebInterfaceKey)
-		if err != nil {
-			c.logger.WithError(err).Error("checkCertFiles failed")
+		if err := c.ensureCertFiles(); err != nil {
 			return nil, err
 		}
 
-		if !exists {
-			c.logger.Infof("Autogenerating HTTP certificate and key files %s, %s", c.config.Node.WebInterfaceCert, c.config.Node.WebInterfaceKey)
-			if err := createCertFiles(c.config.Node.WebInterfaceCert, c.config.Node.WebInterfaceKey); err != nil {
-				c.logger.WithError(err).Error("createCertFiles failed")
-				return nil, err
-			}
-
-			c.logger.Infof("Created cert file %s", c.config.Node.WebInterfaceCert)
-			c.logger.Infof("Created key file %s", c.config.Node.WebInterfaceKey)
-		}
-
 		s, err = api.CreateHTTPS(host, config, gw, c.config.Node.WebInterfaceCert, c.config.Node.WebInterfaceKey)
 		if err != nil {
 			c.logger.WithError(err).Error("Failed to start web failed")
 			return nil, err
 		}
 	} else {
-		var err error
 		s, err = api.Create(host, config, gw)
 		if err != nil {
 			c.logger.WithError(err).Error("Failed to start web failed")
@@ -507,6 +633,61 @@ func (c *Coin) createGUI(gw *api.Gateway, host string) (*api.Server, error) {
 	return s, nil
 }
 
+func (c *Coin) createGRPC(gw *api.Gateway, apiTokens *apitoken.Store, rateLimiter *api.RateLimiter) (*api.GRPCServer, error) {
+	host := fmt.Sprintf("%s:%d", c.config.Node.GRPCAddr, c.config.Node.GRPCPort)
+
+	config := api.GRPCConfig{
+		EnabledAPISets: c.config.Node.enabledAPISets,
+		Username:       c.config.Node.WebInterfaceUsername,
+		Password:       c.config.Node.WebInterfacePassword,
+		PlaintextAuth:  c.config.Node.WebInterfacePlaintextAuth,
+		APITokens:      apiTokens,
+		RateLimiter:    rateLimiter,
+	}
+
+	var s *api.GRPCServer
+	var err error
+	if c.config.Node.WebInterfaceHTTPS {
+		if err := c.ensureCertFiles(); err != nil {
+			return nil, err
+		}
+
+		s, err = api.CreateGRPCTLS(host, config, gw, c.config.Node.WebInterfaceCert, c.config.Node.WebInterfaceKey)
+	} else {
+		s, err = api.CreateGRPC(host, config, gw)
+	}
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to start gRPC server")
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ensureCertFiles verifies the web interface cert/key parameters, and if neither exist, creates them
+func (c *Coin) ensureCertFiles() error {
+	exists, err := checkCertFiles(c.config.Node.WebInterfaceCert, c.config.Node.WebInterfaceKey)
+	if err != nil {
+		c.logger.WithError(err).Error("checkCertFiles failed")
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	c.logger.Infof("Autogenerating HTTP certificate and key files %s, %s", c.config.Node.WebInterfaceCert, c.config.Node.WebInterfaceKey)
+	if err := createCertFiles(c.config.Node.WebInterfaceCert, c.config.Node.WebInterfaceKey); err != nil {
+		c.logger.WithError(err).Error("createCertFiles failed")
+		return err
+	}
+
+	c.logger.Infof("Created cert file %s", c.config.Node.WebInterfaceCert)
+	c.logger.Infof("Created key file %s", c.config.Node.WebInterfaceKey)
+
+	return nil
+}
+
 // checkCertFiles returns true if both cert and key files exist, false if neither exist,
 // or returns an error if only one does not exist
 func checkCertFiles(cert, key string) (bool, error) {
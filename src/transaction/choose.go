@@ -3,6 +3,7 @@ package transaction
 import (
 	"bytes"
 	"errors"
+	"math"
 	"sort"
 
 	"github.com/skycoin/skycoin/src/cipher"
@@ -84,6 +85,24 @@ func uxBalancesSub(a, b []UxBalance) []UxBalance {
 	return x
 }
 
+// uxBalancesFilterAddresses returns the elements of a owned by an address of b
+func uxBalancesFilterAddresses(a, b []UxBalance) []UxBalance {
+	var x []UxBalance
+
+	bMap := make(map[cipher.Address]struct{}, len(b))
+	for _, i := range b {
+		bMap[i.Address] = struct{}{}
+	}
+
+	for _, i := range a {
+		if _, ok := bMap[i.Address]; ok {
+			x = append(x, i)
+		}
+	}
+
+	return x
+}
+
 // ChooseSpendsMinimizeUxOuts chooses uxout spends to satisfy an amount, using the least number of uxouts
 //     -- PRO: Allows more frequent spending, less waiting for confirmations, useful for exchanges.
 //     -- PRO: When transaction is volume is higher, transactions are prioritized by fee/size. Minimizing uxouts minimizes size.
@@ -276,3 +295,229 @@ func ChooseSpends(uxa []UxBalance, coins, hours uint64, sortStrategy func([]UxBa
 
 	return nil, ErrInsufficientHours
 }
+
+// chooseSpendsWithStrategy chooses uxouts to spend with the ChooseStrategy of Params
+func chooseSpendsWithStrategy(strategy string, uxa []UxBalance, coins, hours uint64) ([]UxBalance, error) {
+	switch strategy {
+	case "", ChooseStrategyMinimizeUxOuts:
+		return ChooseSpendsMinimizeUxOuts(uxa, coins, hours)
+	case ChooseStrategyMaximizeUxOuts:
+		return ChooseSpendsMaximizeUxOuts(uxa, coins, hours)
+	case ChooseStrategyAvoidAddressMerge:
+		return ChooseSpendsAvoidAddressMerge(uxa, coins, hours)
+	case ChooseStrategyExactMatch:
+		return ChooseSpendsExactMatch(uxa, coins, hours)
+	case ChooseStrategyMinimizeChange:
+		return ChooseSpendsMinimizeChange(uxa, coins, hours)
+	default:
+		return nil, ErrInvalidChooseStrategy
+	}
+}
+
+// ChooseSpendsAvoidAddressMerge chooses uxout spends to satisfy an amount, avoiding spending
+// outputs of different addresses in the same transaction.
+// Spending outputs of several addresses together reveals that the addresses have the same owner.
+//     -- If the outputs of a single address can satisfy the amount, the address whose outputs
+//        leave the least change is used.
+//     -- Otherwise, addresses are merged starting with the addresses with the most coins,
+//        so that as few addresses as possible are linked together.
+// Outputs are chosen within the selected addresses with ChooseSpendsMinimizeUxOuts.
+func ChooseSpendsAvoidAddressMerge(uxa []UxBalance, coins, hours uint64) ([]UxBalance, error) {
+	if coins == 0 {
+		return nil, ErrZeroSpend
+	}
+
+	if len(uxa) == 0 {
+		return nil, ErrNoUnspents
+	}
+
+	groups := make(map[cipher.Address][]UxBalance)
+	groupCoins := make(map[cipher.Address]uint64)
+	var addrs []cipher.Address
+	for _, ux := range uxa {
+		if _, ok := groups[ux.Address]; !ok {
+			addrs = append(addrs, ux.Address)
+		}
+		groups[ux.Address] = append(groups[ux.Address], ux)
+		groupCoins[ux.Address] += ux.Coins
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	// Try to spend from a single address
+	var best []UxBalance
+	var bestChange uint64
+	for _, a := range addrs {
+		spends, err := ChooseSpendsMinimizeUxOuts(groups[a], coins, hours)
+		if err != nil {
+			continue
+		}
+
+		change := totalUxBalanceCoins(spends) - coins
+		if best == nil || change < bestChange || (change == bestChange && len(spends) < len(best)) {
+			best = spends
+			bestChange = change
+		}
+	}
+
+	if best != nil {
+		return best, nil
+	}
+
+	// Merge addresses, those with the most coins first
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return groupCoins[addrs[i]] > groupCoins[addrs[j]]
+	})
+
+	var pool []UxBalance
+	var err error
+	for _, a := range addrs {
+		pool = append(pool, groups[a]...)
+
+		var spends []UxBalance
+		spends, err = ChooseSpendsMinimizeUxOuts(pool, coins, hours)
+		if err == nil {
+			return spends, nil
+		}
+	}
+
+	return nil, err
+}
+
+// ChooseSpendsExactMatch chooses uxout spends whose coins add up to exactly the amount, so that
+// the transaction has no change output.
+// If there is no exact match, it falls back to ChooseSpendsMinimizeUxOuts.
+func ChooseSpendsExactMatch(uxa []UxBalance, coins, hours uint64) ([]UxBalance, error) {
+	if coins == 0 {
+		return nil, ErrZeroSpend
+	}
+
+	if len(uxa) == 0 {
+		return nil, ErrNoUnspents
+	}
+
+	if spends := chooseSpendsBranchAndBound(uxa, coins, hours, 0); spends != nil {
+		return spends, nil
+	}
+
+	return ChooseSpendsMinimizeUxOuts(uxa, coins, hours)
+}
+
+// ChooseSpendsMinimizeChange chooses uxout spends that leave the least change, with a branch and bound search.
+// An exact match is chosen if one exists.
+// If the search gives up before finding a selection, it falls back to ChooseSpendsMinimizeUxOuts.
+func ChooseSpendsMinimizeChange(uxa []UxBalance, coins, hours uint64) ([]UxBalance, error) {
+	if coins == 0 {
+		return nil, ErrZeroSpend
+	}
+
+	if len(uxa) == 0 {
+		return nil, ErrNoUnspents
+	}
+
+	if spends := chooseSpendsBranchAndBound(uxa, coins, hours, math.MaxUint64); spends != nil {
+		return spends, nil
+	}
+
+	return ChooseSpendsMinimizeUxOuts(uxa, coins, hours)
+}
+
+// branchAndBoundMaxTries limits the number of selections evaluated by the branch and bound search
+const branchAndBoundMaxTries = 100000
+
+// branchAndBound is the state of a branch and bound search for the uxouts that leave the least change
+type branchAndBound struct {
+	uxa []UxBalance
+	// remaining[i] is the total coins of uxa[i:]
+	remaining []uint64
+	coins     uint64
+	hours     uint64
+	maxChange uint64
+	tries     int
+
+	selected   []int
+	best       []int
+	bestChange uint64
+}
+
+// chooseSpendsBranchAndBound searches for the uxouts that satisfy the coins and hours while leaving
+// the least change, which must not be more than maxChange.
+// Returns nil if no selection was found.
+func chooseSpendsBranchAndBound(uxa []UxBalance, coins, hours, maxChange uint64) []UxBalance {
+	sorted := make([]UxBalance, len(uxa))
+	copy(sorted, uxa)
+	sortSpendsCoinsHighToLow(sorted)
+
+	remaining := make([]uint64, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + sorted[i].Coins
+	}
+
+	s := &branchAndBound{
+		uxa:       sorted,
+		remaining: remaining,
+		coins:     coins,
+		hours:     hours,
+		maxChange: maxChange,
+	}
+
+	s.search(0, 0, 0)
+
+	if s.best == nil {
+		return nil
+	}
+
+	spends := make([]UxBalance, len(s.best))
+	for i, j := range s.best {
+		spends[i] = sorted[j]
+	}
+	return spends
+}
+
+func (s *branchAndBound) search(i int, haveCoins, haveHours uint64) {
+	if s.tries >= branchAndBoundMaxTries {
+		return
+	}
+	s.tries++
+
+	// An exact match can't be improved
+	if s.best != nil && s.bestChange == 0 {
+		return
+	}
+
+	if haveCoins >= s.coins {
+		change := haveCoins - s.coins
+		if change > s.maxChange || (s.best != nil && change >= s.bestChange) {
+			return
+		}
+
+		// Adding more uxouts would only increase the change
+		if haveHours > 0 && fee.RemainingHours(haveHours, params.UserVerifyTxn.BurnFactor) >= s.hours {
+			s.best = append(s.best[:0], s.selected...)
+			s.bestChange = change
+			return
+		}
+	}
+
+	if i == len(s.uxa) || haveCoins+s.remaining[i] < s.coins {
+		return
+	}
+
+	// Include uxa[i]
+	s.selected = append(s.selected, i)
+	s.search(i+1, haveCoins+s.uxa[i].Coins, haveHours+s.uxa[i].Hours)
+	s.selected = s.selected[:len(s.selected)-1]
+
+	// Exclude uxa[i]
+	s.search(i+1, haveCoins, haveHours)
+}
+
+func totalUxBalanceCoins(uxa []UxBalance) uint64 {
+	var coins uint64
+	for _, ux := range uxa {
+		coins += ux.Coins
+	}
+	return coins
+}
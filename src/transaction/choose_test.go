@@ -532,3 +532,148 @@ func verifySortedHoursLowToHigh(t *testing.T, uxb []UxBalance) {
 		return a.Hours <= b.Hours
 	})
 }
+
+func TestChooseSpendsStrategies(t *testing.T) {
+	addr1 := testutil.MakeAddress()
+	addr2 := testutil.MakeAddress()
+	addr3 := testutil.MakeAddress()
+
+	mkUxb := func(addr cipher.Address, coins, hours uint64) UxBalance {
+		return UxBalance{
+			Hash:    testutil.RandSHA256(t),
+			BkSeq:   1,
+			Address: addr,
+			Coins:   coins,
+			Hours:   hours,
+		}
+	}
+
+	uxa := []UxBalance{
+		mkUxb(addr1, 7e6, 10),
+		mkUxb(addr1, 4e6, 10),
+		mkUxb(addr2, 5e6, 10),
+		mkUxb(addr2, 3e6, 10),
+		mkUxb(addr3, 20e6, 10),
+		mkUxb(addr3, 1e6, 0),
+	}
+
+	cases := []struct {
+		name   string
+		choose func([]UxBalance, uint64, uint64) ([]UxBalance, error)
+		coins  uint64
+		hours  uint64
+		expect []UxBalance
+		err    error
+	}{
+		{
+			name:   "avoid address merge single address least change",
+			choose: ChooseSpendsAvoidAddressMerge,
+			coins:  8e6,
+			expect: []UxBalance{uxa[2], uxa[3]},
+		},
+		{
+			name:   "avoid address merge single address with zero hour output",
+			choose: ChooseSpendsAvoidAddressMerge,
+			coins:  21e6,
+			expect: []UxBalance{uxa[4], uxa[5]},
+		},
+		{
+			name:   "avoid address merge merges the fewest addresses",
+			choose: ChooseSpendsAvoidAddressMerge,
+			coins:  30e6,
+			expect: []UxBalance{uxa[4], uxa[5], uxa[0], uxa[1]},
+		},
+		{
+			name:   "avoid address merge insufficient balance",
+			choose: ChooseSpendsAvoidAddressMerge,
+			coins:  100e6,
+			err:    ErrInsufficientBalance,
+		},
+		{
+			name:   "exact match",
+			choose: ChooseSpendsExactMatch,
+			coins:  9e6,
+			expect: []UxBalance{uxa[2], uxa[1]},
+		},
+		{
+			name:   "exact match needs hours",
+			choose: ChooseSpendsExactMatch,
+			coins:  1e6,
+			// The only exact match has no hours, fall back to ChooseSpendsMinimizeUxOuts
+			expect: []UxBalance{uxa[4]},
+		},
+		{
+			name:   "minimize change exact match",
+			choose: ChooseSpendsMinimizeChange,
+			coins:  15e6,
+			expect: []UxBalance{uxa[0], uxa[2], uxa[3]},
+		},
+		{
+			name:   "minimize change",
+			choose: ChooseSpendsMinimizeChange,
+			coins:  2e6,
+			expect: []UxBalance{uxa[3]},
+		},
+		{
+			name:   "minimize change with hours",
+			choose: ChooseSpendsMinimizeChange,
+			coins:  2e6,
+			hours:  10,
+			expect: []UxBalance{uxa[1], uxa[3]},
+		},
+		{
+			name:   "minimize change insufficient hours",
+			choose: ChooseSpendsMinimizeChange,
+			coins:  2e6,
+			hours:  1000,
+			err:    ErrInsufficientHours,
+		},
+		{
+			name:   "minimize change zero spend",
+			choose: ChooseSpendsMinimizeChange,
+			err:    ErrZeroSpend,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uxb := make([]UxBalance, len(uxa))
+			copy(uxb, uxa)
+
+			spends, err := tc.choose(uxb, tc.coins, tc.hours)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.expect, spends)
+
+			// The input is not modified
+			require.Equal(t, uxa, uxb)
+		})
+	}
+
+	_, err := ChooseSpendsExactMatch(nil, 1, 0)
+	require.Equal(t, ErrNoUnspents, err)
+	_, err = ChooseSpendsAvoidAddressMerge(nil, 1, 0)
+	require.Equal(t, ErrNoUnspents, err)
+}
+
+func TestChooseSpendsMinimizeChangeRandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		uxb := makeRandomUxBalances(t)
+		if len(uxb) == 0 {
+			continue
+		}
+
+		total := totalUxBalanceCoins(uxb)
+		coins := uint64(rand.Int63n(int64(total))) + 1
+
+		minimized, err := ChooseSpendsMinimizeUxOuts(uxb, coins, 0)
+		spends, err2 := ChooseSpendsMinimizeChange(uxb, coins, 0)
+		require.Equal(t, err, err2)
+		if err != nil {
+			continue
+		}
+
+		have := totalUxBalanceCoins(spends)
+		require.True(t, have >= coins)
+		require.True(t, have <= totalUxBalanceCoins(minimized))
+	}
+}
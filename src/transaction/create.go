@@ -74,24 +74,17 @@ func create(p Params, auxs coin.AddressUxOuts, headTime uint64, callCount int) (
 	}
 
 	// Calculate total coins and minimum hours to send
-	var totalOutCoins uint64
-	var requestedHours uint64
-	for _, to := range p.To {
-		totalOutCoins, err = mathutil.AddUint64(totalOutCoins, to.Coins)
-		if err != nil {
-			return nil, nil, NewError(fmt.Errorf("total output coins error: %v", err))
-		}
-
-		requestedHours, err = mathutil.AddUint64(requestedHours, to.Hours)
-		if err != nil {
-			return nil, nil, NewError(fmt.Errorf("total output hours error: %v", err))
-		}
+	totalOuts, totalRequestedHours, err := p.Totals()
+	if err != nil {
+		return nil, nil, err
 	}
+	totalOutCoins := totalOuts.Value()
+	requestedHours := totalRequestedHours.Value()
 
-	// Use the MinimizeUxOuts strategy, to use least possible uxouts
+	// Use the MinimizeUxOuts strategy by default, to use least possible uxouts
 	// this will allow more frequent spending
 	// we don't need to check whether we have sufficient balance beforehand as ChooseSpends already checks that
-	spends, err := ChooseSpendsMinimizeUxOuts(uxb, totalOutCoins, requestedHours)
+	spends, err := chooseSpendsWithStrategy(p.ChooseStrategy, uxb, totalOutCoins, requestedHours)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -222,12 +215,18 @@ func create(p Params, auxs coin.AddressUxOuts, headTime uint64, callCount int) (
 	// This chooses an available input with the least number of coin hours;
 	// if the extra coin hour fee incurred by this additional input is less than
 	// the remaining coin hours, the input is added.
-	if changeCoins == 0 && changeHours > 0 {
+	// The exact match and minimize change strategies are meant to avoid a change output,
+	// so they skip this.
+	if changeCoins == 0 && changeHours > 0 && p.ChooseStrategy != ChooseStrategyExactMatch && p.ChooseStrategy != ChooseStrategyMinimizeChange {
 		logger.Info("Trying to recover change hours by forcing an extra input")
 		// Find the output with the least coin hours
 		// If size of the fee for this output is less than the changeHours, add it
 		// Update changeCoins and changeHours
 		z := uxBalancesSub(uxb, spends)
+		if p.ChooseStrategy == ChooseStrategyAvoidAddressMerge {
+			// Don't link another address to the addresses being spent
+			z = uxBalancesFilterAddresses(z, spends)
+		}
 		sortSpendsHoursLowToHigh(z)
 		if len(z) > 0 {
 			logger.Info("Extra input found, evaluating if it can recover change hours")
@@ -353,6 +353,54 @@ func TestCreate(t *testing.T) {
 			},
 		},
 
+		{
+			// the exact match strategy does not force a change output
+			name: "manual, 1 output, exact match, no forced change",
+			params: Params{
+				ChangeAddress: &changeAddress,
+				HoursSelection: HoursSelection{
+					Type: HoursSelectionTypeManual,
+				},
+				To: []coin.TransactionOutput{
+					{
+						Address: addrs[0],
+						Hours:   0,
+						Coins:   2e6 * 2,
+					},
+				},
+				ChooseStrategy: ChooseStrategyExactMatch,
+			},
+			unspents:       uxouts,
+			chosenUnspents: []coin.UxOut{originalUxouts[0], originalUxouts[1]},
+			changeOutput:   nil,
+		},
+
+		{
+			// the avoid address merge strategy does not force a change output
+			// with an unspent of another address
+			name: "manual, 1 output, avoid address merge, forced change rejected",
+			params: Params{
+				ChangeAddress: &changeAddress,
+				HoursSelection: HoursSelection{
+					Type: HoursSelectionTypeManual,
+				},
+				To: []coin.TransactionOutput{
+					{
+						Address: addrs[0],
+						Hours:   0,
+						Coins:   2e6 * 2,
+					},
+				},
+				ChooseStrategy: ChooseStrategyAvoidAddressMerge,
+			},
+			addressUnspents: coin.AddressUxOuts{
+				extraWalletAddrs[0]: []coin.UxOut{extraUxouts[0][0], extraUxouts[0][1]},
+				extraWalletAddrs[1]: []coin.UxOut{extraUxouts[1][0]},
+			},
+			chosenUnspents: []coin.UxOut{extraUxouts[0][0], extraUxouts[0][1]},
+			changeOutput:   nil,
+		},
+
 		{
 			// there are leftover coin hours and no coins change,
 			// but there are no more unspents to use to force a change output
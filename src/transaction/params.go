@@ -2,11 +2,13 @@ package transaction
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/util/amount"
 )
 
 // Error wraps transaction creation-related errors.
@@ -31,6 +33,21 @@ const (
 
 	// HoursSelectionModeShare will distribute coin hours equally amongst destinations
 	HoursSelectionModeShare = "share"
+
+	// ChooseStrategyMinimizeUxOuts chooses uxouts with ChooseSpendsMinimizeUxOuts. This is the default strategy
+	ChooseStrategyMinimizeUxOuts = "minimize_uxouts"
+	// ChooseStrategyMaximizeUxOuts chooses uxouts with ChooseSpendsMaximizeUxOuts
+	ChooseStrategyMaximizeUxOuts = "maximize_uxouts"
+	// ChooseStrategyAvoidAddressMerge chooses uxouts with ChooseSpendsAvoidAddressMerge.
+	// An extra input added to recover change hours is only taken from the addresses already being spent.
+	ChooseStrategyAvoidAddressMerge = "avoid_address_merge"
+	// ChooseStrategyExactMatch chooses uxouts with ChooseSpendsExactMatch.
+	// No extra input is added to recover change hours, so that an exact match has no change output.
+	// With manual hours selection, hours that are not sent to a receiver are burned.
+	ChooseStrategyExactMatch = "exact_match"
+	// ChooseStrategyMinimizeChange chooses uxouts with ChooseSpendsMinimizeChange.
+	// No extra input is added to recover change hours, see ChooseStrategyExactMatch.
+	ChooseStrategyMinimizeChange = "minimize_change"
 )
 
 var (
@@ -60,6 +77,8 @@ var (
 	ErrInvalidShareFactor = NewError(errors.New("HoursSelection.ShareFactor can only be used for share mode"))
 	// ErrShareFactorOutOfRange HoursSelection.ShareFactor must be >= 0 and <= 1
 	ErrShareFactorOutOfRange = NewError(errors.New("HoursSelection.ShareFactor must be >= 0 and <= 1"))
+	// ErrInvalidChooseStrategy Invalid ChooseStrategy
+	ErrInvalidChooseStrategy = NewError(errors.New("Invalid ChooseStrategy"))
 )
 
 // HoursSelection defines options for hours distribution
@@ -74,6 +93,9 @@ type Params struct {
 	HoursSelection HoursSelection
 	To             []coin.TransactionOutput
 	ChangeAddress  *cipher.Address
+	// ChooseStrategy is the strategy used to choose the uxouts to spend, one of the ChooseStrategy* values.
+	// Defaults to ChooseStrategyMinimizeUxOuts if empty.
+	ChooseStrategy string
 }
 
 // Validate validates Params
@@ -96,6 +118,10 @@ func (c Params) Validate() error {
 		}
 	}
 
+	if _, _, err := c.Totals(); err != nil {
+		return err
+	}
+
 	// Check for duplicate outputs, a transaction can't have outputs with
 	// the same (address, coins, hours)
 	// Auto mode would distribute hours to the outputs and could hypothetically
@@ -135,6 +161,17 @@ func (c Params) Validate() error {
 		return ErrInvalidHoursSelectionType
 	}
 
+	switch c.ChooseStrategy {
+	case "",
+		ChooseStrategyMinimizeUxOuts,
+		ChooseStrategyMaximizeUxOuts,
+		ChooseStrategyAvoidAddressMerge,
+		ChooseStrategyExactMatch,
+		ChooseStrategyMinimizeChange:
+	default:
+		return ErrInvalidChooseStrategy
+	}
+
 	if c.HoursSelection.ShareFactor == nil {
 		if c.HoursSelection.Mode == HoursSelectionModeShare {
 			return ErrMissingShareFactor
@@ -153,3 +190,23 @@ func (c Params) Validate() error {
 
 	return nil
 }
+
+// Totals returns the total coins and hours sent to the receivers
+func (c Params) Totals() (amount.Amount, amount.Hours, error) {
+	var coins amount.Amount
+	var hours amount.Hours
+	for _, to := range c.To {
+		var err error
+		coins, err = coins.Add(amount.Amount(to.Coins))
+		if err != nil {
+			return 0, 0, NewError(fmt.Errorf("total output coins error: %v", err))
+		}
+
+		hours, err = hours.Add(amount.Hours(to.Hours))
+		if err != nil {
+			return 0, 0, NewError(fmt.Errorf("total output hours error: %v", err))
+		}
+	}
+
+	return coins, hours, nil
+}
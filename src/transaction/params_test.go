@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"errors"
+	"math"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -93,6 +94,44 @@ func TestCreateWalletParamsVerify(t *testing.T) {
 			err: "To.Address must not be the null address",
 		},
 
+		{
+			name: "overflowing to coins",
+			params: Params{
+				ChangeAddress: &changeAddress,
+				To: []coin.TransactionOutput{
+					{
+						Address: testutil.MakeAddress(),
+						Coins:   math.MaxUint64,
+					},
+					{
+						Address: testutil.MakeAddress(),
+						Coins:   1,
+					},
+				},
+			},
+			err: "total output coins error: uint64 addition overflow",
+		},
+
+		{
+			name: "overflowing to hours",
+			params: Params{
+				ChangeAddress: &changeAddress,
+				To: []coin.TransactionOutput{
+					{
+						Address: testutil.MakeAddress(),
+						Coins:   1,
+						Hours:   math.MaxUint64,
+					},
+					{
+						Address: testutil.MakeAddress(),
+						Coins:   1,
+						Hours:   1,
+					},
+				},
+			},
+			err: "total output hours error: uint64 addition overflow",
+		},
+
 		{
 			name: "nonzero to hours for auto selection",
 			params: Params{
@@ -270,6 +309,31 @@ func TestCreateWalletParamsVerify(t *testing.T) {
 				},
 			},
 		},
+
+		{
+			name: "invalid choose strategy",
+			params: Params{
+				ChangeAddress: &changeAddress,
+				To:            toManual,
+				HoursSelection: HoursSelection{
+					Type: HoursSelectionTypeManual,
+				},
+				ChooseStrategy: "foo",
+			},
+			err: "Invalid ChooseStrategy",
+		},
+
+		{
+			name: "valid choose strategy",
+			params: Params{
+				ChangeAddress: &changeAddress,
+				To:            toManual,
+				HoursSelection: HoursSelection{
+					Type: HoursSelectionTypeManual,
+				},
+				ChooseStrategy: ChooseStrategyMinimizeChange,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,219 @@
+/*
+Package amount provides typed coin and coin hour amounts.
+
+An Amount is a number of droplets and Hours is a number of coin hours. Both are uint64 values
+with checked arithmetic, so that the overflow errors of summing balances and outputs and the
+conversion and precision errors of decimal coin strings are handled in one place.
+*/
+package amount
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/util/droplet"
+	"github.com/skycoin/skycoin/src/util/mathutil"
+)
+
+var (
+	// ErrSubUnderflow is returned if subtracting a larger value from a smaller value
+	ErrSubUnderflow = errors.New("uint64 subtraction underflow")
+	// ErrInvalidPrecision is returned if a precision is greater than droplet.Exponent
+	ErrInvalidPrecision = fmt.Errorf("precision must be <= %d", droplet.Exponent)
+)
+
+// Amount is an amount of coins, in droplets
+type Amount uint64
+
+// FromCoins converts a number of whole coins to an Amount
+func FromCoins(coins uint64) (Amount, error) {
+	n, err := mathutil.MultUint64(coins, droplet.Multiplier)
+	if err != nil {
+		return 0, err
+	}
+	return Amount(n), nil
+}
+
+// ParseAmount parses a decimal coin string with up to droplet.Exponent decimal places.
+// For example, "123.000456" is 123000456 droplets.
+func ParseAmount(s string) (Amount, error) {
+	n, err := droplet.FromString(s)
+	if err != nil {
+		return 0, err
+	}
+	return Amount(n), nil
+}
+
+// Value returns the number of droplets
+func (a Amount) Value() uint64 {
+	return uint64(a)
+}
+
+// Add adds b to a, returning an error if the sum overflows
+func (a Amount) Add(b Amount) (Amount, error) {
+	c, err := mathutil.AddUint64(uint64(a), uint64(b))
+	if err != nil {
+		return 0, err
+	}
+	return Amount(c), nil
+}
+
+// Sub subtracts b from a, returning an error if b is greater than a
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b > a {
+		return 0, ErrSubUnderflow
+	}
+	return a - b, nil
+}
+
+// Mul multiplies a by n, returning an error if the product overflows
+func (a Amount) Mul(n uint64) (Amount, error) {
+	c, err := mathutil.MultUint64(uint64(a), n)
+	if err != nil {
+		return 0, err
+	}
+	return Amount(c), nil
+}
+
+// CheckPrecision returns params.ErrInvalidDecimals if the amount has more than precision decimal places
+func (a Amount) CheckPrecision(precision uint8) error {
+	if precision > droplet.Exponent {
+		return ErrInvalidPrecision
+	}
+	return params.DropletPrecisionCheck(precision, uint64(a))
+}
+
+// Format formats the amount as a fixed-point decimal string with precision decimal places.
+// Returns params.ErrInvalidDecimals if the amount has more decimal places than the precision,
+// and droplet.ErrTooLarge if the amount is greater than math.MaxInt64, which ParseAmount rejects.
+func (a Amount) Format(precision uint8) (string, error) {
+	if err := a.CheckPrecision(precision); err != nil {
+		return "", err
+	}
+
+	if uint64(a) > math.MaxInt64 {
+		return "", droplet.ErrTooLarge
+	}
+
+	return a.format(precision), nil
+}
+
+// ToString formats the amount as a fixed-point decimal string with droplet.Exponent decimal places.
+// Returns droplet.ErrTooLarge if the amount is greater than math.MaxInt64.
+func (a Amount) ToString() (string, error) {
+	return a.Format(droplet.Exponent)
+}
+
+// String formats the amount as a fixed-point decimal string with droplet.Exponent decimal places,
+// for example 123000456 is "123.000456"
+func (a Amount) String() string {
+	return a.format(droplet.Exponent)
+}
+
+func (a Amount) format(precision uint8) string {
+	s := strconv.FormatUint(uint64(a)/droplet.Multiplier, 10)
+	if precision == 0 {
+		return s
+	}
+
+	frac := strconv.FormatUint((uint64(a)%droplet.Multiplier)/params.DropletPrecisionToDivisor(precision), 10)
+	return s + "." + strings.Repeat("0", int(precision)-len(frac)) + frac
+}
+
+// Decimal returns the amount as a number of coins
+func (a Amount) Decimal() decimal.Decimal {
+	return decimal.NewFromBigInt(new(big.Int).SetUint64(uint64(a)), -droplet.Exponent)
+}
+
+// MarshalJSON marshals the amount as a fixed-point decimal string
+func (a Amount) MarshalJSON() ([]byte, error) {
+	s, err := a.ToString()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(`"` + s + `"`), nil
+}
+
+// UnmarshalJSON unmarshals a decimal coin string
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	tmp, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+
+	*a = tmp
+
+	return nil
+}
+
+// Hours is an amount of coin hours
+type Hours uint64
+
+// ParseHours parses a base 10 integer coin hours string
+func ParseHours(s string) (Hours, error) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hours value: %v", err)
+	}
+	return Hours(n), nil
+}
+
+// Value returns the number of coin hours
+func (h Hours) Value() uint64 {
+	return uint64(h)
+}
+
+// Add adds b to h, returning an error if the sum overflows
+func (h Hours) Add(b Hours) (Hours, error) {
+	c, err := mathutil.AddUint64(uint64(h), uint64(b))
+	if err != nil {
+		return 0, err
+	}
+	return Hours(c), nil
+}
+
+// Sub subtracts b from h, returning an error if b is greater than h
+func (h Hours) Sub(b Hours) (Hours, error) {
+	if b > h {
+		return 0, ErrSubUnderflow
+	}
+	return h - b, nil
+}
+
+// Mul multiplies h by n, returning an error if the product overflows
+func (h Hours) Mul(n uint64) (Hours, error) {
+	c, err := mathutil.MultUint64(uint64(h), n)
+	if err != nil {
+		return 0, err
+	}
+	return Hours(c), nil
+}
+
+// Int64 converts the hours to an int64, returning an error if the value overflows int64
+func (h Hours) Int64() (int64, error) {
+	return mathutil.Uint64ToInt64(uint64(h))
+}
+
+// String formats the hours as a base 10 integer
+func (h Hours) String() string {
+	return strconv.FormatUint(uint64(h), 10)
+}
+
+// Decimal returns the hours as a decimal
+func (h Hours) Decimal() decimal.Decimal {
+	return decimal.NewFromBigInt(new(big.Int).SetUint64(uint64(h)), 0)
+}
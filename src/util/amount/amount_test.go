@@ -0,0 +1,189 @@
+package amount
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/util/droplet"
+	"github.com/skycoin/skycoin/src/util/mathutil"
+)
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		s   string
+		a   Amount
+		err error
+	}{
+		{s: "0", a: 0},
+		{s: "1", a: 1e6},
+		{s: "0.000001", a: 1},
+		{s: "123.000456", a: 123000456},
+		{s: "9223372036854.775807", a: math.MaxInt64},
+		{s: "9223372036854.775808", err: droplet.ErrTooLarge},
+		{s: "0.0000001", err: droplet.ErrTooManyDecimals},
+		{s: "-1", err: droplet.ErrNegativeValue},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.s, func(t *testing.T) {
+			a, err := ParseAmount(tc.s)
+			if tc.err != nil {
+				require.Equal(t, tc.err, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.a, a)
+		})
+	}
+
+	_, err := ParseAmount("foo")
+	require.Error(t, err)
+}
+
+func TestFromCoins(t *testing.T) {
+	a, err := FromCoins(100)
+	require.NoError(t, err)
+	require.Equal(t, Amount(100e6), a)
+
+	_, err = FromCoins(math.MaxUint64 / 100000)
+	require.Equal(t, mathutil.ErrUint64MultOverflow, err)
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a, err := Amount(10).Add(5)
+	require.NoError(t, err)
+	require.Equal(t, Amount(15), a)
+
+	_, err = Amount(math.MaxUint64).Add(1)
+	require.Equal(t, mathutil.ErrUint64AddOverflow, err)
+
+	a, err = Amount(10).Sub(4)
+	require.NoError(t, err)
+	require.Equal(t, Amount(6), a)
+
+	_, err = Amount(4).Sub(10)
+	require.Equal(t, ErrSubUnderflow, err)
+
+	a, err = Amount(10).Mul(3)
+	require.NoError(t, err)
+	require.Equal(t, Amount(30), a)
+
+	_, err = Amount(math.MaxUint64).Mul(2)
+	require.Equal(t, mathutil.ErrUint64MultOverflow, err)
+}
+
+func TestAmountFormat(t *testing.T) {
+	cases := []struct {
+		a         Amount
+		precision uint8
+		s         string
+		err       error
+	}{
+		{a: 0, precision: 0, s: "0"},
+		{a: 0, precision: 6, s: "0.000000"},
+		{a: 123000456, precision: 6, s: "123.000456"},
+		{a: 123000450, precision: 5, s: "123.00045"},
+		{a: 123100000, precision: 1, s: "123.1"},
+		{a: 123000000, precision: 0, s: "123"},
+		{a: 123000456, precision: 3, err: params.ErrInvalidDecimals},
+		{a: 123000456, precision: 7, err: ErrInvalidPrecision},
+		{a: math.MaxInt64, precision: 6, s: "9223372036854.775807"},
+		{a: math.MaxInt64 + 1, precision: 6, err: droplet.ErrTooLarge},
+	}
+
+	for _, tc := range cases {
+		s, err := tc.a.Format(tc.precision)
+		if tc.err != nil {
+			require.Equal(t, tc.err, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, tc.s, s)
+
+		// Formatted amounts parse back to the same value
+		a, err := ParseAmount(s)
+		require.NoError(t, err)
+		require.Equal(t, tc.a, a)
+
+		// Formatting with the full precision matches droplet.ToString
+		if tc.precision == droplet.Exponent {
+			expected, err := droplet.ToString(tc.a.Value())
+			require.NoError(t, err)
+			require.Equal(t, expected, s)
+			require.Equal(t, expected, tc.a.String())
+		}
+	}
+
+	require.NoError(t, Amount(1e6).CheckPrecision(0))
+	require.Equal(t, params.ErrInvalidDecimals, Amount(1e5).CheckPrecision(0))
+	require.Equal(t, ErrInvalidPrecision, Amount(1e6).CheckPrecision(7))
+
+	require.Equal(t, "18446744073709.551615", Amount(math.MaxUint64).String())
+	require.True(t, decimal.RequireFromString("18446744073709.551615").Equal(Amount(math.MaxUint64).Decimal()))
+}
+
+func TestAmountJSON(t *testing.T) {
+	b, err := json.Marshal(Amount(1000001))
+	require.NoError(t, err)
+	require.Equal(t, `"1.000001"`, string(b))
+
+	_, err = json.Marshal(Amount(math.MaxUint64))
+	require.Error(t, err)
+
+	var a Amount
+	require.NoError(t, json.Unmarshal([]byte(`"1.000001"`), &a))
+	require.Equal(t, Amount(1000001), a)
+
+	err = json.Unmarshal([]byte(`"1.0000001"`), &a)
+	require.Equal(t, droplet.ErrTooManyDecimals, err)
+
+	err = json.Unmarshal([]byte(`1`), &a)
+	require.Error(t, err)
+}
+
+func TestHours(t *testing.T) {
+	h, err := ParseHours("123")
+	require.NoError(t, err)
+	require.Equal(t, Hours(123), h)
+	require.Equal(t, "123", h.String())
+
+	_, err = ParseHours("1.5")
+	require.EqualError(t, err, `invalid hours value: strconv.ParseUint: parsing "1.5": invalid syntax`)
+
+	h, err = Hours(10).Add(5)
+	require.NoError(t, err)
+	require.Equal(t, Hours(15), h)
+
+	_, err = Hours(math.MaxUint64).Add(1)
+	require.Equal(t, mathutil.ErrUint64AddOverflow, err)
+
+	h, err = Hours(10).Sub(4)
+	require.NoError(t, err)
+	require.Equal(t, Hours(6), h)
+
+	_, err = Hours(4).Sub(10)
+	require.Equal(t, ErrSubUnderflow, err)
+
+	h, err = Hours(10).Mul(3)
+	require.NoError(t, err)
+	require.Equal(t, Hours(30), h)
+
+	_, err = Hours(math.MaxUint64).Mul(2)
+	require.Equal(t, mathutil.ErrUint64MultOverflow, err)
+
+	n, err := Hours(math.MaxInt64).Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MaxInt64), n)
+
+	_, err = Hours(math.MaxInt64 + 1).Int64()
+	require.Equal(t, mathutil.ErrUint64OverflowsInt64, err)
+
+	require.True(t, decimal.New(123, 0).Equal(Hours(123).Decimal()))
+}
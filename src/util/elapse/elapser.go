@@ -16,6 +16,7 @@ type Elapser struct {
 	elapsedThreshold time.Duration
 	Done             chan bool
 	logger           *logging.Logger
+	observer         func(name string, elapsed time.Duration)
 }
 
 // NewElapser creates an Elapser
@@ -28,6 +29,12 @@ func NewElapser(elapsedThreshold time.Duration, logger *logging.Logger) *Elapser
 	return elapser
 }
 
+// SetObserver sets a function called with the elapsed time of every measured operation,
+// for example to record it in a metric
+func (e *Elapser) SetObserver(f func(name string, elapsed time.Duration)) {
+	e.observer = f
+}
+
 // CheckForDone checks if the elapser has triggered and records the elapsed time
 func (e *Elapser) CheckForDone() {
 	select {
@@ -65,6 +72,9 @@ func (e *Elapser) Elapsed() {
 		return
 	}
 	elapsed := stopTime.Sub(e.startTime)
+	if e.observer != nil {
+		e.observer(*e.name, elapsed)
+	}
 	if elapsed >= e.elapsedThreshold {
 		e.logger.Warningf("%s elapsed %s", *e.name, elapsed)
 	}
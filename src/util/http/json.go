@@ -6,11 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/skycoin/skycoin/src/cipher"
-	"github.com/skycoin/skycoin/src/util/droplet"
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/util/amount"
 	"github.com/skycoin/skycoin/src/util/logging"
 )
 
@@ -71,14 +71,14 @@ type Address struct {
 	cipher.Address
 }
 
-// UnmarshalJSON unmarshals a string address to a cipher.Address
+// UnmarshalJSON unmarshals a base58 or bech32 string address to a cipher.Address
 func (a *Address) UnmarshalJSON(b []byte) error {
 	var s string
 	if err := json.Unmarshal(b, &s); err != nil {
 		return err
 	}
 
-	tmp, err := cipher.DecodeBase58Address(s)
+	tmp, err := cipher.DecodeAddress(params.AddressHRP, s)
 	if err != nil {
 		return fmt.Errorf("invalid address: %v", err)
 	}
@@ -121,41 +121,8 @@ func (a SHA256) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + a.SHA256.Hex() + `"`), nil
 }
 
-// Coins is a wrapper around uint64 which implements json.Unmarshaler and json.Marshaler.
-// It unmarshals a fixed-point decimal string to droplets and vice versa
-type Coins uint64
-
-// UnmarshalJSON unmarshals a fixed-point decimal string to droplets
-func (c *Coins) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-
-	tmp, err := droplet.FromString(s)
-	if err != nil {
-		return err
-	}
-
-	*c = Coins(tmp)
-
-	return nil
-}
-
-// MarshalJSON marshals droplets to a fixed-point decimal string
-func (c Coins) MarshalJSON() ([]byte, error) {
-	s, err := droplet.ToString(uint64(c))
-	if err != nil {
-		return nil, err
-	}
-
-	return []byte(`"` + s + `"`), nil
-}
-
-// Value returns the underlying uint64 value
-func (c Coins) Value() uint64 {
-	return uint64(c)
-}
+// Coins is an amount.Amount, which unmarshals a fixed-point decimal string to droplets and vice versa
+type Coins = amount.Amount
 
 // Hours is a wrapper around uint64 which implements json.Unmarshaler and json.Marshaler.
 // It unmarshals a fixed-point decimal string to droplets and vice versa
@@ -168,9 +135,9 @@ func (h *Hours) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	tmp, err := strconv.ParseUint(s, 10, 64)
+	tmp, err := amount.ParseHours(s)
 	if err != nil {
-		return fmt.Errorf("invalid hours value: %v", err)
+		return err
 	}
 
 	*h = Hours(tmp)
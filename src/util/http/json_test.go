@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/testutil"
 )
 
@@ -98,10 +99,19 @@ func TestAddressUnmarshalJSON(t *testing.T) {
 			addr: "2blYafFtdkCRNcCyuDvsATV66GvBR9xfvjy",
 			err:  "invalid address: Invalid base58 character",
 		},
+		{
+			name: "invalid bech32 address",
+			addr: "sky1que92eazhyply60hq3ayf669af326n0exaleurq",
+			err:  "invalid address: Invalid bech32 checksum",
+		},
 		{
 			name: "valid address",
 			addr: "2bfYafFtdkCRNcCyuDvsATV66GvBR9xfvjy",
 		},
+		{
+			name: "valid bech32 address",
+			addr: "sky1que92eazhyply60hq3ayf669af326n0exaleur4",
+		},
 	}
 
 	for _, tc := range cases {
@@ -112,7 +122,7 @@ func TestAddressUnmarshalJSON(t *testing.T) {
 				require.Equal(t, errors.New(tc.err), err)
 			} else {
 				require.NoError(t, err)
-				addr, err := cipher.DecodeBase58Address(tc.addr)
+				addr, err := cipher.DecodeAddress(params.AddressHRP, tc.addr)
 				require.NoError(t, err)
 
 				require.Equal(t, addr, a.Address)
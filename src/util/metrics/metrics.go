@@ -0,0 +1,400 @@
+/*
+Package metrics provides counters, gauges and histograms exposed in the Prometheus text format
+*/
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ContentType is the content type of the Prometheus text format
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+var (
+	// DefaultRegistry is the registry of the metrics created with NewCounterVec and NewHistogramVec
+	DefaultRegistry = NewRegistry()
+
+	// DefaultBuckets are the default histogram buckets, in seconds, suited to measure durations
+	DefaultBuckets = []float64{0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+)
+
+// Collector is a metric which can be written in the Prometheus text format
+type Collector interface {
+	// Name returns the name of the metric
+	Name() string
+	// WriteTo writes the metric in the Prometheus text format
+	WriteTo(w *bufio.Writer)
+}
+
+// Registry is a set of metrics
+type Registry struct {
+	sync.Mutex
+	collectors map[string]Collector
+}
+
+// NewRegistry creates a Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		collectors: make(map[string]Collector),
+	}
+}
+
+// MustRegister adds a metric to the registry. Panics if a metric with the same name is already registered
+func (r *Registry) MustRegister(c Collector) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.collectors[c.Name()]; ok {
+		panic(fmt.Sprintf("metric %q is already registered", c.Name()))
+	}
+	r.collectors[c.Name()] = c
+}
+
+// WriteText writes the metrics of the registry in the Prometheus text format, sorted by name
+func (r *Registry) WriteText(w io.Writer) error {
+	r.Lock()
+	names := make([]string, 0, len(r.collectors))
+	for k := range r.collectors {
+		names = append(names, k)
+	}
+	collectors := make([]Collector, 0, len(names))
+	sort.Strings(names)
+	for _, k := range names {
+		collectors = append(collectors, r.collectors[k])
+	}
+	r.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.WriteTo(bw)
+	}
+	return bw.Flush()
+}
+
+// desc describes a metric with labels
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (d desc) checkLabelValues(labelValues []string) {
+	if len(labelValues) != len(d.labels) {
+		panic(fmt.Sprintf("metric %q has %d labels, got %d label values", d.name, len(d.labels), len(labelValues)))
+	}
+}
+
+func (d desc) writeHeader(w *bufio.Writer, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, typ)
+}
+
+// series is the value of a metric for a set of label values
+type series struct {
+	labelValues []string
+	value       float64
+}
+
+// vec is a metric with a value per set of label values
+type vec struct {
+	desc
+	sync.Mutex
+	series map[string]*series
+}
+
+func newVec(name, help string, labels []string) vec {
+	return vec{
+		desc: desc{
+			name:   name,
+			help:   help,
+			labels: labels,
+		},
+		series: make(map[string]*series),
+	}
+}
+
+// Name returns the name of the metric
+func (v *vec) Name() string {
+	return v.name
+}
+
+func (v *vec) get(labelValues []string) *series {
+	v.checkLabelValues(labelValues)
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := v.series[key]
+	if !ok {
+		s = &series{
+			labelValues: append([]string(nil), labelValues...),
+		}
+		v.series[key] = s
+	}
+	return s
+}
+
+func (v *vec) add(delta float64, labelValues []string) {
+	v.Lock()
+	defer v.Unlock()
+	v.get(labelValues).value += delta
+}
+
+func (v *vec) set(value float64, labelValues []string) {
+	v.Lock()
+	defer v.Unlock()
+	v.get(labelValues).value = value
+}
+
+func (v *vec) writeTo(w *bufio.Writer, typ string) {
+	v.Lock()
+	defer v.Unlock()
+
+	v.writeHeader(w, typ)
+	for _, s := range sortedSeries(v.series) {
+		fmt.Fprintf(w, "%s%s %s\n", v.name, formatLabels(v.labels, s.labelValues, "", ""), formatValue(s.value))
+	}
+}
+
+// CounterVec is a counter with a value per set of label values
+type CounterVec struct {
+	vec
+}
+
+// NewCounterVec creates a CounterVec and registers it in DefaultRegistry
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		vec: newVec(name, help, labels),
+	}
+	DefaultRegistry.MustRegister(c)
+	return c
+}
+
+// Inc increments the counter of the label values by 1
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.add(1, labelValues)
+}
+
+// Add increments the counter of the label values by delta, which must not be negative
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("counter %q cannot decrease", c.name))
+	}
+	c.add(delta, labelValues)
+}
+
+// Value returns the counter of the label values
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.get(labelValues).value
+}
+
+// WriteTo writes the metric in the Prometheus text format
+func (c *CounterVec) WriteTo(w *bufio.Writer) {
+	c.writeTo(w, "counter")
+}
+
+// GaugeVec is a gauge with a value per set of label values.
+// Gauges are not registered in DefaultRegistry, they are meant to be set when metrics are collected.
+type GaugeVec struct {
+	vec
+}
+
+// NewGaugeVec creates a GaugeVec
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{
+		vec: newVec(name, help, labels),
+	}
+}
+
+// Set sets the gauge of the label values
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.set(value, labelValues)
+}
+
+// Add adds delta to the gauge of the label values
+func (g *GaugeVec) Add(delta float64, labelValues ...string) {
+	g.add(delta, labelValues)
+}
+
+// WriteTo writes the metric in the Prometheus text format
+func (g *GaugeVec) WriteTo(w *bufio.Writer) {
+	g.writeTo(w, "gauge")
+}
+
+// histogramSeries is the value of a histogram for a set of label values
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// HistogramVec is a histogram with a value per set of label values
+type HistogramVec struct {
+	desc
+	sync.Mutex
+	buckets []float64
+	series  map[string]*histogramSeries
+}
+
+// NewHistogramVec creates a HistogramVec and registers it in DefaultRegistry.
+// If buckets is nil, DefaultBuckets are used.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	h := &HistogramVec{
+		desc: desc{
+			name:   name,
+			help:   help,
+			labels: labels,
+		},
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}
+	DefaultRegistry.MustRegister(h)
+	return h
+}
+
+// Name returns the name of the metric
+func (h *HistogramVec) Name() string {
+	return h.name
+}
+
+// Observe adds an observation to the histogram of the label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.checkLabelValues(labelValues)
+
+	h.Lock()
+	defer h.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+
+	for i, b := range h.buckets {
+		if value <= b {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+// ObserveDuration adds a duration observation, in seconds, to the histogram of the label values
+func (h *HistogramVec) ObserveDuration(d time.Duration, labelValues ...string) {
+	h.Observe(d.Seconds(), labelValues...)
+}
+
+// Count returns the number of observations of the label values
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.Lock()
+	defer h.Unlock()
+
+	s, ok := h.series[strings.Join(labelValues, "\xff")]
+	if !ok {
+		return 0
+	}
+	return s.count
+}
+
+// WriteTo writes the metric in the Prometheus text format
+func (h *HistogramVec) WriteTo(w *bufio.Writer) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.writeHeader(w, "histogram")
+
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := h.series[k]
+		for i, b := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labels, s.labelValues, "le", formatValue(b)), s.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labels, s.labelValues, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labels, s.labelValues, "", ""), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labels, s.labelValues, "", ""), s.count)
+	}
+}
+
+func sortedSeries(m map[string]*series) []*series {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	s := make([]*series, len(keys))
+	for i, k := range keys {
+		s[i] = m[k]
+	}
+	return s
+}
+
+// formatLabels formats label pairs, with an optional extra label such as the "le" label of histogram buckets
+func formatLabels(labels, labelValues []string, extraLabel, extraValue string) string {
+	if len(labels) == 0 && extraLabel == "" {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels)+1)
+	for i, l := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", l, escapeLabelValue(labelValues[i])))
+	}
+	if extraLabel != "" {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extraLabel, extraValue))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryWriteText(t *testing.T) {
+	r := NewRegistry()
+
+	c := &CounterVec{
+		vec: newVec("test_messages_total", "Messages\nreceived", []string{"type"}),
+	}
+	r.MustRegister(c)
+
+	g := NewGaugeVec("test_height", "Height")
+	r.MustRegister(g)
+
+	h := &HistogramVec{
+		desc: desc{
+			name:   "test_duration_seconds",
+			help:   "Duration",
+			labels: []string{"name"},
+		},
+		buckets: []float64{0.1, 1},
+		series:  make(map[string]*histogramSeries),
+	}
+	r.MustRegister(h)
+
+	require.Panics(t, func() {
+		r.MustRegister(g)
+	})
+
+	c.Inc("b")
+	c.Add(2, "a\"")
+	c.Inc("b")
+	require.Equal(t, float64(2), c.Value("b"))
+	require.Panics(t, func() {
+		c.Add(-1, "a")
+	})
+	require.Panics(t, func() {
+		c.Inc("a", "b")
+	})
+
+	g.Set(10)
+	g.Add(-2)
+
+	h.Observe(0.05, "x")
+	h.ObserveDuration(500*time.Millisecond, "x")
+	h.Observe(2, "x")
+	require.Equal(t, uint64(3), h.Count("x"))
+	require.Equal(t, uint64(0), h.Count("y"))
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteText(&buf))
+
+	require.Equal(t, `# HELP test_duration_seconds Duration
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{name="x",le="0.1"} 1
+test_duration_seconds_bucket{name="x",le="1"} 2
+test_duration_seconds_bucket{name="x",le="+Inf"} 3
+test_duration_seconds_sum{name="x"} 2.55
+test_duration_seconds_count{name="x"} 3
+# HELP test_height Height
+# TYPE test_height gauge
+test_height 8
+# HELP test_messages_total Messages\nreceived
+# TYPE test_messages_total counter
+test_messages_total{type="a\""} 2
+test_messages_total{type="b"} 2
+`, buf.String())
+}
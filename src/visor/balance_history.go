@@ -0,0 +1,117 @@
+package visor
+
+import (
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+	"github.com/skycoin/skycoin/src/visor/historydb"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+// GetBalancesAt returns the balances of addresses at a block seq, and the block.
+// The coin hours are the coin hours of the unspent outputs at the time of the block.
+// Returns ErrBlockNotExist if the block does not exist.
+func (vs *Visor) GetBalancesAt(addrs []cipher.Address, seq uint64) ([]wallet.Balance, *coin.SignedBlock, error) {
+	var balances []wallet.Balance
+	var b *coin.SignedBlock
+
+	if err := vs.db.View("GetBalancesAt", func(tx *dbutil.Tx) error {
+		var err error
+		b, err = vs.getBlockAt(tx, seq)
+		if err != nil {
+			return err
+		}
+
+		balances = make([]wallet.Balance, len(addrs))
+		for i, addr := range addrs {
+			outs, err := vs.history.GetOutputsForAddress(tx, addr)
+			if err != nil {
+				return err
+			}
+
+			for _, o := range outs {
+				if !isUnspentAt(o, seq) {
+					continue
+				}
+
+				bal, err := wallet.NewBalanceFromUxOut(b.Time(), &o.Out)
+				if err != nil {
+					return err
+				}
+
+				balances[i], err = balances[i].Add(bal)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return balances, b, nil
+}
+
+// GetRichlistAt returns the Richlist at a block seq, and the block.
+// The balances at the head block are read from the unspent pool, the balances at previous blocks
+// are rebuilt from the latest balance snapshot at or before the block.
+// Returns ErrBlockNotExist if the block does not exist.
+func (vs *Visor) GetRichlistAt(seq uint64, includeDistribution bool) (Richlist, *coin.SignedBlock, error) {
+	var allAccounts map[cipher.Address]uint64
+	var b *coin.SignedBlock
+
+	if err := vs.db.View("GetRichlistAt", func(tx *dbutil.Tx) error {
+		var err error
+		b, err = vs.getBlockAt(tx, seq)
+		if err != nil {
+			return err
+		}
+
+		headSeq, ok, err := vs.blockchain.HeadSeq(tx)
+		if err != nil {
+			return err
+		}
+
+		if ok && seq == headSeq {
+			allAccounts, err = vs.getUnspentAddressCoins(tx)
+			return err
+		}
+
+		allAccounts, err = vs.history.GetAddressBalancesAt(tx, seq)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	richlist, err := vs.newRichlist(allAccounts, includeDistribution)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return richlist, b, nil
+}
+
+func (vs *Visor) getBlockAt(tx *dbutil.Tx, seq uint64) (*coin.SignedBlock, error) {
+	b, err := vs.blockchain.GetSignedBlockBySeq(tx, seq)
+	if err != nil {
+		return nil, err
+	}
+
+	if b == nil {
+		return nil, NewErrBlockNotExist(seq)
+	}
+
+	return b, nil
+}
+
+// isUnspentAt returns true if the output was created at or before the block seq and was not spent by then
+func isUnspentAt(o historydb.UxOut, seq uint64) bool {
+	if o.Out.Head.BkSeq > seq {
+		return false
+	}
+
+	// Outputs can't be spent in the genesis block, so a SpentBlockSeq of 0 means unspent
+	return o.SpentBlockSeq == 0 || o.SpentBlockSeq > seq
+}
@@ -0,0 +1,95 @@
+package visor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+	"github.com/skycoin/skycoin/src/wallet"
+)
+
+func TestGetBalancesAt(t *testing.T) {
+	toAddr := testutil.MakeAddress()
+	v, shutdown := makeCursorTestVisor(t, toAddr)
+	defer shutdown()
+
+	genesis, err := v.GetSignedBlockBySeq(0)
+	require.NoError(t, err)
+	genCoins := genesis.Body.Transactions[0].Out[0].Coins
+
+	// Blocks 2, 3 and 4 each send 3e6 coins to toAddr
+	for seq := uint64(0); seq <= 4; seq++ {
+		var coins uint64
+		if seq >= 2 {
+			coins = (seq - 1) * 3e6
+		}
+
+		balances, b, err := v.GetBalancesAt([]cipher.Address{toAddr, genAddress, testutil.MakeAddress()}, seq)
+		require.NoError(t, err)
+		require.Equal(t, seq, b.Seq())
+		require.Len(t, balances, 3)
+		require.Equal(t, coins, balances[0].Coins.Value())
+		require.Equal(t, genCoins-coins, balances[1].Coins.Value())
+		require.Equal(t, wallet.Balance{}, balances[2])
+	}
+
+	_, _, err = v.GetBalancesAt([]cipher.Address{toAddr}, 5)
+	require.Equal(t, NewErrBlockNotExist(5), err)
+}
+
+func TestGetRichlistAt(t *testing.T) {
+	toAddr := testutil.MakeAddress()
+	v, shutdown := makeCursorTestVisor(t, toAddr)
+	defer shutdown()
+
+	genesis, err := v.GetSignedBlockBySeq(0)
+	require.NoError(t, err)
+	genCoins := genesis.Body.Transactions[0].Out[0].Coins
+
+	richlist, b, err := v.GetRichlistAt(0, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), b.Seq())
+	require.Equal(t, Richlist{
+		{
+			Address: genAddress,
+			Coins:   genCoins,
+		},
+	}, richlist)
+
+	richlist, _, err = v.GetRichlistAt(3, true)
+	require.NoError(t, err)
+	require.Equal(t, Richlist{
+		{
+			Address: genAddress,
+			Coins:   genCoins - 6e6,
+		},
+		{
+			Address: toAddr,
+			Coins:   6e6,
+		},
+	}, richlist)
+
+	// The richlist at the head block is the current richlist, read from the unspent pool,
+	// and matches the balances rebuilt from the history
+	richlist, _, err = v.GetRichlistAt(4, true)
+	require.NoError(t, err)
+	current, err := v.GetRichlist(true)
+	require.NoError(t, err)
+	require.Equal(t, current, richlist)
+
+	err = v.db.View("", func(tx *dbutil.Tx) error {
+		allAccounts, err := v.history.GetAddressBalancesAt(tx, 4)
+		require.NoError(t, err)
+		rebuilt, err := v.newRichlist(allAccounts, true)
+		require.NoError(t, err)
+		require.Equal(t, rebuilt, richlist)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, _, err = v.GetRichlistAt(5, true)
+	require.Equal(t, NewErrBlockNotExist(5), err)
+}
@@ -74,6 +74,9 @@ type chainStore interface {
 	GetGenesisBlock(*dbutil.Tx) (*coin.SignedBlock, error)
 	GetBlockSignature(*dbutil.Tx, *coin.Block) (cipher.Sig, bool, error)
 	ForEachBlock(*dbutil.Tx, func(*coin.Block) error) error
+	GetBlockViewBySeq(*dbutil.Tx, uint64) (coin.BlockView, bool, error)
+	GetBlockViewSignature(*dbutil.Tx, coin.BlockView) (cipher.Sig, bool, error)
+	ForEachBlockView(*dbutil.Tx, func(coin.BlockView) error) error
 }
 
 // DefaultWalker default blockchain walker
@@ -109,13 +112,21 @@ type BlockchainConfig struct {
 	// node will throw the error and return.
 	Arbitrating bool
 	Pubkey      cipher.PubKey
+	// Number of workers verifying the input signatures of a block or transaction concurrently.
+	// If 0, runtime.NumCPU() workers are used
+	SigVerifyWorkers int
+	// Maximum number of valid input signatures cached, so that signatures checked when a transaction
+	// was added to the unconfirmed pool are not checked again when the transaction is included in a block.
+	// If 0, signatures are not cached
+	SigCacheSize int
 }
 
 // Blockchain maintains blockchain and provides apis for accessing the chain.
 type Blockchain struct {
-	db    *dbutil.DB
-	cfg   BlockchainConfig
-	store chainStore
+	db          *dbutil.DB
+	cfg         BlockchainConfig
+	store       chainStore
+	sigVerifier *cipher.BatchVerifier
 }
 
 // NewBlockchain creates a Blockchain
@@ -125,10 +136,16 @@ func NewBlockchain(db *dbutil.DB, cfg BlockchainConfig) (*Blockchain, error) {
 		return nil, err
 	}
 
+	var sigCache *cipher.SigCache
+	if cfg.SigCacheSize > 0 {
+		sigCache = cipher.NewSigCache(cfg.SigCacheSize)
+	}
+
 	return &Blockchain{
-		cfg:   cfg,
-		db:    db,
-		store: chainstore,
+		cfg:         cfg,
+		db:          db,
+		store:       chainstore,
+		sigVerifier: cipher.NewBatchVerifier(cfg.SigVerifyWorkers, sigCache),
 	}, nil
 }
 
@@ -147,6 +164,12 @@ func (bc *Blockchain) GetSignedBlockBySeq(tx *dbutil.Tx, seq uint64) (*coin.Sign
 	return bc.store.GetSignedBlockBySeq(tx, seq)
 }
 
+// GetBlockViewBySeq returns a view of the block of given seq, returns false on not found.
+// The view is only valid for the life of the db transaction.
+func (bc *Blockchain) GetBlockViewBySeq(tx *dbutil.Tx, seq uint64) (coin.BlockView, bool, error) {
+	return bc.store.GetBlockViewBySeq(tx, seq)
+}
+
 // Head returns the most recent confirmed block
 func (bc Blockchain) Head(tx *dbutil.Tx) (*coin.SignedBlock, error) {
 	return bc.store.Head(tx)
@@ -353,7 +376,7 @@ func (bc Blockchain) VerifyBlockTxnConstraints(tx *dbutil.Tx, txn coin.Transacti
 }
 
 func (bc Blockchain) verifyBlockTxnHardConstraints(tx *dbutil.Tx, txn coin.Transaction, head *coin.SignedBlock, uxIn coin.UxArray) error {
-	if err := VerifyBlockTxnConstraints(txn, head.Head, uxIn); err != nil {
+	if err := verifyBlockTxnConstraintsBatch(txn, head.Head, uxIn, bc.sigVerifier); err != nil {
 		return err
 	}
 
@@ -378,6 +401,39 @@ func (bc Blockchain) verifyBlockTxnHardConstraints(tx *dbutil.Tx, txn coin.Trans
 	return nil
 }
 
+// verifyTxnSignaturesBatch verifies the input signatures of all the transactions concurrently,
+// which adds the valid signatures to the signature cache before the transactions are verified one by one.
+// Invalid signatures and transactions are reported by the verification of each transaction.
+func (bc Blockchain) verifyTxnSignaturesBatch(tx *dbutil.Tx, txns coin.Transactions) error {
+	if bc.sigVerifier.Cache() == nil {
+		return nil
+	}
+
+	var sigs []cipher.SignedHash
+	for _, txn := range txns {
+		uxIn, err := bc.Unspent().GetArray(tx, txn.In)
+		if err != nil {
+			switch err.(type) {
+			case blockdb.ErrUnspentNotExist:
+				continue
+			default:
+				return err
+			}
+		}
+
+		txnSigs, err := txn.InputSignedHashes(uxIn)
+		if err != nil {
+			continue
+		}
+
+		sigs = append(sigs, txnSigs...)
+	}
+
+	bc.sigVerifier.VerifyBatch(sigs)
+
+	return nil
+}
+
 // VerifySingleTxnHardConstraints checks that the transaction does not violate hard constraints.
 // for transactions that are not included in a block.
 func (bc Blockchain) VerifySingleTxnHardConstraints(tx *dbutil.Tx, txn coin.Transaction, signed TxnSignedFlag) error {
@@ -430,7 +486,7 @@ func (bc Blockchain) VerifySingleTxnSoftHardConstraints(tx *dbutil.Tx, txn coin.
 }
 
 func (bc Blockchain) verifySingleTxnHardConstraints(tx *dbutil.Tx, txn coin.Transaction, head *coin.SignedBlock, uxIn coin.UxArray, signed TxnSignedFlag) error {
-	if err := VerifySingleTxnHardConstraints(txn, head.Head, uxIn, signed); err != nil {
+	if err := verifySingleTxnHardConstraintsBatch(txn, head.Head, uxIn, signed, bc.sigVerifier); err != nil {
 		return err
 	}
 
@@ -562,6 +618,10 @@ func (bc Blockchain) processTransactions(tx *dbutil.Tx, txs coin.Transactions) (
 		return nil, errors.New("No transactions")
 	}
 
+	if err := bc.verifyTxnSignaturesBatch(tx, txns); err != nil {
+		return nil, err
+	}
+
 	skip := make(map[int]struct{})
 	uxHashes := make(coin.UxHashSet, len(txns))
 	for i, txn := range txns {
@@ -699,22 +759,24 @@ func (bc Blockchain) TransactionFee(tx *dbutil.Tx, headTime uint64) coin.FeeCalc
 
 // VerifySignature checks that BlockSigs state correspond with coin.Blockchain state
 // and that all signatures are valid.
-func (bc *Blockchain) VerifySignature(block *coin.SignedBlock) error {
+func (bc *Blockchain) VerifySignature(block coin.SignedBlockView) error {
 	err := block.VerifySignature(bc.cfg.Pubkey)
 	if err != nil {
-		logger.Errorf("Blockchain signature verification failed for block %d: %v", block.Head.BkSeq, err)
+		logger.Errorf("Blockchain signature verification failed for block %d: %v", block.Seq(), err)
 	}
 	return err
 }
 
 // WalkChain walk through the blockchain concurrently
 // The quit channel is optional and if closed, this method still stop.
-func (bc *Blockchain) WalkChain(workers int, f func(*dbutil.Tx, *coin.SignedBlock) error, quit chan struct{}) error {
+// The blocks are not decoded, f is called on views of the blocks stored in the db.
+// The views are only valid until f returns.
+func (bc *Blockchain) WalkChain(workers int, f func(*dbutil.Tx, coin.SignedBlockView) error, quit chan struct{}) error {
 	if quit == nil {
 		quit = make(chan struct{})
 	}
 
-	signedBlockC := make(chan *coin.SignedBlock, 100)
+	signedBlockC := make(chan coin.SignedBlockView, 100)
 	errC := make(chan error, 100)
 	interrupt := make(chan struct{})
 	verifyDone := make(chan struct{})
@@ -764,22 +826,31 @@ func (bc *Blockchain) WalkChain(workers int, f func(*dbutil.Tx, *coin.SignedBloc
 				return nil
 			}
 			defer wg.Done()
-			defer close(signedBlockC)
+			// The block views point into the memory of this db transaction,
+			// keep it open until the workers are done with the views
+			defer func() {
+				close(signedBlockC)
+				<-verifyDone
+			}()
 
 			errInterrupted := errors.New("goroutine was stopped")
 
-			if err := bc.store.ForEachBlock(tx, func(block *coin.Block) error {
-				sig, ok, err := bc.store.GetBlockSignature(tx, block)
+			if err := bc.store.ForEachBlockView(tx, func(block coin.BlockView) error {
+				sig, ok, err := bc.store.GetBlockViewSignature(tx, block)
 				if err != nil {
 					return err
 				}
 				if !ok {
-					return blockdb.NewErrMissingSignature(block)
+					b, err := block.Block()
+					if err != nil {
+						return err
+					}
+					return blockdb.NewErrMissingSignature(&b)
 				}
 
-				signedBlock := &coin.SignedBlock{
-					Sig:   sig,
-					Block: *block,
+				signedBlock := coin.SignedBlockView{
+					BlockView: block,
+					Sig:       sig,
 				}
 
 				select {
@@ -794,7 +865,7 @@ func (bc *Blockchain) WalkChain(workers int, f func(*dbutil.Tx, *coin.SignedBloc
 				switch err.(type) {
 				case blockdb.ErrMissingSignature:
 				default:
-					logger.Errorf("bc.store.ForEachBlock failed: %v", err)
+					logger.Errorf("bc.store.ForEachBlockView failed: %v", err)
 				}
 				select {
 				case errC <- err:
@@ -2,6 +2,7 @@ package visor
 
 import (
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -142,6 +143,27 @@ func (fcs *fakeChainStore) ForEachBlock(tx *dbutil.Tx, f func(*coin.Block) error
 	return nil
 }
 
+func (fcs *fakeChainStore) GetBlockViewBySeq(tx *dbutil.Tx, seq uint64) (coin.BlockView, bool, error) {
+	l := len(fcs.blocks)
+	if seq >= uint64(l) {
+		return coin.BlockView{}, false, nil
+	}
+
+	v, err := fcs.blocks[seq].Block.View()
+	if err != nil {
+		return coin.BlockView{}, false, err
+	}
+	return v, true, nil
+}
+
+func (fcs *fakeChainStore) GetBlockViewSignature(tx *dbutil.Tx, b coin.BlockView) (cipher.Sig, bool, error) {
+	return cipher.Sig{}, false, nil
+}
+
+func (fcs *fakeChainStore) ForEachBlockView(tx *dbutil.Tx, f func(coin.BlockView) error) error {
+	return nil
+}
+
 func makeBlock(t *testing.T, preBlock coin.Block, tm uint64) *coin.Block {
 	uxHash := testutil.RandSHA256(t)
 	tx := coin.Transaction{}
@@ -717,6 +739,56 @@ func getUxHash(t *testing.T, db *dbutil.DB, bc *Blockchain) cipher.SHA256 {
 	return uxHash
 }
 
+func TestBlockchainSigCache(t *testing.T) {
+	db, closeDB := prepareDB(t)
+	defer closeDB()
+
+	bc, err := NewBlockchain(db, BlockchainConfig{
+		Pubkey:       genPublic,
+		SigCacheSize: 100,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, bc.sigVerifier.Cache())
+
+	gb := addGenesisBlockToBlockchain(t, bc)
+	uxs := coin.CreateUnspents(gb.Head, gb.Body.Transactions[0])
+
+	txn := makeSpendTxn(t, uxs, []cipher.SecKey{genSecret}, testutil.MakeAddress(), 10e6)
+	sigs, err := txn.InputSignedHashes(uxs)
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+
+	// The signatures of a transaction entering the unconfirmed pool are cached
+	err = db.View("", func(tx *dbutil.Tx) error {
+		return bc.VerifySingleTxnHardConstraints(tx, txn, TxnSigned)
+	})
+	require.NoError(t, err)
+	require.True(t, bc.sigVerifier.Cache().Contains(sigs[0]))
+
+	// The signatures of a block are verified in a batch before the transactions are processed
+	bc.sigVerifier = cipher.NewBatchVerifier(2, cipher.NewSigCache(100))
+	err = db.View("", func(tx *dbutil.Tx) error {
+		_, err := bc.processTransactions(tx, coin.Transactions{txn})
+		return err
+	})
+	require.NoError(t, err)
+	require.True(t, bc.sigVerifier.Cache().Contains(sigs[0]))
+
+	// Invalid signatures are not cached, and are reported when the transaction is processed
+	_, s := cipher.GenerateKeyPair()
+	badTxn := makeSpendTxn(t, uxs, []cipher.SecKey{s}, testutil.MakeAddress(), 10e6)
+	badSigs, err := badTxn.InputSignedHashes(uxs)
+	require.NoError(t, err)
+
+	err = db.View("", func(tx *dbutil.Tx) error {
+		_, err := bc.processTransactions(tx, coin.Transactions{badTxn})
+		return err
+	})
+	testutil.RequireError(t, err, NewErrTxnViolatesHardConstraint(errors.New("Signature not valid for output being spent")).Error())
+	require.False(t, bc.sigVerifier.Cache().Contains(badSigs[0]))
+	require.Equal(t, 1, bc.sigVerifier.Cache().Len())
+}
+
 func TestVerifyUxHash(t *testing.T) {
 	db, closeDB := prepareDB(t)
 	defer closeDB()
@@ -862,3 +934,87 @@ func TestExecuteBlock(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestWalkChain(t *testing.T) {
+	db, closeDB := prepareDB(t)
+	defer closeDB()
+
+	err := CreateBuckets(db)
+	require.NoError(t, err)
+
+	store, err := blockdb.NewBlockchain(db, DefaultWalker)
+	require.NoError(t, err)
+
+	bc := &Blockchain{
+		db:    db,
+		store: store,
+		cfg: BlockchainConfig{
+			Pubkey: genPublic,
+		},
+	}
+
+	gb, err := coin.NewGenesisBlock(genAddress, genCoins, genTime)
+	require.NoError(t, err)
+
+	uxs := coin.CreateUnspents(gb.Head, gb.Body.Transactions[0])
+	txn := makeSpendTxn(t, uxs, []cipher.SecKey{genSecret}, testutil.MakeAddress(), 10e6)
+
+	blocks := []coin.SignedBlock{
+		{
+			Block: *gb,
+			Sig:   cipher.MustSignHash(gb.HashHeader(), genSecret),
+		},
+	}
+
+	err = db.Update("", func(tx *dbutil.Tx) error {
+		require.NoError(t, bc.ExecuteBlock(tx, &blocks[0]))
+
+		uxHash, err := bc.Unspent().GetUxHash(tx)
+		require.NoError(t, err)
+		b, err := coin.NewBlock(*gb, genTime+100, uxHash, coin.Transactions{txn}, feeCalc)
+		require.NoError(t, err)
+
+		blocks = append(blocks, coin.SignedBlock{
+			Block: *b,
+			Sig:   cipher.MustSignHash(b.HashHeader(), genSecret),
+		})
+		return bc.ExecuteBlock(tx, &blocks[1])
+	})
+	require.NoError(t, err)
+
+	var lock sync.Mutex
+	walked := make(map[uint64]coin.SignedBlock)
+	err = bc.WalkChain(2, func(tx *dbutil.Tx, b coin.SignedBlockView) error {
+		if err := bc.VerifySignature(b); err != nil {
+			return err
+		}
+
+		sb, err := b.SignedBlock()
+		if err != nil {
+			return err
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		walked[b.Seq()] = sb
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, walked, len(blocks))
+	for _, b := range blocks {
+		require.Equal(t, b, walked[b.Seq()])
+	}
+
+	// A block without a signature stops the walk
+	err = db.Update("", func(tx *dbutil.Tx) error {
+		hash := blocks[1].HashHeader()
+		return dbutil.Delete(tx, blockdb.BlockSigsBkt, hash[:])
+	})
+	require.NoError(t, err)
+
+	err = bc.WalkChain(2, func(tx *dbutil.Tx, b coin.SignedBlockView) error {
+		return bc.VerifySignature(b)
+	}, nil)
+	require.Error(t, err)
+	require.IsType(t, blockdb.ErrMissingSignature{}, err)
+}
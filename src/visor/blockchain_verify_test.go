@@ -589,6 +589,87 @@ func TestVerifyTxnFeeCoinHoursAdditionFails(t *testing.T) {
 	testutil.RequireError(t, err, NewErrTxnViolatesHardConstraint(coinHoursErr).Error())
 }
 
+func TestVerifySchnorrTxnActivation(t *testing.T) {
+	db, closeDB := prepareDB(t)
+	defer closeDB()
+
+	err := CreateBuckets(db)
+	require.NoError(t, err)
+
+	store, err := blockdb.NewBlockchain(db, DefaultWalker)
+	require.NoError(t, err)
+
+	bc := &Blockchain{
+		db:    db,
+		store: store,
+	}
+
+	gb := addGenesisBlockToBlockchain(t, bc)
+
+	// create a Schnorr spending txn
+	uxs := coin.CreateUnspents(gb.Head, gb.Body.Transactions[0])
+	txn := makeSpendTxn(t, uxs, []cipher.SecKey{genSecret}, testutil.MakeAddress(), 10e6)
+	txn.Type = coin.TransactionTypeSchnorr
+	txn.Sigs = nil
+	txn.SignInputs([]cipher.SecKey{genSecret})
+	err = txn.UpdateHeader()
+	require.NoError(t, err)
+	require.Equal(t, coin.TransactionTypeSchnorr, txn.Type)
+
+	defer func(seq uint64) {
+		params.SchnorrActivationSeq = seq
+	}(params.SchnorrActivationSeq)
+
+	cases := []struct {
+		name          string
+		activationSeq uint64
+		err           error
+	}{
+		{
+			name:          "disabled",
+			activationSeq: 0,
+			err:           NewErrTxnViolatesHardConstraint(ErrSchnorrTxnNotActive),
+		},
+		{
+			name:          "activated after the next block",
+			activationSeq: 2,
+			err:           NewErrTxnViolatesHardConstraint(ErrSchnorrTxnNotActive),
+		},
+		{
+			name:          "activated at the next block",
+			activationSeq: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			params.SchnorrActivationSeq = tc.activationSeq
+
+			err := db.View("", func(tx *dbutil.Tx) error {
+				err := bc.VerifySingleTxnHardConstraints(tx, txn, TxnSigned)
+				require.Equal(t, tc.err, err)
+
+				_, _, err = bc.VerifySingleTxnSoftHardConstraints(tx, txn, params.MainNetDistribution, params.UserVerifyTxn, TxnSigned)
+				require.Equal(t, tc.err, err)
+
+				err = bc.VerifyBlockTxnConstraints(tx, txn)
+				require.Equal(t, tc.err, err)
+
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+
+	// Default transactions are not affected by the activation
+	params.SchnorrActivationSeq = 0
+	txn = makeSpendTxn(t, uxs, []cipher.SecKey{genSecret}, testutil.MakeAddress(), 10e6)
+	err = db.View("", func(tx *dbutil.Tx) error {
+		return bc.VerifySingleTxnHardConstraints(tx, txn, TxnSigned)
+	})
+	require.NoError(t, err)
+}
+
 func TestVerifyTransactionIsLocked(t *testing.T) {
 	for _, addr := range params.MainNetDistribution.LockedAddresses() {
 		t.Run(fmt.Sprintf("IsLocked: %s", addr), func(t *testing.T) {
@@ -1,4 +1,4 @@
-// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT.
 
 package blockdb
 
@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
 	"github.com/skycoin/skycoin/src/coin"
 )
 
@@ -153,7 +154,7 @@ func testSkyencoderBlock(t *testing.T, obj *coin.Block) {
 	}
 
 	if !bytes.Equal(data1, data2) {
-		t.Fatal("encoder.Serialize() != encode[1]s()")
+		t.Fatal("encoder.Serialize() != encodeBlock()")
 	}
 
 	// Decode
@@ -420,3 +421,34 @@ func TestSkyencoderBlockDecodeErrors(t *testing.T) {
 		testSkyencoderBlockDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+func TestSkyencoderBlockVectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("testdata/skyencoder-vectors.json", "Block")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj coin.Block
+			if err := decodeBlockExact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decodeBlockExact failed: %v", err)
+			}
+
+			data, err := encodeBlock(&obj)
+			if err != nil {
+				t.Fatalf("encodeBlock failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encodeBlock() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
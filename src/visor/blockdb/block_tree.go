@@ -162,6 +162,41 @@ func (bt *blockTree) GetBlockInDepth(tx *dbutil.Tx, depth uint64, filter Walker)
 	return bt.GetBlock(tx, hash)
 }
 
+// GetBlockView gets a view of the block of given hash, returns false on not found.
+// The view is only valid for the life of the db transaction.
+func (bt *blockTree) GetBlockView(tx *dbutil.Tx, hash cipher.SHA256) (coin.BlockView, bool, error) {
+	v, err := dbutil.GetBucketValueNoCopy(tx, BlocksBkt, hash[:])
+	if err != nil {
+		return coin.BlockView{}, false, err
+	} else if v == nil {
+		return coin.BlockView{}, false, nil
+	}
+
+	b, err := coin.NewBlockView(v)
+	if err != nil {
+		return coin.BlockView{}, false, err
+	}
+
+	if hash != b.HashHeader() {
+		return coin.BlockView{}, false, fmt.Errorf("DB key %s does not match block hash header %s", hash, b.HashHeader())
+	}
+
+	return b, true, nil
+}
+
+// GetBlockViewInDepth gets a view of the block in depth, returns false on not found.
+// The view is only valid for the life of the db transaction.
+func (bt *blockTree) GetBlockViewInDepth(tx *dbutil.Tx, depth uint64, filter Walker) (coin.BlockView, bool, error) {
+	hash, ok, err := bt.getHashInDepth(tx, depth, filter)
+	if err != nil {
+		return coin.BlockView{}, false, fmt.Errorf("BlockTree.getHashInDepth failed: %v", err)
+	} else if !ok {
+		return coin.BlockView{}, false, nil
+	}
+
+	return bt.GetBlockView(tx, hash)
+}
+
 // ForEachBlock iterates all blocks and calls f on them
 func (bt *blockTree) ForEachBlock(tx *dbutil.Tx, f func(b *coin.Block) error) error {
 	return dbutil.ForEach(tx, BlocksBkt, func(_, v []byte) error {
@@ -174,6 +209,19 @@ func (bt *blockTree) ForEachBlock(tx *dbutil.Tx, f func(b *coin.Block) error) er
 	})
 }
 
+// ForEachBlockView iterates all blocks and calls f on views of them, without decoding the blocks.
+// The views are only valid for the life of the db transaction.
+func (bt *blockTree) ForEachBlockView(tx *dbutil.Tx, f func(coin.BlockView) error) error {
+	return dbutil.ForEach(tx, BlocksBkt, func(_, v []byte) error {
+		b, err := coin.NewBlockView(v)
+		if err != nil {
+			return err
+		}
+
+		return f(b)
+	})
+}
+
 func (bt *blockTree) getHashInDepth(tx *dbutil.Tx, depth uint64, filter Walker) (cipher.SHA256, bool, error) {
 	var pairs hashPairsWrapper
 
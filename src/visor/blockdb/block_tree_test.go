@@ -7,6 +7,7 @@ import (
 
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/testutil"
 	"github.com/skycoin/skycoin/src/visor/dbutil"
 )
 
@@ -226,3 +227,78 @@ func TestGetBlockInDepth(t *testing.T) {
 	require.NotNil(t, block)
 	require.Equal(t, blocks[2], *block)
 }
+
+func TestBlockViews(t *testing.T) {
+	db, teardown := prepareDB(t)
+	defer teardown()
+
+	bc := &blockTree{}
+	blocks := []coin.Block{
+		coin.Block{
+			Head: coin.BlockHeader{
+				BkSeq: 0,
+				Time:  0,
+			},
+		},
+		coin.Block{
+			Head: coin.BlockHeader{
+				BkSeq: 1,
+				Time:  1,
+			},
+			Body: coin.BlockBody{
+				Transactions: coin.Transactions{
+					coin.Transaction{
+						In: []cipher.SHA256{testutil.RandSHA256(t)},
+						Out: []coin.TransactionOutput{
+							{
+								Address: testutil.MakeAddress(),
+								Coins:   1e6,
+								Hours:   10,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := db.Update("", func(tx *dbutil.Tx) error {
+		err := bc.AddBlock(tx, &blocks[0])
+		require.NoError(t, err)
+
+		blocks[1].Head.PrevHash = blocks[0].HashHeader()
+		return bc.AddBlock(tx, &blocks[1])
+	})
+	require.NoError(t, err)
+
+	err = db.View("", func(tx *dbutil.Tx) error {
+		for i := range blocks {
+			v, ok, err := bc.GetBlockViewInDepth(tx, uint64(i), DefaultWalker)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.True(t, v.Equal(&blocks[i]))
+		}
+
+		_, ok, err := bc.GetBlockViewInDepth(tx, 2, DefaultWalker)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		_, ok, err = bc.GetBlockView(tx, testutil.RandSHA256(t))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		var n int
+		err = bc.ForEachBlockView(tx, func(v coin.BlockView) error {
+			n++
+			b, err := bc.GetBlock(tx, v.HashHeader())
+			require.NoError(t, err)
+			require.NotNil(t, b)
+			require.True(t, v.Equal(b))
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, len(blocks), n)
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -20,11 +20,7 @@ var (
 	ErrNoHeadBlock = fmt.Errorf("found no head block")
 )
 
-//go:generate skyencoder -unexported -struct Block -output-path . -package blockdb github.com/skycoin/skycoin/src/coin
-//go:generate skyencoder -unexported -struct UxOut -output-path . -package blockdb github.com/skycoin/skycoin/src/coin
-//go:generate skyencoder -unexported -struct hashPairsWrapper
-//go:generate skyencoder -unexported -struct hashesWrapper
-//go:generate skyencoder -unexported -struct sigWrapper
+//go:generate go run github.com/skycoin/skycoin/cmd/skyencoder
 
 // hashesWrapper wraps []cipher.SHA256 so it can be used by skyencoder
 type hashesWrapper struct {
@@ -76,6 +72,8 @@ type BlockTree interface {
 	GetBlock(*dbutil.Tx, cipher.SHA256) (*coin.Block, error)
 	GetBlockInDepth(*dbutil.Tx, uint64, Walker) (*coin.Block, error)
 	ForEachBlock(*dbutil.Tx, func(*coin.Block) error) error
+	GetBlockViewInDepth(*dbutil.Tx, uint64, Walker) (coin.BlockView, bool, error)
+	ForEachBlockView(*dbutil.Tx, func(coin.BlockView) error) error
 }
 
 // BlockSigs block signature storage
@@ -213,6 +211,11 @@ func (bc *Blockchain) GetBlockSignature(tx *dbutil.Tx, b *coin.Block) (cipher.Si
 	return bc.sigs.Get(tx, b.HashHeader())
 }
 
+// GetBlockViewSignature returns the signature of a block view
+func (bc *Blockchain) GetBlockViewSignature(tx *dbutil.Tx, b coin.BlockView) (cipher.Sig, bool, error) {
+	return bc.sigs.Get(tx, b.HashHeader())
+}
+
 // GetBlockByHash returns block of given hash
 func (bc *Blockchain) GetBlockByHash(tx *dbutil.Tx, hash cipher.SHA256) (*coin.Block, error) {
 	b, err := bc.tree.GetBlock(tx, hash)
@@ -274,6 +277,17 @@ func (bc *Blockchain) GetSignedBlockBySeq(tx *dbutil.Tx, seq uint64) (*coin.Sign
 	}, nil
 }
 
+// GetBlockViewBySeq returns a view of the block of given seq, returns false on not found.
+// The view is only valid for the life of the db transaction.
+func (bc *Blockchain) GetBlockViewBySeq(tx *dbutil.Tx, seq uint64) (coin.BlockView, bool, error) {
+	b, ok, err := bc.tree.GetBlockViewInDepth(tx, seq, bc.walker)
+	if err != nil {
+		return coin.BlockView{}, false, fmt.Errorf("bc.tree.GetBlockViewInDepth failed: %v", err)
+	}
+
+	return b, ok, nil
+}
+
 // GetGenesisBlock returns genesis block
 func (bc *Blockchain) GetGenesisBlock(tx *dbutil.Tx) (*coin.SignedBlock, error) {
 	return bc.GetSignedBlockBySeq(tx, 0)
@@ -283,3 +297,9 @@ func (bc *Blockchain) GetGenesisBlock(tx *dbutil.Tx) (*coin.SignedBlock, error)
 func (bc *Blockchain) ForEachBlock(tx *dbutil.Tx, f func(b *coin.Block) error) error {
 	return bc.tree.ForEachBlock(tx, f)
 }
+
+// ForEachBlockView iterates all blocks and calls f on views of them.
+// The views are only valid for the life of the db transaction.
+func (bc *Blockchain) ForEachBlockView(tx *dbutil.Tx, f func(coin.BlockView) error) error {
+	return bc.tree.ForEachBlockView(tx, f)
+}
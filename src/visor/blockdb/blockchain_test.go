@@ -108,6 +108,23 @@ func (bt *fakeBlockTree) ForEachBlock(tx *dbutil.Tx, f func(*coin.Block) error)
 	return nil
 }
 
+func (bt *fakeBlockTree) GetBlockViewInDepth(tx *dbutil.Tx, depth uint64, filter Walker) (coin.BlockView, bool, error) {
+	b, err := bt.GetBlockInDepth(tx, depth, filter)
+	if err != nil || b == nil {
+		return coin.BlockView{}, false, err
+	}
+
+	v, err := b.View()
+	if err != nil {
+		return coin.BlockView{}, false, err
+	}
+	return v, true, nil
+}
+
+func (bt *fakeBlockTree) ForEachBlockView(tx *dbutil.Tx, f func(coin.BlockView) error) error {
+	return nil
+}
+
 type fakeSignatureStore struct {
 	sigs       map[string]cipher.Sig
 	saveFailed bool
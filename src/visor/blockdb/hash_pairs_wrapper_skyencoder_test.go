@@ -1,4 +1,4 @@
-// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT.
 
 package blockdb
 
@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
 )
 
 func newEmptyHashPairsWrapperForEncodeTest() *hashPairsWrapper {
@@ -152,7 +153,7 @@ func testSkyencoderHashPairsWrapper(t *testing.T, obj *hashPairsWrapper) {
 	}
 
 	if !bytes.Equal(data1, data2) {
-		t.Fatal("encoder.Serialize() != encode[1]s()")
+		t.Fatal("encoder.Serialize() != encodeHashPairsWrapper()")
 	}
 
 	// Decode
@@ -419,3 +420,34 @@ func TestSkyencoderHashPairsWrapperDecodeErrors(t *testing.T) {
 		testSkyencoderHashPairsWrapperDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+func TestSkyencoderHashPairsWrapperVectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("testdata/skyencoder-vectors.json", "HashPairsWrapper")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj hashPairsWrapper
+			if err := decodeHashPairsWrapperExact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decodeHashPairsWrapperExact failed: %v", err)
+			}
+
+			data, err := encodeHashPairsWrapper(&obj)
+			if err != nil {
+				t.Fatalf("encodeHashPairsWrapper failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encodeHashPairsWrapper() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
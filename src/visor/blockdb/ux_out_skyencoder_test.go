@@ -1,4 +1,4 @@
-// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT.
 
 package blockdb
 
@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
 	"github.com/skycoin/skycoin/src/coin"
 )
 
@@ -153,7 +154,7 @@ func testSkyencoderUxOut(t *testing.T, obj *coin.UxOut) {
 	}
 
 	if !bytes.Equal(data1, data2) {
-		t.Fatal("encoder.Serialize() != encode[1]s()")
+		t.Fatal("encoder.Serialize() != encodeUxOut()")
 	}
 
 	// Decode
@@ -420,3 +421,34 @@ func TestSkyencoderUxOutDecodeErrors(t *testing.T) {
 		testSkyencoderUxOutDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+func TestSkyencoderUxOutVectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("testdata/skyencoder-vectors.json", "UxOut")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj coin.UxOut
+			if err := decodeUxOutExact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decodeUxOutExact failed: %v", err)
+			}
+
+			data, err := encodeUxOut(&obj)
+			if err != nil {
+				t.Fatalf("encodeUxOut failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encodeUxOut() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
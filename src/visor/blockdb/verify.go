@@ -15,8 +15,8 @@ var (
 	ErrVerifyStopped = errors.New("database verification stopped")
 )
 
-// VerifyDBSkyencoderSafe verifies that the skyencoder generated code has the same result as the encoder
-// for all data in the blockchain
+// VerifyDBSkyencoderSafe verifies that the skyencoder generated code and the coin.BlockView decoder
+// have the same result as the encoder for all data in the blockchain
 func VerifyDBSkyencoderSafe(tx *dbutil.Tx, quit <-chan struct{}) error {
 	if quit == nil {
 		quit = make(chan struct{})
@@ -65,7 +65,14 @@ func VerifyDBSkyencoderSafe(tx *dbutil.Tx, quit <-chan struct{}) error {
 			return err
 		}
 
-		if !reflect.DeepEqual(b1, b2) {
+		// Compare both decodings to the block view, which is cheaper than reflect.DeepEqual
+		// and verifies the block view as well
+		view, err := coin.NewBlockView(v)
+		if err != nil {
+			return err
+		}
+
+		if !view.Equal(&b1) || !view.Equal(&b2) {
 			return errors.New("BlocksBkt block mismatch")
 		}
 
@@ -44,6 +44,15 @@ type Config struct {
 	GenesisCoinVolume uint64
 	// enable arbitrating mode
 	Arbitrating bool
+
+	// Number of blocks between snapshots of the address balances, which speed up
+	// the historical richlist. 0 disables the snapshots
+	BalanceSnapshotInterval uint64
+
+	// Number of workers verifying input signatures concurrently. If 0, runtime.NumCPU() workers are used
+	SigVerifyWorkers int
+	// Maximum number of valid input signatures cached. If 0, signatures are not cached
+	SigCacheSize int
 }
 
 // NewConfig creates Config
@@ -62,6 +71,8 @@ func NewConfig() Config {
 		GenesisSignature:  cipher.Sig{},
 		GenesisTimestamp:  0,
 		GenesisCoinVolume: 0, //100e12, 100e6 * 10e6
+
+		BalanceSnapshotInterval: 10000,
 	}
 
 	return c
@@ -0,0 +1,435 @@
+package visor
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+	"github.com/skycoin/skycoin/src/visor/historydb"
+)
+
+// cursorVersion is the version of the cursor encoding
+const cursorVersion = 1
+
+// ErrInvalidCursor is returned if a cursor can't be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is a position in the blockchain, used for cursor pagination.
+// The cursor of a page is the position of its last item, and the next page starts after it.
+// Since items are ordered by their positions in the blockchain, which never change,
+// pages are not affected by the blocks added after the cursor was returned.
+type Cursor struct {
+	historydb.Position
+}
+
+// String encodes the cursor as an opaque string
+func (c Cursor) String() string {
+	b := make([]byte, 17)
+	b[0] = cursorVersion
+	binary.BigEndian.PutUint64(b[1:9], c.BlockSeq)
+	binary.BigEndian.PutUint32(b[9:13], c.TxnIndex)
+	binary.BigEndian.PutUint32(b[13:17], c.OutIndex)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseCursor decodes a cursor encoded by Cursor.String
+func ParseCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != 17 || b[0] != cursorVersion {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{
+		Position: historydb.Position{
+			BlockSeq: binary.BigEndian.Uint64(b[1:9]),
+			TxnIndex: binary.BigEndian.Uint32(b[9:13]),
+			OutIndex: binary.BigEndian.Uint32(b[13:17]),
+		},
+	}, nil
+}
+
+func (c *Cursor) position() *historydb.Position {
+	if c == nil {
+		return nil
+	}
+	return &c.Position
+}
+
+// CheckPageLimit checks the number of items requested in a page
+func CheckPageLimit(limit uint64) error {
+	if limit == 0 {
+		return ErrZeroPageSize
+	}
+
+	if limit > MaxTxnPageSize {
+		return ErrMaxTxnPageSize
+	}
+
+	return nil
+}
+
+// indexedHashesPage collects the first limit+1 hashes of scans, which may overlap.
+// The extra hash tells if there is a next page.
+type indexedHashesPage struct {
+	reverse bool
+	limit   uint64
+	hashes  []historydb.IndexedHash
+	seen    map[historydb.Position]struct{}
+}
+
+func newIndexedHashesPage(order SortOrder, limit uint64) (*indexedHashesPage, error) {
+	if err := CheckPageLimit(limit); err != nil {
+		return nil, err
+	}
+
+	var reverse bool
+	switch order {
+	case AscOrder:
+	case DescOrder:
+		reverse = true
+	default:
+		return nil, errors.New("unknown sort order")
+	}
+
+	return &indexedHashesPage{
+		reverse: reverse,
+		limit:   limit,
+		seen:    make(map[historydb.Position]struct{}),
+	}, nil
+}
+
+// scanner returns a historydb.ScanFunc which adds the hashes that pass the filter, if not nil, to the page.
+// Each scan stops after limit+1 hashes, as the following hashes can't be in the page.
+func (p *indexedHashesPage) scanner(tx *dbutil.Tx, filter func(*dbutil.Tx, cipher.SHA256) (bool, error)) historydb.ScanFunc {
+	var n uint64
+	return func(h historydb.IndexedHash) (bool, error) {
+		if filter != nil {
+			ok, err := filter(tx, h.Hash)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				return true, nil
+			}
+		}
+
+		if _, ok := p.seen[h.Position]; !ok {
+			p.seen[h.Position] = struct{}{}
+			p.hashes = append(p.hashes, h)
+		}
+
+		n++
+		return n <= p.limit, nil
+	}
+}
+
+// page sorts the collected hashes and returns the hashes of the page, and the cursor of the next page
+// if there are more hashes
+func (p *indexedHashesPage) page() ([]cipher.SHA256, *Cursor) {
+	sort.Slice(p.hashes, func(i, j int) bool {
+		if p.reverse {
+			return p.hashes[j].Position.Less(p.hashes[i].Position)
+		}
+		return p.hashes[i].Position.Less(p.hashes[j].Position)
+	})
+
+	var next *Cursor
+	if uint64(len(p.hashes)) > p.limit {
+		p.hashes = p.hashes[:p.limit]
+		next = &Cursor{
+			Position: p.hashes[len(p.hashes)-1].Position,
+		}
+	}
+
+	hashes := make([]cipher.SHA256, len(p.hashes))
+	for i, h := range p.hashes {
+		hashes[i] = h.Hash
+	}
+
+	return hashes, next
+}
+
+// GetTransactionsPage returns a page of confirmed transactions, ordered by block seq and by their index in the block.
+// If addrs is not empty, only returns the transactions of these addresses.
+// The page starts after the cursor, if not nil. Returns the cursor of the next page, or nil if this is the last page.
+func (vs *Visor) GetTransactionsPage(addrs []cipher.Address, after *Cursor, order SortOrder, limit uint64) ([]Transaction, *Cursor, error) {
+	var txns []Transaction
+	var next *Cursor
+
+	if err := vs.db.View("GetTransactionsPage", func(tx *dbutil.Tx) error {
+		var err error
+		txns, next, err = vs.getTransactionsPage(tx, addrs, after, order, limit)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return txns, next, nil
+}
+
+// GetTransactionsPageWithInputs is the same as GetTransactionsPage but also returns verbose transaction input data
+func (vs *Visor) GetTransactionsPageWithInputs(addrs []cipher.Address, after *Cursor, order SortOrder, limit uint64) ([]Transaction, [][]TransactionInput, *Cursor, error) {
+	var txns []Transaction
+	var inputs [][]TransactionInput
+	var next *Cursor
+
+	if err := vs.db.View("GetTransactionsPageWithInputs", func(tx *dbutil.Tx) error {
+		var err error
+		txns, next, err = vs.getTransactionsPage(tx, addrs, after, order, limit)
+		if err != nil {
+			return err
+		}
+
+		inputs, err = vs.getTransactionsInputs(tx, txns)
+		return err
+	}); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return txns, inputs, next, nil
+}
+
+func (vs *Visor) getTransactionsPage(tx *dbutil.Tx, addrs []cipher.Address, after *Cursor, order SortOrder, limit uint64) ([]Transaction, *Cursor, error) {
+	p, err := newIndexedHashesPage(order, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(addrs) == 0 {
+		if err := vs.history.ScanTransactions(tx, after.position(), p.reverse, p.scanner(tx, nil)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, addr := range addrs {
+		if err := vs.history.ScanAddressTransactions(tx, addr, after.position(), p.reverse, p.scanner(tx, nil)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	hashes, next := p.page()
+
+	ct := confirmedTxnsGetter{
+		transactionModel{
+			history:     vs.history,
+			unconfirmed: vs.unconfirmed,
+			blockchain:  vs.blockchain,
+		},
+	}
+
+	txns := make([]Transaction, len(hashes))
+	for i, h := range hashes {
+		hisTxn, err := vs.history.GetTransaction(tx, h)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if hisTxn == nil {
+			return nil, nil, fmt.Errorf("transaction %s is indexed but not found in historydb", h.Hex())
+		}
+
+		txn, err := ct.convertConfirmedTxn(tx, hisTxn)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		txns[i] = *txn
+	}
+
+	return txns, next, nil
+}
+
+// GetAddressUxOutsPage returns a page of the outputs received by an address, spent or unspent,
+// ordered by block seq, by the index of their transaction in the block and by their index in the transaction.
+// The page starts after the cursor, if not nil. Returns the cursor of the next page, or nil if this is the last page,
+// and the time of the head block.
+func (vs *Visor) GetAddressUxOutsPage(addr cipher.Address, after *Cursor, order SortOrder, limit uint64) ([]historydb.UxOut, *Cursor, uint64, error) {
+	var uxOuts []historydb.UxOut
+	var next *Cursor
+	var headTime uint64
+
+	if err := vs.db.View("GetAddressUxOutsPage", func(tx *dbutil.Tx) error {
+		head, err := vs.blockchain.Head(tx)
+		if err != nil {
+			return err
+		}
+
+		headTime = head.Time()
+
+		p, err := newIndexedHashesPage(order, limit)
+		if err != nil {
+			return err
+		}
+
+		if err := vs.history.ScanAddressOutputs(tx, addr, after.position(), p.reverse, p.scanner(tx, nil)); err != nil {
+			return err
+		}
+
+		var hashes []cipher.SHA256
+		hashes, next = p.page()
+
+		uxOuts, err = vs.history.GetUxOuts(tx, hashes)
+		return err
+	}); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return uxOuts, next, headTime, nil
+}
+
+// GetUnspentOutputsPage returns a page of the confirmed unspent outputs of addresses, ordered by block seq,
+// by the index of their transaction in the block and by their index in the transaction.
+// The page starts after the cursor, if not nil. Returns the cursor of the next page, or nil if this is the last page,
+// and the head block.
+func (vs *Visor) GetUnspentOutputsPage(addrs []cipher.Address, after *Cursor, order SortOrder, limit uint64) ([]UnspentOutput, *Cursor, *coin.SignedBlock, error) {
+	var uxOuts coin.UxArray
+	var next *Cursor
+	var head *coin.SignedBlock
+
+	if err := vs.db.View("GetUnspentOutputsPage", func(tx *dbutil.Tx) error {
+		var err error
+		head, err = vs.blockchain.Head(tx)
+		if err != nil {
+			return err
+		}
+
+		p, err := newIndexedHashesPage(order, limit)
+		if err != nil {
+			return err
+		}
+
+		// The outputs received by the addresses are scanned, skipping the spent outputs
+		for _, addr := range addrs {
+			if err := vs.history.ScanAddressOutputs(tx, addr, after.position(), p.reverse, p.scanner(tx, vs.blockchain.Unspent().Contains)); err != nil {
+				return err
+			}
+		}
+
+		var hashes []cipher.SHA256
+		hashes, next = p.page()
+
+		uxOuts, err = vs.blockchain.Unspent().GetArray(tx, hashes)
+		return err
+	}); err != nil {
+		return nil, nil, nil, err
+	}
+
+	outputs, err := NewUnspentOutputs(uxOuts, head.Time())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return outputs, next, head, nil
+}
+
+// GetBlocksPage returns a page of blocks. The page starts after the cursor, if not nil.
+// Returns the cursor of the next page, or nil if this is the last page.
+func (vs *Visor) GetBlocksPage(after *Cursor, order SortOrder, limit uint64) ([]coin.SignedBlock, *Cursor, error) {
+	var blocks []coin.SignedBlock
+	var next *Cursor
+
+	if err := vs.db.View("GetBlocksPage", func(tx *dbutil.Tx) error {
+		var err error
+		blocks, next, err = vs.getBlocksPage(tx, after, order, limit)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return blocks, next, nil
+}
+
+// GetBlocksPageVerbose is the same as GetBlocksPage but also returns the verbose transaction input data
+// for transactions in these blocks
+func (vs *Visor) GetBlocksPageVerbose(after *Cursor, order SortOrder, limit uint64) ([]coin.SignedBlock, [][][]TransactionInput, *Cursor, error) {
+	var blocks []coin.SignedBlock
+	var inputs [][][]TransactionInput
+	var next *Cursor
+
+	if err := vs.db.View("GetBlocksPageVerbose", func(tx *dbutil.Tx) error {
+		var err error
+		blocks, inputs, err = vs.getBlocksVerbose(tx, func(tx *dbutil.Tx) ([]coin.SignedBlock, error) {
+			blocks, pageNext, err := vs.getBlocksPage(tx, after, order, limit)
+			next = pageNext
+			return blocks, err
+		})
+		return err
+	}); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return blocks, inputs, next, nil
+}
+
+func (vs *Visor) getBlocksPage(tx *dbutil.Tx, after *Cursor, order SortOrder, limit uint64) ([]coin.SignedBlock, *Cursor, error) {
+	if err := CheckPageLimit(limit); err != nil {
+		return nil, nil, err
+	}
+
+	headSeq, ok, err := vs.blockchain.HeadSeq(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, nil
+	}
+
+	// Get one more block than requested, to know if there is a next page
+	var start, end uint64
+	switch order {
+	case AscOrder:
+		if after != nil {
+			if after.BlockSeq >= headSeq {
+				return nil, nil, nil
+			}
+			start = after.BlockSeq + 1
+		}
+		end = start + limit
+		if end > headSeq {
+			end = headSeq
+		}
+	case DescOrder:
+		end = headSeq
+		if after != nil {
+			if after.BlockSeq == 0 {
+				return nil, nil, nil
+			}
+			if after.BlockSeq-1 < end {
+				end = after.BlockSeq - 1
+			}
+		}
+		if end > limit {
+			start = end - limit
+		}
+	default:
+		return nil, nil, errors.New("unknown sort order")
+	}
+
+	blocks, err := vs.blockchain.GetBlocksInRange(tx, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if order == DescOrder {
+		for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+			blocks[i], blocks[j] = blocks[j], blocks[i]
+		}
+	}
+
+	var next *Cursor
+	if uint64(len(blocks)) > limit {
+		blocks = blocks[:limit]
+		next = &Cursor{
+			Position: historydb.Position{
+				BlockSeq: blocks[len(blocks)-1].Head.BkSeq,
+			},
+		}
+	}
+
+	return blocks, next, nil
+}
@@ -0,0 +1,397 @@
+package visor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/params"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+	"github.com/skycoin/skycoin/src/visor/historydb"
+)
+
+func TestCursorString(t *testing.T) {
+	cases := []Cursor{
+		{},
+		{
+			Position: historydb.Position{
+				BlockSeq: 1,
+			},
+		},
+		{
+			Position: historydb.Position{
+				BlockSeq: 123456,
+				TxnIndex: 7,
+				OutIndex: 3,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.String(), func(t *testing.T) {
+			s := c.String()
+			parsed, err := ParseCursor(s)
+			require.NoError(t, err)
+			require.Equal(t, c, *parsed)
+		})
+	}
+}
+
+func TestParseCursorInvalid(t *testing.T) {
+	valid := Cursor{
+		Position: historydb.Position{
+			BlockSeq: 10,
+		},
+	}.String()
+
+	cases := []string{
+		"",
+		"foo",
+		"!!!",
+		valid[:len(valid)-1],
+		valid + "AA",
+		// version 2
+		"AgAAAAAAAAAKAAAAAAAAAAA",
+	}
+
+	for _, s := range cases {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParseCursor(s)
+			require.Equal(t, ErrInvalidCursor, err)
+		})
+	}
+}
+
+// makeCursorTestVisor creates a visor with a blockchain of 5 blocks.
+// Block 1 splits the genesis output, and the following blocks have two transactions each,
+// which send coins from the genesis address to toAddr.
+func makeCursorTestVisor(t *testing.T, toAddr cipher.Address) (*Visor, func()) {
+	db, shutdown := prepareDB(t)
+
+	bc, err := NewBlockchain(db, BlockchainConfig{
+		Pubkey: genPublic,
+	})
+	require.NoError(t, err)
+
+	unconfirmed, err := NewUnconfirmedTransactionPool(db)
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	cfg.BlockchainPubkey = genPublic
+	cfg.GenesisAddress = genAddress
+
+	v := &Visor{
+		Config:      cfg,
+		unconfirmed: unconfirmed,
+		blockchain:  bc,
+		db:          db,
+		history:     historydb.New(),
+	}
+
+	gb := addGenesisBlockToVisor(t, v)
+
+	uxs := coin.CreateUnspents(gb.Head, gb.Body.Transactions[0])
+	txn := makeUnspentsTxn(t, uxs, []cipher.SecKey{genSecret}, genAddress, 6, params.UserVerifyTxn.MaxDropletPrecision)
+	b := addCursorTestBlock(t, v, coin.Transactions{txn}, genTime+100)
+
+	uxs = coin.CreateUnspents(b.Head, b.Body.Transactions[0])
+	for i := 0; i < 3; i++ {
+		txns := coin.Transactions{
+			makeSpendTxn(t, coin.UxArray{uxs[2*i]}, []cipher.SecKey{genSecret}, toAddr, 1e6),
+			makeSpendTxn(t, coin.UxArray{uxs[2*i+1]}, []cipher.SecKey{genSecret}, toAddr, 2e6),
+		}
+		addCursorTestBlock(t, v, txns, genTime+uint64(200+i*100))
+	}
+
+	return v, shutdown
+}
+
+func addCursorTestBlock(t *testing.T, v *Visor, txns coin.Transactions, tm uint64) *coin.SignedBlock {
+	var sb *coin.SignedBlock
+	err := v.db.Update("", func(tx *dbutil.Tx) error {
+		b, err := v.blockchain.NewBlock(tx, txns, tm)
+		if err != nil {
+			return err
+		}
+
+		sb = &coin.SignedBlock{
+			Block: *b,
+			Sig:   cipher.MustSignHash(b.HashHeader(), genSecret),
+		}
+
+		return v.executeSignedBlock(tx, *sb)
+	})
+	require.NoError(t, err)
+	return sb
+}
+
+func txnHashes(txns []Transaction) []cipher.SHA256 {
+	hashes := make([]cipher.SHA256, len(txns))
+	for i, txn := range txns {
+		hashes[i] = txn.Transaction.Hash()
+	}
+	return hashes
+}
+
+func TestGetTransactionsPage(t *testing.T) {
+	toAddr := testutil.MakeAddress()
+	v, shutdown := makeCursorTestVisor(t, toAddr)
+	defer shutdown()
+
+	// Collects all pages of transactions
+	getAll := func(addrs []cipher.Address, order SortOrder, limit uint64) ([]Transaction, int) {
+		var all []Transaction
+		var cursor *Cursor
+		var pages int
+		for {
+			txns, next, err := v.GetTransactionsPage(addrs, cursor, order, limit)
+			require.NoError(t, err)
+			require.True(t, uint64(len(txns)) <= limit)
+			all = append(all, txns...)
+			pages++
+
+			if next == nil {
+				return all, pages
+			}
+
+			// Cursors can be serialized
+			cursor, err = ParseCursor(next.String())
+			require.NoError(t, err)
+		}
+	}
+
+	requireOrdered := func(txns []Transaction, order SortOrder) {
+		for i := 1; i < len(txns); i++ {
+			a := txns[i-1].Status.BlockSeq
+			b := txns[i].Status.BlockSeq
+			if order == AscOrder {
+				require.True(t, a <= b)
+			} else {
+				require.True(t, a >= b)
+			}
+		}
+	}
+
+	txns, pages := getAll(nil, AscOrder, 3)
+	require.Len(t, txns, 8)
+	require.Equal(t, 3, pages)
+	requireOrdered(txns, AscOrder)
+	require.Equal(t, uint64(0), txns[0].Status.BlockSeq)
+	for _, txn := range txns {
+		require.True(t, txn.Status.Confirmed)
+	}
+
+	descTxns, pages := getAll(nil, DescOrder, 3)
+	require.Len(t, descTxns, 8)
+	require.Equal(t, 3, pages)
+	requireOrdered(descTxns, DescOrder)
+	for i := range txns {
+		require.Equal(t, txns[i].Transaction.Hash(), descTxns[len(descTxns)-1-i].Transaction.Hash())
+	}
+
+	// The page size equals the number of transactions, so there is no next page
+	all, pages := getAll(nil, AscOrder, 8)
+	require.Equal(t, txns, all)
+	require.Equal(t, 1, pages)
+
+	// Transactions of toAddr
+	addrTxns, _ := getAll([]cipher.Address{toAddr}, AscOrder, 4)
+	require.Equal(t, txns[2:], addrTxns)
+
+	// Transactions of both addresses are not duplicated
+	bothTxns, _ := getAll([]cipher.Address{toAddr, genAddress}, DescOrder, 2)
+	require.Equal(t, descTxns, bothTxns)
+
+	// Unknown address
+	noTxns, next, err := v.GetTransactionsPage([]cipher.Address{testutil.MakeAddress()}, nil, AscOrder, 10)
+	require.NoError(t, err)
+	require.Empty(t, noTxns)
+	require.Nil(t, next)
+
+	// Pages are not affected by new blocks
+	txns, next, err = v.GetTransactionsPage(nil, nil, DescOrder, 3)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	require.Equal(t, descTxns[:3], txns)
+
+	var unspent coin.AddressUxOuts
+	err = v.db.View("", func(tx *dbutil.Tx) error {
+		var err error
+		unspent, err = v.blockchain.Unspent().GetUnspentsOfAddrs(tx, []cipher.Address{genAddress})
+		return err
+	})
+	require.NoError(t, err)
+	var ux coin.UxOut
+	for _, ux = range unspent[genAddress] {
+		if ux.Body.Coins > 1e6 {
+			break
+		}
+	}
+	txn := makeSpendTxn(t, coin.UxArray{ux}, []cipher.SecKey{genSecret}, toAddr, 1e6)
+	addCursorTestBlock(t, v, coin.Transactions{txn}, genTime+500)
+
+	txns, next, err = v.GetTransactionsPage(nil, next, DescOrder, 3)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	// The transactions are the same, but have one more confirmation
+	require.Equal(t, txnHashes(descTxns[3:6]), txnHashes(txns))
+
+	txns, next, err = v.GetTransactionsPage(nil, next, DescOrder, 3)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Equal(t, txnHashes(descTxns[6:]), txnHashes(txns))
+
+	// Invalid limits
+	_, _, err = v.GetTransactionsPage(nil, nil, AscOrder, 0)
+	require.Equal(t, ErrZeroPageSize, err)
+	_, _, err = v.GetTransactionsPage(nil, nil, AscOrder, MaxTxnPageSize+1)
+	require.Equal(t, ErrMaxTxnPageSize, err)
+}
+
+func TestGetTransactionsPageWithInputs(t *testing.T) {
+	toAddr := testutil.MakeAddress()
+	v, shutdown := makeCursorTestVisor(t, toAddr)
+	defer shutdown()
+
+	txns, inputs, next, err := v.GetTransactionsPageWithInputs([]cipher.Address{toAddr}, nil, AscOrder, 10)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Len(t, txns, 6)
+	require.Len(t, inputs, 6)
+
+	for i, txn := range txns {
+		require.Len(t, inputs[i], len(txn.Transaction.In))
+		for j, in := range inputs[i] {
+			require.Equal(t, txn.Transaction.In[j], in.UxOut.Hash())
+		}
+	}
+}
+
+func TestGetBlocksPage(t *testing.T) {
+	v, shutdown := makeCursorTestVisor(t, testutil.MakeAddress())
+	defer shutdown()
+
+	blocks, next, err := v.GetBlocksPage(nil, AscOrder, 2)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(0), blocks[0].Seq())
+	require.Equal(t, uint64(1), blocks[1].Seq())
+
+	blocks, next, err = v.GetBlocksPage(next, AscOrder, 2)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(2), blocks[0].Seq())
+	require.Equal(t, uint64(3), blocks[1].Seq())
+
+	blocks, next, err = v.GetBlocksPage(next, AscOrder, 2)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Len(t, blocks, 1)
+	require.Equal(t, uint64(4), blocks[0].Seq())
+
+	blocks, next, err = v.GetBlocksPage(nil, DescOrder, 3)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	require.Len(t, blocks, 3)
+	require.Equal(t, uint64(4), blocks[0].Seq())
+	require.Equal(t, uint64(2), blocks[2].Seq())
+
+	blocks, next, err = v.GetBlocksPage(next, DescOrder, 3)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(1), blocks[0].Seq())
+	require.Equal(t, uint64(0), blocks[1].Seq())
+
+	// Cursor past the head
+	blocks, next, err = v.GetBlocksPage(&Cursor{Position: historydb.Position{BlockSeq: 10}}, AscOrder, 2)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Empty(t, blocks)
+
+	blocks, inputs, next, err := v.GetBlocksPageVerbose(nil, DescOrder, 1)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	require.Len(t, blocks, 1)
+	require.Len(t, inputs, 1)
+	require.Len(t, inputs[0], len(blocks[0].Body.Transactions))
+}
+
+func TestGetAddressUxOutsPage(t *testing.T) {
+	toAddr := testutil.MakeAddress()
+	v, shutdown := makeCursorTestVisor(t, toAddr)
+	defer shutdown()
+
+	uxOuts, next, headTime, err := v.GetAddressUxOutsPage(toAddr, nil, AscOrder, 4)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	require.Len(t, uxOuts, 4)
+	require.Equal(t, genTime+400, headTime)
+	for _, ux := range uxOuts {
+		require.Equal(t, toAddr, ux.Out.Body.Address)
+	}
+
+	more, next, _, err := v.GetAddressUxOutsPage(toAddr, next, AscOrder, 4)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Len(t, more, 2)
+	uxOuts = append(uxOuts, more...)
+
+	for i := 1; i < len(uxOuts); i++ {
+		require.True(t, uxOuts[i-1].Out.Head.BkSeq <= uxOuts[i].Out.Head.BkSeq)
+		require.NotEqual(t, uxOuts[i-1].Hash(), uxOuts[i].Hash())
+	}
+
+	// The genesis address received an output in the genesis block, 6 outputs and the change in block 1,
+	// and the change of the spend transactions
+	genUxOuts, next, _, err := v.GetAddressUxOutsPage(genAddress, nil, DescOrder, 100)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Len(t, genUxOuts, 1+7+6)
+	require.Equal(t, uint64(0), genUxOuts[len(genUxOuts)-1].Out.Head.BkSeq)
+}
+
+func TestGetUnspentOutputsPage(t *testing.T) {
+	toAddr := testutil.MakeAddress()
+	v, shutdown := makeCursorTestVisor(t, toAddr)
+	defer shutdown()
+
+	outputs, next, head, err := v.GetUnspentOutputsPage([]cipher.Address{genAddress}, nil, AscOrder, 5)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	require.Equal(t, uint64(4), head.Seq())
+	require.Len(t, outputs, 5)
+
+	more, next, _, err := v.GetUnspentOutputsPage([]cipher.Address{genAddress}, next, AscOrder, 5)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Len(t, more, 2)
+	outputs = append(outputs, more...)
+
+	// The outputs of block 1 are spent, except for the change output of the split
+	var unspent coin.AddressUxOuts
+	err = v.db.View("", func(tx *dbutil.Tx) error {
+		var err error
+		unspent, err = v.blockchain.Unspent().GetUnspentsOfAddrs(tx, []cipher.Address{genAddress})
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, outputs, len(unspent[genAddress]))
+
+	for _, o := range outputs {
+		require.Equal(t, genAddress, o.Body.Address)
+	}
+
+	outputs, next, _, err = v.GetUnspentOutputsPage([]cipher.Address{genAddress, toAddr}, nil, DescOrder, 100)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Len(t, outputs, len(unspent[genAddress])+6)
+	for i := 1; i < len(outputs); i++ {
+		require.True(t, outputs[i-1].Head.BkSeq >= outputs[i].Head.BkSeq)
+	}
+}
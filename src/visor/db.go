@@ -63,7 +63,7 @@ func CheckDatabase(db *dbutil.DB, pubkey cipher.PubKey, quit chan struct{}) erro
 
 	var historyVerifyErr error
 	var lock sync.Mutex
-	verifyFunc := func(tx *dbutil.Tx, b *coin.SignedBlock) error {
+	verifyFunc := func(tx *dbutil.Tx, b coin.SignedBlockView) error {
 		// Verify signature
 		if err := bc.VerifySignature(b); err != nil {
 			return err
@@ -75,7 +75,7 @@ func CheckDatabase(db *dbutil.DB, pubkey cipher.PubKey, quit chan struct{}) erro
 		lock.Lock()
 		defer lock.Unlock()
 		if historyVerifyErr == nil {
-			historyVerifyErr = history.Verify(tx, b, indexesMap)
+			historyVerifyErr = history.Verify(tx, b.BlockView, indexesMap)
 		}
 		return nil
 	}
@@ -118,12 +118,16 @@ func rebuildHistoryDB(db *dbutil.DB, history *historydb.HistoryDB, bc *Blockchai
 			case <-quit:
 				return nil
 			default:
-				b, err := bc.GetSignedBlockBySeq(tx, i)
+				b, ok, err := bc.GetBlockViewBySeq(tx, i)
 				if err != nil {
 					return err
 				}
 
-				if err := history.ParseBlock(tx, b.Block); err != nil {
+				if !ok {
+					return fmt.Errorf("no block exists in depth: %d", i)
+				}
+
+				if err := history.ParseBlockView(tx, b); err != nil {
 					return err
 				}
 
@@ -4,6 +4,7 @@ Package dbutil provides boltdb utility methods
 package dbutil
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 	"github.com/skycoin/skycoin/src/util/logging"
+	"github.com/skycoin/skycoin/src/util/metrics"
 )
 
 var (
@@ -28,6 +30,10 @@ var (
 	txDurationReportingThreshold = time.Millisecond * 100
 )
 
+var (
+	txDuration = metrics.NewHistogramVec("skycoin_db_tx_duration_seconds", "Duration of the database transactions", nil, "op", "name")
+)
+
 // Tx wraps a Tx
 type Tx struct {
 	*bolt.Tx
@@ -94,6 +100,7 @@ func (db *DB) View(name string, f func(*Tx) error) error {
 
 	t1 := time.Now()
 	delta := t1.Sub(t0)
+	txDuration.ObserveDuration(delta, "view", name)
 	if db.DurationLog && delta > db.DurationReportingThreshold {
 		logger.Debugf("db.View [%s] elapsed %s", name, delta)
 	}
@@ -122,6 +129,7 @@ func (db *DB) Update(name string, f func(*Tx) error) error {
 
 	t1 := time.Now()
 	delta := t1.Sub(t0)
+	txDuration.ObserveDuration(delta, "update", name)
 	if db.DurationLog && delta > db.DurationReportingThreshold {
 		logger.Debugf("db.Update [%s] elapsed %s", name, delta)
 	}
@@ -297,6 +305,76 @@ func ForEach(tx *Tx, bktName []byte, f func(k, v []byte) error) error {
 	return bkt.ForEach(f)
 }
 
+// ScanPrefix iterates over the keys of a bucket which start with prefix, in ascending order,
+// or in descending order if reverse is true. If after is not nil, the iteration starts
+// with the first key after it, in the order of the iteration. Iteration stops when f returns false or an error.
+func ScanPrefix(tx *Tx, bktName, prefix, after []byte, reverse bool, f func(k, v []byte) (bool, error)) error {
+	bkt := tx.Bucket(bktName)
+	if bkt == nil {
+		return NewErrBucketNotExist(bktName)
+	}
+
+	c := bkt.Cursor()
+
+	var k, v []byte
+	switch {
+	case !reverse && after != nil:
+		k, v = c.Seek(after)
+		if k != nil && bytes.Equal(k, after) {
+			k, v = c.Next()
+		}
+	case !reverse:
+		k, v = c.Seek(prefix)
+	case after != nil:
+		// Seek returns the first key >= after, so the previous key is the first key < after
+		if k, _ = c.Seek(after); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+	default:
+		end := prefixEnd(prefix)
+		if end == nil {
+			k, v = c.Last()
+		} else if k, _ = c.Seek(end); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+	}
+
+	for k != nil && bytes.HasPrefix(k, prefix) {
+		ok, err := f(k, v)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		if reverse {
+			k, v = c.Prev()
+		} else {
+			k, v = c.Next()
+		}
+	}
+
+	return nil
+}
+
+// prefixEnd returns the first key after all of the keys which start with prefix,
+// or nil if there is no such key
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
 // Delete deletes from a bucket
 func Delete(tx *Tx, bktName, key []byte) error {
 	bkt := tx.Bucket(bktName)
@@ -5,7 +5,7 @@ import (
 	"github.com/skycoin/skycoin/src/visor/dbutil"
 )
 
-//go:generate skyencoder -unexported -struct hashesWrapper
+//go:generate go run github.com/skycoin/skycoin/cmd/skyencoder
 
 // hashesWrapper wraps []cipher.SHA256
 type hashesWrapper struct {
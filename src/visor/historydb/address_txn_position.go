@@ -0,0 +1,37 @@
+package historydb
+
+import (
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// AddressTxnPositionsBkt maps addresses and transaction positions to transaction hashes
+var AddressTxnPositionsBkt = []byte("address_txn_positions")
+
+// addressTxnPositions bucket indexes the transactions of addresses by position,
+// address followed by block seq and transaction index as key, transaction hash as value
+type addressTxnPositions struct{}
+
+// put adds a transaction of an address to the index
+func (atp *addressTxnPositions) put(tx *dbutil.Tx, addr cipher.Address, pos Position, hash cipher.SHA256) error {
+	return dbutil.PutBucketValue(tx, AddressTxnPositionsBkt, append(addr.Bytes(), pos.txnKey()...), hash[:])
+}
+
+// scan iterates over the transactions of an address in the order of their positions, starting after the position after if not nil
+func (atp *addressTxnPositions) scan(tx *dbutil.Tx, addr cipher.Address, after *Position, reverse bool, f ScanFunc) error {
+	var afterKey []byte
+	if after != nil {
+		afterKey = after.txnKey()
+	}
+	return scanPositions(tx, AddressTxnPositionsBkt, addr.Bytes(), afterKey, reverse, f)
+}
+
+// isEmpty checks if the addressTxnPositions bucket is empty
+func (atp *addressTxnPositions) isEmpty(tx *dbutil.Tx) (bool, error) {
+	return dbutil.IsEmpty(tx, AddressTxnPositionsBkt)
+}
+
+// reset resets the bucket
+func (atp *addressTxnPositions) reset(tx *dbutil.Tx) error {
+	return dbutil.Reset(tx, AddressTxnPositionsBkt)
+}
@@ -0,0 +1,37 @@
+package historydb
+
+import (
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// AddressUxPositionsBkt maps addresses and output positions to output hashes
+var AddressUxPositionsBkt = []byte("address_ux_positions")
+
+// addressUxPositions bucket indexes the outputs received by addresses by position,
+// address followed by block seq, transaction index and output index as key, output hash as value
+type addressUxPositions struct{}
+
+// put adds an output of an address to the index
+func (aup *addressUxPositions) put(tx *dbutil.Tx, addr cipher.Address, pos Position, hash cipher.SHA256) error {
+	return dbutil.PutBucketValue(tx, AddressUxPositionsBkt, append(addr.Bytes(), pos.uxKey()...), hash[:])
+}
+
+// scan iterates over the outputs of an address in the order of their positions, starting after the position after if not nil
+func (aup *addressUxPositions) scan(tx *dbutil.Tx, addr cipher.Address, after *Position, reverse bool, f ScanFunc) error {
+	var afterKey []byte
+	if after != nil {
+		afterKey = after.uxKey()
+	}
+	return scanPositions(tx, AddressUxPositionsBkt, addr.Bytes(), afterKey, reverse, f)
+}
+
+// isEmpty checks if the addressUxPositions bucket is empty
+func (aup *addressUxPositions) isEmpty(tx *dbutil.Tx) (bool, error) {
+	return dbutil.IsEmpty(tx, AddressUxPositionsBkt)
+}
+
+// reset resets the bucket
+func (aup *addressUxPositions) reset(tx *dbutil.Tx) error {
+	return dbutil.Reset(tx, AddressUxPositionsBkt)
+}
@@ -0,0 +1,160 @@
+package historydb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/util/mathutil"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// BalanceSnapshotsBkt holds the coins of every address with unspent outputs at some block seqs
+var BalanceSnapshotsBkt = []byte("balance_snapshots")
+
+const (
+	// addressBytesLen is the length of cipher.Address.Bytes()
+	addressBytesLen = 20 + 1 + 4
+	// balanceSnapshotEntryLen is the length of an encoded address and its coins
+	balanceSnapshotEntryLen = addressBytesLen + 8
+)
+
+var errInvalidBalanceSnapshot = errors.New("invalid balance snapshot length")
+
+// balanceSnapshots bucket stores snapshots of the address balances, block seq as key
+// and the encoded addresses and coins as value
+type balanceSnapshots struct{}
+
+// put saves the balances at a block seq
+func (bs *balanceSnapshots) put(tx *dbutil.Tx, seq uint64, balances map[cipher.Address]uint64) error {
+	buf := make([]byte, len(balances)*balanceSnapshotEntryLen)
+	i := 0
+	for addr, coins := range balances {
+		copy(buf[i:], addr.Bytes())
+		binary.BigEndian.PutUint64(buf[i+addressBytesLen:], coins)
+		i += balanceSnapshotEntryLen
+	}
+
+	return dbutil.PutBucketValue(tx, BalanceSnapshotsBkt, dbutil.Itob(seq), buf)
+}
+
+// latest returns the most recent snapshot taken at or before seq, and the block seq of the snapshot.
+// Returns false if there is no such snapshot.
+func (bs *balanceSnapshots) latest(tx *dbutil.Tx, seq uint64) (map[cipher.Address]uint64, uint64, bool, error) {
+	var balances map[cipher.Address]uint64
+	var snapshotSeq uint64
+	var ok bool
+
+	var after []byte
+	if seq < math.MaxUint64 {
+		after = dbutil.Itob(seq + 1)
+	}
+
+	if err := dbutil.ScanPrefix(tx, BalanceSnapshotsBkt, nil, after, true, func(k, v []byte) (bool, error) {
+		var err error
+		balances, err = decodeBalanceSnapshot(v)
+		if err != nil {
+			return false, err
+		}
+
+		snapshotSeq = dbutil.Btoi(k)
+		ok = true
+		return false, nil
+	}); err != nil {
+		return nil, 0, false, err
+	}
+
+	return balances, snapshotSeq, ok, nil
+}
+
+// reset resets the bucket
+func (bs *balanceSnapshots) reset(tx *dbutil.Tx) error {
+	return dbutil.Reset(tx, BalanceSnapshotsBkt)
+}
+
+func decodeBalanceSnapshot(v []byte) (map[cipher.Address]uint64, error) {
+	if len(v)%balanceSnapshotEntryLen != 0 {
+		return nil, errInvalidBalanceSnapshot
+	}
+
+	balances := make(map[cipher.Address]uint64, len(v)/balanceSnapshotEntryLen)
+	for i := 0; i < len(v); i += balanceSnapshotEntryLen {
+		addr, err := cipher.AddressFromBytes(v[i : i+addressBytesLen])
+		if err != nil {
+			return nil, err
+		}
+
+		balances[addr] = binary.BigEndian.Uint64(v[i+addressBytesLen : i+balanceSnapshotEntryLen])
+	}
+
+	return balances, nil
+}
+
+// GetAddressBalancesAt returns the coins of every address with unspent outputs at a block seq, which must be parsed.
+// It starts from the latest snapshot at or before seq and applies the transactions of the following blocks.
+func (hd HistoryDB) GetAddressBalancesAt(tx *dbutil.Tx, seq uint64) (map[cipher.Address]uint64, error) {
+	balances, snapshotSeq, ok, err := hd.snapshots.latest(tx, seq)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *Position
+	if ok {
+		if snapshotSeq == seq {
+			return balances, nil
+		}
+		after = &Position{
+			BlockSeq: snapshotSeq,
+			TxnIndex: math.MaxUint32,
+		}
+	} else {
+		balances = make(map[cipher.Address]uint64)
+	}
+
+	if err := hd.txnPos.scan(tx, after, false, func(h IndexedHash) (bool, error) {
+		if h.Position.BlockSeq > seq {
+			return false, nil
+		}
+
+		txn, err := hd.txns.get(tx, h.Hash)
+		if err != nil {
+			return false, err
+		}
+		if txn == nil {
+			return false, fmt.Errorf("transaction %s is indexed but not found in historydb", h.Hash.Hex())
+		}
+
+		inputs, err := hd.outputs.getArray(tx, txn.Txn.In)
+		if err != nil {
+			return false, err
+		}
+
+		for _, in := range inputs {
+			addr := in.Out.Body.Address
+			if balances[addr] < in.Out.Body.Coins {
+				return false, fmt.Errorf("balance of %s is lower than the coins of its spent output %s", addr, in.Hash().Hex())
+			}
+
+			balances[addr] -= in.Out.Body.Coins
+			if balances[addr] == 0 {
+				delete(balances, addr)
+			}
+		}
+
+		for _, o := range txn.Txn.Out {
+			coins, err := mathutil.AddUint64(balances[o.Address], o.Coins)
+			if err != nil {
+				return false, err
+			}
+			balances[o.Address] = coins
+		}
+
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,157 @@
+package historydb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+func TestGetAddressBalancesAt(t *testing.T) {
+	addrA := "2RxP5N26GhDqHrP6SK45ZzEMSmSpeUeWxsS"
+	addrB := "222uMeCeL1PbkJGZJDgAz5sib2uisv9hYUm"
+
+	expected := []map[cipher.Address]uint64{
+		{
+			genAddress: genCoins,
+		},
+		{
+			cipher.MustDecodeBase58Address(addrA): 10e6,
+			cipher.MustDecodeBase58Address(addrB): genCoins - 10e6,
+		},
+		{
+			cipher.MustDecodeBase58Address(addrA): 20e6,
+			cipher.MustDecodeBase58Address(addrB): genCoins - 20e6,
+		},
+	}
+
+	cases := []struct {
+		name      string
+		config    Config
+		snapshots []uint64
+	}{
+		{
+			name: "no snapshots",
+		},
+		{
+			name: "snapshot every 2 blocks",
+			config: Config{
+				BalanceSnapshotInterval: 2,
+			},
+			snapshots: []uint64{0, 2},
+		},
+		{
+			name: "snapshot every block",
+			config: Config{
+				BalanceSnapshotInterval: 1,
+			},
+			snapshots: []uint64{0, 1, 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, teardown := prepareDB(t)
+			defer teardown()
+
+			bc := newBlockchain()
+			gb := bc.CreateGenesisBlock(genAddress, genCoins, genTime)
+			hisDB := NewWithConfig(tc.config)
+
+			err := db.Update("", func(tx *dbutil.Tx) error {
+				return hisDB.ParseBlock(tx, gb)
+			})
+			require.NoError(t, err)
+
+			testEngine(t, []testData{
+				{
+					PreBlockHash: gb.HashHeader(),
+					Vin: txIn{
+						SigKey:   genSecret.Hex(),
+						Addr:     genAddress.String(),
+						TxID:     gb.Body.Transactions[0].Hash(),
+						BlockSeq: 0,
+					},
+					Vouts: []txOut{
+						{
+							ToAddr: addrA,
+							Coins:  10e6,
+							Hours:  100,
+						},
+						{
+							ToAddr: addrB,
+							Coins:  genCoins - 10e6,
+							Hours:  400,
+						},
+					},
+					AddrInNum: map[string]int{
+						addrA: 1,
+						addrB: 1,
+					},
+				},
+				{
+					Vin: txIn{
+						Addr:     addrB,
+						SigKey:   "62f4d675d991c41a2819d908a4fcf4ba44ff0c31564039e80508c9d68197f90c",
+						BlockSeq: 1,
+					},
+					Vouts: []txOut{
+						{
+							ToAddr: addrA,
+							Coins:  10e6,
+							Hours:  100,
+						},
+						{
+							ToAddr: addrB,
+							Coins:  genCoins - 20e6,
+							Hours:  100,
+						},
+					},
+					AddrInNum: map[string]int{
+						addrA: 2,
+						addrB: 2,
+					},
+				},
+			}, bc, hisDB, db)
+
+			err = db.View("", func(tx *dbutil.Tx) error {
+				for seq, balances := range expected {
+					b, err := hisDB.GetAddressBalancesAt(tx, uint64(seq))
+					require.NoError(t, err)
+					require.Equal(t, balances, b)
+				}
+
+				var snapshots []uint64
+				err := dbutil.ForEach(tx, BalanceSnapshotsBkt, func(k, v []byte) error {
+					snapshots = append(snapshots, dbutil.Btoi(k))
+
+					balances, err := decodeBalanceSnapshot(v)
+					require.NoError(t, err)
+					require.Equal(t, expected[dbutil.Btoi(k)], balances)
+					return nil
+				})
+				require.NoError(t, err)
+				require.Equal(t, tc.snapshots, snapshots)
+
+				return nil
+			})
+			require.NoError(t, err)
+
+			// Erase removes the snapshots
+			err = db.Update("", func(tx *dbutil.Tx) error {
+				return hisDB.Erase(tx)
+			})
+			require.NoError(t, err)
+
+			err = db.View("", func(tx *dbutil.Tx) error {
+				_, _, ok, err := hisDB.snapshots.latest(tx, 2)
+				require.NoError(t, err)
+				require.False(t, ok)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
@@ -24,26 +24,55 @@ func CreateBuckets(tx *dbutil.Tx) error {
 		HistoryMetaBkt,
 		UxOutsBkt,
 		TransactionsBkt,
+		TxnPositionsBkt,
+		AddressTxnPositionsBkt,
+		AddressUxPositionsBkt,
+		SearchIndexBkt,
+		BalanceSnapshotsBkt,
 	})
 }
 
+// Config configures the HistoryDB
+type Config struct {
+	// BalanceSnapshotInterval is the number of blocks between snapshots of the address balances,
+	// which speed up GetAddressBalancesAt. 0 disables the snapshots.
+	BalanceSnapshotInterval uint64
+}
+
 // HistoryDB provides APIs for blockchain explorer
 type HistoryDB struct {
-	outputs  *uxOuts       // outputs bucket
-	txns     *transactions // transactions bucket
-	addrUx   *addressUx    // bucket which stores all UxOuts that address received
-	addrTxns *addressTxns  // address related transaction bucket
-	meta     *historyMeta  // stores history meta info
+	config     Config
+	outputs    *uxOuts              // outputs bucket
+	txns       *transactions        // transactions bucket
+	addrUx     *addressUx           // bucket which stores all UxOuts that address received
+	addrTxns   *addressTxns         // address related transaction bucket
+	txnPos     *txnPositions        // transactions indexed by position
+	addrTxnPos *addressTxnPositions // address related transactions indexed by position
+	addrUxPos  *addressUxPositions  // UxOuts that address received, indexed by position
+	search     *searchIndex         // transaction hashes, block hashes and addresses indexed for prefix search
+	snapshots  *balanceSnapshots    // address balances at every BalanceSnapshotInterval blocks
+	meta       *historyMeta         // stores history meta info
 }
 
 // New create HistoryDB instance
 func New() *HistoryDB {
+	return NewWithConfig(Config{})
+}
+
+// NewWithConfig creates a HistoryDB instance with a Config
+func NewWithConfig(c Config) *HistoryDB {
 	return &HistoryDB{
-		outputs:  &uxOuts{},
-		txns:     &transactions{},
-		addrUx:   &addressUx{},
-		addrTxns: &addressTxns{},
-		meta:     &historyMeta{},
+		config:     c,
+		outputs:    &uxOuts{},
+		txns:       &transactions{},
+		addrUx:     &addressUx{},
+		addrTxns:   &addressTxns{},
+		txnPos:     &txnPositions{},
+		addrTxnPos: &addressTxnPositions{},
+		addrUxPos:  &addressUxPositions{},
+		search:     &searchIndex{},
+		snapshots:  &balanceSnapshots{},
+		meta:       &historyMeta{},
 	}
 }
 
@@ -79,7 +108,27 @@ func (hd *HistoryDB) NeedsReset(tx *dbutil.Tx) (bool, error) {
 		return false, err
 	}
 
-	if addrTxnsEmpty || addrUxEmpty || txnsEmpty || outputsEmpty {
+	txnPosEmpty, err := hd.txnPos.isEmpty(tx)
+	if err != nil {
+		return false, err
+	}
+
+	addrTxnPosEmpty, err := hd.addrTxnPos.isEmpty(tx)
+	if err != nil {
+		return false, err
+	}
+
+	addrUxPosEmpty, err := hd.addrUxPos.isEmpty(tx)
+	if err != nil {
+		return false, err
+	}
+
+	searchEmpty, err := hd.search.isEmpty(tx)
+	if err != nil {
+		return false, err
+	}
+
+	if addrTxnsEmpty || addrUxEmpty || txnsEmpty || outputsEmpty || txnPosEmpty || addrTxnPosEmpty || addrUxPosEmpty || searchEmpty {
 		return true, nil
 	}
 
@@ -101,6 +150,26 @@ func (hd *HistoryDB) Erase(tx *dbutil.Tx) error {
 		return err
 	}
 
+	if err := hd.txnPos.reset(tx); err != nil {
+		return err
+	}
+
+	if err := hd.addrTxnPos.reset(tx); err != nil {
+		return err
+	}
+
+	if err := hd.addrUxPos.reset(tx); err != nil {
+		return err
+	}
+
+	if err := hd.search.reset(tx); err != nil {
+		return err
+	}
+
+	if err := hd.snapshots.reset(tx); err != nil {
+		return err
+	}
+
 	if err := hd.meta.reset(tx); err != nil {
 		return err
 	}
@@ -125,20 +194,44 @@ func (hd *HistoryDB) GetUxOuts(tx *dbutil.Tx, uxIDs []cipher.SHA256) ([]UxOut, e
 
 // ParseBlock builds indexes out of the block data
 func (hd *HistoryDB) ParseBlock(tx *dbutil.Tx, b coin.Block) error {
-	for _, t := range b.Body.Transactions {
-		txn := Transaction{
-			Txn:      t,
-			BlockSeq: b.Seq(),
-		}
+	v, err := b.View()
+	if err != nil {
+		return err
+	}
+
+	return hd.ParseBlockView(tx, v)
+}
+
+// ParseBlockView builds indexes out of the block data, reading the block from its encoding
+func (hd *HistoryDB) ParseBlockView(tx *dbutil.Tx, b coin.BlockView) error {
+	head := b.Head()
+
+	if err := hd.search.putBlock(tx, b.HashHeader(), head.BkSeq); err != nil {
+		return err
+	}
 
+	if err := b.ForEachTransaction(func(i int, t coin.TransactionView) error {
 		spentTxnID := t.Hash()
 
-		if err := hd.txns.put(tx, &txn); err != nil {
+		if err := hd.txns.putView(tx, spentTxnID, t, head.BkSeq); err != nil {
 			return err
 		}
 
-		for _, in := range t.In {
-			o, err := hd.outputs.get(tx, in)
+		txnPos := Position{
+			BlockSeq: head.BkSeq,
+			TxnIndex: uint32(i),
+		}
+
+		if err := hd.txnPos.put(tx, txnPos, spentTxnID); err != nil {
+			return err
+		}
+
+		if err := hd.search.putTxn(tx, spentTxnID, head.BkSeq); err != nil {
+			return err
+		}
+
+		for j := 0; j < t.NumIn(); j++ {
+			o, err := hd.outputs.get(tx, t.In(j))
 			if err != nil {
 				return err
 			}
@@ -148,7 +241,7 @@ func (hd *HistoryDB) ParseBlock(tx *dbutil.Tx, b coin.Block) error {
 			}
 
 			// update the output's spent block seq and txid
-			o.SpentBlockSeq = b.Seq()
+			o.SpentBlockSeq = head.BkSeq
 			o.SpentTxnID = spentTxnID
 			if err := hd.outputs.put(tx, *o); err != nil {
 				return err
@@ -158,28 +251,67 @@ func (hd *HistoryDB) ParseBlock(tx *dbutil.Tx, b coin.Block) error {
 			if err := hd.addrTxns.add(tx, o.Out.Body.Address, spentTxnID); err != nil {
 				return err
 			}
+
+			if err := hd.addrTxnPos.put(tx, o.Out.Body.Address, txnPos, spentTxnID); err != nil {
+				return err
+			}
+
+			if err := hd.search.putAddress(tx, o.Out.Body.Address); err != nil {
+				return err
+			}
 		}
 
 		// handle the tx out
-		uxArray := coin.CreateUnspents(b.Head, t)
-		for _, ux := range uxArray {
+		for j := 0; j < t.NumOut(); j++ {
+			ux := t.CreateUnspent(head, spentTxnID, j)
+			uxHash := ux.Hash()
+
 			if err := hd.outputs.put(tx, UxOut{
 				Out: ux,
 			}); err != nil {
 				return err
 			}
 
-			if err := hd.addrUx.add(tx, ux.Body.Address, ux.Hash()); err != nil {
+			if err := hd.addrUx.add(tx, ux.Body.Address, uxHash); err != nil {
 				return err
 			}
 
 			if err := hd.addrTxns.add(tx, ux.Body.Address, spentTxnID); err != nil {
 				return err
 			}
+
+			if err := hd.addrTxnPos.put(tx, ux.Body.Address, txnPos, spentTxnID); err != nil {
+				return err
+			}
+
+			uxPos := txnPos
+			uxPos.OutIndex = uint32(j)
+			if err := hd.addrUxPos.put(tx, ux.Body.Address, uxPos, uxHash); err != nil {
+				return err
+			}
+
+			if err := hd.search.putAddress(tx, ux.Body.Address); err != nil {
+				return err
+			}
 		}
+
+		return nil
+	}); err != nil {
+		return err
 	}
 
-	return hd.SetParsedBlockSeq(tx, b.Seq())
+	if hd.config.BalanceSnapshotInterval != 0 && head.BkSeq%hd.config.BalanceSnapshotInterval == 0 {
+		balances, err := hd.GetAddressBalancesAt(tx, head.BkSeq)
+		if err != nil {
+			return err
+		}
+
+		if err := hd.snapshots.put(tx, head.BkSeq, balances); err != nil {
+			return err
+		}
+	}
+
+	return hd.SetParsedBlockSeq(tx, head.BkSeq)
 }
 
 // GetTransaction get transaction by hash.
@@ -219,6 +351,38 @@ func (hd HistoryDB) GetTransactionHashesForAddresses(tx *dbutil.Tx, addrs []ciph
 	return hashes, nil
 }
 
+// ScanTransactions calls f with the hashes of the transactions in the order of their positions,
+// in descending order if reverse is true. If after is not nil, the scan starts after this position.
+func (hd HistoryDB) ScanTransactions(tx *dbutil.Tx, after *Position, reverse bool, f ScanFunc) error {
+	return hd.txnPos.scan(tx, after, reverse, f)
+}
+
+// ScanAddressTransactions is the same as ScanTransactions but only scans the transactions of an address
+func (hd HistoryDB) ScanAddressTransactions(tx *dbutil.Tx, addr cipher.Address, after *Position, reverse bool, f ScanFunc) error {
+	return hd.addrTxnPos.scan(tx, addr, after, reverse, f)
+}
+
+// ScanAddressOutputs calls f with the hashes of the outputs that the address received in the order of their positions,
+// in descending order if reverse is true. If after is not nil, the scan starts after this position.
+func (hd HistoryDB) ScanAddressOutputs(tx *dbutil.Tx, addr cipher.Address, after *Position, reverse bool, f ScanFunc) error {
+	return hd.addrUxPos.scan(tx, addr, after, reverse, f)
+}
+
+// SearchTransactions returns up to limit hashes of the transactions which start with the hex prefix, in ascending order
+func (hd HistoryDB) SearchTransactions(tx *dbutil.Tx, prefix string, limit int) ([]HashMatch, error) {
+	return hd.search.searchTxns(tx, prefix, limit)
+}
+
+// SearchBlocks returns up to limit hashes of the blocks which start with the hex prefix, in ascending order
+func (hd HistoryDB) SearchBlocks(tx *dbutil.Tx, prefix string, limit int) ([]HashMatch, error) {
+	return hd.search.searchBlocks(tx, prefix, limit)
+}
+
+// SearchAddresses returns up to limit addresses seen in the blockchain which start with the prefix, in ascending order
+func (hd HistoryDB) SearchAddresses(tx *dbutil.Tx, prefix string, limit int) ([]cipher.Address, error) {
+	return hd.search.searchAddresses(tx, prefix, limit)
+}
+
 // AddressSeen returns true if the address appears in the blockchain
 func (hd HistoryDB) AddressSeen(tx *dbutil.Tx, addr cipher.Address) (bool, error) {
 	return hd.addrTxns.contains(tx, addr)
@@ -264,20 +428,24 @@ type AddressIndexes struct {
 }
 
 // Verify checks if the historydb is corrupted
-func (hd HistoryDB) Verify(tx *dbutil.Tx, b *coin.SignedBlock, indexesMap *IndexesMap) error {
-	for _, t := range b.Body.Transactions {
+func (hd HistoryDB) Verify(tx *dbutil.Tx, b coin.BlockView, indexesMap *IndexesMap) error {
+	head := b.Head()
+
+	return b.ForEachTransaction(func(_ int, t coin.TransactionView) error {
 		txnHash := t.Hash()
-		txn, err := hd.txns.get(tx, txnHash)
+		ok, err := hd.txns.has(tx, txnHash)
 		if err != nil {
 			return err
 		}
 
-		if txn == nil {
+		if !ok {
 			err := fmt.Errorf("HistoryDB.Verify: transaction %v does not exist in historydb", txnHash.Hex())
 			return ErrHistoryDBCorrupted{err}
 		}
 
-		for _, in := range t.In {
+		for j := 0; j < t.NumIn(); j++ {
+			in := t.In(j)
+
 			// Checks the existence of transaction input
 			o, err := hd.outputs.get(tx, in)
 			if err != nil {
@@ -290,9 +458,9 @@ func (hd HistoryDB) Verify(tx *dbutil.Tx, b *coin.SignedBlock, indexesMap *Index
 			}
 
 			// Checks the output's spend block seq
-			if o.SpentBlockSeq != b.Seq() {
+			if o.SpentBlockSeq != head.BkSeq {
 				err := fmt.Errorf("HistoryDB.Verify: spend block seq of transaction input %v is wrong, should be: %v, but is %v",
-					in.Hex(), b.Seq(), o.SpentBlockSeq)
+					in.Hex(), head.BkSeq, o.SpentBlockSeq)
 				return ErrHistoryDBCorrupted{err}
 			}
 
@@ -342,8 +510,8 @@ func (hd HistoryDB) Verify(tx *dbutil.Tx, b *coin.SignedBlock, indexesMap *Index
 		}
 
 		// Checks the transaction outs
-		uxArray := coin.CreateUnspents(b.Head, t)
-		for _, ux := range uxArray {
+		for j := 0; j < t.NumOut(); j++ {
+			ux := t.CreateUnspent(head, txnHash, j)
 			uxHash := ux.Hash()
 			out, err := hd.outputs.get(tx, uxHash)
 			if err != nil {
@@ -391,8 +559,9 @@ func (hd HistoryDB) Verify(tx *dbutil.Tx, b *coin.SignedBlock, indexesMap *Index
 				return ErrHistoryDBCorrupted{err}
 			}
 		}
-	}
-	return nil
+
+		return nil
+	})
 }
 
 // ErrHistoryDBCorrupted is returned when found the historydb is corrupted
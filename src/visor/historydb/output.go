@@ -8,8 +8,6 @@ import (
 	"github.com/skycoin/skycoin/src/visor/dbutil"
 )
 
-//go:generate skyencoder -unexported -struct UxOut
-
 // UxOutsBkt holds unspent outputs
 var UxOutsBkt = []byte("uxouts")
 
@@ -0,0 +1,106 @@
+package historydb
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// Position is the position of a transaction, or of a transaction output, in the blockchain.
+// Positions are ordered by block seq, then by the index of the transaction in the block,
+// then by the index of the output in the transaction.
+type Position struct {
+	BlockSeq uint64
+	TxnIndex uint32
+	OutIndex uint32
+}
+
+// Less returns true if p is before q
+func (p Position) Less(q Position) bool {
+	if p.BlockSeq != q.BlockSeq {
+		return p.BlockSeq < q.BlockSeq
+	}
+	if p.TxnIndex != q.TxnIndex {
+		return p.TxnIndex < q.TxnIndex
+	}
+	return p.OutIndex < q.OutIndex
+}
+
+const (
+	txnPositionKeyLen = 8 + 4
+	uxPositionKeyLen  = 8 + 4 + 4
+)
+
+// txnKey encodes the block seq and transaction index of the position, in big endian so that the keys sort by position
+func (p Position) txnKey() []byte {
+	b := make([]byte, txnPositionKeyLen)
+	binary.BigEndian.PutUint64(b[:8], p.BlockSeq)
+	binary.BigEndian.PutUint32(b[8:12], p.TxnIndex)
+	return b
+}
+
+// uxKey encodes the block seq, transaction index and output index of the position
+func (p Position) uxKey() []byte {
+	b := make([]byte, uxPositionKeyLen)
+	binary.BigEndian.PutUint64(b[:8], p.BlockSeq)
+	binary.BigEndian.PutUint32(b[8:12], p.TxnIndex)
+	binary.BigEndian.PutUint32(b[12:16], p.OutIndex)
+	return b
+}
+
+var errInvalidPositionKey = errors.New("invalid position key length")
+
+func positionFromKey(k []byte) (Position, error) {
+	switch len(k) {
+	case txnPositionKeyLen:
+		return Position{
+			BlockSeq: binary.BigEndian.Uint64(k[:8]),
+			TxnIndex: binary.BigEndian.Uint32(k[8:12]),
+		}, nil
+	case uxPositionKeyLen:
+		return Position{
+			BlockSeq: binary.BigEndian.Uint64(k[:8]),
+			TxnIndex: binary.BigEndian.Uint32(k[8:12]),
+			OutIndex: binary.BigEndian.Uint32(k[12:16]),
+		}, nil
+	default:
+		return Position{}, errInvalidPositionKey
+	}
+}
+
+// IndexedHash is the hash of a transaction or an output, with its position in the blockchain
+type IndexedHash struct {
+	Position Position
+	Hash     cipher.SHA256
+}
+
+// ScanFunc is called for each hash of a scan. The scan stops if it returns false or an error.
+type ScanFunc func(IndexedHash) (bool, error)
+
+// scanPositions scans the keys of a position index bucket which start with prefix, followed by the position key.
+// If after is not nil, the scan starts after it
+func scanPositions(tx *dbutil.Tx, bktName, prefix, after []byte, reverse bool, f ScanFunc) error {
+	var afterKey []byte
+	if after != nil {
+		afterKey = append(append([]byte{}, prefix...), after...)
+	}
+
+	return dbutil.ScanPrefix(tx, bktName, prefix, afterKey, reverse, func(k, v []byte) (bool, error) {
+		pos, err := positionFromKey(k[len(prefix):])
+		if err != nil {
+			return false, err
+		}
+
+		hash, err := cipher.SHA256FromBytes(v)
+		if err != nil {
+			return false, err
+		}
+
+		return f(IndexedHash{
+			Position: pos,
+			Hash:     hash,
+		})
+	})
+}
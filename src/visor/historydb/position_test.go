@@ -0,0 +1,116 @@
+package historydb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+func TestPositionLess(t *testing.T) {
+	positions := []Position{
+		{BlockSeq: 0, TxnIndex: 0, OutIndex: 0},
+		{BlockSeq: 0, TxnIndex: 0, OutIndex: 1},
+		{BlockSeq: 0, TxnIndex: 1, OutIndex: 0},
+		{BlockSeq: 1, TxnIndex: 0, OutIndex: 0},
+		{BlockSeq: 256, TxnIndex: 0, OutIndex: 0},
+	}
+
+	for i := range positions {
+		require.False(t, positions[i].Less(positions[i]))
+		for j := i + 1; j < len(positions); j++ {
+			require.True(t, positions[i].Less(positions[j]))
+			require.False(t, positions[j].Less(positions[i]))
+		}
+	}
+}
+
+func TestAddressTxnPositionsScan(t *testing.T) {
+	db, td := prepareDB(t)
+	defer td()
+
+	addrs := []cipher.Address{makeAddress(), makeAddress()}
+
+	var positions []Position
+	for seq := uint64(0); seq < 3; seq++ {
+		// Block seqs larger than 255 verify that keys are sorted by position
+		for i := uint32(0); i < 2; i++ {
+			positions = append(positions, Position{
+				BlockSeq: seq * 300,
+				TxnIndex: i,
+			})
+		}
+	}
+
+	hashOf := func(addr cipher.Address, pos Position) cipher.SHA256 {
+		return cipher.SumSHA256([]byte(fmt.Sprintf("%s-%d-%d", addr, pos.BlockSeq, pos.TxnIndex)))
+	}
+
+	atp := &addressTxnPositions{}
+	err := db.Update("", func(tx *dbutil.Tx) error {
+		for _, addr := range addrs {
+			for _, pos := range positions {
+				if err := atp.put(tx, addr, pos, hashOf(addr, pos)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	scan := func(addr cipher.Address, after *Position, reverse bool, limit int) []IndexedHash {
+		var hashes []IndexedHash
+		err := db.View("", func(tx *dbutil.Tx) error {
+			return atp.scan(tx, addr, after, reverse, func(h IndexedHash) (bool, error) {
+				hashes = append(hashes, h)
+				return len(hashes) < limit, nil
+			})
+		})
+		require.NoError(t, err)
+		return hashes
+	}
+
+	expected := func(addr cipher.Address, positions []Position) []IndexedHash {
+		var hashes []IndexedHash
+		for _, pos := range positions {
+			hashes = append(hashes, IndexedHash{
+				Position: pos,
+				Hash:     hashOf(addr, pos),
+			})
+		}
+		return hashes
+	}
+
+	reversed := make([]Position, len(positions))
+	for i, pos := range positions {
+		reversed[len(positions)-1-i] = pos
+	}
+
+	for _, addr := range addrs {
+		require.Equal(t, expected(addr, positions), scan(addr, nil, false, 100))
+		require.Equal(t, expected(addr, reversed), scan(addr, nil, true, 100))
+
+		// The scan stops when f returns false
+		require.Equal(t, expected(addr, positions[:2]), scan(addr, nil, false, 2))
+
+		// The scan starts after the position
+		require.Equal(t, expected(addr, positions[3:]), scan(addr, &positions[2], false, 100))
+		require.Equal(t, expected(addr, reversed[4:]), scan(addr, &positions[2], true, 100))
+
+		// The position does not need to be in the index
+		after := Position{BlockSeq: 301}
+		require.Equal(t, expected(addr, positions[4:]), scan(addr, &after, false, 100))
+		require.Equal(t, expected(addr, reversed[2:]), scan(addr, &after, true, 100))
+
+		// Scanning after the last position
+		require.Empty(t, scan(addr, &positions[len(positions)-1], false, 100))
+		require.Empty(t, scan(addr, &positions[0], true, 100))
+	}
+
+	require.Empty(t, scan(makeAddress(), nil, false, 100))
+	require.Empty(t, scan(makeAddress(), nil, true, 100))
+}
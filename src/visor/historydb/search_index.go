@@ -0,0 +1,171 @@
+package historydb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// SearchIndexBkt indexes transaction hashes, block hashes and addresses for prefix search
+var SearchIndexBkt = []byte("search_index")
+
+// ErrInvalidHexPrefix is returned when searching hashes with a prefix that is not hexadecimal
+var ErrInvalidHexPrefix = errors.New("invalid hex prefix")
+
+// Kinds of keys in the search index. The kind is the first byte of the key.
+const (
+	searchKindTxn     byte = 't'
+	searchKindBlock   byte = 'b'
+	searchKindAddress byte = 'a'
+)
+
+// HashMatch is a transaction or block hash found by a prefix search, with the seq of its block
+type HashMatch struct {
+	Hash     cipher.SHA256
+	BlockSeq uint64
+}
+
+// searchIndex bucket indexes transaction and block hashes with the hash bytes as key and the block seq as value,
+// and addresses with the base58 address string as key and no value
+type searchIndex struct{}
+
+// putTxn adds a transaction hash to the index
+func (si *searchIndex) putTxn(tx *dbutil.Tx, hash cipher.SHA256, seq uint64) error {
+	return dbutil.PutBucketValue(tx, SearchIndexBkt, searchKey(searchKindTxn, hash[:]), dbutil.Itob(seq))
+}
+
+// putBlock adds a block hash to the index
+func (si *searchIndex) putBlock(tx *dbutil.Tx, hash cipher.SHA256, seq uint64) error {
+	return dbutil.PutBucketValue(tx, SearchIndexBkt, searchKey(searchKindBlock, hash[:]), dbutil.Itob(seq))
+}
+
+// putAddress adds an address to the index
+func (si *searchIndex) putAddress(tx *dbutil.Tx, addr cipher.Address) error {
+	return dbutil.PutBucketValue(tx, SearchIndexBkt, searchKey(searchKindAddress, []byte(addr.String())), []byte{})
+}
+
+// searchTxns returns up to limit transaction hashes which start with the hex prefix, in ascending order
+func (si *searchIndex) searchTxns(tx *dbutil.Tx, prefix string, limit int) ([]HashMatch, error) {
+	return si.searchHashes(tx, searchKindTxn, prefix, limit)
+}
+
+// searchBlocks returns up to limit block hashes which start with the hex prefix, in ascending order
+func (si *searchIndex) searchBlocks(tx *dbutil.Tx, prefix string, limit int) ([]HashMatch, error) {
+	return si.searchHashes(tx, searchKindBlock, prefix, limit)
+}
+
+func (si *searchIndex) searchHashes(tx *dbutil.Tx, kind byte, prefix string, limit int) ([]HashMatch, error) {
+	if len(prefix) > 2*len(cipher.SHA256{}) {
+		return nil, ErrInvalidHexPrefix
+	}
+
+	prefix = strings.ToLower(prefix)
+
+	// A hex prefix of odd length ends with half a byte, which is matched against the high nibble
+	// of the next byte of the keys
+	evenLen := len(prefix) - len(prefix)%2
+	b, err := hex.DecodeString(prefix[:evenLen])
+	if err != nil {
+		return nil, ErrInvalidHexPrefix
+	}
+
+	keyPrefix := searchKey(kind, b)
+	start := keyPrefix
+	var nibble byte
+	odd := evenLen != len(prefix)
+	if odd {
+		n, err := hex.DecodeString(prefix[evenLen:] + "0")
+		if err != nil {
+			return nil, ErrInvalidHexPrefix
+		}
+		nibble = n[0]
+		start = append(append([]byte{}, keyPrefix...), nibble)
+	}
+
+	var matches []HashMatch
+	err = si.scan(tx, keyPrefix, start, limit, func(k, v []byte) (bool, error) {
+		if odd && k[len(keyPrefix)]&0xf0 != nibble {
+			return false, nil
+		}
+
+		hash, err := cipher.SHA256FromBytes(k[1:])
+		if err != nil {
+			return false, err
+		}
+
+		matches = append(matches, HashMatch{
+			Hash:     hash,
+			BlockSeq: dbutil.Btoi(v),
+		})
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// searchAddresses returns up to limit addresses which start with the base58 prefix, in ascending order
+func (si *searchIndex) searchAddresses(tx *dbutil.Tx, prefix string, limit int) ([]cipher.Address, error) {
+	keyPrefix := searchKey(searchKindAddress, []byte(prefix))
+
+	var addrs []cipher.Address
+	if err := si.scan(tx, keyPrefix, keyPrefix, limit, func(k, v []byte) (bool, error) {
+		addr, err := cipher.DecodeBase58Address(string(k[1:]))
+		if err != nil {
+			return false, err
+		}
+
+		addrs = append(addrs, addr)
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return addrs, nil
+}
+
+// scan iterates over the keys with prefix starting from the key start, until limit keys are accepted by f
+func (si *searchIndex) scan(tx *dbutil.Tx, prefix, start []byte, limit int, f func(k, v []byte) (bool, error)) error {
+	bkt := tx.Bucket(SearchIndexBkt)
+	if bkt == nil {
+		return dbutil.NewErrBucketNotExist(SearchIndexBkt)
+	}
+
+	c := bkt.Cursor()
+	n := 0
+	for k, v := c.Seek(start); k != nil && n < limit && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		ok, err := f(k, v)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		n++
+	}
+
+	return nil
+}
+
+// isEmpty checks if the searchIndex bucket is empty
+func (si *searchIndex) isEmpty(tx *dbutil.Tx) (bool, error) {
+	return dbutil.IsEmpty(tx, SearchIndexBkt)
+}
+
+// reset resets the bucket
+func (si *searchIndex) reset(tx *dbutil.Tx) error {
+	return dbutil.Reset(tx, SearchIndexBkt)
+}
+
+func searchKey(kind byte, data []byte) []byte {
+	k := make([]byte, 1+len(data))
+	k[0] = kind
+	copy(k[1:], data)
+	return k
+}
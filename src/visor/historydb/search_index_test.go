@@ -0,0 +1,189 @@
+package historydb
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+func TestSearchIndex(t *testing.T) {
+	db, td := prepareDB(t)
+	defer td()
+
+	hashes := []cipher.SHA256{
+		cipher.MustSHA256FromHex("ab00000000000000000000000000000000000000000000000000000000000000"),
+		cipher.MustSHA256FromHex("ab10000000000000000000000000000000000000000000000000000000000000"),
+		cipher.MustSHA256FromHex("ab1f000000000000000000000000000000000000000000000000000000000000"),
+		cipher.MustSHA256FromHex("ab20000000000000000000000000000000000000000000000000000000000000"),
+		cipher.MustSHA256FromHex("ac00000000000000000000000000000000000000000000000000000000000000"),
+	}
+
+	b := coin.Block{
+		Head: coin.BlockHeader{
+			BkSeq: 7,
+		},
+	}
+	blockHash := b.HashHeader()
+
+	addrs := []cipher.Address{makeAddress(), makeAddress(), makeAddress()}
+
+	si := &searchIndex{}
+	err := db.Update("", func(tx *dbutil.Tx) error {
+		for i, h := range hashes {
+			if err := si.putTxn(tx, h, uint64(i)); err != nil {
+				return err
+			}
+		}
+
+		if err := si.putBlock(tx, b.HashHeader(), b.Seq()); err != nil {
+			return err
+		}
+
+		for _, addr := range addrs {
+			// Addresses are added once for each output and input, adding them again has no effect
+			for i := 0; i < 2; i++ {
+				if err := si.putAddress(tx, addr); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	matchesOf := func(idx ...int) []HashMatch {
+		var m []HashMatch
+		for _, i := range idx {
+			m = append(m, HashMatch{
+				Hash:     hashes[i],
+				BlockSeq: uint64(i),
+			})
+		}
+		return m
+	}
+
+	cases := []struct {
+		name   string
+		prefix string
+		limit  int
+		expect []HashMatch
+		err    error
+	}{
+		{
+			name:   "empty prefix",
+			prefix: "",
+			limit:  10,
+			expect: matchesOf(0, 1, 2, 3, 4),
+		},
+		{
+			name:   "one char",
+			prefix: "a",
+			limit:  10,
+			expect: matchesOf(0, 1, 2, 3, 4),
+		},
+		{
+			name:   "even length",
+			prefix: "ab",
+			limit:  10,
+			expect: matchesOf(0, 1, 2, 3),
+		},
+		{
+			name:   "odd length",
+			prefix: "ab1",
+			limit:  10,
+			expect: matchesOf(1, 2),
+		},
+		{
+			name:   "upper case",
+			prefix: "AB1F",
+			limit:  10,
+			expect: matchesOf(2),
+		},
+		{
+			name:   "limit",
+			prefix: "ab",
+			limit:  2,
+			expect: matchesOf(0, 1),
+		},
+		{
+			name:   "full hash",
+			prefix: hashes[3].Hex(),
+			limit:  10,
+			expect: matchesOf(3),
+		},
+		{
+			name:   "no match",
+			prefix: "abc",
+			limit:  10,
+		},
+		{
+			name:   "not hex",
+			prefix: "abz",
+			limit:  10,
+			err:    ErrInvalidHexPrefix,
+		},
+		{
+			name:   "too long",
+			prefix: hashes[3].Hex() + "0",
+			limit:  10,
+			err:    ErrInvalidHexPrefix,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := db.View("", func(tx *dbutil.Tx) error {
+				matches, err := si.searchTxns(tx, tc.prefix, tc.limit)
+				require.Equal(t, tc.err, err)
+				require.Equal(t, tc.expect, matches)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+
+	err = db.View("", func(tx *dbutil.Tx) error {
+		// Transaction hashes and block hashes are searched separately
+		matches, err := si.searchBlocks(tx, blockHash.Hex()[:5], 10)
+		require.NoError(t, err)
+		require.Equal(t, []HashMatch{
+			{
+				Hash:     blockHash,
+				BlockSeq: 7,
+			},
+		}, matches)
+
+		matches, err = si.searchTxns(tx, blockHash.Hex(), 10)
+		require.NoError(t, err)
+		require.Empty(t, matches)
+
+		sort.Slice(addrs, func(i, j int) bool {
+			return addrs[i].String() < addrs[j].String()
+		})
+
+		found, err := si.searchAddresses(tx, "", 10)
+		require.NoError(t, err)
+		require.Equal(t, addrs, found)
+
+		found, err = si.searchAddresses(tx, "", 2)
+		require.NoError(t, err)
+		require.Equal(t, addrs[:2], found)
+
+		found, err = si.searchAddresses(tx, addrs[1].String()[:20], 10)
+		require.NoError(t, err)
+		require.Equal(t, addrs[1:2], found)
+
+		found, err = si.searchAddresses(tx, addrs[1].String()+"1", 10)
+		require.NoError(t, err)
+		require.Empty(t, found)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
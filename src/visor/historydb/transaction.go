@@ -6,6 +6,7 @@ package historydb
 // transaction hash, and get the tx value from transactions bucket.
 
 import (
+	"encoding/binary"
 	"errors"
 
 	"github.com/skycoin/skycoin/src/cipher"
@@ -13,8 +14,6 @@ import (
 	"github.com/skycoin/skycoin/src/visor/dbutil"
 )
 
-//go:generate skyencoder -unexported -struct Transaction
-
 // Transaction contains transaction info and the seq of block which executed this block.
 type Transaction struct {
 	Txn      coin.Transaction
@@ -43,6 +42,15 @@ func (txs *transactions) put(tx *dbutil.Tx, txn *Transaction) error {
 	return dbutil.PutBucketValue(tx, TransactionsBkt, hash[:], buf)
 }
 
+// putView puts the transaction of a transaction view in the db, with the same encoding as put
+func (txs *transactions) putView(tx *dbutil.Tx, hash cipher.SHA256, txn coin.TransactionView, seq uint64) error {
+	buf := make([]byte, len(txn.Bytes())+8)
+	n := copy(buf, txn.Bytes())
+	binary.LittleEndian.PutUint64(buf[n:], seq)
+
+	return dbutil.PutBucketValue(tx, TransactionsBkt, hash[:], buf)
+}
+
 // get gets transaction by transaction hash, return nil on not found
 func (txs *transactions) get(tx *dbutil.Tx, hash cipher.SHA256) (*Transaction, error) {
 	var txn Transaction
@@ -61,6 +69,11 @@ func (txs *transactions) get(tx *dbutil.Tx, hash cipher.SHA256) (*Transaction, e
 	return &txn, nil
 }
 
+// has returns true if the transaction of given hash is in the db
+func (txs *transactions) has(tx *dbutil.Tx, hash cipher.SHA256) (bool, error) {
+	return dbutil.BucketHasKey(tx, TransactionsBkt, hash[:])
+}
+
 // getArray returns transactions slice of given hashes
 func (txs *transactions) getArray(tx *dbutil.Tx, hashes []cipher.SHA256) ([]Transaction, error) {
 	txns := make([]Transaction, 0, len(hashes))
@@ -1,4 +1,5 @@
-// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT.
+
 package historydb
 
 import (
@@ -14,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
 )
 
 func newEmptyTransactionForEncodeTest() *Transaction {
@@ -151,7 +153,7 @@ func testSkyencoderTransaction(t *testing.T, obj *Transaction) {
 	}
 
 	if !bytes.Equal(data1, data2) {
-		t.Fatal("encoder.Serialize() != encode[1]s()")
+		t.Fatal("encoder.Serialize() != encodeTransaction()")
 	}
 
 	// Decode
@@ -418,3 +420,34 @@ func TestSkyencoderTransactionDecodeErrors(t *testing.T) {
 		testSkyencoderTransactionDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+func TestSkyencoderTransactionVectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("testdata/skyencoder-vectors.json", "Transaction")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj Transaction
+			if err := decodeTransactionExact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decodeTransactionExact failed: %v", err)
+			}
+
+			data, err := encodeTransaction(&obj)
+			if err != nil {
+				t.Fatalf("encodeTransaction failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encodeTransaction() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
@@ -168,6 +168,46 @@ func TestTransactionGetArray(t *testing.T) {
 	}
 }
 
+func TestTransactionPutView(t *testing.T) {
+	txn := makeTransaction(t)
+	txn.BlockSeq = 42
+	hash := txn.Hash()
+
+	v, err := coin.NewTransactionView(txn.Txn.MustSerialize())
+	require.NoError(t, err)
+
+	db, td := prepareDB(t)
+	defer td()
+
+	err = db.Update("", func(tx *dbutil.Tx) error {
+		txsBkt := &transactions{}
+
+		ok, err := txsBkt.has(tx, hash)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		require.NoError(t, txsBkt.put(tx, &txn))
+		expected, err := dbutil.GetBucketValue(tx, TransactionsBkt, hash[:])
+		require.NoError(t, err)
+
+		require.NoError(t, txsBkt.reset(tx))
+		require.NoError(t, txsBkt.putView(tx, hash, v, txn.BlockSeq))
+		data, err := dbutil.GetBucketValue(tx, TransactionsBkt, hash[:])
+		require.NoError(t, err)
+		require.Equal(t, expected, data)
+
+		ok, err = txsBkt.has(tx, hash)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		x, err := txsBkt.get(tx, hash)
+		require.NoError(t, err)
+		require.Equal(t, txn, *x)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func makeTransaction(t *testing.T) Transaction {
 	txn := Transaction{}
 	ux, s := makeUxOutWithSecret(t)
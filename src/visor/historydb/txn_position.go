@@ -0,0 +1,36 @@
+package historydb
+
+import (
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// TxnPositionsBkt maps the positions of transactions to their hashes
+var TxnPositionsBkt = []byte("txn_positions")
+
+// txnPositions bucket indexes transactions by position, block seq and transaction index as key, transaction hash as value
+type txnPositions struct{}
+
+// put adds a transaction to the index
+func (tp *txnPositions) put(tx *dbutil.Tx, pos Position, hash cipher.SHA256) error {
+	return dbutil.PutBucketValue(tx, TxnPositionsBkt, pos.txnKey(), hash[:])
+}
+
+// scan iterates over the transactions in the order of their positions, starting after the position after if not nil
+func (tp *txnPositions) scan(tx *dbutil.Tx, after *Position, reverse bool, f ScanFunc) error {
+	var afterKey []byte
+	if after != nil {
+		afterKey = after.txnKey()
+	}
+	return scanPositions(tx, TxnPositionsBkt, nil, afterKey, reverse, f)
+}
+
+// isEmpty checks if the txnPositions bucket is empty
+func (tp *txnPositions) isEmpty(tx *dbutil.Tx) (bool, error) {
+	return dbutil.IsEmpty(tx, TxnPositionsBkt)
+}
+
+// reset resets the bucket
+func (tp *txnPositions) reset(tx *dbutil.Tx) error {
+	return dbutil.Reset(tx, TxnPositionsBkt)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/visor/dbutil"
 )
 
@@ -14,8 +15,8 @@ var (
 	ErrVerifyStopped = errors.New("database verification stopped")
 )
 
-// VerifyDBSkyencoderSafe verifies that the skyencoder generated code has the same result as the encoder
-// for all data in the blockchain
+// VerifyDBSkyencoderSafe verifies that the skyencoder generated code and the coin.TransactionView decoder
+// have the same result as the encoder for all data in the blockchain
 func VerifyDBSkyencoderSafe(tx *dbutil.Tx, quit <-chan struct{}) error {
 	if quit == nil {
 		quit = make(chan struct{})
@@ -116,7 +117,14 @@ func VerifyDBSkyencoderSafe(tx *dbutil.Tx, quit <-chan struct{}) error {
 			return err
 		}
 
-		if !reflect.DeepEqual(b1, b2) {
+		// The transaction is followed by the block seq
+		view, rest, err := coin.ReadTransactionView(v)
+		if err != nil {
+			return err
+		}
+
+		if len(rest) != 8 || b1.BlockSeq != b2.BlockSeq ||
+			!view.Equal(&b1.Txn) || !view.Equal(&b2.Txn) {
 			return errors.New("TransactionsBkt ux out mismatch")
 		}
 
@@ -25,6 +25,13 @@ type Historyer interface {
 	Erase(tx *dbutil.Tx) error
 	ParsedBlockSeq(tx *dbutil.Tx) (uint64, bool, error)
 	ForEachTxn(tx *dbutil.Tx, f func(cipher.SHA256, *historydb.Transaction) error) error
+	ScanTransactions(tx *dbutil.Tx, after *historydb.Position, reverse bool, f historydb.ScanFunc) error
+	ScanAddressTransactions(tx *dbutil.Tx, addr cipher.Address, after *historydb.Position, reverse bool, f historydb.ScanFunc) error
+	ScanAddressOutputs(tx *dbutil.Tx, addr cipher.Address, after *historydb.Position, reverse bool, f historydb.ScanFunc) error
+	SearchTransactions(tx *dbutil.Tx, prefix string, limit int) ([]historydb.HashMatch, error)
+	SearchBlocks(tx *dbutil.Tx, prefix string, limit int) ([]historydb.HashMatch, error)
+	SearchAddresses(tx *dbutil.Tx, prefix string, limit int) ([]cipher.Address, error)
+	GetAddressBalancesAt(tx *dbutil.Tx, seq uint64) (map[cipher.Address]uint64, error)
 }
 
 // Blockchainer is the interface that provides methods for accessing the blockchain data
@@ -67,6 +67,29 @@ func (_m *MockHistoryer) ForEachTxn(tx *dbutil.Tx, f func(cipher.SHA256, *histor
 	return r0
 }
 
+// GetAddressBalancesAt provides a mock function with given fields: tx, seq
+func (_m *MockHistoryer) GetAddressBalancesAt(tx *dbutil.Tx, seq uint64) (map[cipher.Address]uint64, error) {
+	ret := _m.Called(tx, seq)
+
+	var r0 map[cipher.Address]uint64
+	if rf, ok := ret.Get(0).(func(*dbutil.Tx, uint64) map[cipher.Address]uint64); ok {
+		r0 = rf(tx, seq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[cipher.Address]uint64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*dbutil.Tx, uint64) error); ok {
+		r1 = rf(tx, seq)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOutputsForAddress provides a mock function with given fields: tx, address
 func (_m *MockHistoryer) GetOutputsForAddress(tx *dbutil.Tx, address cipher.Address) ([]historydb.UxOut, error) {
 	ret := _m.Called(tx, address)
@@ -221,3 +244,114 @@ func (_m *MockHistoryer) ParsedBlockSeq(tx *dbutil.Tx) (uint64, bool, error) {
 
 	return r0, r1, r2
 }
+
+// ScanAddressOutputs provides a mock function with given fields: tx, addr, after, reverse, f
+func (_m *MockHistoryer) ScanAddressOutputs(tx *dbutil.Tx, addr cipher.Address, after *historydb.Position, reverse bool, f historydb.ScanFunc) error {
+	ret := _m.Called(tx, addr, after, reverse, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*dbutil.Tx, cipher.Address, *historydb.Position, bool, historydb.ScanFunc) error); ok {
+		r0 = rf(tx, addr, after, reverse, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScanAddressTransactions provides a mock function with given fields: tx, addr, after, reverse, f
+func (_m *MockHistoryer) ScanAddressTransactions(tx *dbutil.Tx, addr cipher.Address, after *historydb.Position, reverse bool, f historydb.ScanFunc) error {
+	ret := _m.Called(tx, addr, after, reverse, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*dbutil.Tx, cipher.Address, *historydb.Position, bool, historydb.ScanFunc) error); ok {
+		r0 = rf(tx, addr, after, reverse, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScanTransactions provides a mock function with given fields: tx, after, reverse, f
+func (_m *MockHistoryer) ScanTransactions(tx *dbutil.Tx, after *historydb.Position, reverse bool, f historydb.ScanFunc) error {
+	ret := _m.Called(tx, after, reverse, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*dbutil.Tx, *historydb.Position, bool, historydb.ScanFunc) error); ok {
+		r0 = rf(tx, after, reverse, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchAddresses provides a mock function with given fields: tx, prefix, limit
+func (_m *MockHistoryer) SearchAddresses(tx *dbutil.Tx, prefix string, limit int) ([]cipher.Address, error) {
+	ret := _m.Called(tx, prefix, limit)
+
+	var r0 []cipher.Address
+	if rf, ok := ret.Get(0).(func(*dbutil.Tx, string, int) []cipher.Address); ok {
+		r0 = rf(tx, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cipher.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*dbutil.Tx, string, int) error); ok {
+		r1 = rf(tx, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchBlocks provides a mock function with given fields: tx, prefix, limit
+func (_m *MockHistoryer) SearchBlocks(tx *dbutil.Tx, prefix string, limit int) ([]historydb.HashMatch, error) {
+	ret := _m.Called(tx, prefix, limit)
+
+	var r0 []historydb.HashMatch
+	if rf, ok := ret.Get(0).(func(*dbutil.Tx, string, int) []historydb.HashMatch); ok {
+		r0 = rf(tx, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]historydb.HashMatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*dbutil.Tx, string, int) error); ok {
+		r1 = rf(tx, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchTransactions provides a mock function with given fields: tx, prefix, limit
+func (_m *MockHistoryer) SearchTransactions(tx *dbutil.Tx, prefix string, limit int) ([]historydb.HashMatch, error) {
+	ret := _m.Called(tx, prefix, limit)
+
+	var r0 []historydb.HashMatch
+	if rf, ok := ret.Get(0).(func(*dbutil.Tx, string, int) []historydb.HashMatch); ok {
+		r0 = rf(tx, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]historydb.HashMatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*dbutil.Tx, string, int) error); ok {
+		r1 = rf(tx, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
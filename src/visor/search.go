@@ -0,0 +1,127 @@
+package visor
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+	"github.com/skycoin/skycoin/src/visor/historydb"
+)
+
+// MaxSearchLimit is the maximum number of results of each kind returned by Search
+const MaxSearchLimit = 100
+
+var (
+	// ErrEmptySearchQuery is returned if the search query is empty
+	ErrEmptySearchQuery = errors.New("search query is empty")
+	// ErrInvalidSearchLimit is returned if the search limit is 0 or greater than MaxSearchLimit
+	ErrInvalidSearchLimit = errors.New("search limit must be between 1 and 100")
+)
+
+// base58Alphabet are the characters of base58 encoded addresses
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// SearchResult are the blocks, transactions and addresses matched by a search query.
+// Only confirmed transactions are searched.
+type SearchResult struct {
+	Blocks       []historydb.HashMatch
+	Transactions []historydb.HashMatch
+	Addresses    []cipher.Address
+}
+
+// Search finds the blocks, transactions and addresses matched by the query, up to limit of each kind.
+// A query of decimal digits matches the block with this seq.
+// A hexadecimal query matches the block hashes and transaction hashes starting with it.
+// A base58 query matches the addresses starting with it.
+// A query may match several kinds, for example "123" matches all of them.
+func (vs *Visor) Search(query string, limit int) (*SearchResult, error) {
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	if limit <= 0 || limit > MaxSearchLimit {
+		return nil, ErrInvalidSearchLimit
+	}
+
+	var result SearchResult
+	if err := vs.db.View("Search", func(tx *dbutil.Tx) error {
+		var err error
+		result, err = vs.search(tx, query, limit)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (vs *Visor) search(tx *dbutil.Tx, query string, limit int) (SearchResult, error) {
+	var result SearchResult
+
+	if seq, err := strconv.ParseUint(query, 10, 64); err == nil {
+		b, err := vs.blockchain.GetSignedBlockBySeq(tx, seq)
+		if err != nil {
+			return SearchResult{}, err
+		}
+
+		if b != nil {
+			result.Blocks = append(result.Blocks, historydb.HashMatch{
+				Hash:     b.HashHeader(),
+				BlockSeq: seq,
+			})
+		}
+	}
+
+	if isHex(query) && len(query) <= 2*len(cipher.SHA256{}) {
+		blocks, err := vs.history.SearchBlocks(tx, query, limit)
+		if err != nil {
+			return SearchResult{}, err
+		}
+
+		for _, b := range blocks {
+			if len(result.Blocks) == limit {
+				break
+			}
+			// Skip the block matched by seq
+			if len(result.Blocks) != 0 && result.Blocks[0] == b {
+				continue
+			}
+			result.Blocks = append(result.Blocks, b)
+		}
+
+		result.Transactions, err = vs.history.SearchTransactions(tx, query, limit)
+		if err != nil {
+			return SearchResult{}, err
+		}
+	}
+
+	if isBase58(query) {
+		var err error
+		result.Addresses, err = vs.history.SearchAddresses(tx, query, limit)
+		if err != nil {
+			return SearchResult{}, err
+		}
+	}
+
+	return result, nil
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isBase58(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune(base58Alphabet, c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package visor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/visor/historydb"
+)
+
+func TestSearch(t *testing.T) {
+	toAddr := testutil.MakeAddress()
+	v, shutdown := makeCursorTestVisor(t, toAddr)
+	defer shutdown()
+
+	b, err := v.GetSignedBlockBySeq(3)
+	require.NoError(t, err)
+	blockMatch := historydb.HashMatch{
+		Hash:     b.HashHeader(),
+		BlockSeq: 3,
+	}
+	txnMatch := historydb.HashMatch{
+		Hash:     b.Body.Transactions[1].Hash(),
+		BlockSeq: 3,
+	}
+
+	_, err = v.Search("", 10)
+	require.Equal(t, ErrEmptySearchQuery, err)
+
+	_, err = v.Search("abc", 0)
+	require.Equal(t, ErrInvalidSearchLimit, err)
+
+	_, err = v.Search("abc", MaxSearchLimit+1)
+	require.Equal(t, ErrInvalidSearchLimit, err)
+
+	// Block seq
+	r, err := v.Search("3", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, r.Blocks)
+	require.Equal(t, blockMatch, r.Blocks[0])
+
+	// Block seq after the head
+	r, err = v.Search("1000000", 10)
+	require.NoError(t, err)
+	require.Empty(t, r.Blocks)
+
+	// Block hash prefix
+	r, err = v.Search(blockMatch.Hash.Hex()[:9], 10)
+	require.NoError(t, err)
+	require.Contains(t, r.Blocks, blockMatch)
+
+	// Full transaction hash
+	r, err = v.Search(txnMatch.Hash.Hex(), 10)
+	require.NoError(t, err)
+	require.Equal(t, []historydb.HashMatch{txnMatch}, r.Transactions)
+	require.Empty(t, r.Blocks)
+	require.Empty(t, r.Addresses)
+
+	// Transaction hash prefix
+	r, err = v.Search(txnMatch.Hash.Hex()[:7], 10)
+	require.NoError(t, err)
+	require.Contains(t, r.Transactions, txnMatch)
+
+	// Address prefix
+	r, err = v.Search(toAddr.String()[:12], 10)
+	require.NoError(t, err)
+	require.Equal(t, []cipher.Address{toAddr}, r.Addresses)
+
+	r, err = v.Search(genAddress.String(), 10)
+	require.NoError(t, err)
+	require.Equal(t, []cipher.Address{genAddress}, r.Addresses)
+
+	// Neither hex, base58 nor a block seq
+	r, err = v.Search("0OIl", 10)
+	require.NoError(t, err)
+	require.Equal(t, &SearchResult{}, r)
+}
@@ -20,8 +20,7 @@ var (
 	errUpdateObjectDoesNotExist = errors.New("object does not exist in bucket")
 )
 
-//go:generate skyencoder -unexported -struct UnconfirmedTransaction
-//go:generate skyencoder -unexported -struct UxArray
+//go:generate go run github.com/skycoin/skycoin/cmd/skyencoder
 
 // UxArray wraps coin.UxArray
 type UxArray struct {
@@ -1,4 +1,5 @@
-// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+// Code generated by github.com/skycoin/skycoin/cmd/skyencoder. DO NOT EDIT.
+
 package visor
 
 import (
@@ -14,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skycoin/src/cipher/encoder/skyencoder"
 )
 
 func newEmptyUxArrayForEncodeTest() *UxArray {
@@ -151,7 +153,7 @@ func testSkyencoderUxArray(t *testing.T, obj *UxArray) {
 	}
 
 	if !bytes.Equal(data1, data2) {
-		t.Fatal("encoder.Serialize() != encode[1]s()")
+		t.Fatal("encoder.Serialize() != encodeUxArray()")
 	}
 
 	// Decode
@@ -418,3 +420,34 @@ func TestSkyencoderUxArrayDecodeErrors(t *testing.T) {
 		testSkyencoderUxArrayDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+func TestSkyencoderUxArrayVectors(t *testing.T) {
+	vectors, err := skyencoder.ReadVectors("testdata/skyencoder-vectors.json", "UxArray")
+	if err != nil {
+		t.Fatalf("skyencoder.ReadVectors failed: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("skyencoder.ReadVectors found no test vectors")
+	}
+
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("vector %d", i), func(t *testing.T) {
+			var obj UxArray
+			if err := decodeUxArrayExact(v.Encoded, &obj); err != nil {
+				t.Fatalf("decodeUxArrayExact failed: %v", err)
+			}
+
+			data, err := encodeUxArray(&obj)
+			if err != nil {
+				t.Fatalf("encodeUxArray failed: %v", err)
+			}
+			if !bytes.Equal(data, v.Encoded) {
+				t.Fatal("encodeUxArray() != test vector")
+			}
+
+			if !bytes.Equal(encoder.Serialize(&obj), v.Encoded) {
+				t.Fatal("encoder.Serialize() != test vector")
+			}
+		})
+	}
+}
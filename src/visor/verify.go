@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/util/fee"
@@ -72,6 +73,8 @@ var (
 	ErrTxnExceedsMaxBlockSize = errors.New("Transaction size bigger than max block size")
 	// ErrTxnIsLocked transaction has locked address inputs
 	ErrTxnIsLocked = errors.New("Transaction has locked address inputs")
+	// ErrSchnorrTxnNotActive Schnorr transactions are not active at the next block
+	ErrSchnorrTxnNotActive = errors.New("Schnorr transactions are not active")
 )
 
 // TxnSignedFlag indicates if the transaction is unsigned or not
@@ -204,8 +207,15 @@ func verifyTxnSoftConstraints(txn coin.Transaction, headTime uint64, uxIn coin.U
 //      * That there are no duplicate outputs
 //      * That the transaction input and output coins do not overflow uint64
 //      * That the transaction input and output hours do not overflow uint64
+//      * That the transaction type is active at the next block
 // NOTE: Double spends are checked against the unspent output pool when querying for uxIn
 func VerifySingleTxnHardConstraints(txn coin.Transaction, head coin.BlockHeader, uxIn coin.UxArray, signed TxnSignedFlag) error {
+	return verifySingleTxnHardConstraintsBatch(txn, head, uxIn, signed, nil)
+}
+
+// verifySingleTxnHardConstraintsBatch is VerifySingleTxnHardConstraints, with the input signatures
+// verified by a cipher.BatchVerifier
+func verifySingleTxnHardConstraintsBatch(txn coin.Transaction, head coin.BlockHeader, uxIn coin.UxArray, signed TxnSignedFlag, sigVerifier *cipher.BatchVerifier) error {
 	// Check for output hours overflow
 	// When verifying a single transaction, this is considered a hard constraint.
 	// For transactions inside of a block, it is a soft constraint.
@@ -223,7 +233,7 @@ func VerifySingleTxnHardConstraints(txn coin.Transaction, head coin.BlockHeader,
 		}
 	}
 
-	if err := verifyTxnHardConstraints(txn, head, uxIn, signed); err != nil {
+	if err := verifyTxnHardConstraints(txn, head, uxIn, signed, sigVerifier); err != nil {
 		return NewErrTxnViolatesHardConstraint(err)
 	}
 
@@ -242,18 +252,25 @@ func VerifySingleTxnHardConstraints(txn coin.Transaction, head coin.BlockHeader,
 //      * That there are no duplicate outputs
 //      * That the transaction input and output coins do not overflow uint64
 //      * That the transaction input hours do not overflow uint64
+//      * That the transaction type is active at the next block
 // NOTE: Double spends are checked against the unspent output pool when querying for uxIn
 // NOTE: output hours overflow is treated as a soft constraint for transactions inside of a block, due to a bug
 //       which allowed some blocks to be published with overflowing output hours.
 func VerifyBlockTxnConstraints(txn coin.Transaction, head coin.BlockHeader, uxIn coin.UxArray) error {
-	if err := verifyTxnHardConstraints(txn, head, uxIn, TxnSigned); err != nil {
+	return verifyBlockTxnConstraintsBatch(txn, head, uxIn, nil)
+}
+
+// verifyBlockTxnConstraintsBatch is VerifyBlockTxnConstraints, with the input signatures
+// verified by a cipher.BatchVerifier
+func verifyBlockTxnConstraintsBatch(txn coin.Transaction, head coin.BlockHeader, uxIn coin.UxArray, sigVerifier *cipher.BatchVerifier) error {
+	if err := verifyTxnHardConstraints(txn, head, uxIn, TxnSigned, sigVerifier); err != nil {
 		return NewErrTxnViolatesHardConstraint(err)
 	}
 
 	return nil
 }
 
-func verifyTxnHardConstraints(txn coin.Transaction, head coin.BlockHeader, uxIn coin.UxArray, signed TxnSignedFlag) error {
+func verifyTxnHardConstraints(txn coin.Transaction, head coin.BlockHeader, uxIn coin.UxArray, signed TxnSignedFlag, sigVerifier *cipher.BatchVerifier) error {
 	//CHECKLIST: DONE: check for duplicate ux inputs/double spending
 	//     NOTE: Double spends are checked against the unspent output pool when querying for uxIn
 
@@ -275,6 +292,14 @@ func verifyTxnHardConstraints(txn coin.Transaction, head coin.BlockHeader, uxIn
 	// Check for zero coin outputs
 	// Check valid looking signatures
 
+	// Schnorr transactions can only be included in blocks from params.SchnorrActivationSeq.
+	// The transaction is verified for the block after head, both when it is
+	// processed as part of that block and when it is injected to the unconfirmed pool
+	// or selected for a new block.
+	if txn.Type == coin.TransactionTypeSchnorr && !params.SchnorrActive(head.BkSeq+1) {
+		return ErrSchnorrTxnNotActive
+	}
+
 	switch signed {
 	case TxnSigned:
 		if err := txn.Verify(); err != nil {
@@ -282,7 +307,7 @@ func verifyTxnHardConstraints(txn coin.Transaction, head coin.BlockHeader, uxIn
 		}
 
 		// Check that signatures are allowed to spend inputs
-		if err := txn.VerifyInputSignatures(uxIn); err != nil {
+		if err := txn.VerifyInputSignaturesBatch(uxIn, sigVerifier); err != nil {
 			return err
 		}
 	case TxnUnsigned:
@@ -291,7 +316,7 @@ func verifyTxnHardConstraints(txn coin.Transaction, head coin.BlockHeader, uxIn
 		}
 
 		// Check that signatures are allowed to spend inputs for signatures that are not null
-		if err := txn.VerifyPartialInputSignatures(uxIn); err != nil {
+		if err := txn.VerifyPartialInputSignaturesBatch(uxIn, sigVerifier); err != nil {
 			return err
 		}
 	default:
@@ -71,14 +71,18 @@ func New(c Config, db *dbutil.DB, wltServ *wallet.Service) (*Visor, error) {
 	}
 
 	bc, err := NewBlockchain(db, BlockchainConfig{
-		Pubkey:      c.BlockchainPubkey,
-		Arbitrating: c.Arbitrating,
+		Pubkey:           c.BlockchainPubkey,
+		Arbitrating:      c.Arbitrating,
+		SigVerifyWorkers: c.SigVerifyWorkers,
+		SigCacheSize:     c.SigCacheSize,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	history := historydb.New()
+	history := historydb.NewWithConfig(historydb.Config{
+		BalanceSnapshotInterval: c.BalanceSnapshotInterval,
+	})
 
 	if !db.IsReadOnly() {
 		if err := db.Update("build unspent indexes and init history", func(tx *dbutil.Tx) error {
@@ -193,16 +197,16 @@ func parseHistoryTo(tx *dbutil.Tx, history *historydb.HistoryDB, bc *Blockchain,
 	}
 
 	for i := uint64(0); i < height-parsedBlockSeq; i++ {
-		b, err := bc.GetSignedBlockBySeq(tx, parsedBlockSeq+i+1)
+		b, ok, err := bc.GetBlockViewBySeq(tx, parsedBlockSeq+i+1)
 		if err != nil {
 			return err
 		}
 
-		if b == nil {
+		if !ok {
 			return fmt.Errorf("no block exists in depth: %d", parsedBlockSeq+i+1)
 		}
 
-		if err := history.ParseBlock(tx, b.Block); err != nil {
+		if err := history.ParseBlockView(tx, b); err != nil {
 			return err
 		}
 	}
@@ -1036,30 +1040,36 @@ func (vs *Visor) GetTransactionsWithInputs(flts []TxFilter, order SortOrder, pag
 			return err
 		}
 
-		inputs = make([][]TransactionInput, len(txns))
-		for i, txn := range txns {
-			feeCalcTime, err := vs.getFeeCalcTimeForTransaction(tx, txn)
-			if err != nil {
-				return err
-			}
-			if feeCalcTime == nil {
-				continue
-			}
+		inputs, err = vs.getTransactionsInputs(tx, txns)
+		return err
+	}); err != nil {
+		return nil, nil, 0, err
+	}
 
-			txnInputs, err := vs.getTransactionInputs(tx, *feeCalcTime, txn.Transaction.In)
-			if err != nil {
-				return err
-			}
+	return txns, inputs, pages, nil
+}
 
-			inputs[i] = txnInputs
+// getTransactionsInputs returns the verbose transaction input data of transactions
+func (vs *Visor) getTransactionsInputs(tx *dbutil.Tx, txns []Transaction) ([][]TransactionInput, error) {
+	inputs := make([][]TransactionInput, len(txns))
+	for i, txn := range txns {
+		feeCalcTime, err := vs.getFeeCalcTimeForTransaction(tx, txn)
+		if err != nil {
+			return nil, err
+		}
+		if feeCalcTime == nil {
+			continue
 		}
 
-		return nil
-	}); err != nil {
-		return nil, nil, 0, err
+		txnInputs, err := vs.getTransactionInputs(tx, *feeCalcTime, txn.Transaction.In)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs[i] = txnInputs
 	}
 
-	return txns, inputs, pages, nil
+	return inputs, nil
 }
 
 // AddressBalances computes the total balance for cipher.Addresses and their coin.UxOuts
@@ -1797,14 +1807,8 @@ func (vs Visor) GetBalanceOfAddresses(addrs []cipher.Address) ([]wallet.BalanceP
 		}
 
 		bp := wallet.BalancePair{
-			Confirmed: wallet.Balance{
-				Coins: coins,
-				Hours: coinHours,
-			},
-			Predicted: wallet.Balance{
-				Coins: pcoins,
-				Hours: pcoinHours,
-			},
+			Confirmed: wallet.NewBalance(coins, coinHours),
+			Predicted: wallet.NewBalance(pcoins, pcoinHours),
 		}
 
 		bps = append(bps, bp)
@@ -2144,14 +2148,29 @@ func (vs *Visor) GetUnspentOutputsSummary(filters []OutputsFilter) (*UnspentOutp
 
 // GetRichlist returns a Richlist
 func (vs *Visor) GetRichlist(includeDistribution bool) (Richlist, error) {
-	rbOuts, err := vs.GetUnspentOutputsSummary(nil)
+	var allAccounts map[cipher.Address]uint64
+
+	if err := vs.db.View("GetRichlist", func(tx *dbutil.Tx) error {
+		var err error
+		allAccounts, err = vs.getUnspentAddressCoins(tx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return vs.newRichlist(allAccounts, includeDistribution)
+}
+
+// getUnspentAddressCoins returns the total coins held by every address with outputs in the unspent pool
+func (vs *Visor) getUnspentAddressCoins(tx *dbutil.Tx) (map[cipher.Address]uint64, error) {
+	uxa, err := vs.blockchain.Unspent().GetAll(tx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Build a map from addresses to total coins held
 	allAccounts := map[cipher.Address]uint64{}
-	for _, out := range rbOuts.Confirmed {
+	for _, out := range uxa {
 		if _, ok := allAccounts[out.Body.Address]; ok {
 			var err error
 			allAccounts[out.Body.Address], err = mathutil.AddUint64(allAccounts[out.Body.Address], out.Body.Coins)
@@ -2163,6 +2182,12 @@ func (vs *Visor) GetRichlist(includeDistribution bool) (Richlist, error) {
 		}
 	}
 
+	return allAccounts, nil
+}
+
+// newRichlist creates a Richlist from the coins held by addresses, which excludes the
+// distribution addresses unless includeDistribution is true
+func (vs *Visor) newRichlist(allAccounts map[cipher.Address]uint64, includeDistribution bool) (Richlist, error) {
 	lockedAddrs := vs.Config.Distribution.LockedAddressesDecoded()
 	addrsMap := make(map[cipher.Address]struct{}, len(lockedAddrs))
 	for _, a := range lockedAddrs {
@@ -146,7 +146,7 @@ func TestErrMissingSignatureRecreateDB(t *testing.T) {
 		require.NoError(t, err)
 
 		// err = db.View("", func(tx *dbutil.Tx) error {
-		f := func(tx *dbutil.Tx, b *coin.SignedBlock) error {
+		f := func(tx *dbutil.Tx, b coin.SignedBlockView) error {
 			return bc.VerifySignature(b)
 		}
 
@@ -242,8 +242,8 @@ func TestHistorydbVerifier(t *testing.T) {
 			require.NoError(t, err)
 
 			indexesMap := historydb.NewIndexesMap()
-			f := func(tx *dbutil.Tx, b *coin.SignedBlock) error {
-				return history.Verify(tx, b, indexesMap)
+			f := func(tx *dbutil.Tx, b coin.SignedBlockView) error {
+				return history.Verify(tx, b.BlockView, indexesMap)
 			}
 
 			err = bc.WalkChain(2, f, nil)
@@ -2926,3 +2926,42 @@ func TestTransactionsFinder(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionsFinderPeekChangeAddress(t *testing.T) {
+	w, err := wallet.NewWallet("bip44.wlt", "label", "voyage say extend find sheriff surge priority merit ignore maple cash argue", wallet.Options{
+		Type:      wallet.WalletTypeBip44,
+		GenerateN: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = w.GenerateAddresses(1, wallet.OptionChange())
+	require.NoError(t, err)
+	changeAddrs, err := w.GetAddresses(wallet.OptionChange())
+	require.NoError(t, err)
+	require.Len(t, changeAddrs, 2)
+
+	db, shutdown := prepareDB(t)
+	defer shutdown()
+
+	unconfirmed := &MockUnconfirmedTransactionPoolerForEach{}
+	tf := &TransactionsFinder{
+		db:          db,
+		history:     &MockHistoryerAddressSeen{addrsMap: map[cipher.Address]struct{}{}},
+		unconfirmed: unconfirmed,
+	}
+
+	addr, err := w.(wallet.ChangeAddressPeeker).PeekChangeAddress(tf)
+	require.NoError(t, err)
+	require.Equal(t, changeAddrs[0], addr)
+
+	// The change address of a pending transaction is not reused
+	unconfirmed.txns = []UnconfirmedTransaction{{
+		Transaction: coin.Transaction{
+			Out: []coin.TransactionOutput{{Address: changeAddrs[0].(cipher.Address)}},
+		},
+	}}
+
+	addr, err = w.(wallet.ChangeAddressPeeker).PeekChangeAddress(tf)
+	require.NoError(t, err)
+	require.Equal(t, changeAddrs[1], addr)
+}
@@ -9,10 +9,8 @@ import (
 	"github.com/skycoin/skycoin/src/coin"
 	"github.com/skycoin/skycoin/src/params"
 	"github.com/skycoin/skycoin/src/transaction"
-	"github.com/skycoin/skycoin/src/util/mathutil"
 	"github.com/skycoin/skycoin/src/visor/dbutil"
 	"github.com/skycoin/skycoin/src/wallet"
-	"github.com/skycoin/skycoin/src/wallet/bip44wallet"
 )
 
 // UserError wraps user input-related errors.
@@ -82,21 +80,13 @@ func (vs *Visor) GetWalletBalance(wltID string) (wallet.BalancePair, wallet.Addr
 	for _, addrBalance := range addressBalances {
 		var err error
 		// compute confirmed balance
-		walletBalance.Confirmed.Coins, err = mathutil.AddUint64(walletBalance.Confirmed.Coins, addrBalance.Confirmed.Coins)
-		if err != nil {
-			return walletBalance, addressBalances, err
-		}
-		walletBalance.Confirmed.Hours, err = mathutil.AddUint64(walletBalance.Confirmed.Hours, addrBalance.Confirmed.Hours)
+		walletBalance.Confirmed, err = walletBalance.Confirmed.Add(addrBalance.Confirmed)
 		if err != nil {
 			return walletBalance, addressBalances, err
 		}
 
 		// compute predicted balance
-		walletBalance.Predicted.Coins, err = mathutil.AddUint64(walletBalance.Predicted.Coins, addrBalance.Predicted.Coins)
-		if err != nil {
-			return walletBalance, addressBalances, err
-		}
-		walletBalance.Predicted.Hours, err = mathutil.AddUint64(walletBalance.Predicted.Hours, addrBalance.Predicted.Hours)
+		walletBalance.Predicted, err = walletBalance.Predicted.Add(addrBalance.Predicted)
 		if err != nil {
 			return walletBalance, addressBalances, err
 		}
@@ -276,29 +266,26 @@ func (vs *Visor) WalletCreateTransactionSigned(wltID string, password []byte, p
 		return nil, nil, err
 	}
 
-	if p.ChangeAddress == nil && w.Type() == wallet.WalletTypeBip44 {
-		// TODO: Maybe add the `PeekChangeAddress` to wallet.Wallet interface, and
-		// only bip44 wallet will implement it, all others do nothing. In this way
-		// we don't have to explicitly check the wallet type here.
-		//
-		// For bip44 wallet, peek a change address if p.ChangeAddress is nill
+	if _, ok := w.(wallet.ChangeAddressPeeker); ok && p.ChangeAddress == nil {
+		// Peek a fresh change address if p.ChangeAddress is nil, the wallet
+		// is updated because a new change address might be generated
 		if err := vs.wallets.Update(wltID, func(w wallet.Wallet) error {
-			addr, err := w.(*bip44wallet.Wallet).PeekChangeAddress(vs.tf)
-			if err != nil {
-				logger.Critical().WithError(err).Error("PeekChangeAddress failed")
-				return err
-			}
-			skyAddr := addr.(cipher.Address)
-			p.ChangeAddress = &skyAddr
-			return nil
+			var err error
+			p.ChangeAddress, err = vs.peekChangeAddress(w)
+			return err
 		}); err != nil {
 			return nil, nil, err
 		}
 	}
 
+	uxOutsMeta, err := vs.wallets.GetUxOutsMeta(wltID)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if err := vs.wallets.ViewSecrets(wltID, password, func(w wallet.Wallet) error {
 		var err error
-		txn, inputs, err = vs.walletCreateTransaction("WalletCreateTransactionSigned", w, p, wp, TxnSigned)
+		txn, inputs, err = vs.walletCreateTransaction("WalletCreateTransactionSigned", w, p, wp, uxOutsMeta, TxnSigned)
 		return err
 	}); err != nil {
 		return nil, nil, err
@@ -307,6 +294,59 @@ func (vs *Visor) WalletCreateTransactionSigned(wltID string, password []byte, p
 	return txn, inputs, nil
 }
 
+// UpdateWalletUxOutsMeta applies f to the coin control metadata of the given unspent outputs of a wallet.
+// The outputs must be unspent outputs owned by the wallet, confirmed or created by an unconfirmed transaction.
+// The metadata of outputs that have been spent is pruned.
+func (vs *Visor) UpdateWalletUxOutsMeta(wltID string, hashes []cipher.SHA256, f func(*wallet.UxOutMeta)) (wallet.UxOutsMeta, error) {
+	var addrs []cipher.Address
+	if err := vs.wallets.View(wltID, func(w wallet.Wallet) error {
+		a, err := w.GetAddresses()
+		if err != nil {
+			return err
+		}
+		addrs = wallet.SkycoinAddresses(a)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	unspents := make(map[cipher.SHA256]struct{})
+	if err := vs.db.View("UpdateWalletUxOutsMeta", func(tx *dbutil.Tx) error {
+		addrHashes, err := vs.blockchain.Unspent().GetUnspentHashesOfAddrs(tx, addrs)
+		if err != nil {
+			return err
+		}
+
+		for _, hashes := range addrHashes {
+			for _, h := range hashes {
+				unspents[h] = struct{}{}
+			}
+		}
+
+		incoming, err := vs.unconfirmedIncomingOutputs(tx)
+		if err != nil {
+			return err
+		}
+
+		addrsMap := make(map[cipher.Address]struct{}, len(addrs))
+		for _, a := range addrs {
+			addrsMap[a] = struct{}{}
+		}
+
+		for _, ux := range incoming {
+			if _, ok := addrsMap[ux.Body.Address]; ok {
+				unspents[ux.Hash()] = struct{}{}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return vs.wallets.UpdateUxOutsMeta(wltID, unspents, hashes, f)
+}
+
 // WalletCreateTransaction creates a transaction based upon the parameters in CreateTransactionParams
 // TODO: Only referenced by tests, vs.walletCreateTransaction
 func (vs *Visor) WalletCreateTransaction(wltID string, p transaction.Params, wp CreateTransactionParams) (*coin.Transaction, []TransactionInput, error) {
@@ -321,24 +361,23 @@ func (vs *Visor) WalletCreateTransaction(wltID string, p transaction.Params, wp
 	var txn *coin.Transaction
 	var inputs []TransactionInput
 
+	// The metadata must be read before opening the wallet, which holds the wallet service lock
+	uxOutsMeta, err := vs.wallets.GetUxOutsMeta(wltID)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if err := vs.wallets.Update(wltID, func(w wallet.Wallet) error {
-		if p.ChangeAddress == nil && w.Type() == wallet.WalletTypeBip44 {
-			// TODO: Maybe add the `PeekChangeAddress` to wallet.Wallet interface, and
-			// only bip44 wallet will implement it, all others do nothing. In this way
-			// we don't have to explicitly check the wallet type here.
-			//
-			// For bip44 wallet, peek a change address if p.ChangeAddress is nill
-			addr, err := w.(*bip44wallet.Wallet).PeekChangeAddress(vs.tf)
+		if _, ok := w.(wallet.ChangeAddressPeeker); ok && p.ChangeAddress == nil {
+			var err error
+			p.ChangeAddress, err = vs.peekChangeAddress(w)
 			if err != nil {
-				logger.Critical().WithError(err).Error("PeekChangeAddress failed")
 				return err
 			}
-			skyAddr := addr.(cipher.Address)
-			p.ChangeAddress = &skyAddr
 		}
 
 		var err error
-		txn, inputs, err = vs.walletCreateTransaction("WalletCreateTransaction", w, p, wp, TxnUnsigned)
+		txn, inputs, err = vs.walletCreateTransaction("WalletCreateTransaction", w, p, wp, uxOutsMeta, TxnUnsigned)
 		return err
 	}); err != nil {
 		return nil, nil, err
@@ -347,7 +386,23 @@ func (vs *Visor) WalletCreateTransaction(wltID string, p transaction.Params, wp
 	return txn, inputs, nil
 }
 
-func (vs *Visor) walletCreateTransaction(methodName string, w wallet.Wallet, p transaction.Params, wp CreateTransactionParams, signed TxnSignedFlag) (*coin.Transaction, []TransactionInput, error) {
+// peekChangeAddress returns a change address that has never received coins from
+// the change chain of a wallet that implements wallet.ChangeAddressPeeker
+func (vs *Visor) peekChangeAddress(w wallet.Wallet) (*cipher.Address, error) {
+	addr, err := w.(wallet.ChangeAddressPeeker).PeekChangeAddress(vs.tf)
+	if err != nil {
+		logger.Critical().WithError(err).Error("PeekChangeAddress failed")
+		return nil, err
+	}
+
+	skyAddr, ok := addr.(cipher.Address)
+	if !ok {
+		return nil, errors.New("change address is not a skycoin address")
+	}
+	return &skyAddr, nil
+}
+
+func (vs *Visor) walletCreateTransaction(methodName string, w wallet.Wallet, p transaction.Params, wp CreateTransactionParams, uxOutsMeta wallet.UxOutsMeta, signed TxnSignedFlag) (*coin.Transaction, []TransactionInput, error) {
 	if err := p.Validate(); err != nil {
 		return nil, nil, err
 	}
@@ -390,7 +445,7 @@ func (vs *Visor) walletCreateTransaction(methodName string, w wallet.Wallet, p t
 
 	if err := vs.db.View(methodName, func(tx *dbutil.Tx) error {
 		var err error
-		txn, uxb, err = vs.walletCreateTransactionTx(tx, methodName, w, p, wp, signed, addrs, walletAddressesMap)
+		txn, uxb, err = vs.walletCreateTransactionTx(tx, methodName, w, p, wp, uxOutsMeta, signed, addrs, walletAddressesMap)
 		return err
 	}); err != nil {
 		return nil, nil, err
@@ -402,7 +457,7 @@ func (vs *Visor) walletCreateTransaction(methodName string, w wallet.Wallet, p t
 }
 
 func (vs *Visor) walletCreateTransactionTx(tx *dbutil.Tx, methodName string,
-	w wallet.Wallet, p transaction.Params, wp CreateTransactionParams, signed TxnSignedFlag,
+	w wallet.Wallet, p transaction.Params, wp CreateTransactionParams, uxOutsMeta wallet.UxOutsMeta, signed TxnSignedFlag,
 	addrs []cipher.Address, walletAddressesMap map[cipher.Address]struct{}) (*coin.Transaction, []transaction.UxBalance, error) {
 	// Note: assumes inputs have already been validated by walletCreateTransaction
 
@@ -427,6 +482,9 @@ func (vs *Visor) walletCreateTransactionTx(tx *dbutil.Tx, methodName string,
 				return nil, nil, wallet.ErrUnknownUxOut
 			}
 		}
+
+		// Frozen outputs can be spent by requesting them explicitly
+		uxOutsMeta = nil
 	} else {
 		var err error
 		auxs, err = vs.getCreateTransactionAuxsAddress(tx, addrs, wp.IgnoreUnconfirmed)
@@ -441,9 +499,9 @@ func (vs *Visor) walletCreateTransactionTx(tx *dbutil.Tx, methodName string,
 
 	switch signed {
 	case TxnSigned:
-		txn, uxb, err = wallet.CreateTransactionSigned(w, p, auxs, head.Time())
+		txn, uxb, err = wallet.CreateTransactionSigned(w, p, auxs, head.Time(), uxOutsMeta)
 	case TxnUnsigned:
-		txn, uxb, err = wallet.CreateTransaction(w, p, auxs, head.Time())
+		txn, uxb, err = wallet.CreateTransaction(w, p, auxs, head.Time(), uxOutsMeta)
 	default:
 		logger.Panic("Invalid TxnSignedFlag")
 	}
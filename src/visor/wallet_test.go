@@ -21,8 +21,10 @@ import (
 	"github.com/skycoin/skycoin/src/visor/blockdb"
 	"github.com/skycoin/skycoin/src/visor/dbutil"
 	"github.com/skycoin/skycoin/src/wallet"
+	_ "github.com/skycoin/skycoin/src/wallet/bip44wallet"
 	"github.com/skycoin/skycoin/src/wallet/collection"
 	"github.com/skycoin/skycoin/src/wallet/crypto"
+	_ "github.com/skycoin/skycoin/src/wallet/deterministic"
 )
 
 func TestCreateTransaction(t *testing.T) {
@@ -1651,3 +1653,62 @@ func (mb mockTxnsFinder) AddressesActivity(addrs []cipher.Addresser) ([]bool, er
 	}
 	return active, nil
 }
+
+func TestUpdateWalletUxOutsMeta(t *testing.T) {
+	ws, err := wallet.NewService(wallet.Config{
+		EnableWalletAPI: true,
+		CryptoType:      crypto.CryptoTypeScryptChacha20poly1305Insecure,
+		WalletDir:       prepareWltDir(),
+	})
+	require.NoError(t, err)
+
+	w, err := ws.CreateWallet("t.wlt", wallet.Options{
+		Coin: wallet.CoinTypeSkycoin,
+		Type: wallet.WalletTypeDeterministic,
+		Seed: "seed",
+	})
+	require.NoError(t, err)
+
+	addrs, err := w.GetAddresses()
+	require.NoError(t, err)
+	toAddr := addrs[0].(cipher.Address)
+
+	v, shutdown := makeCursorTestVisor(t, toAddr)
+	defer shutdown()
+	v.wallets = ws
+
+	var owned, notOwned blockdb.AddressHashes
+	err = v.db.View("", func(tx *dbutil.Tx) error {
+		var err error
+		owned, err = v.blockchain.Unspent().GetUnspentHashesOfAddrs(tx, []cipher.Address{toAddr})
+		if err != nil {
+			return err
+		}
+		notOwned, err = v.blockchain.Unspent().GetUnspentHashesOfAddrs(tx, []cipher.Address{genAddress})
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, owned[toAddr], 6)
+	require.NotEmpty(t, notOwned[genAddress])
+
+	freeze := func(m *wallet.UxOutMeta) {
+		m.Frozen = true
+	}
+
+	// Outputs that are not unspent outputs of the wallet are rejected
+	_, err = v.UpdateWalletUxOutsMeta("t.wlt", []cipher.SHA256{testutil.RandSHA256(t)}, freeze)
+	require.Equal(t, wallet.ErrUnknownUxOut, err)
+
+	_, err = v.UpdateWalletUxOutsMeta("t.wlt", notOwned[genAddress][:1], freeze)
+	require.Equal(t, wallet.ErrUnknownUxOut, err)
+
+	_, err = v.UpdateWalletUxOutsMeta("unknown.wlt", owned[toAddr][:1], freeze)
+	require.Equal(t, wallet.ErrWalletNotExist, err)
+
+	m, err := v.UpdateWalletUxOutsMeta("t.wlt", owned[toAddr][:2], freeze)
+	require.NoError(t, err)
+	require.Equal(t, wallet.UxOutsMeta{
+		owned[toAddr][0]: {Frozen: true},
+		owned[toAddr][1]: {Frozen: true},
+	}, m)
+}
@@ -2,7 +2,7 @@ package wallet
 
 import (
 	"github.com/skycoin/skycoin/src/coin"
-	"github.com/skycoin/skycoin/src/util/mathutil"
+	"github.com/skycoin/skycoin/src/util/amount"
 )
 
 /*
@@ -21,15 +21,15 @@ type AddressBalances map[string]BalancePair
 
 // Balance has coins and hours
 type Balance struct {
-	Coins uint64
-	Hours uint64
+	Coins amount.Amount
+	Hours amount.Hours
 }
 
 // NewBalance creates balance
 func NewBalance(coins, hours uint64) Balance {
 	return Balance{
-		Coins: coins,
-		Hours: hours,
+		Coins: amount.Amount(coins),
+		Hours: amount.Hours(hours),
 	}
 }
 
@@ -41,19 +41,19 @@ func NewBalanceFromUxOut(headTime uint64, ux *coin.UxOut) (Balance, error) {
 	}
 
 	return Balance{
-		Coins: ux.Body.Coins,
-		Hours: hours,
+		Coins: amount.Amount(ux.Body.Coins),
+		Hours: amount.Hours(hours),
 	}, nil
 }
 
 // Add adds two Balances
 func (bal Balance) Add(other Balance) (Balance, error) {
-	coins, err := mathutil.AddUint64(bal.Coins, other.Coins)
+	coins, err := bal.Coins.Add(other.Coins)
 	if err != nil {
 		return Balance{}, err
 	}
 
-	hours, err := mathutil.AddUint64(bal.Hours, other.Hours)
+	hours, err := bal.Hours.Add(other.Hours)
 	if err != nil {
 		return Balance{}, err
 	}
@@ -67,12 +67,17 @@ func (bal Balance) Add(other Balance) (Balance, error) {
 // Sub subtracts other from self and returns the new Balance.  Will panic if
 // other is greater than balance, because Coins and Hours are unsigned.
 func (bal Balance) Sub(other Balance) Balance {
-	if other.Coins > bal.Coins || other.Hours > bal.Hours {
+	coins, err := bal.Coins.Sub(other.Coins)
+	if err != nil {
+		logger.Panic("Cannot subtract balances, second balance is too large")
+	}
+	hours, err := bal.Hours.Sub(other.Hours)
+	if err != nil {
 		logger.Panic("Cannot subtract balances, second balance is too large")
 	}
 	return Balance{
-		Coins: bal.Coins - other.Coins,
-		Hours: bal.Hours - other.Hours,
+		Coins: coins,
+		Hours: hours,
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/skycoin/skycoin/src/cipher/bip32"
 	"github.com/skycoin/skycoin/src/cipher/bip39"
 	"github.com/skycoin/skycoin/src/cipher/bip44"
+	"github.com/skycoin/skycoin/src/cipher/descriptor"
 	"github.com/skycoin/skycoin/src/util/mathutil"
 	"github.com/skycoin/skycoin/src/wallet"
 )
@@ -23,6 +24,9 @@ type bip44Account struct {
 	Index    uint32          // Account index
 	CoinType wallet.CoinType // Account coin type, determins the way to generate addresses
 	Chains   []bip44Chain    // Chains, external chain with index value of 0, and internal(change) chain with index value of 1.
+	// Descriptor is the output descriptor of the account, it is empty for accounts created before
+	// descriptors were recorded
+	Descriptor string
 }
 
 type bip44AccountCreateOptions struct {
@@ -32,6 +36,9 @@ type bip44AccountCreateOptions struct {
 	seedPassphrase string
 	coinType       wallet.CoinType
 	bip44CoinType  *bip44.CoinType
+	// descriptor is the descriptor of the account key, if the account is not
+	// the bip44 account m/44'/coin_type'/index'
+	descriptor *descriptor.Descriptor
 }
 
 func newBip44Account(opts bip44AccountCreateOptions) (*bip44Account, error) {
@@ -45,18 +52,32 @@ func newBip44Account(opts bip44AccountCreateOptions) (*bip44Account, error) {
 		return nil, errors.New("newBip44Account missing bip44 coin type")
 	}
 
-	c, err := bip44.NewCoin(seed, *opts.bip44CoinType)
+	master, err := bip32.NewMasterKey(seed)
 	if err != nil {
-		logger.Critical().WithError(err).Error("Failed to derive the bip44 purpose node")
-		if bip32.IsImpossibleChildError(err) {
-			logger.Critical().Error("ImpossibleChild: this seed cannot be used for bip44")
-		}
 		return nil, err
 	}
-	a, err := c.Account(opts.index)
+
+	d := opts.descriptor
+	if d == nil {
+		d, err = descriptor.NewBip44(master, *opts.bip44CoinType, opts.index)
+		if err != nil {
+			logger.Critical().WithError(err).Error("Failed to derive the bip44 account node")
+			if bip32.IsImpossibleChildError(err) {
+				logger.Critical().Error("ImpossibleChild: this seed cannot be used for bip44")
+			}
+			return nil, err
+		}
+	}
+
+	key, err := d.DeriveKey(master)
 	if err != nil {
+		if opts.descriptor != nil {
+			// The descriptor was not derived from the seed
+			return nil, wallet.NewError(err)
+		}
 		return nil, err
 	}
+	a := &bip44.Account{PrivateKey: key}
 
 	externalChainKey, changeChainKey, err := makeChainPubKeys(a)
 	if err != nil {
@@ -64,10 +85,11 @@ func newBip44Account(opts bip44AccountCreateOptions) (*bip44Account, error) {
 	}
 
 	ba := &bip44Account{
-		Account:  *a,
-		Name:     opts.name,
-		Index:    opts.index,
-		CoinType: opts.coinType,
+		Account:    *a,
+		Name:       opts.name,
+		Index:      opts.index,
+		CoinType:   opts.coinType,
+		Descriptor: d.String(),
 	}
 
 	// init the external chain
@@ -243,10 +265,11 @@ func (a bip44Account) getEntry(address cipher.Addresser) (wallet.Entry, bool) {
 // call it mistakenly.
 func (a bip44Account) Clone() bip44Account {
 	na := bip44Account{
-		Account:  a.Account.Clone(),
-		Name:     a.Name,
-		Index:    a.Index,
-		CoinType: a.CoinType,
+		Account:    a.Account.Clone(),
+		Name:       a.Name,
+		Index:      a.Index,
+		CoinType:   a.CoinType,
+		Descriptor: a.Descriptor,
 	}
 
 	na.Chains = make([]bip44Chain, len(a.Chains))
@@ -82,9 +82,10 @@ func (ras readableBip44Accounts) toBip44Accounts(d wallet.AddressSecKeyDecoder)
 	as := bip44Accounts{}
 	for _, ra := range ras {
 		a := bip44Account{
-			Name:     ra.Name,
-			Index:    ra.Index,
-			CoinType: wallet.CoinType(ra.CoinType),
+			Name:       ra.Name,
+			Index:      ra.Index,
+			CoinType:   wallet.CoinType(ra.CoinType),
+			Descriptor: ra.Descriptor,
 		}
 
 		// decode private key if not empty
@@ -113,10 +114,11 @@ func (ras readableBip44Accounts) toBip44Accounts(d wallet.AddressSecKeyDecoder)
 // ReadableBip44Account is the JSON representation of account
 type readableBip44Account struct {
 	PrivateKey string               `json:"private_key,omitempty"`
-	Name       string               `json:"name"`      // Account name
-	Index      uint32               `json:"index"`     // Account index
-	CoinType   string               `json:"coin_type"` // Account coin type, determins the way to generate addresses
-	Chains     []readableBip44Chain `json:"chains"`    // Chains, external chain with index value of 0, and internal(change) chain with index value of 1.
+	Name       string               `json:"name"`                 // Account name
+	Index      uint32               `json:"index"`                // Account index
+	CoinType   string               `json:"coin_type"`            // Account coin type, determins the way to generate addresses
+	Chains     []readableBip44Chain `json:"chains"`               // Chains, external chain with index value of 0, and internal(change) chain with index value of 1.
+	Descriptor string               `json:"descriptor,omitempty"` // Output descriptor of the account
 }
 
 // ReadableBip44Chain bip44 chain with JSON tags
@@ -230,6 +232,7 @@ func newReadableBip44Accounts(as *bip44Accounts) (*readableBip44Accounts, error)
 			Index:      a.Index,
 			CoinType:   string(a.CoinType),
 			Chains:     rc,
+			Descriptor: a.Descriptor,
 		})
 	}
 
@@ -17,6 +17,7 @@ import (
 	"github.com/skycoin/skycoin/src/cipher/bip32"
 	"github.com/skycoin/skycoin/src/cipher/bip39"
 	"github.com/skycoin/skycoin/src/cipher/bip44"
+	"github.com/skycoin/skycoin/src/cipher/descriptor"
 )
 
 const (
@@ -29,6 +30,11 @@ const (
 var (
 	// defaultWalletDecoder is the default bip44 wallet decoder
 	defaultWalletDecoder = &JSONDecoder{}
+
+	// ErrDescriptorChains is returned if the descriptor of a bip44 wallet does not derive the external and change chains
+	ErrDescriptorChains = wallet.NewError(errors.New("descriptor of a bip44 wallet must derive the external and change chains with /<0;1>/*"))
+	// ErrDescriptorSingleAccount is returned when creating an account in a wallet created from a descriptor with a custom derivation path
+	ErrDescriptorSingleAccount = wallet.NewError(errors.New("wallet created from a descriptor with a custom derivation path can only have one account"))
 )
 
 var logger = logging.MustGetLogger("bip44wallet")
@@ -94,7 +100,12 @@ type ChainEntry struct {
 }
 
 // NewWallet create a bip44 wallet with options
-// also, a default address will be generated
+// also, a default address will be generated.
+//
+// The default account is the bip44 account m/44'/coin_type'/0', unless an output descriptor
+// of the account is set with wallet.OptionDescriptor. The descriptor must have the key origin
+// of an account key derived from the seed, e.g. pkh([fingerprint/84'/0'/2']xpub.../<0;1>/*).
+// If its path is a bip44 account path, its coin type is the bip44 coin type of the wallet.
 func NewWallet(filename, label, seed, seedPassphrase string, options ...wallet.Option) (*Wallet, error) {
 	wlt := &Wallet{
 		Meta: wallet.Meta{
@@ -120,6 +131,19 @@ func NewWallet(filename, label, seed, seedPassphrase string, options ...wallet.O
 		opt(&advOpts)
 	}
 
+	var desc *descriptor.Descriptor
+	if advOpts.Descriptor != "" {
+		var err error
+		desc, err = parseDescriptor(advOpts.Descriptor)
+		if err != nil {
+			return nil, err
+		}
+
+		if coinType, _, ok := desc.Bip44Account(); ok {
+			wlt.SetBip44Coin(coinType)
+		}
+	}
+
 	if wlt.Bip44Coin() == nil {
 		switch wlt.Coin() {
 		case wallet.CoinTypeSkycoin:
@@ -142,8 +166,10 @@ func NewWallet(filename, label, seed, seedPassphrase string, options ...wallet.O
 		accountName = advOpts.DefaultBip44AccountName
 	}
 
-	_, err := wlt.NewAccount(accountName)
-	if err != nil {
+	if _, err := wlt.newAccount(accountName, desc); err != nil {
+		if _, ok := err.(wallet.Error); ok {
+			return nil, err
+		}
 		return nil, fmt.Errorf("generate default account failed: %v", err)
 	}
 
@@ -197,6 +223,21 @@ func NewWallet(filename, label, seed, seedPassphrase string, options ...wallet.O
 	return wlt, nil
 }
 
+// parseDescriptor parses the output descriptor of an account
+func parseDescriptor(s string) (*descriptor.Descriptor, error) {
+	d, err := descriptor.Parse(s)
+	if err != nil {
+		return nil, wallet.NewError(err)
+	}
+
+	if len(d.Path) != 0 || len(d.Chains) != 2 ||
+		d.Chains[0] != bip44.ExternalChainIndex || d.Chains[1] != bip44.ChangeChainIndex {
+		return nil, ErrDescriptorChains
+	}
+
+	return d, nil
+}
+
 func validateMeta(m wallet.Meta) error {
 	if m[wallet.MetaType] != WalletType {
 		return wallet.ErrInvalidWalletType
@@ -232,16 +273,68 @@ func (w *Wallet) SetDecoder(d wallet.Decoder) {
 
 // NewAccount create a bip44 wallet account, returns account index and
 // error, if any.
+// Returns ErrDescriptorSingleAccount if the wallet was created from a descriptor
+// whose path is not the bip44 path of the first account.
 func (w *Wallet) NewAccount(name string) (uint32, error) {
+	if w.accountManager.len() > 0 {
+		a, err := w.accountManager.account(0)
+		if err != nil {
+			return 0, err
+		}
+
+		if !w.isBip44Descriptor(a.Descriptor) {
+			return 0, ErrDescriptorSingleAccount
+		}
+	}
+
+	return w.newAccount(name, nil)
+}
+
+// newAccount creates an account, which is the next bip44 account if desc is nil
+func (w *Wallet) newAccount(name string, desc *descriptor.Descriptor) (uint32, error) {
 	return w.accountManager.new(bip44AccountCreateOptions{
 		name:           name,
 		seed:           w.Seed(),
 		seedPassphrase: w.SeedPassphrase(),
 		coinType:       w.Coin(),
 		bip44CoinType:  w.Bip44Coin(),
+		descriptor:     desc,
 	})
 }
 
+// isBip44Descriptor returns true if the descriptor of the first account is the descriptor of
+// the first bip44 account of the wallet's bip44 coin type. Accounts without descriptors
+// are bip44 accounts.
+func (w *Wallet) isBip44Descriptor(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	d, err := descriptor.Parse(s)
+	if err != nil {
+		return false
+	}
+
+	coinType, account, ok := d.Bip44Account()
+	bip44Coin := w.Bip44Coin()
+	return ok && account == 0 && bip44Coin != nil && coinType == *bip44Coin
+}
+
+// Descriptor returns the output descriptor of the default account, or an empty string
+// if the wallet was created before descriptors were recorded
+func (w Wallet) Descriptor() string {
+	if w.accountManager == nil || w.accountManager.len() == 0 {
+		return ""
+	}
+
+	a, err := w.accountManager.account(0)
+	if err != nil {
+		return ""
+	}
+
+	return a.Descriptor
+}
+
 // newExternalAddresses generates addresses on external chain of selected account
 func (w *Wallet) newExternalAddresses(account, n uint32) ([]cipher.Addresser, error) {
 	return w.newAddresses(account, bip44.ExternalChainIndex, n)
@@ -769,42 +862,44 @@ func (w *Wallet) reset() {
 	w.accountManager.reset()
 }
 
-// PeekChangeAddress returns the last entry address on change chain if
-// no transactions are found, otherwise, returns with a new address.
+// PeekChangeAddress returns the first address on the change chain that comes after
+// the last address with transactions, so that change is never sent to an address
+// which has been used before. The transactions of the unconfirmed pool are included,
+// so that the change address of a pending transaction is not reused.
+// A new address is generated if there is no such address.
+// The address discovery process of wallet.Service keeps unused addresses ahead on the
+// change chain, so usually no address needs to be generated.
 func (w *Wallet) PeekChangeAddress(tf wallet.TransactionsFinder) (cipher.Addresser, error) {
 	onChangeChain := wallet.OptionChange()
-	entries, err := w.GetEntries(onChangeChain)
+	addrs, err := w.GetAddresses(onChangeChain)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(entries) == 0 {
-		// generate a new address and return
-		addrs, err := w.GenerateAddresses(1, onChangeChain)
-		if err != nil {
-			return nil, err
-		}
-
-		return addrs[0], nil
-	}
-
-	// checks if the last entry address has transactions
-	addr := entries[len(entries)-1].Address
-	oks, err := tf.AddressesActivity([]cipher.Addresser{addr})
+	active, err := tf.AddressesActivity(addrs)
 	if err != nil {
 		return nil, err
 	}
 
-	if oks[0] == false {
-		return addr, nil
+	// finds the first address after the last one that has confirmed or unconfirmed transactions
+	next := 0
+	for i := len(active) - 1; i >= 0; i-- {
+		if active[i] {
+			next = i + 1
+			break
+		}
+	}
+
+	if next < len(addrs) {
+		return addrs[next], nil
 	}
 
 	// generate a new address and return it
-	addrs, err := w.GenerateAddresses(1, onChangeChain)
+	newAddrs, err := w.GenerateAddresses(1, onChangeChain)
 	if err != nil {
 		return nil, err
 	}
-	return addrs[0], nil
+	return newAddrs[0], nil
 }
 
 func makeChainPubKeys(a *bip44.Account) (*bip32.PublicKey, *bip32.PublicKey, error) {
@@ -860,6 +955,10 @@ func convertOptions(options wallet.Options) []wallet.Option {
 		opts = append(opts, wallet.OptionBip44Coin(options.Bip44Coin))
 	}
 
+	if options.Descriptor != "" {
+		opts = append(opts, wallet.OptionDescriptor(options.Descriptor))
+	}
+
 	if options.CryptoType != "" {
 		opts = append(opts, wallet.OptionCryptoType(options.CryptoType))
 	}
@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/cipher/bip32"
+	"github.com/skycoin/skycoin/src/cipher/bip39"
 	"github.com/skycoin/skycoin/src/cipher/bip44"
+	"github.com/skycoin/skycoin/src/cipher/descriptor"
 	"github.com/skycoin/skycoin/src/wallet"
 	"github.com/skycoin/skycoin/src/wallet/crypto"
 	"github.com/stretchr/testify/require"
@@ -101,6 +104,27 @@ func TestBip44NewWallet(t *testing.T) {
 				DefaultAccountName,
 			},
 		},
+		{
+			name:           "skycoin japanese seed",
+			filename:       "test.wlt",
+			label:          "test",
+			seed:           "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+			seedPassphrase: testSeedPassphrase,
+			expect: expect{
+				wallet.CoinTypeSkycoin,
+				bip44.CoinTypeSkycoin,
+				2, // 1 external, 1 change
+				DefaultAccountName,
+			},
+		},
+		{
+			name:           "invalid seed checksum",
+			filename:       "test.wlt",
+			label:          "test",
+			seed:           "chief stadium sniff exhibit ostrich exit fruit noodle good coin coin supply",
+			seedPassphrase: testSeedPassphrase,
+			err:            bip39.ErrChecksumIncorrect,
+		},
 		{
 			name:           "skycoin geneateN=5",
 			filename:       "test.wlt",
@@ -804,6 +828,182 @@ func TestBip44WalletNewSerializeDeserialize(t *testing.T) {
 	}
 }
 
+func testMasterKey(t *testing.T) *bip32.PrivateKey {
+	seed, err := bip39.NewSeed(testSeed, testSeedPassphrase)
+	require.NoError(t, err)
+	master, err := bip32.NewMasterKey(seed)
+	require.NoError(t, err)
+	return master
+}
+
+func TestBip44NewWalletDescriptor(t *testing.T) {
+	master := testMasterKey(t)
+
+	skycoinDesc, err := descriptor.NewBip44(master, bip44.CoinTypeSkycoin, 0)
+	require.NoError(t, err)
+	bitcoinDesc, err := descriptor.NewBip44(master, bip44.CoinTypeBitcoin, 0)
+	require.NoError(t, err)
+	customDesc, err := descriptor.New(master, []uint32{84 + bip32.FirstHardenedChild, bip32.FirstHardenedChild, 2 + bip32.FirstHardenedChild}, 0, 1)
+	require.NoError(t, err)
+	singleChainDesc, err := descriptor.New(master, []uint32{bip32.FirstHardenedChild}, 0)
+	require.NoError(t, err)
+
+	// The descriptor of the seed without the seed passphrase
+	seed, err := bip39.NewSeed(testSeed, "")
+	require.NoError(t, err)
+	otherMaster, err := bip32.NewMasterKey(seed)
+	require.NoError(t, err)
+	otherDesc, err := descriptor.NewBip44(otherMaster, bip44.CoinTypeSkycoin, 0)
+	require.NoError(t, err)
+
+	tt := []struct {
+		name          string
+		descriptor    string
+		opts          []wallet.Option
+		bip44CoinType bip44.CoinType
+		externalAddrs []cipher.Addresser
+		changeAddrs   []cipher.Addresser
+		err           error
+	}{
+		{
+			name:          "skycoin bip44 account",
+			descriptor:    skycoinDesc.String(),
+			bip44CoinType: bip44.CoinTypeSkycoin,
+			externalAddrs: skycoinExternalAddrs[:1],
+			changeAddrs:   skycoinChangeAddrs[:1],
+		},
+		{
+			name:       "bitcoin bip44 account sets the bip44 coin type",
+			descriptor: bitcoinDesc.String(),
+			opts: []wallet.Option{
+				wallet.OptionCoinType(wallet.CoinTypeBitcoin),
+				wallet.OptionBip44Coin(newBip44CoinType(bip44.CoinTypeSkycoin)),
+			},
+			bip44CoinType: bip44.CoinTypeBitcoin,
+			externalAddrs: bitcoinExternalAddrs[:1],
+		},
+		{
+			name:          "custom derivation path",
+			descriptor:    customDesc.String(),
+			bip44CoinType: bip44.CoinTypeSkycoin,
+			externalAddrs: descriptorAddresses(t, customDesc, 0, 1),
+			changeAddrs:   descriptorAddresses(t, customDesc, 1, 1),
+		},
+		{
+			name:       "descriptor of another seed",
+			descriptor: otherDesc.String(),
+			err:        wallet.NewError(descriptor.ErrFingerprintMismatch),
+		},
+		{
+			name:       "descriptor with one chain",
+			descriptor: singleChainDesc.String(),
+			err:        ErrDescriptorChains,
+		},
+		{
+			name:       "invalid descriptor",
+			descriptor: "pkh(xpub)",
+			err:        wallet.NewError(errors.New("Invalid descriptor xpub key: Serialized keys should be exactly 82 bytes")),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append(tc.opts,
+				wallet.OptionDescriptor(tc.descriptor),
+				wallet.OptionCryptoType(crypto.CryptoTypeSha256Xor))
+			w, err := NewWallet("test.wlt", "test", testSeed, testSeedPassphrase, opts...)
+			require.Equal(t, tc.err, err)
+			if err != nil {
+				return
+			}
+
+			require.Equal(t, tc.bip44CoinType, *w.Bip44Coin())
+			require.Equal(t, tc.descriptor, w.Descriptor())
+
+			addrs, err := w.GetAddresses(wallet.OptionExternal())
+			require.NoError(t, err)
+			require.Equal(t, tc.externalAddrs, addrs)
+
+			if tc.changeAddrs != nil {
+				addrs, err = w.GetAddresses(wallet.OptionChange())
+				require.NoError(t, err)
+				require.Equal(t, tc.changeAddrs, addrs)
+			}
+
+			// The descriptor is kept by serialization and encryption
+			b, err := w.Serialize()
+			require.NoError(t, err)
+			w2 := &Wallet{}
+			require.NoError(t, w2.Deserialize(b))
+			require.Equal(t, tc.descriptor, w2.Descriptor())
+
+			require.NoError(t, w2.Lock([]byte("pwd")))
+			require.Equal(t, tc.descriptor, w2.Descriptor())
+			w3, err := w2.Unlock([]byte("pwd"))
+			require.NoError(t, err)
+			require.Equal(t, tc.descriptor, w3.Descriptor())
+		})
+	}
+}
+
+func TestBip44WalletDescriptorNewAccount(t *testing.T) {
+	master := testMasterKey(t)
+
+	// Wallets without a descriptor have the descriptor of the first bip44 account
+	w, err := NewWallet("test.wlt", "test", testSeed, testSeedPassphrase)
+	require.NoError(t, err)
+	d, err := descriptor.NewBip44(master, bip44.CoinTypeSkycoin, 0)
+	require.NoError(t, err)
+	require.Equal(t, d.String(), w.Descriptor())
+
+	// More accounts can be created in a wallet of the first bip44 account
+	w, err = NewWallet("test.wlt", "test", testSeed, testSeedPassphrase, wallet.OptionDescriptor(d.String()))
+	require.NoError(t, err)
+	ai, err := w.NewAccount("account1")
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), ai)
+
+	a, err := w.account(ai)
+	require.NoError(t, err)
+	d1, err := descriptor.NewBip44(master, bip44.CoinTypeSkycoin, 1)
+	require.NoError(t, err)
+	require.Equal(t, d1.String(), a.Descriptor)
+
+	// A wallet of another bip44 account has one account
+	d, err = descriptor.NewBip44(master, bip44.CoinTypeSkycoin, 3)
+	require.NoError(t, err)
+	w, err = NewWallet("test.wlt", "test", testSeed, testSeedPassphrase, wallet.OptionDescriptor(d.String()))
+	require.NoError(t, err)
+	_, err = w.NewAccount("account1")
+	require.Equal(t, ErrDescriptorSingleAccount, err)
+
+	// A wallet of a custom derivation path has one account
+	d, err = descriptor.New(master, []uint32{bip32.FirstHardenedChild}, 0, 1)
+	require.NoError(t, err)
+	w, err = NewWallet("test.wlt", "test", testSeed, testSeedPassphrase, wallet.OptionDescriptor(d.String()))
+	require.NoError(t, err)
+	_, err = w.NewAccount("account1")
+	require.Equal(t, ErrDescriptorSingleAccount, err)
+}
+
+// descriptorAddresses returns the first n skycoin addresses of a chain of a descriptor
+func descriptorAddresses(t *testing.T, d *descriptor.Descriptor, chain int, n uint32) []cipher.Addresser {
+	k, err := d.ChainKey(chain)
+	require.NoError(t, err)
+
+	var addrs []cipher.Addresser
+	for i := uint32(0); i < n; i++ {
+		ck, err := k.NewPublicChildKey(i)
+		require.NoError(t, err)
+		addrs = append(addrs, cipher.AddressFromPubKey(cipher.MustNewPubKey(ck.Key)))
+	}
+	return addrs
+}
+
+func newBip44CoinType(ct bip44.CoinType) *bip44.CoinType {
+	return &ct
+}
+
 func skycoinAddressStringsToAddress(addrsStr []string) []cipher.Addresser {
 	var addrs []cipher.Addresser
 	for _, addr := range addrsStr {
@@ -0,0 +1,288 @@
+package wallet
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/util/file"
+)
+
+// addressChain identifies an address chain of a wallet that is kept topped up
+// by the address discovery process
+type addressChain struct {
+	name    string
+	options []Option
+}
+
+// discoveryChains returns the address chains of w that the address discovery
+// process maintains. Only bip44 and xpub wallets have address chains that can be
+// extended without unlocking the wallet.
+func discoveryChains(w Wallet) []addressChain {
+	switch w.Type() {
+	case WalletTypeBip44:
+		var chains []addressChain
+		for _, a := range w.Accounts() {
+			chains = append(chains, addressChain{
+				name:    "external",
+				options: []Option{OptionAccount(a.Index), OptionExternal()},
+			}, addressChain{
+				name:    "change",
+				options: []Option{OptionAccount(a.Index), OptionChange()},
+			})
+		}
+		return chains
+	case WalletTypeXPub:
+		return []addressChain{{name: "external"}}
+	default:
+		return nil
+	}
+}
+
+// lastActiveIndex returns the index of the last address that has activity, or -1 if none
+func lastActiveIndex(active []bool) int {
+	for i := len(active) - 1; i >= 0; i-- {
+		if active[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// chainLength is the number of addresses an address chain needs to have
+type chainLength struct {
+	chain addressChain
+	n     uint64
+}
+
+// discoveryLengths returns the number of addresses each address chain of w needs to have,
+// so that the chain ends with at least gap addresses without any activity
+func discoveryLengths(w Wallet, gap uint64, tf TransactionsFinder) ([]chainLength, error) {
+	var lengths []chainLength
+	for _, c := range discoveryChains(w) {
+		addrs, err := w.GetAddresses(c.options...)
+		if err != nil {
+			return nil, err
+		}
+
+		active, err := tf.AddressesActivity(addrs)
+		if err != nil {
+			return nil, err
+		}
+
+		lengths = append(lengths, chainLength{
+			chain: c,
+			n:     uint64(lastActiveIndex(active)+1) + gap,
+		})
+	}
+
+	return lengths, nil
+}
+
+// topUpAddresses generates addresses on the address chains of w until they have the given number of addresses.
+// Returns the newly generated addresses.
+func topUpAddresses(w Wallet, lengths []chainLength) ([]cipher.Addresser, error) {
+	var newAddrs []cipher.Addresser
+	for _, l := range lengths {
+		n, err := w.EntriesLen(l.chain.options...)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint64(n) >= l.n {
+			continue
+		}
+
+		generated, err := w.GenerateAddresses(l.n-uint64(n), l.chain.options...)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.WithFields(logrus.Fields{
+			"wallet": w.Filename(),
+			"chain":  l.chain.name,
+			"n":      len(generated),
+		}).Debug("topUpAddresses: generated addresses")
+
+		newAddrs = append(newAddrs, generated...)
+	}
+
+	return newAddrs, nil
+}
+
+// DiscoveryErrors are the errors of the wallets whose address discovery failed, by wallet ID
+type DiscoveryErrors map[string]error
+
+func (e DiscoveryErrors) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("%s: %v", id, e[id])
+	}
+
+	return fmt.Sprintf("address discovery failed for %d wallet(s): %s", len(e), strings.Join(msgs, "; "))
+}
+
+// DiscoverAddresses keeps a gap of AddressGapLimit unused addresses at the end of
+// the external and change chains of every loaded bip44 and xpub wallet.
+// The activity of the addresses is scanned without holding the service lock, which is only
+// taken to add the new addresses. A wallet that fails does not stop the discovery of the others.
+// Returns the newly generated addresses of each wallet that was updated, and DiscoveryErrors if any wallet failed.
+func (serv *Service) DiscoverAddresses(tf TransactionsFinder) (map[string][]cipher.Address, error) {
+	if !serv.config.EnableWalletAPI {
+		return nil, ErrWalletAPIDisabled
+	}
+
+	if serv.config.AddressGapLimit == 0 {
+		return nil, nil
+	}
+
+	serv.RLock()
+	var wallets []Wallet
+	for wltID := range serv.wallets {
+		w, err := serv.getWallet(wltID)
+		if err != nil {
+			serv.RUnlock()
+			return nil, err
+		}
+
+		if len(discoveryChains(w)) != 0 {
+			wallets = append(wallets, w)
+		}
+	}
+	serv.RUnlock()
+
+	updated := make(map[string][]cipher.Address)
+	errs := make(DiscoveryErrors)
+	for _, w := range wallets {
+		lengths, err := discoveryLengths(w, serv.config.AddressGapLimit, tf)
+		if err != nil {
+			errs[w.Filename()] = err
+			continue
+		}
+
+		addrs, err := serv.topUpWallet(w, lengths)
+		if err != nil {
+			errs[w.Filename()] = err
+			continue
+		}
+
+		if len(addrs) != 0 {
+			updated[w.Filename()] = SkycoinAddresses(addrs)
+		}
+	}
+
+	if len(errs) != 0 {
+		return updated, errs
+	}
+
+	return updated, nil
+}
+
+// topUpWallet generates the addresses missing from the address chains of the loaded copy of the scanned wallet,
+// and saves it. Returns the new addresses. Nothing is generated if the wallet was removed or replaced since it was scanned.
+func (serv *Service) topUpWallet(scanned Wallet, lengths []chainLength) ([]cipher.Addresser, error) {
+	serv.Lock()
+	defer serv.Unlock()
+
+	w, err := serv.getWallet(scanned.Filename())
+	if err != nil {
+		if err == ErrWalletNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if w.Fingerprint() != scanned.Fingerprint() {
+		return nil, nil
+	}
+
+	addrs, err := topUpAddresses(w, lengths)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+
+	// Leave the wallet untouched if it can't be saved, e.g. if the user made it read-only
+	wf := filepath.Join(serv.config.WalletDir, w.Filename())
+	if !file.IsWritable(wf) {
+		logger.WithField("wallet", w.Filename()).Warning("DiscoverAddresses: wallet file is not writable, skipping")
+		return nil, nil
+	}
+
+	if err := Save(w, serv.config.WalletDir); err != nil {
+		return nil, err
+	}
+
+	serv.wallets.set(w)
+	return addrs, nil
+}
+
+// RunAddressDiscovery runs DiscoverAddresses every AddressDiscoveryInterval until Shutdown is called.
+// It returns immediately if the wallet API is disabled or AddressGapLimit is 0.
+func (serv *Service) RunAddressDiscovery(tf TransactionsFinder) {
+	if !serv.config.EnableWalletAPI || serv.config.AddressGapLimit == 0 || serv.config.AddressDiscoveryInterval <= 0 {
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"gapLimit": serv.config.AddressGapLimit,
+		"interval": serv.config.AddressDiscoveryInterval,
+	}).Info("Address discovery started")
+	defer logger.Info("Address discovery stopped")
+
+	discover := func() {
+		updated, err := serv.DiscoverAddresses(tf)
+		switch e := err.(type) {
+		case nil:
+		case DiscoveryErrors:
+			for wltID, err := range e {
+				logger.WithError(err).WithField("wallet", wltID).Error("Address discovery failed")
+			}
+		default:
+			logger.WithError(err).Error("DiscoverAddresses failed")
+			return
+		}
+
+		for wltID, addrs := range updated {
+			logger.WithFields(logrus.Fields{
+				"wallet": wltID,
+				"n":      len(addrs),
+			}).Info("Address discovery generated new addresses")
+		}
+	}
+
+	discover()
+
+	ticker := time.NewTicker(serv.config.AddressDiscoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-serv.quit:
+			return
+		case <-ticker.C:
+			discover()
+		}
+	}
+}
+
+// Shutdown stops the address discovery process
+func (serv *Service) Shutdown() {
+	serv.quitOnce.Do(func() {
+		close(serv.quit)
+	})
+}
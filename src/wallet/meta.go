@@ -26,6 +26,7 @@ const (
 	MetaAccountsHash   = "accountsHash"   // accounts hash
 	MetaSeedPassphrase = "seedPassphrase" // seed passphrase [bip44 wallets]
 	MetaXPub           = "xpub"           // xpub key [xpub wallets]
+	MetaDescriptor     = "descriptor"     // output descriptor of the xpub key [xpub wallets]
 )
 
 //const (
@@ -257,6 +258,11 @@ func (m Meta) XPub() string {
 	return m[MetaXPub]
 }
 
+// Descriptor returns the output descriptor
+func (m Meta) Descriptor() string {
+	return m[MetaDescriptor]
+}
+
 // Validate validates the meta data
 func (m Meta) Validate() error {
 	if fn := m[MetaFilename]; fn == "" {
@@ -97,6 +97,20 @@ func (_m *MockWallet) CryptoType() crypto.CryptoType {
 	return r0
 }
 
+// Descriptor provides a mock function with given fields:
+func (_m *MockWallet) Descriptor() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // Deserialize provides a mock function with given fields: data
 func (_m *MockWallet) Deserialize(data []byte) error {
 	ret := _m.Called(data)
@@ -108,6 +108,7 @@ type AdvancedOptions struct {
 	GenerateN               uint64
 	ScanN                   uint64
 	TF                      TransactionsFinder
+	Descriptor              string
 }
 
 // advancedOptionFunc is a helper function that assert the
@@ -158,6 +159,14 @@ func OptionTransactionsFinder(tf TransactionsFinder) Option {
 	})
 }
 
+// OptionDescriptor can be used to set the output descriptor of the addresses
+// when creating a new bip44 or xpub wallet
+func OptionDescriptor(desc string) Option {
+	return advancedOptionFunc(func(opts *AdvancedOptions) {
+		opts.Descriptor = desc
+	})
+}
+
 // OptionGenerateN can be used to set the initial number of addresses to generate
 // when creating a new wallet
 func OptionGenerateN(n uint64) Option {
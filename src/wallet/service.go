@@ -8,17 +8,23 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/cipher/bip32"
+	"github.com/skycoin/skycoin/src/cipher/bip39"
 	"github.com/skycoin/skycoin/src/cipher/bip44"
+	"github.com/skycoin/skycoin/src/cipher/descriptor"
 	"github.com/skycoin/skycoin/src/util/file"
 	"github.com/skycoin/skycoin/src/wallet/crypto"
 )
 
 // TransactionsFinder interface for finding address related transaction hashes
 type TransactionsFinder interface {
+	// AddressesActivity returns whether each address appears in the blockchain or in the
+	// outputs of an unconfirmed transaction
 	AddressesActivity(addrs []cipher.Addresser) ([]bool, error)
 }
 
@@ -29,6 +35,10 @@ type Service struct {
 	config  Config
 	// fingerprints is used to check for duplicate deterministic wallets
 	fingerprints map[string]string
+	// uxOutsMeta caches the coin control metadata of unspent outputs, keyed by wallet ID
+	uxOutsMeta map[string]UxOutsMeta
+	quit       chan struct{}
+	quitOnce   sync.Once
 }
 
 // Config wallet service config
@@ -38,17 +48,24 @@ type Config struct {
 	EnableWalletAPI bool
 	EnableSeedAPI   bool
 	Bip44Coin       *bip44.CoinType
+	// AddressGapLimit is the number of unused addresses that the address discovery
+	// process keeps at the end of each bip44 and xpub wallet address chain. 0 disables it.
+	AddressGapLimit uint64
+	// AddressDiscoveryInterval is how often the address discovery process runs
+	AddressDiscoveryInterval time.Duration
 }
 
 // NewConfig creates a default Config
 func NewConfig() Config {
 	bc := bip44.CoinTypeSkycoin
 	return Config{
-		WalletDir:       "./",
-		CryptoType:      crypto.DefaultCryptoType,
-		EnableWalletAPI: false,
-		EnableSeedAPI:   false,
-		Bip44Coin:       &bc,
+		WalletDir:                "./",
+		CryptoType:               crypto.DefaultCryptoType,
+		EnableWalletAPI:          false,
+		EnableSeedAPI:            false,
+		Bip44Coin:                &bc,
+		AddressGapLimit:          20,
+		AddressDiscoveryInterval: time.Minute,
 	}
 }
 
@@ -57,6 +74,8 @@ func NewService(c Config) (*Service, error) {
 	serv := &Service{
 		config:       c,
 		fingerprints: make(map[string]string),
+		uxOutsMeta:   make(map[string]UxOutsMeta),
+		quit:         make(chan struct{}),
 	}
 
 	if !serv.config.EnableWalletAPI {
@@ -240,6 +259,13 @@ func (serv *Service) loadWallet(wltName string, options Options) (Wallet, error)
 		return nil, err
 	}
 
+	if err := backupUxOutsMeta(serv.config.WalletDir, w.Filename()); err != nil {
+		logger.WithError(err).WithField("wallet", w.Filename()).Error("backupUxOutsMeta failed")
+		serv.wallets.remove(w.Filename())
+		return nil, err
+	}
+	delete(serv.uxOutsMeta, w.Filename())
+
 	if err := Save(w, serv.config.WalletDir); err != nil {
 		// If save fails, remove the added wallet
 		serv.wallets.remove(w.Filename())
@@ -600,7 +626,8 @@ func (serv *Service) UpdateWalletLabel(wltID, label string) error {
 	return nil
 }
 
-// UnloadWallet removes wallet of given wallet id from the service
+// UnloadWallet removes wallet of given wallet id from the service.
+// The wallet file and its unspent outputs metadata are left in the wallet directory.
 func (serv *Service) UnloadWallet(wltID string) error {
 	serv.Lock()
 	defer serv.Unlock()
@@ -616,6 +643,7 @@ func (serv *Service) UnloadWallet(wltID string) error {
 	}
 
 	serv.wallets.remove(wltID)
+	delete(serv.uxOutsMeta, wltID)
 	return nil
 }
 
@@ -663,6 +691,32 @@ func (serv *Service) GetWalletSeed(wltID string, password []byte) (string, strin
 	return seed, seedPassphrase, nil
 }
 
+// SignMessage signs a message with the secret key of an address of the wallet, to prove the ownership of the address.
+// The password is required if the wallet is encrypted.
+func (serv *Service) SignMessage(wltID string, password []byte, addr cipher.Address, msg []byte) (cipher.Sig, error) {
+	var sig cipher.Sig
+	if err := serv.ViewSecrets(wltID, password, func(w Wallet) error {
+		if w.Type() == WalletTypeXPub {
+			return ErrWalletCantSign
+		}
+
+		e, err := w.GetEntry(addr)
+		if err != nil {
+			if err == ErrEntryNotFound {
+				return ErrUnknownAddress
+			}
+			return err
+		}
+
+		sig, err = cipher.SignMessage(msg, e.Secret)
+		return err
+	}); err != nil {
+		return cipher.Sig{}, err
+	}
+
+	return sig, nil
+}
+
 // UpdateSecrets opens a wallet for modification of secret data and saves it safely
 func (serv *Service) UpdateSecrets(wltID string, password []byte, f func(Wallet) error) error {
 	serv.Lock()
@@ -763,6 +817,67 @@ func (serv *Service) View(wltID string, f func(Wallet) error) error {
 	return f(w)
 }
 
+// GetWalletDescriptor returns the output descriptor of a bip44 or xpub wallet.
+// The descriptor of a bip44 wallet that was created before descriptors were recorded
+// is derived from its seed, which requires the password if the wallet is encrypted.
+func (serv *Service) GetWalletDescriptor(wltID string, password []byte) (string, error) {
+	var desc string
+	if err := serv.View(wltID, func(w Wallet) error {
+		switch w.Type() {
+		case WalletTypeBip44:
+		case WalletTypeXPub:
+			if w.Descriptor() == "" {
+				return ErrWalletDescriptorUnknown
+			}
+		default:
+			return ErrWalletDescriptor
+		}
+
+		desc = w.Descriptor()
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	if desc != "" {
+		return desc, nil
+	}
+
+	if err := serv.ViewSecrets(wltID, password, func(w Wallet) error {
+		var err error
+		desc, err = bip44Descriptor(w.Seed(), w.SeedPassphrase(), w.Bip44Coin())
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	return desc, nil
+}
+
+// bip44Descriptor returns the output descriptor of the first bip44 account of a seed
+func bip44Descriptor(seed, seedPassphrase string, coinType *bip44.CoinType) (string, error) {
+	if coinType == nil {
+		return "", errors.New("missing bip44 coin type")
+	}
+
+	s, err := bip39.NewSeed(seed, seedPassphrase)
+	if err != nil {
+		return "", err
+	}
+
+	master, err := bip32.NewMasterKey(s)
+	if err != nil {
+		return "", err
+	}
+
+	d, err := descriptor.NewBip44(master, *coinType, 0)
+	if err != nil {
+		return "", err
+	}
+
+	return d.String(), nil
+}
+
 // RecoverWallet recovers an encrypted wallet from seed.
 // The recovered wallet will be encrypted with the new password, if provided.
 func (serv *Service) RecoverWallet(wltName, seed, seedPassphrase string, password []byte) (Wallet, error) {
@@ -794,8 +909,14 @@ func (serv *Service) RecoverWallet(wltName, seed, seedPassphrase string, passwor
 		Bip44Coin:      w.Bip44Coin(),
 		Seed:           seed,
 		SeedPassphrase: seedPassphrase,
+		Descriptor:     w.Descriptor(),
 		GenerateN:      1,
 	})
+	switch err {
+	case NewError(descriptor.ErrFingerprintMismatch), NewError(descriptor.ErrKeyMismatch):
+		// The seed does not derive the key of the wallet's descriptor
+		return nil, ErrWalletRecoverSeedWrong
+	}
 	if err != nil {
 		err = NewError(fmt.Errorf("RecoverWallet failed to create temporary wallet for fingerprint comparison: %v", err))
 		logger.Critical().WithError(err).Error()
@@ -827,6 +948,7 @@ func (serv *Service) RecoverWallet(wltName, seed, seedPassphrase string, passwor
 		Password:       password,
 		CryptoType:     w.CryptoType(),
 		Bip44Coin:      w.Bip44Coin(),
+		Descriptor:     w.Descriptor(),
 		GenerateN:      uint64(l),
 	})
 	if err != nil {
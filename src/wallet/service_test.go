@@ -11,7 +11,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/skycoin/skycoin/src/cipher/bip32"
 	"github.com/skycoin/skycoin/src/cipher/bip39"
+	"github.com/skycoin/skycoin/src/cipher/bip44"
+	"github.com/skycoin/skycoin/src/cipher/descriptor"
 	"github.com/skycoin/skycoin/src/testutil"
 	"github.com/skycoin/skycoin/src/wallet/bip44wallet"
 	"github.com/skycoin/skycoin/src/wallet/collection"
@@ -3174,6 +3177,297 @@ func TestGetWalletSeed(t *testing.T) {
 	}
 }
 
+func TestServiceGetWalletDescriptor(t *testing.T) {
+	seed := "into raccoon purity buzz shock arch artefact stadium bless witness used odor"
+	bip44Desc := func(t *testing.T, coinType bip44.CoinType) string {
+		s, err := bip39.NewSeed(seed, "")
+		require.NoError(t, err)
+		master, err := bip32.NewMasterKey(s)
+		require.NoError(t, err)
+		d, err := descriptor.NewBip44(master, coinType, 0)
+		require.NoError(t, err)
+		return d.String()
+	}
+
+	bitcoin := bip44.CoinTypeBitcoin
+
+	tt := []struct {
+		name      string
+		opts      wallet.Options
+		id        string
+		pwd       []byte
+		expect    func(t *testing.T) string
+		expectErr error
+	}{
+		{
+			name: "bip44",
+			opts: wallet.Options{
+				Seed:  seed,
+				Label: "label",
+				Type:  wallet.WalletTypeBip44,
+			},
+			expect: func(t *testing.T) string {
+				return bip44Desc(t, bip44.CoinTypeSkycoin)
+			},
+		},
+		{
+			name: "bip44 encrypted",
+			opts: wallet.Options{
+				Seed:      seed,
+				Label:     "label",
+				Type:      wallet.WalletTypeBip44,
+				Bip44Coin: &bitcoin,
+				Encrypt:   true,
+				Password:  []byte("pwd"),
+			},
+			expect: func(t *testing.T) string {
+				return bip44Desc(t, bip44.CoinTypeBitcoin)
+			},
+		},
+		{
+			name: "xpub descriptor",
+			opts: wallet.Options{
+				Label:      "label",
+				Type:       wallet.WalletTypeXPub,
+				Descriptor: bip44Desc(t, bip44.CoinTypeSkycoin),
+			},
+			expect: func(t *testing.T) string {
+				d, err := descriptor.Parse(bip44Desc(t, bip44.CoinTypeSkycoin))
+				require.NoError(t, err)
+				d.Path = []uint32{d.Chains[0]}
+				d.Chains = nil
+				return d.String()
+			},
+		},
+		{
+			name: "deterministic wallet",
+			opts: wallet.Options{
+				Seed:  "seed",
+				Label: "label",
+				Type:  wallet.WalletTypeDeterministic,
+			},
+			expectErr: wallet.ErrWalletDescriptor,
+		},
+		{
+			name: "wallet does not exist",
+			opts: wallet.Options{
+				Seed:  seed,
+				Label: "label",
+				Type:  wallet.WalletTypeBip44,
+			},
+			id:        "none-exist.wlt",
+			expectErr: wallet.ErrWalletNotExist,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := prepareWltDir()
+			s, err := wallet.NewService(wallet.Config{
+				WalletDir:       dir,
+				CryptoType:      crypto.CryptoTypeSha256Xor,
+				EnableWalletAPI: true,
+			})
+			require.NoError(t, err)
+
+			_, err = s.CreateWallet("wallet.wlt", tc.opts)
+			require.NoError(t, err)
+
+			id := tc.id
+			if id == "" {
+				id = "wallet.wlt"
+			}
+
+			// The descriptor is recorded when the wallet is created, no password is needed
+			desc, err := s.GetWalletDescriptor(id, nil)
+			require.Equal(t, tc.expectErr, err)
+			if err != nil {
+				return
+			}
+
+			require.Equal(t, tc.expect(t), desc)
+		})
+	}
+
+	t.Run("wallet api disabled", func(t *testing.T) {
+		s, err := wallet.NewService(wallet.Config{
+			WalletDir:       prepareWltDir(),
+			CryptoType:      crypto.CryptoTypeSha256Xor,
+			EnableWalletAPI: false,
+		})
+		require.NoError(t, err)
+
+		_, err = s.GetWalletDescriptor("wallet.wlt", nil)
+		require.Equal(t, wallet.ErrWalletAPIDisabled, err)
+	})
+
+	t.Run("bip44 wallet without descriptor", func(t *testing.T) {
+		s, err := wallet.NewService(wallet.Config{
+			WalletDir:       "./testdata",
+			CryptoType:      crypto.CryptoTypeSha256Xor,
+			EnableWalletAPI: true,
+		})
+		require.NoError(t, err)
+
+		desc, err := s.GetWalletDescriptor("test6-bip44.wlt", nil)
+		require.NoError(t, err)
+		require.Equal(t, bip44Desc(t, bip44.CoinTypeSkycoin), desc)
+
+		_, err = s.GetWalletDescriptor("test6-bip44.wlt", []byte("pwd"))
+		require.Equal(t, wallet.ErrWalletNotEncrypted, err)
+	})
+}
+
+func TestServiceSignMessage(t *testing.T) {
+	msg := []byte("I own this address")
+
+	tt := []struct {
+		name             string
+		opts             wallet.Options
+		id               string
+		pwd              []byte
+		addr             *cipher.Address
+		disableWalletAPI bool
+		expectErr        error
+	}{
+		{
+			name: "ok deterministic",
+			opts: wallet.Options{
+				Seed: "seed",
+				Type: wallet.WalletTypeDeterministic,
+			},
+			id: "wallet.wlt",
+		},
+		{
+			name: "ok bip44",
+			opts: wallet.Options{
+				Seed: bip39.MustNewDefaultMnemonic(),
+				Type: wallet.WalletTypeBip44,
+			},
+			id: "wallet.wlt",
+		},
+		{
+			name: "ok encrypted",
+			opts: wallet.Options{
+				Seed:     "seed",
+				Type:     wallet.WalletTypeDeterministic,
+				Encrypt:  true,
+				Password: []byte("pwd"),
+			},
+			id:  "wallet.wlt",
+			pwd: []byte("pwd"),
+		},
+		{
+			name: "encrypted missing password",
+			opts: wallet.Options{
+				Seed:     "seed",
+				Type:     wallet.WalletTypeDeterministic,
+				Encrypt:  true,
+				Password: []byte("pwd"),
+			},
+			id:        "wallet.wlt",
+			expectErr: wallet.ErrMissingPassword,
+		},
+		{
+			name: "encrypted wrong password",
+			opts: wallet.Options{
+				Seed:     "seed",
+				Type:     wallet.WalletTypeDeterministic,
+				Encrypt:  true,
+				Password: []byte("pwd"),
+			},
+			id:        "wallet.wlt",
+			pwd:       []byte("wrong"),
+			expectErr: wallet.ErrInvalidPassword,
+		},
+		{
+			name: "not encrypted with password",
+			opts: wallet.Options{
+				Seed: "seed",
+				Type: wallet.WalletTypeDeterministic,
+			},
+			id:        "wallet.wlt",
+			pwd:       []byte("pwd"),
+			expectErr: wallet.ErrWalletNotEncrypted,
+		},
+		{
+			name: "address not in wallet",
+			opts: wallet.Options{
+				Seed: "seed",
+				Type: wallet.WalletTypeDeterministic,
+			},
+			id:        "wallet.wlt",
+			addr:      &cipher.Address{Key: cipher.Ripemd160{1}},
+			expectErr: wallet.ErrUnknownAddress,
+		},
+		{
+			name: "xpub wallet",
+			opts: wallet.Options{
+				Type: wallet.WalletTypeXPub,
+				XPub: "xpub6EFYYRQeAbWLdWQYbtQv8HnemieKNmYUE23RmwphgtMLjz4UaStKADSKNoSSXM5FDcq4gZec2q6n7kdNWfuMdScxK1cXm8tR37kaitHtvuJ",
+			},
+			id:        "wallet.wlt",
+			expectErr: wallet.ErrWalletCantSign,
+		},
+		{
+			name: "wallet does not exist",
+			opts: wallet.Options{
+				Seed: "seed",
+				Type: wallet.WalletTypeDeterministic,
+			},
+			id:        "none-exist.wlt",
+			expectErr: wallet.ErrWalletNotExist,
+		},
+		{
+			name: "wallet api disabled",
+			opts: wallet.Options{
+				Seed: "seed",
+				Type: wallet.WalletTypeDeterministic,
+			},
+			id:               "wallet.wlt",
+			disableWalletAPI: true,
+			expectErr:        wallet.ErrWalletAPIDisabled,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := prepareWltDir()
+			s, err := wallet.NewService(wallet.Config{
+				WalletDir:       dir,
+				CryptoType:      crypto.CryptoTypeScryptChacha20poly1305Insecure,
+				EnableWalletAPI: !tc.disableWalletAPI,
+			})
+			require.NoError(t, err)
+
+			if tc.disableWalletAPI {
+				_, err = s.SignMessage(tc.id, tc.pwd, cipher.Address{}, msg)
+				require.Equal(t, tc.expectErr, err)
+				return
+			}
+
+			w, err := s.CreateWallet("wallet.wlt", tc.opts)
+			require.NoError(t, err)
+
+			addrs, err := w.GetAddresses()
+			require.NoError(t, err)
+			require.NotEmpty(t, addrs)
+			addr := addrs[0].(cipher.Address)
+			if tc.addr != nil {
+				addr = *tc.addr
+			}
+
+			sig, err := s.SignMessage(tc.id, tc.pwd, addr, msg)
+			require.Equal(t, tc.expectErr, err)
+			if err != nil {
+				return
+			}
+
+			require.NoError(t, cipher.VerifyAddressSignedMessage(addr, sig, msg))
+		})
+	}
+}
+
 func TestServiceView(t *testing.T) {
 	tt := []struct {
 		name             string
@@ -4328,3 +4622,333 @@ func combineAddrs(a []cipher.Address, b []cipher.Address) []cipher.Address {
 	copy(addrs[len(a):], b[:])
 	return addrs
 }
+
+func TestServiceDiscoverAddresses(t *testing.T) {
+	bip44Seed := "voyage say extend find sheriff surge priority merit ignore maple cash argue"
+
+	dir := prepareWltDir()
+	s, err := wallet.NewService(wallet.Config{
+		WalletDir:       dir,
+		CryptoType:      crypto.CryptoTypeSha256Xor,
+		EnableWalletAPI: true,
+		AddressGapLimit: 5,
+	})
+	require.NoError(t, err)
+
+	w, err := s.CreateWallet("t.wlt", wallet.Options{
+		Type:  wallet.WalletTypeBip44,
+		Seed:  bip44Seed,
+		Label: "bip44",
+	})
+	require.NoError(t, err)
+
+	_, err = s.CreateWallet("d.wlt", wallet.Options{
+		Type:  wallet.WalletTypeDeterministic,
+		Seed:  "seed1",
+		Label: "deterministic",
+	})
+	require.NoError(t, err)
+
+	externalAddrs, err := w.GetAddresses(wallet.OptionExternal())
+	require.NoError(t, err)
+	require.Len(t, externalAddrs, 1)
+
+	// The first external address has transactions, 5 unused addresses are appended after it.
+	// The change chain has 1 unused address, 4 more are appended.
+	tf := mockTxnsFinder{externalAddrs[0]: true}
+	updated, err := s.DiscoverAddresses(tf)
+	require.NoError(t, err)
+	require.Len(t, updated, 1)
+	require.Len(t, updated["t.wlt"], 9)
+
+	w, err = s.GetWallet("t.wlt")
+	require.NoError(t, err)
+	l, err := w.EntriesLen(wallet.OptionExternal())
+	require.NoError(t, err)
+	require.Equal(t, 6, l)
+	l, err = w.EntriesLen(wallet.OptionChange())
+	require.NoError(t, err)
+	require.Equal(t, 5, l)
+
+	// The wallet is persisted
+	w2, err := wallet.Load(filepath.Join(dir, "t.wlt"))
+	require.NoError(t, err)
+	l, err = w2.EntriesLen(wallet.OptionExternal())
+	require.NoError(t, err)
+	require.Equal(t, 6, l)
+
+	// The deterministic wallet is untouched
+	dw, err := s.GetWallet("d.wlt")
+	require.NoError(t, err)
+	l, err = dw.EntriesLen()
+	require.NoError(t, err)
+	require.Equal(t, 1, l)
+
+	// Nothing changes when the gap is already satisfied
+	updated, err = s.DiscoverAddresses(tf)
+	require.NoError(t, err)
+	require.Empty(t, updated)
+
+	// A transaction to the 4th external address leaves 2 unused addresses, 3 are appended
+	externalAddrs, err = w.GetAddresses(wallet.OptionExternal())
+	require.NoError(t, err)
+	tf[externalAddrs[3]] = true
+	updated, err = s.DiscoverAddresses(tf)
+	require.NoError(t, err)
+	require.Len(t, updated["t.wlt"], 3)
+
+	w, err = s.GetWallet("t.wlt")
+	require.NoError(t, err)
+	l, err = w.EntriesLen(wallet.OptionExternal())
+	require.NoError(t, err)
+	require.Equal(t, 9, l)
+
+	// Change addresses are taken after the last used change address
+	changeAddrs, err := w.GetAddresses(wallet.OptionChange())
+	require.NoError(t, err)
+	tf[changeAddrs[1]] = true
+	addr, err := w.(wallet.ChangeAddressPeeker).PeekChangeAddress(tf)
+	require.NoError(t, err)
+	require.Equal(t, changeAddrs[2], addr)
+}
+
+// txnsFinderFunc implements wallet.TransactionsFinder with a function
+type txnsFinderFunc func(addrs []cipher.Addresser) ([]bool, error)
+
+func (f txnsFinderFunc) AddressesActivity(addrs []cipher.Addresser) ([]bool, error) {
+	return f(addrs)
+}
+
+func TestServiceDiscoverAddressesErrors(t *testing.T) {
+	s, err := wallet.NewService(wallet.Config{
+		WalletDir:       prepareWltDir(),
+		CryptoType:      crypto.CryptoTypeSha256Xor,
+		EnableWalletAPI: true,
+		AddressGapLimit: 5,
+	})
+	require.NoError(t, err)
+
+	a, err := s.CreateWallet("a.wlt", wallet.Options{
+		Type:  wallet.WalletTypeBip44,
+		Seed:  "voyage say extend find sheriff surge priority merit ignore maple cash argue",
+		Label: "a",
+	})
+	require.NoError(t, err)
+
+	_, err = s.CreateWallet("b.wlt", wallet.Options{
+		Type:  wallet.WalletTypeBip44,
+		Seed:  bip39.MustNewDefaultMnemonic(),
+		Label: "b",
+	})
+	require.NoError(t, err)
+
+	aAddrs, err := a.GetAddresses()
+	require.NoError(t, err)
+	failing := make(map[cipher.Addresser]struct{})
+	for _, addr := range aAddrs {
+		failing[addr] = struct{}{}
+	}
+
+	tf := txnsFinderFunc(func(addrs []cipher.Addresser) ([]bool, error) {
+		// The service is not locked while the addresses are scanned
+		_, err := s.GetWallets()
+		require.NoError(t, err)
+
+		for _, addr := range addrs {
+			if _, ok := failing[addr]; ok {
+				return nil, errors.New("scan failed")
+			}
+		}
+		return make([]bool, len(addrs)), nil
+	})
+
+	// The failure of a wallet does not stop the discovery of the others
+	updated, err := s.DiscoverAddresses(tf)
+	require.Equal(t, wallet.DiscoveryErrors{"a.wlt": errors.New("scan failed")}, err)
+	require.EqualError(t, err, "address discovery failed for 1 wallet(s): a.wlt: scan failed")
+	require.Len(t, updated, 1)
+	require.Len(t, updated["b.wlt"], 8)
+
+	a, err = s.GetWallet("a.wlt")
+	require.NoError(t, err)
+	l, err := a.EntriesLen()
+	require.NoError(t, err)
+	require.Equal(t, 2, l)
+}
+
+func TestServiceDiscoverAddressesDisabled(t *testing.T) {
+	s, err := wallet.NewService(wallet.Config{
+		WalletDir:       prepareWltDir(),
+		CryptoType:      crypto.CryptoTypeSha256Xor,
+		EnableWalletAPI: false,
+		AddressGapLimit: 5,
+	})
+	require.NoError(t, err)
+
+	_, err = s.DiscoverAddresses(mockTxnsFinder{})
+	require.Equal(t, wallet.ErrWalletAPIDisabled, err)
+
+	// RunAddressDiscovery returns immediately when the wallet API is disabled
+	s.RunAddressDiscovery(mockTxnsFinder{})
+	s.Shutdown()
+	s.Shutdown()
+}
+
+func TestServiceUpdateUxOutsMeta(t *testing.T) {
+	dir := prepareWltDir()
+	s, err := wallet.NewService(wallet.Config{
+		WalletDir:       dir,
+		CryptoType:      crypto.CryptoTypeSha256Xor,
+		EnableWalletAPI: true,
+	})
+	require.NoError(t, err)
+
+	_, err = s.CreateWallet("t.wlt", wallet.Options{
+		Type:  wallet.WalletTypeDeterministic,
+		Seed:  "seed",
+		Label: "label",
+	})
+	require.NoError(t, err)
+
+	_, err = s.GetUxOutsMeta("unknown.wlt")
+	require.Equal(t, wallet.ErrWalletNotExist, err)
+
+	m, err := s.GetUxOutsMeta("t.wlt")
+	require.NoError(t, err)
+	require.Empty(t, m)
+
+	h1 := testutil.RandSHA256(t)
+	h2 := testutil.RandSHA256(t)
+	h3 := testutil.RandSHA256(t)
+	unspents := map[cipher.SHA256]struct{}{
+		h1: {},
+		h2: {},
+		h3: {},
+	}
+
+	// Only unspent outputs of the wallet can be updated
+	_, err = s.UpdateUxOutsMeta("t.wlt", unspents, []cipher.SHA256{h1, testutil.RandSHA256(t)}, func(m *wallet.UxOutMeta) {
+		m.Frozen = true
+	})
+	require.Equal(t, wallet.ErrUnknownUxOut, err)
+
+	updated, err := s.UpdateUxOutsMeta("t.wlt", unspents, []cipher.SHA256{h1, h2}, func(m *wallet.UxOutMeta) {
+		m.Frozen = true
+		m.Tags = []string{"cold", "cold"}
+	})
+	require.NoError(t, err)
+	require.Equal(t, wallet.UxOutsMeta{
+		h1: {Frozen: true, Tags: []string{"cold"}},
+		h2: {Frozen: true, Tags: []string{"cold"}},
+	}, updated)
+
+	// Invalid metadata is not saved
+	_, err = s.UpdateUxOutsMeta("t.wlt", unspents, []cipher.SHA256{h1}, func(m *wallet.UxOutMeta) {
+		m.Tags = []string{""}
+	})
+	require.Equal(t, wallet.ErrInvalidUxOutTag, err)
+
+	// Metadata that becomes empty is removed
+	_, err = s.UpdateUxOutsMeta("t.wlt", unspents, []cipher.SHA256{h2}, func(m *wallet.UxOutMeta) {
+		m.Frozen = false
+		m.Tags = nil
+	})
+	require.NoError(t, err)
+
+	expect := wallet.UxOutsMeta{
+		h1: {Frozen: true, Tags: []string{"cold"}},
+	}
+
+	m, err = s.GetUxOutsMeta("t.wlt")
+	require.NoError(t, err)
+	require.Equal(t, expect, m)
+
+	// The metadata is persisted
+	s2, err := wallet.NewService(wallet.Config{
+		WalletDir:       dir,
+		CryptoType:      crypto.CryptoTypeSha256Xor,
+		EnableWalletAPI: true,
+	})
+	require.NoError(t, err)
+	m, err = s2.GetUxOutsMeta("t.wlt")
+	require.NoError(t, err)
+	require.Equal(t, expect, m)
+
+	// The metadata of spent outputs is pruned
+	delete(unspents, h1)
+	updated, err = s.UpdateUxOutsMeta("t.wlt", unspents, []cipher.SHA256{h3}, func(m *wallet.UxOutMeta) {
+		m.Label = "savings"
+	})
+	require.NoError(t, err)
+	require.Equal(t, wallet.UxOutsMeta{
+		h3: {Label: "savings"},
+	}, updated)
+
+	m, err = s.GetUxOutsMeta("t.wlt")
+	require.NoError(t, err)
+	require.Equal(t, updated, m)
+
+	// The file is removed when there is no metadata left
+	_, err = s.UpdateUxOutsMeta("t.wlt", unspents, []cipher.SHA256{h3}, func(m *wallet.UxOutMeta) {
+		*m = wallet.UxOutMeta{}
+	})
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "t.wlt."+wallet.UxOutsMetaExt))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestServiceCreateWalletStaleUxOutsMeta(t *testing.T) {
+	dir := prepareWltDir()
+	s, err := wallet.NewService(wallet.Config{
+		WalletDir:       dir,
+		CryptoType:      crypto.CryptoTypeSha256Xor,
+		EnableWalletAPI: true,
+	})
+	require.NoError(t, err)
+
+	_, err = s.CreateWallet("t.wlt", wallet.Options{
+		Type:  wallet.WalletTypeDeterministic,
+		Seed:  "seed",
+		Label: "label",
+	})
+	require.NoError(t, err)
+
+	h := testutil.RandSHA256(t)
+	_, err = s.UpdateUxOutsMeta("t.wlt", map[cipher.SHA256]struct{}{h: {}}, []cipher.SHA256{h}, func(m *wallet.UxOutMeta) {
+		m.Frozen = true
+	})
+	require.NoError(t, err)
+
+	// Unloading the wallet keeps its metadata
+	require.NoError(t, s.UnloadWallet("t.wlt"))
+	fn := filepath.Join(dir, "t.wlt."+wallet.UxOutsMetaExt)
+	_, err = os.Stat(fn)
+	require.NoError(t, err)
+
+	// A new wallet with the same ID doesn't inherit the metadata, which is backed up
+	_, err = s.CreateWallet("t.wlt", wallet.Options{
+		Type:  wallet.WalletTypeDeterministic,
+		Seed:  "seed2",
+		Label: "label",
+	})
+	require.NoError(t, err)
+
+	m, err := s.GetUxOutsMeta("t.wlt")
+	require.NoError(t, err)
+	require.Empty(t, m)
+
+	_, err = os.Stat(fn)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(fn + ".bak")
+	require.NoError(t, err)
+
+	s2, err := wallet.NewService(wallet.Config{
+		WalletDir:       dir,
+		CryptoType:      crypto.CryptoTypeSha256Xor,
+		EnableWalletAPI: true,
+	})
+	require.NoError(t, err)
+	m, err = s2.GetUxOutsMeta("t.wlt")
+	require.NoError(t, err)
+	require.Empty(t, m)
+}
@@ -19,6 +19,14 @@ var (
 	ErrWalletCantSign = NewError(errors.New("wallet does not have the signing capability"))
 )
 
+// ChangeAddressPeeker is implemented by wallets that send change to a dedicated
+// change chain, such as bip44 wallets
+type ChangeAddressPeeker interface {
+	// PeekChangeAddress returns a change chain address that has never been used,
+	// by a confirmed or an unconfirmed transaction
+	PeekChangeAddress(tf TransactionsFinder) (cipher.Addresser, error)
+}
+
 func validateSignIndexes(x []int, uxOuts []coin.UxOut) error {
 	if len(x) > len(uxOuts) {
 		return errors.New("Number of signature indexes exceeds number of inputs")
@@ -197,12 +205,16 @@ func SignTransaction(w Wallet, txn *coin.Transaction, signIndexes []int, uxOuts
 //     if the coinhour cost of adding that output is less than the coinhours that would be lost as change
 // If receiving hours are not explicitly specified, hours are allocated amongst the receiving outputs proportional to the number of coins being sent to them.
 // If the change address is not specified, the address whose bytes are lexically sorted first is chosen from the owners of the outputs being spent.
+// Outputs frozen in uxOutsMeta are excluded from auxs. Frozen outputs can only be spent by selecting them explicitly,
+// in which case the caller passes a nil uxOutsMeta.
 // WARNING: This method is not concurrent-safe if operating on the same wallet. Use Service.View or Service.ViewSecrets to lock the wallet, or use your own lock.
-func CreateTransaction(w Wallet, p transaction.Params, auxs coin.AddressUxOuts, headTime uint64) (*coin.Transaction, []transaction.UxBalance, error) {
+func CreateTransaction(w Wallet, p transaction.Params, auxs coin.AddressUxOuts, headTime uint64, uxOutsMeta UxOutsMeta) (*coin.Transaction, []transaction.UxBalance, error) {
 	if err := p.Validate(); err != nil {
 		return nil, nil, err
 	}
 
+	auxs = uxOutsMeta.ExcludeFrozen(auxs)
+
 	// Check that auxs does not contain addresses that are not known to this wallet
 	for a := range auxs {
 		has, err := w.HasEntry(a)
@@ -214,10 +226,11 @@ func CreateTransaction(w Wallet, p transaction.Params, auxs coin.AddressUxOuts,
 		}
 	}
 
-	// Generate a new change address for bip44 wallets
-	if p.ChangeAddress == nil && w.Type() == WalletTypeBip44 {
+	// Wallets with a change chain must be given a fresh change address by the caller,
+	// see ChangeAddressPeeker
+	if _, ok := w.(ChangeAddressPeeker); ok && p.ChangeAddress == nil {
 		err := errors.New("change address must not be nil")
-		logger.Critical().WithError(err).Error("CreateTransaction change address must not be nil for bip44 wallet")
+		logger.Critical().WithError(err).Error("CreateTransaction change address must not be nil for wallets with a change chain")
 		return nil, nil, err
 	}
 
@@ -227,8 +240,8 @@ func CreateTransaction(w Wallet, p transaction.Params, auxs coin.AddressUxOuts,
 // CreateTransactionSigned creates and signs a transaction based upon transaction.Params.
 // Set the password as nil if the wallet is not encrypted, otherwise the password must be provided.
 // Refer to CreateTransaction for information about transaction creation.
-func CreateTransactionSigned(w Wallet, p transaction.Params, auxs coin.AddressUxOuts, headTime uint64) (*coin.Transaction, []transaction.UxBalance, error) {
-	txn, uxb, err := CreateTransaction(w, p, auxs, headTime)
+func CreateTransactionSigned(w Wallet, p transaction.Params, auxs coin.AddressUxOuts, headTime uint64, uxOutsMeta UxOutsMeta) (*coin.Transaction, []transaction.UxBalance, error) {
+	txn, uxb, err := CreateTransaction(w, p, auxs, headTime, uxOutsMeta)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -416,6 +416,7 @@ func TestWalletCreateTransaction(t *testing.T) {
 		unspents        []coin.UxOut
 		addressUnspents coin.AddressUxOuts
 		chosenUnspents  []coin.UxOut
+		uxOutsMeta      wallet.UxOutsMeta
 		headTime        uint64
 		changeOutput    *coin.TransactionOutput
 		toExpectedHours []uint64
@@ -518,6 +519,28 @@ func TestWalletCreateTransaction(t *testing.T) {
 			err:      transaction.ErrInsufficientBalance,
 		},
 
+		{
+			name: "frozen unspents",
+			params: transaction.Params{
+				ChangeAddress: &changeAddress,
+				HoursSelection: transaction.HoursSelection{
+					Type: transaction.HoursSelectionTypeManual,
+				},
+				To: []coin.TransactionOutput{
+					{
+						Address: addrs[0],
+						Hours:   10,
+						Coins:   1e6,
+					},
+				},
+			},
+			unspents: uxouts[:1],
+			uxOutsMeta: wallet.UxOutsMeta{
+				uxouts[0].Hash(): {Frozen: true},
+			},
+			err: transaction.ErrNoUnspents,
+		},
+
 		{
 			name: "insufficient hours",
 			params: transaction.Params{
@@ -634,9 +657,9 @@ func TestWalletCreateTransaction(t *testing.T) {
 				var inputs []transaction.UxBalance
 				var err error
 				if unsigned {
-					txn, inputs, err = wallet.CreateTransaction(w, tc.params, addrUxOuts, tc.headTime)
+					txn, inputs, err = wallet.CreateTransaction(w, tc.params, addrUxOuts, tc.headTime, tc.uxOutsMeta)
 				} else {
-					txn, inputs, err = wallet.CreateTransactionSigned(w, tc.params, addrUxOuts, tc.headTime)
+					txn, inputs, err = wallet.CreateTransactionSigned(w, tc.params, addrUxOuts, tc.headTime, tc.uxOutsMeta)
 				}
 				require.Equal(t, tc.err, err, "%v != %v", tc.err, err)
 				if tc.err != nil {
@@ -0,0 +1,261 @@
+package wallet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/util/file"
+)
+
+// UxOutsMetaExt is the extension of the file that stores the unspent output metadata of a wallet.
+// The file is saved next to the wallet file, e.g. "2019_01_01_abcd.wlt.uxouts"
+const UxOutsMetaExt = "uxouts"
+
+// ErrInvalidUxOutTag is returned when an unspent output tag is empty
+var ErrInvalidUxOutTag = NewError(errors.New("unspent output tag must not be empty"))
+
+// UxOutMeta is the coin control metadata of an unspent output owned by a wallet
+type UxOutMeta struct {
+	// Frozen outputs are never chosen automatically when creating transactions
+	Frozen bool     `json:"frozen,omitempty"`
+	Label  string   `json:"label,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// IsEmpty returns true if the metadata has no value set
+func (m UxOutMeta) IsEmpty() bool {
+	return !m.Frozen && m.Label == "" && len(m.Tags) == 0
+}
+
+// Validate validates the metadata and normalizes the tags
+func (m *UxOutMeta) Validate() error {
+	tags := make(map[string]struct{}, len(m.Tags))
+	for _, t := range m.Tags {
+		if t == "" {
+			return ErrInvalidUxOutTag
+		}
+		tags[t] = struct{}{}
+	}
+
+	if len(tags) == 0 {
+		m.Tags = nil
+		return nil
+	}
+
+	m.Tags = make([]string, 0, len(tags))
+	for t := range tags {
+		m.Tags = append(m.Tags, t)
+	}
+	sort.Strings(m.Tags)
+	return nil
+}
+
+// UxOutsMeta maps unspent output hashes to their metadata
+type UxOutsMeta map[cipher.SHA256]UxOutMeta
+
+// Clone returns a copy of the UxOutsMeta
+func (m UxOutsMeta) Clone() UxOutsMeta {
+	mm := make(UxOutsMeta, len(m))
+	for k, v := range m {
+		v.Tags = append([]string(nil), v.Tags...)
+		mm[k] = v
+	}
+	return mm
+}
+
+// Frozen returns the hashes of the frozen outputs
+func (m UxOutsMeta) Frozen() map[cipher.SHA256]struct{} {
+	frozen := make(map[cipher.SHA256]struct{})
+	for k, v := range m {
+		if v.Frozen {
+			frozen[k] = struct{}{}
+		}
+	}
+	return frozen
+}
+
+// ExcludeFrozen returns a copy of auxs without the frozen outputs
+func (m UxOutsMeta) ExcludeFrozen(auxs coin.AddressUxOuts) coin.AddressUxOuts {
+	frozen := m.Frozen()
+	if len(frozen) == 0 {
+		return auxs
+	}
+
+	filtered := make(coin.AddressUxOuts, len(auxs))
+	for addr, uxs := range auxs {
+		var kept coin.UxArray
+		for _, ux := range uxs {
+			if _, ok := frozen[ux.Hash()]; ok {
+				continue
+			}
+			kept = append(kept, ux)
+		}
+
+		if len(kept) > 0 {
+			filtered[addr] = kept
+		}
+	}
+	return filtered
+}
+
+// uxOutsMetaPath returns the path of the file that stores the unspent outputs metadata of a wallet
+func uxOutsMetaPath(dir, wltID string) string {
+	return filepath.Join(dir, wltID+"."+UxOutsMetaExt)
+}
+
+// loadUxOutsMeta loads the unspent outputs metadata of a wallet.
+// An empty UxOutsMeta is returned if the file does not exist.
+func loadUxOutsMeta(dir, wltID string) (UxOutsMeta, error) {
+	fn := uxOutsMetaPath(dir, wltID)
+	if _, err := os.Stat(fn); os.IsNotExist(err) {
+		return UxOutsMeta{}, nil
+	}
+
+	var data map[string]UxOutMeta
+	if err := file.LoadJSON(fn, &data); err != nil {
+		logger.WithError(err).WithField("filename", fn).Error("loadUxOutsMeta: file.LoadJSON failed")
+		return nil, err
+	}
+
+	m := make(UxOutsMeta, len(data))
+	for k, v := range data {
+		h, err := cipher.SHA256FromHex(k)
+		if err != nil {
+			return nil, err
+		}
+		m[h] = v
+	}
+	return m, nil
+}
+
+// saveUxOutsMeta saves the unspent outputs metadata of a wallet, the file is removed if there is no metadata
+func saveUxOutsMeta(dir, wltID string, m UxOutsMeta) error {
+	fn := uxOutsMetaPath(dir, wltID)
+	if len(m) == 0 {
+		if err := os.Remove(fn); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data := make(map[string]UxOutMeta, len(m))
+	for k, v := range m {
+		data[k.Hex()] = v
+	}
+	return file.SaveJSON(fn, data, 0600)
+}
+
+// backupUxOutsMeta renames the unspent outputs metadata file of a wallet to a ".bak" file, if it exists.
+// A new wallet must not inherit the metadata left by a previous wallet file with the same ID,
+// e.g. one that was unloaded or deleted from the wallet directory.
+func backupUxOutsMeta(dir, wltID string) error {
+	fn := uxOutsMetaPath(dir, wltID)
+	if _, err := os.Stat(fn); os.IsNotExist(err) {
+		return nil
+	}
+
+	return os.Rename(fn, fn+".bak")
+}
+
+// getUxOutsMeta returns the cached unspent outputs metadata of a wallet, loading it from disk if needed
+func (serv *Service) getUxOutsMeta(wltID string) (UxOutsMeta, error) {
+	if m, ok := serv.uxOutsMeta[wltID]; ok {
+		return m, nil
+	}
+
+	m, err := loadUxOutsMeta(serv.config.WalletDir, wltID)
+	if err != nil {
+		return nil, err
+	}
+
+	serv.uxOutsMeta[wltID] = m
+	return m, nil
+}
+
+// GetUxOutsMeta returns the coin control metadata of the unspent outputs of a wallet
+func (serv *Service) GetUxOutsMeta(wltID string) (UxOutsMeta, error) {
+	serv.Lock()
+	defer serv.Unlock()
+	if !serv.config.EnableWalletAPI {
+		return nil, ErrWalletAPIDisabled
+	}
+
+	if _, err := serv.getWallet(wltID); err != nil {
+		return nil, err
+	}
+
+	m, err := serv.getUxOutsMeta(wltID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Clone(), nil
+}
+
+// UpdateUxOutsMeta applies f to the metadata of each of the given unspent outputs of a wallet and saves the result.
+// unspents are the hashes of the unspent outputs owned by the wallet. ErrUnknownUxOut is returned if
+// a hash is not in unspents, and the metadata of outputs that are not in unspents anymore is pruned.
+// Metadata that becomes empty is removed.
+func (serv *Service) UpdateUxOutsMeta(wltID string, unspents map[cipher.SHA256]struct{}, hashes []cipher.SHA256, f func(*UxOutMeta)) (UxOutsMeta, error) {
+	serv.Lock()
+	defer serv.Unlock()
+	if !serv.config.EnableWalletAPI {
+		return nil, ErrWalletAPIDisabled
+	}
+
+	if _, err := serv.getWallet(wltID); err != nil {
+		return nil, err
+	}
+
+	m, err := serv.getUxOutsMeta(wltID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range hashes {
+		if _, ok := unspents[h]; !ok {
+			return nil, ErrUnknownUxOut
+		}
+	}
+
+	// Work on a copy, so that the cached metadata is unchanged if saving fails
+	m = m.Clone()
+
+	// Prune the metadata of spent outputs
+	for h := range m {
+		if _, ok := unspents[h]; !ok {
+			delete(m, h)
+		}
+	}
+
+	for _, h := range hashes {
+		meta := m[h]
+		f(&meta)
+
+		if err := meta.Validate(); err != nil {
+			return nil, err
+		}
+
+		if meta.IsEmpty() {
+			delete(m, h)
+		} else {
+			m[h] = meta
+		}
+	}
+
+	if err := saveUxOutsMeta(serv.config.WalletDir, wltID, m); err != nil {
+		return nil, err
+	}
+
+	serv.uxOutsMeta[wltID] = m
+
+	updated := make(UxOutsMeta, len(hashes))
+	for _, h := range hashes {
+		updated[h] = m[h]
+	}
+	return updated.Clone(), nil
+}
@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/testutil"
+)
+
+func TestUxOutMetaValidate(t *testing.T) {
+	m := UxOutMeta{Tags: []string{"b", "a", "b"}}
+	require.NoError(t, m.Validate())
+	require.Equal(t, []string{"a", "b"}, m.Tags)
+
+	m = UxOutMeta{Tags: []string{}}
+	require.NoError(t, m.Validate())
+	require.Nil(t, m.Tags)
+	require.True(t, m.IsEmpty())
+
+	m = UxOutMeta{Tags: []string{"a", ""}}
+	require.Equal(t, ErrInvalidUxOutTag, m.Validate())
+}
+
+func TestUxOutsMetaExcludeFrozen(t *testing.T) {
+	addr1 := testutil.MakeAddress()
+	addr2 := testutil.MakeAddress()
+
+	mkUx := func(addr cipher.Address, coins uint64) coin.UxOut {
+		return coin.UxOut{
+			Head: coin.UxHead{BkSeq: 1},
+			Body: coin.UxBody{
+				SrcTransaction: testutil.RandSHA256(t),
+				Address:        addr,
+				Coins:          coins,
+			},
+		}
+	}
+
+	ux1 := mkUx(addr1, 1e6)
+	ux2 := mkUx(addr1, 2e6)
+	ux3 := mkUx(addr2, 3e6)
+
+	auxs := coin.AddressUxOuts{
+		addr1: coin.UxArray{ux1, ux2},
+		addr2: coin.UxArray{ux3},
+	}
+
+	// Nothing frozen
+	m := UxOutsMeta{ux1.Hash(): {Label: "a"}}
+	require.Equal(t, auxs, m.ExcludeFrozen(auxs))
+
+	m = UxOutsMeta{
+		ux1.Hash(): {Frozen: true},
+		ux3.Hash(): {Frozen: true},
+	}
+	require.Equal(t, coin.AddressUxOuts{
+		addr1: coin.UxArray{ux2},
+	}, m.ExcludeFrozen(auxs))
+}
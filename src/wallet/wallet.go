@@ -87,6 +87,10 @@ var (
 	ErrWalletRecoverSeedWrong = NewError(errors.New("wallet recovery seed or seed passphrase is wrong"))
 	// ErrWalletSeedPassphrase is returned when using seed passphrase for none bip44 wallet
 	ErrWalletSeedPassphrase = NewError(errors.New("seedPassphrase is only used for \"bip44\" wallets"))
+	// ErrWalletDescriptor is returned when using an output descriptor for a wallet that is not a bip44 or xpub wallet
+	ErrWalletDescriptor = NewError(errors.New("descriptor is only used for \"bip44\" and \"xpub\" wallets"))
+	// ErrWalletDescriptorUnknown is returned if the output descriptor of a wallet can't be determined
+	ErrWalletDescriptorUnknown = NewError(errors.New("wallet does not have an output descriptor"))
 	// ErrNilTransactionsFinder is returned if Options.ScanN > 0 but a nil TransactionsFinder was provided
 	ErrNilTransactionsFinder = NewError(errors.New("scan ahead requested but balance getter is nil"))
 	// ErrInvalidCoinType is returned for invalid coin types
@@ -154,6 +158,7 @@ type Options struct {
 	ScanN          uint64            // number of addresses that're going to be scanned for a balance. The highest address with a balance will be used.
 	GenerateN      uint64            // number of addresses to generate, regardless of balance
 	XPub           string            // xpub key (xpub wallets only)
+	Descriptor     string            // output descriptor (bip44 and xpub wallets only)
 	Decoder        Decoder
 	TF             TransactionsFinder
 }
@@ -162,6 +167,9 @@ func (opts Options) Validate() error {
 	if opts.Type == WalletTypeDeterministic && opts.SeedPassphrase != "" {
 		return ErrWalletSeedPassphrase
 	}
+	if opts.Descriptor != "" && opts.Type != WalletTypeBip44 && opts.Type != WalletTypeXPub {
+		return ErrWalletDescriptor
+	}
 	return nil
 }
 
@@ -197,6 +205,9 @@ type Wallet interface {
 	Secrets() string
 	// XPub returns the xpub key of a xpub wallet
 	XPub() string
+	// Descriptor returns the output descriptor of the addresses of a xpub wallet, or of
+	// the default account of a bip44 wallet. It is empty if it is not known.
+	Descriptor() string
 	// Lock encrypts the wallet
 	Lock(password []byte) error
 	// Unlock decrypts the wallets, returns an copy of the decrypted wallet
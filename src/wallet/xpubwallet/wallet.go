@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/cipher/bip32"
+	"github.com/skycoin/skycoin/src/cipher/descriptor"
 	"github.com/skycoin/skycoin/src/util/logging"
 	"github.com/skycoin/skycoin/src/util/mathutil"
 	"github.com/skycoin/skycoin/src/wallet"
@@ -21,6 +22,13 @@ const WalletType = "xpub"
 var defaultWalletDecoder = &JSONDecoder{}
 var logger = logging.MustGetLogger("xpubwallet")
 
+var (
+	// ErrDescriptorXPub is returned when creating a xpub wallet with both a xpub key and a descriptor
+	ErrDescriptorXPub = wallet.NewError(errors.New("xpub and descriptor can't be used together"))
+	// ErrDescriptorMismatch is returned if the xpub key of a xpub wallet is not the key of its descriptor
+	ErrDescriptorMismatch = wallet.NewError(errors.New("xpub key does not match the descriptor"))
+)
+
 func init() {
 	if err := wallet.RegisterCreator(WalletType, &Creator{}); err != nil {
 		panic(err)
@@ -42,7 +50,9 @@ type Wallet struct {
 	decoder wallet.Decoder
 }
 
-// NewWallet creates a xpub wallet with options
+// NewWallet creates a xpub wallet with options.
+// The output descriptor that the xpub key was derived from can be set with wallet.OptionDescriptor,
+// to record the origin of the key. The xpub key must be the key of the first chain of the descriptor.
 func NewWallet(filename, label, xPub string, options ...wallet.Option) (*Wallet, error) {
 	if xPub == "" {
 		return nil, wallet.ErrMissingXPub
@@ -73,6 +83,24 @@ func NewWallet(filename, label, xPub string, options ...wallet.Option) (*Wallet,
 		opt(advOpts)
 	}
 
+	if advOpts.Descriptor != "" {
+		d, err := chainDescriptor(advOpts.Descriptor)
+		if err != nil {
+			return nil, err
+		}
+
+		k, err := d.ChainKey(0)
+		if err != nil {
+			return nil, wallet.NewError(err)
+		}
+
+		if k.String() != xPub {
+			return nil, ErrDescriptorMismatch
+		}
+
+		wlt.Meta[wallet.MetaDescriptor] = d.String()
+	}
+
 	if err := validateMeta(wlt.Meta); err != nil {
 		return nil, err
 	}
@@ -103,6 +131,38 @@ func NewWallet(filename, label, xPub string, options ...wallet.Option) (*Wallet,
 	return wlt, nil
 }
 
+// chainDescriptor parses a descriptor, and returns the descriptor of its first chain
+func chainDescriptor(s string) (*descriptor.Descriptor, error) {
+	d, err := descriptor.Parse(s)
+	if err != nil {
+		return nil, wallet.NewError(err)
+	}
+
+	if len(d.Chains) > 0 {
+		d.Path = append(d.Path, d.Chains[0])
+		d.Chains = nil
+	}
+
+	return d, nil
+}
+
+// Descriptor returns the output descriptor of the wallet's addresses. If the wallet was not
+// created from a descriptor, the descriptor of the xpub key has no key origin.
+func (w Wallet) Descriptor() string {
+	if d := w.Meta.Descriptor(); d != "" {
+		return d
+	}
+
+	if w.xpub == nil {
+		return ""
+	}
+
+	d := descriptor.Descriptor{
+		XPub: w.xpub,
+	}
+	return d.String()
+}
+
 // SetDecoder sets the wallet decoder
 func (w *Wallet) SetDecoder(d wallet.Decoder) {
 	w.decoder = d
@@ -427,10 +487,28 @@ func (c Creator) Create(filename, label, _ string, options wallet.Options) (wall
 		return nil, err
 	}
 
+	xPub := options.XPub
+	if options.Descriptor != "" {
+		if xPub != "" {
+			return nil, ErrDescriptorXPub
+		}
+
+		d, err := chainDescriptor(options.Descriptor)
+		if err != nil {
+			return nil, err
+		}
+
+		k, err := d.ChainKey(0)
+		if err != nil {
+			return nil, wallet.NewError(err)
+		}
+		xPub = k.String()
+	}
+
 	return NewWallet(
 		filename,
 		label,
-		options.XPub,
+		xPub,
 		convertOptions(options)...)
 }
 
@@ -453,6 +531,10 @@ func convertOptions(options wallet.Options) []wallet.Option {
 		opts = append(opts, wallet.OptionDecoder(options.Decoder))
 	}
 
+	if options.Descriptor != "" {
+		opts = append(opts, wallet.OptionDescriptor(options.Descriptor))
+	}
+
 	if options.GenerateN > 0 {
 		opts = append(opts, wallet.OptionGenerateN(options.GenerateN))
 	}
@@ -9,6 +9,10 @@ import (
 	"testing"
 
 	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/cipher/bip32"
+	"github.com/skycoin/skycoin/src/cipher/bip39"
+	"github.com/skycoin/skycoin/src/cipher/bip44"
+	"github.com/skycoin/skycoin/src/cipher/descriptor"
 	"github.com/skycoin/skycoin/src/wallet"
 	"github.com/stretchr/testify/require"
 )
@@ -150,6 +154,85 @@ func TestNewWallet(t *testing.T) {
 	}
 }
 
+func TestCreateDescriptor(t *testing.T) {
+	// testXPub is the external chain key of the first bip44 account of the seed
+	seed, err := bip39.NewSeed("attitude coach wet rely typical habit alien security deny imitate spike slab", "pwd")
+	require.NoError(t, err)
+	master, err := bip32.NewMasterKey(seed)
+	require.NoError(t, err)
+	accountDesc, err := descriptor.NewBip44(master, bip44.CoinTypeSkycoin, 0)
+	require.NoError(t, err)
+
+	externalDesc := *accountDesc
+	externalDesc.Path = []uint32{0}
+	externalDesc.Chains = nil
+
+	tt := []struct {
+		name       string
+		xpub       string
+		descriptor string
+		expect     string
+		err        error
+	}{
+		{
+			name:   "xpub",
+			xpub:   testXPub,
+			expect: "pkh(" + testXPub + "/*)#egg28u6v",
+		},
+		{
+			name:       "account descriptor",
+			descriptor: accountDesc.String(),
+			expect:     externalDesc.String(),
+		},
+		{
+			name:       "external chain descriptor",
+			descriptor: externalDesc.String(),
+			expect:     externalDesc.String(),
+		},
+		{
+			name:       "xpub and descriptor",
+			xpub:       testXPub,
+			descriptor: accountDesc.String(),
+			err:        ErrDescriptorXPub,
+		},
+		{
+			name:       "invalid descriptor",
+			descriptor: "pkh(" + testXPub + "/0)",
+			err:        wallet.NewError(descriptor.ErrMissingWildcard),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := Creator{}.Create("test.wlt", "test", "", wallet.Options{
+				XPub:       tc.xpub,
+				Descriptor: tc.descriptor,
+				GenerateN:  2,
+			})
+			require.Equal(t, tc.err, err)
+			if err != nil {
+				return
+			}
+
+			require.Equal(t, testXPub, w.XPub())
+			require.Equal(t, tc.expect, w.Descriptor())
+
+			addrs, err := w.GetAddresses()
+			require.NoError(t, err)
+			require.Equal(t, testSkycoinAddresses[:2], addrs)
+		})
+	}
+
+	// The xpub key must be the key of the descriptor's first chain
+	_, err = NewWallet("test.wlt", "test", testXPub, wallet.OptionDescriptor(accountDesc.String()))
+	require.NoError(t, err)
+
+	changeDesc := *accountDesc
+	changeDesc.Chains = []uint32{1, 0}
+	_, err = NewWallet("test.wlt", "test", testXPub, wallet.OptionDescriptor(changeDesc.String()))
+	require.Equal(t, ErrDescriptorMismatch, err)
+}
+
 func TestWalletGenerateAddresses(t *testing.T) {
 	tt := []struct {
 		name               string
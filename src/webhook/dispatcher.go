@@ -0,0 +1,751 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/util/amount"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// Config configures the Dispatcher
+type Config struct {
+	// CheckInterval is how often webhooks are checked for new events and pending deliveries are sent
+	CheckInterval time.Duration
+	// MaxAttempts is the number of attempts to send a delivery before it fails
+	MaxAttempts int
+	// RetryInterval is the delay before the second attempt of a delivery.
+	// The delay doubles after each failed attempt, up to MaxRetryInterval.
+	RetryInterval time.Duration
+	// MaxRetryInterval is the maximum delay between two attempts of a delivery
+	MaxRetryInterval time.Duration
+	// Timeout is the timeout of a delivery request
+	Timeout time.Duration
+	// AllowPrivateURLs allows deliveries to loopback, link-local, private and other non-public addresses.
+	// They are refused by default, so that webhooks can't be used to reach the services of the node's network.
+	AllowPrivateURLs bool
+	// MaxDeliveries is the number of delivered and failed deliveries kept for each webhook.
+	// Older finished deliveries are deleted.
+	MaxDeliveries int
+}
+
+// NewConfig creates a default Config
+func NewConfig() Config {
+	return Config{
+		CheckInterval:    time.Second * 10,
+		MaxAttempts:      8,
+		RetryInterval:    time.Second * 10,
+		MaxRetryInterval: time.Hour,
+		Timeout:          time.Second * 10,
+		MaxDeliveries:    100,
+	}
+}
+
+// Visorer is the interface of the visor.Visor methods used by Dispatcher
+type Visorer interface {
+	HeadBkSeq() (uint64, bool, error)
+	GetSignedBlockBySeq(seq uint64) (*coin.SignedBlock, error)
+	GetTransaction(txid cipher.SHA256) (*visor.Transaction, error)
+}
+
+// Dispatcher stores webhooks, checks them for events and sends the deliveries of the events
+type Dispatcher struct {
+	config Config
+	db     *dbutil.DB
+	visor  Visorer
+	client *http.Client
+	now    func() time.Time
+
+	// checkLock serializes the checks of webhooks, so that an event is never stored twice
+	checkLock sync.Mutex
+	// sendLock serializes the sending of deliveries, so that a delivery is never sent concurrently
+	sendLock sync.Mutex
+
+	quit     chan struct{}
+	quitOnce sync.Once
+}
+
+// NewDispatcher creates a Dispatcher
+func NewDispatcher(c Config, db *dbutil.DB, v Visorer) (*Dispatcher, error) {
+	if c.MaxAttempts <= 0 {
+		return nil, errors.New("MaxAttempts must be > 0")
+	}
+	if c.RetryInterval <= 0 {
+		return nil, errors.New("RetryInterval must be > 0")
+	}
+	if c.MaxRetryInterval < c.RetryInterval {
+		return nil, errors.New("MaxRetryInterval must be >= RetryInterval")
+	}
+	if c.Timeout <= 0 {
+		return nil, errors.New("Timeout must be > 0")
+	}
+	if c.MaxDeliveries <= 0 {
+		return nil, errors.New("MaxDeliveries must be > 0")
+	}
+
+	return &Dispatcher{
+		config: c,
+		db:     db,
+		visor:  v,
+		client: newHTTPClient(c.Timeout, c.AllowPrivateURLs),
+		now: func() time.Time {
+			return time.Now().UTC()
+		},
+		quit: make(chan struct{}),
+	}, nil
+}
+
+// newHTTPClient creates the client which sends the deliveries. Redirects are not followed.
+// Unless allowPrivate is true, connections to non-public addresses are refused, see isPrivateIP.
+// The addresses are checked after the host is resolved, so that a host name can't resolve to a refused address.
+func newHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+	}
+
+	if !allowPrivate {
+		dialer.Control = func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+				return ErrPrivateURL
+			}
+
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		// No proxy is used, since the proxy would connect to the webhook URL without checking its address
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// privateIPNets are the non-public address ranges which are not covered by the net.IP methods.
+// The IPv4 ranges also match the IPv4-mapped IPv6 addresses.
+var privateIPNets = []*net.IPNet{
+	// "This network" (RFC 1122)
+	mustParseCIDR("0.0.0.0/8"),
+	// Private networks (RFC 1918)
+	mustParseCIDR("10.0.0.0/8"),
+	mustParseCIDR("172.16.0.0/12"),
+	mustParseCIDR("192.168.0.0/16"),
+	// Shared address space of carrier-grade NAT (RFC 6598)
+	mustParseCIDR("100.64.0.0/10"),
+	// Link-local, which includes the metadata services of cloud providers (RFC 3927)
+	mustParseCIDR("169.254.0.0/16"),
+	// Benchmarking (RFC 2544)
+	mustParseCIDR("198.18.0.0/15"),
+	// Reserved (RFC 1112), including the broadcast address
+	mustParseCIDR("240.0.0.0/4"),
+	// Unique local addresses (RFC 4193)
+	mustParseCIDR("fc00::/7"),
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// isPrivateIP returns true if ip is a loopback, link-local, private, multicast, unspecified
+// or other non-public address
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+
+	for _, n := range privateIPNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Run periodically checks webhooks for events and sends pending deliveries, until Shutdown is called
+func (d *Dispatcher) Run() {
+	if d.config.CheckInterval <= 0 {
+		return
+	}
+
+	logger.Info("Webhook dispatcher started")
+	defer logger.Info("Webhook dispatcher stopped")
+
+	ticker := time.NewTicker(d.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.quit:
+			return
+		case <-ticker.C:
+			if err := d.CheckWebhooks(); err != nil {
+				logger.WithError(err).Error("CheckWebhooks failed")
+			}
+			if err := d.SendDeliveries(); err != nil {
+				logger.WithError(err).Error("SendDeliveries failed")
+			}
+		}
+	}
+}
+
+// Shutdown stops Run
+func (d *Dispatcher) Shutdown() {
+	d.quitOnce.Do(func() {
+		close(d.quit)
+	})
+}
+
+// CreateWebhook registers a webhook
+func (d *Dispatcher) CreateWebhook(r Request) (*Webhook, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	// URLs with a host name are checked when the deliveries are sent, once the host is resolved
+	if !d.config.AllowPrivateURLs {
+		if ip := net.ParseIP(r.host()); ip != nil && isPrivateIP(ip) {
+			return nil, ErrPrivateURL
+		}
+	}
+
+	headSeq, _, err := d.visor.HeadBkSeq()
+	if err != nil {
+		return nil, err
+	}
+
+	w := newWebhook(r, headSeq, d.now())
+	if err := d.db.Update("CreateWebhook", func(tx *dbutil.Tx) error {
+		return putWebhook(tx, w)
+	}); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// GetWebhook returns the webhook with the given ID
+func (d *Dispatcher) GetWebhook(id string) (*Webhook, error) {
+	var w *Webhook
+	if err := d.db.View("GetWebhook", func(tx *dbutil.Tx) error {
+		var err error
+		w, err = getWebhook(tx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if w == nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	return w, nil
+}
+
+// GetWebhooks returns all webhooks, oldest first
+func (d *Dispatcher) GetWebhooks() ([]Webhook, error) {
+	var webhooks []Webhook
+	if err := d.db.View("GetWebhooks", func(tx *dbutil.Tx) error {
+		var err error
+		webhooks, err = getWebhooks(tx, nil)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes a webhook and its deliveries
+func (d *Dispatcher) DeleteWebhook(id string) error {
+	return d.db.Update("DeleteWebhook", func(tx *dbutil.Tx) error {
+		w, err := getWebhook(tx, id)
+		if err != nil {
+			return err
+		}
+		if w == nil {
+			return ErrWebhookNotFound
+		}
+
+		return deleteWebhook(tx, id)
+	})
+}
+
+// GetWebhookDeliveries returns the deliveries of a webhook, newest first
+func (d *Dispatcher) GetWebhookDeliveries(id string) ([]Delivery, error) {
+	var deliveries []Delivery
+	if err := d.db.View("GetWebhookDeliveries", func(tx *dbutil.Tx) error {
+		w, err := getWebhook(tx, id)
+		if err != nil {
+			return err
+		}
+		if w == nil {
+			return ErrWebhookNotFound
+		}
+
+		deliveries, err = getDeliveries(tx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// CheckWebhooks checks the blocks executed since the last check for payments to the watched addresses,
+// and the transactions watched by active transaction webhooks. A delivery is stored for each event.
+func (d *Dispatcher) CheckWebhooks() error {
+	d.checkLock.Lock()
+	defer d.checkLock.Unlock()
+
+	headSeq, ok, err := d.visor.HeadBkSeq()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	var webhooks []Webhook
+	var lastSeq uint64
+	var hasLastSeq bool
+	if err := d.db.View("CheckWebhooks", func(tx *dbutil.Tx) error {
+		var err error
+		webhooks, err = getWebhooks(tx, func(w *Webhook) bool {
+			return w.Status == StatusActive
+		})
+		if err != nil {
+			return err
+		}
+
+		lastSeq, hasLastSeq, err = getLastBlockSeq(tx)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	// On the first check, start from the block at which the oldest payment_received webhook was created,
+	// since the payments in earlier blocks are not reported
+	if !hasLastSeq {
+		lastSeq = headSeq
+		for _, w := range webhooks {
+			if w.Event == EventPaymentReceived && w.BlockSeq < lastSeq {
+				lastSeq = w.BlockSeq
+			}
+		}
+
+		if err := d.db.Update("CheckWebhooks", func(tx *dbutil.Tx) error {
+			return setLastBlockSeq(tx, lastSeq)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := d.checkPayments(webhooks, lastSeq, headSeq); err != nil {
+		return err
+	}
+
+	for i := range webhooks {
+		w := &webhooks[i]
+		if w.Event == EventPaymentReceived {
+			continue
+		}
+
+		if err := d.checkTransaction(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkPayments stores a delivery for each transaction with outputs to the addresses of a
+// payment_received webhook, in the blocks after lastSeq up to headSeq.
+// The blocks are checked one at a time. The deliveries of a block and its sequence are stored at once,
+// so that an event is never stored twice.
+func (d *Dispatcher) checkPayments(webhooks []Webhook, lastSeq, headSeq uint64) error {
+	if headSeq <= lastSeq {
+		return nil
+	}
+
+	watched := make(map[string][]*Webhook)
+	for i := range webhooks {
+		w := &webhooks[i]
+		if w.Event != EventPaymentReceived {
+			continue
+		}
+		for _, a := range w.Addresses {
+			watched[a] = append(watched[a], w)
+		}
+	}
+
+	// If no address is watched, the blocks are skipped
+	if len(watched) == 0 {
+		return d.db.Update("checkPayments", func(tx *dbutil.Tx) error {
+			return setLastBlockSeq(tx, headSeq)
+		})
+	}
+
+	for seq := lastSeq + 1; seq <= headSeq; seq++ {
+		select {
+		case <-d.quit:
+			return nil
+		default:
+		}
+
+		b, err := d.visor.GetSignedBlockBySeq(seq)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("block %d not found", seq)
+		}
+
+		deliveries, err := d.paymentDeliveries(watched, b.Block)
+		if err != nil {
+			return err
+		}
+
+		if err := d.db.Update("checkPayments", func(tx *dbutil.Tx) error {
+			for _, dv := range deliveries {
+				// The webhook may have been deleted since it was loaded
+				w, err := getWebhook(tx, dv.WebhookID)
+				if err != nil {
+					return err
+				}
+				if w == nil {
+					continue
+				}
+
+				if err := addDelivery(tx, dv); err != nil {
+					return err
+				}
+			}
+
+			return setLastBlockSeq(tx, seq)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// paymentDeliveries creates the payment_received deliveries of a block
+func (d *Dispatcher) paymentDeliveries(watched map[string][]*Webhook, b coin.Block) ([]*Delivery, error) {
+	var deliveries []*Delivery
+	for _, txn := range b.Body.Transactions {
+		txid := txn.Hash()
+
+		var order []*Webhook
+		outputs := make(map[*Webhook][]PaymentOutput)
+		for _, ux := range coin.CreateUnspents(b.Head, txn) {
+			addr := ux.Body.Address.String()
+			for _, w := range watched[addr] {
+				if b.Head.BkSeq <= w.BlockSeq {
+					continue
+				}
+
+				coins, err := amount.Amount(ux.Body.Coins).ToString()
+				if err != nil {
+					return nil, err
+				}
+
+				if _, ok := outputs[w]; !ok {
+					order = append(order, w)
+				}
+				outputs[w] = append(outputs[w], PaymentOutput{
+					UxID:    ux.Hash().Hex(),
+					Address: addr,
+					Coins:   coins,
+					Hours:   ux.Body.Hours,
+				})
+			}
+		}
+
+		for _, w := range order {
+			dv, err := d.newDelivery(w, PaymentReceivedData{
+				TxID:     txid.Hex(),
+				BlockSeq: b.Head.BkSeq,
+				Outputs:  outputs[w],
+			})
+			if err != nil {
+				return nil, err
+			}
+			deliveries = append(deliveries, dv)
+		}
+	}
+
+	return deliveries, nil
+}
+
+// checkTransaction checks the transaction of a txn_confirmations or txn_dropped webhook.
+// If the event happened, a delivery is stored and the webhook is completed.
+func (d *Dispatcher) checkTransaction(w *Webhook) error {
+	txid, err := cipher.SHA256FromHex(w.TxID)
+	if err != nil {
+		return err
+	}
+
+	txn, err := d.visor.GetTransaction(txid)
+	if err != nil {
+		return err
+	}
+
+	var data interface{}
+	switch w.Event {
+	case EventTxnConfirmations:
+		if txn == nil || !txn.Status.Confirmed || txn.Status.Height < w.Confirmations {
+			return nil
+		}
+		data = TxnConfirmationsData{
+			TxID:          w.TxID,
+			BlockSeq:      txn.Status.BlockSeq,
+			Confirmations: txn.Status.Height,
+		}
+		w.Status = StatusCompleted
+
+	case EventTxnDropped:
+		switch {
+		case txn == nil && w.SeenUnconfirmed:
+			data = TxnDroppedData{
+				TxID: w.TxID,
+			}
+			w.Status = StatusCompleted
+		case txn == nil:
+			// The transaction has not been received yet
+			return nil
+		case txn.Status.Confirmed:
+			// An executed transaction can not be dropped
+			w.Status = StatusCompleted
+		case w.SeenUnconfirmed:
+			return nil
+		default:
+			w.SeenUnconfirmed = true
+		}
+
+	default:
+		return nil
+	}
+
+	var dv *Delivery
+	if data != nil {
+		dv, err = d.newDelivery(w, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.UpdatedAt = d.now()
+
+	return d.db.Update("checkTransaction", func(tx *dbutil.Tx) error {
+		// The webhook may have been deleted since it was loaded
+		existing, err := getWebhook(tx, w.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return nil
+		}
+
+		if dv != nil {
+			if err := addDelivery(tx, dv); err != nil {
+				return err
+			}
+		}
+
+		return putWebhook(tx, w)
+	})
+}
+
+// newDelivery creates a pending delivery of an event
+func (d *Dispatcher) newDelivery(w *Webhook, data interface{}) (*Delivery, error) {
+	now := d.now()
+	id := randomHex(16)
+
+	body, err := json.Marshal(Payload{
+		ID:        id,
+		WebhookID: w.ID,
+		Event:     w.Event,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Delivery{
+		ID:            id,
+		WebhookID:     w.ID,
+		Event:         w.Event,
+		Status:        DeliveryStatusPending,
+		Body:          body,
+		Attempts:      []Attempt{},
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// SendDeliveries sends the pending deliveries whose next attempt is due.
+// A delivery succeeds if the URL responds with a 2xx status code. Failed deliveries are
+// retried with exponential backoff, until the maximum number of attempts is reached.
+func (d *Dispatcher) SendDeliveries() error {
+	d.sendLock.Lock()
+	defer d.sendLock.Unlock()
+
+	var deliveries []Delivery
+	if err := d.db.View("SendDeliveries", func(tx *dbutil.Tx) error {
+		var err error
+		deliveries, err = getPendingDeliveries(tx)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		dv := &deliveries[i]
+
+		select {
+		case <-d.quit:
+			return nil
+		default:
+		}
+
+		if d.now().Before(dv.NextAttemptAt) {
+			continue
+		}
+
+		w, err := d.GetWebhook(dv.WebhookID)
+		if err != nil {
+			if err == ErrWebhookNotFound {
+				continue
+			}
+			return err
+		}
+
+		d.send(w, dv)
+
+		if err := d.db.Update("SendDeliveries", func(tx *dbutil.Tx) error {
+			// The webhook may have been deleted while the delivery was sent
+			existing, err := getWebhook(tx, w.ID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return nil
+			}
+
+			if err := putDelivery(tx, dv); err != nil {
+				return err
+			}
+
+			if dv.Status == DeliveryStatusPending {
+				return nil
+			}
+
+			return pruneDeliveries(tx, w.ID, d.config.MaxDeliveries)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send makes an attempt to send a delivery, and updates the delivery with its result
+func (d *Dispatcher) send(w *Webhook, dv *Delivery) {
+	attempt := Attempt{
+		Time: d.now(),
+	}
+
+	statusCode, err := d.post(w, dv)
+	attempt.StatusCode = statusCode
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	dv.Attempts = append(dv.Attempts, attempt)
+	dv.UpdatedAt = d.now()
+
+	switch {
+	case err == nil:
+		dv.Status = DeliveryStatusDelivered
+	case len(dv.Attempts) >= d.config.MaxAttempts:
+		logger.WithError(err).WithField("webhookID", w.ID).WithField("deliveryID", dv.ID).Warning("Webhook delivery failed")
+		dv.Status = DeliveryStatusFailed
+	default:
+		dv.NextAttemptAt = dv.UpdatedAt.Add(d.retryDelay(len(dv.Attempts)))
+	}
+}
+
+// post sends the body of a delivery to the webhook URL. Returns the response status code.
+// Returns an error if the request failed or the status code is not 2xx.
+func (d *Dispatcher) post(w *Webhook, dv *Delivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(dv.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(dv.Event))
+	req.Header.Set(DeliveryHeader, dv.ID)
+	req.Header.Set(SignatureHeader, Sign(w.Secret, dv.Body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16)) //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryDelay returns the delay before the next attempt of a delivery that failed n times
+func (d *Dispatcher) retryDelay(n int) time.Duration {
+	delay := d.config.RetryInterval
+	for i := 1; i < n && delay < d.config.MaxRetryInterval; i++ {
+		delay *= 2
+	}
+
+	if delay > d.config.MaxRetryInterval {
+		delay = d.config.MaxRetryInterval
+	}
+
+	return delay
+}
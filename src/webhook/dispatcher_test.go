@@ -0,0 +1,953 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skycoin/src/testutil"
+	"github.com/skycoin/skycoin/src/visor"
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+// fakeVisor implements Visorer
+type fakeVisor struct {
+	blocks      []coin.SignedBlock
+	unconfirmed map[cipher.SHA256]coin.Transaction
+	// blockRequests is the number of GetSignedBlockBySeq calls
+	blockRequests int
+	// blockErrs are the errors returned by GetSignedBlockBySeq, by block sequence
+	blockErrs map[uint64]error
+}
+
+func newFakeVisor() *fakeVisor {
+	v := &fakeVisor{
+		unconfirmed: make(map[cipher.SHA256]coin.Transaction),
+	}
+	v.addBlock()
+	return v
+}
+
+// addBlock adds a block executing txns, removing them from the unconfirmed transactions
+func (v *fakeVisor) addBlock(txns ...coin.Transaction) coin.Block {
+	seq := uint64(len(v.blocks))
+	b := coin.Block{
+		Head: coin.BlockHeader{
+			BkSeq: seq,
+			Time:  1e9 + seq*10,
+		},
+		Body: coin.BlockBody{
+			Transactions: txns,
+		},
+	}
+
+	for _, txn := range txns {
+		delete(v.unconfirmed, txn.Hash())
+	}
+
+	v.blocks = append(v.blocks, coin.SignedBlock{Block: b})
+	return b
+}
+
+func (v *fakeVisor) HeadBkSeq() (uint64, bool, error) {
+	if len(v.blocks) == 0 {
+		return 0, false, nil
+	}
+	return uint64(len(v.blocks) - 1), true, nil
+}
+
+func (v *fakeVisor) GetSignedBlockBySeq(seq uint64) (*coin.SignedBlock, error) {
+	v.blockRequests++
+	if err := v.blockErrs[seq]; err != nil {
+		return nil, err
+	}
+	if seq >= uint64(len(v.blocks)) {
+		return nil, nil
+	}
+	return &v.blocks[seq], nil
+}
+
+func (v *fakeVisor) GetTransaction(txid cipher.SHA256) (*visor.Transaction, error) {
+	if txn, ok := v.unconfirmed[txid]; ok {
+		return &visor.Transaction{
+			Transaction: txn,
+			Status:      visor.NewUnconfirmedTransactionStatus(),
+		}, nil
+	}
+
+	for _, b := range v.blocks {
+		for _, txn := range b.Block.Body.Transactions {
+			if txn.Hash() == txid {
+				return &visor.Transaction{
+					Transaction: txn,
+					Status:      visor.NewConfirmedTransactionStatus(uint64(len(v.blocks))-b.Block.Head.BkSeq, b.Block.Head.BkSeq),
+				}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func makeTransaction(t *testing.T, to ...coin.TransactionOutput) coin.Transaction {
+	txn := coin.Transaction{}
+	err := txn.PushInput(testutil.RandSHA256(t))
+	require.NoError(t, err)
+	for _, o := range to {
+		err := txn.PushOutput(o.Address, o.Coins, o.Hours)
+		require.NoError(t, err)
+	}
+	err = txn.UpdateHeader()
+	require.NoError(t, err)
+	return txn
+}
+
+// receivedDelivery is a delivery received by the test server
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+// testServer records the deliveries it receives, and responds with the status codes of statuses
+// before responding with 200
+type testServer struct {
+	*httptest.Server
+	sync.Mutex
+	statuses  []int
+	delivered []receivedDelivery
+}
+
+func newTestServer(t *testing.T, statuses ...int) *testServer {
+	s := &testServer{
+		statuses: statuses,
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		s.Lock()
+		defer s.Unlock()
+
+		s.delivered = append(s.delivered, receivedDelivery{
+			header: r.Header,
+			body:   body,
+		})
+
+		if len(s.statuses) != 0 {
+			status := s.statuses[0]
+			s.statuses = s.statuses[1:]
+			w.WriteHeader(status)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return s
+}
+
+func (s *testServer) deliveries() []receivedDelivery {
+	s.Lock()
+	defer s.Unlock()
+	return append([]receivedDelivery{}, s.delivered...)
+}
+
+func newTestDispatcher(t *testing.T, v *fakeVisor) (*Dispatcher, *time.Time, func()) {
+	return newTestDispatcherAllowPrivateURLs(t, v, true)
+}
+
+func newTestDispatcherAllowPrivateURLs(t *testing.T, v *fakeVisor, allowPrivateURLs bool) (*Dispatcher, *time.Time, func()) {
+	db, shutdown := testutil.PrepareDB(t)
+
+	c := NewConfig()
+	c.MaxAttempts = 3
+	c.RetryInterval = time.Minute
+	c.MaxRetryInterval = time.Minute * 3
+	// The test servers listen on the loopback address
+	c.AllowPrivateURLs = allowPrivateURLs
+
+	d, err := NewDispatcher(c, db, v)
+	require.NoError(t, err)
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.now = func() time.Time {
+		return now
+	}
+
+	return d, &now, shutdown
+}
+
+// requireDelivery checks the headers and signature of a received delivery and returns its payload
+func requireDelivery(t *testing.T, w *Webhook, rd receivedDelivery, data interface{}) Payload {
+	require.Equal(t, "application/json", rd.header.Get("Content-Type"))
+	require.Equal(t, string(w.Event), rd.header.Get(EventHeader))
+	require.True(t, Verify(w.Secret, rd.body, rd.header.Get(SignatureHeader)))
+
+	p := Payload{
+		Data: data,
+	}
+	err := json.Unmarshal(rd.body, &p)
+	require.NoError(t, err)
+	require.Equal(t, w.ID, p.WebhookID)
+	require.Equal(t, w.Event, p.Event)
+	require.Equal(t, p.ID, rd.header.Get(DeliveryHeader))
+
+	return p
+}
+
+func TestNewDispatcher(t *testing.T) {
+	db, shutdown := testutil.PrepareDB(t)
+	defer shutdown()
+
+	c := NewConfig()
+	c.MaxAttempts = 0
+	_, err := NewDispatcher(c, db, newFakeVisor())
+	require.Error(t, err)
+
+	c = NewConfig()
+	c.MaxRetryInterval = c.RetryInterval - 1
+	_, err = NewDispatcher(c, db, newFakeVisor())
+	require.Error(t, err)
+
+	c = NewConfig()
+	c.MaxDeliveries = 0
+	_, err = NewDispatcher(c, db, newFakeVisor())
+	require.Error(t, err)
+
+	_, err = NewDispatcher(NewConfig(), db, newFakeVisor())
+	require.NoError(t, err)
+}
+
+func TestWebhooks(t *testing.T) {
+	d, _, shutdown := newTestDispatcher(t, newFakeVisor())
+	defer shutdown()
+
+	webhooks, err := d.GetWebhooks()
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+
+	_, err = d.CreateWebhook(Request{
+		URL:   "foo",
+		Event: EventPaymentReceived,
+	})
+	require.Equal(t, ErrInvalidURL, err)
+
+	w1, err := d.CreateWebhook(Request{
+		URL:       "http://127.0.0.1/hook",
+		Event:     EventPaymentReceived,
+		Addresses: []cipher.Address{testutil.MakeAddress()},
+	})
+	require.NoError(t, err)
+
+	w2, err := d.CreateWebhook(Request{
+		URL:   "http://127.0.0.1/hook",
+		Event: EventTxnDropped,
+		TxID:  testutil.RandSHA256(t),
+	})
+	require.NoError(t, err)
+
+	w, err := d.GetWebhook(w1.ID)
+	require.NoError(t, err)
+	require.Equal(t, *w1, *w)
+
+	webhooks, err = d.GetWebhooks()
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+
+	deliveries, err := d.GetWebhookDeliveries(w2.ID)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	err = d.DeleteWebhook(w1.ID)
+	require.NoError(t, err)
+
+	_, err = d.GetWebhook(w1.ID)
+	require.Equal(t, ErrWebhookNotFound, err)
+
+	_, err = d.GetWebhookDeliveries(w1.ID)
+	require.Equal(t, ErrWebhookNotFound, err)
+
+	err = d.DeleteWebhook(w1.ID)
+	require.Equal(t, ErrWebhookNotFound, err)
+
+	webhooks, err = d.GetWebhooks()
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, w2.ID, webhooks[0].ID)
+}
+
+func TestCheckWebhooksPaymentReceived(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	s := newTestServer(t)
+	defer s.Close()
+
+	addr := testutil.MakeAddress()
+	other := testutil.MakeAddress()
+
+	// Payments in blocks executed before the webhook was created are not reported
+	v.addBlock(makeTransaction(t, coin.TransactionOutput{Address: addr, Coins: 1e6, Hours: 1}))
+
+	w, err := d.CreateWebhook(Request{
+		URL:       s.URL,
+		Event:     EventPaymentReceived,
+		Addresses: []cipher.Address{addr},
+	})
+	require.NoError(t, err)
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	deliveries, err := d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	txn1 := makeTransaction(t,
+		coin.TransactionOutput{Address: addr, Coins: 2e6, Hours: 2},
+		coin.TransactionOutput{Address: other, Coins: 3e6, Hours: 3},
+		coin.TransactionOutput{Address: addr, Coins: 4e6, Hours: 4},
+	)
+	txn2 := makeTransaction(t, coin.TransactionOutput{Address: other, Coins: 5e6, Hours: 5})
+	b := v.addBlock(txn1, txn2)
+	v.addBlock()
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	// A block is only checked once
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	deliveries, err = d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, DeliveryStatusPending, deliveries[0].Status)
+	require.Empty(t, s.deliveries())
+
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+
+	received := s.deliveries()
+	require.Len(t, received, 1)
+
+	var data PaymentReceivedData
+	p := requireDelivery(t, w, received[0], &data)
+	require.Equal(t, deliveries[0].ID, p.ID)
+	require.Equal(t, PaymentReceivedData{
+		TxID:     txn1.Hash().Hex(),
+		BlockSeq: b.Head.BkSeq,
+		Outputs: []PaymentOutput{
+			{
+				UxID:    coin.CreateUnspents(b.Head, txn1)[0].Hash().Hex(),
+				Address: addr.String(),
+				Coins:   "2.000000",
+				Hours:   2,
+			},
+			{
+				UxID:    coin.CreateUnspents(b.Head, txn1)[2].Hash().Hex(),
+				Address: addr.String(),
+				Coins:   "4.000000",
+				Hours:   4,
+			},
+		},
+	}, data)
+
+	deliveries, err = d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, DeliveryStatusDelivered, deliveries[0].Status)
+	require.Len(t, deliveries[0].Attempts, 1)
+	require.Equal(t, http.StatusOK, deliveries[0].Attempts[0].StatusCode)
+
+	// Delivered deliveries are not sent again
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+	require.Len(t, s.deliveries(), 1)
+
+	// payment_received webhooks stay active
+	w2, err := d.GetWebhook(w.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusActive, w2.Status)
+
+	v.addBlock(makeTransaction(t, coin.TransactionOutput{Address: addr, Coins: 1e6, Hours: 1}))
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+	require.Len(t, s.deliveries(), 2)
+
+	// Deliveries are returned newest first
+	deliveries, err = d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.True(t, deliveries[0].Seq > deliveries[1].Seq)
+}
+
+func TestCheckWebhooksNoPayments(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	txn := makeTransaction(t, coin.TransactionOutput{Address: testutil.MakeAddress(), Coins: 1e6, Hours: 1})
+	_, err := d.CreateWebhook(Request{
+		URL:   "http://127.0.0.1/hook",
+		Event: EventTxnConfirmations,
+		TxID:  txn.Hash(),
+	})
+	require.NoError(t, err)
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		v.addBlock()
+	}
+
+	// Without payment_received webhooks, the blocks are skipped and the last checked block is the head block
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+	require.Equal(t, 0, v.blockRequests)
+
+	err = d.db.View("", func(tx *dbutil.Tx) error {
+		seq, ok, err := getLastBlockSeq(tx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, uint64(3), seq)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestCheckWebhooksPaymentsPerBlock(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	addr := testutil.MakeAddress()
+	w, err := d.CreateWebhook(Request{
+		URL:       "http://127.0.0.1/hook",
+		Event:     EventPaymentReceived,
+		Addresses: []cipher.Address{addr},
+	})
+	require.NoError(t, err)
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	v.addBlock(makeTransaction(t, coin.TransactionOutput{Address: addr, Coins: 1e6, Hours: 1}))
+	v.addBlock(makeTransaction(t, coin.TransactionOutput{Address: addr, Coins: 2e6, Hours: 2}))
+
+	// The deliveries of the blocks checked before a failure are stored
+	v.blockErrs = map[uint64]error{
+		2: errors.New("failed"),
+	}
+	err = d.CheckWebhooks()
+	require.EqualError(t, err, "failed")
+
+	deliveries, err := d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	lastBlockSeq := func() uint64 {
+		var seq uint64
+		err := d.db.View("", func(tx *dbutil.Tx) error {
+			var ok bool
+			var err error
+			seq, ok, err = getLastBlockSeq(tx)
+			require.True(t, ok)
+			return err
+		})
+		require.NoError(t, err)
+		return seq
+	}
+	require.Equal(t, uint64(1), lastBlockSeq())
+
+	// The next check resumes after the last checked block
+	v.blockErrs = nil
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	deliveries, err = d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.Equal(t, uint64(2), lastBlockSeq())
+}
+
+func TestCheckWebhooksTxnConfirmations(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	s := newTestServer(t)
+	defer s.Close()
+
+	txn := makeTransaction(t, coin.TransactionOutput{Address: testutil.MakeAddress(), Coins: 1e6, Hours: 1})
+
+	w, err := d.CreateWebhook(Request{
+		URL:           s.URL,
+		Event:         EventTxnConfirmations,
+		TxID:          txn.Hash(),
+		Confirmations: 2,
+	})
+	require.NoError(t, err)
+
+	check := func() []Delivery {
+		err := d.CheckWebhooks()
+		require.NoError(t, err)
+		deliveries, err := d.GetWebhookDeliveries(w.ID)
+		require.NoError(t, err)
+		return deliveries
+	}
+
+	// Unknown transaction
+	require.Empty(t, check())
+
+	// Unconfirmed transaction
+	v.unconfirmed[txn.Hash()] = txn
+	require.Empty(t, check())
+
+	// 1 confirmation
+	b := v.addBlock(txn)
+	require.Empty(t, check())
+
+	// 2 confirmations
+	v.addBlock()
+	require.Len(t, check(), 1)
+
+	w2, err := d.GetWebhook(w.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusCompleted, w2.Status)
+
+	// Completed webhooks are not triggered again
+	v.addBlock()
+	require.Len(t, check(), 1)
+
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+
+	received := s.deliveries()
+	require.Len(t, received, 1)
+
+	var data TxnConfirmationsData
+	requireDelivery(t, w, received[0], &data)
+	require.Equal(t, TxnConfirmationsData{
+		TxID:          txn.Hash().Hex(),
+		BlockSeq:      b.Head.BkSeq,
+		Confirmations: 2,
+	}, data)
+}
+
+func TestCheckWebhooksTxnDropped(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	s := newTestServer(t)
+	defer s.Close()
+
+	dropped := makeTransaction(t, coin.TransactionOutput{Address: testutil.MakeAddress(), Coins: 1e6, Hours: 1})
+	executed := makeTransaction(t, coin.TransactionOutput{Address: testutil.MakeAddress(), Coins: 1e6, Hours: 1})
+
+	wDropped, err := d.CreateWebhook(Request{
+		URL:   s.URL,
+		Event: EventTxnDropped,
+		TxID:  dropped.Hash(),
+	})
+	require.NoError(t, err)
+
+	wExecuted, err := d.CreateWebhook(Request{
+		URL:   s.URL,
+		Event: EventTxnDropped,
+		TxID:  executed.Hash(),
+	})
+	require.NoError(t, err)
+
+	check := func(w *Webhook) (*Webhook, []Delivery) {
+		err := d.CheckWebhooks()
+		require.NoError(t, err)
+		w, err = d.GetWebhook(w.ID)
+		require.NoError(t, err)
+		deliveries, err := d.GetWebhookDeliveries(w.ID)
+		require.NoError(t, err)
+		return w, deliveries
+	}
+
+	// A transaction that was never seen is not dropped
+	w, deliveries := check(wDropped)
+	require.False(t, w.SeenUnconfirmed)
+	require.Empty(t, deliveries)
+
+	v.unconfirmed[dropped.Hash()] = dropped
+	v.unconfirmed[executed.Hash()] = executed
+
+	w, deliveries = check(wDropped)
+	require.True(t, w.SeenUnconfirmed)
+	require.Equal(t, StatusActive, w.Status)
+	require.Empty(t, deliveries)
+
+	delete(v.unconfirmed, dropped.Hash())
+	v.addBlock(executed)
+
+	w, deliveries = check(wDropped)
+	require.Equal(t, StatusCompleted, w.Status)
+	require.Len(t, deliveries, 1)
+
+	// An executed transaction is not dropped
+	w, deliveries = check(wExecuted)
+	require.Equal(t, StatusCompleted, w.Status)
+	require.Empty(t, deliveries)
+
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+
+	received := s.deliveries()
+	require.Len(t, received, 1)
+
+	var data TxnDroppedData
+	requireDelivery(t, wDropped, received[0], &data)
+	require.Equal(t, TxnDroppedData{
+		TxID: dropped.Hash().Hex(),
+	}, data)
+}
+
+func TestSendDeliveriesRetry(t *testing.T) {
+	v := newFakeVisor()
+	d, now, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	s := newTestServer(t, http.StatusInternalServerError, http.StatusNotFound, http.StatusBadGateway, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	addr := testutil.MakeAddress()
+	w, err := d.CreateWebhook(Request{
+		URL:       s.URL,
+		Event:     EventPaymentReceived,
+		Addresses: []cipher.Address{addr},
+	})
+	require.NoError(t, err)
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	v.addBlock(makeTransaction(t, coin.TransactionOutput{Address: addr, Coins: 1e6, Hours: 1}))
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+	v.addBlock(makeTransaction(t, coin.TransactionOutput{Address: addr, Coins: 1e6, Hours: 1}))
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	send := func() []Delivery {
+		err := d.SendDeliveries()
+		require.NoError(t, err)
+		deliveries, err := d.GetWebhookDeliveries(w.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		// Oldest first
+		return []Delivery{deliveries[1], deliveries[0]}
+	}
+
+	start := *now
+
+	// Both deliveries fail
+	deliveries := send()
+	require.Len(t, s.deliveries(), 2)
+	for _, dv := range deliveries {
+		require.Equal(t, DeliveryStatusPending, dv.Status)
+		require.Len(t, dv.Attempts, 1)
+		require.NotEmpty(t, dv.Attempts[0].Error)
+		require.Equal(t, start.Add(time.Minute), dv.NextAttemptAt)
+	}
+	require.Equal(t, http.StatusInternalServerError, deliveries[0].Attempts[0].StatusCode)
+	require.Equal(t, http.StatusNotFound, deliveries[1].Attempts[0].StatusCode)
+
+	// Deliveries are not sent before their next attempt
+	*now = start.Add(time.Minute - time.Second)
+	send()
+	require.Len(t, s.deliveries(), 2)
+
+	// The second attempt fails, the retry delay doubles
+	*now = start.Add(time.Minute)
+	deliveries = send()
+	require.Len(t, s.deliveries(), 4)
+	for _, dv := range deliveries {
+		require.Equal(t, DeliveryStatusPending, dv.Status)
+		require.Len(t, dv.Attempts, 2)
+		require.Equal(t, now.Add(time.Minute*2), dv.NextAttemptAt)
+	}
+
+	// The third attempt succeeds
+	*now = now.Add(time.Minute * 2)
+	deliveries = send()
+	require.Len(t, s.deliveries(), 6)
+	for _, dv := range deliveries {
+		require.Equal(t, DeliveryStatusDelivered, dv.Status)
+		require.Len(t, dv.Attempts, 3)
+		require.Equal(t, http.StatusOK, dv.Attempts[2].StatusCode)
+		require.Empty(t, dv.Attempts[2].Error)
+	}
+
+	// The body and signature of a delivery do not change between attempts
+	received := s.deliveries()
+	require.Equal(t, received[0].body, received[2].body)
+	require.Equal(t, received[0].header.Get(SignatureHeader), received[2].header.Get(SignatureHeader))
+	require.Equal(t, received[0].body, received[4].body)
+}
+
+func TestSendDeliveriesFailed(t *testing.T) {
+	v := newFakeVisor()
+	d, now, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	// The server is closed before the deliveries are sent
+	s := newTestServer(t)
+	s.Close()
+
+	txn := makeTransaction(t, coin.TransactionOutput{Address: testutil.MakeAddress(), Coins: 1e6, Hours: 1})
+	v.addBlock(txn)
+
+	w, err := d.CreateWebhook(Request{
+		URL:   s.URL,
+		Event: EventTxnConfirmations,
+		TxID:  txn.Hash(),
+	})
+	require.NoError(t, err)
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	for i := 0; i < d.config.MaxAttempts; i++ {
+		err = d.SendDeliveries()
+		require.NoError(t, err)
+		*now = now.Add(d.config.MaxRetryInterval)
+	}
+
+	deliveries, err := d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, DeliveryStatusFailed, deliveries[0].Status)
+	require.Len(t, deliveries[0].Attempts, d.config.MaxAttempts)
+	for _, a := range deliveries[0].Attempts {
+		require.Equal(t, 0, a.StatusCode)
+		require.NotEmpty(t, a.Error)
+	}
+
+	// Failed deliveries are not sent again
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+
+	deliveries, err = d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries[0].Attempts, d.config.MaxAttempts)
+}
+
+func TestSendDeliveriesMaxDeliveries(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+	d.config.MaxDeliveries = 2
+
+	s := newTestServer(t)
+	defer s.Close()
+
+	addr := testutil.MakeAddress()
+	w, err := d.CreateWebhook(Request{
+		URL:       s.URL,
+		Event:     EventPaymentReceived,
+		Addresses: []cipher.Address{addr},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		v.addBlock(makeTransaction(t, coin.TransactionOutput{Address: addr, Coins: 1e6, Hours: 1}))
+	}
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	deliveries, err := d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 4)
+
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+	require.Len(t, s.deliveries(), 4)
+
+	// Only the newest finished deliveries are kept
+	kept, err := d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, kept, 2)
+	require.Equal(t, deliveries[0].ID, kept[0].ID)
+	require.Equal(t, deliveries[1].ID, kept[1].ID)
+	for _, dv := range kept {
+		require.Equal(t, DeliveryStatusDelivered, dv.Status)
+	}
+}
+
+func TestDeleteWebhookDeliveries(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	s := newTestServer(t)
+	defer s.Close()
+
+	txn := makeTransaction(t, coin.TransactionOutput{Address: testutil.MakeAddress(), Coins: 1e6, Hours: 1})
+	v.addBlock(txn)
+
+	w, err := d.CreateWebhook(Request{
+		URL:   s.URL,
+		Event: EventTxnConfirmations,
+		TxID:  txn.Hash(),
+	})
+	require.NoError(t, err)
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+
+	err = d.DeleteWebhook(w.ID)
+	require.NoError(t, err)
+
+	// The pending deliveries of a deleted webhook are not sent
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+	require.Empty(t, s.deliveries())
+}
+
+func TestPrivateURLs(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcherAllowPrivateURLs(t, v, false)
+	defer shutdown()
+
+	txn := makeTransaction(t, coin.TransactionOutput{Address: testutil.MakeAddress(), Coins: 1e6, Hours: 1})
+	v.addBlock(txn)
+
+	for _, u := range []string{
+		"http://127.0.0.1/hook",
+		"http://[::1]:8080/hook",
+		"http://10.1.2.3/hook",
+		"https://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/hook",
+	} {
+		_, err := d.CreateWebhook(Request{
+			URL:   u,
+			Event: EventTxnConfirmations,
+			TxID:  txn.Hash(),
+		})
+		require.Equal(t, ErrPrivateURL, err, u)
+	}
+
+	// Host names are checked once they are resolved, when the deliveries are sent
+	s := newTestServer(t)
+	defer s.Close()
+
+	_, port, err := net.SplitHostPort(s.Listener.Addr().String())
+	require.NoError(t, err)
+
+	w, err := d.CreateWebhook(Request{
+		URL:   "http://localhost:" + port + "/hook",
+		Event: EventTxnConfirmations,
+		TxID:  txn.Hash(),
+	})
+	require.NoError(t, err)
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+
+	require.Empty(t, s.deliveries())
+
+	deliveries, err := d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Len(t, deliveries[0].Attempts, 1)
+	require.Contains(t, deliveries[0].Attempts[0].Error, ErrPrivateURL.Error())
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	for _, ip := range []string{
+		"127.0.0.1", "::1", "10.0.0.1", "172.16.0.1", "172.31.255.255", "192.168.0.1", "169.254.1.1", "fe80::1", "fd00::1",
+		"0.0.0.0", "::", "0.1.2.3", "100.64.0.1", "100.127.255.255", "198.18.0.1", "198.19.255.255", "224.0.0.1",
+		"239.255.255.250", "255.255.255.255", "ff02::1", "ff0e::1",
+		"::ffff:127.0.0.1", "::ffff:10.0.0.1", "::ffff:169.254.169.254", "::ffff:100.64.0.1", "::ffff:224.0.0.1",
+	} {
+		require.True(t, isPrivateIP(net.ParseIP(ip)), ip)
+	}
+
+	for _, ip := range []string{"1.1.1.1", "172.32.0.1", "8.8.8.8", "100.128.0.1", "198.20.0.1", "::ffff:8.8.8.8", "2001:4860:4860::8888"} {
+		require.False(t, isPrivateIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestSendDeliveriesRedirect(t *testing.T) {
+	v := newFakeVisor()
+	d, _, shutdown := newTestDispatcher(t, v)
+	defer shutdown()
+
+	var redirected bool
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hook" {
+			http.Redirect(w, r, "/other", http.StatusFound)
+			return
+		}
+		redirected = true
+	}))
+	defer s.Close()
+
+	txn := makeTransaction(t, coin.TransactionOutput{Address: testutil.MakeAddress(), Coins: 1e6, Hours: 1})
+	v.addBlock(txn)
+
+	w, err := d.CreateWebhook(Request{
+		URL:   s.URL + "/hook",
+		Event: EventTxnConfirmations,
+		TxID:  txn.Hash(),
+	})
+	require.NoError(t, err)
+
+	err = d.CheckWebhooks()
+	require.NoError(t, err)
+	err = d.SendDeliveries()
+	require.NoError(t, err)
+
+	// Redirects are not followed, and fail the attempt
+	require.False(t, redirected)
+
+	deliveries, err := d.GetWebhookDeliveries(w.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, DeliveryStatusPending, deliveries[0].Status)
+	require.Len(t, deliveries[0].Attempts, 1)
+	require.Equal(t, http.StatusFound, deliveries[0].Attempts[0].StatusCode)
+	require.NotEmpty(t, deliveries[0].Attempts[0].Error)
+}
+
+func TestRetryDelay(t *testing.T) {
+	d := &Dispatcher{
+		config: Config{
+			RetryInterval:    time.Second * 10,
+			MaxRetryInterval: time.Minute,
+		},
+	}
+
+	require.Equal(t, time.Second*10, d.retryDelay(1))
+	require.Equal(t, time.Second*20, d.retryDelay(2))
+	require.Equal(t, time.Second*40, d.retryDelay(3))
+	require.Equal(t, time.Minute, d.retryDelay(4))
+	require.Equal(t, time.Minute, d.retryDelay(100))
+}
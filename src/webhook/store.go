@@ -0,0 +1,269 @@
+package webhook
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/skycoin/skycoin/src/visor/dbutil"
+)
+
+var (
+	// WebhooksBkt stores webhooks, keyed by ID, JSON encoded
+	WebhooksBkt = []byte("webhooks")
+	// DeliveriesBkt stores deliveries, keyed by webhook ID followed by the 8 byte big endian
+	// delivery sequence, JSON encoded. The deliveries of a webhook are scanned by prefix.
+	DeliveriesBkt = []byte("webhook_deliveries")
+	// PendingDeliveriesBkt indexes the keys of the pending deliveries in DeliveriesBkt
+	PendingDeliveriesBkt = []byte("webhook_pending_deliveries")
+	// MetaBkt stores the block sequence up to which payments were checked
+	MetaBkt = []byte("webhook_meta")
+
+	lastBlockSeqKey = []byte("last_block_seq")
+)
+
+// deliveryKey returns the key of a delivery in DeliveriesBkt
+func deliveryKey(webhookID string, seq uint64) []byte {
+	return append([]byte(webhookID), dbutil.Itob(seq)...)
+}
+
+// createBuckets creates the buckets of the package if they do not exist
+func createBuckets(tx *dbutil.Tx) error {
+	for _, bkt := range [][]byte{WebhooksBkt, DeliveriesBkt, PendingDeliveriesBkt, MetaBkt} {
+		if _, err := tx.CreateBucketIfNotExists(bkt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getWebhook returns the webhook with the given ID, or nil if it does not exist
+func getWebhook(tx *dbutil.Tx, id string) (*Webhook, error) {
+	var w Webhook
+	ok, err := dbutil.GetBucketObjectJSON(tx, WebhooksBkt, []byte(id), &w)
+	if err != nil {
+		switch err.(type) {
+		case dbutil.ErrBucketNotExist:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &w, nil
+}
+
+// putWebhook saves a webhook
+func putWebhook(tx *dbutil.Tx, w *Webhook) error {
+	if err := createBuckets(tx); err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.PutBucketValue(tx, WebhooksBkt, []byte(w.ID), v)
+}
+
+// getWebhooks returns all webhooks that satisfy the filter, oldest first
+func getWebhooks(tx *dbutil.Tx, filter func(*Webhook) bool) ([]Webhook, error) {
+	var webhooks []Webhook
+	if err := dbutil.ForEach(tx, WebhooksBkt, func(_, v []byte) error {
+		var w Webhook
+		if err := json.Unmarshal(v, &w); err != nil {
+			return err
+		}
+
+		if filter == nil || filter(&w) {
+			webhooks = append(webhooks, w)
+		}
+
+		return nil
+	}); err != nil {
+		switch err.(type) {
+		case dbutil.ErrBucketNotExist:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+
+	return webhooks, nil
+}
+
+// deleteWebhook deletes a webhook and its deliveries
+func deleteWebhook(tx *dbutil.Tx, id string) error {
+	if err := createBuckets(tx); err != nil {
+		return err
+	}
+
+	var keys [][]byte
+	if err := dbutil.ScanPrefix(tx, DeliveriesBkt, []byte(id), nil, false, func(k, _ []byte) (bool, error) {
+		keys = append(keys, append([]byte{}, k...))
+		return true, nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := dbutil.Delete(tx, DeliveriesBkt, k); err != nil {
+			return err
+		}
+		if err := dbutil.Delete(tx, PendingDeliveriesBkt, k); err != nil {
+			return err
+		}
+	}
+
+	return dbutil.Delete(tx, WebhooksBkt, []byte(id))
+}
+
+// addDelivery saves a new pending delivery, assigning its sequence
+func addDelivery(tx *dbutil.Tx, d *Delivery) error {
+	if err := createBuckets(tx); err != nil {
+		return err
+	}
+
+	seq, err := dbutil.NextSequence(tx, DeliveriesBkt)
+	if err != nil {
+		return err
+	}
+	d.Seq = seq
+
+	return putDelivery(tx, d)
+}
+
+// putDelivery saves a delivery. Pending deliveries are added to PendingDeliveriesBkt,
+// other deliveries are removed from it.
+func putDelivery(tx *dbutil.Tx, d *Delivery) error {
+	v, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	k := deliveryKey(d.WebhookID, d.Seq)
+	if err := dbutil.PutBucketValue(tx, DeliveriesBkt, k, v); err != nil {
+		return err
+	}
+
+	if d.Status == DeliveryStatusPending {
+		return dbutil.PutBucketValue(tx, PendingDeliveriesBkt, k, []byte{})
+	}
+	return dbutil.Delete(tx, PendingDeliveriesBkt, k)
+}
+
+// getDeliveries returns the deliveries of a webhook, newest first
+func getDeliveries(tx *dbutil.Tx, webhookID string) ([]Delivery, error) {
+	var deliveries []Delivery
+	if err := dbutil.ScanPrefix(tx, DeliveriesBkt, []byte(webhookID), nil, true, func(_, v []byte) (bool, error) {
+		var d Delivery
+		if err := json.Unmarshal(v, &d); err != nil {
+			return false, err
+		}
+		deliveries = append(deliveries, d)
+		return true, nil
+	}); err != nil {
+		switch err.(type) {
+		case dbutil.ErrBucketNotExist:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+
+	return deliveries, nil
+}
+
+// pruneDeliveries deletes the delivered and failed deliveries of a webhook, except the newest max ones
+func pruneDeliveries(tx *dbutil.Tx, webhookID string, max int) error {
+	var keys [][]byte
+	var finished int
+	if err := dbutil.ScanPrefix(tx, DeliveriesBkt, []byte(webhookID), nil, true, func(k, v []byte) (bool, error) {
+		var d Delivery
+		if err := json.Unmarshal(v, &d); err != nil {
+			return false, err
+		}
+
+		if d.Status == DeliveryStatusPending {
+			return true, nil
+		}
+
+		finished++
+		if finished > max {
+			keys = append(keys, append([]byte{}, k...))
+		}
+
+		return true, nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := dbutil.Delete(tx, DeliveriesBkt, k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getPendingDeliveries returns the pending deliveries, in the order they were created for each webhook
+func getPendingDeliveries(tx *dbutil.Tx) ([]Delivery, error) {
+	var deliveries []Delivery
+	if err := dbutil.ForEach(tx, PendingDeliveriesBkt, func(k, _ []byte) error {
+		var d Delivery
+		ok, err := dbutil.GetBucketObjectJSON(tx, DeliveriesBkt, k, &d)
+		if err != nil {
+			return err
+		}
+		if ok {
+			deliveries = append(deliveries, d)
+		}
+		return nil
+	}); err != nil {
+		switch err.(type) {
+		case dbutil.ErrBucketNotExist:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+
+	return deliveries, nil
+}
+
+// getLastBlockSeq returns the block sequence up to which payments were checked
+func getLastBlockSeq(tx *dbutil.Tx) (uint64, bool, error) {
+	v, err := dbutil.GetBucketValueNoCopy(tx, MetaBkt, lastBlockSeqKey)
+	if err != nil {
+		switch err.(type) {
+		case dbutil.ErrBucketNotExist:
+			return 0, false, nil
+		default:
+			return 0, false, err
+		}
+	}
+
+	if v == nil {
+		return 0, false, nil
+	}
+
+	return dbutil.Btoi(v), true, nil
+}
+
+// setLastBlockSeq saves the block sequence up to which payments were checked
+func setLastBlockSeq(tx *dbutil.Tx, seq uint64) error {
+	if err := createBuckets(tx); err != nil {
+		return err
+	}
+
+	return dbutil.PutBucketValue(tx, MetaBkt, lastBlockSeqKey, dbutil.Itob(seq))
+}
@@ -0,0 +1,313 @@
+/*
+Package webhook implements HTTP callbacks for blockchain events.
+
+A webhook registers a URL to be notified of an event: a payment received by a watched
+address, a transaction reaching a number of confirmations, or a transaction being dropped
+from the unconfirmed transaction pool.
+
+Webhooks are persisted in the node database and checked periodically. Each event is stored
+as a delivery before it is sent, and failed deliveries are retried with exponential backoff.
+The body of a delivery is signed with the secret of its webhook, using HMAC-SHA256, so that
+the receiver can verify it was sent by the node.
+*/
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/util/logging"
+)
+
+var (
+	logger = logging.MustGetLogger("webhook")
+)
+
+// Error wraps webhook errors caused by user input
+type Error struct {
+	error
+}
+
+// NewError creates an Error
+func NewError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return Error{err}
+}
+
+var (
+	// ErrInvalidURL is returned if the URL of a webhook request is not an absolute http or https URL
+	ErrInvalidURL = NewError(errors.New("url must be an absolute http or https URL"))
+	// ErrInvalidEvent is returned if the event of a webhook request is unknown
+	ErrInvalidEvent = NewError(errors.New("invalid event"))
+	// ErrMissingAddresses is returned if a payment_received webhook request has no addresses
+	ErrMissingAddresses = NewError(errors.New("addresses are required"))
+	// ErrTooManyAddresses is returned if a webhook request has too many addresses
+	ErrTooManyAddresses = NewError(fmt.Errorf("at most %d addresses can be watched by a webhook", MaxAddresses))
+	// ErrMissingTxID is returned if a transaction webhook request has no txid
+	ErrMissingTxID = NewError(errors.New("txid is required"))
+	// ErrUnexpectedAddresses is returned if a transaction webhook request has addresses
+	ErrUnexpectedAddresses = NewError(errors.New("addresses are only allowed for the payment_received event"))
+	// ErrUnexpectedTxID is returned if a payment_received webhook request has a txid
+	ErrUnexpectedTxID = NewError(errors.New("txid is not allowed for the payment_received event"))
+	// ErrUnexpectedConfirmations is returned if a webhook request has confirmations for an event other than txn_confirmations
+	ErrUnexpectedConfirmations = NewError(errors.New("confirmations are only allowed for the txn_confirmations event"))
+	// ErrWebhookNotFound is returned if a webhook does not exist
+	ErrWebhookNotFound = NewError(errors.New("webhook not found"))
+	// ErrPrivateURL is returned if the URL of a webhook is a loopback, link-local or private address, unless they are allowed
+	ErrPrivateURL = NewError(errors.New("url must not be a loopback, link-local or private address"))
+)
+
+// MaxAddresses is the maximum number of addresses watched by a webhook
+const MaxAddresses = 1000
+
+// EventType is the type of event that triggers a webhook
+type EventType string
+
+const (
+	// EventPaymentReceived a transaction with outputs to a watched address was executed
+	EventPaymentReceived EventType = "payment_received"
+	// EventTxnConfirmations a transaction reached the required number of confirmations
+	EventTxnConfirmations EventType = "txn_confirmations"
+	// EventTxnDropped a transaction was removed from the unconfirmed transaction pool without being executed
+	EventTxnDropped EventType = "txn_dropped"
+)
+
+// Status is the status of a webhook
+type Status string
+
+const (
+	// StatusActive the webhook is waiting for its event
+	StatusActive Status = "active"
+	// StatusCompleted the event of a transaction webhook was triggered, the webhook will not be triggered again.
+	// payment_received webhooks are never completed.
+	StatusCompleted Status = "completed"
+)
+
+// Request is a request to register a webhook
+type Request struct {
+	// URL is notified of the events with a POST request
+	URL   string
+	Event EventType
+	// Secret signs the deliveries. If empty, a random secret is generated.
+	Secret string
+	// Addresses are the addresses watched by a payment_received webhook
+	Addresses []cipher.Address
+	// TxID is the transaction watched by a txn_confirmations or txn_dropped webhook
+	TxID cipher.SHA256
+	// Confirmations is the number of confirmations of a txn_confirmations webhook. Defaults to 1.
+	Confirmations uint64
+}
+
+// Validate validates the Request
+func (r Request) Validate() error {
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+
+	switch r.Event {
+	case EventPaymentReceived:
+		if len(r.Addresses) == 0 {
+			return ErrMissingAddresses
+		}
+		if len(r.Addresses) > MaxAddresses {
+			return ErrTooManyAddresses
+		}
+		if !r.TxID.Null() {
+			return ErrUnexpectedTxID
+		}
+		if r.Confirmations != 0 {
+			return ErrUnexpectedConfirmations
+		}
+	case EventTxnConfirmations, EventTxnDropped:
+		if r.TxID.Null() {
+			return ErrMissingTxID
+		}
+		if len(r.Addresses) != 0 {
+			return ErrUnexpectedAddresses
+		}
+		if r.Event == EventTxnDropped && r.Confirmations != 0 {
+			return ErrUnexpectedConfirmations
+		}
+	default:
+		return ErrInvalidEvent
+	}
+
+	return nil
+}
+
+// host returns the host of the URL, without the port
+func (r Request) host() string {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// Webhook is a registered webhook
+type Webhook struct {
+	ID     string    `json:"id"`
+	URL    string    `json:"url"`
+	Secret string    `json:"secret"`
+	Event  EventType `json:"event"`
+	Status Status    `json:"status"`
+	// Addresses are the base58 encoded addresses watched by a payment_received webhook
+	Addresses []string `json:"addresses,omitempty"`
+	// TxID is the hex encoded transaction watched by a txn_confirmations or txn_dropped webhook
+	TxID          string `json:"txid,omitempty"`
+	Confirmations uint64 `json:"confirmations,omitempty"`
+	// BlockSeq is the head block sequence when the webhook was created.
+	// Payments in this block or earlier blocks do not trigger the webhook.
+	BlockSeq uint64 `json:"block_seq"`
+	// SeenUnconfirmed is set when the transaction of a txn_dropped webhook is found in the unconfirmed transaction pool
+	SeenUnconfirmed bool      `json:"seen_unconfirmed,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// newWebhook creates a Webhook from a Request
+func newWebhook(r Request, headSeq uint64, now time.Time) *Webhook {
+	secret := r.Secret
+	if secret == "" {
+		secret = randomHex(32)
+	}
+
+	w := &Webhook{
+		ID:        randomHex(16),
+		URL:       r.URL,
+		Secret:    secret,
+		Event:     r.Event,
+		Status:    StatusActive,
+		BlockSeq:  headSeq,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	switch r.Event {
+	case EventPaymentReceived:
+		w.Addresses = make([]string, len(r.Addresses))
+		for i, a := range r.Addresses {
+			w.Addresses[i] = a.String()
+		}
+	case EventTxnConfirmations:
+		w.TxID = r.TxID.Hex()
+		w.Confirmations = r.Confirmations
+		if w.Confirmations == 0 {
+			w.Confirmations = 1
+		}
+	case EventTxnDropped:
+		w.TxID = r.TxID.Hex()
+	}
+
+	return w
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) string {
+	return hex.EncodeToString(cipher.RandByte(n))
+}
+
+// Sign returns the signature of a delivery body, sent in the SignatureHeader header.
+// The signature is the hex encoded HMAC-SHA256 of the body keyed by the webhook secret,
+// prefixed with "sha256=".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) //nolint:errcheck
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if signature is the signature of the body for the secret
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+const (
+	// EventHeader is the HTTP header with the event type of a delivery
+	EventHeader = "X-Skycoin-Event"
+	// DeliveryHeader is the HTTP header with the ID of a delivery
+	DeliveryHeader = "X-Skycoin-Delivery"
+	// SignatureHeader is the HTTP header with the signature of a delivery body
+	SignatureHeader = "X-Skycoin-Signature"
+)
+
+// DeliveryStatus is the status of a delivery
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending the delivery has not succeeded yet and will be attempted again
+	DeliveryStatusPending DeliveryStatus = "pending"
+	// DeliveryStatusDelivered the URL responded with a 2xx status code
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	// DeliveryStatusFailed all attempts of the delivery failed
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// Attempt is an attempt to send a delivery
+type Attempt struct {
+	Time time.Time `json:"time"`
+	// StatusCode is the HTTP status code of the response, 0 if there was no response
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Delivery is the notification of an event to a webhook
+type Delivery struct {
+	ID        string         `json:"id"`
+	WebhookID string         `json:"webhook_id"`
+	Seq       uint64         `json:"seq"`
+	Event     EventType      `json:"event"`
+	Status    DeliveryStatus `json:"status"`
+	// Body is the JSON body that is sent. It does not change between attempts.
+	Body          json.RawMessage `json:"body"`
+	Attempts      []Attempt       `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// Payload is the JSON body of a delivery
+type Payload struct {
+	ID        string      `json:"id"`
+	WebhookID string      `json:"webhook_id"`
+	Event     EventType   `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// PaymentOutput is an output received by a watched address
+type PaymentOutput struct {
+	UxID    string `json:"uxid"`
+	Address string `json:"address"`
+	Coins   string `json:"coins"`
+	Hours   uint64 `json:"hours"`
+}
+
+// PaymentReceivedData is the data of a payment_received event.
+// One event is sent for each transaction with outputs to the watched addresses.
+type PaymentReceivedData struct {
+	TxID     string          `json:"txid"`
+	BlockSeq uint64          `json:"block_seq"`
+	Outputs  []PaymentOutput `json:"outputs"`
+}
+
+// TxnConfirmationsData is the data of a txn_confirmations event
+type TxnConfirmationsData struct {
+	TxID          string `json:"txid"`
+	BlockSeq      uint64 `json:"block_seq"`
+	Confirmations uint64 `json:"confirmations"`
+}
+
+// TxnDroppedData is the data of a txn_dropped event
+type TxnDroppedData struct {
+	TxID string `json:"txid"`
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skycoin/skycoin/src/cipher"
+	"github.com/skycoin/skycoin/src/testutil"
+)
+
+func TestRequestValidate(t *testing.T) {
+	addrs := []cipher.Address{testutil.MakeAddress()}
+	txid := testutil.RandSHA256(t)
+
+	cases := []struct {
+		name string
+		r    Request
+		err  error
+	}{
+		{
+			name: "payment_received",
+			r: Request{
+				URL:       "http://127.0.0.1:8080/hook",
+				Event:     EventPaymentReceived,
+				Addresses: addrs,
+			},
+		},
+		{
+			name: "txn_confirmations",
+			r: Request{
+				URL:           "https://example.com/hook",
+				Event:         EventTxnConfirmations,
+				TxID:          txid,
+				Confirmations: 3,
+			},
+		},
+		{
+			name: "txn_dropped",
+			r: Request{
+				URL:   "https://example.com/hook",
+				Event: EventTxnDropped,
+				TxID:  txid,
+			},
+		},
+		{
+			name: "relative url",
+			r: Request{
+				URL:       "/hook",
+				Event:     EventPaymentReceived,
+				Addresses: addrs,
+			},
+			err: ErrInvalidURL,
+		},
+		{
+			name: "unsupported scheme",
+			r: Request{
+				URL:       "ftp://example.com/hook",
+				Event:     EventPaymentReceived,
+				Addresses: addrs,
+			},
+			err: ErrInvalidURL,
+		},
+		{
+			name: "invalid event",
+			r: Request{
+				URL:   "https://example.com/hook",
+				Event: "foo",
+			},
+			err: ErrInvalidEvent,
+		},
+		{
+			name: "missing addresses",
+			r: Request{
+				URL:   "https://example.com/hook",
+				Event: EventPaymentReceived,
+			},
+			err: ErrMissingAddresses,
+		},
+		{
+			name: "too many addresses",
+			r: Request{
+				URL:       "https://example.com/hook",
+				Event:     EventPaymentReceived,
+				Addresses: make([]cipher.Address, MaxAddresses+1),
+			},
+			err: ErrTooManyAddresses,
+		},
+		{
+			name: "payment_received with txid",
+			r: Request{
+				URL:       "https://example.com/hook",
+				Event:     EventPaymentReceived,
+				Addresses: addrs,
+				TxID:      txid,
+			},
+			err: ErrUnexpectedTxID,
+		},
+		{
+			name: "payment_received with confirmations",
+			r: Request{
+				URL:           "https://example.com/hook",
+				Event:         EventPaymentReceived,
+				Addresses:     addrs,
+				Confirmations: 1,
+			},
+			err: ErrUnexpectedConfirmations,
+		},
+		{
+			name: "missing txid",
+			r: Request{
+				URL:   "https://example.com/hook",
+				Event: EventTxnConfirmations,
+			},
+			err: ErrMissingTxID,
+		},
+		{
+			name: "txn_dropped with addresses",
+			r: Request{
+				URL:       "https://example.com/hook",
+				Event:     EventTxnDropped,
+				TxID:      txid,
+				Addresses: addrs,
+			},
+			err: ErrUnexpectedAddresses,
+		},
+		{
+			name: "txn_dropped with confirmations",
+			r: Request{
+				URL:           "https://example.com/hook",
+				Event:         EventTxnDropped,
+				TxID:          txid,
+				Confirmations: 1,
+			},
+			err: ErrUnexpectedConfirmations,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, tc.r.Validate())
+		})
+	}
+}
+
+func TestNewWebhook(t *testing.T) {
+	now := time.Now().UTC()
+	txid := testutil.RandSHA256(t)
+
+	w := newWebhook(Request{
+		URL:   "https://example.com/hook",
+		Event: EventTxnConfirmations,
+		TxID:  txid,
+	}, 10, now)
+	require.Len(t, w.ID, 32)
+	require.Len(t, w.Secret, 64)
+	require.Equal(t, StatusActive, w.Status)
+	require.Equal(t, txid.Hex(), w.TxID)
+	require.Equal(t, uint64(1), w.Confirmations)
+	require.Equal(t, uint64(10), w.BlockSeq)
+
+	w2 := newWebhook(Request{
+		URL:    "https://example.com/hook",
+		Event:  EventTxnConfirmations,
+		TxID:   txid,
+		Secret: "foo",
+	}, 10, now)
+	require.NotEqual(t, w.ID, w2.ID)
+	require.Equal(t, "foo", w2.Secret)
+}
+
+func TestSign(t *testing.T) {
+	// Test vector from RFC 4231, test case 2
+	sig := Sign("Jefe", []byte("what do ya want for nothing?"))
+	require.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", sig)
+
+	require.True(t, Verify("Jefe", []byte("what do ya want for nothing?"), sig))
+	require.False(t, Verify("jefe", []byte("what do ya want for nothing?"), sig))
+	require.False(t, Verify("Jefe", []byte("what do ya want for nothing"), sig))
+	require.False(t, Verify("Jefe", []byte("what do ya want for nothing?"), sig[len("sha256="):]))
+}